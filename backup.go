@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/auth"
+	"github.com/dang-w/momentum-mcp-server/internal/backup"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/config"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// openBackupStore opens the backup location configured by
+// BACKUP_DESTINATION.
+func openBackupStore(cfg *config.Config) (*backup.Store, error) {
+	var s storage.Storage
+	switch {
+	case cfg.BackupDir != "":
+		s = backup.NewDir(cfg.BackupDir)
+	case cfg.BackupGitHubRepo != "":
+		gh, err := storage.NewGitHubStorage(cfg.BackupGitHubToken, cfg.BackupGitHubRepo)
+		if err != nil {
+			return nil, fmt.Errorf("opening the backup repo: %w", err)
+		}
+		s = gh
+	case cfg.BackupObject.Bucket != "":
+		bucket, err := storage.NewObjectStorage(cfg.BackupObject)
+		if err != nil {
+			return nil, fmt.Errorf("opening the backup bucket: %w", err)
+		}
+		s = bucket
+	default:
+		return nil, errors.New("BACKUP_DESTINATION is not set")
+	}
+	return backup.NewStore(s, cfg.BackupKeep), nil
+}
+
+// openDataRepo opens the configured data repo or bucket directly, by data
+// file name, for the backup commands.
+func openDataRepo(cfg *config.Config) (storage.Storage, error) {
+	switch cfg.StorageBackend {
+	case config.StorageGitHub:
+		gh, err := storage.NewGitHubStorage(cfg.GitHubToken, cfg.GitHubRepo)
+		if err != nil {
+			return nil, fmt.Errorf("creating storage: %w", err)
+		}
+		if cfg.CommitAuthorName != "" {
+			gh.SetCommitter(cfg.CommitAuthorName, cfg.CommitAuthorEmail)
+		}
+		return storage.WithPaths(gh, cfg.DataPaths), nil
+	case config.StorageS3, config.StorageGCS:
+		bucket, err := storage.NewObjectStorage(cfg.Object)
+		if err != nil {
+			return nil, fmt.Errorf("creating storage: %w", err)
+		}
+		return storage.WithPaths(bucket, cfg.DataPaths), nil
+	}
+	return nil, fmt.Errorf("backups aren't supported with STORAGE_BACKEND=%s", cfg.StorageBackend)
+}
+
+// oauthStatePath is the OAuth state file, or "" without a data directory.
+func oauthStatePath(cfg *config.Config) string {
+	if cfg.DataDir == "" {
+		return ""
+	}
+	return filepath.Join(cfg.DataDir, auth.StateFileName)
+}
+
+// eventLogPath is the event log to back up, or "" outside events mode.
+func eventLogPath(cfg *config.Config) string {
+	if cfg.StorageMode != "events" {
+		return ""
+	}
+	return cfg.EventLogPath
+}
+
+// runBackup is the backup subcommand: it takes a backup now.
+func runBackup(args []string) int {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: momentum-mcp-server backup")
+		fmt.Fprintln(os.Stderr, "\nBacks up the data files, and the OAuth state if encrypted, to BACKUP_DESTINATION.")
+	}
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, store, data, err := loadBackupConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	job := backup.NewJob(data, eventLogPath(cfg), oauthStatePath(cfg), store, nil, clock.InLocation(clock.Real{}, cfg.Location))
+	entry, stored, err := job.Run(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "backup failed:", err)
+		return 1
+	}
+	if !stored {
+		fmt.Printf("nothing changed since %s\n", entry.Name)
+		return 0
+	}
+	fmt.Printf("stored %s (%d files, %d bytes)\n", entry.Name, entry.Files, entry.Bytes)
+	return 0
+}
+
+// runRestoreBackup is the restore-backup subcommand. It lists the backups,
+// or writes one back to the data repo in a single commit.
+func runRestoreBackup(args []string) int {
+	fs := flag.NewFlagSet("restore-backup", flag.ContinueOnError)
+	list := fs.Bool("list", false, "list the stored backups")
+	dryRun := fs.Bool("dry-run", false, "show which files would be restored without writing them")
+	oauth := fs.Bool("oauth", false, "also restore the OAuth state file (stop the server first)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: momentum-mcp-server restore-backup -list")
+		fmt.Fprintln(os.Stderr, "       momentum-mcp-server restore-backup [-dry-run] [-oauth] <name or latest>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *list != (fs.NArg() == 0) || fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, store, data, err := loadBackupConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	if *list {
+		entries, err := store.List(ctx)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if len(entries) == 0 {
+			fmt.Println("no backups")
+		}
+		for _, e := range entries {
+			oauthNote := ""
+			if e.OAuth {
+				oauthNote = " + OAuth state"
+			}
+			fmt.Printf("%s  %s  %d files%s, %d bytes\n", e.Name, e.CreatedAt.Format(time.RFC3339), e.Files, oauthNote, e.Bytes)
+		}
+		return 0
+	}
+
+	entry, snap, err := store.Get(ctx, fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "backup %s: %v\n", fs.Arg(0), err)
+		return 1
+	}
+	changed, err := backup.Restore(ctx, data, snap, "Restore backup "+entry.Name, *dryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "restore failed:", err)
+		return 1
+	}
+	verb := "restored"
+	if *dryRun {
+		verb = "would restore"
+	}
+	if len(changed) == 0 {
+		fmt.Printf("data files already match %s\n", entry.Name)
+	} else {
+		fmt.Printf("%s %d files from %s: %s\n", verb, len(changed), entry.Name, strings.Join(changed, ", "))
+	}
+
+	if *oauth {
+		path := oauthStatePath(cfg)
+		if path == "" {
+			fmt.Fprintln(os.Stderr, "DATA_DIR is required to restore the OAuth state")
+			return 1
+		}
+		if !*dryRun {
+			if err := backup.RestoreOAuthState(snap, path); err != nil {
+				fmt.Fprintln(os.Stderr, "restoring OAuth state failed:", err)
+				return 1
+			}
+		}
+		fmt.Printf("%s OAuth state to %s\n", verb, path)
+	}
+	return 0
+}
+
+// loadBackupConfig loads the config and opens the backup location and the
+// data repo.
+func loadBackupConfig() (*config.Config, *backup.Store, storage.Storage, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("loading config: %w", err)
+	}
+	store, err := openBackupStore(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	data, err := openDataRepo(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return cfg, store, data, nil
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/config"
+	"github.com/dang-w/momentum-mcp-server/internal/version"
+	"github.com/dang-w/momentum-mcp-server/server"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/dang-w/momentum-mcp-server/tools"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// cliCommands are the subcommands handled by runCLI.
+var cliCommands = map[string]bool{"todo": true, "list": true, "remind": true, "read": true}
+
+const cliUsage = `usage: momentum-mcp-server <command> [flags] [args]
+
+  todo add [-priority urgent|high|normal|someday] [-project name] [-force] <text>
+  todo done <id or text>
+  remind <YYYY-MM-DD> <text>
+  read [-notes text] <url>       add a URL to the reading list
+  list [todos|reminders|reading] [-all]
+
+With MOMENTUM_URL set (e.g. https://momentum.example.com), commands go to
+that server's /mcp endpoint, authenticated with MOMENTUM_TOKEN. Otherwise
+they run locally against the data repo configured by the usual environment
+(GITHUB_TOKEN, GITHUB_REPO, ...).`
+
+// toolSession calls tools; *mcp.ClientSession implements it.
+type toolSession interface {
+	CallTool(ctx context.Context, params *mcp.CallToolParams) (*mcp.CallToolResult, error)
+}
+
+// runCLI runs a capture subcommand (todo, remind, read, list).
+func runCLI(args []string) int {
+	name, call, err := parseCLI(args)
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		fmt.Fprintln(os.Stderr, cliUsage)
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	session, err := connectCLI(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer session.Close()
+
+	out, err := runTool(ctx, session, name, call)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Println(out)
+	return 0
+}
+
+// cliCall is a parsed subcommand: the tool to call, its arguments, and how
+// to print a successful result.
+type cliCall struct {
+	tool   string
+	args   map[string]any
+	format func(message, text string) string
+}
+
+// parseCLI parses a subcommand's arguments into a tool call.
+func parseCLI(args []string) (string, cliCall, error) {
+	if len(args) == 0 {
+		return "", cliCall{}, flag.ErrHelp
+	}
+	name := args[0]
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	switch name {
+	case "todo":
+		if len(args) < 2 {
+			return name, cliCall{}, errors.New("todo: expected add or done")
+		}
+		switch args[1] {
+		case "add":
+			priority := fs.String("priority", "", "urgent, high, normal, or someday")
+			project := fs.String("project", "", "project the todo belongs to")
+			force := fs.Bool("force", false, "add even if it looks like a duplicate")
+			if err := fs.Parse(args[2:]); err != nil {
+				return name, cliCall{}, fmt.Errorf("todo add: %w", err)
+			}
+			text := strings.Join(fs.Args(), " ")
+			if text == "" {
+				return name, cliCall{}, errors.New("todo add: missing text")
+			}
+			return name, cliCall{tool: "add_todo", args: withoutEmpty(map[string]any{
+				"text": text, "priority": *priority, "project": *project, "force": *force,
+			}), format: formatAddedTodo}, nil
+		case "done":
+			ref := strings.Join(args[2:], " ")
+			if ref == "" {
+				return name, cliCall{}, errors.New("todo done: missing id or text")
+			}
+			key := "text"
+			if storage.IsID(ref) {
+				key = "id"
+			}
+			return name, cliCall{tool: "complete_todo", args: map[string]any{key: ref}, format: formatCompletedTodo}, nil
+		}
+		return name, cliCall{}, fmt.Errorf("todo: unknown command %q", args[1])
+
+	case "remind":
+		if len(args) < 3 {
+			return name, cliCall{}, errors.New("remind: expected a date and text")
+		}
+		return name, cliCall{tool: "set_reminder", args: map[string]any{
+			"date": args[1], "text": strings.Join(args[2:], " "),
+		}, format: formatReminder}, nil
+
+	case "read":
+		notes := fs.String("notes", "", "why it's worth reading")
+		if err := fs.Parse(args[1:]); err != nil {
+			return name, cliCall{}, fmt.Errorf("read: %w", err)
+		}
+		if fs.NArg() != 1 {
+			return name, cliCall{}, errors.New("read: expected one URL")
+		}
+		return name, cliCall{tool: "add_to_reading_list", args: withoutEmpty(map[string]any{
+			"url": fs.Arg(0), "notes": *notes,
+		}), format: formatReadingItem}, nil
+
+	case "list":
+		what := "todos"
+		if len(args) > 1 && !strings.HasPrefix(args[1], "-") {
+			what, args = args[1], args[1:]
+		}
+		all := fs.Bool("all", false, "include completed items")
+		if err := fs.Parse(args[1:]); err != nil {
+			return name, cliCall{}, fmt.Errorf("list: %w", err)
+		}
+		tool, status := map[string]string{
+			"todos":     "list_todos",
+			"reminders": "list_reminders",
+			"reading":   "list_reading_list",
+		}[what], ""
+		if tool == "" {
+			return name, cliCall{}, fmt.Errorf("list: unknown list %q", what)
+		}
+		if *all {
+			status = "all"
+		}
+		return name, cliCall{tool: tool, args: withoutEmpty(map[string]any{"status": status}), format: formatText}, nil
+	}
+	return name, cliCall{}, fmt.Errorf("unknown command %q", name)
+}
+
+// withoutEmpty drops unset flags, so tools apply their own defaults.
+func withoutEmpty(args map[string]any) map[string]any {
+	for key, value := range args {
+		if value == "" || value == false {
+			delete(args, key)
+		}
+	}
+	return args
+}
+
+// runTool calls the tool and formats its result, returning the tool's own
+// message as the error when it reports failure.
+func runTool(ctx context.Context, session toolSession, name string, call cliCall) (string, error) {
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: call.tool, Arguments: call.args})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+
+	var text string
+	for _, c := range res.Content {
+		if t, ok := c.(*mcp.TextContent); ok {
+			text = t.Text
+			break
+		}
+	}
+	if res.IsError {
+		return "", fmt.Errorf("%s: %s", name, text)
+	}
+
+	var out struct {
+		Success bool   `json:"success"`
+		Message string `json:"message"`
+	}
+	raw, _ := json.Marshal(res.StructuredContent)
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return "", fmt.Errorf("%s: unexpected response: %w", name, err)
+	}
+	if !out.Success {
+		return "", errors.New(out.Message)
+	}
+	return call.format(out.Message, text), nil
+}
+
+// connectCLI opens a session to the remote server if MOMENTUM_URL is set,
+// else to an in-process server over the configured data repo.
+func connectCLI(ctx context.Context) (*mcp.ClientSession, error) {
+	endpoint := os.Getenv("MOMENTUM_URL")
+	if endpoint == "" {
+		return connectLocal(ctx, "momentum-cli")
+	}
+
+	endpoint = strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/mcp") {
+		endpoint += "/mcp"
+	}
+	transport := &mcp.StreamableClientTransport{
+		Endpoint: endpoint,
+		HTTPClient: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: bearerTransport{token: os.Getenv("MOMENTUM_TOKEN"), next: http.DefaultTransport},
+		},
+		MaxRetries: -1,
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "momentum-cli", Version: version.Version}, nil)
+	session, err := client.Connect(ctx, transport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", endpoint, err)
+	}
+	return session, nil
+}
+
+// connectLocal opens an in-process session over the data repo configured
+// by the environment, honoring data file paths and events mode.
+func connectLocal(ctx context.Context, clientName string) (*mcp.ClientSession, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	ghStorage, err := storage.NewGitHubStorage(cfg.GitHubToken, cfg.GitHubRepo)
+	if err != nil {
+		return nil, fmt.Errorf("creating storage: %w", err)
+	}
+	dataStorage := storage.WithPaths(ghStorage, cfg.DataPaths)
+	if cfg.StorageMode == "events" {
+		dataStorage = storage.NewEventStore(dataStorage, cfg.EventLogPath, nil)
+	}
+	return server.ConnectInProcess(ctx, server.New(server.Config{Storage: dataStorage}), clientName)
+}
+
+// bearerTransport adds an Authorization header to each request.
+type bearerTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.token != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+	return t.next.RoundTrip(req)
+}
+
+func formatAddedTodo(message, text string) string {
+	var item tools.TodoItem
+	if json.Unmarshal([]byte(message), &item) != nil {
+		return message
+	}
+	return fmt.Sprintf("Added todo %s: %s (%s)", item.ID, item.Text, item.Priority)
+}
+
+func formatCompletedTodo(message, text string) string {
+	var item tools.TodoItem
+	if json.Unmarshal([]byte(message), &item) != nil {
+		return message
+	}
+	return fmt.Sprintf("Completed %s: %s", item.ID, item.Text)
+}
+
+func formatReminder(message, text string) string {
+	var item tools.ReminderItem
+	if json.Unmarshal([]byte(message), &item) != nil {
+		return message
+	}
+	return fmt.Sprintf("Reminder %s set for %s: %s", item.ID, item.Date, item.Text)
+}
+
+func formatReadingItem(message, text string) string {
+	var item tools.ReadingListItem
+	if json.Unmarshal([]byte(message), &item) != nil {
+		return message
+	}
+	return fmt.Sprintf("Added to reading list %s: %s", item.ID, item.URL)
+}
+
+// formatText prints the text the read tools render for people.
+func formatText(message, text string) string {
+	if text != "" {
+		return text
+	}
+	return message
+}
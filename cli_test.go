@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCLI(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		tool   string
+		params map[string]any
+		format func(message, text string) string
+		err    string // substring of the expected error, if any
+	}{
+		{"todo add", []string{"todo", "add", "Buy", "milk"}, "add_todo", map[string]any{"text": "Buy milk"}, formatAddedTodo, ""},
+		{"todo add flags", []string{"todo", "add", "-priority", "high", "-project", "home", "-force", "Buy milk"},
+			"add_todo", map[string]any{"text": "Buy milk", "priority": "high", "project": "home", "force": true}, formatAddedTodo, ""},
+		{"todo add missing text", []string{"todo", "add", "-priority", "high"}, "", nil, nil, "todo add: missing text"},
+		{"todo add unknown flag", []string{"todo", "add", "-due", "friday", "Buy milk"}, "", nil, nil, "todo add: flag provided but not defined"},
+		{"todo done by legacy id", []string{"todo", "done", "a1b2c3d4"}, "complete_todo", map[string]any{"id": "a1b2c3d4"}, formatCompletedTodo, ""},
+		{"todo done by prefixed id", []string{"todo", "done", "td_a1b2c3"}, "complete_todo", map[string]any{"id": "td_a1b2c3"}, formatCompletedTodo, ""},
+		{"todo done by text", []string{"todo", "done", "buy", "milk"}, "complete_todo", map[string]any{"text": "buy milk"}, formatCompletedTodo, ""},
+		{"todo done by id-like text", []string{"todo", "done", "a1b2c3d4", "later"}, "complete_todo", map[string]any{"text": "a1b2c3d4 later"}, formatCompletedTodo, ""},
+		{"todo done missing ref", []string{"todo", "done"}, "", nil, nil, "todo done: missing id or text"},
+		{"todo missing command", []string{"todo"}, "", nil, nil, "todo: expected add or done"},
+		{"todo unknown command", []string{"todo", "drop", "x"}, "", nil, nil, `todo: unknown command "drop"`},
+		{"remind", []string{"remind", "2026-03-01", "Renew", "domain"}, "set_reminder", map[string]any{"date": "2026-03-01", "text": "Renew domain"}, formatReminder, ""},
+		{"remind missing text", []string{"remind", "2026-03-01"}, "", nil, nil, "remind: expected a date and text"},
+		{"read", []string{"read", "https://example.com"}, "add_to_reading_list", map[string]any{"url": "https://example.com"}, formatReadingItem, ""},
+		{"read notes", []string{"read", "-notes", "for the talk", "https://example.com"},
+			"add_to_reading_list", map[string]any{"url": "https://example.com", "notes": "for the talk"}, formatReadingItem, ""},
+		{"read missing url", []string{"read", "-notes", "x"}, "", nil, nil, "read: expected one URL"},
+		{"read two urls", []string{"read", "https://a.example", "https://b.example"}, "", nil, nil, "read: expected one URL"},
+		{"list", []string{"list"}, "list_todos", map[string]any{}, formatText, ""},
+		{"list all", []string{"list", "-all"}, "list_todos", map[string]any{"status": "all"}, formatText, ""},
+		{"list reminders", []string{"list", "reminders"}, "list_reminders", map[string]any{}, formatText, ""},
+		{"list reading all", []string{"list", "reading", "-all"}, "list_reading_list", map[string]any{"status": "all"}, formatText, ""},
+		{"list unknown", []string{"list", "notes"}, "", nil, nil, `list: unknown list "notes"`},
+		{"list unknown flag", []string{"list", "todos", "-done"}, "", nil, nil, "list: flag provided but not defined"},
+		{"unknown command", []string{"journal", "hi"}, "", nil, nil, `unknown command "journal"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, call, err := parseCLI(tt.args)
+			if name != tt.args[0] {
+				t.Errorf("expected command %q, got %q", tt.args[0], name)
+			}
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if call.tool != tt.tool || !reflect.DeepEqual(call.args, tt.params) {
+				t.Errorf("expected %s %v, got %s %v", tt.tool, tt.params, call.tool, call.args)
+			}
+			if reflect.ValueOf(call.format).Pointer() != reflect.ValueOf(tt.format).Pointer() {
+				t.Error("unexpected format helper")
+			}
+		})
+	}
+}
+
+func TestParseCLI_Help(t *testing.T) {
+	for _, args := range [][]string{nil, {"todo", "add", "-h"}, {"list", "-help"}} {
+		if _, _, err := parseCLI(args); !errors.Is(err, flag.ErrHelp) {
+			t.Errorf("parseCLI(%q) = %v, expected flag.ErrHelp", args, err)
+		}
+	}
+}
+
+func TestWithoutEmpty(t *testing.T) {
+	got := withoutEmpty(map[string]any{"text": "x", "priority": "", "force": false, "all": true, "limit": 0})
+	want := map[string]any{"text": "x", "all": true, "limit": 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("withoutEmpty() = %v, expected %v", got, want)
+	}
+}
+
+func TestFormatHelpers(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  func(message, text string) string
+		message string
+		text    string
+		want    string
+	}{
+		{"added todo", formatAddedTodo, `{"id":"a1b2c3d4","text":"Buy milk","priority":"high"}`, "", "Added todo a1b2c3d4: Buy milk (high)"},
+		{"completed todo", formatCompletedTodo, `{"id":"a1b2c3d4","text":"Buy milk","completed":true}`, "", "Completed a1b2c3d4: Buy milk"},
+		{"reminder", formatReminder, `{"id":"rm_a1b2c3","date":"2026-03-01","text":"Renew domain"}`, "", "Reminder rm_a1b2c3 set for 2026-03-01: Renew domain"},
+		{"reading item", formatReadingItem, `{"id":"rd_a1b2c3","url":"https://example.com"}`, "", "Added to reading list rd_a1b2c3: https://example.com"},
+		// Messages that aren't an item, like duplicate warnings, print as is
+		{"added todo plain message", formatAddedTodo, "Possible duplicate of a1b2c3d4", "", "Possible duplicate of a1b2c3d4"},
+		{"completed todo plain message", formatCompletedTodo, "Multiple todos match", "", "Multiple todos match"},
+		{"reminder plain message", formatReminder, "Invalid date", "", "Invalid date"},
+		{"reading item plain message", formatReadingItem, "Already on the list", "", "Already on the list"},
+		{"text", formatText, `{"todos":[]}`, "0 todos", "0 todos"},
+		{"text falls back to message", formatText, "No todos", "", "No todos"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.format(tt.message, tt.text); got != tt.want {
+			t.Errorf("%s: got %q, expected %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+// Package entitystore reads and changes the items kept in the markdown data
+// files - todos, reminders, milestones, reading list items - through typed
+// stores, so each tool doesn't repeat the read, parse, find, change,
+// serialize and write cycle, and reports missing IDs, ambiguous matches and
+// conflicts the same way.
+package entitystore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// List selects which of a file's lists an operation looks in.
+type List int
+
+const (
+	// Open is the list of items still to do: active todos, upcoming
+	// reminders, unread items, active milestones.
+	Open List = iota
+	// Done is the list of completed items.
+	Done
+	// Both looks in the open list, then the done list.
+	Both
+)
+
+// Kind describes one kind of item kept in a data file as an open and a
+// done list.
+type Kind[F, T any] struct {
+	// Name and Plural name the item in messages, e.g. "todo" and "todos".
+	Name   string
+	Plural string
+	// OpenLabel and DoneLabel describe the lists in messages, e.g.
+	// "active" and "completed".
+	OpenLabel string
+	DoneLabel string
+	// File is the data file the items are kept in.
+	File string
+
+	Parse     func(ctx context.Context, content string) (*F, error)
+	Serialize func(*F) string
+	// Lists returns the file's open and done lists.
+	Lists func(*F) (open, done *[]T)
+	ID    func(*T) string
+	// Prefix is the items' ID prefix (see storage.NewID), and SetID sets
+	// an item's ID. If SetID is set, Add gives items a new ID unused in the
+	// file.
+	Prefix string
+	SetID  func(*T, string)
+	// Match is the text a Ref's Text is ranked against (see Rank), and
+	// Describe the line listing an item when several match.
+	Match    func(*T) string
+	Describe func(*T) string
+}
+
+// Ref identifies an item by ID or, if ID is empty, by its text: part of
+// it, or something close (see Score).
+type Ref struct {
+	ID   string
+	Text string
+}
+
+// ConflictMessage is reported when the file changed between the read and
+// the write.
+const ConflictMessage = "File was modified by another process. Please try again."
+
+// ProposedMessage is reported for a change proposed as pull request pr
+// instead of committed.
+func ProposedMessage(pr *storage.PullRequest) string {
+	msg := fmt.Sprintf("Proposed as pull request #%d, pending review. Nothing changes until it is merged.", pr.Number)
+	if pr.URL != "" {
+		msg += " " + pr.URL
+	}
+	return msg
+}
+
+// WriteMessage returns the message to report for a write that was refused
+// or held back rather than failed: a conflict, or a change proposed as a
+// pull request for review.
+func WriteMessage(err error) (string, bool) {
+	var proposed *storage.ProposedError
+	switch {
+	case errors.Is(err, storage.ErrConflict):
+		return ConflictMessage, true
+	case errors.As(err, &proposed):
+		return ProposedMessage(proposed.PullRequest), true
+	}
+	return "", false
+}
+
+// Error is a failure to report to the user, such as an unknown ID or a
+// conflicting write, as opposed to a storage failure.
+type Error struct {
+	Message string
+	// Candidates are the items a text matched when it matched several
+	// without a clear winner, best first.
+	Candidates []Candidate
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Message returns err's message if it is an *Error.
+func Message(err error) (string, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Message, true
+	}
+	return "", false
+}
+
+// Candidates returns the candidates of err if it is an *Error for an
+// ambiguous match.
+func Candidates(err error) []Candidate {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Candidates
+	}
+	return nil
+}
+
+// CheckUnchanged implements if_unchanged_sha: when the caller supplies the
+// source_sha it last saw, refuse the write if the file has changed since.
+// Returns an empty string if the write may proceed.
+func CheckUnchanged(path, expected, actual string) string {
+	if expected == "" || expected == actual {
+		return ""
+	}
+	return fmt.Sprintf("%s has changed since you read it (source_sha %s, now %s). Re-read it and retry with the new source_sha.", path, expected, actual)
+}
+
+// Store reads and changes one kind of item.
+type Store[F, T any] struct {
+	storage storage.Storage
+	kind    Kind[F, T]
+}
+
+// New creates a Store for kind's items in s.
+func New[F, T any](s storage.Storage, kind Kind[F, T]) *Store[F, T] {
+	return &Store[F, T]{storage: s, kind: kind}
+}
+
+// Load reads and parses the file, returning it with its SHA. If
+// ifUnchangedSHA is set and the file has changed since, it returns an
+// *Error.
+func (s *Store[F, T]) Load(ctx context.Context, ifUnchangedSHA string) (*F, string, error) {
+	content, sha, err := s.storage.ReadFile(ctx, s.kind.File)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading %s: %w", s.kind.File, err)
+	}
+	if msg := CheckUnchanged(s.kind.File, ifUnchangedSHA, sha); msg != "" {
+		return nil, "", &Error{Message: msg}
+	}
+	f, err := s.kind.Parse(ctx, content)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing %s: %w", s.kind.Plural, err)
+	}
+	return f, sha, nil
+}
+
+// Save writes f over the version of the file with sha. A conflict, or a
+// change proposed for review instead of written (see WriteMessage), is
+// returned as an *Error.
+func (s *Store[F, T]) Save(ctx context.Context, f *F, sha, message string) error {
+	err := s.storage.WriteFile(ctx, s.kind.File, s.kind.Serialize(f), sha, message)
+	if msg, ok := WriteMessage(err); ok {
+		return &Error{Message: msg}
+	}
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", s.kind.File, err)
+	}
+	return nil
+}
+
+// Change returns the write of f over the version of the file with sha, to
+// commit along with changes to other files (see storage.WriteFiles).
+func (s *Store[F, T]) Change(f *F, sha string) storage.FileChange {
+	return storage.FileChange{Path: s.kind.File, Content: s.kind.Serialize(f), SHA: sha}
+}
+
+// Find returns the list in f holding ref's item, and its index there. A
+// text is ranked against the items (see Rank) and the clear best match
+// taken; if there isn't one, the *Error lists the candidates.
+func (s *Store[F, T]) Find(f *F, list List, ref Ref) (*[]T, int, error) {
+	open, done := s.kind.Lists(f)
+	var lists []*[]T
+	switch list {
+	case Open:
+		lists = []*[]T{open}
+	case Done:
+		lists = []*[]T{done}
+	default:
+		lists = []*[]T{open, done}
+	}
+
+	if id := strings.TrimSpace(ref.ID); id != "" {
+		for _, l := range lists {
+			for i := range *l {
+				if s.kind.ID(&(*l)[i]) == id {
+					return l, i, nil
+				}
+			}
+		}
+		return nil, -1, &Error{Message: fmt.Sprintf("No %s found with id %q", s.describe(list), id)}
+	}
+
+	if strings.TrimSpace(ref.Text) == "" {
+		return nil, -1, &Error{Message: "id is required"}
+	}
+	type position struct {
+		list *[]T
+		i    int
+	}
+	var positions []position
+	var ids, texts []string
+	for _, l := range lists {
+		for i := range *l {
+			item := &(*l)[i]
+			positions = append(positions, position{l, i})
+			ids = append(ids, s.kind.ID(item))
+			texts = append(texts, s.kind.Match(item))
+		}
+	}
+	ranked := Rank(ref.Text, ids, texts)
+	if len(ranked) == 0 {
+		return nil, -1, &Error{Message: fmt.Sprintf("No %s found matching %q", s.describe(list), ref.Text)}
+	}
+	if best, ok := Pick(ranked); ok {
+		p := positions[best.Index]
+		return p.list, p.i, nil
+	}
+	lines := make([]string, len(ranked))
+	for i, c := range ranked {
+		p := positions[c.Index]
+		lines[i] = "- " + s.kind.Describe(&(*p.list)[p.i])
+	}
+	return nil, -1, &Error{
+		Message:    fmt.Sprintf("Multiple %s match %q. Please be more specific or use an id:\n%s", s.kind.Plural, ref.Text, strings.Join(lines, "\n")),
+		Candidates: ranked,
+	}
+}
+
+// describe names the items in list, e.g. "active todo".
+func (s *Store[F, T]) describe(list List) string {
+	switch list {
+	case Open:
+		return s.kind.OpenLabel + " " + s.kind.Name
+	case Done:
+		return s.kind.DoneLabel + " " + s.kind.Name
+	}
+	return s.kind.Name
+}
+
+// Add appends item to the open list and returns it, with its new ID if
+// the kind sets one. check, if not nil, sees the file first and can refuse
+// the add by returning an error (an *Error to report to the user).
+func (s *Store[F, T]) Add(ctx context.Context, ifUnchangedSHA string, item T, message string, check func(*F) error) (T, error) {
+	var zero T
+	f, sha, err := s.Load(ctx, ifUnchangedSHA)
+	if err != nil {
+		return zero, err
+	}
+	if check != nil {
+		if err := check(f); err != nil {
+			return zero, err
+		}
+	}
+	if s.kind.SetID != nil {
+		s.kind.SetID(&item, storage.NewID(s.kind.Prefix, func(id string) bool {
+			_, _, err := s.Find(f, Both, Ref{ID: id})
+			return err == nil
+		}))
+	}
+	open, _ := s.kind.Lists(f)
+	*open = append(*open, item)
+	if err := s.Save(ctx, f, sha, message); err != nil {
+		return zero, err
+	}
+	return item, nil
+}
+
+// Update finds ref's item in list, applies change, and writes the file
+// with the commit message change returns. It returns the changed item.
+func (s *Store[F, T]) Update(ctx context.Context, list List, ref Ref, ifUnchangedSHA string, change func(*T) string) (T, error) {
+	var zero T
+	f, sha, err := s.Load(ctx, ifUnchangedSHA)
+	if err != nil {
+		return zero, err
+	}
+	l, i, err := s.Find(f, list, ref)
+	if err != nil {
+		return zero, err
+	}
+	message := change(&(*l)[i])
+	if err := s.Save(ctx, f, sha, message); err != nil {
+		return zero, err
+	}
+	return (*l)[i], nil
+}
+
+// Move finds ref's item in list from (Open or Done), applies change, and
+// moves it to the other list: to the front of the done list, as the most
+// recently completed, or the end of the open list. It returns the moved
+// item.
+func (s *Store[F, T]) Move(ctx context.Context, from List, ref Ref, ifUnchangedSHA string, change func(*T) string) (T, error) {
+	var zero T
+	f, sha, err := s.Load(ctx, ifUnchangedSHA)
+	if err != nil {
+		return zero, err
+	}
+	l, i, err := s.Find(f, from, ref)
+	if err != nil {
+		return zero, err
+	}
+	item := (*l)[i]
+	message := change(&item)
+	*l = append((*l)[:i], (*l)[i+1:]...)
+
+	open, done := s.kind.Lists(f)
+	if from == Open {
+		*done = append([]T{item}, *done...)
+	} else {
+		*open = append(*open, item)
+	}
+	if err := s.Save(ctx, f, sha, message); err != nil {
+		return zero, err
+	}
+	return item, nil
+}
+
+// Delete removes ref's item from whichever list holds it, and writes the
+// file with the commit message message returns. It returns the deleted
+// item.
+func (s *Store[F, T]) Delete(ctx context.Context, ref Ref, ifUnchangedSHA string, message func(*T) string) (T, error) {
+	var zero T
+	f, sha, err := s.Load(ctx, ifUnchangedSHA)
+	if err != nil {
+		return zero, err
+	}
+	item, err := s.Remove(f, ref)
+	if err != nil {
+		return zero, err
+	}
+	if err := s.Save(ctx, f, sha, message(&item)); err != nil {
+		return zero, err
+	}
+	return item, nil
+}
+
+// Remove takes ref's item out of whichever list in f holds it, without
+// writing the file, and returns it.
+func (s *Store[F, T]) Remove(f *F, ref Ref) (T, error) {
+	var zero T
+	l, i, err := s.Find(f, Both, ref)
+	if err != nil {
+		return zero, err
+	}
+	item := (*l)[i]
+	*l = append((*l)[:i], (*l)[i+1:]...)
+	return item, nil
+}
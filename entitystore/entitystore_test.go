@@ -0,0 +1,141 @@
+package entitystore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+var todoKind = Kind[storage.TodoFile, storage.Todo]{
+	Name:      "todo",
+	Plural:    "todos",
+	OpenLabel: "active",
+	DoneLabel: "completed",
+	File:      storage.TodosFile,
+	Parse: func(ctx context.Context, content string) (*storage.TodoFile, error) {
+		return storage.ParseTodos(content)
+	},
+	Serialize: storage.SerializeTodos,
+	Lists: func(f *storage.TodoFile) (*[]storage.Todo, *[]storage.Todo) {
+		return &f.Active, &f.Completed
+	},
+	ID:       func(t *storage.Todo) string { return t.ID },
+	Match:    func(t *storage.Todo) string { return t.Text },
+	Describe: func(t *storage.Todo) string { return fmt.Sprintf("[%s] %s", t.ID, t.Text) },
+}
+
+const todos = `# Active
+
+## High Priority
+- [ ] Write report {id:aaaa1111}
+- [ ] Review report {id:bbbb2222}
+
+# Completed
+- [x] Book flights {id:cccc3333}
+`
+
+func newStore(t *testing.T) (*Store[storage.TodoFile, storage.Todo], *storage.MemoryStorage) {
+	t.Helper()
+	mem := storage.NewMemoryStorage(map[string]string{storage.TodosFile: todos}, nil)
+	return New(mem, todoKind), mem
+}
+
+func TestFind(t *testing.T) {
+	s, _ := newStore(t)
+	f, _, err := s.Load(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		list    List
+		ref     Ref
+		wantID  string
+		wantErr string
+	}{
+		{Open, Ref{ID: " bbbb2222 "}, "bbbb2222", ""},
+		{Open, Ref{Text: "WRITE"}, "aaaa1111", ""},
+		{Open, Ref{Text: "wrte reprt"}, "aaaa1111", ""},
+		{Open, Ref{ID: "cccc3333"}, "", `No active todo found with id "cccc3333"`},
+		{Both, Ref{ID: "cccc3333"}, "cccc3333", ""},
+		{Done, Ref{Text: "report"}, "", `No completed todo found matching "report"`},
+		{Open, Ref{Text: "report"}, "", "Multiple todos match \"report\". Please be more specific or use an id:\n- [aaaa1111] Write report\n- [bbbb2222] Review report"},
+		{Both, Ref{ID: "dddd4444"}, "", `No todo found with id "dddd4444"`},
+	}
+	for _, tt := range tests {
+		l, i, err := s.Find(f, tt.list, tt.ref)
+		if tt.wantErr != "" {
+			if msg, ok := Message(err); !ok || msg != tt.wantErr {
+				t.Errorf("Find(%v, %+v) error = %v, want %q", tt.list, tt.ref, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Find(%v, %+v) error = %v", tt.list, tt.ref, err)
+			continue
+		}
+		if got := (*l)[i].ID; got != tt.wantID {
+			t.Errorf("Find(%v, %+v) = %s, want %s", tt.list, tt.ref, got, tt.wantID)
+		}
+	}
+}
+
+func TestMoveAndDelete(t *testing.T) {
+	ctx := context.Background()
+	s, mem := newStore(t)
+
+	moved, err := s.Move(ctx, Open, Ref{ID: "aaaa1111"}, "", func(todo *storage.Todo) string {
+		todo.Completed = true
+		return "Complete todo"
+	})
+	if err != nil || !moved.Completed {
+		t.Fatalf("Move = %+v, %v", moved, err)
+	}
+	f, sha, _ := s.Load(ctx, "")
+	if len(f.Active) != 1 || f.Completed[0].ID != "aaaa1111" {
+		t.Errorf("after Move: active %+v, completed %+v", f.Active, f.Completed)
+	}
+
+	if _, err := s.Delete(ctx, Ref{ID: "cccc3333"}, "stale", func(*storage.Todo) string { return "Delete" }); err == nil {
+		t.Error("Delete with a stale if_unchanged_sha succeeded")
+	} else if msg, _ := Message(err); !strings.Contains(msg, "has changed since you read it") {
+		t.Errorf("stale Delete message = %q", msg)
+	}
+	if _, err := s.Delete(ctx, Ref{ID: "cccc3333"}, sha, func(*storage.Todo) string { return "Delete" }); err != nil {
+		t.Fatal(err)
+	}
+	content, _, _ := mem.ReadFile(ctx, storage.TodosFile)
+	if strings.Contains(content, "cccc3333") {
+		t.Errorf("deleted todo still in file:\n%s", content)
+	}
+}
+
+func TestCheckUnchanged(t *testing.T) {
+	if msg := CheckUnchanged(storage.TodosFile, "", "abc"); msg != "" {
+		t.Errorf("empty sha: %q, want the check skipped", msg)
+	}
+	if msg := CheckUnchanged(storage.TodosFile, "abc", "abc"); msg != "" {
+		t.Errorf("matching sha: %q", msg)
+	}
+	msg := CheckUnchanged(storage.TodosFile, "abc", "def")
+	if !strings.Contains(msg, "todos.md has changed since you read it") || !strings.Contains(msg, "abc") || !strings.Contains(msg, "def") {
+		t.Errorf("stale sha: %q", msg)
+	}
+}
+
+func TestSave_Conflict(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newStore(t)
+	f, sha, _ := s.Load(ctx, "")
+	if err := s.Save(ctx, f, sha, "First"); err != nil {
+		t.Fatal(err)
+	}
+	f.Active = f.Active[:1]
+	err := s.Save(ctx, f, sha, "Second")
+	if msg, ok := Message(err); !ok || msg != ConflictMessage {
+		t.Errorf("Save over a stale SHA = %v, want the conflict message", err)
+	}
+}
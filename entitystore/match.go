@@ -0,0 +1,139 @@
+package entitystore
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Scores from Score. Text containing the whole query always outranks a
+// fuzzy match, which is capped at fuzzyWeight.
+const (
+	// MinScore is the score from which an item is a candidate at all.
+	MinScore = 0.5
+	// clearMargin is how far the best candidate has to be ahead of the
+	// next to be picked without asking.
+	clearMargin = 0.15
+	// substringScore is the least a text containing the query scores.
+	substringScore = 0.8
+	fuzzyWeight    = 0.75
+)
+
+// Candidate is an item that matched a text query.
+type Candidate struct {
+	ID    string  `json:"id"`
+	Text  string  `json:"text"`
+	Score float64 `json:"score"`
+	// Index is the item's position in the texts given to Rank.
+	Index int `json:"-"`
+}
+
+// NormalizeText lowercases s, drops punctuation, and collapses whitespace,
+// so "Write the docs!" and "write the  docs" compare equal.
+func NormalizeText(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Score rates how well text matches query, from 0 to 1: 1 for the same
+// text, at least 0.8 when text contains the query (more the more of it
+// the query covers), and otherwise up to 0.75 by how closely each query
+// word matches a word of text, allowing typos and prefixes.
+func Score(query, text string) float64 {
+	q, t := NormalizeText(query), NormalizeText(text)
+	if q == "" || t == "" {
+		return 0
+	}
+	if q == t {
+		return 1
+	}
+	if strings.Contains(t, q) {
+		return substringScore + (1-substringScore)*float64(len(q))/float64(len(t))
+	}
+
+	words := strings.Fields(t)
+	total := 0.0
+	for _, qw := range strings.Fields(q) {
+		best := 0.0
+		for _, w := range words {
+			if s := wordSimilarity(qw, w); s > best {
+				best = s
+			}
+		}
+		total += best
+	}
+	return fuzzyWeight * total / float64(len(strings.Fields(q)))
+}
+
+// wordSimilarity is 1 for the same word or a prefix of at least three
+// letters, and otherwise one less the edit distance relative to the longer
+// word.
+func wordSimilarity(a, b string) float64 {
+	if a == b || (len(a) >= 3 && strings.HasPrefix(b, a)) {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longer := max(len(ra), len(rb))
+	return 1 - float64(levenshtein(ra, rb))/float64(longer)
+}
+
+// levenshtein is the edit distance between a and b.
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// Rank scores texts against query and returns those scoring at least
+// MinScore, best first (ties in text order). ids[i] identifies texts[i].
+func Rank(query string, ids, texts []string) []Candidate {
+	var ranked []Candidate
+	for i, text := range texts {
+		if s := Score(query, text); s >= MinScore {
+			ranked = append(ranked, Candidate{ID: ids[i], Text: text, Score: s, Index: i})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Score > ranked[j].Score })
+	return ranked
+}
+
+// Pick returns the candidate a query clearly means: the only one, the only
+// exact match, the only one containing the whole query, or one well ahead
+// of the rest.
+func Pick(ranked []Candidate) (Candidate, bool) {
+	switch {
+	case len(ranked) == 0:
+		return Candidate{}, false
+	case len(ranked) == 1:
+		return ranked[0], true
+	case ranked[0].Score == 1 && ranked[1].Score < 1:
+		return ranked[0], true
+	case ranked[0].Score >= substringScore && ranked[1].Score < substringScore:
+		return ranked[0], true
+	case ranked[0].Score-ranked[1].Score >= clearMargin:
+		return ranked[0], true
+	}
+	return Candidate{}, false
+}
@@ -0,0 +1,62 @@
+package entitystore
+
+import "testing"
+
+func TestScore(t *testing.T) {
+	exact := Score("write report", "Write report!")
+	substring := Score("report", "Write report")
+	fuzzy := Score("reprot", "Write report")
+	if exact != 1 {
+		t.Errorf("exact match scored %v, want 1", exact)
+	}
+	if !(substring < exact && substring >= substringScore) {
+		t.Errorf("substring match scored %v, want in [%v, 1)", substring, substringScore)
+	}
+	if !(fuzzy < substring && fuzzy >= MinScore) {
+		t.Errorf("typo scored %v, want in [%v, %v)", fuzzy, MinScore, substring)
+	}
+	if s := Score("groceries", "Write report"); s >= MinScore {
+		t.Errorf("unrelated text scored %v, want below %v", s, MinScore)
+	}
+	if s := Score("rev", "Review report"); s < substringScore {
+		t.Errorf("prefix scored %v, want at least %v", s, substringScore)
+	}
+}
+
+func TestRankAndPick(t *testing.T) {
+	ids := []string{"a", "b", "c"}
+	texts := []string{"Write report", "Review report", "Book flights"}
+
+	tests := []struct {
+		query   string
+		wantIDs []string
+		want    string // picked ID, "" if ambiguous
+	}{
+		{"report", []string{"a", "b"}, ""},
+		{"write report", []string{"a"}, "a"},
+		{"reveiw", []string{"b"}, "b"},
+		{"flights", []string{"c"}, "c"},
+		{"groceries", nil, ""},
+	}
+	for _, tt := range tests {
+		ranked := Rank(tt.query, ids, texts)
+		var gotIDs []string
+		for _, c := range ranked {
+			gotIDs = append(gotIDs, c.ID)
+			if texts[c.Index] != c.Text {
+				t.Errorf("Rank(%q): candidate %s has index %d, text %q", tt.query, c.ID, c.Index, c.Text)
+			}
+		}
+		if len(gotIDs) != len(tt.wantIDs) || (len(gotIDs) > 0 && gotIDs[0] != tt.wantIDs[0]) {
+			t.Errorf("Rank(%q) = %v, want %v", tt.query, gotIDs, tt.wantIDs)
+		}
+		best, ok := Pick(ranked)
+		if tt.want == "" {
+			if ok {
+				t.Errorf("Pick(%q) = %s, want no clear winner", tt.query, best.ID)
+			}
+		} else if !ok || best.ID != tt.want {
+			t.Errorf("Pick(%q) = %s, %v, want %s", tt.query, best.ID, ok, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dang-w/momentum-mcp-server/tools"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// runImportReadingList is the import-reading-list subcommand. It feeds a
+// Pocket or Instapaper export through the import_reading_list tool, one
+// commit per batch, until every new item is imported.
+func runImportReadingList(args []string) int {
+	fs := flag.NewFlagSet("import-reading-list", flag.ContinueOnError)
+	format := fs.String("format", "auto", "export format: pocket, instapaper, or auto")
+	includeArchived := fs.Bool("include-archived", false, "also import archived items as read")
+	batch := fs.Int("batch", 0, "items per commit (default 200)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: momentum-mcp-server import-reading-list [flags] <export file>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	content, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "reading export:", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	session, err := connectLocal(ctx, "import-reading-list")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer session.Close()
+
+	for {
+		res, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Name: "import_reading_list",
+			Arguments: tools.ImportReadingListInput{
+				Content:         string(content),
+				Format:          *format,
+				IncludeArchived: *includeArchived,
+				Limit:           *batch,
+			},
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "import failed:", err)
+			return 1
+		}
+
+		var out tools.ImportReadingListOutput
+		raw, _ := json.Marshal(res.StructuredContent)
+		if err := json.Unmarshal(raw, &out); err != nil || res.IsError {
+			fmt.Fprintln(os.Stderr, "import failed")
+			return 1
+		}
+		if !out.Success {
+			fmt.Fprintln(os.Stderr, out.Message)
+			return 1
+		}
+
+		var result tools.ImportReadingListResult
+		if err := json.Unmarshal([]byte(out.Message), &result); err != nil {
+			fmt.Fprintln(os.Stderr, "decoding result:", err)
+			return 1
+		}
+		fmt.Printf("imported %d (%d duplicates, %d archived skipped, %d remaining)\n",
+			result.Imported, result.Duplicates, result.Archived, result.Remaining)
+		if result.Remaining == 0 || result.Imported == 0 {
+			return 0
+		}
+	}
+}
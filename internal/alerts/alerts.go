@@ -0,0 +1,245 @@
+// Package alerts piggybacks urgent items on tool results: when enabled, a
+// tool call's result carries a compact "alerts" field with the number of
+// overdue reminders and the milestones due soon, so the assistant can
+// mention them even when the user asked about something else.
+//
+// Nothing is added when there's nothing urgent, nor to the tools that
+// already report these items (see DefaultSkip).
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DefaultWindow is how far ahead a milestone counts as due soon.
+const DefaultWindow = 48 * time.Hour
+
+// DefaultSkip are the tools that get no alerts by default: those that list
+// overdue reminders or upcoming milestones themselves.
+var DefaultSkip = []string{"get_briefing", "get_today", "get_dashboard", "list_reminders", "get_milestones"}
+
+// Alerts is the compact summary attached to tool results.
+type Alerts struct {
+	OverdueReminders  int         `json:"overdue_reminders,omitempty"`
+	MilestonesDueSoon []Milestone `json:"milestones_due_soon,omitempty"`
+}
+
+// Milestone is a milestone due soon (or overdue).
+type Milestone struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	Due  string `json:"due"`
+}
+
+// Empty reports whether there's nothing to alert about.
+func (a Alerts) Empty() bool {
+	return a.OverdueReminders == 0 && len(a.MilestonesDueSoon) == 0
+}
+
+// String is the one-line text form, e.g. "Alerts: 2 overdue reminders;
+// milestone "Beta" due 2026-02-11".
+func (a Alerts) String() string {
+	var parts []string
+	switch a.OverdueReminders {
+	case 0:
+	case 1:
+		parts = append(parts, "1 overdue reminder")
+	default:
+		parts = append(parts, fmt.Sprintf("%d overdue reminders", a.OverdueReminders))
+	}
+	for _, m := range a.MilestonesDueSoon {
+		parts = append(parts, fmt.Sprintf("milestone %q due %s (id %s)", m.Text, m.Due, m.ID))
+	}
+	return "Alerts: " + strings.Join(parts, "; ")
+}
+
+// Checker works out the alerts from the data files.
+type Checker struct {
+	storage storage.Storage
+	clock   clock.Clock
+	window  time.Duration
+	skip    map[string]bool
+}
+
+// New creates a Checker. Milestones due within window (DefaultWindow if
+// zero or less) count as due soon; the skipped tools get no alerts. A nil
+// clock uses the system clock.
+func New(s storage.Storage, c clock.Clock, window time.Duration, skip []string) *Checker {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	ch := &Checker{storage: s, clock: clock.Or(c), window: window, skip: make(map[string]bool)}
+	for _, name := range skip {
+		ch.skip[name] = true
+	}
+	return ch
+}
+
+// Check returns the current alerts. Missing files count as having nothing
+// urgent.
+func (c *Checker) Check(ctx context.Context) (Alerts, error) {
+	var a Alerts
+	now := c.clock.Now()
+	today := clock.Today(c.clock)
+	files := storage.ReadFiles(ctx, c.storage, storage.RemindersFile, storage.StrategyFile)
+
+	if r := files[storage.RemindersFile]; r.Err == nil {
+		rf, err := storage.ParseReminders(r.Content)
+		if err != nil {
+			return a, fmt.Errorf("parsing reminders: %w", err)
+		}
+		for _, rem := range rf.Upcoming {
+			if !rem.Completed && rem.Date.Before(today) {
+				a.OverdueReminders++
+			}
+		}
+	} else if !errors.Is(r.Err, storage.ErrNotFound) {
+		return a, fmt.Errorf("reading reminders: %w", r.Err)
+	}
+
+	if r := files[storage.StrategyFile]; r.Err == nil {
+		strategy, err := storage.ParseStrategy(r.Content)
+		if err != nil {
+			return a, fmt.Errorf("parsing strategy: %w", err)
+		}
+		for _, m := range strategy.ActiveMilestones {
+			if m.Completed || m.Due == nil || m.Due.After(now.Add(c.window)) {
+				continue
+			}
+			a.MilestonesDueSoon = append(a.MilestonesDueSoon, Milestone{ID: m.ID, Text: m.Text, Due: m.Due.Format("2006-01-02")})
+		}
+	} else if !errors.Is(r.Err, storage.ErrNotFound) {
+		return a, fmt.Errorf("reading strategy: %w", r.Err)
+	}
+	return a, nil
+}
+
+// Middleware returns MCP receiving middleware that attaches the alerts to
+// successful tool results, both as an "alerts" field in the structured
+// content and as a line of text, and adds the field to the tools' output
+// schemas so clients that validate results accept it.
+func (c *Checker) Middleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			if err != nil {
+				return result, err
+			}
+			switch method {
+			case "tools/list":
+				if list, ok := result.(*mcp.ListToolsResult); ok {
+					c.addSchemas(list)
+				}
+			case "tools/call":
+				call, ok := req.(*mcp.CallToolRequest)
+				res, isResult := result.(*mcp.CallToolResult)
+				if !ok || !isResult || call.Params == nil || c.skip[call.Params.Name] || res.IsError {
+					break
+				}
+				a, err := c.Check(ctx)
+				if err != nil {
+					slog.WarnContext(ctx, "checking alerts failed", "error", err)
+					break
+				}
+				if !a.Empty() {
+					return attach(res, a), nil
+				}
+			}
+			return result, nil
+		}
+	}
+}
+
+// attach returns res with a added. res itself is left alone.
+func attach(res *mcp.CallToolResult, a Alerts) *mcp.CallToolResult {
+	out := *res
+	var original string
+	if raw, err := json.Marshal(res.StructuredContent); err == nil && res.StructuredContent != nil {
+		var fields map[string]any
+		if json.Unmarshal(raw, &fields) == nil && fields != nil {
+			fields["alerts"] = a
+			original = string(raw)
+			out.StructuredContent = fields
+		}
+	}
+
+	// Content that just repeats the structured content is replaced, so the
+	// two stay alike; otherwise the alerts follow as a line of text
+	if text, ok := singleText(res.Content); ok && original != "" && text == original {
+		withAlerts, _ := json.Marshal(out.StructuredContent)
+		out.Content = []mcp.Content{&mcp.TextContent{Text: string(withAlerts)}}
+	} else {
+		out.Content = append(append([]mcp.Content{}, res.Content...), &mcp.TextContent{Text: a.String()})
+	}
+	return &out
+}
+
+func singleText(content []mcp.Content) (string, bool) {
+	if len(content) != 1 {
+		return "", false
+	}
+	text, ok := content[0].(*mcp.TextContent)
+	if !ok {
+		return "", false
+	}
+	return text.Text, true
+}
+
+// alertsSchema describes the alerts field.
+var alertsSchema = map[string]any{
+	"type":        "object",
+	"description": "Urgent items, attached to any tool's result: the number of overdue reminders and the milestones due soon",
+	"properties": map[string]any{
+		"overdue_reminders": map[string]any{"type": "integer"},
+		"milestones_due_soon": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"id":   map[string]any{"type": "string"},
+					"text": map[string]any{"type": "string"},
+					"due":  map[string]any{"type": "string"},
+				},
+			},
+		},
+	},
+}
+
+// addSchemas adds the alerts field to the output schemas of the tools that
+// get alerts. The server's own tools are copied, not changed.
+func (c *Checker) addSchemas(list *mcp.ListToolsResult) {
+	tools := make([]*mcp.Tool, len(list.Tools))
+	for i, tool := range list.Tools {
+		tools[i] = tool
+		if tool.OutputSchema == nil || c.skip[tool.Name] {
+			continue
+		}
+		raw, err := json.Marshal(tool.OutputSchema)
+		if err != nil {
+			continue
+		}
+		var schema map[string]any
+		if json.Unmarshal(raw, &schema) != nil {
+			continue
+		}
+		properties, ok := schema["properties"].(map[string]any)
+		if !ok {
+			continue
+		}
+		properties["alerts"] = alertsSchema
+		copied := *tool
+		copied.OutputSchema = schema
+		tools[i] = &copied
+	}
+	list.Tools = tools
+}
@@ -0,0 +1,124 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type pingOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// connect serves ping (JSON content) and say (its own text content) behind
+// c's middleware.
+func connect(t *testing.T, c *Checker) *mcp.ClientSession {
+	t.Helper()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "ping"}, func(ctx context.Context, req *mcp.CallToolRequest, in struct{}) (*mcp.CallToolResult, pingOutput, error) {
+		return nil, pingOutput{Success: true, Message: "pong"}, nil
+	})
+	mcp.AddTool(server, &mcp.Tool{Name: "say"}, func(ctx context.Context, req *mcp.CallToolRequest, in struct{}) (*mcp.CallToolResult, pingOutput, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "hello"}}}, pingOutput{Success: true, Message: "hello"}, nil
+	})
+	server.AddReceivingMiddleware(c.Middleware())
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+func call(t *testing.T, session *mcp.ClientSession, name string) (map[string]any, []string) {
+	t.Helper()
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	raw, _ := json.Marshal(res.StructuredContent)
+	json.Unmarshal(raw, &fields)
+	var texts []string
+	for _, c := range res.Content {
+		texts = append(texts, c.(*mcp.TextContent).Text)
+	}
+	return fields, texts
+}
+
+func TestMiddleware(t *testing.T) {
+	mem := storage.NewMemoryStorage(map[string]string{
+		storage.RemindersFile: "# Reminders\n\n## Upcoming\n- 2026-02-01: Renew passport {id:rm_aaaa11}\n- 2026-02-09: Call the bank {id:rm_bbbb22}\n- 2026-03-01: Book dentist {id:rm_cccc33}\n",
+		storage.StrategyFile:  "# My Plan\n\n## Current Phase\nLaunch\n\n## Active Milestones\n- [ ] Beta — Due: 2026-02-11 {id:ms_dddd44}\n- [ ] GA — Due: 2026-03-01 {id:ms_eeee55}\n\n## Notes\n",
+	}, nil)
+	c := New(mem, clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)), 0, []string{"say"})
+
+	a, err := c.Check(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.OverdueReminders != 2 || len(a.MilestonesDueSoon) != 1 || a.MilestonesDueSoon[0].ID != "ms_dddd44" {
+		t.Fatalf("Check() = %+v", a)
+	}
+
+	session := connect(t, c)
+	fields, texts := call(t, session, "ping")
+	alerts, _ := fields["alerts"].(map[string]any)
+	if fields["message"] != "pong" || alerts["overdue_reminders"] != float64(2) {
+		t.Errorf("unexpected ping result %v", fields)
+	}
+	if len(texts) != 1 || !strings.Contains(texts[0], `"alerts"`) {
+		t.Errorf("expected the JSON content to carry the alerts, got %q", texts)
+	}
+
+	// Skipped tools are left alone
+	if fields, texts := call(t, session, "say"); fields["alerts"] != nil || len(texts) != 1 {
+		t.Errorf("unexpected say result %v %q", fields, texts)
+	}
+
+	// Listed output schemas allow the field
+	tools, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tool := range tools.Tools {
+		raw, _ := json.Marshal(tool.OutputSchema)
+		if has := strings.Contains(string(raw), `"alerts"`); has != (tool.Name == "ping") {
+			t.Errorf("%s output schema has alerts = %v", tool.Name, has)
+		}
+	}
+
+	// Nothing urgent, nothing added
+	c.clock = clock.NewFake(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC))
+	if fields, _ := call(t, session, "ping"); fields["alerts"] != nil {
+		t.Errorf("unexpected alerts %v", fields["alerts"])
+	}
+}
+
+func TestAttach_TextContent(t *testing.T) {
+	res := &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: "Added todo"}},
+		StructuredContent: json.RawMessage(`{"success":true}`),
+	}
+	got := attach(res, Alerts{OverdueReminders: 1, MilestonesDueSoon: []Milestone{{ID: "ms_dddd44", Text: "Beta", Due: "2026-02-11"}}})
+	if len(res.Content) != 1 {
+		t.Error("attach changed the original result")
+	}
+	want := `Alerts: 1 overdue reminder; milestone "Beta" due 2026-02-11 (id ms_dddd44)`
+	if len(got.Content) != 2 || got.Content[1].(*mcp.TextContent).Text != want {
+		t.Errorf("unexpected content %+v", got.Content)
+	}
+}
@@ -0,0 +1,279 @@
+// Package analytics reconstructs historic completion data from the data
+// repository's commit history.
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// WeekCounts holds the number of items completed in one week, by type.
+type WeekCounts struct {
+	WeekStart  string `json:"week_start"` // Monday, YYYY-MM-DD
+	Todos      int    `json:"todos"`
+	Milestones int    `json:"milestones"`
+	Reminders  int    `json:"reminders"`
+	Reading    int    `json:"reading"`
+}
+
+// Total returns the total number of completions in the week.
+func (w WeekCounts) Total() int {
+	return w.Todos + w.Milestones + w.Reminders + w.Reading
+}
+
+// Backfill walks the data repo history once and caches weekly completion
+// counts in dataDir/analytics_backfill.json.
+type Backfill struct {
+	history    storage.History
+	filePath   string
+	maxCommits int
+	clock      clock.Clock
+
+	mu    sync.RWMutex
+	state backfillState
+}
+
+// backfillState is the cached result of a backfill, also the on-disk format.
+type backfillState struct {
+	Weeks map[string]*WeekCounts `json:"weeks"`
+	// Seen records completed items already counted ("kind|text[|completed]"), so an item
+	// that stays completed across many commits is counted once.
+	Seen        map[string]bool `json:"seen"`
+	Commits     int             `json:"commits_scanned"`
+	CompletedAt time.Time       `json:"completed_at,omitempty"`
+}
+
+// NewBackfill creates a backfill over the given history. If dataDir is empty
+// the result is not cached and is recomputed on every Run. c stamps the
+// completed backfill; nil uses the system clock.
+func NewBackfill(history storage.History, dataDir string, maxCommits int, c clock.Clock) *Backfill {
+	b := &Backfill{
+		history:    history,
+		maxCommits: maxCommits,
+		clock:      clock.Or(c),
+		state:      newState(),
+	}
+	if dataDir != "" {
+		b.filePath = filepath.Join(dataDir, "analytics_backfill.json")
+	}
+	return b
+}
+
+func newState() backfillState {
+	return backfillState{
+		Weeks: make(map[string]*WeekCounts),
+		Seen:  make(map[string]bool),
+	}
+}
+
+// Done reports whether a backfill has completed (now or in a previous run).
+func (b *Backfill) Done() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return !b.state.CompletedAt.IsZero()
+}
+
+// Weeks returns the reconstructed weekly counts, oldest first.
+func (b *Backfill) Weeks() []WeekCounts {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	weeks := make([]WeekCounts, 0, len(b.state.Weeks))
+	for _, w := range b.state.Weeks {
+		weeks = append(weeks, *w)
+	}
+	sort.Slice(weeks, func(i, j int) bool { return weeks[i].WeekStart < weeks[j].WeekStart })
+	return weeks
+}
+
+// Run loads a cached backfill if one exists, otherwise walks the history of
+// every data file and caches the result.
+func (b *Backfill) Run(ctx context.Context) error {
+	if loaded, err := b.load(); err != nil {
+		slog.Warn("could not load analytics backfill cache", "error", err)
+	} else if loaded {
+		slog.Info("analytics backfill loaded from cache", "weeks", len(b.state.Weeks))
+		return nil
+	}
+
+	state := newState()
+	for _, file := range []string{storage.TodosFile, storage.StrategyFile, storage.RemindersFile, storage.ReadingListFile} {
+		commits, err := b.history.ListCommits(ctx, file, b.maxCommits)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return fmt.Errorf("listing commits for %s: %w", file, err)
+		}
+
+		// Walk oldest first so items without a completed date are attributed
+		// to the first commit in which they appear completed.
+		for i := len(commits) - 1; i >= 0; i-- {
+			c := commits[i]
+			content, err := b.history.ReadFileAt(ctx, file, c.SHA)
+			if err != nil {
+				if errors.Is(err, storage.ErrNotFound) {
+					continue // file deleted in this commit
+				}
+				return fmt.Errorf("reading %s at %s: %w", file, c.SHA, err)
+			}
+			state.Commits++
+			collect(&state, file, content, c.Date)
+		}
+	}
+	state.CompletedAt = b.clock.Now().UTC()
+
+	b.mu.Lock()
+	b.state = state
+	b.mu.Unlock()
+
+	slog.Info("analytics backfill complete", "commits", state.Commits, "weeks", len(state.Weeks))
+	return b.save()
+}
+
+// collect counts newly seen completions in one version of a data file.
+func collect(state *backfillState, file, content string, commitDate time.Time) {
+	switch file {
+	case storage.TodosFile:
+		tf, err := storage.ParseTodos(content)
+		if err != nil {
+			return
+		}
+		for _, t := range tf.Completed {
+			count(state, "todo", t.Text, t.CompletedAt, commitDate)
+		}
+	case storage.StrategyFile:
+		s, err := storage.ParseStrategy(content)
+		if err != nil {
+			return
+		}
+		for _, m := range s.CompletedMilestones {
+			count(state, "milestone", m.Text, m.CompletedAt, commitDate)
+		}
+	case storage.RemindersFile:
+		rf, err := storage.ParseReminders(content)
+		if err != nil {
+			return
+		}
+		for _, r := range rf.Completed {
+			count(state, "reminder", r.Text, r.CompletedAt, commitDate)
+		}
+	case storage.ReadingListFile:
+		rl, err := storage.ParseReadingList(content)
+		if err != nil {
+			return
+		}
+		for _, r := range rl.Read {
+			count(state, "reading", r.URL, r.ReadAt, commitDate)
+		}
+	}
+}
+
+// count records a completion once per item. Items are keyed by text (and
+// completed date when present) rather than ID: the parser assigns a fresh
+// random ID to lines without one, so IDs aren't stable across old commits.
+func count(state *backfillState, kind, text string, completedAt *time.Time, commitDate time.Time) {
+	seenKey := kind + "|" + text
+	if completedAt != nil {
+		seenKey += "|" + completedAt.Format("2006-01-02")
+	}
+	if state.Seen[seenKey] {
+		return
+	}
+	state.Seen[seenKey] = true
+
+	when := commitDate
+	if completedAt != nil {
+		when = *completedAt
+	}
+	week := WeekStart(when).Format("2006-01-02")
+
+	w, ok := state.Weeks[week]
+	if !ok {
+		w = &WeekCounts{WeekStart: week}
+		state.Weeks[week] = w
+	}
+	switch kind {
+	case "todo":
+		w.Todos++
+	case "milestone":
+		w.Milestones++
+	case "reminder":
+		w.Reminders++
+	case "reading":
+		w.Reading++
+	}
+}
+
+// WeekStart returns the Monday of t's week, in t's timezone, as midnight UTC
+// (see clock.Date).
+func WeekStart(t time.Time) time.Time {
+	t = clock.Date(t)
+	weekday := int(t.Weekday())
+	if weekday == 0 {
+		weekday = 7 // Sunday becomes 7
+	}
+	monday := t.AddDate(0, 0, -(weekday - 1))
+	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// load reads a completed backfill from disk. Returns false if there is none.
+func (b *Backfill) load() (bool, error) {
+	if b.filePath == "" {
+		return false, nil
+	}
+	data, err := os.ReadFile(b.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	state := newState()
+	if err := json.Unmarshal(data, &state); err != nil {
+		return false, err
+	}
+	if state.CompletedAt.IsZero() {
+		return false, nil
+	}
+
+	b.mu.Lock()
+	b.state = state
+	b.mu.Unlock()
+	return true, nil
+}
+
+// save writes the backfill to disk atomically.
+func (b *Backfill) save() error {
+	if b.filePath == "" {
+		return nil
+	}
+
+	b.mu.RLock()
+	data, err := json.MarshalIndent(b.state, "", "  ")
+	b.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.filePath), 0700); err != nil {
+		return err
+	}
+	tmpFile := b.filePath + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, b.filePath)
+}
@@ -0,0 +1,159 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// fakeHistory serves fixed file versions keyed by path then SHA.
+type fakeHistory struct {
+	commits  map[string][]storage.Commit // newest first
+	versions map[string]map[string]string
+}
+
+func (f *fakeHistory) ListCommits(ctx context.Context, path string, limit int) ([]storage.Commit, error) {
+	commits, ok := f.commits[path]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return commits, nil
+}
+
+func (f *fakeHistory) ReadFileAt(ctx context.Context, path string, ref string) (string, error) {
+	content, ok := f.versions[path][ref]
+	if !ok {
+		return "", storage.ErrNotFound
+	}
+	return content, nil
+}
+
+func TestBackfill_Run(t *testing.T) {
+	history := &fakeHistory{
+		commits: map[string][]storage.Commit{
+			"todos.md": {
+				{SHA: "c3", Date: time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)},
+				{SHA: "c2", Date: time.Date(2025, 1, 14, 9, 0, 0, 0, time.UTC)},
+				{SHA: "c1", Date: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)},
+			},
+		},
+		versions: map[string]map[string]string{
+			"todos.md": {
+				"c1": "# Active Todos\n\n## Normal\n- [ ] Old task\n\n# Completed\n",
+				// Completed without a date - attributed to this commit's week
+				"c2": "# Active Todos\n\n# Completed\n- [x] Old task\n",
+				// Old task removed, new one completed with an explicit date
+				"c3": "# Active Todos\n\n# Completed\n- [x] New task {id:abcd1234,completed:2025-01-21}\n",
+			},
+		},
+	}
+
+	b := NewBackfill(history, "", 0, nil)
+	if err := b.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !b.Done() {
+		t.Error("expected backfill to be done")
+	}
+
+	weeks := b.Weeks()
+	if len(weeks) != 2 {
+		t.Fatalf("expected 2 weeks, got %d: %+v", len(weeks), weeks)
+	}
+	if weeks[0].WeekStart != "2025-01-13" || weeks[0].Todos != 1 {
+		t.Errorf("unexpected first week: %+v", weeks[0])
+	}
+	if weeks[1].WeekStart != "2025-01-20" || weeks[1].Todos != 1 {
+		t.Errorf("unexpected second week: %+v", weeks[1])
+	}
+}
+
+func TestBackfill_Cached(t *testing.T) {
+	dir := t.TempDir()
+	history := &fakeHistory{
+		commits: map[string][]storage.Commit{
+			"todos.md": {{SHA: "c1", Date: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)}},
+		},
+		versions: map[string]map[string]string{
+			"todos.md": {"c1": "# Active Todos\n\n# Completed\n- [x] Task\n"},
+		},
+	}
+
+	if err := NewBackfill(history, dir, 0, nil).Run(context.Background()); err != nil {
+		t.Fatalf("first Run() error = %v", err)
+	}
+
+	// A second backfill must use the cache rather than the (now empty) history
+	cached := NewBackfill(&fakeHistory{}, dir, 0, nil)
+	if err := cached.Run(context.Background()); err != nil {
+		t.Fatalf("cached Run() error = %v", err)
+	}
+	if weeks := cached.Weeks(); len(weeks) != 1 || weeks[0].Todos != 1 {
+		t.Errorf("expected cached week, got %+v", weeks)
+	}
+}
+
+func TestBackfill_RunTwice(t *testing.T) {
+	// The same two completed todos in every commit: one dated, one not
+	completed := "# Active Todos\n\n# Completed\n- [x] Dated {id:abcd1234,completed:2025-01-07}\n- [x] Undated\n"
+	history := &fakeHistory{
+		commits: map[string][]storage.Commit{
+			"todos.md": {
+				{SHA: "c3", Date: time.Date(2025, 1, 22, 9, 0, 0, 0, time.UTC)},
+				{SHA: "c2", Date: time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)},
+				{SHA: "c1", Date: time.Date(2025, 1, 8, 9, 0, 0, 0, time.UTC)},
+			},
+		},
+		versions: map[string]map[string]string{
+			"todos.md": {"c1": completed, "c2": completed, "c3": completed},
+		},
+	}
+	now := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	total := func(b *Backfill) int {
+		n := 0
+		for _, w := range b.Weeks() {
+			n += w.Total()
+		}
+		return n
+	}
+
+	// Re-running over the same history recounts from scratch
+	b := NewBackfill(history, "", 0, clock.NewFake(now))
+	for run := 1; run <= 2; run++ {
+		if err := b.Run(context.Background()); err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+		if got := total(b); got != 2 {
+			t.Errorf("run %d counted %d completions, want 2: %+v", run, got, b.Weeks())
+		}
+	}
+	if weeks := b.Weeks(); len(weeks) != 1 || weeks[0].WeekStart != "2025-01-06" {
+		t.Errorf("weeks = %+v, want both in the week of 2025-01-06", weeks)
+	}
+
+	// and so does a second backfill reading the first one's cache
+	dir := t.TempDir()
+	for run := 1; run <= 2; run++ {
+		b := NewBackfill(history, dir, 0, clock.NewFake(now))
+		if err := b.Run(context.Background()); err != nil {
+			t.Fatalf("cached run %d: %v", run, err)
+		}
+		if got := total(b); got != 2 {
+			t.Errorf("cached run %d counted %d completions, want 2", run, got)
+		}
+		if !b.state.CompletedAt.Equal(now) {
+			t.Errorf("cached run %d completed at %s, want the clock's %s", run, b.state.CompletedAt, now)
+		}
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	sunday := time.Date(2025, 1, 19, 23, 0, 0, 0, time.UTC)
+	if got := WeekStart(sunday).Format("2006-01-02"); got != "2025-01-13" {
+		t.Errorf("WeekStart(Sunday) = %s, expected 2025-01-13", got)
+	}
+}
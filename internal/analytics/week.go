@@ -0,0 +1,51 @@
+package analytics
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+// isoWeekPattern matches an ISO week such as 2026-W06.
+var isoWeekPattern = regexp.MustCompile(`^(\d{4})-[Ww](\d{1,2})$`)
+
+// ParseWeek returns the Monday (UTC) of an ISO week ("2026-W06") or of the
+// week containing a date ("2026-02-04").
+func ParseWeek(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if m := isoWeekPattern.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		week, _ := strconv.Atoi(m[2])
+		// January 4th is always in week 1
+		start := WeekStart(time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)).AddDate(0, 0, 7*(week-1))
+		if y, w := start.ISOWeek(); week < 1 || y != year || w != week {
+			return time.Time{}, fmt.Errorf("no week %d in %d", week, year)
+		}
+		return start, nil
+	}
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return WeekStart(d), nil
+}
+
+// ISOWeek formats t's ISO week as 2026-W06.
+func ISOWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// AsOf is the moment to report the week starting at weekStart as of: now
+// for the current week, and the week's last second for past weeks. The
+// week is current if now's date, in now's timezone, falls in it.
+func AsOf(weekStart, now time.Time) time.Time {
+	if weekEnd := weekStart.AddDate(0, 0, 7); !clock.Date(now).Before(weekEnd) {
+		return weekEnd.Add(-time.Second)
+	}
+	return now
+}
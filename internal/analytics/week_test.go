@@ -0,0 +1,72 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWeek(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"2026-W06", "2026-02-02", false},
+		{"2026-w1", "2025-12-29", false},
+		{"2025-W53", "", true},
+		{"2026-W53", "2026-12-28", false},
+		{"2020-W53", "2020-12-28", false},
+		{"2026-W00", "", true},
+		{"2026-02-08", "2026-02-02", false},
+		{"next week", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseWeek(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseWeek(%q) = %v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWeek(%q) error = %v", tt.input, err)
+			}
+			if got.Format("2006-01-02") != tt.want {
+				t.Errorf("ParseWeek(%q) = %s, want %s", tt.input, got.Format("2006-01-02"), tt.want)
+			}
+		})
+	}
+}
+
+func TestISOWeek(t *testing.T) {
+	if got := ISOWeek(time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC)); got != "2026-W06" {
+		t.Errorf("ISOWeek() = %s, want 2026-W06", got)
+	}
+	// Early January can belong to the previous ISO year
+	if got := ISOWeek(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)); got != "2026-W53" {
+		t.Errorf("ISOWeek() = %s, want 2026-W53", got)
+	}
+}
+
+func TestAsOf(t *testing.T) {
+	weekStart := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 2, 4, 10, 0, 0, 0, time.UTC)
+	if got := AsOf(weekStart, now); !got.Equal(now) {
+		t.Errorf("AsOf(current week) = %v, want %v", got, now)
+	}
+	if got, want := AsOf(weekStart.AddDate(0, 0, -7), now), weekStart.Add(-time.Second); !got.Equal(want) {
+		t.Errorf("AsOf(last week) = %v, want %v", got, want)
+	}
+
+	// Early Monday in Auckland is still Sunday in UTC, but the week has
+	// rolled over there
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatal(err)
+	}
+	monday := time.Date(2026, 2, 9, 1, 0, 0, 0, auckland)
+	if got, want := AsOf(weekStart, monday), weekStart.AddDate(0, 0, 7).Add(-time.Second); !got.Equal(want) {
+		t.Errorf("AsOf(last week) on Monday in Auckland = %v, want %v", got, want)
+	}
+}
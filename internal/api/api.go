@@ -0,0 +1,306 @@
+// Package api serves a JSON REST API over the MCP tools, for shortcuts,
+// scripts and widgets that don't speak MCP. Every request is a tool call
+// through an in-process session, so it shares the tools' validation,
+// middleware and storage handling.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Prefix is the path the API is served under.
+const Prefix = "/api/v1"
+
+// maxBody bounds request bodies.
+const maxBody = 1 << 20
+
+// Session calls tools. *mcp.ClientSession implements it.
+type Session interface {
+	CallTool(ctx context.Context, params *mcp.CallToolParams) (*mcp.CallToolResult, error)
+	ListTools(ctx context.Context, params *mcp.ListToolsParams) (*mcp.ListToolsResult, error)
+}
+
+// route maps a method and path to a tool. A {id} in the path is passed as
+// the tool's "id" argument; args are fixed arguments added to every call.
+type route struct {
+	method string
+	path   string
+	tool   string
+	args   map[string]any
+	// created responds 201 instead of 200.
+	created bool
+}
+
+// routes lists the API, grouped by resource.
+var routes = []route{
+	{method: http.MethodGet, path: "/dashboard", tool: "get_dashboard"},
+	{method: http.MethodGet, path: "/today", tool: "get_today"},
+
+	{method: http.MethodGet, path: "/todos", tool: "list_todos"},
+	{method: http.MethodPost, path: "/todos", tool: "add_todo", created: true},
+	{method: http.MethodPatch, path: "/todos/{id}", tool: "edit_todo"},
+	{method: http.MethodDelete, path: "/todos/{id}", tool: "delete_todo"},
+	{method: http.MethodPost, path: "/todos/{id}/complete", tool: "complete_todo"},
+	{method: http.MethodPost, path: "/todos/{id}/move", tool: "reorder_todo"},
+
+	{method: http.MethodGet, path: "/reminders", tool: "list_reminders"},
+	{method: http.MethodPost, path: "/reminders", tool: "set_reminder", created: true},
+	{method: http.MethodPatch, path: "/reminders/{id}", tool: "edit_reminder"},
+	{method: http.MethodDelete, path: "/reminders/{id}", tool: "delete_reminder"},
+	{method: http.MethodPost, path: "/reminders/{id}/complete", tool: "complete_reminder"},
+
+	{method: http.MethodGet, path: "/reading-list", tool: "list_reading_list"},
+	{method: http.MethodPost, path: "/reading-list", tool: "add_to_reading_list", created: true},
+	{method: http.MethodPatch, path: "/reading-list/{id}", tool: "edit_reading_item"},
+	{method: http.MethodDelete, path: "/reading-list/{id}", tool: "delete_reading_item"},
+	{method: http.MethodPost, path: "/reading-list/{id}/read", tool: "mark_read"},
+
+	{method: http.MethodGet, path: "/milestones", tool: "get_milestones"},
+	{method: http.MethodPatch, path: "/milestones/{id}", tool: "edit_milestone"},
+	{method: http.MethodPost, path: "/milestones/{id}/complete", tool: "update_milestone", args: map[string]any{"complete": true}},
+	{method: http.MethodPost, path: "/milestones/{id}/reopen", tool: "update_milestone", args: map[string]any{"complete": false}},
+
+	{method: http.MethodGet, path: "/notes", tool: "list_notes"},
+	{method: http.MethodPost, path: "/notes", tool: "add_note", created: true},
+	{method: http.MethodDelete, path: "/notes/{id}", tool: "delete_note"},
+
+	{method: http.MethodGet, path: "/journal", tool: "list_journal"},
+	{method: http.MethodPost, path: "/journal", tool: "add_journal_entry", created: true},
+}
+
+// API serves the REST routes. Authentication is left to the caller's
+// middleware.
+type API struct {
+	session Session
+
+	// propertyTypes holds the JSON schema type of each tool argument, used
+	// to convert query parameters. Loaded on first use.
+	mu            sync.Mutex
+	propertyTypes map[string]map[string]string
+}
+
+// New creates an API that calls tools through session.
+func New(session Session) *API {
+	return &API{session: session}
+}
+
+// Handler returns the API's routes, to be mounted at Prefix.
+func (a *API) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, rt := range routes {
+		mux.Handle(rt.method+" "+Prefix+rt.path, a.handle(rt))
+	}
+	mux.HandleFunc(Prefix+"/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "Unknown API endpoint")
+	})
+	return mux
+}
+
+func (a *API) handle(rt route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		args, err := a.arguments(r, rt)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := a.session.CallTool(r.Context(), &mcp.CallToolParams{Name: rt.tool, Arguments: args})
+		if err != nil {
+			slog.Warn("api tool call failed", "tool", rt.tool, "error", err)
+			writeError(w, http.StatusInternalServerError, "Something went wrong. Please try again.")
+			return
+		}
+		if res.IsError {
+			// Input validation by the SDK, or a handler error
+			writeError(w, http.StatusBadRequest, toolErrorText(res))
+			return
+		}
+
+		var out struct {
+			Success bool            `json:"success"`
+			Message string          `json:"message"`
+			Result  json.RawMessage `json:"result"`
+			Item    json.RawMessage `json:"item"`
+		}
+		raw, _ := json.Marshal(res.StructuredContent)
+		if err := json.Unmarshal(raw, &out); err != nil {
+			writeError(w, http.StatusInternalServerError, "Unexpected tool response")
+			return
+		}
+		if !out.Success {
+			writeError(w, failureStatus(out.Message), out.Message)
+			return
+		}
+
+		status := http.StatusOK
+		if rt.created {
+			status = http.StatusCreated
+		}
+		writeJSON(w, status, responseBody(out.Result, out.Item, out.Message))
+	})
+}
+
+// arguments builds the tool arguments from the JSON body (POST and PATCH),
+// the query string (GET and DELETE), the path ID, and the route's fixed
+// arguments, in increasing precedence.
+func (a *API) arguments(r *http.Request, rt route) (map[string]any, error) {
+	args := map[string]any{}
+	switch r.Method {
+	case http.MethodPost, http.MethodPatch:
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBody))
+		if err != nil {
+			return nil, errors.New("Failed to read request body")
+		}
+		if len(strings.TrimSpace(string(body))) > 0 {
+			if err := json.Unmarshal(body, &args); err != nil {
+				return nil, errors.New("Request body must be a JSON object")
+			}
+		}
+	default:
+		types := a.types(r.Context(), rt.tool)
+		for key, values := range r.URL.Query() {
+			args[key] = convertQuery(values[len(values)-1], types[key])
+		}
+	}
+	if id := r.PathValue("id"); id != "" {
+		args["id"] = id
+	}
+	for key, value := range rt.args {
+		args[key] = value
+	}
+	return args, nil
+}
+
+// types returns the argument types of tool, listing the tools once.
+func (a *API) types(ctx context.Context, tool string) map[string]string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.propertyTypes == nil {
+		res, err := a.session.ListTools(ctx, &mcp.ListToolsParams{})
+		if err != nil {
+			slog.Warn("api could not list tools", "error", err)
+			return nil
+		}
+		a.propertyTypes = make(map[string]map[string]string, len(res.Tools))
+		for _, t := range res.Tools {
+			a.propertyTypes[t.Name] = schemaTypes(t.InputSchema)
+		}
+	}
+	return a.propertyTypes[tool]
+}
+
+// schemaTypes extracts the "type" of each property of an object schema.
+func schemaTypes(schema any) map[string]string {
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return nil
+	}
+	var s struct {
+		Properties map[string]struct {
+			Type any `json:"type"`
+		} `json:"properties"`
+	}
+	if json.Unmarshal(raw, &s) != nil {
+		return nil
+	}
+	types := make(map[string]string, len(s.Properties))
+	for name, p := range s.Properties {
+		switch t := p.Type.(type) {
+		case string:
+			types[name] = t
+		case []any:
+			// e.g. ["null", "string"] for optional pointer fields
+			for _, v := range t {
+				if v, ok := v.(string); ok && v != "null" {
+					types[name] = v
+				}
+			}
+		}
+	}
+	return types
+}
+
+// convertQuery converts a query parameter to the argument's schema type,
+// leaving it a string if it doesn't parse.
+func convertQuery(value, typ string) any {
+	switch typ {
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case "integer":
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	case "number":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "array":
+		return strings.Split(value, ",")
+	}
+	return value
+}
+
+// responseBody is a tool's structured result or changed item if it has one;
+// otherwise its message, decoded if it is JSON.
+func responseBody(result, item json.RawMessage, message string) any {
+	for _, body := range []json.RawMessage{result, item} {
+		if len(body) > 0 && string(body) != "null" {
+			return body
+		}
+	}
+	var decoded any
+	if json.Unmarshal([]byte(message), &decoded) == nil {
+		if _, ok := decoded.(map[string]any); ok {
+			return json.RawMessage(message)
+		}
+		if _, ok := decoded.([]any); ok {
+			return json.RawMessage(message)
+		}
+	}
+	return map[string]string{"message": message}
+}
+
+// failureStatus maps a tool's failure message to an HTTP status.
+func failureStatus(message string) int {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "modified by another process") || strings.Contains(lower, "has changed since"):
+		return http.StatusConflict
+	case strings.HasPrefix(lower, "no ") && strings.Contains(lower, " found"):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// toolErrorText returns the text of an error result.
+func toolErrorText(res *mcp.CallToolResult) string {
+	for _, c := range res.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return "Invalid request"
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
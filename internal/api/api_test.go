@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type listInput struct {
+	Priority         string `json:"priority,omitempty"`
+	IncludeCompleted bool   `json:"include_completed,omitempty"`
+}
+
+type addInput struct {
+	Text     string `json:"text"`
+	Priority string `json:"priority,omitempty"`
+}
+
+type idInput struct {
+	ID       string `json:"id"`
+	Complete bool   `json:"complete,omitempty"`
+}
+
+type output struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Result  map[string]any `json:"result,omitempty"`
+}
+
+// connect serves a few stand-in tools over an in-memory session.
+func connect(t *testing.T) *mcp.ClientSession {
+	t.Helper()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "list_todos"}, func(ctx context.Context, req *mcp.CallToolRequest, in listInput) (*mcp.CallToolResult, output, error) {
+		return nil, output{Success: true, Message: "listed", Result: map[string]any{
+			"priority": in.Priority, "include_completed": in.IncludeCompleted,
+		}}, nil
+	})
+	mcp.AddTool(server, &mcp.Tool{Name: "add_todo"}, func(ctx context.Context, req *mcp.CallToolRequest, in addInput) (*mcp.CallToolResult, output, error) {
+		if in.Text == "" {
+			return nil, output{Success: false, Message: "Todo text cannot be empty."}, nil
+		}
+		item, _ := json.Marshal(map[string]string{"id": "abcd1234", "text": in.Text, "priority": in.Priority})
+		return nil, output{Success: true, Message: string(item)}, nil
+	})
+	mcp.AddTool(server, &mcp.Tool{Name: "delete_todo"}, func(ctx context.Context, req *mcp.CallToolRequest, in idInput) (*mcp.CallToolResult, output, error) {
+		return nil, output{Success: false, Message: fmt.Sprintf("No todo found with id %q", in.ID)}, nil
+	})
+	mcp.AddTool(server, &mcp.Tool{Name: "update_milestone"}, func(ctx context.Context, req *mcp.CallToolRequest, in idInput) (*mcp.CallToolResult, output, error) {
+		return nil, output{Success: true, Message: fmt.Sprintf("Milestone %s complete=%v", in.ID, in.Complete)}, nil
+	})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(context.Background(), serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "api-test"}, nil).Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+func TestAPI(t *testing.T) {
+	handler := New(connect(t)).Handler()
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		want   string
+	}{
+		{"list with typed query", http.MethodGet, "/api/v1/todos?priority=high&include_completed=true", "", http.StatusOK,
+			`{"include_completed":true,"priority":"high"}`},
+		{"create", http.MethodPost, "/api/v1/todos", `{"text":"Ship it","priority":"high"}`, http.StatusCreated,
+			`{"id":"abcd1234","priority":"high","text":"Ship it"}`},
+		{"tool failure", http.MethodPost, "/api/v1/todos", `{"text":""}`, http.StatusBadRequest,
+			`{"error":"Todo text cannot be empty."}`},
+		{"bad body", http.MethodPost, "/api/v1/todos", `[1]`, http.StatusBadRequest,
+			`{"error":"Request body must be a JSON object"}`},
+		{"not found", http.MethodDelete, "/api/v1/todos/ffff0000", "", http.StatusNotFound,
+			`{"error":"No todo found with id \"ffff0000\""}`},
+		{"fixed args and path id", http.MethodPost, "/api/v1/milestones/aaaa1111/complete", "", http.StatusOK,
+			`{"message":"Milestone aaaa1111 complete=true"}`},
+		{"unknown endpoint", http.MethodGet, "/api/v1/nope", "", http.StatusNotFound,
+			`{"error":"Unknown API endpoint"}`},
+		{"unsupported method", http.MethodPut, "/api/v1/todos", "", http.StatusNotFound,
+			`{"error":"Unknown API endpoint"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+			if rec.Code != tt.status {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			var got, want any
+			json.Unmarshal(rec.Body.Bytes(), &got)
+			json.Unmarshal([]byte(tt.want), &want)
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("body %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestConvertQuery(t *testing.T) {
+	tests := []struct {
+		value, typ string
+		want       any
+	}{
+		{"true", "boolean", true},
+		{"yes", "boolean", "yes"},
+		{"5", "integer", 5},
+		{"5", "string", "5"},
+		{"1.5", "number", 1.5},
+		{"a,b", "array", []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		if got := convertQuery(tt.value, tt.typ); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("convertQuery(%q, %q) = %v, want %v", tt.value, tt.typ, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Package assets embeds the files the server needs at runtime, so the binary
+// runs standalone (e.g. from a scratch container) without anything on disk.
+package assets
+
+import (
+	"embed"
+	"html/template"
+)
+
+// FS holds the HTML templates and default data file templates.
+//
+//go:embed authorize.html defaults
+var FS embed.FS
+
+// AuthorizeTemplate is the OAuth authorize page.
+var AuthorizeTemplate = template.Must(template.ParseFS(FS, "authorize.html"))
+
+// DefaultDataFile returns the starter content for a data file (e.g.
+// "todos.md"), for bootstrapping an empty data repository.
+func DefaultDataFile(name string) (string, error) {
+	data, err := FS.ReadFile("defaults/" + name)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,67 @@
+package assets
+
+import (
+	"io"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestAuthorizeTemplate(t *testing.T) {
+	data := map[string]string{"ClientName": "Test Client", "PinRequired": "false"}
+	if err := AuthorizeTemplate.Execute(io.Discard, data); err != nil {
+		t.Fatalf("executing authorize template: %v", err)
+	}
+}
+
+func TestDefaultDataFiles(t *testing.T) {
+	for _, name := range []string{"todos.md", "strategy.md", "reading-list.md", "reminders.md", "journal.md", "notes.md", "projects.md", "phase-templates.md", "timelog.md", "feeds.md", "goals.md", "focus.md", "trash.md", "preferences.md"} {
+		if _, err := DefaultDataFile(name); err != nil {
+			t.Errorf("missing default %s: %v", name, err)
+		}
+	}
+
+	// Defaults must parse to empty files, and match what the serializers write
+	todos, _ := DefaultDataFile("todos.md")
+	tf, err := storage.ParseTodos(todos)
+	if err != nil || len(tf.Active)+len(tf.Completed) != 0 {
+		t.Errorf("default todos.md should parse empty: %+v, %v", tf, err)
+	}
+	reading, _ := DefaultDataFile("reading-list.md")
+	rl, _ := storage.ParseReadingList(reading)
+	if got := storage.SerializeReadingList(rl); got != reading {
+		t.Errorf("default reading-list.md differs from serialized form:\n%q\n%q", reading, got)
+	}
+	notes, _ := DefaultDataFile("notes.md")
+	nf, _ := storage.ParseNotes(notes)
+	if got := storage.SerializeNotes(nf); got != notes {
+		t.Errorf("default notes.md differs from serialized form:\n%q\n%q", notes, got)
+	}
+	projects, _ := DefaultDataFile("projects.md")
+	if p, _ := storage.ParseProjects(projects); len(p) != 0 {
+		t.Errorf("default projects.md should register no projects, got %+v", p)
+	}
+	feeds, _ := DefaultDataFile("feeds.md")
+	if f, _ := storage.ParseFeeds(feeds); len(f) != 0 {
+		t.Errorf("default feeds.md should list no feeds, got %+v", f)
+	}
+	goals, _ := DefaultDataFile("goals.md")
+	g, _ := storage.ParseGoals(goals)
+	if got := storage.SerializeGoals(g); got != goals || !g.Contribution.IsZero() {
+		t.Errorf("default goals.md should set no goals and match its serialized form:\n%q\n%q", goals, got)
+	}
+	focus, _ := DefaultDataFile("focus.md")
+	f, _ := storage.ParseFocus(focus)
+	if got := storage.SerializeFocus(f); got != focus || len(f.Items) != 0 {
+		t.Errorf("default focus.md should set no focus and match its serialized form:\n%q\n%q", focus, got)
+	}
+	trash, _ := DefaultDataFile("trash.md")
+	tr, _ := storage.ParseTrash(trash)
+	if got := storage.SerializeTrash(tr); got != trash || len(tr.Items) != 0 {
+		t.Errorf("default trash.md should be empty and match its serialized form:\n%q\n%q", trash, got)
+	}
+	templates, _ := DefaultDataFile("phase-templates.md")
+	if tmpl, _ := storage.ParsePhaseTemplates(templates); len(tmpl) != 0 {
+		t.Errorf("default phase-templates.md should define no templates, got %+v", tmpl)
+	}
+}
@@ -0,0 +1,109 @@
+// Package attribution records which tool and MCP client made each change, so
+// commit messages in the data repo can say which assistant did what.
+//
+// Middleware stores the tool name and the client's self-reported name in the
+// tool call's context; WrapStorage renders each commit message from a
+// template using them.
+package attribution
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/internal/logging"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Info identifies the origin of a change.
+type Info struct {
+	// Tool is the MCP tool being called.
+	Tool string
+	// Client is the MCP client's name from its initialize request
+	// (e.g. "claude-ai"), or the integration's for in-process sessions.
+	Client string
+}
+
+type infoKey struct{}
+
+// WithInfo returns a context carrying info.
+func WithInfo(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, infoKey{}, info)
+}
+
+// FromContext returns the Info stored in ctx; zero outside tool calls.
+func FromContext(ctx context.Context) Info {
+	info, _ := ctx.Value(infoKey{}).(Info)
+	return info
+}
+
+// Middleware returns MCP receiving middleware that records each tool call's
+// Info in its context.
+func Middleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			call, ok := req.(*mcp.CallToolRequest)
+			if method != "tools/call" || !ok || call.Params == nil {
+				return next(ctx, method, req)
+			}
+			info := Info{Tool: call.Params.Name}
+			if call.Session != nil {
+				if params := call.Session.InitializeParams(); params != nil && params.ClientInfo != nil {
+					info.Client = params.ClientInfo.Name
+				}
+			}
+			return next(WithInfo(ctx, info), method, req)
+		}
+	}
+}
+
+// Template placeholders.
+const (
+	PlaceholderMessage   = "{message}"
+	PlaceholderTool      = "{tool}"
+	PlaceholderClient    = "{client}"
+	PlaceholderRequestID = "{request_id}"
+)
+
+// Render fills template with the change's message and origin. A literal
+// "\n" in template is a line break. Lines without {message} whose other
+// placeholders are all empty are dropped, so trailers like "Tool: {tool}"
+// disappear for background jobs. An empty template returns message as is.
+func Render(template, message string, info Info, requestID string) string {
+	if template == "" {
+		return message
+	}
+	values := map[string]string{
+		PlaceholderTool:      info.Tool,
+		PlaceholderClient:    info.Client,
+		PlaceholderRequestID: requestID,
+	}
+	replacer := strings.NewReplacer(
+		PlaceholderMessage, message,
+		PlaceholderTool, info.Tool,
+		PlaceholderClient, info.Client,
+		PlaceholderRequestID, requestID,
+	)
+
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(template, `\n`, "\n"), "\n") {
+		used, filled := 0, 0
+		for placeholder, value := range values {
+			if strings.Contains(line, placeholder) {
+				used++
+				if value != "" {
+					filled++
+				}
+			}
+		}
+		if used > 0 && filled == 0 && !strings.Contains(line, PlaceholderMessage) {
+			continue
+		}
+		lines = append(lines, replacer.Replace(line))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// render fills template for a change made with ctx.
+func render(ctx context.Context, template, message string) string {
+	return Render(template, message, FromContext(ctx), logging.RequestID(ctx))
+}
@@ -0,0 +1,79 @@
+package attribution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestRender(t *testing.T) {
+	info := Info{Tool: "add_todo", Client: "claude-ai"}
+	tests := []struct {
+		template string
+		info     Info
+		want     string
+	}{
+		{"", info, "Add todo: x"},
+		{`{message}\n\nTool: {tool}\nClient: {client}`, info, "Add todo: x\n\nTool: add_todo\nClient: claude-ai"},
+		{"[{client}] {message}", info, "[claude-ai] Add todo: x"},
+		// Background jobs have no tool or client
+		{`{message}\n\nTool: {tool}\nClient: {client}`, Info{}, "Add todo: x"},
+		{`{message}\n\nVia {tool} ({client})`, Info{Tool: "add_todo"}, "Add todo: x\n\nVia add_todo ()"},
+		{"[{client}] {message}", Info{}, "[] Add todo: x"},
+		{`{message}\nRequest: {request_id}`, info, "Add todo: x\nRequest: req-1"},
+	}
+	for _, tt := range tests {
+		if got := Render(tt.template, "Add todo: x", tt.info, "req-1"); got != tt.want {
+			t.Errorf("Render(%q, %+v) = %q, want %q", tt.template, tt.info, got, tt.want)
+		}
+	}
+}
+
+type writeInput struct {
+	Text string `json:"text"`
+}
+
+type writeOutput struct {
+	Success bool `json:"success"`
+}
+
+func TestMiddlewareAndStorage(t *testing.T) {
+	mem := storage.NewMemoryStorage(nil, nil)
+	s := WrapStorage(mem, `{message}\n\nTool: {tool}\nClient: {client}`)
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	server.AddReceivingMiddleware(Middleware())
+	mcp.AddTool(server, &mcp.Tool{Name: "add_todo"}, func(ctx context.Context, req *mcp.CallToolRequest, in writeInput) (*mcp.CallToolResult, writeOutput, error) {
+		return nil, writeOutput{}, s.WriteFile(ctx, "todos.md", in.Text, "", "Add todo: "+in.Text)
+	})
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "claude-ai", Version: "1.0"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	if _, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "add_todo", Arguments: map[string]any{"text": "x"}}); err != nil {
+		t.Fatal(err)
+	}
+	// Outside a tool call, only the message is left
+	if err := storage.WriteFiles(ctx, s, []storage.FileChange{{Path: "notes.md", Content: "n"}}, "Snapshot"); err != nil {
+		t.Fatal(err)
+	}
+
+	todos, _ := mem.ListCommits(ctx, "todos.md", 1)
+	if want := "Add todo: x\n\nTool: add_todo\nClient: claude-ai"; len(todos) != 1 || todos[0].Message != want {
+		t.Errorf("tool commits = %+v, want message %q", todos, want)
+	}
+	notes, _ := mem.ListCommits(ctx, "notes.md", 1)
+	if len(notes) != 1 || notes[0].Message != "Snapshot" {
+		t.Errorf("background commits = %+v, want message Snapshot", notes)
+	}
+}
@@ -0,0 +1,31 @@
+package attribution
+
+import (
+	"context"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// attributedStorage rewrites commit messages with a template.
+type attributedStorage struct {
+	next     storage.Storage
+	template string
+}
+
+// WrapStorage returns a Storage whose commit messages are rendered from
+// template (see Render) using the calling context's Info.
+func WrapStorage(s storage.Storage, template string) storage.Storage {
+	return &attributedStorage{next: s, template: template}
+}
+
+func (a *attributedStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	return a.next.ReadFile(ctx, path)
+}
+
+func (a *attributedStorage) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	return a.next.WriteFile(ctx, path, content, sha, render(ctx, a.template, message))
+}
+
+func (a *attributedStorage) WriteFiles(ctx context.Context, changes []storage.FileChange, message string) error {
+	return storage.WriteFiles(ctx, a.next, changes, render(ctx, a.template, message))
+}
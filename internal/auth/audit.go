@@ -0,0 +1,107 @@
+// Package auth keeps an audit log of OAuth events.
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+// AuthEvent is one recorded authorization event. Like the logs, it never
+// holds tokens, codes or PINs.
+type AuthEvent struct {
+	Time     time.Time `json:"time"`
+	Event    string    `json:"event"`
+	ClientID string    `json:"client_id,omitempty"`
+	IP       string    `json:"ip,omitempty"`
+	Detail   string    `json:"detail,omitempty"`
+}
+
+// AuditLog keeps the last N auth events in memory.
+type AuditLog struct {
+	mu      sync.Mutex
+	entries []AuthEvent
+	size    int
+	clock   clock.Clock
+}
+
+// NewAuditLog creates an audit log that retains the most recent size events,
+// timing events recorded without one by c (nil for the system clock).
+func NewAuditLog(size int, c clock.Clock) *AuditLog {
+	if size <= 0 {
+		size = 200
+	}
+	return &AuditLog{size: size, clock: clock.Or(c)}
+}
+
+// Record stores an event, evicting the oldest when full. A nil log is a
+// no-op so callers don't need to guard.
+func (a *AuditLog) Record(e AuthEvent) {
+	if a == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = a.clock.Now()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.entries = append(a.entries, e)
+	if len(a.entries) > a.size {
+		a.entries = a.entries[len(a.entries)-a.size:]
+	}
+}
+
+// Recent returns the recorded events, most recent first.
+func (a *AuditLog) Recent() []AuthEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	out := make([]AuthEvent, len(a.entries))
+	for i, e := range a.entries {
+		out[len(a.entries)-1-i] = e
+	}
+	return out
+}
+
+// authEventsReport is the response body for GET /admin/auth-events.
+type authEventsReport struct {
+	EventCounts  map[string]int `json:"event_counts"`
+	LockedIPs    []string       `json:"locked_ips"`
+	GlobalLocked bool           `json:"global_locked"`
+	Recent       []AuthEvent    `json:"recent"`
+}
+
+// AuthEventsHandler serves a JSON report of recent auth events and current
+// PIN lockouts.
+func (s *OAuthServer) AuthEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	recent := s.audit.Recent()
+	counts := make(map[string]int)
+	for _, e := range recent {
+		counts[e.Event]++
+	}
+	ips, global := s.pinLockout.Locked()
+	sort.Strings(ips)
+	if ips == nil {
+		ips = []string{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(authEventsReport{
+		EventCounts:  counts,
+		LockedIPs:    ips,
+		GlobalLocked: global,
+		Recent:       recent,
+	})
+}
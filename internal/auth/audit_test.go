@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+func TestAuditLog(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	a := NewAuditLog(2, clock.NewFake(now))
+
+	a.Record(AuthEvent{Event: "first"})
+	a.Record(AuthEvent{Event: "second", Time: now.Add(-time.Hour)})
+	a.Record(AuthEvent{Event: "third"})
+
+	recent := a.Recent()
+	if len(recent) != 2 || recent[0].Event != "third" || recent[1].Event != "second" {
+		t.Fatalf("Recent() = %+v, want third and second, most recent first", recent)
+	}
+	// Events without a time get the clock's
+	if !recent[0].Time.Equal(now) || !recent[1].Time.Equal(now.Add(-time.Hour)) {
+		t.Errorf("event times = %s, %s", recent[0].Time, recent[1].Time)
+	}
+
+	// A nil log records nothing, without panicking
+	var none *AuditLog
+	none.Record(AuthEvent{Event: "ignored"})
+}
@@ -0,0 +1,164 @@
+// Package auth supports confidential OAuth clients, which authenticate to
+// the token endpoint with a client secret.
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Token endpoint authentication methods (RFC 7591 section 2).
+const (
+	AuthMethodNone        = "none"
+	AuthMethodSecretPost  = "client_secret_post"
+	AuthMethodSecretBasic = "client_secret_basic"
+)
+
+// Grant types a client can register for. client_credentials is only for
+// confidential clients: it issues tokens on the secret alone, for
+// server-side automations with no user at a browser.
+const (
+	GrantAuthorizationCode = "authorization_code"
+	GrantRefreshToken      = "refresh_token"
+	GrantClientCredentials = "client_credentials"
+)
+
+// defaultGrantTypes are the grants of clients that don't ask for others.
+var defaultGrantTypes = []string{GrantAuthorizationCode, GrantRefreshToken}
+
+// Confidential reports whether the client authenticates with a secret.
+func (c *ClientInfo) Confidential() bool {
+	return c != nil && c.SecretHash != ""
+}
+
+// AllowsGrant reports whether the client registered for grantType.
+func (c *ClientInfo) AllowsGrant(grantType string) bool {
+	grants := c.GrantTypes
+	if len(grants) == 0 {
+		grants = defaultGrantTypes
+	}
+	for _, g := range grants {
+		if g == grantType {
+			return true
+		}
+	}
+	return false
+}
+
+// hashSecret returns the stored form of a client secret. Secrets are long
+// and random, so a plain SHA-256 is enough.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *ClientInfo) checkSecret(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(c.SecretHash)) == 1
+}
+
+// authenticateClient reads the client credentials of a token request, from
+// the form (client_secret_post) or an Authorization: Basic header
+// (client_secret_basic), and checks the secret of confidential clients.
+// authenticated is true only when a secret was checked. Public clients send
+// no secret; one that does is refused.
+func (s *OAuthServer) authenticateClient(r *http.Request) (clientID string, authenticated bool, err error) {
+	clientID = r.FormValue("client_id")
+	secret := r.FormValue("client_secret")
+	if id, password, ok := r.BasicAuth(); ok {
+		// Both parts are form-encoded before Basic encoding (RFC 6749 section 2.3.1)
+		if id, err = url.QueryUnescape(id); err != nil {
+			return "", false, errors.New("malformed client_id in Authorization header")
+		}
+		if password, err = url.QueryUnescape(password); err != nil {
+			return id, false, errors.New("malformed client secret in Authorization header")
+		}
+		if clientID != "" && clientID != id {
+			return id, false, errors.New("client_id doesn't match the Authorization header")
+		}
+		if secret != "" {
+			return id, false, errors.New("client secret sent in both the form and the Authorization header")
+		}
+		clientID, secret = id, password
+	}
+	if clientID == "" {
+		return "", false, nil
+	}
+
+	client := s.clientStore.Get(clientID)
+	if !client.Confidential() {
+		if secret != "" {
+			return clientID, false, errors.New("client secret sent for a public client")
+		}
+		return clientID, false, nil
+	}
+	if secret == "" {
+		return clientID, false, errors.New("missing client secret")
+	}
+	if !client.checkSecret(secret) {
+		return clientID, false, errors.New("invalid client secret")
+	}
+	return clientID, true, nil
+}
+
+// clientAuthError writes an invalid_client response (RFC 6749 section 5.2).
+func (s *OAuthServer) clientAuthError(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.Header.Get("Authorization"), "Basic ") {
+		w.Header().Set("WWW-Authenticate", `Basic realm="momentum"`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusUnauthorized)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":             "invalid_client",
+		"error_description": "Client authentication failed",
+	})
+}
+
+// validRegistrationToken reports whether r carries the initial access token
+// needed to register a confidential client (RFC 7591 section 3).
+func (s *OAuthServer) validRegistrationToken(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && s.registrationToken != "" &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(s.registrationToken)) == 1
+}
+
+func (s *OAuthServer) handleClientCredentialsGrant(w http.ResponseWriter, r *http.Request, clientID string, authenticated bool) {
+	if !authenticated {
+		s.logAuthEvent(r, "token_failed", clientID, "client_credentials without client authentication")
+		s.clientAuthError(w, r)
+		return
+	}
+	if !s.clientStore.Get(clientID).AllowsGrant(GrantClientCredentials) {
+		s.logAuthEvent(r, "token_failed", clientID, "client_credentials not registered")
+		s.tokenError(w, "unauthorized_client", "Client is not registered for the client_credentials grant")
+		return
+	}
+	if resource := r.FormValue("resource"); resource != "" && !s.validResource(resource) {
+		s.logAuthEvent(r, "token_failed", clientID, "resource is not this server")
+		s.tokenError(w, "invalid_target", "resource must be this server's URL")
+		return
+	}
+
+	// No refresh token: the client can always ask again (RFC 6749 section 4.4.3)
+	accessToken, expiresAt, err := s.tokenStore.GenerateAccessToken(clientID, "", s.audience)
+	if err != nil {
+		s.tokenError(w, "server_error", "Failed to generate tokens")
+		return
+	}
+	s.logAuthEvent(r, "token_issued", clientID, "client_credentials")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   int(expiresAt.Sub(s.clock.Now()).Seconds()),
+		"scope":        "mcp:read mcp:write",
+	})
+}
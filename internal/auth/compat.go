@@ -0,0 +1,141 @@
+// Package auth provides compatibility shims for known MCP client quirks.
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CompatConfig toggles workarounds for known client quirks.
+type CompatConfig struct {
+	// RootEndpoint indicates MCP is also served at "/" (reported only; routing happens in main).
+	RootEndpoint bool `json:"root_endpoint"`
+
+	// AllowRefreshWithoutClientID accepts refresh_token grants that omit client_id.
+	AllowRefreshWithoutClientID bool `json:"allow_refresh_without_client_id"`
+
+	// EchoResource includes the requested "resource" parameter in token responses.
+	EchoResource bool `json:"echo_resource"`
+}
+
+// Known quirk identifiers recorded in ClientNegotiation.Quirks.
+const (
+	QuirkRootEndpoint         = "mcp_at_root"
+	QuirkRefreshNoClientID    = "refresh_without_client_id"
+	QuirkResourceParam        = "resource_parameter"
+	QuirkUnregisteredRedirect = "unregistered_redirect_uri"
+	QuirkMissingPKCE          = "missing_pkce"
+)
+
+// ClientNegotiation records what a single client request negotiated with the server.
+type ClientNegotiation struct {
+	Time      time.Time `json:"time"`
+	Event     string    `json:"event"` // authorize, token, register, mcp_initialize
+	Endpoint  string    `json:"endpoint"`
+	ClientID  string    `json:"client_id,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	GrantType string    `json:"grant_type,omitempty"`
+	Resource  string    `json:"resource,omitempty"`
+	Quirks    []string  `json:"quirks,omitempty"`
+}
+
+// CompatRecorder keeps the last N client negotiations in memory.
+type CompatRecorder struct {
+	mu      sync.Mutex
+	entries []ClientNegotiation
+	size    int
+	config  CompatConfig
+}
+
+// NewCompatRecorder creates a recorder that retains the most recent size entries.
+func NewCompatRecorder(size int, config CompatConfig) *CompatRecorder {
+	if size <= 0 {
+		size = 20
+	}
+	return &CompatRecorder{size: size, config: config}
+}
+
+// Record stores a negotiation, evicting the oldest entry when full.
+// A nil recorder is a no-op so callers don't need to guard.
+func (c *CompatRecorder) Record(n ClientNegotiation) {
+	if c == nil {
+		return
+	}
+	if n.Time.IsZero() {
+		n.Time = time.Now()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = append(c.entries, n)
+	if len(c.entries) > c.size {
+		c.entries = c.entries[len(c.entries)-c.size:]
+	}
+}
+
+// Recent returns the recorded negotiations, most recent first.
+func (c *CompatRecorder) Recent() []ClientNegotiation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]ClientNegotiation, len(c.entries))
+	for i, e := range c.entries {
+		out[len(c.entries)-1-i] = e
+	}
+	return out
+}
+
+// compatReport is the response body for GET /compat-report.
+type compatReport struct {
+	Config      CompatConfig        `json:"config"`
+	QuirkCounts map[string]int      `json:"quirk_counts"`
+	Recent      []ClientNegotiation `json:"recent"`
+}
+
+// ReportHandler serves a JSON summary of recent client negotiations.
+func (c *CompatRecorder) ReportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	recent := c.Recent()
+	counts := make(map[string]int)
+	for _, n := range recent {
+		for _, q := range n.Quirks {
+			counts[q]++
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(compatReport{
+		Config:      c.config,
+		QuirkCounts: counts,
+		Recent:      recent,
+	})
+}
+
+// CompatEndpointMiddleware records MCP session initializations (requests without
+// an Mcp-Session-Id header) so the report shows which endpoint each client uses.
+func CompatEndpointMiddleware(rec *CompatRecorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if rec != nil && r.Method == http.MethodPost && r.Header.Get("Mcp-Session-Id") == "" {
+				n := ClientNegotiation{
+					Event:     "mcp_initialize",
+					Endpoint:  r.URL.Path,
+					UserAgent: r.UserAgent(),
+				}
+				if r.URL.Path == "/" {
+					n.Quirks = append(n.Quirks, QuirkRootEndpoint)
+				}
+				rec.Record(n)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newCompatTestServer returns an OAuth server with the given shims, its
+// recorder, and a refresh token for the "app" client.
+func newCompatTestServer(t *testing.T, compat CompatConfig) (*OAuthServer, *CompatRecorder, string) {
+	t.Helper()
+	clients := NewClientStore()
+	clients.Register(&ClientInfo{ClientID: "app", ClientName: "App", RedirectURIs: []string{testRedirectURI}})
+	recorder := NewCompatRecorder(0, compat)
+	s := NewOAuthServer(OAuthConfig{
+		TokenStore:  NewTokenStore(time.Hour, time.Hour, nil),
+		ClientStore: clients,
+		BaseURL:     "https://momentum.example",
+		Compat:      compat,
+		Recorder:    recorder,
+	})
+	refresh, _, err := s.tokenStore.GenerateRefreshToken("app", s.Audience())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, recorder, refresh
+}
+
+func TestCompat_RefreshWithoutClientID(t *testing.T) {
+	form := func(refresh string) url.Values {
+		return url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refresh}}
+	}
+
+	s, _, refresh := newCompatTestServer(t, CompatConfig{})
+	if rec := tokenRequest(s, form(refresh), "", ""); rec.Code != http.StatusBadRequest || tokenErrorCode(t, rec) != "invalid_request" {
+		t.Errorf("refresh without client_id, shim off = %d %s", rec.Code, rec.Body)
+	}
+
+	s, recorder, refresh := newCompatTestServer(t, CompatConfig{AllowRefreshWithoutClientID: true})
+	if rec := tokenRequest(s, form(refresh), "", ""); rec.Code != http.StatusOK {
+		t.Errorf("refresh without client_id, shim on = %d %s", rec.Code, rec.Body)
+	}
+	recent := recorder.Recent()
+	if len(recent) != 1 || recent[0].Event != "token" || len(recent[0].Quirks) != 1 || recent[0].Quirks[0] != QuirkRefreshNoClientID {
+		t.Errorf("recorded %+v, want a token negotiation with %s", recent, QuirkRefreshNoClientID)
+	}
+}
+
+func TestCompat_EchoResource(t *testing.T) {
+	for _, echo := range []bool{false, true} {
+		s, recorder, refresh := newCompatTestServer(t, CompatConfig{EchoResource: echo})
+		form := url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {refresh},
+			"client_id":     {"app"},
+			"resource":      {"https://momentum.example/mcp"},
+		}
+		rec := tokenRequest(s, form, "", "")
+		var tok map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &tok); err != nil || rec.Code != http.StatusOK {
+			t.Fatalf("echo %v: refresh = %d %s", echo, rec.Code, rec.Body)
+		}
+		resource, ok := tok["resource"]
+		if echo && resource != "https://momentum.example/mcp" || !echo && ok {
+			t.Errorf("echo %v: resource in the response = %v", echo, resource)
+		}
+		if recent := recorder.Recent(); len(recent) != 1 || recent[0].Resource != "https://momentum.example/mcp" {
+			t.Errorf("echo %v: recorded %+v", echo, recent)
+		}
+	}
+}
+
+func TestCompatEndpointMiddleware(t *testing.T) {
+	recorder := NewCompatRecorder(0, CompatConfig{RootEndpoint: true})
+	served := 0
+	handler := CompatEndpointMiddleware(recorder)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served++
+	}))
+
+	request := func(method, path, session string) {
+		r := httptest.NewRequest(method, path, nil)
+		r.Header.Set("User-Agent", "test-client/1.0")
+		if session != "" {
+			r.Header.Set("Mcp-Session-Id", session)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+	}
+	request(http.MethodPost, "/mcp", "")
+	request(http.MethodPost, "/", "")
+	// Requests within a session, and GETs, aren't initializations
+	request(http.MethodPost, "/", "session-1")
+	request(http.MethodGet, "/mcp", "")
+
+	if served != 4 {
+		t.Errorf("served %d requests, want all 4", served)
+	}
+	recent := recorder.Recent()
+	if len(recent) != 2 {
+		t.Fatalf("recorded %d negotiations, want 2: %+v", len(recent), recent)
+	}
+	root, mcp := recent[0], recent[1]
+	if root.Endpoint != "/" || len(root.Quirks) != 1 || root.Quirks[0] != QuirkRootEndpoint || root.UserAgent != "test-client/1.0" {
+		t.Errorf("root negotiation = %+v", root)
+	}
+	if mcp.Endpoint != "/mcp" || mcp.Event != "mcp_initialize" || len(mcp.Quirks) != 0 {
+		t.Errorf("/mcp negotiation = %+v", mcp)
+	}
+
+	// A nil recorder passes requests through
+	handler = CompatEndpointMiddleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served++
+	}))
+	request(http.MethodPost, "/", "")
+	if served != 5 {
+		t.Error("request not served without a recorder")
+	}
+}
+
+func TestCompatRecorder_ReportHandler(t *testing.T) {
+	config := CompatConfig{RootEndpoint: true, EchoResource: true}
+	recorder := NewCompatRecorder(2, config)
+	recorder.Record(ClientNegotiation{Event: "authorize", Quirks: []string{QuirkMissingPKCE}})
+	recorder.Record(ClientNegotiation{Event: "token", Quirks: []string{QuirkResourceParam}})
+	recorder.Record(ClientNegotiation{Event: "mcp_initialize", Endpoint: "/", Quirks: []string{QuirkRootEndpoint, QuirkResourceParam}})
+
+	rec := httptest.NewRecorder()
+	recorder.ReportHandler(rec, httptest.NewRequest(http.MethodGet, "/compat-report", nil))
+	var report compatReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("report = %d %s", rec.Code, rec.Body)
+	}
+	if rec.Header().Get("Cache-Control") != "no-store" {
+		t.Error("report may be cached")
+	}
+	if report.Config != config {
+		t.Errorf("config = %+v, want %+v", report.Config, config)
+	}
+	// Only the retained negotiations count, most recent first
+	if len(report.Recent) != 2 || report.Recent[0].Event != "mcp_initialize" || report.Recent[1].Event != "token" {
+		t.Errorf("recent = %+v", report.Recent)
+	}
+	want := map[string]int{QuirkResourceParam: 2, QuirkRootEndpoint: 1}
+	if len(report.QuirkCounts) != len(want) || report.QuirkCounts[QuirkResourceParam] != 2 || report.QuirkCounts[QuirkRootEndpoint] != 1 {
+		t.Errorf("quirk counts = %v, want %v", report.QuirkCounts, want)
+	}
+
+	rec = httptest.NewRecorder()
+	recorder.ReportHandler(rec, httptest.NewRequest(http.MethodPost, "/compat-report", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d", rec.Code)
+	}
+}
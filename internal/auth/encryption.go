@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// StateKeySize is the length of an OAuth state encryption key (AES-256).
+const StateKeySize = 32
+
+// ParseStateKey decodes a base64 encoded 32-byte key, as generated by
+// `openssl rand -base64 32`.
+func ParseStateKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("key is not valid base64: %w", err)
+	}
+	if len(key) != StateKeySize {
+		return nil, fmt.Errorf("key is %d bytes, want %d", len(key), StateKeySize)
+	}
+	return key, nil
+}
+
+// encryptedState is the on-disk form of an encrypted oauth_state.json. A
+// plaintext file is the PersistentData JSON itself, which has no
+// "encrypted" field.
+type encryptedState struct {
+	Encrypted *sealedState `json:"encrypted"`
+}
+
+type sealedState struct {
+	// KeyID identifies the key that sealed Data, so rotation doesn't have
+	// to try every key.
+	KeyID string `json:"key_id"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// stateAAD binds the ciphertext to its purpose.
+var stateAAD = []byte("momentum oauth_state v1")
+
+// errStateKeyNeeded is returned when the state file is encrypted but no key
+// that can open it is configured.
+var errStateKeyNeeded = errors.New("oauth state is encrypted with a key that isn't configured (set OAUTH_STATE_KEY, or OAUTH_STATE_OLD_KEYS after rotating)")
+
+// keyID returns a short, non-secret fingerprint of key.
+func keyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:4])
+}
+
+// sealState encrypts plaintext with key using AES-256-GCM.
+func sealState(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(encryptedState{Encrypted: &sealedState{
+		KeyID: keyID(key),
+		Nonce: nonce,
+		Data:  gcm.Seal(nil, nonce, plaintext, stateAAD),
+	}}, "", "  ")
+}
+
+// StateEncrypted reports whether data, the content of a state file, is
+// encrypted. A plaintext file holds live access and refresh tokens.
+func StateEncrypted(data []byte) bool {
+	var env encryptedState
+	return json.Unmarshal(data, &env) == nil && env.Encrypted != nil
+}
+
+// openState returns the plaintext of a state file, decrypting it with
+// whichever of keys sealed it. A plaintext file is returned as is, so
+// turning encryption on needs no migration. sealedWith is the key used, or
+// nil for a plaintext file.
+func openState(data []byte, keys [][]byte) (plaintext, sealedWith []byte, err error) {
+	var env encryptedState
+	if json.Unmarshal(data, &env) != nil || env.Encrypted == nil {
+		return data, nil, nil
+	}
+	for _, key := range keys {
+		if keyID(key) != env.Encrypted.KeyID {
+			continue
+		}
+		gcm, err := newGCM(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		plaintext, err := gcm.Open(nil, env.Encrypted.Nonce, env.Encrypted.Data, stateAAD)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decrypting oauth state: %w", err)
+		}
+		return plaintext, key, nil
+	}
+	return nil, nil, errStateKeyNeeded
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
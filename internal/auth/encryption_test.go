@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, StateKeySize)
+}
+
+func TestSealOpenState(t *testing.T) {
+	key := testKey(1)
+	sealed, err := sealState(key, []byte(`{"tokens":{}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(sealed, []byte("tokens")) {
+		t.Errorf("sealed state leaks the plaintext: %s", sealed)
+	}
+
+	plaintext, sealedWith, err := openState(sealed, [][]byte{testKey(2), key})
+	if err != nil || string(plaintext) != `{"tokens":{}}` || !bytes.Equal(sealedWith, key) {
+		t.Errorf("openState() = %q, %x, %v", plaintext, sealedWith, err)
+	}
+
+	// Plaintext files are read as is
+	if plaintext, sealedWith, err := openState([]byte(`{"tokens":{}}`), nil); err != nil || sealedWith != nil || string(plaintext) != `{"tokens":{}}` {
+		t.Errorf("openState(plaintext) = %q, %x, %v", plaintext, sealedWith, err)
+	}
+}
+
+func TestOpenState_WrongKey(t *testing.T) {
+	sealed, err := sealState(testKey(1), []byte("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := openState(sealed, [][]byte{testKey(2)}); !errors.Is(err, errStateKeyNeeded) {
+		t.Errorf("openState(wrong key) error = %v, want errStateKeyNeeded", err)
+	}
+	if _, _, err := openState(sealed, nil); !errors.Is(err, errStateKeyNeeded) {
+		t.Errorf("openState(no key) error = %v, want errStateKeyNeeded", err)
+	}
+
+	// A tampered ciphertext fails to decrypt rather than loading garbage
+	tampered := bytes.Replace(sealed, []byte(`"data": "`), []byte(`"data": "AAAA`), 1)
+	if _, _, err := openState(tampered, [][]byte{testKey(1)}); err == nil {
+		t.Error("openState(tampered) succeeded")
+	}
+}
+
+// newTestPersistence returns a Persistence over dir with one client and one
+// token in memory.
+func newTestPersistence(t *testing.T, dir string) *Persistence {
+	t.Helper()
+	tokens := NewTokenStore(time.Hour, time.Hour, nil)
+	clients := NewClientStore()
+	return NewPersistence(dir, tokens, clients)
+}
+
+func TestPersistence_KeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	oldKey, newKey := testKey(1), testKey(2)
+
+	p := newTestPersistence(t, dir)
+	p.clients.Register(&ClientInfo{ClientID: "app", ClientName: "App"})
+	p.SetEncryptionKeys(oldKey)
+	if err := p.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Starting with the new key, and the old one to decrypt, loads the
+	// state and re-encrypts it with the new key
+	p = newTestPersistence(t, dir)
+	p.SetEncryptionKeys(newKey, oldKey)
+	if err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+	p.Stop()
+	if p.clients.Get("app") == nil {
+		t.Fatal("client not loaded with the old key")
+	}
+
+	p = newTestPersistence(t, dir)
+	p.SetEncryptionKeys(newKey)
+	if err := p.Load(); err != nil || p.clients.Get("app") == nil {
+		t.Errorf("Load() with only the new key = %v, want the client back", err)
+	}
+}
+
+func TestPersistence_FailedLoadNeverSaves(t *testing.T) {
+	dir := t.TempDir()
+	p := newTestPersistence(t, dir)
+	p.clients.Register(&ClientInfo{ClientID: "app", ClientName: "App"})
+	p.SetEncryptionKeys(testKey(1))
+	if err := p.Save(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, StateFileName)
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Started without the key: the load fails and nothing may save over it
+	p = newTestPersistence(t, dir)
+	if err := p.Start(); err == nil || !strings.Contains(err.Error(), "persistence disabled") {
+		t.Fatalf("Start() error = %v, want a load failure", err)
+	}
+	p.TriggerSave()
+	if err := p.Save(); err == nil {
+		t.Error("Save() after a failed load succeeded")
+	}
+	p.Stop()
+
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("state file was overwritten after a failed load")
+	}
+
+	// A missing file is a first run, which does save
+	p = newTestPersistence(t, t.TempDir())
+	if err := p.Start(); err != nil {
+		t.Errorf("Start() on a first run = %v", err)
+	}
+	p.Stop()
+	if _, err := os.Stat(p.filePath); err != nil {
+		t.Errorf("first run didn't save: %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+// Package auth provides brute-force protection for the authorize PIN.
+package auth
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+// PIN lockout defaults. After PinMaxFailuresPerIP wrong PINs in a row from
+// one IP, or PinMaxFailuresGlobal from everywhere, PIN attempts are refused
+// for PinLockoutBase, doubling with each further failure up to
+// PinLockoutMax. Failures are forgotten after PinFailureMemory without any,
+// or on a correct PIN. At most PinMaxTrackedIPs IPs are remembered, the
+// longest quiet forgotten first, so a guesser with many addresses can't
+// grow the table without bound.
+const (
+	PinMaxFailuresPerIP  = 5
+	PinMaxFailuresGlobal = 20
+	PinLockoutBase       = time.Minute
+	PinLockoutMax        = time.Hour
+	PinFailureMemory     = 24 * time.Hour
+	PinMaxTrackedIPs     = 10000
+)
+
+// PinLockout counts failed authorize PINs per IP and across all IPs, and
+// locks PIN entry out with exponential backoff. The global counter stops a
+// distributed guesser at the cost of briefly locking out the owner too.
+type PinLockout struct {
+	mu     sync.Mutex
+	clock  clock.Clock
+	perIP  map[string]*pinFailures
+	maxIPs int
+	global pinFailures
+}
+
+// pinFailures is a run of failed PINs.
+type pinFailures struct {
+	count       int
+	last        time.Time
+	lockedUntil time.Time
+}
+
+// NewPinLockout creates a PinLockout using the given clock (nil for the
+// system clock).
+func NewPinLockout(c clock.Clock) *PinLockout {
+	return &PinLockout{clock: clock.Or(c), perIP: make(map[string]*pinFailures), maxIPs: PinMaxTrackedIPs}
+}
+
+// Check returns how long PIN attempts from ip are locked out for, or zero
+// if one may be made.
+func (l *PinLockout) Check(ip string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	wait := l.global.lockedUntil.Sub(now)
+	if f := l.perIP[ip]; f != nil {
+		if w := f.lockedUntil.Sub(now); w > wait {
+			wait = w
+		}
+	}
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// Fail records a wrong PIN from ip and returns the lockout it triggered,
+// or zero.
+func (l *PinLockout) Fail(ip string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	f := l.perIP[ip]
+	if f == nil {
+		f = &pinFailures{}
+		l.perIP[ip] = f
+	}
+	wait := f.fail(now, PinMaxFailuresPerIP)
+	if w := l.global.fail(now, PinMaxFailuresGlobal); w > wait {
+		wait = w
+	}
+
+	// Drop runs that have been forgotten, and the longest quiet beyond the cap
+	var oldest string
+	for addr, f := range l.perIP {
+		if now.Sub(f.last) > PinFailureMemory {
+			delete(l.perIP, addr)
+		} else if addr != ip && (oldest == "" || f.last.Before(l.perIP[oldest].last)) {
+			oldest = addr
+		}
+	}
+	if len(l.perIP) > l.maxIPs && oldest != "" {
+		delete(l.perIP, oldest)
+	}
+	return wait
+}
+
+// Succeed records a correct PIN from ip, clearing its failures and the
+// global count.
+func (l *PinLockout) Succeed(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.perIP, ip)
+	l.global = pinFailures{}
+}
+
+// Locked returns the IPs currently locked out and whether PIN entry is
+// locked out globally.
+func (l *PinLockout) Locked() (ips []string, global bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	for ip, f := range l.perIP {
+		if f.lockedUntil.After(now) {
+			ips = append(ips, ip)
+		}
+	}
+	return ips, l.global.lockedUntil.After(now)
+}
+
+// fail counts a failure at now and returns the lockout once the count
+// reaches max.
+func (f *pinFailures) fail(now time.Time, max int) time.Duration {
+	if now.Sub(f.last) > PinFailureMemory {
+		f.count = 0
+	}
+	f.count++
+	f.last = now
+	if f.count < max {
+		return 0
+	}
+
+	wait := PinLockoutBase
+	for i := max; i < f.count && wait < PinLockoutMax; i++ {
+		wait *= 2
+	}
+	if wait > PinLockoutMax {
+		wait = PinLockoutMax
+	}
+	f.lockedUntil = now.Add(wait)
+	return wait
+}
+
+// formatWait renders a lockout for people: "45 seconds", "4 minutes".
+func formatWait(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%d seconds", int(d.Seconds()+0.5))
+	}
+	minutes := int(d.Minutes() + 0.5)
+	if minutes == 1 {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
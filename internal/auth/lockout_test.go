@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+func TestPinLockout_Threshold(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+	l := NewPinLockout(fake)
+
+	for i := 1; i < PinMaxFailuresPerIP; i++ {
+		if wait := l.Fail("192.0.2.1"); wait != 0 {
+			t.Fatalf("failure %d locked out for %s", i, wait)
+		}
+	}
+	if wait := l.Check("192.0.2.1"); wait != 0 {
+		t.Fatalf("locked out below the threshold: %s", wait)
+	}
+
+	// The next failure locks the IP out, and each further one doubles it
+	if wait := l.Fail("192.0.2.1"); wait != PinLockoutBase {
+		t.Errorf("lockout at the threshold = %s, want %s", wait, PinLockoutBase)
+	}
+	if wait := l.Fail("192.0.2.1"); wait != 2*PinLockoutBase {
+		t.Errorf("next lockout = %s, want %s", wait, 2*PinLockoutBase)
+	}
+	if wait := l.Check("192.0.2.1"); wait != 2*PinLockoutBase {
+		t.Errorf("Check() = %s, want %s", wait, 2*PinLockoutBase)
+	}
+	if ips, global := l.Locked(); len(ips) != 1 || ips[0] != "192.0.2.1" || global {
+		t.Errorf("Locked() = %v, %v", ips, global)
+	}
+
+	// Other IPs aren't affected, and the lockout ends
+	if wait := l.Check("192.0.2.2"); wait != 0 {
+		t.Errorf("another IP locked out for %s", wait)
+	}
+	fake.Advance(2 * PinLockoutBase)
+	if wait := l.Check("192.0.2.1"); wait != 0 {
+		t.Errorf("still locked out after the lockout: %s", wait)
+	}
+
+	// Backoff stops at PinLockoutMax
+	var wait time.Duration
+	for i := 0; i < 20; i++ {
+		wait = l.Fail("192.0.2.1")
+	}
+	if wait != PinLockoutMax {
+		t.Errorf("lockout after many failures = %s, want %s", wait, PinLockoutMax)
+	}
+}
+
+func TestPinLockout_Global(t *testing.T) {
+	l := NewPinLockout(clock.NewFake(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)))
+	for i := 0; i < PinMaxFailuresGlobal; i++ {
+		l.Fail(fmt.Sprintf("198.51.100.%d", i))
+	}
+	if wait := l.Check("203.0.113.9"); wait != PinLockoutBase {
+		t.Errorf("Check() after %d failures from everywhere = %s, want %s", PinMaxFailuresGlobal, wait, PinLockoutBase)
+	}
+	if _, global := l.Locked(); !global {
+		t.Error("Locked() doesn't report the global lockout")
+	}
+}
+
+func TestPinLockout_SucceedResets(t *testing.T) {
+	l := NewPinLockout(clock.NewFake(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)))
+	for i := 1; i < PinMaxFailuresPerIP; i++ {
+		l.Fail("192.0.2.1")
+	}
+	l.Succeed("192.0.2.1")
+
+	// The count starts again
+	for i := 1; i < PinMaxFailuresPerIP; i++ {
+		if wait := l.Fail("192.0.2.1"); wait != 0 {
+			t.Fatalf("failure %d after a correct PIN locked out for %s", i, wait)
+		}
+	}
+}
+
+func TestPinLockout_Expiry(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+	l := NewPinLockout(fake)
+	for i := 1; i < PinMaxFailuresPerIP; i++ {
+		l.Fail("192.0.2.1")
+	}
+
+	// Failures are forgotten after a quiet PinFailureMemory
+	fake.Advance(PinFailureMemory + time.Minute)
+	if wait := l.Fail("192.0.2.1"); wait != 0 {
+		t.Errorf("failure after the memory expired locked out for %s", wait)
+	}
+
+	// and so are the IPs they came from
+	l.Fail("192.0.2.2")
+	fake.Advance(PinFailureMemory + time.Minute)
+	l.Fail("192.0.2.3")
+	if len(l.perIP) != 1 || l.perIP["192.0.2.3"] == nil {
+		t.Errorf("tracked IPs = %v, want only 192.0.2.3", l.perIP)
+	}
+}
+
+func TestPinLockout_CapsTrackedIPs(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+	l := NewPinLockout(fake)
+	l.maxIPs = 3
+	for i := 1; i <= 5; i++ {
+		l.Fail(fmt.Sprintf("192.0.2.%d", i))
+		fake.Advance(time.Second)
+	}
+	if len(l.perIP) != 3 {
+		t.Fatalf("tracked %d IPs, want 3", len(l.perIP))
+	}
+	for _, ip := range []string{"192.0.2.3", "192.0.2.4", "192.0.2.5"} {
+		if l.perIP[ip] == nil {
+			t.Errorf("%s forgotten before the quieter IPs", ip)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/internal/tracing"
 )
 
 // TokenValidator is an interface for validating tokens from multiple sources.
@@ -25,11 +27,14 @@ func (v *staticTokenValidator) ValidateToken(token string) bool {
 
 // oauthTokenValidator validates OAuth-issued access tokens.
 type oauthTokenValidator struct {
-	store *TokenStore
+	store    *TokenStore
+	audience string
 }
 
 func (v *oauthTokenValidator) ValidateToken(token string) bool {
-	return v.store.ValidateAccessToken(token) != nil
+	info := v.store.ValidateAccessToken(token)
+	// Tokens from before audience binding have none; they expire soon enough
+	return info != nil && (info.Audience == "" || info.Audience == v.audience)
 }
 
 // MultiValidator combines multiple token validators.
@@ -58,9 +63,10 @@ func NewStaticTokenValidator(token string) TokenValidator {
 	return &staticTokenValidator{token: token}
 }
 
-// NewOAuthTokenValidator creates a validator for OAuth-issued tokens.
-func NewOAuthTokenValidator(store *TokenStore) TokenValidator {
-	return &oauthTokenValidator{store: store}
+// NewOAuthTokenValidator creates a validator for OAuth-issued tokens that
+// accepts only those bound to audience (see OAuthServer.Audience).
+func NewOAuthTokenValidator(store *TokenStore, audience string) TokenValidator {
+	return &oauthTokenValidator{store: store, audience: audience}
 }
 
 // MiddlewareConfig configures the auth middleware behavior.
@@ -90,7 +96,11 @@ func Middleware(config MiddlewareConfig) func(http.Handler) http.Handler {
 
 			// Extract and validate token
 			token := strings.TrimPrefix(authHeader, "Bearer ")
-			if !config.Validator.ValidateToken(token) {
+			_, span := tracing.Start(r.Context(), "auth", tracing.KindInternal)
+			valid := config.Validator.ValidateToken(token)
+			span.SetAttr("auth.valid", valid)
+			span.End()
+			if !valid {
 				writeUnauthorized(w, config.ResourceMetadataURL, "invalid token")
 				return
 			}
@@ -6,12 +6,16 @@ import (
 	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
-	"html/template"
-	"log"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/assets"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
 )
 
 // OAuthServer handles OAuth 2.0 authorization flows.
@@ -21,6 +25,15 @@ type OAuthServer struct {
 	authCodes    *AuthCodeStore
 	baseURL      string
 	authorizePin string // Optional PIN for authorize page
+	compat       CompatConfig
+	recorder     *CompatRecorder // Optional - records client negotiations
+	audit        *AuditLog
+	pinLockout   *PinLockout
+	audience     string // canonical baseURL; see Audience
+	proxies      TrustedProxies
+	clock        clock.Clock
+
+	registrationToken string // initial access token for confidential clients
 }
 
 // OAuthConfig configures the OAuth server.
@@ -29,12 +42,27 @@ type OAuthConfig struct {
 	ClientStore  *ClientStore // Optional - if nil, a new one is created
 	BaseURL      string
 	AuthorizePin string
+	Compat       CompatConfig
+	Recorder     *CompatRecorder
+	AuditLog     *AuditLog   // Optional - if nil, a new one is created
+	Clock        clock.Clock // Optional - if nil, the system clock is used
+
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For is used
+	// to identify clients for PIN lockouts and the audit log.
+	TrustedProxies TrustedProxies
+
+	// RegistrationToken must be presented as a bearer token to register a
+	// confidential client. If empty, only public clients can register.
+	RegistrationToken string
 }
 
-// logAuthEvent logs an authorization event without exposing sensitive data.
-func logAuthEvent(event, clientID, detail string) {
+// logAuthEvent logs an authorization event without exposing sensitive data,
+// and records it in the audit log.
+func (s *OAuthServer) logAuthEvent(r *http.Request, event, clientID, detail string) {
 	// Never log tokens, codes, or PINs - only event type and client identifier
-	log.Printf("[OAuth] %s: client=%s %s", event, clientID, detail)
+	ip := s.proxies.ClientIP(r)
+	slog.Info("oauth event", "event", event, "client_id", clientID, "ip", ip, "detail", detail)
+	s.audit.Record(AuthEvent{Time: s.clock.Now(), Event: event, ClientID: clientID, IP: ip, Detail: detail})
 }
 
 // NewOAuthServer creates a new OAuth server.
@@ -43,12 +71,28 @@ func NewOAuthServer(config OAuthConfig) *OAuthServer {
 	if clientStore == nil {
 		clientStore = NewClientStore()
 	}
+	audit := config.AuditLog
+	if audit == nil {
+		audit = NewAuditLog(0, config.Clock)
+	}
+	baseURL := strings.TrimSuffix(config.BaseURL, "/")
+	audience, _ := canonicalResource(baseURL)
+	clk := clock.Or(config.Clock)
 	return &OAuthServer{
 		tokenStore:   config.TokenStore,
 		clientStore:  clientStore,
-		authCodes:    NewAuthCodeStore(),
-		baseURL:      strings.TrimSuffix(config.BaseURL, "/"),
+		authCodes:    NewAuthCodeStore(clk),
+		baseURL:      baseURL,
 		authorizePin: config.AuthorizePin,
+		compat:       config.Compat,
+		recorder:     config.Recorder,
+		audit:        audit,
+		pinLockout:   NewPinLockout(clk),
+		audience:     audience,
+		proxies:      config.TrustedProxies,
+		clock:        clk,
+
+		registrationToken: config.RegistrationToken,
 	}
 }
 
@@ -75,9 +119,9 @@ func (s *OAuthServer) AuthorizationServerMetadata(w http.ResponseWriter, r *http
 		"token_endpoint":                        s.baseURL + "/token",
 		"registration_endpoint":                 s.baseURL + "/register",
 		"response_types_supported":              []string{"code"},
-		"grant_types_supported":                 []string{"authorization_code", "refresh_token"},
+		"grant_types_supported":                 []string{"authorization_code", "refresh_token", "client_credentials"},
 		"code_challenge_methods_supported":      []string{"S256"},
-		"token_endpoint_auth_methods_supported": []string{"none"}, // Public clients
+		"token_endpoint_auth_methods_supported": []string{AuthMethodNone, AuthMethodSecretPost, AuthMethodSecretBasic},
 		"scopes_supported":                      []string{"mcp:read", "mcp:write"},
 		"service_documentation":                 "https://github.com/dang-w/momentum-mcp-server",
 	}
@@ -93,6 +137,7 @@ type AuthCode struct {
 	RedirectURI         string
 	CodeChallenge       string
 	CodeChallengeMethod string
+	Resource            string // RFC 8707 resource indicator, if the client sent one
 	ExpiresAt           time.Time
 	Used                bool
 }
@@ -101,12 +146,15 @@ type AuthCode struct {
 type AuthCodeStore struct {
 	mu    sync.RWMutex
 	codes map[string]*AuthCode
+	clock clock.Clock
 }
 
-// NewAuthCodeStore creates a new authorization code store.
-func NewAuthCodeStore() *AuthCodeStore {
+// NewAuthCodeStore creates a new authorization code store. A nil clock uses
+// the system clock.
+func NewAuthCodeStore(c clock.Clock) *AuthCodeStore {
 	store := &AuthCodeStore{
 		codes: make(map[string]*AuthCode),
+		clock: clock.Or(c),
 	}
 	go store.cleanupExpired()
 	return store
@@ -126,7 +174,7 @@ func (s *AuthCodeStore) Get(code string) *AuthCode {
 	defer s.mu.Unlock()
 
 	ac, exists := s.codes[code]
-	if !exists || ac.Used || time.Now().After(ac.ExpiresAt) {
+	if !exists || ac.Used || s.clock.Now().After(ac.ExpiresAt) {
 		return nil
 	}
 	ac.Used = true
@@ -137,7 +185,7 @@ func (s *AuthCodeStore) cleanupExpired() {
 	ticker := time.NewTicker(time.Minute)
 	for range ticker.C {
 		s.mu.Lock()
-		now := time.Now()
+		now := s.clock.Now()
 		for code, ac := range s.codes {
 			if now.After(ac.ExpiresAt) || ac.Used {
 				delete(s.codes, code)
@@ -153,6 +201,9 @@ type ClientInfo struct {
 	ClientName   string
 	RedirectURIs []string
 	CreatedAt    time.Time
+	AuthMethod   string   // token endpoint auth method; empty means none
+	SecretHash   string   // hash of the client secret; empty for public clients
+	GrantTypes   []string // empty means authorization_code and refresh_token
 }
 
 // ClientStore manages registered OAuth clients.
@@ -232,6 +283,25 @@ func (s *OAuthServer) authorizeGet(w http.ResponseWriter, r *http.Request) {
 	state := r.URL.Query().Get("state")
 	codeChallenge := r.URL.Query().Get("code_challenge")
 	codeChallengeMethod := r.URL.Query().Get("code_challenge_method")
+	resource := r.URL.Query().Get("resource")
+
+	negotiation := ClientNegotiation{
+		Event:     "authorize",
+		Endpoint:  r.URL.Path,
+		ClientID:  clientID,
+		UserAgent: r.UserAgent(),
+		Resource:  resource,
+	}
+	if resource != "" {
+		negotiation.Quirks = append(negotiation.Quirks, QuirkResourceParam)
+	}
+	if codeChallenge == "" {
+		negotiation.Quirks = append(negotiation.Quirks, QuirkMissingPKCE)
+	}
+	if clientID != "" && redirectURI != "" && !s.clientStore.ValidateRedirectURI(clientID, redirectURI) {
+		negotiation.Quirks = append(negotiation.Quirks, QuirkUnregisteredRedirect)
+	}
+	s.recorder.Record(negotiation)
 
 	// Validate required parameters
 	if clientID == "" || redirectURI == "" || responseType == "" {
@@ -256,14 +326,21 @@ func (s *OAuthServer) authorizeGet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Tokens are only issued for this server (RFC 8707)
+	if resource != "" && !s.validResource(resource) {
+		s.logAuthEvent(r, "auth_failed", clientID, "resource is not this server")
+		redirectWithError(w, r, redirectURI, state, "invalid_target", "resource must be this server's URL")
+		return
+	}
+
 	// If no PIN required, auto-approve
 	if s.authorizePin == "" {
-		s.issueAuthorizationCode(w, r, clientID, redirectURI, state, codeChallenge, codeChallengeMethod)
+		s.issueAuthorizationCode(w, r, clientID, redirectURI, state, codeChallenge, codeChallengeMethod, resource)
 		return
 	}
 
 	// Show authorization page with PIN entry
-	s.renderAuthorizePage(w, clientID, redirectURI, state, codeChallenge, codeChallengeMethod)
+	s.renderAuthorizePage(w, clientID, redirectURI, state, codeChallenge, codeChallengeMethod, resource)
 }
 
 func (s *OAuthServer) authorizePost(w http.ResponseWriter, r *http.Request) {
@@ -278,29 +355,50 @@ func (s *OAuthServer) authorizePost(w http.ResponseWriter, r *http.Request) {
 	state := r.FormValue("state")
 	codeChallenge := r.FormValue("code_challenge")
 	codeChallengeMethod := r.FormValue("code_challenge_method")
+	resource := r.FormValue("resource")
 	action := r.FormValue("action")
 
 	// Check if user denied
 	if action == "deny" {
-		logAuthEvent("auth_denied", clientID, "user denied")
+		s.logAuthEvent(r, "auth_denied", clientID, "user denied")
 		redirectWithError(w, r, redirectURI, state, "access_denied", "User denied the request")
 		return
 	}
 
-	// Validate PIN if required
+	if resource != "" && !s.validResource(resource) {
+		s.logAuthEvent(r, "auth_failed", clientID, "resource is not this server")
+		redirectWithError(w, r, redirectURI, state, "invalid_target", "resource must be this server's URL")
+		return
+	}
+
+	// Validate PIN if required, refusing attempts while locked out
 	if s.authorizePin != "" {
+		ip := s.proxies.ClientIP(r)
+		if wait := s.pinLockout.Check(ip); wait > 0 {
+			s.logAuthEvent(r, "auth_locked", clientID, "PIN attempt during lockout")
+			w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds()+0.5)))
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusTooManyRequests)
+			s.renderAuthorizePageWithError(w, clientID, redirectURI, state, codeChallenge, codeChallengeMethod, resource, "Too many failed attempts. Try again in "+formatWait(wait)+".")
+			return
+		}
 		if subtle.ConstantTimeCompare([]byte(pin), []byte(s.authorizePin)) != 1 {
-			logAuthEvent("auth_failed", clientID, "invalid PIN")
+			detail := "invalid PIN"
+			if wait := s.pinLockout.Fail(ip); wait > 0 {
+				detail = "invalid PIN; locked out for " + formatWait(wait)
+			}
+			s.logAuthEvent(r, "auth_failed", clientID, detail)
 			// Re-render page with error
-			s.renderAuthorizePageWithError(w, clientID, redirectURI, state, codeChallenge, codeChallengeMethod, "Invalid PIN")
+			s.renderAuthorizePageWithError(w, clientID, redirectURI, state, codeChallenge, codeChallengeMethod, resource, "Invalid PIN")
 			return
 		}
+		s.pinLockout.Succeed(ip)
 	}
 
-	s.issueAuthorizationCode(w, r, clientID, redirectURI, state, codeChallenge, codeChallengeMethod)
+	s.issueAuthorizationCode(w, r, clientID, redirectURI, state, codeChallenge, codeChallengeMethod, resource)
 }
 
-func (s *OAuthServer) issueAuthorizationCode(w http.ResponseWriter, r *http.Request, clientID, redirectURI, state, codeChallenge, codeChallengeMethod string) {
+func (s *OAuthServer) issueAuthorizationCode(w http.ResponseWriter, r *http.Request, clientID, redirectURI, state, codeChallenge, codeChallengeMethod, resource string) {
 	// Generate authorization code
 	code, err := generateSecureToken()
 	if err != nil {
@@ -315,24 +413,25 @@ func (s *OAuthServer) issueAuthorizationCode(w http.ResponseWriter, r *http.Requ
 		RedirectURI:         redirectURI,
 		CodeChallenge:       codeChallenge,
 		CodeChallengeMethod: codeChallengeMethod,
-		ExpiresAt:           time.Now().Add(5 * time.Minute), // Short-lived
+		Resource:            resource,
+		ExpiresAt:           s.clock.Now().Add(5 * time.Minute), // Short-lived
 	})
 
-	logAuthEvent("auth_code_issued", clientID, "")
+	s.logAuthEvent(r, "auth_code_issued", clientID, "")
 
 	// Redirect back to client with code
-	redirectURL := redirectURI + "?code=" + code
+	params := url.Values{"code": {code}}
 	if state != "" {
-		redirectURL += "&state=" + state
+		params.Set("state", state)
 	}
-	http.Redirect(w, r, redirectURL, http.StatusFound)
+	redirectTo(w, r, redirectURI, params)
 }
 
-func (s *OAuthServer) renderAuthorizePage(w http.ResponseWriter, clientID, redirectURI, state, codeChallenge, codeChallengeMethod string) {
-	s.renderAuthorizePageWithError(w, clientID, redirectURI, state, codeChallenge, codeChallengeMethod, "")
+func (s *OAuthServer) renderAuthorizePage(w http.ResponseWriter, clientID, redirectURI, state, codeChallenge, codeChallengeMethod, resource string) {
+	s.renderAuthorizePageWithError(w, clientID, redirectURI, state, codeChallenge, codeChallengeMethod, resource, "")
 }
 
-func (s *OAuthServer) renderAuthorizePageWithError(w http.ResponseWriter, clientID, redirectURI, state, codeChallenge, codeChallengeMethod, errorMsg string) {
+func (s *OAuthServer) renderAuthorizePageWithError(w http.ResponseWriter, clientID, redirectURI, state, codeChallenge, codeChallengeMethod, resource, errorMsg string) {
 	client := s.clientStore.Get(clientID)
 	clientName := clientID
 	if client != nil {
@@ -346,12 +445,13 @@ func (s *OAuthServer) renderAuthorizePageWithError(w http.ResponseWriter, client
 		"State":               state,
 		"CodeChallenge":       codeChallenge,
 		"CodeChallengeMethod": codeChallengeMethod,
+		"Resource":            resource,
 		"Error":               errorMsg,
 		"PinRequired":         "true",
 	}
 
 	w.Header().Set("Content-Type", "text/html")
-	if err := authorizeTemplate.Execute(w, data); err != nil {
+	if err := assets.AuthorizeTemplate.Execute(w, data); err != nil {
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
 	}
 }
@@ -370,11 +470,40 @@ func (s *OAuthServer) Token(w http.ResponseWriter, r *http.Request) {
 
 	grantType := r.FormValue("grant_type")
 
+	// Confidential clients authenticate with their secret (RFC 6749 section 2.3.1)
+	clientID, authenticated, err := s.authenticateClient(r)
+	if err != nil {
+		s.logAuthEvent(r, "token_failed", clientID, err.Error())
+		s.clientAuthError(w, r)
+		return
+	}
+	if clientID != "" {
+		r.Form.Set("client_id", clientID)
+	}
+
+	negotiation := ClientNegotiation{
+		Event:     "token",
+		Endpoint:  r.URL.Path,
+		ClientID:  clientID,
+		UserAgent: r.UserAgent(),
+		GrantType: grantType,
+		Resource:  r.FormValue("resource"),
+	}
+	if negotiation.Resource != "" {
+		negotiation.Quirks = append(negotiation.Quirks, QuirkResourceParam)
+	}
+	if grantType == "refresh_token" && negotiation.ClientID == "" {
+		negotiation.Quirks = append(negotiation.Quirks, QuirkRefreshNoClientID)
+	}
+	s.recorder.Record(negotiation)
+
 	switch grantType {
 	case "authorization_code":
 		s.handleAuthorizationCodeGrant(w, r)
 	case "refresh_token":
-		s.handleRefreshTokenGrant(w, r)
+		s.handleRefreshTokenGrant(w, r, authenticated)
+	case "client_credentials":
+		s.handleClientCredentialsGrant(w, r, clientID, authenticated)
 	default:
 		s.tokenError(w, "unsupported_grant_type", "Grant type not supported")
 	}
@@ -394,37 +523,46 @@ func (s *OAuthServer) handleAuthorizationCodeGrant(w http.ResponseWriter, r *htt
 	// Retrieve and validate authorization code
 	authCode := s.authCodes.Get(code)
 	if authCode == nil {
-		logAuthEvent("token_failed", clientID, "invalid or expired code")
+		s.logAuthEvent(r, "token_failed", clientID, "invalid or expired code")
 		s.tokenError(w, "invalid_grant", "Invalid or expired authorization code")
 		return
 	}
 
 	// Validate client_id matches
 	if authCode.ClientID != clientID {
-		logAuthEvent("token_failed", clientID, "client ID mismatch")
+		s.logAuthEvent(r, "token_failed", clientID, "client ID mismatch")
 		s.tokenError(w, "invalid_grant", "Client ID mismatch")
 		return
 	}
 
 	// Validate redirect_uri matches
 	if authCode.RedirectURI != redirectURI {
-		logAuthEvent("token_failed", clientID, "redirect URI mismatch")
+		s.logAuthEvent(r, "token_failed", clientID, "redirect URI mismatch")
 		s.tokenError(w, "invalid_grant", "Redirect URI mismatch")
 		return
 	}
 
 	// Validate PKCE code_verifier
 	if !validatePKCE(codeVerifier, authCode.CodeChallenge) {
-		logAuthEvent("token_failed", clientID, "invalid PKCE verifier")
+		s.logAuthEvent(r, "token_failed", clientID, "invalid PKCE verifier")
 		s.tokenError(w, "invalid_grant", "Invalid code_verifier")
 		return
 	}
 
-	// Generate tokens
-	s.issueTokens(w, clientID)
+	// Generate tokens, echoing the resource from the authorize request if the token request omits it
+	resource := r.FormValue("resource")
+	if resource != "" && !s.validResource(resource) {
+		s.logAuthEvent(r, "token_failed", clientID, "resource is not this server")
+		s.tokenError(w, "invalid_target", "resource must be this server's URL")
+		return
+	}
+	if resource == "" {
+		resource = authCode.Resource
+	}
+	s.issueTokens(w, r, clientID, resource)
 }
 
-func (s *OAuthServer) handleRefreshTokenGrant(w http.ResponseWriter, r *http.Request) {
+func (s *OAuthServer) handleRefreshTokenGrant(w http.ResponseWriter, r *http.Request, authenticated bool) {
 	refreshToken := r.FormValue("refresh_token")
 	clientID := r.FormValue("client_id")
 
@@ -433,46 +571,72 @@ func (s *OAuthServer) handleRefreshTokenGrant(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if clientID == "" && !s.compat.AllowRefreshWithoutClientID {
+		s.logAuthEvent(r, "refresh_failed", "", "missing client_id")
+		s.tokenError(w, "invalid_request", "Missing client_id")
+		return
+	}
+
 	// Validate refresh token
 	tokenInfo := s.tokenStore.ValidateRefreshToken(refreshToken)
 	if tokenInfo == nil {
-		logAuthEvent("refresh_failed", clientID, "invalid or expired token")
+		s.logAuthEvent(r, "refresh_failed", clientID, "invalid or expired token")
 		s.tokenError(w, "invalid_grant", "Invalid or expired refresh token")
 		return
 	}
 
 	// Validate client_id if provided
 	if clientID != "" && tokenInfo.ClientID != clientID {
-		logAuthEvent("refresh_failed", clientID, "client ID mismatch")
+		s.logAuthEvent(r, "refresh_failed", clientID, "client ID mismatch")
 		s.tokenError(w, "invalid_grant", "Client ID mismatch")
 		return
 	}
 
+	// A confidential client's refresh token is only good with its secret
+	if !authenticated && s.clientStore.Get(tokenInfo.ClientID).Confidential() {
+		s.logAuthEvent(r, "refresh_failed", tokenInfo.ClientID, "missing client secret")
+		s.clientAuthError(w, r)
+		return
+	}
+
+	// The refresh token must have been issued for this server, and so must
+	// the new tokens
+	if tokenInfo.Audience != "" && tokenInfo.Audience != s.audience {
+		s.logAuthEvent(r, "refresh_failed", clientID, "audience mismatch")
+		s.tokenError(w, "invalid_grant", "Refresh token was issued for another resource")
+		return
+	}
+	if resource := r.FormValue("resource"); resource != "" && !s.validResource(resource) {
+		s.logAuthEvent(r, "refresh_failed", clientID, "resource is not this server")
+		s.tokenError(w, "invalid_target", "resource must be this server's URL")
+		return
+	}
+
 	// Issue new tokens (rotate refresh token for security)
 	s.tokenStore.RevokeToken(refreshToken)
-	logAuthEvent("token_refreshed", tokenInfo.ClientID, "")
-	s.issueTokens(w, tokenInfo.ClientID)
+	s.logAuthEvent(r, "token_refreshed", tokenInfo.ClientID, "")
+	s.issueTokens(w, r, tokenInfo.ClientID, r.FormValue("resource"))
 }
 
-func (s *OAuthServer) issueTokens(w http.ResponseWriter, clientID string) {
+func (s *OAuthServer) issueTokens(w http.ResponseWriter, r *http.Request, clientID, resource string) {
 	// Generate refresh token first
-	refreshToken, _, err := s.tokenStore.GenerateRefreshToken(clientID)
+	refreshToken, _, err := s.tokenStore.GenerateRefreshToken(clientID, s.audience)
 	if err != nil {
 		s.tokenError(w, "server_error", "Failed to generate tokens")
 		return
 	}
 
 	// Generate access token linked to refresh token
-	accessToken, expiresAt, err := s.tokenStore.GenerateAccessToken(clientID, refreshToken)
+	accessToken, expiresAt, err := s.tokenStore.GenerateAccessToken(clientID, refreshToken, s.audience)
 	if err != nil {
 		s.tokenError(w, "server_error", "Failed to generate tokens")
 		return
 	}
 
 	// Calculate expires_in
-	expiresIn := int(time.Until(expiresAt).Seconds())
+	expiresIn := int(expiresAt.Sub(s.clock.Now()).Seconds())
 
-	logAuthEvent("token_issued", clientID, "")
+	s.logAuthEvent(r, "token_issued", clientID, "")
 
 	response := map[string]any{
 		"access_token":  accessToken,
@@ -481,6 +645,9 @@ func (s *OAuthServer) issueTokens(w http.ResponseWriter, clientID string) {
 		"refresh_token": refreshToken,
 		"scope":         "mcp:read mcp:write",
 	}
+	if s.compat.EchoResource && resource != "" {
+		response["resource"] = resource
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Cache-Control", "no-store")
@@ -495,9 +662,10 @@ func (s *OAuthServer) Register(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req struct {
-		ClientName   string   `json:"client_name"`
-		RedirectURIs []string `json:"redirect_uris"`
-		GrantTypes   []string `json:"grant_types"`
+		ClientName              string   `json:"client_name"`
+		RedirectURIs            []string `json:"redirect_uris"`
+		GrantTypes              []string `json:"grant_types"`
+		TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -505,7 +673,50 @@ func (s *OAuthServer) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(req.RedirectURIs) == 0 {
+	authMethod := req.TokenEndpointAuthMethod
+	switch authMethod {
+	case "":
+		authMethod = AuthMethodNone
+	case AuthMethodNone, AuthMethodSecretPost, AuthMethodSecretBasic:
+	default:
+		s.registrationError(w, "invalid_client_metadata", "Unsupported token_endpoint_auth_method")
+		return
+	}
+	confidential := authMethod != AuthMethodNone
+
+	// A confidential client gets tokens without the PIN, so registering one
+	// takes the server's own token (RFC 7591 section 3)
+	if confidential && !s.validRegistrationToken(r) {
+		s.logAuthEvent(r, "registration_failed", "", "confidential client without initial access token")
+		w.Header().Set("WWW-Authenticate", `Bearer realm="momentum"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error":             "invalid_token",
+			"error_description": "Registering a confidential client requires the server's auth token as a bearer token",
+		})
+		return
+	}
+
+	// Unknown grants are dropped rather than refused, as before;
+	// client_credentials only makes sense with a secret
+	var grantTypes []string
+	for _, g := range req.GrantTypes {
+		switch g {
+		case GrantAuthorizationCode, GrantRefreshToken:
+			grantTypes = append(grantTypes, g)
+		case GrantClientCredentials:
+			if confidential {
+				grantTypes = append(grantTypes, g)
+			}
+		}
+	}
+	if len(grantTypes) == 0 {
+		grantTypes = defaultGrantTypes
+	}
+	client := &ClientInfo{ClientName: req.ClientName, GrantTypes: grantTypes}
+
+	if client.AllowsGrant(GrantAuthorizationCode) && len(req.RedirectURIs) == 0 {
 		s.registrationError(w, "invalid_redirect_uri", "At least one redirect_uri is required")
 		return
 	}
@@ -519,21 +730,40 @@ func (s *OAuthServer) Register(w http.ResponseWriter, r *http.Request) {
 	// Use shorter client ID
 	clientID = clientID[:16]
 
-	client := &ClientInfo{
-		ClientID:     clientID,
-		ClientName:   req.ClientName,
-		RedirectURIs: req.RedirectURIs,
-		CreatedAt:    time.Now(),
+	client.ClientID = clientID
+	client.RedirectURIs = req.RedirectURIs
+	client.CreatedAt = s.clock.Now()
+
+	var secret string
+	if confidential {
+		if secret, err = generateSecureToken(); err != nil {
+			http.Error(w, "Failed to generate client secret", http.StatusInternalServerError)
+			return
+		}
+		client.AuthMethod = authMethod
+		client.SecretHash = hashSecret(secret)
 	}
 	s.clientStore.Register(client)
-	logAuthEvent("client_registered", clientID, req.ClientName)
+	s.logAuthEvent(r, "client_registered", clientID, req.ClientName)
+	s.recorder.Record(ClientNegotiation{
+		Event:     "register",
+		Endpoint:  r.URL.Path,
+		ClientID:  clientID,
+		UserAgent: r.UserAgent(),
+	})
 
 	response := map[string]any{
 		"client_id":                clientID,
 		"client_name":              req.ClientName,
 		"redirect_uris":            req.RedirectURIs,
-		"grant_types":              []string{"authorization_code", "refresh_token"},
-		"token_endpoint_auth_method": "none",
+		"grant_types":              grantTypes,
+		"token_endpoint_auth_method": authMethod,
+	}
+	if confidential {
+		// The secret is only ever shown here; the server keeps its hash
+		response["client_secret"] = secret
+		response["client_secret_expires_at"] = 0
+		response["client_id_issued_at"] = client.CreatedAt.Unix()
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -572,11 +802,29 @@ func (s *OAuthServer) registrationError(w http.ResponseWriter, errorCode, descri
 }
 
 func redirectWithError(w http.ResponseWriter, r *http.Request, redirectURI, state, errorCode, description string) {
-	url := redirectURI + "?error=" + errorCode + "&error_description=" + description
+	params := url.Values{"error": {errorCode}, "error_description": {description}}
 	if state != "" {
-		url += "&state=" + state
+		params.Set("state", state)
+	}
+	redirectTo(w, r, redirectURI, params)
+}
+
+// redirectTo redirects to redirectURI with params added to its query.
+// Parameters already in the URI are kept (RFC 6749 section 3.1.2), and
+// every value is escaped, so a state is returned exactly as it was sent.
+func redirectTo(w http.ResponseWriter, r *http.Request, redirectURI string, params url.Values) {
+	u, err := url.Parse(redirectURI)
+	if err != nil || !u.IsAbs() {
+		http.Error(w, "Invalid redirect_uri", http.StatusBadRequest)
+		return
 	}
-	http.Redirect(w, r, url, http.StatusFound)
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+	u.Fragment = ""
+	http.Redirect(w, r, u.String(), http.StatusFound)
 }
 
 // validatePKCE validates the code_verifier against the code_challenge using S256.
@@ -586,75 +834,3 @@ func validatePKCE(verifier, challenge string) bool {
 	computed := base64.RawURLEncoding.EncodeToString(h[:])
 	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
 }
-
-// Simple HTML template for the authorize page
-var authorizeTemplate = template.Must(template.New("authorize").Parse(`
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="utf-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1">
-    <title>Authorize - Momentum MCP Server</title>
-    <style>
-        body {
-            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif;
-            max-width: 400px;
-            margin: 50px auto;
-            padding: 20px;
-            background: #f5f5f5;
-        }
-        .card {
-            background: white;
-            border-radius: 8px;
-            padding: 24px;
-            box-shadow: 0 2px 4px rgba(0,0,0,0.1);
-        }
-        h1 { font-size: 1.5em; margin-top: 0; }
-        .client-name { color: #0066cc; font-weight: bold; }
-        .error { color: #cc0000; margin-bottom: 16px; }
-        input[type="text"] {
-            width: 100%;
-            padding: 12px;
-            margin: 8px 0 16px;
-            border: 1px solid #ddd;
-            border-radius: 4px;
-            font-size: 1em;
-            box-sizing: border-box;
-        }
-        .buttons { display: flex; gap: 12px; }
-        button {
-            flex: 1;
-            padding: 12px;
-            border: none;
-            border-radius: 4px;
-            font-size: 1em;
-            cursor: pointer;
-        }
-        .approve { background: #0066cc; color: white; }
-        .deny { background: #f0f0f0; color: #333; }
-    </style>
-</head>
-<body>
-    <div class="card">
-        <h1>Authorization Request</h1>
-        <p><span class="client-name">{{.ClientName}}</span> wants to access your Momentum MCP Server.</p>
-        {{if .Error}}<p class="error">{{.Error}}</p>{{end}}
-        <form method="POST">
-            <input type="hidden" name="client_id" value="{{.ClientID}}">
-            <input type="hidden" name="redirect_uri" value="{{.RedirectURI}}">
-            <input type="hidden" name="state" value="{{.State}}">
-            <input type="hidden" name="code_challenge" value="{{.CodeChallenge}}">
-            <input type="hidden" name="code_challenge_method" value="{{.CodeChallengeMethod}}">
-            {{if eq .PinRequired "true"}}
-            <label for="pin">Enter PIN to authorize:</label>
-            <input type="text" id="pin" name="pin" autocomplete="off" autofocus>
-            {{end}}
-            <div class="buttons">
-                <button type="submit" name="action" value="deny" class="deny">Deny</button>
-                <button type="submit" name="action" value="approve" class="approve">Approve</button>
-            </div>
-        </form>
-    </div>
-</body>
-</html>
-`))
@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"encoding/json"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testRedirectURI = "https://app.example/callback?tenant=a%26b&v=1"
+
+// trickyStates are state values that hand-built query strings mangle.
+var trickyStates = []string{
+	"simple",
+	"a&code=forged",
+	"x#fragment",
+	"100% sure",
+	"spaces and +plus",
+	"état/éé?=",
+	`"quoted" <tag>`,
+}
+
+func newTestOAuthServer(pin string) *OAuthServer {
+	clients := NewClientStore()
+	clients.Register(&ClientInfo{ClientID: "app", ClientName: "App", RedirectURIs: []string{testRedirectURI}})
+	return NewOAuthServer(OAuthConfig{
+		TokenStore:   NewTokenStore(time.Hour, time.Hour, nil),
+		ClientStore:  clients,
+		BaseURL:      "https://momentum.example",
+		AuthorizePin: pin,
+	})
+}
+
+func authorizeQuery(state string) url.Values {
+	return url.Values{
+		"client_id":             {"app"},
+		"redirect_uri":          {testRedirectURI},
+		"response_type":         {"code"},
+		"state":                 {state},
+		"code_challenge":        {"challenge"},
+		"code_challenge_method": {"S256"},
+	}
+}
+
+// redirectQuery checks rec is a redirect to testRedirectURI and returns its
+// query.
+func redirectQuery(t *testing.T, rec *httptest.ResponseRecorder) url.Values {
+	t.Helper()
+	if rec.Code != http.StatusFound {
+		t.Fatalf("status = %d, want a redirect; body %s", rec.Code, rec.Body)
+	}
+	loc, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.Fragment != "" || loc.Scheme+"://"+loc.Host+loc.Path != "https://app.example/callback" {
+		t.Errorf("redirected to %s", loc)
+	}
+	q := loc.Query()
+	// Parameters already on the redirect URI survive
+	if q.Get("tenant") != "a&b" || q.Get("v") != "1" {
+		t.Errorf("redirect URI parameters lost: %s", loc.RawQuery)
+	}
+	return q
+}
+
+func TestAuthorize_StateRoundTrips(t *testing.T) {
+	s := newTestOAuthServer("")
+	for _, state := range trickyStates {
+		rec := httptest.NewRecorder()
+		s.Authorize(rec, httptest.NewRequest(http.MethodGet, "/authorize?"+authorizeQuery(state).Encode(), nil))
+		q := redirectQuery(t, rec)
+		if q.Get("state") != state || q.Get("code") == "" || len(q["code"]) != 1 {
+			t.Errorf("state %q came back as %q with code %q", state, q.Get("state"), q["code"])
+		}
+	}
+}
+
+var stateField = regexp.MustCompile(`name="state" value="([^"]*)"`)
+
+func TestAuthorize_StateRoundTripsThroughPinPage(t *testing.T) {
+	s := newTestOAuthServer("1234")
+	for _, state := range trickyStates {
+		rec := httptest.NewRecorder()
+		s.Authorize(rec, httptest.NewRequest(http.MethodGet, "/authorize?"+authorizeQuery(state).Encode(), nil))
+		m := stateField.FindStringSubmatch(rec.Body.String())
+		if m == nil {
+			t.Fatalf("no state field on the PIN page")
+		}
+
+		// Submit the form as a browser would
+		form := authorizeQuery(html.UnescapeString(m[1]))
+		form.Set("pin", "1234")
+		req := httptest.NewRequest(http.MethodPost, "/authorize", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec = httptest.NewRecorder()
+		s.Authorize(rec, req)
+		if got := redirectQuery(t, rec).Get("state"); got != state {
+			t.Errorf("state %q came back as %q", state, got)
+		}
+	}
+}
+
+func TestRedirectWithError_Escapes(t *testing.T) {
+	for _, state := range trickyStates {
+		rec := httptest.NewRecorder()
+		redirectWithError(rec, httptest.NewRequest(http.MethodGet, "/", nil), testRedirectURI, state, "access_denied", "User denied & left #1")
+		q := redirectQuery(t, rec)
+		if q.Get("error") != "access_denied" || q.Get("error_description") != "User denied & left #1" || q.Get("state") != state {
+			t.Errorf("error redirect query = %v", q)
+		}
+	}
+
+	// No state, no state parameter
+	rec := httptest.NewRecorder()
+	redirectWithError(rec, httptest.NewRequest(http.MethodGet, "/", nil), testRedirectURI, "", "access_denied", "denied")
+	if _, ok := redirectQuery(t, rec)["state"]; ok {
+		t.Error("empty state sent")
+	}
+
+	// A redirect URI that isn't absolute isn't followed
+	rec = httptest.NewRecorder()
+	redirectWithError(rec, httptest.NewRequest(http.MethodGet, "/", nil), "/relative", "", "access_denied", "denied")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("relative redirect URI status = %d", rec.Code)
+	}
+}
+
+// registerClient posts a registration with the given bearer token and
+// returns the response.
+func registerClient(s *OAuthServer, bearer, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(body))
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	rec := httptest.NewRecorder()
+	s.Register(rec, req)
+	return rec
+}
+
+func tokenRequest(s *OAuthServer, form url.Values, basicID, basicSecret string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if basicID != "" {
+		req.SetBasicAuth(url.QueryEscape(basicID), url.QueryEscape(basicSecret))
+	}
+	rec := httptest.NewRecorder()
+	s.Token(rec, req)
+	return rec
+}
+
+func TestRegister_ConfidentialClientNeedsToken(t *testing.T) {
+	s := newTestOAuthServer("")
+	s.registrationToken = "admin-token"
+	body := `{"client_name":"cron","token_endpoint_auth_method":"client_secret_basic","grant_types":["client_credentials"]}`
+
+	for _, bearer := range []string{"", "wrong"} {
+		if rec := registerClient(s, bearer, body); rec.Code != http.StatusUnauthorized {
+			t.Errorf("bearer %q: status = %d", bearer, rec.Code)
+		}
+	}
+
+	// Public clients still register without one, and can't take client_credentials
+	rec := registerClient(s, "", `{"redirect_uris":["https://a.example/cb"],"grant_types":["client_credentials"]}`)
+	var public struct {
+		ClientSecret string   `json:"client_secret"`
+		GrantTypes   []string `json:"grant_types"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &public)
+	if rec.Code != http.StatusCreated || public.ClientSecret != "" || strings.Join(public.GrantTypes, ",") != "authorization_code,refresh_token" {
+		t.Errorf("public registration = %d %s", rec.Code, rec.Body)
+	}
+}
+
+func TestClientCredentialsGrant(t *testing.T) {
+	s := newTestOAuthServer("")
+	s.registrationToken = "admin-token"
+	rec := registerClient(s, "admin-token", `{"client_name":"cron","token_endpoint_auth_method":"client_secret_basic","grant_types":["client_credentials"]}`)
+	var reg struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &reg); err != nil || rec.Code != http.StatusCreated || reg.ClientSecret == "" {
+		t.Fatalf("registration = %d %s", rec.Code, rec.Body)
+	}
+	if stored := s.clientStore.Get(reg.ClientID); stored.SecretHash == reg.ClientSecret {
+		t.Error("client secret stored in the clear")
+	}
+
+	grant := url.Values{"grant_type": {"client_credentials"}}
+	posted := url.Values{"grant_type": {"client_credentials"}, "client_id": {reg.ClientID}, "client_secret": {reg.ClientSecret}}
+	validator := NewOAuthTokenValidator(s.tokenStore, s.Audience())
+	for name, rec := range map[string]*httptest.ResponseRecorder{
+		"basic": tokenRequest(s, grant, reg.ClientID, reg.ClientSecret),
+		"post":  tokenRequest(s, posted, "", ""),
+	} {
+		var tok map[string]any
+		json.Unmarshal(rec.Body.Bytes(), &tok)
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: status = %d %s", name, rec.Code, rec.Body)
+			continue
+		}
+		if _, ok := tok["refresh_token"]; ok {
+			t.Errorf("%s: client_credentials issued a refresh token", name)
+		}
+		access, _ := tok["access_token"].(string)
+		if !validator.ValidateToken(access) {
+			t.Errorf("%s: issued token doesn't validate", name)
+		}
+	}
+
+	// Wrong or missing secrets are refused
+	if rec := tokenRequest(s, grant, reg.ClientID, "wrong"); rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+		t.Errorf("wrong basic secret: status = %d", rec.Code)
+	}
+	posted.Set("client_secret", "wrong")
+	if rec := tokenRequest(s, posted, "", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrong posted secret: status = %d", rec.Code)
+	}
+	posted.Del("client_secret")
+	if rec := tokenRequest(s, posted, "", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("missing secret: status = %d", rec.Code)
+	}
+
+	// Public clients can't use the grant
+	public := url.Values{"grant_type": {"client_credentials"}, "client_id": {"app"}}
+	if rec := tokenRequest(s, public, "", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("public client: status = %d", rec.Code)
+	}
+}
@@ -2,14 +2,19 @@
 package auth
 
 import (
+	"bytes"
 	"encoding/json"
-	"log"
+	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
 )
 
+// StateFileName is the OAuth state file's name in the data directory.
+const StateFileName = "oauth_state.json"
+
 // PersistentData holds all data that survives server restarts.
 type PersistentData struct {
 	Tokens  map[string]*TokenInfo  `json:"tokens"`
@@ -24,6 +29,16 @@ type Persistence struct {
 	tokens   *TokenStore
 	clients  *ClientStore
 
+	// key, if set, encrypts the file; oldKeys can still decrypt it
+	key     []byte
+	oldKeys [][]byte
+	// resave is set by Load when the file isn't sealed with key
+	resave bool
+	// loadErr is set when an existing file couldn't be loaded, say with a
+	// missing or wrong key. Saving is then refused, as it would replace
+	// every persisted client and token with the empty in-memory state.
+	loadErr error
+
 	// For periodic saves
 	saveInterval time.Duration
 	stopCh       chan struct{}
@@ -40,35 +55,54 @@ func NewPersistence(dataDir string, tokens *TokenStore, clients *ClientStore) *P
 	}
 
 	if dataDir != "" {
-		p.filePath = filepath.Join(dataDir, "oauth_state.json")
+		p.filePath = filepath.Join(dataDir, StateFileName)
 	}
 
 	return p
 }
 
+// SetEncryptionKeys encrypts the state file with key (AES-256-GCM) from the
+// next save on. oldKeys are previous keys, tried when loading so a key can
+// be rotated: the file is re-encrypted with key on start. A nil key saves
+// plaintext, decrypting a file sealed with one of oldKeys.
+func (p *Persistence) SetEncryptionKeys(key []byte, oldKeys ...[]byte) {
+	p.key = key
+	p.oldKeys = oldKeys
+}
+
 // Start begins periodic saving and loads existing state.
 func (p *Persistence) Start() error {
 	if p.filePath == "" {
-		log.Println("Persistence disabled (no data directory configured)")
+		slog.Info("persistence disabled (no data directory configured)")
 		return nil
 	}
 
-	// Load existing state
+	// Load existing state. A missing file is a first run; any other error
+	// leaves the file alone and persistence off until it's fixed.
 	if err := p.Load(); err != nil {
-		// Log but don't fail - might be first run
-		log.Printf("Could not load persisted state (may be first run): %v", err)
+		return fmt.Errorf("loading %s (persistence disabled, the file is left as is): %w", p.filePath, err)
+	}
+
+	// Rewrite the file straight away if it was sealed with another key (or
+	// none), so a rotated-out key can be dropped after one restart
+	if p.resave {
+		if err := p.Save(); err != nil {
+			slog.Error("re-encrypting oauth state failed", "error", err)
+		} else {
+			slog.Info("oauth state re-encrypted with the current key")
+		}
 	}
 
 	// Start periodic save goroutine
 	go p.periodicSave()
 
-	log.Printf("Persistence enabled: %s", p.filePath)
+	slog.Info("persistence enabled", "path", p.filePath)
 	return nil
 }
 
 // Stop performs a final save and stops periodic saving.
 func (p *Persistence) Stop() {
-	if p.filePath == "" {
+	if p.filePath == "" || p.failedLoad() {
 		return
 	}
 
@@ -76,9 +110,9 @@ func (p *Persistence) Stop() {
 
 	// Final save
 	if err := p.Save(); err != nil {
-		log.Printf("Error during final save: %v", err)
+		slog.Error("final save failed", "error", err)
 	} else {
-		log.Println("OAuth state saved successfully")
+		slog.Info("oauth state saved")
 	}
 }
 
@@ -91,21 +125,35 @@ func (p *Persistence) Load() error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	p.loadErr = nil
 	data, err := os.ReadFile(p.filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil // No file yet, that's OK
 		}
+		p.loadErr = err
+		return err
+	}
+
+	keys := p.oldKeys
+	if p.key != nil {
+		keys = append([][]byte{p.key}, keys...)
+	}
+	data, sealedWith, err := openState(data, keys)
+	if err != nil {
+		p.loadErr = err
 		return err
 	}
 
 	var persisted PersistentData
 	if err := json.Unmarshal(data, &persisted); err != nil {
+		p.loadErr = err
 		return err
 	}
+	p.resave = !bytes.Equal(sealedWith, p.key)
 
 	// Load tokens (only non-expired ones)
-	now := time.Now()
+	now := p.tokens.clock.Now()
 	loadedTokens := 0
 	for token, info := range persisted.Tokens {
 		if now.Before(info.ExpiresAt) {
@@ -127,8 +175,12 @@ func (p *Persistence) Load() error {
 		}
 	}
 
-	log.Printf("Loaded %d tokens and %d clients from %s (saved at %s)",
-		loadedTokens, loadedClients, p.filePath, persisted.SavedAt.Format(time.RFC3339))
+	slog.Info("loaded persisted oauth state",
+		"tokens", loadedTokens,
+		"clients", loadedClients,
+		"path", p.filePath,
+		"saved_at", persisted.SavedAt.Format(time.RFC3339),
+	)
 
 	return nil
 }
@@ -141,11 +193,14 @@ func (p *Persistence) Save() error {
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if p.loadErr != nil {
+		return fmt.Errorf("not saving over oauth state that failed to load: %w", p.loadErr)
+	}
 
 	// Gather tokens
 	p.tokens.mu.RLock()
 	tokens := make(map[string]*TokenInfo, len(p.tokens.tokens))
-	now := time.Now()
+	now := p.tokens.clock.Now()
 	for token, info := range p.tokens.tokens {
 		// Only save non-expired tokens
 		if now.Before(info.ExpiresAt) {
@@ -165,13 +220,18 @@ func (p *Persistence) Save() error {
 	persisted := PersistentData{
 		Tokens:  tokens,
 		Clients: clients,
-		SavedAt: time.Now(),
+		SavedAt: now,
 	}
 
 	data, err := json.MarshalIndent(persisted, "", "  ")
 	if err != nil {
 		return err
 	}
+	if p.key != nil {
+		if data, err = sealState(p.key, data); err != nil {
+			return err
+		}
+	}
 
 	// Ensure directory exists
 	dir := filepath.Dir(p.filePath)
@@ -197,7 +257,7 @@ func (p *Persistence) periodicSave() {
 		select {
 		case <-ticker.C:
 			if err := p.Save(); err != nil {
-				log.Printf("Error during periodic save: %v", err)
+				slog.Error("periodic save failed", "error", err)
 			}
 		case <-p.stopCh:
 			return
@@ -207,12 +267,20 @@ func (p *Persistence) periodicSave() {
 
 // TriggerSave triggers an immediate save (call after important changes).
 func (p *Persistence) TriggerSave() {
-	if p.filePath == "" {
+	if p.filePath == "" || p.failedLoad() {
 		return
 	}
 	go func() {
 		if err := p.Save(); err != nil {
-			log.Printf("Error during triggered save: %v", err)
+			slog.Error("triggered save failed", "error", err)
 		}
 	}()
 }
+
+// failedLoad reports whether an existing state file couldn't be loaded, in
+// which case it must not be saved over.
+func (p *Persistence) failedLoad() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.loadErr != nil
+}
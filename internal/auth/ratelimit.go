@@ -2,8 +2,11 @@
 package auth
 
 import (
+	"fmt"
 	"net"
 	"net/http"
+	"net/netip"
+	"strings"
 	"sync"
 	"time"
 )
@@ -77,11 +80,12 @@ func (rl *RateLimiter) cleanup() {
 	}
 }
 
-// RateLimitMiddleware wraps a handler with rate limiting.
-func RateLimitMiddleware(rl *RateLimiter) func(http.Handler) http.Handler {
+// RateLimitMiddleware wraps a handler with rate limiting, identifying
+// clients behind proxies as ClientIP does.
+func RateLimitMiddleware(rl *RateLimiter, proxies TrustedProxies) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ip := getClientIP(r)
+			ip := proxies.ClientIP(r)
 			if !rl.Allow(ip) {
 				w.Header().Set("Retry-After", "60")
 				http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
@@ -92,26 +96,69 @@ func RateLimitMiddleware(rl *RateLimiter) func(http.Handler) http.Handler {
 	}
 }
 
-// getClientIP extracts the client IP from the request.
-// Handles X-Forwarded-For for proxied requests.
-func getClientIP(r *http.Request) string {
-	// Check X-Forwarded-For header (set by reverse proxies)
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		// Take the first IP (original client)
-		if idx := len(xff); idx > 0 {
-			for i, c := range xff {
-				if c == ',' {
-					return xff[:i]
-				}
+// TrustedProxies are the reverse proxies in front of the server, whose
+// X-Forwarded-For header is believed. Anyone else can send the header, so
+// without them a request is identified by the address it came from.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies parses IP addresses and CIDR ranges, e.g. 10.0.0.0/8.
+func ParseTrustedProxies(list []string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+	for _, s := range list {
+		if !strings.Contains(s, "/") {
+			addr, err := netip.ParseAddr(s)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not an IP address or CIDR range", s)
 			}
-			return xff
+			proxies = append(proxies, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
 		}
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP address or CIDR range", s)
+		}
+		proxies = append(proxies, prefix.Masked())
 	}
+	return proxies, nil
+}
 
-	// Fall back to RemoteAddr
+// trusts reports whether ip is one of the proxies.
+func (t TrustedProxies) trusts(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range t {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the IP of the client that made r. X-Forwarded-For is
+// only read when the request came from a trusted proxy, and then from the
+// right: the first address not itself a trusted proxy is the client, as
+// everything left of it could have been sent by the client.
+func (t TrustedProxies) ClientIP(r *http.Request) string {
 	ip, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
-		return r.RemoteAddr
+		ip = r.RemoteAddr
+	}
+	if !t.trusts(ip) {
+		return ip
+	}
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		ip = hop
+		if !t.trusts(hop) {
+			break
+		}
 	}
 	return ip
 }
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTrustedProxies_ClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "fd00::/8", "192.0.2.7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        []string
+		want       string
+	}{
+		{"direct", "203.0.113.5:4321", nil, "203.0.113.5"},
+		{"header from an untrusted client", "203.0.113.5:4321", []string{"198.51.100.1"}, "203.0.113.5"},
+		{"through a proxy", "10.1.2.3:80", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"spoofed hop before the proxy's", "10.1.2.3:80", []string{"1.2.3.4, 198.51.100.1"}, "198.51.100.1"},
+		{"chained proxies", "10.1.2.3:80", []string{"198.51.100.1, 192.0.2.7", "10.9.9.9"}, "198.51.100.1"},
+		{"IPv6 proxy", "[fd12::1]:80", []string{"2001:db8::5"}, "2001:db8::5"},
+		{"proxy without a header", "10.1.2.3:80", nil, "10.1.2.3"},
+		{"only proxies", "10.1.2.3:80", []string{"10.4.4.4"}, "10.4.4.4"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/token", nil)
+		r.RemoteAddr = tt.remoteAddr
+		for _, v := range tt.xff {
+			r.Header.Add("X-Forwarded-For", v)
+		}
+		if got := proxies.ClientIP(r); got != tt.want {
+			t.Errorf("%s: ClientIP() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// With no trusted proxies the header is never used
+	r := httptest.NewRequest("GET", "/token", nil)
+	r.RemoteAddr = "10.1.2.3:80"
+	r.Header.Set("X-Forwarded-For", "198.51.100.1")
+	if got := TrustedProxies(nil).ClientIP(r); got != "10.1.2.3" {
+		t.Errorf("ClientIP() without proxies = %q", got)
+	}
+}
+
+func TestParseTrustedProxies_Invalid(t *testing.T) {
+	for _, s := range []string{"proxy.internal", "10.0.0.0/33", "10.0.0"} {
+		if _, err := ParseTrustedProxies([]string{s}); err == nil {
+			t.Errorf("ParseTrustedProxies(%q) succeeded", s)
+		}
+	}
+}
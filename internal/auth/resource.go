@@ -0,0 +1,35 @@
+// Package auth implements resource indicators (RFC 8707).
+package auth
+
+import (
+	"net/url"
+	"strings"
+)
+
+// canonicalResource normalizes a resource URI for comparison: scheme and
+// host lower-cased, trailing slash dropped. It reports false for values
+// RFC 8707 rejects - relative URIs and URIs with a fragment.
+func canonicalResource(raw string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || !u.IsAbs() || u.Host == "" || u.Fragment != "" {
+		return "", false
+	}
+	canonical := strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host) + strings.TrimSuffix(u.Path, "/")
+	if u.RawQuery != "" {
+		canonical += "?" + u.RawQuery
+	}
+	return canonical, true
+}
+
+// Audience is the canonical URL of this server. Issued tokens are bound to
+// it, and only tokens bound to it are accepted.
+func (s *OAuthServer) Audience() string {
+	return s.audience
+}
+
+// validResource reports whether a resource indicator names this server:
+// its base URL, or the /mcp endpoint under it.
+func (s *OAuthServer) validResource(resource string) bool {
+	canonical, ok := canonicalResource(resource)
+	return ok && (canonical == s.audience || canonical == s.audience+"/mcp")
+}
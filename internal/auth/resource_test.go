@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestCanonicalResource(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+		ok   bool
+	}{
+		{"https://momentum.example", "https://momentum.example", true},
+		{"HTTPS://Momentum.Example/", "https://momentum.example", true},
+		{" https://momentum.example/mcp/ ", "https://momentum.example/mcp", true},
+		{"https://momentum.example:8443/MCP", "https://momentum.example:8443/MCP", true},
+		{"https://momentum.example/mcp?tenant=a", "https://momentum.example/mcp?tenant=a", true},
+		{"/mcp", "", false},
+		{"momentum.example", "", false},
+		{"https://momentum.example/mcp#frag", "", false},
+		{"file:///etc/passwd", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := canonicalResource(tt.raw)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("canonicalResource(%q) = %q, %v, want %q, %v", tt.raw, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestValidResource(t *testing.T) {
+	s := newTestOAuthServer("")
+	if s.Audience() != "https://momentum.example" {
+		t.Fatalf("Audience() = %q", s.Audience())
+	}
+	for resource, want := range map[string]bool{
+		"https://momentum.example":          true,
+		"https://MOMENTUM.example/":         true,
+		"https://momentum.example/mcp":      true,
+		"https://momentum.example/mcp/":     true,
+		"https://momentum.example/other":    false,
+		"https://other.example":             false,
+		"https://other.example/mcp":         false,
+		"http://momentum.example":           false,
+		"https://momentum.example/mcp#x":    false,
+		"/mcp":                              false,
+		"https://momentum.example.evil.com": false,
+	} {
+		if got := s.validResource(resource); got != want {
+			t.Errorf("validResource(%q) = %v, want %v", resource, got, want)
+		}
+	}
+}
+
+// tokenErrorCode returns the error code of a token endpoint error response.
+func tokenErrorCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body struct {
+		Error string `json:"error"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	return body.Error
+}
+
+func TestAuthorize_InvalidTarget(t *testing.T) {
+	s := newTestOAuthServer("")
+	q := authorizeQuery("st")
+	q.Set("resource", "https://other.example/mcp")
+	rec := httptest.NewRecorder()
+	s.Authorize(rec, httptest.NewRequest(http.MethodGet, "/authorize?"+q.Encode(), nil))
+	got := redirectQuery(t, rec)
+	if got.Get("error") != "invalid_target" || got.Get("code") != "" || got.Get("state") != "st" {
+		t.Errorf("authorize for another resource redirected with %v", got)
+	}
+}
+
+func TestRefresh_Audience(t *testing.T) {
+	s := newTestOAuthServer("")
+	refresh := func(token, resource string) *httptest.ResponseRecorder {
+		form := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {token}, "client_id": {"app"}}
+		if resource != "" {
+			form.Set("resource", resource)
+		}
+		return tokenRequest(s, form, "", "")
+	}
+
+	// A refresh token issued for another server is refused, and not rotated
+	foreign, _, err := s.tokenStore.GenerateRefreshToken("app", "https://other.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec := refresh(foreign, ""); rec.Code != http.StatusBadRequest || tokenErrorCode(t, rec) != "invalid_grant" {
+		t.Errorf("refresh with another audience = %d %s", rec.Code, rec.Body)
+	}
+	if s.tokenStore.ValidateRefreshToken(foreign) == nil {
+		t.Error("refused refresh token was revoked")
+	}
+
+	// So is asking for tokens for another resource
+	own, _, err := s.tokenStore.GenerateRefreshToken("app", s.Audience())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec := refresh(own, "https://other.example/mcp"); rec.Code != http.StatusBadRequest || tokenErrorCode(t, rec) != "invalid_target" {
+		t.Errorf("refresh for another resource = %d %s", rec.Code, rec.Body)
+	}
+
+	// The new tokens are bound to this server
+	rec := refresh(own, "https://momentum.example/mcp")
+	var tok struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &tok); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("refresh = %d %s", rec.Code, rec.Body)
+	}
+	if info := s.tokenStore.ValidateRefreshToken(tok.RefreshToken); info == nil || info.Audience != s.Audience() {
+		t.Errorf("new refresh token = %+v, want audience %s", info, s.Audience())
+	}
+	if !NewOAuthTokenValidator(s.tokenStore, s.Audience()).ValidateToken(tok.AccessToken) {
+		t.Error("new access token rejected by this server")
+	}
+}
+
+func TestOAuthTokenValidator_Audience(t *testing.T) {
+	store := NewTokenStore(time.Hour, time.Hour, nil)
+	validator := NewOAuthTokenValidator(store, "https://momentum.example")
+
+	own, _, _ := store.GenerateAccessToken("app", "", "https://momentum.example")
+	foreign, _, _ := store.GenerateAccessToken("app", "", "https://other.example")
+	unbound, _, _ := store.GenerateAccessToken("app", "", "")
+	for token, want := range map[string]bool{
+		own:     true,
+		foreign: false,
+		// Tokens from before audience binding are still accepted
+		unbound: true,
+		"":      false,
+		"bogus": false,
+	} {
+		if got := validator.ValidateToken(token); got != want {
+			t.Errorf("ValidateToken(%q) = %v, want %v", token, got, want)
+		}
+	}
+}
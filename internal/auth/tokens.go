@@ -6,6 +6,8 @@ import (
 	"encoding/base64"
 	"sync"
 	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
 )
 
 // TokenType distinguishes between access and refresh tokens.
@@ -25,6 +27,9 @@ type TokenInfo struct {
 	CreatedAt time.Time
 	// RefreshTokenID links an access token to its refresh token (for revocation).
 	RefreshTokenID string
+	// Audience is the canonical URL of the server the token was issued for
+	// (RFC 8707). Empty for tokens issued before tokens were bound.
+	Audience string
 }
 
 // TokenStore manages OAuth tokens in memory.
@@ -36,14 +41,17 @@ type TokenStore struct {
 
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
+	clock           clock.Clock
 }
 
-// NewTokenStore creates a new token store with the specified TTLs.
-func NewTokenStore(accessTTL, refreshTTL time.Duration) *TokenStore {
+// NewTokenStore creates a new token store with the specified TTLs. Expiry is
+// measured against c; a nil clock uses the system clock.
+func NewTokenStore(accessTTL, refreshTTL time.Duration, c clock.Clock) *TokenStore {
 	store := &TokenStore{
 		tokens:          make(map[string]*TokenInfo),
 		accessTokenTTL:  accessTTL,
 		refreshTokenTTL: refreshTTL,
+		clock:           clock.Or(c),
 	}
 
 	// Start background cleanup goroutine
@@ -52,14 +60,16 @@ func NewTokenStore(accessTTL, refreshTTL time.Duration) *TokenStore {
 	return store
 }
 
-// GenerateAccessToken creates a new access token for the given client.
-func (s *TokenStore) GenerateAccessToken(clientID string, refreshTokenID string) (string, time.Time, error) {
+// GenerateAccessToken creates a new access token for the given client,
+// bound to audience.
+func (s *TokenStore) GenerateAccessToken(clientID string, refreshTokenID string, audience string) (string, time.Time, error) {
 	token, err := generateSecureToken()
 	if err != nil {
 		return "", time.Time{}, err
 	}
 
-	expiresAt := time.Now().Add(s.accessTokenTTL)
+	now := s.clock.Now()
+	expiresAt := now.Add(s.accessTokenTTL)
 
 	s.mu.Lock()
 	s.tokens[token] = &TokenInfo{
@@ -67,22 +77,25 @@ func (s *TokenStore) GenerateAccessToken(clientID string, refreshTokenID string)
 		Type:           AccessToken,
 		ClientID:       clientID,
 		ExpiresAt:      expiresAt,
-		CreatedAt:      time.Now(),
+		CreatedAt:      now,
 		RefreshTokenID: refreshTokenID,
+		Audience:       audience,
 	}
 	s.mu.Unlock()
 
 	return token, expiresAt, nil
 }
 
-// GenerateRefreshToken creates a new refresh token for the given client.
-func (s *TokenStore) GenerateRefreshToken(clientID string) (string, time.Time, error) {
+// GenerateRefreshToken creates a new refresh token for the given client,
+// bound to audience.
+func (s *TokenStore) GenerateRefreshToken(clientID string, audience string) (string, time.Time, error) {
 	token, err := generateSecureToken()
 	if err != nil {
 		return "", time.Time{}, err
 	}
 
-	expiresAt := time.Now().Add(s.refreshTokenTTL)
+	now := s.clock.Now()
+	expiresAt := now.Add(s.refreshTokenTTL)
 
 	s.mu.Lock()
 	s.tokens[token] = &TokenInfo{
@@ -90,7 +103,8 @@ func (s *TokenStore) GenerateRefreshToken(clientID string) (string, time.Time, e
 		Type:      RefreshToken,
 		ClientID:  clientID,
 		ExpiresAt: expiresAt,
-		CreatedAt: time.Now(),
+		CreatedAt: now,
+		Audience:  audience,
 	}
 	s.mu.Unlock()
 
@@ -112,7 +126,7 @@ func (s *TokenStore) ValidateToken(token string, expectedType TokenType) *TokenI
 		return nil
 	}
 
-	if time.Now().After(info.ExpiresAt) {
+	if s.clock.Now().After(info.ExpiresAt) {
 		// Token expired, remove it
 		s.mu.Lock()
 		delete(s.tokens, token)
@@ -162,7 +176,7 @@ func (s *TokenStore) cleanupExpired() {
 	ticker := time.NewTicker(5 * time.Minute)
 	for range ticker.C {
 		s.mu.Lock()
-		now := time.Now()
+		now := s.clock.Now()
 		for token, info := range s.tokens {
 			if now.After(info.ExpiresAt) {
 				delete(s.tokens, token)
@@ -0,0 +1,212 @@
+// Package backup snapshots the data files and the encrypted OAuth state
+// into gzipped tarballs kept at a secondary location, and restores them:
+// protection against a tool run that wipes or mangles a file.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/auth"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// Tarball layout: data files under dataDir by their logical name, and the
+// OAuth state file as oauthEntry.
+const (
+	dataDir    = "data/"
+	oauthEntry = "oauth/oauth_state.json"
+)
+
+// archiveYears is how many years of reading list archives Files includes.
+const archiveYears = 10
+
+// Snapshot is the content of one backup.
+type Snapshot struct {
+	// Files maps data file names (as the tools use them, before any path
+	// prefix or override) to their content.
+	Files map[string]string
+	// OAuthState is the OAuth state file as stored on disk, or nil if there
+	// was none. Only encrypted state is backed up (see Take).
+	OAuthState []byte
+}
+
+// Files lists the data files to back up as of today: every data file, the
+// reading list archives of recent years and, if set, the event log.
+func Files(today time.Time, eventLog string) []string {
+	files := append([]string{}, storage.DataFiles...)
+	for year := today.Year(); year > today.Year()-archiveYears; year-- {
+		files = append(files, storage.ReadingArchivePath(year))
+	}
+	if eventLog != "" {
+		files = append(files, eventLog)
+	}
+	return files
+}
+
+// Take reads files from s, skipping any that don't exist, and the OAuth
+// state from oauthPath (if not empty). OAuth state that isn't encrypted
+// with OAUTH_STATE_KEY holds live tokens in plaintext, so it is left out
+// rather than copied to the backup destination.
+func Take(ctx context.Context, s storage.Storage, files []string, oauthPath string) (*Snapshot, error) {
+	snap := &Snapshot{Files: make(map[string]string)}
+	for path, r := range storage.ReadFiles(ctx, s, files...) {
+		if errors.Is(r.Err, storage.ErrNotFound) {
+			continue
+		}
+		if r.Err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, r.Err)
+		}
+		snap.Files[path] = r.Content
+	}
+	if oauthPath != "" {
+		state, err := os.ReadFile(oauthPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("reading OAuth state: %w", err)
+		}
+		if state != nil && !auth.StateEncrypted(state) {
+			slog.Warn("OAuth state is not encrypted, leaving it out of the backup (set OAUTH_STATE_KEY to include it)")
+			state = nil
+		}
+		snap.OAuthState = state
+	}
+	return snap, nil
+}
+
+// Digest identifies the snapshot's content, so an unchanged one needn't be
+// stored again.
+func (s *Snapshot) Digest() string {
+	h := sha256.New()
+	for _, name := range s.names() {
+		fmt.Fprintf(h, "%s\x00%d\x00%s", name, len(s.Files[name]), s.Files[name])
+	}
+	fmt.Fprintf(h, "%s\x00%d\x00", oauthEntry, len(s.OAuthState))
+	h.Write(s.OAuthState)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// names returns the file names in order.
+func (s *Snapshot) names() []string {
+	names := make([]string, 0, len(s.Files))
+	for name := range s.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Tarball encodes the snapshot as a gzipped tarball, with entries dated
+// at.
+func (s *Snapshot) Tarball(at time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	add := func(name string, content []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0o600, Size: int64(len(content)), ModTime: at}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+	for _, name := range s.names() {
+		if err := add(dataDir+name, []byte(s.Files[name])); err != nil {
+			return nil, fmt.Errorf("adding %s: %w", name, err)
+		}
+	}
+	if s.OAuthState != nil {
+		if err := add(oauthEntry, s.OAuthState); err != nil {
+			return nil, fmt.Errorf("adding OAuth state: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadTarball decodes a tarball written by Tarball. Unknown entries are
+// ignored.
+func ReadTarball(data []byte) (*Snapshot, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("opening backup: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	snap := &Snapshot{Files: make(map[string]string)}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return snap, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading backup: %w", err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", hdr.Name, err)
+		}
+		switch {
+		case hdr.Name == oauthEntry:
+			snap.OAuthState = content
+		case strings.HasPrefix(hdr.Name, dataDir):
+			snap.Files[strings.TrimPrefix(hdr.Name, dataDir)] = string(content)
+		}
+	}
+}
+
+// Restore writes the snapshot's data files that differ from s in one
+// commit, and returns their names. Files s has that the snapshot doesn't
+// are left alone. With dryRun, nothing is written.
+func Restore(ctx context.Context, s storage.Storage, snap *Snapshot, message string, dryRun bool) ([]string, error) {
+	names := snap.names()
+	current := storage.ReadFiles(ctx, s, names...)
+	var changes []storage.FileChange
+	var changed []string
+	for _, name := range names {
+		r := current[name]
+		if r.Err != nil && !errors.Is(r.Err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("reading %s: %w", name, r.Err)
+		}
+		if r.Err == nil && r.Content == snap.Files[name] {
+			continue
+		}
+		changes = append(changes, storage.FileChange{Path: name, Content: snap.Files[name], SHA: r.SHA})
+		changed = append(changed, name)
+	}
+	if dryRun || len(changes) == 0 {
+		return changed, nil
+	}
+	if err := storage.WriteFiles(ctx, s, changes, message); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// RestoreOAuthState writes the snapshot's OAuth state to path. The server
+// must be stopped first, or its next periodic save overwrites the file.
+func RestoreOAuthState(snap *Snapshot, path string) error {
+	if snap.OAuthState == nil {
+		return errors.New("the backup has no OAuth state")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, snap.OAuthState, 0o600)
+}
@@ -0,0 +1,96 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	data := storage.NewMemoryStorage(map[string]string{
+		storage.TodosFile:                "# Active Todos\n",
+		storage.ReadingArchivePath(2025): "# Reading Archive 2025\n",
+		"unrelated.md":                   "not backed up\n",
+	}, nil)
+	oauthPath := filepath.Join(t.TempDir(), "oauth_state.json")
+	sealed := []byte(`{"encrypted": {"key_id": "0a1b2c3d", "nonce": "AAAA", "data": "AAAA"}}`)
+	os.WriteFile(oauthPath, sealed, 0o600)
+
+	snap, err := Take(ctx, data, Files(time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC), ""), oauthPath)
+	if err != nil {
+		t.Fatalf("Take() error = %v", err)
+	}
+	if string(snap.OAuthState) != string(sealed) {
+		t.Errorf("OAuthState = %q, want the encrypted state", snap.OAuthState)
+	}
+	want := map[string]string{
+		storage.TodosFile:                "# Active Todos\n",
+		storage.ReadingArchivePath(2025): "# Reading Archive 2025\n",
+	}
+	if !reflect.DeepEqual(snap.Files, want) {
+		t.Errorf("Files = %v, want %v", snap.Files, want)
+	}
+
+	tarball, err := snap.Tarball(time.Now())
+	if err != nil {
+		t.Fatalf("Tarball() error = %v", err)
+	}
+	got, err := ReadTarball(tarball)
+	if err != nil {
+		t.Fatalf("ReadTarball() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, snap) || got.Digest() != snap.Digest() {
+		t.Errorf("ReadTarball() = %+v, want %+v", got, snap)
+	}
+}
+
+func TestTake_PlaintextOAuthState(t *testing.T) {
+	// Unencrypted state holds live tokens and stays out of backups
+	oauthPath := filepath.Join(t.TempDir(), "oauth_state.json")
+	os.WriteFile(oauthPath, []byte(`{"tokens":{"secret":{}}}`), 0o600)
+	snap, err := Take(context.Background(), storage.NewMemoryStorage(nil, nil), nil, oauthPath)
+	if err != nil || snap.OAuthState != nil {
+		t.Errorf("Take() = %q, %v, want no OAuth state", snap.OAuthState, err)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	data := storage.NewMemoryStorage(map[string]string{
+		storage.TodosFile:   "wiped\n",
+		storage.NotesFile:   "# Notes\n",
+		storage.JournalFile: "added since\n",
+	}, nil)
+	snap := &Snapshot{Files: map[string]string{
+		storage.TodosFile: "# Active Todos\n- [ ] Ship it\n",
+		storage.NotesFile: "# Notes\n",
+		storage.GoalsFile: "# Goals\n",
+	}}
+
+	changed, err := Restore(ctx, data, snap, "Restore", true)
+	if err != nil || !reflect.DeepEqual(changed, []string{storage.GoalsFile, storage.TodosFile}) {
+		t.Fatalf("Restore(dry run) = %v, %v", changed, err)
+	}
+	if content, _, _ := data.ReadFile(ctx, storage.TodosFile); content != "wiped\n" {
+		t.Error("dry run wrote a file")
+	}
+
+	if _, err := Restore(ctx, data, snap, "Restore", false); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	for name, want := range map[string]string{
+		storage.TodosFile:   "# Active Todos\n- [ ] Ship it\n",
+		storage.GoalsFile:   "# Goals\n",
+		storage.JournalFile: "added since\n",
+	} {
+		if got, _, _ := data.ReadFile(ctx, name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
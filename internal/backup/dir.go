@@ -0,0 +1,71 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// Dir is a storage.Storage over a local directory, for keeping backups on
+// disk. The SHA of a file is the SHA-1 of its content, and writes are
+// conditional on it like the other backends.
+type Dir struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewDir creates a Dir storing files under dir, which is created on the
+// first write.
+func NewDir(dir string) *Dir {
+	return &Dir{dir: dir}
+}
+
+// ReadFile returns the content of path and its SHA.
+func (d *Dir) ReadFile(ctx context.Context, path string) (string, string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.read(path)
+}
+
+func (d *Dir) read(path string) (string, string, error) {
+	content, err := os.ReadFile(filepath.Join(d.dir, filepath.FromSlash(path)))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", "", storage.ErrNotFound
+	}
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha1.Sum(content)
+	return string(content), hex.EncodeToString(sum[:]), nil
+}
+
+// WriteFile writes path if sha matches its current SHA (or, for an empty
+// sha, if it doesn't exist), and returns storage.ErrConflict otherwise. The
+// message is ignored.
+func (d *Dir) WriteFile(ctx context.Context, path, content, sha, message string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, current, err := d.read(path)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+	if current != sha {
+		return storage.ErrConflict
+	}
+
+	full := filepath.Join(d.dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(full), 0o700); err != nil {
+		return err
+	}
+	tmp := full + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, full)
+}
@@ -0,0 +1,96 @@
+package backup
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/notify"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// Job backs up the data files and OAuth state to a Store on a cron
+// schedule.
+type Job struct {
+	data      storage.Storage
+	eventLog  string
+	oauthPath string
+	store     *Store
+	schedule  *notify.Cron
+	clock     clock.Clock
+
+	// lastRun is the minute of the last run, so each scheduled minute backs
+	// up once however often the loop wakes
+	lastRun string
+	cancel  context.CancelFunc
+}
+
+// NewJob creates a job backing up the data files in data (and eventLog, if
+// set) and the OAuth state file at oauthPath (if set) to store at the
+// times schedule selects, in the clock's timezone. A nil clock uses the
+// system clock.
+func NewJob(data storage.Storage, eventLog, oauthPath string, store *Store, schedule *notify.Cron, c clock.Clock) *Job {
+	return &Job{data: data, eventLog: eventLog, oauthPath: oauthPath, store: store, schedule: schedule, clock: clock.Or(c)}
+}
+
+// Start begins checking the schedule in the background.
+func (j *Job) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+	go j.loop(ctx)
+}
+
+// Stop ends the schedule checks.
+func (j *Job) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+}
+
+func (j *Job) loop(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+	for {
+		j.tick(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// tick backs up if the current minute is scheduled and it hasn't run in it
+// yet.
+func (j *Job) tick(ctx context.Context) {
+	now := j.clock.Now()
+	minute := now.Format("2006-01-02 15:04")
+	if minute == j.lastRun || !j.schedule.Matches(now) {
+		return
+	}
+	j.lastRun = minute
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+	entry, stored, err := j.Run(ctx)
+	if err != nil {
+		slog.Warn("backup failed", "error", err)
+		return
+	}
+	if !stored {
+		slog.Info("backup skipped, nothing changed since the last one", "latest", entry.Name)
+		return
+	}
+	slog.Info("backup stored", "name", entry.Name, "files", entry.Files, "bytes", entry.Bytes)
+}
+
+// Run takes a backup now.
+func (j *Job) Run(ctx context.Context) (Entry, bool, error) {
+	now := j.clock.Now()
+	snap, err := Take(ctx, j.data, Files(clock.Date(now), j.eventLog), j.oauthPath)
+	if err != nil {
+		return Entry{}, false, err
+	}
+	return j.store.Put(ctx, snap, now)
+}
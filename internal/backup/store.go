@@ -0,0 +1,160 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// indexPath lists the backups held in a Store.
+const indexPath = "backups/index.json"
+
+// DefaultKeep is how many backups a Store keeps by default.
+const DefaultKeep = 7
+
+// Store keeps the most recent backups in any storage.Storage: a second
+// GitHub repo, a bucket, or a local directory. Storage can't list or delete
+// files, so backups rotate through keep numbered slots, and an index file
+// records which backup is in which slot. Overwriting a slot drops the oldest
+// backup (on GitHub it stays in the repo's history).
+type Store struct {
+	storage storage.Storage
+	keep    int
+}
+
+// Entry describes a stored backup.
+type Entry struct {
+	// Name identifies the backup, from the time it was taken.
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+	Files     int       `json:"files"`
+	OAuth     bool      `json:"oauth,omitempty"`
+	Bytes     int       `json:"bytes"`
+	Digest    string    `json:"digest"`
+}
+
+// NewStore creates a Store keeping the keep most recent backups in s. A
+// keep below 1 uses DefaultKeep.
+func NewStore(s storage.Storage, keep int) *Store {
+	if keep < 1 {
+		keep = DefaultKeep
+	}
+	return &Store{storage: s, keep: keep}
+}
+
+// List returns the stored backups, newest first.
+func (st *Store) List(ctx context.Context) ([]Entry, error) {
+	entries, _, err := st.index(ctx)
+	return entries, err
+}
+
+func (st *Store) index(ctx context.Context) ([]Entry, string, error) {
+	content, sha, err := st.storage.ReadFile(ctx, indexPath)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("reading the backup index: %w", err)
+	}
+	var entries []Entry
+	if err := json.Unmarshal([]byte(content), &entries); err != nil {
+		return nil, "", fmt.Errorf("parsing the backup index: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].CreatedAt.After(entries[j].CreatedAt) })
+	return entries, sha, nil
+}
+
+// Put stores snap as a backup taken at now, replacing the oldest backup once
+// the store is full. If the newest backup has the same content, nothing is
+// stored and that entry is returned with stored false.
+func (st *Store) Put(ctx context.Context, snap *Snapshot, now time.Time) (entry Entry, stored bool, err error) {
+	entries, indexSHA, err := st.index(ctx)
+	if err != nil {
+		return Entry{}, false, err
+	}
+	digest := snap.Digest()
+	if len(entries) > 0 && entries[0].Digest == digest {
+		return entries[0], false, nil
+	}
+
+	data, err := snap.Tarball(now)
+	if err != nil {
+		return Entry{}, false, fmt.Errorf("creating the backup: %w", err)
+	}
+	entry = Entry{
+		Name:      "momentum-" + now.UTC().Format("20060102-150405"),
+		CreatedAt: now,
+		Files:     len(snap.Files),
+		OAuth:     snap.OAuthState != nil,
+		Bytes:     len(data),
+		Digest:    digest,
+	}
+
+	// Reuse the oldest backup's slot once there are keep of them, dropping
+	// any beyond keep left by a larger setting
+	used := make(map[string]bool)
+	for len(entries) >= st.keep {
+		entries = entries[:len(entries)-1]
+	}
+	for _, e := range entries {
+		used[e.Path] = true
+	}
+	for i := 1; entry.Path == ""; i++ {
+		if path := fmt.Sprintf("backups/slot-%d.tar.gz", i); !used[path] {
+			entry.Path = path
+		}
+	}
+
+	_, slotSHA, err := st.storage.ReadFile(ctx, entry.Path)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return Entry{}, false, fmt.Errorf("reading %s: %w", entry.Path, err)
+	}
+	entries = append([]Entry{entry}, entries...)
+	index, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return Entry{}, false, err
+	}
+	message := "Backup " + entry.Name
+	if err := storage.WriteFiles(ctx, st.storage, []storage.FileChange{
+		{Path: entry.Path, Content: string(data), SHA: slotSHA},
+		{Path: indexPath, Content: string(index) + "\n", SHA: indexSHA},
+	}, message); err != nil {
+		return Entry{}, false, fmt.Errorf("storing the backup: %w", err)
+	}
+	return entry, true, nil
+}
+
+// Get returns the backup named name, or the newest for "latest".
+func (st *Store) Get(ctx context.Context, name string) (Entry, *Snapshot, error) {
+	entries, _, err := st.index(ctx)
+	if err != nil {
+		return Entry{}, nil, err
+	}
+	for i, e := range entries {
+		if e.Name != name && (name != "latest" || i > 0) {
+			continue
+		}
+		content, _, err := st.storage.ReadFile(ctx, e.Path)
+		if err != nil {
+			return Entry{}, nil, fmt.Errorf("reading %s: %w", e.Path, err)
+		}
+		snap, err := ReadTarball([]byte(content))
+		if err != nil {
+			return Entry{}, nil, err
+		}
+		if snap.Digest() != e.Digest {
+			return Entry{}, nil, fmt.Errorf("backup %s doesn't match its index entry; its slot may have been overwritten", e.Name)
+		}
+		return e, snap, nil
+	}
+	return Entry{}, nil, ErrNoBackup
+}
+
+// ErrNoBackup is returned by Get when there's no such backup.
+var ErrNoBackup = errors.New("no such backup")
@@ -0,0 +1,56 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestStore_Rotation(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(NewDir(t.TempDir()), 2)
+	now := time.Date(2026, 2, 10, 3, 0, 0, 0, time.UTC)
+
+	var names []string
+	for i, content := range []string{"one", "two", "two", "three"} {
+		snap := &Snapshot{Files: map[string]string{storage.TodosFile: content}}
+		entry, stored, err := store.Put(ctx, snap, now.AddDate(0, 0, i))
+		if err != nil {
+			t.Fatalf("Put(%s) error = %v", content, err)
+		}
+		// The unchanged third snapshot isn't stored again
+		if stored != (i != 2) {
+			t.Errorf("Put(%s) stored = %v", content, stored)
+		}
+		if stored {
+			names = append(names, entry.Name)
+		}
+	}
+
+	entries, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Name != names[2] || entries[1].Name != names[1] {
+		t.Fatalf("List() = %+v", entries)
+	}
+	// The third backup took the first one's slot
+	if entries[0].Path != "backups/slot-1.tar.gz" {
+		t.Errorf("newest backup in %s", entries[0].Path)
+	}
+
+	_, snap, err := store.Get(ctx, "latest")
+	if err != nil || snap.Files[storage.TodosFile] != "three" {
+		t.Errorf("Get(latest) = %+v, %v", snap, err)
+	}
+	_, snap, err = store.Get(ctx, names[1])
+	if err != nil || snap.Files[storage.TodosFile] != "two" {
+		t.Errorf("Get(%s) = %+v, %v", names[1], snap, err)
+	}
+	if _, _, err := store.Get(ctx, names[0]); !errors.Is(err, ErrNoBackup) {
+		t.Errorf("Get(rotated out) error = %v, want ErrNoBackup", err)
+	}
+}
@@ -0,0 +1,102 @@
+// Package clock abstracts the current time so date-sensitive behavior (week
+// boundaries, overdue items, streaks, token expiry) can be tested
+// deterministically and demos can run at a fixed date via FAKE_NOW.
+//
+// A clock's times are in the user's timezone (see InLocation), and the
+// calendar date there is "today". Dates in the data files have no time or
+// zone and parse to midnight UTC, so Today and Date return dates in that
+// form for comparing with them.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock reports the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is the system clock, in UTC.
+type Real struct{}
+
+// Now returns time.Now() in UTC.
+func (Real) Now() time.Time { return time.Now().UTC() }
+
+// Or returns c, or the system clock if c is nil, so a Clock field can be
+// left unset outside tests.
+func Or(c Clock) Clock {
+	if c == nil {
+		return Real{}
+	}
+	return c
+}
+
+// InLocation returns a clock reading c's time in loc, the user's timezone.
+func InLocation(c Clock, loc *time.Location) Clock {
+	return located{clock: c, loc: loc}
+}
+
+type located struct {
+	clock Clock
+	loc   *time.Location
+}
+
+func (l located) Now() time.Time { return l.clock.Now().In(l.loc) }
+
+// Today returns the clock's current date (see Date).
+func Today(c Clock) time.Time {
+	return Date(c.Now())
+}
+
+// Date returns t's calendar date in t's location, as midnight UTC.
+func Date(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// StartingAt returns a clock that reads start now and then advances in real
+// time. It backs FAKE_NOW: dates are reproducible while timers and token
+// expiry keep working.
+func StartingAt(start time.Time) Clock {
+	return offset{delta: time.Until(start)}
+}
+
+type offset struct {
+	delta time.Duration
+}
+
+func (o offset) Now() time.Time { return time.Now().UTC().Add(o.delta) }
+
+// Fake is a manually controlled clock for tests.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake returns a Fake clock set to now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the fake time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set moves the fake clock to t.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+}
+
+// Advance moves the fake clock forward by d.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
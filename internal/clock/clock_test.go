@@ -0,0 +1,65 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC)
+	f := NewFake(start)
+
+	if !f.Now().Equal(start) {
+		t.Fatalf("expected %v, got %v", start, f.Now())
+	}
+	f.Advance(time.Hour)
+	if want := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC); !Today(f).Equal(want) {
+		t.Errorf("expected today %v after advancing past midnight, got %v", want, Today(f))
+	}
+}
+
+func TestStartingAt(t *testing.T) {
+	start := time.Date(2020, 6, 15, 12, 0, 0, 0, time.UTC)
+	c := StartingAt(start)
+
+	if d := c.Now().Sub(start); d < 0 || d > time.Minute {
+		t.Errorf("expected clock near %v, got %v", start, c.Now())
+	}
+}
+
+func TestOr(t *testing.T) {
+	if _, ok := Or(nil).(Real); !ok {
+		t.Error("expected nil clock to fall back to Real")
+	}
+	f := NewFake(time.Time{})
+	if Or(f) != Clock(f) {
+		t.Error("expected non-nil clock to be returned unchanged")
+	}
+}
+
+func TestInLocation(t *testing.T) {
+	// 23:30 UTC is already the next day in Auckland and still the same
+	// day in New York
+	f := NewFake(time.Date(2026, 3, 1, 23, 30, 0, 0, time.UTC))
+	tests := []struct {
+		zone string
+		want time.Time
+	}{
+		{"UTC", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"Pacific/Auckland", time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"America/New_York", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		loc, err := time.LoadLocation(tt.zone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := InLocation(f, loc)
+		if got := Today(c); !got.Equal(tt.want) {
+			t.Errorf("Today in %s = %v, want %v", tt.zone, got, tt.want)
+		}
+		if !c.Now().Equal(f.Now()) || c.Now().Location() != loc {
+			t.Errorf("Now in %s = %v, want the same instant in that zone", tt.zone, c.Now())
+		}
+	}
+}
@@ -4,9 +4,19 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	// Embedded zoneinfo for TIMEZONE: the container image has none
+	_ "time/tzdata"
+
+	"github.com/dang-w/momentum-mcp-server/internal/alerts"
+	"github.com/dang-w/momentum-mcp-server/internal/auth"
+	"github.com/dang-w/momentum-mcp-server/internal/confirm"
+	"github.com/dang-w/momentum-mcp-server/internal/notify"
+	"github.com/dang-w/momentum-mcp-server/storage"
 )
 
 // Default OAuth token lifetimes.
@@ -15,6 +25,9 @@ const (
 	DefaultRefreshTokenTTL = 7 * 24 * time.Hour // 7 days
 )
 
+// DefaultRequestTimeout is the default per-request tool deadline.
+const DefaultRequestTimeout = 30 * time.Second
+
 // Config holds all configuration values for the server.
 type Config struct {
 	// GitHubToken is the personal access token for GitHub API access.
@@ -23,6 +36,12 @@ type Config struct {
 	// GitHubRepo is the data repository in "owner/repo" format.
 	GitHubRepo string
 
+	// GitHubActivityCacheTTL is how long fetched GitHub activity is reused.
+	GitHubActivityCacheTTL time.Duration
+
+	// ReadCacheTTL is how long a data file's last read is reused.
+	ReadCacheTTL time.Duration
+
 	// AuthToken is the shared secret for authenticating MCP clients (Claude Code).
 	AuthToken string
 
@@ -41,13 +60,273 @@ type Config struct {
 	// OAuthRefreshTokenTTL is the lifetime of issued refresh tokens.
 	OAuthRefreshTokenTTL time.Duration
 
-	// BaseURL is the public URL of this server (used for OAuth issuer).
+	// OAuthStateKey, if set, encrypts the persisted OAuth state at rest.
+	// OAuthStateOldKeys are previous keys, still accepted when loading.
+	OAuthStateKey     []byte
+	OAuthStateOldKeys [][]byte
+
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For header
+	// identifies the client for rate limits and PIN lockouts. Without them
+	// the connecting address is used.
+	TrustedProxies auth.TrustedProxies
+
+	// BaseURL is the public URL of this server (used for OAuth issuer, and as
+	// the audience OAuth tokens are bound to, so changing it signs clients out).
 	// If not set, it will be derived from request headers.
 	BaseURL string
 
 	// DataDir is the directory for persistent data (OAuth tokens, etc.).
 	// If empty, data is stored in memory only (lost on restart).
 	DataDir string
+
+	// LogLevel is the minimum level for structured logs (debug, info, warn, error).
+	LogLevel string
+
+	// OTLPEndpoint is the OTLP/HTTP collector base URL for trace export.
+	// If empty, tracing is disabled.
+	OTLPEndpoint string
+
+	// OTLPHeaders are extra headers sent with trace exports ("k1=v1,k2=v2").
+	OTLPHeaders string
+
+	// ServiceName is reported as the service.name of exported traces.
+	ServiceName string
+
+	// RequestTimeout bounds each MCP tool call. Timed-out calls return a tool
+	// error instead of hanging.
+	RequestTimeout time.Duration
+
+	// MCPSessionTimeout closes MCP sessions idle this long; zero keeps them
+	// until the client deletes them.
+	MCPSessionTimeout time.Duration
+
+	// MCPEventStoreBytes bounds the SSE events kept in memory so dropped
+	// streams can resume with Last-Event-ID; zero disables resumption.
+	MCPEventStoreBytes int
+
+	// DataFileWarnBytes is the soft size limit per data file; the dashboard
+	// warns (suggesting archival) above it.
+	DataFileWarnBytes int
+
+	// DataTotalWarnBytes is the soft size limit for all data files combined.
+	DataTotalWarnBytes int
+
+	// WorkloadMaxHighPriority is the burnout guard's limit on active
+	// high-priority todos before the dashboard flags the workload.
+	WorkloadMaxHighPriority int
+
+	// WorkloadMaxDueSoon is the burnout guard's limit on milestones and
+	// reminders due within WorkloadDueSoonDays.
+	WorkloadMaxDueSoon int
+
+	// WorkloadDueSoonDays is the look-ahead window for WorkloadMaxDueSoon.
+	WorkloadDueSoonDays int
+
+	// StaleTodoDays, StaleReadingWeeks and StaleMilestoneOverdueDays are the
+	// default thresholds for get_stale_items.
+	StaleTodoDays             int
+	StaleReadingWeeks         int
+	StaleMilestoneOverdueDays int
+
+	// WIPLimits caps the active todos per priority (e.g. at most 3 high);
+	// WIPLimitRefuse refuses adds and edits over a limit instead of
+	// warning.
+	WIPLimits      map[storage.Priority]int
+	WIPLimitRefuse bool
+
+	// StorageMode is "files" (markdown written directly) or "events"
+	// (markdown written directly, each change also logged as a line diff in
+	// a JSONL log committed with it).
+	StorageMode string
+
+	// EventLogPath is the event log path in the data repo when StorageMode is "events".
+	EventLogPath string
+
+	// InitDataFiles creates missing data files from templates at startup, so
+	// a new deployment works against an empty repo.
+	InitDataFiles bool
+
+	// DataPaths places the data files in the data repo: under a directory
+	// prefix, with optional per-file renames. The default is the repo root.
+	DataPaths storage.Paths
+
+	// StorageBackend is where the data files live: StorageGitHub (the
+	// default), a bucket - StorageS3 or StorageGCS - configured by Object,
+	// or StorageSQLite.
+	StorageBackend string
+	Object         storage.ObjectConfig
+	// SQLitePath is the SQLite database file. With GitHubRepo set, the
+	// files changed in it are exported to the repo every
+	// SQLiteExportInterval.
+	SQLitePath           string
+	SQLiteExportInterval time.Duration
+
+	// DevMode runs the server on in-memory sample data instead of a GitHub
+	// repo. Nothing is persisted; GITHUB_TOKEN and GITHUB_REPO aren't needed.
+	DevMode bool
+
+	// FakeNow, when set, starts the server clock at this instant instead of
+	// the real time, for reproducible demos. The clock still advances.
+	FakeNow time.Time
+
+	// Location is the user's timezone (TIMEZONE, default UTC): "today",
+	// week boundaries and streaks are computed in it.
+	Location *time.Location
+
+	// AnalyticsBackfill walks the data repo's commit history once at startup
+	// to reconstruct weekly completion counts.
+	AnalyticsBackfill bool
+
+	// AnalyticsBackfillMaxCommits caps the commits examined per data file.
+	AnalyticsBackfillMaxCommits int
+
+	// Google Calendar integration (optional; enabled when the client ID,
+	// secret and refresh token are all set)
+	GoogleCalendarClientID     string
+	GoogleCalendarClientSecret string
+	GoogleCalendarRefreshToken string
+	// GoogleCalendarID is the calendar to read (default "primary").
+	GoogleCalendarID string
+	// GoogleCalendarCacheTTL is how long fetched events are reused.
+	GoogleCalendarCacheTTL time.Duration
+
+	// WakaTimeAPIKey enables the coding time integration when set.
+	WakaTimeAPIKey string
+	// WakaTimeCacheTTL is how long fetched coding time is reused.
+	WakaTimeCacheTTL time.Duration
+
+	// MilestoneIssuesRepo ("owner/repo") enables mirroring milestones as
+	// GitHub issues when set. Uses GitHubToken, which needs issue write access.
+	MilestoneIssuesRepo string
+	// MilestoneIssuesLabels are applied to newly created milestone issues.
+	MilestoneIssuesLabels []string
+
+	// TrendsEnabled records a daily snapshot of item counts to
+	// metrics/history.jsonl in the data repo.
+	TrendsEnabled bool
+	// TrendsHour is the hour (0-23) in Location after which the snapshot is taken.
+	TrendsHour int
+
+	// Reminder notifications (optional; enabled when a webhook URL or an
+	// SMTP host is set)
+
+	// NotifyHour is the hour (0-23) in Location the daily reminder check runs.
+	NotifyHour int
+	// NotifyWebhookURL receives Slack/Discord-compatible JSON notifications.
+	NotifyWebhookURL string
+	// SMTP settings for email notifications.
+	SMTPHost        string
+	SMTPPort        int
+	SMTPUsername    string
+	SMTPPassword    string
+	NotifyEmailFrom string
+	NotifyEmailTo   string // Comma-separated recipients
+	// BriefingSchedule pushes the morning briefing to the notification
+	// channels at the times it selects, in Location (nil: off).
+	BriefingSchedule *notify.Cron
+
+	// Outbound webhooks (optional; enabled when any URL is set)
+
+	// EventWebhookURLs receive a JSON event for every item a tool changes.
+	EventWebhookURLs []string
+	// EventWebhookSecret signs event payloads with HMAC-SHA256.
+	EventWebhookSecret string
+	// EventWebhookEvents limits the event types sent (e.g. todo.completed,
+	// reminder.*); empty sends all.
+	EventWebhookEvents []string
+
+	// Backups (optional; scheduled when BackupSchedule is set)
+
+	// BackupSchedule snapshots the data files and OAuth state at the times
+	// it selects, in Location (nil: off).
+	BackupSchedule *notify.Cron
+	// BackupDir, BackupGitHubRepo or BackupObject (when its Bucket is set)
+	// is where backups are kept, from BACKUP_DESTINATION; at most one is set.
+	BackupDir         string
+	BackupGitHubRepo  string
+	BackupGitHubToken string
+	BackupObject      storage.ObjectConfig
+	// BackupKeep is how many backups are kept before the oldest is replaced.
+	BackupKeep int
+
+	// CommitMessageTemplate formats data repo commit messages; see
+	// attribution.Render for the placeholders. Empty keeps the tools' own.
+	CommitMessageTemplate string
+	// CommitAuthorName and CommitAuthorEmail, if set, are the author and
+	// committer of every commit instead of the GitHub token's user.
+	CommitAuthorName  string
+	CommitAuthorEmail string
+	// ReviewFiles are data file names (e.g. strategy.md, or * for all) whose
+	// changes are opened as pull requests instead of committed.
+	ReviewFiles []string
+	// OfflineQueue saves writes locally while GitHub is unavailable and
+	// syncs them when it's back.
+	OfflineQueue bool
+
+	// GitHubWebhookSecret enables the /webhooks/github endpoint, which
+	// notices pushes to the data repo made outside the server.
+	GitHubWebhookSecret string
+
+	// ReadOnly refuses every change to the data (switchable at runtime
+	// through /admin/read-only); ReadOnlyTools are refused even when it's off.
+	ReadOnly      bool
+	ReadOnlyTools []string
+
+	// ToolAlerts attaches overdue reminders and milestones due within
+	// ToolAlertsWindow to tool results; ToolAlertsSkip get none.
+	ToolAlerts       bool
+	ToolAlertsWindow time.Duration
+	ToolAlertsSkip   []string
+
+	// ConfirmMode is how destructive tools (confirm.DefaultTools) are
+	// confirmed with the user: off, elicit or token.
+	ConfirmMode confirm.Mode
+	// ConfirmTools overrides the mode per tool, and can guard other tools.
+	ConfirmTools map[string]confirm.Mode
+
+	// APIEnabled serves the JSON REST API at /api/v1 (bearer auth).
+	APIEnabled bool
+
+	// StatusPage serves a read-only HTML dashboard at /status.
+	StatusPage bool
+	// StatusPageToken, if set, must be given to view /status (as ?token=
+	// or a bearer token). It grants no other access.
+	StatusPageToken string
+
+	// SlackSigningSecret enables the /integrations/slack slash command
+	// endpoint when set.
+	SlackSigningSecret string
+
+	// TelegramBotToken enables the Telegram quick-capture bot when set.
+	TelegramBotToken string
+	// TelegramChatID is the only chat the bot answers.
+	TelegramChatID int64
+
+	// FeedsInterval is how often fetch_feeds runs in the background; zero
+	// disables the job (the tool still works on demand).
+	FeedsInterval time.Duration
+	// FeedsMaxPerFeed caps the entries each background run adds per feed.
+	FeedsMaxPerFeed int
+
+	// ReadingArchiveMonths archives read items older than this many months
+	// once a day; zero disables the job (archive_reading still works on
+	// demand).
+	ReadingArchiveMonths int
+
+	// Client compatibility shims
+
+	// CompatRootEndpoint serves MCP at "/" as well as "/mcp", for clients
+	// (e.g. Claude.ai custom connectors) that POST to the base URL.
+	CompatRootEndpoint bool
+
+	// CompatRefreshWithoutClientID accepts refresh_token grants that omit client_id.
+	CompatRefreshWithoutClientID bool
+
+	// CompatEchoResource echoes the requested "resource" parameter back in token responses.
+	CompatEchoResource bool
+
+	// CompatReportSize is the number of recent client negotiations kept for /compat-report.
+	CompatReportSize int
 }
 
 // Load reads configuration from environment variables and validates
@@ -61,6 +340,44 @@ func Load() (*Config, error) {
 		OAuthAuthorizePin: os.Getenv("OAUTH_AUTHORIZE_PIN"),
 		BaseURL:           os.Getenv("BASE_URL"),
 		DataDir:           os.Getenv("DATA_DIR"),
+		LogLevel:          os.Getenv("LOG_LEVEL"),
+		StorageMode:       os.Getenv("STORAGE_MODE"),
+		EventLogPath:      os.Getenv("EVENT_LOG_PATH"),
+		OTLPEndpoint:      os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		OTLPHeaders:       os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"),
+		ServiceName:       os.Getenv("OTEL_SERVICE_NAME"),
+
+		GoogleCalendarClientID:     os.Getenv("GOOGLE_CALENDAR_CLIENT_ID"),
+		GoogleCalendarClientSecret: os.Getenv("GOOGLE_CALENDAR_CLIENT_SECRET"),
+		GoogleCalendarRefreshToken: os.Getenv("GOOGLE_CALENDAR_REFRESH_TOKEN"),
+		GoogleCalendarID:           os.Getenv("GOOGLE_CALENDAR_ID"),
+		WakaTimeAPIKey:             os.Getenv("WAKATIME_API_KEY"),
+
+		MilestoneIssuesRepo: os.Getenv("MILESTONE_ISSUES_REPO"),
+
+		NotifyWebhookURL: os.Getenv("NOTIFY_WEBHOOK_URL"),
+		SMTPHost:         os.Getenv("SMTP_HOST"),
+		SMTPUsername:     os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:     os.Getenv("SMTP_PASSWORD"),
+		NotifyEmailFrom:  os.Getenv("NOTIFY_EMAIL_FROM"),
+		NotifyEmailTo:    os.Getenv("NOTIFY_EMAIL_TO"),
+
+		EventWebhookURLs:   parseList(os.Getenv("EVENT_WEBHOOK_URLS")),
+		EventWebhookSecret: os.Getenv("EVENT_WEBHOOK_SECRET"),
+		EventWebhookEvents: parseList(os.Getenv("EVENT_WEBHOOK_EVENTS")),
+
+		CommitMessageTemplate: os.Getenv("COMMIT_MESSAGE_TEMPLATE"),
+		CommitAuthorName:      os.Getenv("COMMIT_AUTHOR_NAME"),
+		CommitAuthorEmail:     os.Getenv("COMMIT_AUTHOR_EMAIL"),
+		ReviewFiles:           parseList(os.Getenv("REVIEW_FILES")),
+		OfflineQueue:          parseBool(os.Getenv("OFFLINE_QUEUE"), false),
+
+		GitHubWebhookSecret: os.Getenv("GITHUB_WEBHOOK_SECRET"),
+
+		StatusPageToken: os.Getenv("STATUS_PAGE_TOKEN"),
+
+		SlackSigningSecret: os.Getenv("SLACK_SIGNING_SECRET"),
+		TelegramBotToken:   os.Getenv("TELEGRAM_BOT_TOKEN"),
 	}
 
 	// Default port if not specified
@@ -68,6 +385,136 @@ func Load() (*Config, error) {
 		cfg.Port = "8080"
 	}
 
+	// Default log level if not specified
+	if cfg.LogLevel == "" {
+		cfg.LogLevel = "info"
+	}
+
+	// Parse request timeout (seconds) with default
+	cfg.RequestTimeout = parseDurationSeconds(os.Getenv("REQUEST_TIMEOUT"), DefaultRequestTimeout)
+
+	// MCP session idle timeout (seconds, off by default)
+	cfg.MCPSessionTimeout = parseDurationSeconds(os.Getenv("MCP_SESSION_TIMEOUT"), 0)
+
+	// SSE resumption buffer (KB); 0 turns resumption off, so parseInt's
+	// default-on-zero won't do
+	cfg.MCPEventStoreBytes = 10240 * 1024
+	if v := os.Getenv("MCP_EVENT_STORE_KB"); v != "" {
+		kb, err := strconv.Atoi(v)
+		if err != nil || kb < 0 {
+			return nil, fmt.Errorf("MCP_EVENT_STORE_KB must be a number of KB (0 to disable), got %q", v)
+		}
+		cfg.MCPEventStoreBytes = kb * 1024
+	}
+
+	// Soft data size quotas (configured in KB)
+	cfg.DataFileWarnBytes = parseInt(os.Getenv("DATA_FILE_WARN_KB"), 100) * 1024
+	cfg.DataTotalWarnBytes = parseInt(os.Getenv("DATA_TOTAL_WARN_KB"), 400) * 1024
+
+	// Burnout guard thresholds
+	cfg.WorkloadMaxHighPriority = parseInt(os.Getenv("WORKLOAD_MAX_HIGH_PRIORITY"), 5)
+	cfg.WorkloadMaxDueSoon = parseInt(os.Getenv("WORKLOAD_MAX_DUE_SOON"), 5)
+	cfg.WorkloadDueSoonDays = parseInt(os.Getenv("WORKLOAD_DUE_SOON_DAYS"), 3)
+
+	// Stale item thresholds
+	cfg.StaleTodoDays = parseInt(os.Getenv("STALE_TODO_DAYS"), 30)
+	cfg.StaleReadingWeeks = parseInt(os.Getenv("STALE_READING_WEEKS"), 8)
+	cfg.StaleMilestoneOverdueDays = parseInt(os.Getenv("STALE_MILESTONE_OVERDUE_DAYS"), 7)
+
+	// Per-priority WIP limits as priority=max pairs, warning by default
+	for _, pair := range parseList(os.Getenv("WIP_LIMITS")) {
+		name, value, ok := strings.Cut(pair, "=")
+		priority := storage.Priority(strings.ToLower(strings.TrimSpace(name)))
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if !ok || !slices.Contains(storage.Priorities, priority) || err != nil || limit < 0 {
+			return nil, fmt.Errorf("WIP_LIMITS: %q must be priority=max, with priority one of urgent, high, normal or someday", pair)
+		}
+		if cfg.WIPLimits == nil {
+			cfg.WIPLimits = make(map[storage.Priority]int)
+		}
+		cfg.WIPLimits[priority] = limit
+	}
+	switch mode := os.Getenv("WIP_LIMIT_MODE"); mode {
+	case "", "warn":
+	case "refuse":
+		cfg.WIPLimitRefuse = true
+	default:
+		return nil, fmt.Errorf("WIP_LIMIT_MODE must be \"warn\" or \"refuse\", got %q", mode)
+	}
+
+	// Default storage mode if not specified
+	if cfg.StorageMode == "" {
+		cfg.StorageMode = "files"
+	}
+	if cfg.StorageMode != "files" && cfg.StorageMode != "events" {
+		return nil, fmt.Errorf("STORAGE_MODE must be \"files\" or \"events\", got %q", cfg.StorageMode)
+	}
+	if cfg.EventLogPath == "" {
+		cfg.EventLogPath = "events.jsonl"
+	}
+
+	cfg.InitDataFiles = parseBool(os.Getenv("INIT_DATA_FILES"), true)
+
+	// Data file locations in the data repo
+	paths, err := storage.ParsePaths(os.Getenv("DATA_PATH_PREFIX"), os.Getenv("DATA_PATHS"))
+	if err != nil {
+		return nil, fmt.Errorf("DATA_PATH_PREFIX/DATA_PATHS: %w", err)
+	}
+	cfg.DataPaths = paths
+
+	// Data file backend: the GitHub repo, or an S3/GCS bucket
+	cfg.StorageBackend = strings.ToLower(strings.TrimSpace(os.Getenv("STORAGE_BACKEND")))
+	if cfg.StorageBackend == "" {
+		cfg.StorageBackend = StorageGitHub
+	}
+	switch cfg.StorageBackend {
+	case StorageGitHub:
+	case StorageSQLite:
+		cfg.SQLitePath = os.Getenv("SQLITE_PATH")
+		if cfg.SQLitePath == "" {
+			cfg.SQLitePath = filepath.Join(cfg.DataDir, "momentum.db")
+		}
+		cfg.SQLiteExportInterval = parseDurationSeconds(os.Getenv("SQLITE_EXPORT_INTERVAL"), storage.DefaultExportInterval)
+	case StorageS3, StorageGCS:
+		cfg.Object = storage.ObjectConfig{
+			Provider:        cfg.StorageBackend,
+			Bucket:          os.Getenv("OBJECT_BUCKET"),
+			Prefix:          os.Getenv("OBJECT_PREFIX"),
+			Endpoint:        os.Getenv("OBJECT_ENDPOINT"),
+			Region:          os.Getenv("OBJECT_REGION"),
+			AccessKeyID:     os.Getenv("OBJECT_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("OBJECT_SECRET_ACCESS_KEY"),
+		}
+	default:
+		return nil, fmt.Errorf("STORAGE_BACKEND must be %q, %q, %q or %q, got %q", StorageGitHub, StorageSQLite, StorageS3, StorageGCS, cfg.StorageBackend)
+	}
+
+	// In-memory sample data instead of a repo, for trying the server out
+	cfg.DevMode = parseBool(os.Getenv("DEV_MODE"), false)
+
+	// Optional fake start time for demos
+	if s := os.Getenv("FAKE_NOW"); s != "" {
+		t, err := parseFakeNow(s)
+		if err != nil {
+			return nil, err
+		}
+		cfg.FakeNow = t
+	}
+
+	cfg.Location = time.UTC
+	if s := os.Getenv("TIMEZONE"); s != "" {
+		loc, err := time.LoadLocation(s)
+		if err != nil {
+			return nil, fmt.Errorf("TIMEZONE must be an IANA timezone name such as Europe/London, got %q", s)
+		}
+		cfg.Location = loc
+	}
+
+	// Default trace service name if not specified
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = "momentum-mcp-server"
+	}
+
 	// Parse OAuth token TTLs with defaults
 	cfg.OAuthAccessTokenTTL = parseDurationSeconds(
 		os.Getenv("OAUTH_ACCESS_TOKEN_TTL"),
@@ -78,12 +525,186 @@ func Load() (*Config, error) {
 		DefaultRefreshTokenTTL,
 	)
 
-	// Validate required fields
-	if cfg.GitHubToken == "" {
-		return nil, fmt.Errorf("GITHUB_TOKEN environment variable is required")
+	// OAuth state encryption keys (base64, 32 bytes)
+	if v := os.Getenv("OAUTH_STATE_KEY"); v != "" {
+		if cfg.OAuthStateKey, err = auth.ParseStateKey(v); err != nil {
+			return nil, fmt.Errorf("OAUTH_STATE_KEY: %w", err)
+		}
+	}
+	for i, v := range parseList(os.Getenv("OAUTH_STATE_OLD_KEYS")) {
+		key, err := auth.ParseStateKey(v)
+		if err != nil {
+			return nil, fmt.Errorf("OAUTH_STATE_OLD_KEYS entry %d: %w", i+1, err)
+		}
+		cfg.OAuthStateOldKeys = append(cfg.OAuthStateOldKeys, key)
+	}
+
+	// Reverse proxies whose X-Forwarded-For is believed
+	if cfg.TrustedProxies, err = auth.ParseTrustedProxies(parseList(os.Getenv("TRUSTED_PROXIES"))); err != nil {
+		return nil, fmt.Errorf("TRUSTED_PROXIES: %w", err)
+	}
+
+	// GitHub activity cache (seconds)
+	cfg.GitHubActivityCacheTTL = parseDurationSeconds(os.Getenv("GITHUB_ACTIVITY_CACHE_TTL"), 15*time.Minute)
+
+	// Data file read cache (seconds)
+	cfg.ReadCacheTTL = parseDurationSeconds(os.Getenv("READ_CACHE_TTL"), storage.DefaultReadCacheTTL)
+
+	// Calendar events cache (seconds)
+	cfg.GoogleCalendarCacheTTL = parseDurationSeconds(os.Getenv("GOOGLE_CALENDAR_CACHE_TTL"), 5*time.Minute)
+
+	// WakaTime coding time cache (seconds)
+	cfg.WakaTimeCacheTTL = parseDurationSeconds(os.Getenv("WAKATIME_CACHE_TTL"), 15*time.Minute)
+
+	// Milestone issue labels (comma-separated)
+	for _, label := range strings.Split(os.Getenv("MILESTONE_ISSUES_LABELS"), ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			cfg.MilestoneIssuesLabels = append(cfg.MilestoneIssuesLabels, label)
+		}
+	}
+
+	cfg.ReadOnly = parseBool(os.Getenv("READ_ONLY"), false)
+	cfg.ReadOnlyTools = parseList(os.Getenv("READ_ONLY_TOOLS"))
+
+	// Alerts on tool results (off by default), skipping the tools that
+	// already list overdue and due items unless TOOL_ALERTS_SKIP says otherwise
+	cfg.ToolAlerts = parseBool(os.Getenv("TOOL_ALERTS"), false)
+	cfg.ToolAlertsWindow = time.Duration(parseInt(os.Getenv("TOOL_ALERTS_HOURS"), 48)) * time.Hour
+	cfg.ToolAlertsSkip = alerts.DefaultSkip
+	if skip, ok := os.LookupEnv("TOOL_ALERTS_SKIP"); ok {
+		cfg.ToolAlertsSkip = parseList(skip)
+	}
+
+	// Destructive tool confirmation (off by default), with per-tool
+	// overrides as tool=mode pairs
+	if cfg.ConfirmMode, err = confirm.ParseMode(os.Getenv("CONFIRM_DESTRUCTIVE")); err != nil {
+		return nil, fmt.Errorf("CONFIRM_DESTRUCTIVE: %w", err)
+	}
+	for _, pair := range parseList(os.Getenv("CONFIRM_TOOLS")) {
+		name, value, ok := strings.Cut(pair, "=")
+		mode, err := confirm.ParseMode(value)
+		if !ok || strings.TrimSpace(name) == "" || err != nil {
+			return nil, fmt.Errorf("CONFIRM_TOOLS: %q must be tool=off, tool=elicit or tool=token", pair)
+		}
+		if cfg.ConfirmTools == nil {
+			cfg.ConfirmTools = make(map[string]confirm.Mode)
+		}
+		cfg.ConfirmTools[strings.TrimSpace(name)] = mode
+	}
+
+	// JSON REST API alongside MCP (on by default; same auth as /mcp)
+	cfg.APIEnabled = parseBool(os.Getenv("API_ENABLED"), true)
+
+	// Read-only /status page (off by default)
+	cfg.StatusPage = parseBool(os.Getenv("STATUS_PAGE"), false)
+
+	// Daily trend snapshots (off by default; each one is a commit)
+	cfg.TrendsEnabled = parseBool(os.Getenv("TRENDS_ENABLED"), false)
+	cfg.TrendsHour = parseInt(os.Getenv("TRENDS_HOUR"), 23)
+	if cfg.TrendsHour < 0 || cfg.TrendsHour > 23 {
+		return nil, fmt.Errorf("TRENDS_HOUR must be between 0 and 23, got %d", cfg.TrendsHour)
+	}
+
+	// Reminder notification schedule and SMTP port
+	cfg.NotifyHour = parseInt(os.Getenv("NOTIFY_HOUR"), 8)
+	if cfg.NotifyHour < 0 || cfg.NotifyHour > 23 {
+		return nil, fmt.Errorf("NOTIFY_HOUR must be between 0 and 23, got %d", cfg.NotifyHour)
+	}
+	cfg.SMTPPort = parseInt(os.Getenv("SMTP_PORT"), 587)
+	if cfg.SMTPHost != "" && (cfg.NotifyEmailFrom == "" || cfg.NotifyEmailTo == "") {
+		return nil, fmt.Errorf("NOTIFY_EMAIL_FROM and NOTIFY_EMAIL_TO are required when SMTP_HOST is set")
+	}
+	if expr := os.Getenv("BRIEFING_SCHEDULE"); expr != "" {
+		schedule, err := notify.ParseCron(expr)
+		if err != nil {
+			return nil, fmt.Errorf("BRIEFING_SCHEDULE: %w", err)
+		}
+		if cfg.NotifyWebhookURL == "" && cfg.SMTPHost == "" {
+			return nil, fmt.Errorf("BRIEFING_SCHEDULE requires NOTIFY_WEBHOOK_URL or SMTP_HOST")
+		}
+		cfg.BriefingSchedule = schedule
+	}
+
+	// Backups to a secondary location (off by default)
+	if err := parseBackupDestination(cfg, os.Getenv("BACKUP_DESTINATION")); err != nil {
+		return nil, err
+	}
+	cfg.BackupKeep = parseInt(os.Getenv("BACKUP_KEEP"), 7)
+	if cfg.BackupKeep < 1 {
+		return nil, fmt.Errorf("BACKUP_KEEP must be at least 1, got %d", cfg.BackupKeep)
+	}
+	if expr := os.Getenv("BACKUP_SCHEDULE"); expr != "" {
+		schedule, err := notify.ParseCron(expr)
+		if err != nil {
+			return nil, fmt.Errorf("BACKUP_SCHEDULE: %w", err)
+		}
+		if !cfg.HasBackupDestination() {
+			return nil, fmt.Errorf("BACKUP_SCHEDULE requires BACKUP_DESTINATION")
+		}
+		cfg.BackupSchedule = schedule
+	}
+
+	// GitHub needs both a name and an email for a commit identity
+	if (cfg.CommitAuthorName == "") != (cfg.CommitAuthorEmail == "") {
+		return nil, fmt.Errorf("COMMIT_AUTHOR_NAME and COMMIT_AUTHOR_EMAIL must be set together")
+	}
+
+	// The Telegram bot must be locked to one chat
+	if cfg.TelegramBotToken != "" {
+		id, err := strconv.ParseInt(os.Getenv("TELEGRAM_CHAT_ID"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("TELEGRAM_CHAT_ID is required when TELEGRAM_BOT_TOKEN is set")
+		}
+		cfg.TelegramChatID = id
 	}
-	if cfg.GitHubRepo == "" {
-		return nil, fmt.Errorf("GITHUB_REPO environment variable is required")
+
+	// Background feed fetching (off by default)
+	cfg.FeedsInterval = parseDurationSeconds(os.Getenv("FEEDS_INTERVAL"), 0)
+	cfg.FeedsMaxPerFeed = parseInt(os.Getenv("FEEDS_MAX_PER_FEED"), 5)
+
+	// Daily reading list archival (off by default)
+	cfg.ReadingArchiveMonths = parseInt(os.Getenv("READING_ARCHIVE_MONTHS"), 0)
+
+	// Historic analytics backfill (on by default; cached after the first run)
+	cfg.AnalyticsBackfill = parseBool(os.Getenv("ANALYTICS_BACKFILL"), true)
+	cfg.AnalyticsBackfillMaxCommits = parseInt(os.Getenv("ANALYTICS_BACKFILL_MAX_COMMITS"), 500)
+
+	// Client compatibility shims (root endpoint and client_id-less refresh
+	// default on, since existing connectors depend on them)
+	cfg.CompatRootEndpoint = parseBool(os.Getenv("COMPAT_ROOT_ENDPOINT"), true)
+	cfg.CompatRefreshWithoutClientID = parseBool(os.Getenv("COMPAT_REFRESH_WITHOUT_CLIENT_ID"), true)
+	cfg.CompatEchoResource = parseBool(os.Getenv("COMPAT_ECHO_RESOURCE"), false)
+	cfg.CompatReportSize = parseInt(os.Getenv("COMPAT_REPORT_SIZE"), 20)
+
+	// Dev mode needs no repo, and a well-known token if none is set
+	if cfg.DevMode {
+		if cfg.AuthToken == "" {
+			cfg.AuthToken = DevAuthToken
+		}
+		return cfg, nil
+	}
+
+	// Validate required fields. SQLite needs nothing; it exports to the
+	// repo if there is one
+	switch cfg.StorageBackend {
+	case StorageSQLite:
+		if cfg.GitHubRepo != "" && cfg.GitHubToken == "" {
+			return nil, fmt.Errorf("GITHUB_TOKEN is required to export the SQLite data to GITHUB_REPO")
+		}
+	case StorageS3, StorageGCS:
+		if cfg.Object.Bucket == "" {
+			return nil, fmt.Errorf("OBJECT_BUCKET is required when STORAGE_BACKEND is %s", cfg.StorageBackend)
+		}
+		if cfg.Object.AccessKeyID == "" || cfg.Object.SecretAccessKey == "" {
+			return nil, fmt.Errorf("OBJECT_ACCESS_KEY_ID and OBJECT_SECRET_ACCESS_KEY are required when STORAGE_BACKEND is %s", cfg.StorageBackend)
+		}
+	default:
+		if cfg.GitHubToken == "" {
+			return nil, fmt.Errorf("GITHUB_TOKEN environment variable is required")
+		}
+		if cfg.GitHubRepo == "" {
+			return nil, fmt.Errorf("GITHUB_REPO environment variable is required")
+		}
 	}
 	if cfg.AuthToken == "" {
 		return nil, fmt.Errorf("AUTH_TOKEN environment variable is required")
@@ -92,8 +713,83 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// Storage backends for STORAGE_BACKEND.
+const (
+	StorageGitHub = "github"
+	StorageSQLite = "sqlite"
+	StorageS3     = storage.ProviderS3
+	StorageGCS    = storage.ProviderGCS
+)
+
+// DevAuthToken is the AUTH_TOKEN in dev mode when none is configured.
+const DevAuthToken = "dev"
+
+// parseFakeNow parses FAKE_NOW as RFC 3339 or a YYYY-MM-DD date (midnight UTC).
+func parseFakeNow(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("FAKE_NOW must be RFC 3339 or YYYY-MM-DD, got %q", s)
+}
+
 // parseDurationSeconds parses a string as seconds and returns a Duration.
 // If the string is empty or invalid, returns the default value.
+// parseBackupDestination sets the backup location from dest: dir:<path>,
+// github:<owner/repo>, s3:<bucket>[/<prefix>] or gcs:<bucket>[/<prefix>].
+func parseBackupDestination(cfg *Config, dest string) error {
+	if dest == "" {
+		return nil
+	}
+	kind, target, _ := strings.Cut(dest, ":")
+	if target == "" {
+		return fmt.Errorf("BACKUP_DESTINATION must be dir:<path>, github:<owner/repo>, s3:<bucket> or gcs:<bucket>, got %q", dest)
+	}
+	switch kind {
+	case "dir":
+		cfg.BackupDir = target
+	case "github":
+		if !strings.Contains(target, "/") {
+			return fmt.Errorf("BACKUP_DESTINATION github repo must be owner/repo, got %q", target)
+		}
+		cfg.BackupGitHubRepo = target
+		cfg.BackupGitHubToken = os.Getenv("BACKUP_GITHUB_TOKEN")
+		if cfg.BackupGitHubToken == "" {
+			cfg.BackupGitHubToken = cfg.GitHubToken
+		}
+		if cfg.BackupGitHubToken == "" {
+			return fmt.Errorf("BACKUP_GITHUB_TOKEN or GITHUB_TOKEN is required for a github backup destination")
+		}
+	case StorageS3, StorageGCS:
+		bucket, prefix, _ := strings.Cut(target, "/")
+		if prefix != "" && !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+		cfg.BackupObject = storage.ObjectConfig{
+			Provider:        kind,
+			Bucket:          bucket,
+			Prefix:          prefix,
+			Endpoint:        os.Getenv("BACKUP_OBJECT_ENDPOINT"),
+			Region:          os.Getenv("BACKUP_OBJECT_REGION"),
+			AccessKeyID:     os.Getenv("BACKUP_OBJECT_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("BACKUP_OBJECT_SECRET_ACCESS_KEY"),
+		}
+		if cfg.BackupObject.AccessKeyID == "" || cfg.BackupObject.SecretAccessKey == "" {
+			return fmt.Errorf("BACKUP_OBJECT_ACCESS_KEY_ID and BACKUP_OBJECT_SECRET_ACCESS_KEY are required for a %s backup destination", kind)
+		}
+	default:
+		return fmt.Errorf("BACKUP_DESTINATION must be dir:<path>, github:<owner/repo>, s3:<bucket> or gcs:<bucket>, got %q", dest)
+	}
+	return nil
+}
+
+// HasBackupDestination reports whether BACKUP_DESTINATION is set.
+func (c *Config) HasBackupDestination() bool {
+	return c.BackupDir != "" || c.BackupGitHubRepo != "" || c.BackupObject.Bucket != ""
+}
+
 func parseDurationSeconds(s string, defaultVal time.Duration) time.Duration {
 	if s == "" {
 		return defaultVal
@@ -105,6 +801,43 @@ func parseDurationSeconds(s string, defaultVal time.Duration) time.Duration {
 	return time.Duration(seconds) * time.Second
 }
 
+// parseBool parses a boolean string ("true", "1", "false", "0", ...).
+// If the string is empty or invalid, returns the default value.
+func parseBool(s string, defaultVal bool) bool {
+	if s == "" {
+		return defaultVal
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return defaultVal
+	}
+	return b
+}
+
+// parseList splits a comma-separated string, dropping empty entries.
+func parseList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// parseInt parses a positive integer string.
+// If the string is empty, invalid, or not positive, returns the default value.
+func parseInt(s string, defaultVal int) int {
+	if s == "" {
+		return defaultVal
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return defaultVal
+	}
+	return n
+}
+
 // GitHubUsername extracts the owner/username from the GitHubRepo.
 func (c *Config) GitHubUsername() string {
 	parts := strings.SplitN(c.GitHubRepo, "/", 2)
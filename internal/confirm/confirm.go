@@ -0,0 +1,268 @@
+// Package confirm guards destructive tools (deletes and bulk changes) behind
+// a server-wide confirmation policy.
+//
+// Some tools already take a confirm flag, but a model can set that itself.
+// The policy asks the user instead: with MCP elicitation where the client
+// supports it, or else by refusing the first call with a one-time token the
+// model has to pass back, after checking with the user, as confirm_token.
+// It is applied as receiving middleware, so tools need no changes of their
+// own; the middleware also adds confirm_token to guarded tools' schemas.
+package confirm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/usage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Mode is how a guarded tool is confirmed.
+type Mode string
+
+const (
+	// ModeOff runs the tool without confirmation.
+	ModeOff Mode = "off"
+	// ModeElicit asks the user through MCP elicitation, falling back to a
+	// token for clients that don't support it.
+	ModeElicit Mode = "elicit"
+	// ModeToken refuses the first call with a one-time confirm token.
+	ModeToken Mode = "token"
+)
+
+// ParseMode parses a mode name. Empty means ModeOff.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case "":
+		return ModeOff, nil
+	case ModeOff, ModeElicit, ModeToken:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown confirmation mode %q (use off, elicit or token)", s)
+}
+
+// TokenArg is the argument a confirm token is passed back in.
+const TokenArg = "confirm_token"
+
+// TokenTTL is how long a confirm token stays valid.
+const TokenTTL = 10 * time.Minute
+
+// DefaultTools are the tools the default mode applies to: deletes and
+// changes that rewrite whole files.
+var DefaultTools = []string{
+	"delete_todo",
+	"delete_reminder",
+	"delete_reading_item",
+	"delete_note",
+	"dedupe_reading_list",
+	"import_data",
+	"patch_file",
+	"undo_last_change",
+}
+
+// Policy decides which tools need confirmation and tracks issued tokens.
+type Policy struct {
+	modes map[string]Mode
+	clock clock.Clock
+
+	mu     sync.Mutex
+	tokens map[string]pending // token -> the call it confirms
+}
+
+type pending struct {
+	call    string
+	expires time.Time
+}
+
+// New creates a Policy applying defaultMode to DefaultTools, with per-tool
+// overrides (which may also guard other tools, or turn a default off).
+func New(defaultMode Mode, overrides map[string]Mode, c clock.Clock) *Policy {
+	modes := make(map[string]Mode)
+	for _, name := range DefaultTools {
+		modes[name] = defaultMode
+	}
+	for name, mode := range overrides {
+		modes[name] = mode
+	}
+	for name, mode := range modes {
+		if mode == ModeOff || mode == "" {
+			delete(modes, name)
+		}
+	}
+	return &Policy{modes: modes, clock: clock.Or(c), tokens: make(map[string]pending)}
+}
+
+// Enabled reports whether any tool needs confirmation.
+func (p *Policy) Enabled() bool {
+	return len(p.modes) > 0
+}
+
+// Guarded returns the guarded tool names, sorted.
+func (p *Policy) Guarded() []string {
+	names := make([]string, 0, len(p.modes))
+	for name := range p.modes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Middleware returns MCP receiving middleware that confirms calls to
+// guarded tools before running them, and advertises confirm_token in their
+// input schemas.
+func (p *Policy) Middleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			switch method {
+			case "tools/list":
+				result, err := next(ctx, method, req)
+				if list, ok := result.(*mcp.ListToolsResult); ok && err == nil {
+					return p.advertise(list), nil
+				}
+				return result, err
+			case "tools/call":
+				call, ok := req.(*mcp.CallToolRequest)
+				if !ok || call.Params == nil {
+					break
+				}
+				mode := p.modes[call.Params.Name]
+				if mode == "" {
+					break
+				}
+				if refused := p.confirm(ctx, mode, call); refused != nil {
+					return refused, nil
+				}
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// confirm returns nil if the call may run, or the result refusing it.
+// It strips confirm_token from the call's arguments.
+func (p *Policy) confirm(ctx context.Context, mode Mode, call *mcp.CallToolRequest) *mcp.CallToolResult {
+	name := call.Params.Name
+	args := map[string]any{}
+	if len(call.Params.Arguments) > 0 {
+		if err := json.Unmarshal(call.Params.Arguments, &args); err != nil {
+			return nil // let the tool report the malformed arguments
+		}
+	}
+	token, _ := args[TokenArg].(string)
+	if _, ok := args[TokenArg]; ok {
+		delete(args, TokenArg)
+		call.Params.Arguments, _ = json.Marshal(args)
+	}
+
+	// Previews change nothing
+	if dryRun, _ := args["dry_run"].(bool); dryRun {
+		return nil
+	}
+
+	// Arguments marshal with sorted keys, so equal calls give equal keys
+	key, _ := json.Marshal(args)
+	callKey := name + " " + string(key)
+
+	if token != "" {
+		if p.redeem(token, callKey) {
+			return nil
+		}
+		return usage.FailedResult(fmt.Sprintf("Invalid or expired %s for %s with these arguments. Call it again without one to get a new token.", TokenArg, name))
+	}
+
+	if mode == ModeElicit && supportsElicitation(call.Session) {
+		res, err := call.Session.Elicit(ctx, &mcp.ElicitParams{
+			Message:         fmt.Sprintf("Allow %s? It permanently changes your data.\n\nArguments: %s", name, key),
+			RequestedSchema: map[string]any{"type": "object", "properties": map[string]any{}},
+		})
+		if err == nil {
+			if res.Action == "accept" {
+				return nil
+			}
+			return usage.FailedResult(fmt.Sprintf("Cancelled: the user did not confirm %s.", name))
+		}
+		slog.Warn("confirmation elicitation failed; falling back to a confirm token", "tool", name, "error", err)
+	}
+
+	token = p.issue(callKey)
+	return usage.FailedResult(fmt.Sprintf("%s permanently changes data and needs confirmation. Check with the user, then call it again with the same arguments plus %s %q (valid for %d minutes).",
+		name, TokenArg, token, int(TokenTTL.Minutes())))
+}
+
+func supportsElicitation(ss *mcp.ServerSession) bool {
+	if ss == nil {
+		return false
+	}
+	params := ss.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+// issue returns a new single-use token for the call.
+func (p *Policy) issue(callKey string) string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	token := hex.EncodeToString(b)
+
+	now := p.clock.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for t, pend := range p.tokens {
+		if now.After(pend.expires) {
+			delete(p.tokens, t)
+		}
+	}
+	p.tokens[token] = pending{call: callKey, expires: now.Add(TokenTTL)}
+	return token
+}
+
+// redeem consumes token if it was issued for this call and hasn't expired.
+func (p *Policy) redeem(token, callKey string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pend, ok := p.tokens[token]
+	if !ok || pend.call != callKey {
+		return false
+	}
+	delete(p.tokens, token)
+	return !p.clock.Now().After(pend.expires)
+}
+
+// advertise returns list with confirm_token added to the input schema of
+// each guarded tool. Registered tools are copied, not modified.
+func (p *Policy) advertise(list *mcp.ListToolsResult) *mcp.ListToolsResult {
+	out := *list
+	out.Tools = make([]*mcp.Tool, len(list.Tools))
+	for i, tool := range list.Tools {
+		out.Tools[i] = tool
+		if p.modes[tool.Name] == "" {
+			continue
+		}
+		var schema map[string]any
+		raw, _ := json.Marshal(tool.InputSchema)
+		if json.Unmarshal(raw, &schema) != nil || schema == nil {
+			continue
+		}
+		props, _ := schema["properties"].(map[string]any)
+		if props == nil {
+			props = map[string]any{}
+		}
+		props[TokenArg] = map[string]any{
+			"type":        "string",
+			"description": "Confirms this destructive call. Leave unset; if the server asks for confirmation, check with the user and retry with the token it gives.",
+		}
+		schema["properties"] = props
+		copied := *tool
+		copied.InputSchema = schema
+		out.Tools[i] = &copied
+	}
+	return &out
+}
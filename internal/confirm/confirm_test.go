@@ -0,0 +1,180 @@
+package confirm
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type deleteInput struct {
+	ID     string `json:"id"`
+	DryRun bool   `json:"dry_run,omitempty"`
+}
+
+type deleteOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// connect serves a delete_todo tool behind the policy and returns a client
+// session to it and a pointer to the number of times the tool ran.
+func connect(t *testing.T, p *Policy, opts *mcp.ClientOptions) (*mcp.ClientSession, *int) {
+	t.Helper()
+	ran := new(int)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "delete_todo"}, func(ctx context.Context, req *mcp.CallToolRequest, in deleteInput) (*mcp.CallToolResult, deleteOutput, error) {
+		if !in.DryRun {
+			*ran++
+		}
+		return nil, deleteOutput{Success: true, Message: "deleted " + in.ID}, nil
+	})
+	server.AddReceivingMiddleware(p.Middleware())
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, opts).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session, ran
+}
+
+func call(t *testing.T, session *mcp.ClientSession, args map[string]any) deleteOutput {
+	t.Helper()
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "delete_todo", Arguments: args})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError {
+		t.Fatalf("error result %+v", res.Content)
+	}
+	var out deleteOutput
+	raw, _ := json.Marshal(res.StructuredContent)
+	json.Unmarshal(raw, &out)
+	return out
+}
+
+var tokenPattern = regexp.MustCompile(`confirm_token "([0-9a-f]+)"`)
+
+func TestToken(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC))
+	session, ran := connect(t, New(ModeToken, nil, fake), nil)
+
+	first := call(t, session, map[string]any{"id": "a1"})
+	m := tokenPattern.FindStringSubmatch(first.Message)
+	if first.Success || m == nil || *ran != 0 {
+		t.Fatalf("first call = %+v, ran %d; want a refusal with a token", first, *ran)
+	}
+	token := m[1]
+
+	// The token only confirms the call it was issued for
+	if out := call(t, session, map[string]any{"id": "b2", TokenArg: token}); out.Success || *ran != 0 {
+		t.Errorf("token accepted for other arguments: %+v", out)
+	}
+	if out := call(t, session, map[string]any{"id": "a1", TokenArg: token}); !out.Success || out.Message != "deleted a1" || *ran != 1 {
+		t.Errorf("confirmed call = %+v, ran %d", out, *ran)
+	}
+	// and only once
+	if out := call(t, session, map[string]any{"id": "a1", TokenArg: token}); out.Success || *ran != 1 {
+		t.Errorf("token reused: %+v", out)
+	}
+
+	// Tokens expire
+	m = tokenPattern.FindStringSubmatch(call(t, session, map[string]any{"id": "a1"}).Message)
+	fake.Advance(TokenTTL + time.Second)
+	if out := call(t, session, map[string]any{"id": "a1", TokenArg: m[1]}); out.Success || *ran != 1 {
+		t.Errorf("expired token accepted: %+v", out)
+	}
+
+	// Dry runs go straight through
+	if out := call(t, session, map[string]any{"id": "a1", "dry_run": true}); !out.Success {
+		t.Errorf("dry run = %+v", out)
+	}
+}
+
+func TestElicit(t *testing.T) {
+	var action string
+	var asked string
+	session, ran := connect(t, New(ModeElicit, nil, nil), &mcp.ClientOptions{
+		ElicitationHandler: func(ctx context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			asked = req.Params.Message
+			return &mcp.ElicitResult{Action: action}, nil
+		},
+	})
+
+	action = "decline"
+	if out := call(t, session, map[string]any{"id": "a1"}); out.Success || *ran != 0 {
+		t.Errorf("declined call = %+v, ran %d", out, *ran)
+	}
+	if !regexp.MustCompile(`(?s)delete_todo.*"id":"a1"`).MatchString(asked) {
+		t.Errorf("elicitation message %q doesn't describe the call", asked)
+	}
+
+	action = "accept"
+	if out := call(t, session, map[string]any{"id": "a1"}); !out.Success || *ran != 1 {
+		t.Errorf("accepted call = %+v, ran %d", out, *ran)
+	}
+}
+
+func TestElicit_FallsBackToToken(t *testing.T) {
+	session, ran := connect(t, New(ModeElicit, nil, nil), nil)
+
+	out := call(t, session, map[string]any{"id": "a1"})
+	m := tokenPattern.FindStringSubmatch(out.Message)
+	if out.Success || m == nil {
+		t.Fatalf("call without elicitation support = %+v; want a token", out)
+	}
+	if out := call(t, session, map[string]any{"id": "a1", TokenArg: m[1]}); !out.Success || *ran != 1 {
+		t.Errorf("confirmed call = %+v, ran %d", out, *ran)
+	}
+}
+
+func TestOverridesAndSchema(t *testing.T) {
+	p := New(ModeToken, map[string]Mode{"delete_todo": ModeOff, "advance_phase": ModeElicit}, nil)
+	if got := p.Guarded(); len(got) != len(DefaultTools) || got[0] != "advance_phase" {
+		t.Errorf("Guarded() = %v", got)
+	}
+	session, ran := connect(t, p, nil)
+	if out := call(t, session, map[string]any{"id": "a1"}); !out.Success || *ran != 1 {
+		t.Errorf("unguarded call = %+v", out)
+	}
+
+	// Guarded tools advertise confirm_token
+	session, _ = connect(t, New(ModeToken, nil, nil), nil)
+	list, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := json.Marshal(list.Tools[0].InputSchema)
+	var schema struct {
+		Properties map[string]any `json:"properties"`
+	}
+	json.Unmarshal(raw, &schema)
+	if schema.Properties[TokenArg] == nil || schema.Properties["id"] == nil {
+		t.Errorf("delete_todo schema = %s", raw)
+	}
+
+	if New(ModeOff, nil, nil).Enabled() {
+		t.Error("policy with everything off reports enabled")
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for in, want := range map[string]Mode{"": ModeOff, "OFF": ModeOff, "elicit": ModeElicit, " token ": ModeToken} {
+		if got, err := ParseMode(in); err != nil || got != want {
+			t.Errorf("ParseMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseMode("ask"); err == nil {
+		t.Error("ParseMode(ask) succeeded")
+	}
+}
@@ -0,0 +1,166 @@
+// Package deadline enforces per-request timeouts and propagates HTTP request
+// cancellation into MCP tool handlers.
+//
+// The MCP SDK runs tool handlers on the session's context rather than the
+// HTTP request's, so a client disconnecting or a request timing out would not
+// otherwise stop in-flight storage and GitHub calls. HTTPMiddleware registers
+// each request's context under a key of its own, passed on in a request
+// header, and ToolMiddleware links the tool handler's context to it.
+package deadline
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// httpGrace is extra time the HTTP request is allowed beyond the tool
+// deadline, so the timeout result can still be written to the client.
+const httpGrace = 2 * time.Second
+
+// keyHeader carries the key HTTPMiddleware registered a request's context
+// under to the MCP handlers, which see the request headers but not its
+// context. The key is generated here rather than taken from the client's
+// X-Request-Id, which two requests may share.
+const keyHeader = "X-Momentum-Deadline-Key"
+
+// Enforcer applies a request deadline and tracks live HTTP request contexts.
+type Enforcer struct {
+	timeout time.Duration
+
+	mu       sync.Mutex
+	requests map[string]context.Context // key -> HTTP request context
+	lastKey  atomic.Uint64
+}
+
+// New creates an Enforcer with the given per-request timeout. A timeout of
+// zero or less disables deadlines but still propagates cancellation.
+func New(timeout time.Duration) *Enforcer {
+	return &Enforcer{
+		timeout:  timeout,
+		requests: make(map[string]context.Context),
+	}
+}
+
+// HTTPMiddleware bounds each request with the configured deadline (plus a
+// short grace period) and registers its context for ToolMiddleware. Long-lived
+// SSE GET streams are exempt from the deadline.
+func (e *Enforcer) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		if e.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, e.timeout+httpGrace)
+			defer cancel()
+		}
+
+		// A key sent by the client is replaced, so it can't reach another
+		// request's context
+		key := strconv.FormatUint(e.lastKey.Add(1), 10)
+		r.Header.Set(keyHeader, key)
+		e.mu.Lock()
+		e.requests[key] = ctx
+		e.mu.Unlock()
+		defer func() {
+			e.mu.Lock()
+			delete(e.requests, key)
+			e.mu.Unlock()
+		}()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requestContext returns the live HTTP context registered under key, if any.
+func (e *Enforcer) requestContext(key string) context.Context {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.requests[key]
+}
+
+// ToolMiddleware returns MCP receiving middleware that cancels a tool call
+// when its HTTP request goes away and reports deadline overruns as a tool
+// error result instead of leaving the request hanging.
+func (e *Enforcer) ToolMiddleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+
+			ctx, cancel := e.linkContext(ctx, req)
+			defer cancel()
+
+			type outcome struct {
+				result mcp.Result
+				err    error
+			}
+			done := make(chan outcome, 1)
+			go func() {
+				result, err := next(ctx, method, req)
+				done <- outcome{result, err}
+			}()
+
+			select {
+			case o := <-done:
+				if ctx.Err() == context.DeadlineExceeded {
+					return e.timeoutResult(), nil
+				}
+				return o.result, o.err
+			case <-ctx.Done():
+				if ctx.Err() == context.DeadlineExceeded {
+					return e.timeoutResult(), nil
+				}
+				// Client disconnected; nobody is waiting for the result
+				return nil, ctx.Err()
+			}
+		}
+	}
+}
+
+// linkContext derives the tool context: bounded by the timeout and cancelled
+// when the originating HTTP request ends.
+func (e *Enforcer) linkContext(ctx context.Context, req mcp.Request) (context.Context, context.CancelFunc) {
+	var cancel context.CancelFunc
+	if e.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+
+	extra := req.GetExtra()
+	if extra == nil || extra.Header == nil {
+		return ctx, cancel
+	}
+	httpCtx := e.requestContext(extra.Header.Get(keyHeader))
+	if httpCtx == nil {
+		return ctx, cancel
+	}
+
+	stop := context.AfterFunc(httpCtx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+func (e *Enforcer) timeoutResult() *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{
+			Text: fmt.Sprintf("Request timed out after %s. The change may still have been saved - check before retrying.", e.timeout),
+		}},
+	}
+}
@@ -0,0 +1,128 @@
+package deadline
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/logging"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// blockingHandler waits until its context is done.
+func blockingHandler(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestToolMiddleware_Timeout(t *testing.T) {
+	e := New(20 * time.Millisecond)
+	handler := e.ToolMiddleware()(blockingHandler)
+
+	result, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: "slow"},
+	})
+	if err != nil {
+		t.Fatalf("expected timeout to be reported as a tool result, got error %v", err)
+	}
+	res, ok := result.(*mcp.CallToolResult)
+	if !ok || !res.IsError {
+		t.Errorf("expected error result, got %+v", result)
+	}
+}
+
+// startCall serves an HTTP request through the Enforcer that makes a tool
+// call once call is closed, the way the MCP handler does, with the request
+// headers. It returns the request's cancel function, a channel closed when
+// the tool handler runs and the tool call's error once it ends.
+func startCall(e *Enforcer, requestID string, call <-chan struct{}) (context.CancelFunc, <-chan struct{}, <-chan error) {
+	httpCtx, disconnect := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	finished := make(chan error, 1)
+
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-call
+		handler := e.ToolMiddleware()(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		_, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{
+			Params: &mcp.CallToolParamsRaw{Name: "slow"},
+			Extra:  &mcp.RequestExtra{Header: r.Header.Clone()},
+		})
+		finished <- err
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil).WithContext(httpCtx)
+	req.Header.Set(logging.RequestIDHeader, requestID)
+	// A client can't pick the key itself
+	req.Header.Set(keyHeader, "1")
+	go e.HTTPMiddleware(inner).ServeHTTP(httptest.NewRecorder(), req)
+	return disconnect, started, finished
+}
+
+// waitRegistered waits until n requests are registered with e.
+func waitRegistered(t *testing.T, e *Enforcer, n int) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		e.mu.Lock()
+		got := len(e.requests)
+		e.mu.Unlock()
+		if got == n {
+			return
+		}
+	}
+	t.Fatalf("expected %d registered requests", n)
+}
+
+func TestToolMiddleware_ClientDisconnect(t *testing.T) {
+	e := New(time.Minute)
+	call := make(chan struct{})
+	close(call)
+	disconnect, started, finished := startCall(e, "req-1", call)
+	<-started
+	disconnect()
+
+	select {
+	case err := <-finished:
+		if err != context.Canceled {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("tool call was not cancelled when the client disconnected")
+	}
+}
+
+func TestToolMiddleware_SharedRequestID(t *testing.T) {
+	e := New(time.Minute)
+
+	// Two clients sending the same X-Request-Id keep their own contexts,
+	// even when both requests are in flight before either calls a tool
+	call := make(chan struct{})
+	disconnectA, startedA, finishedA := startCall(e, "same", call)
+	defer disconnectA()
+	waitRegistered(t, e, 1)
+	disconnectB, startedB, finishedB := startCall(e, "same", call)
+	waitRegistered(t, e, 2)
+	close(call)
+	<-startedA
+	<-startedB
+	disconnectB()
+
+	select {
+	case err := <-finishedB:
+		if err != context.Canceled {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("tool call was not cancelled when its client disconnected")
+	}
+	select {
+	case err := <-finishedA:
+		t.Errorf("another client's disconnect ended the call: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
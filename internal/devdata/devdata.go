@@ -0,0 +1,138 @@
+// Package devdata generates sample data files for DEV_MODE, so every tool
+// and resource has something to show without a data repo.
+package devdata
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/assets"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// Files returns sample content for every data file, keyed by file name,
+// with dates relative to now so reminders and milestones are always due
+// around today. IDs are fixed, so examples can refer to them.
+func Files(now time.Time) (map[string]string, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	day := func(offset int) time.Time { return today.AddDate(0, 0, offset) }
+	at := func(offset, hour, minute int) time.Time {
+		return day(offset).Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	files := map[string]string{}
+
+	files[storage.TodosFile] = storage.SerializeTodos(&storage.TodoFile{
+		Active: []storage.Todo{
+			{ID: "a1000001", Text: "Fix flaky login test", Priority: storage.PriorityHigh, Project: "momentum", Added: day(-3)},
+			{ID: "a1000002", Text: "Write launch blog post", Priority: storage.PriorityHigh, Project: "momentum", Milestone: "b1000001", Added: day(-6)},
+			{ID: "a1000003", Text: "Review open pull requests", Priority: storage.PriorityNormal, Added: day(-1)},
+			{ID: "a1000004", Text: "Update README screenshots", Priority: storage.PriorityNormal, Project: "momentum", Added: day(-12)},
+			{ID: "a1000005", Text: "Book dentist appointment", Priority: storage.PriorityNormal, Added: day(-20)},
+			{ID: "a1000006", Text: "Learn a little Rust", Priority: storage.PrioritySomeday, Added: day(-45)},
+		},
+		Completed: []storage.Todo{
+			{ID: "a1000007", Text: "Set up CI pipeline", Priority: storage.PriorityHigh, Project: "momentum", Completed: true, Added: day(-10), CompletedAt: ptr(day(-2))},
+			{ID: "a1000008", Text: "Draft pricing page", Priority: storage.PriorityNormal, Completed: true, Added: day(-8), CompletedAt: ptr(day(-1))},
+			{ID: "a1000009", Text: "Reply to conference invite", Priority: storage.PriorityNormal, Completed: true, Added: day(-4), CompletedAt: ptr(day(0))},
+		},
+	})
+
+	files[storage.StrategyFile] = storage.SerializeStrategy(&storage.Strategy{
+		CurrentPhase: "Phase 2: Launch",
+		Phases: []storage.Phase{
+			{Name: "Phase 1: Foundations", Started: day(-60), Ended: ptr(day(-15)), Objectives: []string{"Private alpha"}},
+			{Name: "Phase 2: Launch", Started: day(-15), Objectives: []string{"Public beta", "First 100 users"}},
+		},
+		ActiveMilestones: []storage.Milestone{
+			{ID: "b1000001", Text: "Public beta announcement", Due: ptr(day(5)), Project: "momentum", Added: day(-14)},
+			{ID: "b1000002", Text: "First 100 users", Due: ptr(day(30)), Added: day(-14), KeyResults: []storage.KeyResult{
+				{ID: "kr_100001", Text: "Signed-up users", Current: 38, Target: 100, Unit: "users"},
+				{ID: "kr_100002", Text: "Weekly active users", Current: 12, Target: 40, Unit: "users"},
+			}},
+		},
+		CompletedMilestones: []storage.Milestone{
+			{ID: "b1000003", Text: "Private alpha with 10 testers", Completed: true, Added: day(-40), CompletedAt: ptr(day(-15))},
+		},
+		Notes: []string{
+			"Alpha testers asked most for a mobile view.",
+			"Keep the launch scope small: todos, reminders, reading list.",
+		},
+	})
+
+	files[storage.ReadingListFile] = storage.SerializeReadingList(&storage.ReadingList{
+		ToRead: []storage.ReadingItem{
+			{ID: "c1000001", URL: "https://go.dev/blog/loopvar-preview", Notes: "Loop variable semantics", Priority: storage.ReadingPriorityNext, Category: "go", Added: day(-2)},
+			{ID: "c1000002", URL: "https://modelcontextprotocol.io/specification", Notes: "Re-read the resources section", Category: "mcp", Added: day(-5)},
+			{ID: "c1000003", URL: "https://www.paulgraham.com/ds.html", Notes: "Do things that don't scale", Priority: storage.ReadingPrioritySomeday, Added: day(-30)},
+		},
+		Read: []storage.ReadingItem{
+			{ID: "c1000004", URL: "https://sqlite.org/whentouse.html", Read: true, Category: "databases", Added: day(-9), ReadAt: ptr(day(-3))},
+		},
+	})
+
+	files[storage.RemindersFile] = storage.SerializeReminders(&storage.ReminderFile{
+		Upcoming: []storage.Reminder{
+			{ID: "d1000001", Date: day(-1), Text: "Renew domain name", Added: day(-20)},
+			{ID: "d1000002", Date: day(0), Text: "Send weekly update to testers", Added: day(-7)},
+			{ID: "d1000003", Date: day(3), Text: "Call accountant about taxes", Added: day(-4)},
+			{ID: "d1000004", Date: day(12), Text: "Submit conference talk", Added: day(-2)},
+		},
+		Completed: []storage.Reminder{
+			{ID: "d1000005", Date: day(-5), Text: "Pay hosting invoice", Completed: true, Added: day(-15), CompletedAt: ptr(day(-5))},
+		},
+	})
+
+	files[storage.JournalFile] = storage.SerializeJournal(&storage.Journal{
+		Entries: []storage.JournalEntry{
+			{ID: "e1000001", Time: at(-1, 9, 15), Text: "Planned the beta announcement with the team."},
+			{ID: "e1000002", Time: at(-1, 17, 40), Text: "CI is green again after fixing the cache key."},
+			{ID: "e1000003", Time: at(0, 8, 30), Text: "Focus today: the login test and the blog post."},
+		},
+	})
+
+	files[storage.NotesFile] = storage.SerializeNotes(&storage.NoteFile{
+		Notes: []storage.Note{
+			{ID: "f1000001", Text: "Deploys go out from main via the release workflow.", Category: "Ops", Added: day(-20)},
+			{ID: "f1000002", Text: "Beta invite copy lives in the marketing folder.", Category: "Launch", Added: day(-6)},
+		},
+		Categories: []string{"Ops", "Launch"},
+	})
+
+	files[storage.TimeLogFile] = storage.SerializeTimeLog(&storage.TimeLog{
+		Entries: []storage.TimeEntry{
+			{ID: "9a000001", Start: at(-2, 9, 0), End: ptr(at(-2, 11, 30)), ItemType: "todo", ItemID: "a1000007", Text: "Set up CI pipeline", Project: "momentum"},
+			{ID: "9a000002", Start: at(-1, 14, 0), End: ptr(at(-1, 15, 15)), ItemType: "milestone", ItemID: "b1000001", Text: "Public beta announcement", Project: "momentum"},
+		},
+	})
+
+	files[storage.GoalsFile] = storage.SerializeGoals(&storage.Goals{
+		Contribution: storage.ContributionGoal{WeeklyCommits: 20, WeeklyActiveDays: 5},
+	})
+
+	files[storage.FocusFile] = storage.SerializeFocus(&storage.Focus{
+		Date: today,
+		Items: []storage.FocusItem{
+			{ItemType: "todo", ItemID: "a1000001", Text: "Fix flaky login test"},
+			{ItemType: "milestone", ItemID: "b1000001", Text: "Public beta announcement"},
+			{ItemType: "todo", ItemID: "a1000009", Text: "Reply to conference invite"},
+		},
+	})
+
+	// Files without a serializer start from their defaults
+	for _, name := range storage.DataFiles {
+		if _, ok := files[name]; ok {
+			continue
+		}
+		content, err := assets.DefaultDataFile(name)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = content
+	}
+	files[storage.ProjectsFile] = strings.TrimRight(files[storage.ProjectsFile], "\n") +
+		"\n\n- momentum: The productivity server itself\n"
+
+	return files, nil
+}
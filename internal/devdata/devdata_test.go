@@ -0,0 +1,53 @@
+package devdata
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/server"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/dang-w/momentum-mcp-server/tools"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestFiles(t *testing.T) {
+	files, err := Files(time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Files() error = %v", err)
+	}
+	for _, name := range storage.DataFiles {
+		if files[name] == "" {
+			t.Errorf("no sample %s", name)
+		}
+	}
+
+	// The samples are valid data files a server can run on
+	ctx := context.Background()
+	session, err := server.ConnectInProcess(ctx, server.New(server.Config{Storage: storage.NewMemoryStorage(files, nil)}), "devdata-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "validate_data", Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out tools.ValidateDataOutput
+	raw, _ := json.Marshal(res.StructuredContent)
+	json.Unmarshal(raw, &out)
+	var result tools.ValidateDataResult
+	if err := json.Unmarshal([]byte(out.Message), &result); err != nil {
+		t.Fatalf("decoding validate_data: %v (%s)", err, out.Message)
+	}
+	if len(result.Files) == 0 {
+		t.Fatal("validate_data checked no files")
+	}
+	for _, f := range result.Files {
+		if len(f.Issues) > 0 || f.Normalizes || f.Missing {
+			t.Errorf("sample %s is not clean: %+v", f.File, f)
+		}
+	}
+}
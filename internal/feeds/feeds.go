@@ -0,0 +1,151 @@
+// Package feeds fetches and parses RSS 2.0 and Atom feeds, and estimates
+// how long the pages they link to take to read.
+package feeds
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxFeedBytes caps how much of a feed response is read.
+const maxFeedBytes = 5 << 20
+
+var client = &http.Client{Timeout: 20 * time.Second}
+
+// Entry is a single feed item.
+type Entry struct {
+	Title     string
+	URL       string
+	Published time.Time // zero if the feed gives no date
+}
+
+// Fetch downloads and parses the feed at url.
+func Fetch(ctx context.Context, url string) ([]Entry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+	req.Header.Set("User-Agent", "momentum-mcp-server")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching feed: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedBytes))
+	if err != nil {
+		return nil, fmt.Errorf("reading feed: %w", err)
+	}
+	return Parse(data)
+}
+
+// document covers both RSS (<rss><channel><item>) and Atom (<feed><entry>).
+type document struct {
+	XMLName xml.Name
+	Items   []rssItem   `xml:"channel>item"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type rssItem struct {
+	Title   string `xml:"title"`
+	Link    string `xml:"link"`
+	GUID    string `xml:"guid"`
+	PubDate string `xml:"pubDate"`
+	Date    string `xml:"http://purl.org/dc/elements/1.1/ date"`
+}
+
+type atomEntry struct {
+	Title     string     `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+// Parse parses an RSS 2.0 or Atom document. Entries without a link are dropped.
+func Parse(data []byte) ([]Entry, error) {
+	var doc document
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing feed: %w", err)
+	}
+
+	var entries []Entry
+	switch doc.XMLName.Local {
+	case "rss", "RDF":
+		for _, item := range doc.Items {
+			link := strings.TrimSpace(item.Link)
+			if link == "" && strings.HasPrefix(item.GUID, "http") {
+				link = strings.TrimSpace(item.GUID)
+			}
+			if link == "" {
+				continue
+			}
+			published := parseTime(item.PubDate)
+			if published.IsZero() {
+				published = parseTime(item.Date)
+			}
+			entries = append(entries, Entry{Title: strings.TrimSpace(item.Title), URL: link, Published: published})
+		}
+	case "feed":
+		for _, e := range doc.Entries {
+			link := ""
+			for _, l := range e.Links {
+				if l.Rel == "" || l.Rel == "alternate" {
+					link = strings.TrimSpace(l.Href)
+					break
+				}
+			}
+			if link == "" {
+				continue
+			}
+			published := parseTime(e.Published)
+			if published.IsZero() {
+				published = parseTime(e.Updated)
+			}
+			entries = append(entries, Entry{Title: strings.TrimSpace(e.Title), URL: link, Published: published})
+		}
+	default:
+		return nil, fmt.Errorf("parsing feed: unrecognized root element <%s>", doc.XMLName.Local)
+	}
+	return entries, nil
+}
+
+// timeLayouts are the date formats seen in the wild, RFC 822 variants first.
+var timeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC3339,
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02",
+}
+
+func parseTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,64 @@
+package feeds
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_RSS(t *testing.T) {
+	data := []byte(`<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Blog</title>
+<item><title>First post</title><link>https://example.com/1</link><pubDate>Tue, 03 Feb 2026 09:00:00 +0000</pubDate></item>
+<item><title>GUID only</title><guid>https://example.com/2</guid></item>
+<item><title>No link</title></item>
+</channel></rss>`)
+
+	entries, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+	if entries[0].URL != "https://example.com/1" || !entries[0].Published.Equal(time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected first entry: %+v", entries[0])
+	}
+	if entries[1].URL != "https://example.com/2" || !entries[1].Published.IsZero() {
+		t.Errorf("unexpected second entry: %+v", entries[1])
+	}
+}
+
+func TestParse_Atom(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><title>Go Blog</title>
+<entry><title>Release</title><link rel="self" href="https://example.com/self"/><link rel="alternate" href="https://example.com/release"/><updated>2026-02-02T12:00:00Z</updated></entry>
+</feed>`)
+
+	entries, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].URL != "https://example.com/release" || entries[0].Published.Day() != 2 {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	if _, err := Parse([]byte(`<html><body>nope</body></html>`)); err == nil {
+		t.Error("expected error for non-feed document")
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	page := `<html><head><title>Post</title><style>body { color: red }</style></head>
+<body><nav>Home About</nav><!-- skip me --><article><h1>Hello&nbsp;world</h1>
+<p>One two <b>three</b> four.</p><script>var x = 1;</script></article></body></html>`
+	if got := CountWords(page); got != 7 {
+		t.Errorf("CountWords() = %d, want 7", got)
+	}
+	for words, want := range map[int]int{0: 1, 100: 1, 345: 2, 2300: 10} {
+		if got := MinutesFor(words); got != want {
+			t.Errorf("MinutesFor(%d) = %d, want %d", words, got, want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// WordsPerMinute is the reading speed estimates assume.
+const WordsPerMinute = 230
+
+// maxPageBytes caps how much of a page is read to estimate its length.
+const maxPageBytes = 5 << 20
+
+var (
+	// Elements whose content isn't read: scripts, styles and page chrome
+	skippedElements = regexp.MustCompile(`(?is)<(script|style|noscript|svg|nav|header|footer|aside)\b.*?</(script|style|noscript|svg|nav|header|footer|aside)>`)
+	htmlComment     = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlTag         = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// ReadingMinutes downloads the page at url and estimates how many minutes
+// it takes to read, at least 1.
+func ReadingMinutes(ctx context.Context, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html, */*;q=0.8")
+	req.Header.Set("User-Agent", "momentum-mcp-server")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("fetching page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("fetching page: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPageBytes))
+	if err != nil {
+		return 0, fmt.Errorf("reading page: %w", err)
+	}
+	return MinutesFor(CountWords(string(data))), nil
+}
+
+// CountWords counts the words of text in an HTML page, leaving out markup,
+// scripts, styles and navigation.
+func CountWords(page string) int {
+	page = htmlComment.ReplaceAllString(page, " ")
+	page = skippedElements.ReplaceAllString(page, " ")
+	page = htmlTag.ReplaceAllString(page, " ")
+	return len(strings.Fields(html.UnescapeString(page)))
+}
+
+// MinutesFor converts a word count to whole reading minutes, at least 1.
+func MinutesFor(words int) int {
+	minutes := (words + WordsPerMinute/2) / WordsPerMinute
+	if minutes < 1 {
+		return 1
+	}
+	return minutes
+}
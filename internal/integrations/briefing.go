@@ -0,0 +1,130 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/notify"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/dang-w/momentum-mcp-server/tools"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// BriefingJob pushes the morning briefing (get_briefing) to the
+// notification channels on a cron schedule, skipping runs in the quiet hours
+// set in preferences.md.
+type BriefingJob struct {
+	tools     ToolCaller
+	schedule  *notify.Cron
+	notifiers []notify.Notifier
+	clock     clock.Clock
+
+	// lastRun is the minute of the last run, so each scheduled minute sends
+	// once however often the loop wakes
+	lastRun string
+	cancel  context.CancelFunc
+}
+
+// NewBriefingJob creates a job sending the briefing at the times schedule
+// selects, in the clock's timezone. A nil clock uses the system clock.
+func NewBriefingJob(t ToolCaller, schedule *notify.Cron, notifiers []notify.Notifier, c clock.Clock) *BriefingJob {
+	return &BriefingJob{tools: t, schedule: schedule, notifiers: notifiers, clock: clock.Or(c)}
+}
+
+// Start begins checking the schedule in the background.
+func (j *BriefingJob) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+	go j.loop(ctx)
+}
+
+// Stop ends the schedule checks.
+func (j *BriefingJob) Stop() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+}
+
+func (j *BriefingJob) loop(ctx context.Context) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+	for {
+		j.tick(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// tick sends the briefing if the current minute is scheduled and it hasn't
+// been sent in it yet.
+func (j *BriefingJob) tick(ctx context.Context) {
+	now := j.clock.Now()
+	minute := now.Format("2006-01-02 15:04")
+	if minute == j.lastRun || !j.schedule.Matches(now) {
+		return
+	}
+	j.lastRun = minute
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	prefs, err := j.preferences(ctx)
+	if err != nil {
+		slog.Warn("sending the briefing failed", "error", err)
+		return
+	}
+	if quiet, err := storage.ParseQuietHours(prefs.QuietHours); err == nil && quiet.Contains(now) {
+		slog.Info("skipped the briefing during quiet hours", "quiet_hours", prefs.QuietHours)
+		return
+	}
+	if err := j.Send(ctx); err != nil {
+		slog.Warn("sending the briefing failed", "error", err)
+		return
+	}
+	slog.Info("sent the briefing")
+}
+
+// Send generates the briefing and delivers it now, through the channels
+// the notification preferences select.
+func (j *BriefingJob) Send(ctx context.Context) error {
+	prefs, err := j.preferences(ctx)
+	if err != nil {
+		return err
+	}
+	notifiers := notify.Select(j.notifiers, prefs.Channels)
+	if len(notifiers) == 0 {
+		return fmt.Errorf("none of the preferred channels (%s) is configured", strings.Join(prefs.Channels, ", "))
+	}
+
+	res, err := j.tools.CallTool(ctx, &mcp.CallToolParams{Name: "get_briefing", Arguments: map[string]any{}})
+	if err != nil {
+		return fmt.Errorf("calling get_briefing: %w", err)
+	}
+	var out tools.GetBriefingOutput
+	raw, _ := json.Marshal(res.StructuredContent)
+	if err := json.Unmarshal(raw, &out); err != nil || res.IsError || !out.Success || out.Result == nil {
+		return fmt.Errorf("get_briefing failed: %s", out.Message)
+	}
+	return notify.Deliver(ctx, notifiers, notify.Message{Subject: out.Result.Subject(), Body: out.Message})
+}
+
+// preferences reads the notification preferences (get_preferences).
+func (j *BriefingJob) preferences(ctx context.Context) (tools.PreferencesItem, error) {
+	res, err := j.tools.CallTool(ctx, &mcp.CallToolParams{Name: "get_preferences", Arguments: map[string]any{}})
+	if err != nil {
+		return tools.PreferencesItem{}, fmt.Errorf("calling get_preferences: %w", err)
+	}
+	var out tools.PreferencesOutput
+	raw, _ := json.Marshal(res.StructuredContent)
+	if err := json.Unmarshal(raw, &out); err != nil || res.IsError || !out.Success || out.Result == nil {
+		return tools.PreferencesItem{}, fmt.Errorf("get_preferences failed: %s", out.Message)
+	}
+	return *out.Result, nil
+}
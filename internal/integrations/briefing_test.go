@@ -0,0 +1,82 @@
+package integrations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/notify"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// briefingTools answers get_briefing, and get_preferences with quietHours.
+type briefingTools struct {
+	calls      int
+	quietHours string
+}
+
+func (b *briefingTools) CallTool(ctx context.Context, params *mcp.CallToolParams) (*mcp.CallToolResult, error) {
+	if params.Name == "get_preferences" {
+		return &mcp.CallToolResult{StructuredContent: map[string]any{
+			"success": true,
+			"result":  map[string]any{"channels": []string{}, "quiet_hours": b.quietHours, "digest": "daily"},
+		}}, nil
+	}
+	b.calls++
+	return &mcp.CallToolResult{StructuredContent: map[string]any{
+		"success": true,
+		"message": "Good morning! Briefing for 2026-02-03",
+		"result":  map[string]any{"date": "2026-02-03"},
+	}}, nil
+}
+
+// messages captures delivered notifications.
+type messages []notify.Message
+
+func (m *messages) Notify(ctx context.Context, msg notify.Message) error {
+	*m = append(*m, msg)
+	return nil
+}
+
+func TestBriefingJob(t *testing.T) {
+	schedule, err := notify.ParseCron("0 7 * * 1-5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bt := &briefingTools{}
+	var sent messages
+	clk := clock.NewFake(time.Date(2026, 2, 3, 6, 59, 30, 0, time.UTC)) // a Tuesday
+	job := NewBriefingJob(bt, schedule, []notify.Notifier{&sent}, clk)
+	ctx := context.Background()
+
+	job.tick(ctx)
+	if bt.calls != 0 {
+		t.Fatal("sent before the scheduled minute")
+	}
+	clk.Advance(30 * time.Second)
+	job.tick(ctx)
+	clk.Advance(30 * time.Second)
+	job.tick(ctx)
+	if bt.calls != 1 || len(sent) != 1 {
+		t.Fatalf("sent %d times in the scheduled minute, want once", len(sent))
+	}
+	if sent[0].Subject != "Momentum briefing (Tue Feb 3)" || sent[0].Body != "Good morning! Briefing for 2026-02-03" {
+		t.Errorf("unexpected message %+v", sent[0])
+	}
+
+	// Not at the weekend
+	clk.Set(time.Date(2026, 2, 7, 7, 0, 0, 0, time.UTC))
+	job.tick(ctx)
+	if len(sent) != 1 {
+		t.Error("sent on a Saturday")
+	}
+
+	// Not in the quiet hours
+	bt.quietHours = "06:00-08:00"
+	clk.Set(time.Date(2026, 2, 4, 7, 0, 0, 0, time.UTC))
+	job.tick(ctx)
+	if len(sent) != 1 {
+		t.Error("sent during the quiet hours")
+	}
+}
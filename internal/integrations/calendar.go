@@ -0,0 +1,276 @@
+// Package integrations provides read-only clients for third-party services
+// the assistant can plan around. Each client owns its credentials and cache,
+// so the rest of the server never talks to an external API directly.
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+const (
+	googleTokenURL    = "https://oauth2.googleapis.com/token"
+	googleCalendarURL = "https://www.googleapis.com/calendar/v3"
+)
+
+// CalendarConfig holds Google Calendar OAuth credentials. The refresh token
+// is obtained once out of band (e.g. via the OAuth playground) with the
+// calendar.readonly scope.
+type CalendarConfig struct {
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+	// CalendarID defaults to "primary".
+	CalendarID string
+	// CacheTTL defaults to 5 minutes.
+	CacheTTL time.Duration
+}
+
+// Enabled reports whether credentials are configured.
+func (c CalendarConfig) Enabled() bool {
+	return c.ClientID != "" && c.ClientSecret != "" && c.RefreshToken != ""
+}
+
+// CalendarEvent is a single calendar event.
+type CalendarEvent struct {
+	Summary  string    `json:"summary"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	AllDay   bool      `json:"all_day,omitempty"`
+	Location string    `json:"location,omitempty"`
+}
+
+// Calendar reads events from Google Calendar.
+type Calendar struct {
+	cfg    CalendarConfig
+	client *http.Client
+	clock  clock.Clock
+
+	// Endpoints, overridden in tests
+	tokenURL string
+	apiURL   string
+
+	mu          sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
+
+	// Cache of the current week's events
+	cachedWeek   time.Time
+	cachedEvents []CalendarEvent
+	cachedAt     time.Time
+}
+
+// NewCalendar creates a Google Calendar client. A nil clock uses the system clock.
+func NewCalendar(cfg CalendarConfig, c clock.Clock) *Calendar {
+	if cfg.CalendarID == "" {
+		cfg.CalendarID = "primary"
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = 5 * time.Minute
+	}
+	return &Calendar{
+		cfg:      cfg,
+		client:   &http.Client{Timeout: 15 * time.Second},
+		clock:    clock.Or(c),
+		tokenURL: googleTokenURL,
+		apiURL:   googleCalendarURL,
+	}
+}
+
+// Week returns the events in the week (Monday-Sunday, UTC) starting at
+// weekStart, sorted by start time. Results are cached for CacheTTL; if a
+// refresh fails, stale events for the same week are returned instead.
+func (c *Calendar) Week(ctx context.Context, weekStart time.Time) ([]CalendarEvent, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if c.cachedEvents != nil && c.cachedWeek.Equal(weekStart) && now.Sub(c.cachedAt) < c.cfg.CacheTTL {
+		return c.cachedEvents, nil
+	}
+
+	events, err := c.fetchEvents(ctx, weekStart, weekStart.AddDate(0, 0, 7))
+	if err != nil {
+		if c.cachedEvents != nil && c.cachedWeek.Equal(weekStart) {
+			return c.cachedEvents, nil
+		}
+		return nil, err
+	}
+
+	c.cachedWeek = weekStart
+	c.cachedEvents = events
+	c.cachedAt = now
+	return events, nil
+}
+
+// Agenda lists the events on day (a date, as midnight UTC) in the clock's
+// timezone, one line each: all-day events first, then timed events as
+// "09:00-09:30 Standup (Room 1)". It isn't cached, as Week is: the day
+// rarely lines up with a UTC week.
+func (c *Calendar) Agenda(ctx context.Context, day time.Time) ([]string, error) {
+	loc := c.clock.Now().Location()
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+
+	c.mu.Lock()
+	events, err := c.fetchEvents(ctx, from, from.AddDate(0, 0, 1))
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var allDay, timed []string
+	for _, e := range events {
+		if e.AllDay {
+			allDay = append(allDay, "All day: "+e.Summary)
+			continue
+		}
+		line := e.Start.In(loc).Format("15:04") + "-" + e.End.In(loc).Format("15:04") + " " + e.Summary
+		if e.Location != "" {
+			line += " (" + e.Location + ")"
+		}
+		timed = append(timed, line)
+	}
+	return append(allDay, timed...), nil
+}
+
+// token returns a valid access token, refreshing it when it is about to expire.
+// Callers must hold c.mu.
+func (c *Calendar) token(ctx context.Context) (string, error) {
+	if c.accessToken != "" && c.clock.Now().Add(time.Minute).Before(c.tokenExpiry) {
+		return c.accessToken, nil
+	}
+
+	form := url.Values{
+		"client_id":     {c.cfg.ClientID},
+		"client_secret": {c.cfg.ClientSecret},
+		"refresh_token": {c.cfg.RefreshToken},
+		"grant_type":    {"refresh_token"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("creating token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("refreshing calendar token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("refreshing calendar token: status %d: %s", resp.StatusCode, body)
+	}
+
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return "", fmt.Errorf("decoding token response: %w", err)
+	}
+
+	c.accessToken = tok.AccessToken
+	c.tokenExpiry = c.clock.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+	return c.accessToken, nil
+}
+
+// googleEvent is an event as returned by the Calendar API.
+type googleEvent struct {
+	Summary  string     `json:"summary"`
+	Location string     `json:"location"`
+	Status   string     `json:"status"`
+	Start    googleTime `json:"start"`
+	End      googleTime `json:"end"`
+}
+
+// googleTime is either a timed (dateTime) or all-day (date) boundary.
+type googleTime struct {
+	DateTime string `json:"dateTime"`
+	Date     string `json:"date"`
+}
+
+func (t googleTime) parse() (time.Time, bool, error) {
+	if t.DateTime != "" {
+		v, err := time.Parse(time.RFC3339, t.DateTime)
+		return v, false, err
+	}
+	v, err := time.Parse("2006-01-02", t.Date)
+	return v, true, err
+}
+
+// fetchEvents lists single (expanded) events in [from, to).
+func (c *Calendar) fetchEvents(ctx context.Context, from, to time.Time) ([]CalendarEvent, error) {
+	token, err := c.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{
+		"timeMin":      {from.Format(time.RFC3339)},
+		"timeMax":      {to.Format(time.RFC3339)},
+		"singleEvents": {"true"},
+		"orderBy":      {"startTime"},
+		"maxResults":   {"250"},
+	}
+	endpoint := fmt.Sprintf("%s/calendars/%s/events?%s", c.apiURL, url.PathEscape(c.cfg.CalendarID), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating events request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("listing calendar events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("listing calendar events: status %d: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Items []googleEvent `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding events: %w", err)
+	}
+
+	events := make([]CalendarEvent, 0, len(result.Items))
+	for _, item := range result.Items {
+		if item.Status == "cancelled" {
+			continue
+		}
+		start, allDay, err := item.Start.parse()
+		if err != nil {
+			continue
+		}
+		end, _, err := item.End.parse()
+		if err != nil {
+			end = start
+		}
+		events = append(events, CalendarEvent{
+			Summary:  item.Summary,
+			Start:    start,
+			End:      end,
+			AllDay:   allDay,
+			Location: item.Location,
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+	return events, nil
+}
@@ -0,0 +1,123 @@
+package integrations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+func TestCalendar_Week(t *testing.T) {
+	tokenCalls, eventCalls := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			tokenCalls++
+			if r.FormValue("refresh_token") != "refresh" {
+				t.Errorf("unexpected refresh_token %q", r.FormValue("refresh_token"))
+			}
+			w.Write([]byte(`{"access_token":"access","expires_in":3600}`))
+		case "/calendars/primary/events":
+			eventCalls++
+			if r.Header.Get("Authorization") != "Bearer access" {
+				t.Errorf("unexpected Authorization %q", r.Header.Get("Authorization"))
+			}
+			w.Write([]byte(`{"items":[
+				{"summary":"Standup","start":{"dateTime":"2026-02-03T09:00:00Z"},"end":{"dateTime":"2026-02-03T09:15:00Z"}},
+				{"summary":"Offsite","start":{"date":"2026-02-02"},"end":{"date":"2026-02-03"}},
+				{"summary":"Cancelled","status":"cancelled","start":{"dateTime":"2026-02-04T10:00:00Z"},"end":{"dateTime":"2026-02-04T11:00:00Z"}}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	clk := clock.NewFake(time.Date(2026, 2, 3, 8, 0, 0, 0, time.UTC))
+	cal := NewCalendar(CalendarConfig{ClientID: "id", ClientSecret: "secret", RefreshToken: "refresh"}, clk)
+	cal.tokenURL = srv.URL + "/token"
+	cal.apiURL = srv.URL
+
+	weekStart := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	events, err := cal.Week(context.Background(), weekStart)
+	if err != nil {
+		t.Fatalf("Week() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", events)
+	}
+	if events[0].Summary != "Offsite" || !events[0].AllDay {
+		t.Errorf("expected all-day Offsite first, got %+v", events[0])
+	}
+	if events[1].Summary != "Standup" || events[1].AllDay {
+		t.Errorf("expected timed Standup second, got %+v", events[1])
+	}
+
+	// Served from cache within the TTL
+	if _, err := cal.Week(context.Background(), weekStart); err != nil {
+		t.Fatalf("Week() error = %v", err)
+	}
+	if eventCalls != 1 {
+		t.Errorf("expected 1 events call while cached, got %d", eventCalls)
+	}
+
+	// Refetched after the TTL, reusing the access token
+	clk.Advance(10 * time.Minute)
+	if _, err := cal.Week(context.Background(), weekStart); err != nil {
+		t.Fatalf("Week() error = %v", err)
+	}
+	if eventCalls != 2 || tokenCalls != 1 {
+		t.Errorf("expected 2 events calls and 1 token call, got %d and %d", eventCalls, tokenCalls)
+	}
+}
+
+func TestCalendarConfig_Enabled(t *testing.T) {
+	if (CalendarConfig{ClientID: "id", ClientSecret: "secret"}).Enabled() {
+		t.Error("expected config without refresh token to be disabled")
+	}
+	if !(CalendarConfig{ClientID: "id", ClientSecret: "secret", RefreshToken: "r"}).Enabled() {
+		t.Error("expected complete config to be enabled")
+	}
+}
+
+func TestCalendar_Agenda(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			w.Write([]byte(`{"access_token":"access","expires_in":3600}`))
+		case "/calendars/primary/events":
+			// The user's day in New York
+			if got := r.URL.Query().Get("timeMin"); got != "2026-02-03T00:00:00-05:00" {
+				t.Errorf("timeMin = %s", got)
+			}
+			w.Write([]byte(`{"items":[
+				{"summary":"Standup","location":"Room 1","start":{"dateTime":"2026-02-03T14:00:00Z"},"end":{"dateTime":"2026-02-03T14:15:00Z"}},
+				{"summary":"Offsite","start":{"date":"2026-02-03"},"end":{"date":"2026-02-04"}}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clk := clock.InLocation(clock.NewFake(time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)), newYork)
+	cal := NewCalendar(CalendarConfig{ClientID: "id", ClientSecret: "secret", RefreshToken: "refresh"}, clk)
+	cal.tokenURL = srv.URL + "/token"
+	cal.apiURL = srv.URL
+
+	lines, err := cal.Agenda(context.Background(), time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(lines, "\n"); got != "All day: Offsite\n09:00-09:15 Standup (Room 1)" {
+		t.Errorf("Agenda() =\n%s", got)
+	}
+}
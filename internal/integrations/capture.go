@@ -0,0 +1,123 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/dang-w/momentum-mcp-server/tools"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ToolCaller invokes an MCP tool by name. *mcp.ClientSession implements it,
+// so commands go through the same handlers and middleware as any client.
+type ToolCaller interface {
+	CallTool(ctx context.Context, params *mcp.CallToolParams) (*mcp.CallToolResult, error)
+}
+
+// completeTodoArgs targets a todo by ID if ref looks like one, else by text.
+func completeTodoArgs(ref string) map[string]any {
+	if storage.IsID(ref) {
+		return map[string]any{"id": ref}
+	}
+	return map[string]any{"text": ref}
+}
+
+// callTool invokes a tool and formats a successful result with format. Tools
+// with structured output pass format their result as JSON, others their
+// message. Failures return the tool's own message.
+func callTool(ctx context.Context, tc ToolCaller, name string, args map[string]any, format func(msg string) string) string {
+	res, err := tc.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		slog.Warn("integration tool call failed", "tool", name, "error", err)
+		return "Something went wrong. Please try again."
+	}
+
+	var out struct {
+		Success bool            `json:"success"`
+		Message string          `json:"message"`
+		Result  json.RawMessage `json:"result"`
+	}
+	raw, _ := json.Marshal(res.StructuredContent)
+	if err := json.Unmarshal(raw, &out); err != nil || res.IsError {
+		slog.Warn("integration tool call returned an error", "tool", name)
+		return "Something went wrong. Please try again."
+	}
+	if !out.Success {
+		return out.Message
+	}
+	if len(out.Result) > 0 {
+		return format(string(out.Result))
+	}
+	return format(out.Message)
+}
+
+// formatToday renders the dashboard as a short daily overview.
+func formatToday(msg string) string {
+	var d tools.DashboardResult
+	if err := json.Unmarshal([]byte(msg), &d); err != nil {
+		return msg
+	}
+
+	var sb strings.Builder
+	var high []tools.TodoItem
+	for _, t := range d.Todos.Active {
+		if t.Priority == "urgent" || t.Priority == "high" {
+			high = append(high, t)
+		}
+	}
+	sb.WriteString(fmt.Sprintf("*%d active todos*", d.Todos.ActiveCount))
+	if len(high) > 0 {
+		sb.WriteString(", high priority:")
+		for _, t := range high {
+			sb.WriteString("\n• " + t.Text)
+		}
+	}
+	sb.WriteString("\n")
+
+	if len(d.Reminders.Overdue) > 0 {
+		sb.WriteString("\n*Overdue*")
+		for _, r := range d.Reminders.Overdue {
+			sb.WriteString(fmt.Sprintf("\n• %s (%s)", r.Text, r.Date))
+		}
+		sb.WriteString("\n")
+	}
+	if len(d.Reminders.Upcoming) > 0 {
+		sb.WriteString("\n*Upcoming*")
+		for _, r := range d.Reminders.Upcoming {
+			sb.WriteString(fmt.Sprintf("\n• %s (%s)", r.Text, r.Date))
+		}
+		sb.WriteString("\n")
+	}
+	if d.Workload.Overloaded {
+		sb.WriteString("\n:warning: " + strings.Join(d.Workload.Warnings, "; ") + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func formatTodoAdded(msg string) string {
+	var item tools.TodoItem
+	if json.Unmarshal([]byte(msg), &item) != nil {
+		return msg
+	}
+	return fmt.Sprintf("Added todo: %s (%s) `%s`", item.Text, item.Priority, item.ID)
+}
+
+func formatTodoCompleted(msg string) string {
+	var item tools.TodoItem
+	if json.Unmarshal([]byte(msg), &item) != nil {
+		return msg
+	}
+	return fmt.Sprintf("Completed: %s", item.Text)
+}
+
+func formatReminderSet(msg string) string {
+	var item tools.ReminderItem
+	if json.Unmarshal([]byte(msg), &item) != nil {
+		return msg
+	}
+	return fmt.Sprintf("Reminder set for %s: %s", item.Date, item.Text)
+}
@@ -0,0 +1,75 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/tools"
+)
+
+// FeedPoller runs fetch_feeds on a fixed interval so new feed entries land in
+// the reading list without anyone asking.
+type FeedPoller struct {
+	tools      ToolCaller
+	interval   time.Duration
+	maxPerFeed int
+	cancel     context.CancelFunc
+}
+
+// NewFeedPoller creates a poller that fetches every interval, adding at most
+// maxPerFeed entries per feed each run.
+func NewFeedPoller(t ToolCaller, interval time.Duration, maxPerFeed int) *FeedPoller {
+	return &FeedPoller{tools: t, interval: interval, maxPerFeed: maxPerFeed}
+}
+
+// Start begins polling in the background. The first fetch runs immediately.
+func (p *FeedPoller) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	go p.loop(ctx)
+}
+
+// Stop ends polling.
+func (p *FeedPoller) Stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+func (p *FeedPoller) loop(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.runOnce(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runOnce calls fetch_feeds and logs the outcome.
+func (p *FeedPoller) runOnce(ctx context.Context) string {
+	summary := callTool(ctx, p.tools, "fetch_feeds", map[string]any{"max_per_feed": p.maxPerFeed}, formatFeedsFetched)
+	slog.Info("feed fetch finished", "result", summary)
+	return summary
+}
+
+// formatFeedsFetched summarizes a fetch_feeds result, noting failed feeds.
+func formatFeedsFetched(msg string) string {
+	var r tools.FetchFeedsResult
+	if err := json.Unmarshal([]byte(msg), &r); err != nil {
+		return msg
+	}
+	summary := fmt.Sprintf("Added %d feed entries", r.Added)
+	for _, f := range r.Feeds {
+		if f.Error != "" {
+			summary += "; " + f.Feed + " failed: " + f.Error
+		}
+	}
+	return summary
+}
@@ -0,0 +1,20 @@
+package integrations
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFeedPoller_RunOnce(t *testing.T) {
+	ft := &fakeTools{message: `{"added":3,"feeds":[{"feed":"go-blog","found":10,"added":[]},{"feed":"broken","found":0,"added":[],"error":"status 404"}]}`}
+	p := NewFeedPoller(ft, time.Hour, 2)
+
+	got := p.runOnce(context.Background())
+	if ft.name != "fetch_feeds" || ft.args["max_per_feed"] != 2 {
+		t.Errorf("unexpected tool call %s %v", ft.name, ft.args)
+	}
+	if want := "Added 3 feed entries; broken failed: status 404"; got != want {
+		t.Errorf("runOnce() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,128 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+const githubAPIURL = "https://api.github.com"
+
+// GitHubIssues mirrors strategy milestones as issues in a GitHub repo. It
+// implements tools.MilestoneIssues.
+type GitHubIssues struct {
+	token  string
+	owner  string
+	repo   string
+	labels []string
+	client *http.Client
+
+	// apiURL is overridden in tests
+	apiURL string
+}
+
+// NewGitHubIssues creates a GitHubIssues for repoPath ("owner/repo"). New
+// issues get the given labels, if any.
+func NewGitHubIssues(token, repoPath string, labels []string) (*GitHubIssues, error) {
+	owner, repo, ok := strings.Cut(repoPath, "/")
+	if !ok || owner == "" || repo == "" {
+		return nil, fmt.Errorf("invalid repo path %q: expected owner/repo format", repoPath)
+	}
+	return &GitHubIssues{
+		token:  token,
+		owner:  owner,
+		repo:   repo,
+		labels: labels,
+		client: &http.Client{Timeout: 15 * time.Second},
+		apiURL: githubAPIURL,
+	}, nil
+}
+
+// issueBody describes a milestone for its issue. The marker comment ties the
+// issue back to the milestone ID for anyone reconciling by hand.
+func issueBody(m storage.Milestone) string {
+	var b strings.Builder
+	b.WriteString("Strategy milestone tracked in momentum.\n\n")
+	if m.Due != nil {
+		b.WriteString("**Due:** " + m.Due.Format("2006-01-02") + "\n")
+	} else {
+		b.WriteString("**Due:** none\n")
+	}
+	if m.Project != "" {
+		b.WriteString("**Project:** " + m.Project + "\n")
+	}
+	b.WriteString("\n<!-- momentum:milestone:" + m.ID + " -->\n")
+	return b.String()
+}
+
+// CreateMilestoneIssue opens an issue for m and returns its number.
+func (g *GitHubIssues) CreateMilestoneIssue(ctx context.Context, m storage.Milestone) (int, error) {
+	payload := map[string]any{"title": m.Text, "body": issueBody(m)}
+	if len(g.labels) > 0 {
+		payload["labels"] = g.labels
+	}
+
+	var created struct {
+		Number int `json:"number"`
+	}
+	if err := g.do(ctx, http.MethodPost, "/issues", payload, &created); err != nil {
+		return 0, fmt.Errorf("creating issue: %w", err)
+	}
+	if created.Number == 0 {
+		return 0, fmt.Errorf("creating issue: no issue number in response")
+	}
+	return created.Number, nil
+}
+
+// UpdateMilestoneIssue sets the title, body and state of m's issue.
+func (g *GitHubIssues) UpdateMilestoneIssue(ctx context.Context, m storage.Milestone) error {
+	state := "open"
+	if m.Completed {
+		state = "closed"
+	}
+	payload := map[string]any{"title": m.Text, "body": issueBody(m), "state": state}
+	if err := g.do(ctx, http.MethodPatch, fmt.Sprintf("/issues/%d", m.Issue), payload, nil); err != nil {
+		return fmt.Errorf("updating issue #%d: %w", m.Issue, err)
+	}
+	return nil
+}
+
+// do sends a JSON request to the repo's API and decodes the response into out
+// (if non-nil).
+func (g *GitHubIssues) do(ctx context.Context, method, path string, payload, out any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/repos/%s/%s%s", g.apiURL, g.owner, g.repo, path)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, msg)
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
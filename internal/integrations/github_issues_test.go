@@ -0,0 +1,61 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestGitHubIssues(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		gotBody = nil
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"number":42}`))
+	}))
+	defer srv.Close()
+
+	gi, err := NewGitHubIssues("tok", "me/board", []string{"milestone"})
+	if err != nil {
+		t.Fatalf("NewGitHubIssues() error = %v", err)
+	}
+	gi.apiURL = srv.URL
+
+	due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	m := storage.Milestone{ID: "abcd1234", Text: "Ship v1", Due: &due}
+	number, err := gi.CreateMilestoneIssue(context.Background(), m)
+	if err != nil || number != 42 {
+		t.Fatalf("CreateMilestoneIssue() = %d, %v", number, err)
+	}
+	if gotMethod != http.MethodPost || gotPath != "/repos/me/board/issues" || gotBody["title"] != "Ship v1" {
+		t.Errorf("unexpected create request %s %s %v", gotMethod, gotPath, gotBody)
+	}
+	if body, _ := gotBody["body"].(string); !strings.Contains(body, "2026-03-01") || !strings.Contains(body, "momentum:milestone:abcd1234") {
+		t.Errorf("unexpected issue body %q", body)
+	}
+
+	m.Issue = number
+	m.Completed = true
+	if err := gi.UpdateMilestoneIssue(context.Background(), m); err != nil {
+		t.Fatalf("UpdateMilestoneIssue() error = %v", err)
+	}
+	if gotMethod != http.MethodPatch || gotPath != "/repos/me/board/issues/42" || gotBody["state"] != "closed" {
+		t.Errorf("unexpected update request %s %s %v", gotMethod, gotPath, gotBody)
+	}
+
+	if _, err := NewGitHubIssues("tok", "no-slash", nil); err == nil {
+		t.Error("expected error for invalid repo path")
+	}
+}
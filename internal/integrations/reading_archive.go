@@ -0,0 +1,72 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/tools"
+)
+
+// ReadingArchiver runs archive_reading once a day so read items move out of
+// the reading list once they're old enough.
+type ReadingArchiver struct {
+	tools  ToolCaller
+	months int
+	cancel context.CancelFunc
+}
+
+// NewReadingArchiver creates a job archiving items read more than months
+// months ago.
+func NewReadingArchiver(t ToolCaller, months int) *ReadingArchiver {
+	return &ReadingArchiver{tools: t, months: months}
+}
+
+// Start begins archiving in the background. The first run is immediate.
+func (a *ReadingArchiver) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+	go a.loop(ctx)
+}
+
+// Stop ends archiving.
+func (a *ReadingArchiver) Stop() {
+	if a.cancel != nil {
+		a.cancel()
+	}
+}
+
+func (a *ReadingArchiver) loop(ctx context.Context) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+	for {
+		a.runOnce(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runOnce calls archive_reading and logs the outcome.
+func (a *ReadingArchiver) runOnce(ctx context.Context) string {
+	summary := callTool(ctx, a.tools, "archive_reading", map[string]any{"older_than_months": a.months}, formatReadingArchived)
+	slog.Info("reading list archival finished", "result", summary)
+	return summary
+}
+
+// formatReadingArchived summarizes an archive_reading result.
+func formatReadingArchived(msg string) string {
+	var r tools.ArchiveReadingResult
+	if err := json.Unmarshal([]byte(msg), &r); err != nil {
+		return msg
+	}
+	if r.Archived == 0 {
+		return "Nothing to archive"
+	}
+	return fmt.Sprintf("Archived %d read items to %s", r.Archived, strings.Join(r.Files, ", "))
+}
@@ -0,0 +1,19 @@
+package integrations
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReadingArchiver_RunOnce(t *testing.T) {
+	ft := &fakeTools{message: `{"archived":3,"files":["archive/reading-2025.md","archive/reading-2026.md"],"items":[]}`}
+	a := NewReadingArchiver(ft, 6)
+
+	got := a.runOnce(context.Background())
+	if ft.name != "archive_reading" || ft.args["older_than_months"] != 6 {
+		t.Errorf("unexpected tool call %s %v", ft.name, ft.args)
+	}
+	if want := "Archived 3 read items to archive/reading-2025.md, archive/reading-2026.md"; got != want {
+		t.Errorf("runOnce() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,128 @@
+package integrations
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+// slackMaxSkew is how old a signed Slack request may be before it is
+// rejected as a possible replay.
+const slackMaxSkew = 5 * time.Minute
+
+// SlackBridge serves a Slack slash command (e.g. /momentum) that maps short
+// commands onto the existing tools.
+type SlackBridge struct {
+	signingSecret string
+	tools         ToolCaller
+	clock         clock.Clock
+}
+
+// NewSlackBridge creates a SlackBridge. Requests are verified against the
+// app's signing secret. A nil clock uses the system clock.
+func NewSlackBridge(signingSecret string, t ToolCaller, c clock.Clock) *SlackBridge {
+	return &SlackBridge{signingSecret: signingSecret, tools: t, clock: clock.Or(c)}
+}
+
+// slackHelp lists the supported commands.
+const slackHelp = "Usage:\n" +
+	"• `add [!urgent|!high|!someday] <text>` - add a todo\n" +
+	"• `done <id or text>` - complete a todo\n" +
+	"• `remind <YYYY-MM-DD|today|tomorrow> <text>` - set a reminder\n" +
+	"• `today` - high-priority todos, overdue and upcoming reminders"
+
+// ServeHTTP handles a slash command request.
+func (b *SlackBridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 64*1024))
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusBadRequest)
+		return
+	}
+	if !b.verify(r.Header, body) {
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "Invalid form body", http.StatusBadRequest)
+		return
+	}
+
+	text := b.run(r.Context(), form.Get("text"))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"response_type": "ephemeral",
+		"text":          text,
+	})
+}
+
+// verify checks Slack's v0 request signature and timestamp.
+func (b *SlackBridge) verify(h http.Header, body []byte) bool {
+	ts := h.Get("X-Slack-Request-Timestamp")
+	sig := h.Get("X-Slack-Signature")
+	if ts == "" || sig == "" {
+		return false
+	}
+
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return false
+	}
+	age := b.clock.Now().Sub(time.Unix(sec, 0))
+	if age > slackMaxSkew || age < -slackMaxSkew {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(b.signingSecret))
+	mac.Write([]byte("v0:" + ts + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(sig))
+}
+
+// run executes a command and returns the reply text.
+func (b *SlackBridge) run(ctx context.Context, text string) string {
+	cmd, rest, _ := strings.Cut(strings.TrimSpace(text), " ")
+	rest = strings.TrimSpace(rest)
+
+	switch strings.ToLower(cmd) {
+	case "add":
+		args := map[string]any{}
+		if p, remaining, ok := strings.Cut(rest, " "); ok && (p == "!urgent" || p == "!high" || p == "!someday") {
+			args["priority"] = strings.TrimPrefix(p, "!")
+			rest = strings.TrimSpace(remaining)
+		}
+		args["text"] = rest
+		return callTool(ctx, b.tools, "add_todo", args, formatTodoAdded)
+
+	case "done":
+		return callTool(ctx, b.tools, "complete_todo", completeTodoArgs(rest), formatTodoCompleted)
+
+	case "remind":
+		date, reminder, _ := strings.Cut(rest, " ")
+		return callTool(ctx, b.tools, "set_reminder", map[string]any{"date": date, "text": strings.TrimSpace(reminder)}, formatReminderSet)
+
+	case "today":
+		return callTool(ctx, b.tools, "get_dashboard", map[string]any{}, formatToday)
+
+	default:
+		return slackHelp
+	}
+}
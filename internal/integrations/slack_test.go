@@ -0,0 +1,120 @@
+package integrations
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// fakeTools records tool calls and returns a canned message.
+type fakeTools struct {
+	name    string
+	args    map[string]any
+	message string
+}
+
+func (f *fakeTools) CallTool(ctx context.Context, params *mcp.CallToolParams) (*mcp.CallToolResult, error) {
+	f.name = params.Name
+	f.args, _ = params.Arguments.(map[string]any)
+	return &mcp.CallToolResult{StructuredContent: map[string]any{"success": true, "message": f.message}}, nil
+}
+
+func signedRequest(secret, body string, ts time.Time) *http.Request {
+	tsStr := strconv.FormatInt(ts.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + tsStr + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/integrations/slack", strings.NewReader(body))
+	req.Header.Set("X-Slack-Request-Timestamp", tsStr)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestSlackBridge_AddTodo(t *testing.T) {
+	now := time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC)
+	ft := &fakeTools{message: `{"id":"abcd1234","text":"buy milk","priority":"high","completed":false}`}
+	bridge := NewSlackBridge("secret", ft, clock.NewFake(now))
+
+	body := url.Values{"command": {"/momentum"}, "text": {"add !high buy milk"}}.Encode()
+	rec := httptest.NewRecorder()
+	bridge.ServeHTTP(rec, signedRequest("secret", body, now))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if ft.name != "add_todo" || ft.args["text"] != "buy milk" || ft.args["priority"] != "high" {
+		t.Errorf("unexpected tool call %s %v", ft.name, ft.args)
+	}
+	var resp map[string]string
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if !strings.Contains(resp["text"], "Added todo: buy milk") {
+		t.Errorf("unexpected reply %q", resp["text"])
+	}
+}
+
+func TestSlackBridge_RejectsBadSignatures(t *testing.T) {
+	now := time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC)
+	ft := &fakeTools{}
+	bridge := NewSlackBridge("secret", ft, clock.NewFake(now))
+	body := url.Values{"text": {"today"}}.Encode()
+
+	tests := map[string]*http.Request{
+		"wrong secret": signedRequest("other", body, now),
+		"stale":        signedRequest("secret", body, now.Add(-10*time.Minute)),
+		"unsigned":     httptest.NewRequest(http.MethodPost, "/integrations/slack", strings.NewReader(body)),
+	}
+	for name, req := range tests {
+		rec := httptest.NewRecorder()
+		bridge.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected 401, got %d", name, rec.Code)
+		}
+	}
+	if ft.name != "" {
+		t.Errorf("tool called for rejected request: %s", ft.name)
+	}
+}
+
+func TestSlackBridge_Commands(t *testing.T) {
+	tests := []struct {
+		text string
+		tool string
+		args map[string]any
+	}{
+		{"done abcd1234", "complete_todo", map[string]any{"id": "abcd1234"}},
+		{"done milk", "complete_todo", map[string]any{"text": "milk"}},
+		{"remind tomorrow call mum", "set_reminder", map[string]any{"date": "tomorrow", "text": "call mum"}},
+		{"today", "get_dashboard", map[string]any{}},
+	}
+	for _, tt := range tests {
+		ft := &fakeTools{message: "{}"}
+		bridge := NewSlackBridge("secret", ft, nil)
+		bridge.run(context.Background(), tt.text)
+		if ft.name != tt.tool || len(ft.args) != len(tt.args) {
+			t.Errorf("%q: expected %s %v, got %s %v", tt.text, tt.tool, tt.args, ft.name, ft.args)
+			continue
+		}
+		for k, v := range tt.args {
+			if ft.args[k] != v {
+				t.Errorf("%q: expected %s=%v, got %v", tt.text, k, v, ft.args[k])
+			}
+		}
+	}
+
+	ft := &fakeTools{}
+	if reply := NewSlackBridge("secret", ft, nil).run(context.Background(), "dance"); !strings.HasPrefix(reply, "Usage:") || ft.name != "" {
+		t.Errorf("expected help for unknown command, got %q", reply)
+	}
+}
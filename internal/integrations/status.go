@@ -0,0 +1,229 @@
+package integrations
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/tools"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// StatusSource reads the data the status page shows. *mcp.ClientSession
+// implements it.
+type StatusSource interface {
+	ToolCaller
+	ReadResource(ctx context.Context, params *mcp.ReadResourceParams) (*mcp.ReadResourceResult, error)
+}
+
+// StatusPage serves a read-only HTML overview of the dashboard, for a
+// glance from a phone browser.
+type StatusPage struct {
+	source StatusSource
+	// token, if set, must be given as ?token= or a bearer token. It is a
+	// viewer token, separate from the one MCP clients use.
+	token string
+	clock clock.Clock
+}
+
+// NewStatusPage creates a StatusPage. An empty token serves the page to
+// anyone. A nil clock uses the system clock.
+func NewStatusPage(source StatusSource, token string, c clock.Clock) *StatusPage {
+	return &StatusPage{source: source, token: token, clock: clock.Or(c)}
+}
+
+// statusData is what the page template renders.
+type statusData struct {
+	Generated   string
+	Urgent      []tools.TodoItem
+	High        []tools.TodoItem
+	Normal      []tools.TodoItem
+	Someday     []tools.TodoItem
+	Overdue     []tools.ReminderItem
+	Upcoming    []tools.ReminderItem
+	Phase       string
+	Milestones  []tools.MilestoneItem
+	Warnings    []string
+	HasGitHub   bool
+	Streak      int
+	CommitsWeek int
+	Unavailable bool
+}
+
+// ServeHTTP renders the page.
+func (p *StatusPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !p.authorized(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	data := p.load(r.Context())
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := statusTemplate.Execute(w, data); err != nil {
+		slog.Warn("rendering status page failed", "error", err)
+	}
+}
+
+// authorized checks the viewer token, if one is configured.
+func (p *StatusPage) authorized(r *http.Request) bool {
+	if p.token == "" {
+		return true
+	}
+	given := r.URL.Query().Get("token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		given = bearer
+	}
+	return subtle.ConstantTimeCompare([]byte(given), []byte(p.token)) == 1
+}
+
+// load gathers the page data. A dashboard that can't be read leaves the
+// page marked unavailable; missing GitHub activity just hides its section.
+func (p *StatusPage) load(ctx context.Context) statusData {
+	data := statusData{Generated: p.clock.Now().Format("Mon 2 Jan 15:04")}
+
+	res, err := p.source.CallTool(ctx, &mcp.CallToolParams{Name: "get_dashboard", Arguments: map[string]any{}})
+	var out struct {
+		Success bool                   `json:"success"`
+		Result  *tools.DashboardResult `json:"result"`
+	}
+	if err == nil && !res.IsError {
+		raw, _ := json.Marshal(res.StructuredContent)
+		err = json.Unmarshal(raw, &out)
+	}
+	if err != nil || res.IsError || !out.Success || out.Result == nil {
+		slog.Warn("status page could not load the dashboard", "error", err)
+		data.Unavailable = true
+		return data
+	}
+
+	d := out.Result
+	for _, t := range d.Todos.Active {
+		switch t.Priority {
+		case "urgent":
+			data.Urgent = append(data.Urgent, t)
+		case "high":
+			data.High = append(data.High, t)
+		case "someday":
+			data.Someday = append(data.Someday, t)
+		default:
+			data.Normal = append(data.Normal, t)
+		}
+	}
+	data.Overdue = d.Reminders.Overdue
+	data.Upcoming = d.Reminders.Upcoming
+	data.Phase = d.Strategy.CurrentPhase
+	data.Milestones = d.Strategy.Active
+	data.Warnings = d.Workload.Warnings
+
+	if activity, err := p.githubActivity(ctx); err == nil {
+		data.HasGitHub = true
+		data.Streak = activity.StreakDays
+		data.CommitsWeek = activity.CommitsThisWeek
+	}
+	return data
+}
+
+// githubActivity reads momentum://github-activity, which is only
+// registered when GitHub activity is configured.
+func (p *StatusPage) githubActivity(ctx context.Context) (*githubStatus, error) {
+	res, err := p.source.ReadResource(ctx, &mcp.ReadResourceParams{URI: "momentum://github-activity"})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Contents) == 0 {
+		return nil, fmt.Errorf("empty github activity resource")
+	}
+	var activity githubStatus
+	if err := json.Unmarshal([]byte(res.Contents[0].Text), &activity); err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+// githubStatus is the part of momentum://github-activity the page shows.
+type githubStatus struct {
+	StreakDays      int `json:"streak_days"`
+	CommitsThisWeek int `json:"commits_this_week"`
+}
+
+// statusRefresh is how often the page reloads itself.
+const statusRefresh = 5 * time.Minute
+
+var statusTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"refresh": func() int { return int(statusRefresh.Seconds()) },
+	"plural": func(n int, word string) string {
+		if n == 1 {
+			return fmt.Sprintf("%d %s", n, word)
+		}
+		return fmt.Sprintf("%d %ss", n, word)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta http-equiv="refresh" content="{{refresh}}">
+<title>Momentum status</title>
+<style>
+body { font-family: -apple-system, system-ui, sans-serif; max-width: 40rem; margin: 0 auto; padding: 1rem; line-height: 1.4; color: #222; background: #fafafa; }
+h1 { font-size: 1.4rem; margin-bottom: 0; }
+h2 { font-size: 1.1rem; margin: 1.5rem 0 .5rem; border-bottom: 1px solid #ddd; }
+h3 { font-size: .95rem; margin: .75rem 0 .25rem; color: #555; }
+ul { padding-left: 1.2rem; margin: 0; }
+.meta, .empty { color: #777; font-size: .9rem; }
+.overdue { color: #b00020; }
+.warning { background: #fff3cd; padding: .5rem; border-radius: 4px; }
+.stats { display: flex; gap: 1rem; }
+.stat { background: #fff; border: 1px solid #ddd; border-radius: 6px; padding: .5rem 1rem; text-align: center; }
+.stat b { display: block; font-size: 1.5rem; }
+</style>
+</head>
+<body>
+<h1>Momentum</h1>
+<p class="meta">Updated {{.Generated}}</p>
+{{if .Unavailable}}
+<p class="warning">The dashboard couldn't be loaded. Try again shortly.</p>
+{{else}}
+{{range .Warnings}}<p class="warning">{{.}}</p>{{end}}
+
+{{if .HasGitHub}}
+<div class="stats">
+<div class="stat"><b>{{.Streak}}</b>day streak</div>
+<div class="stat"><b>{{.CommitsWeek}}</b>commits this week</div>
+</div>
+{{end}}
+
+<h2>Todos</h2>
+{{if not (or .Urgent .High .Normal .Someday)}}<p class="empty">Nothing to do.</p>{{end}}
+{{with .Urgent}}<h3>Urgent</h3><ul>{{range .}}<li>{{.Text}}</li>{{end}}</ul>{{end}}
+{{with .High}}<h3>High priority</h3><ul>{{range .}}<li>{{.Text}}</li>{{end}}</ul>{{end}}
+{{with .Normal}}<h3>Normal</h3><ul>{{range .}}<li>{{.Text}}</li>{{end}}</ul>{{end}}
+{{with .Someday}}<h3>{{plural (len .) "someday item"}}</h3>{{end}}
+
+<h2>Reminders</h2>
+{{if not (or .Overdue .Upcoming)}}<p class="empty">Nothing in the next 7 days.</p>{{end}}
+<ul>
+{{range .Overdue}}<li class="overdue">{{.Date}} — {{.Text}} (overdue)</li>{{end}}
+{{range .Upcoming}}<li>{{.Date}} — {{.Text}}</li>{{end}}
+</ul>
+
+<h2>Strategy</h2>
+{{if .Phase}}<p>Current phase: <b>{{.Phase}}</b></p>{{end}}
+{{with .Milestones}}<ul>{{range .}}<li>{{.Text}}{{with .Due}} (due {{.}}){{end}}</li>{{end}}</ul>{{else}}<p class="empty">No active milestones.</p>{{end}}
+{{end}}
+</body>
+</html>
+`))
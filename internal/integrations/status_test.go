@@ -0,0 +1,109 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// fakeStatusSource serves a canned dashboard and, optionally, GitHub activity.
+type fakeStatusSource struct {
+	dashboard map[string]any
+	github    string
+}
+
+func (f *fakeStatusSource) CallTool(ctx context.Context, params *mcp.CallToolParams) (*mcp.CallToolResult, error) {
+	if params.Name != "get_dashboard" {
+		return nil, errors.New("unexpected tool " + params.Name)
+	}
+	return &mcp.CallToolResult{StructuredContent: map[string]any{"success": true, "result": f.dashboard}}, nil
+}
+
+func (f *fakeStatusSource) ReadResource(ctx context.Context, params *mcp.ReadResourceParams) (*mcp.ReadResourceResult, error) {
+	if f.github == "" {
+		return nil, errors.New("resource not found")
+	}
+	return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{URI: params.URI, Text: f.github}}}, nil
+}
+
+func TestStatusPage(t *testing.T) {
+	source := &fakeStatusSource{
+		dashboard: map[string]any{
+			"todos": map[string]any{"active": []any{
+				map[string]any{"id": "aaaa1111", "text": "Ship <release>", "priority": "high"},
+				map[string]any{"id": "bbbb2222", "text": "Tidy desk", "priority": "normal"},
+				map[string]any{"id": "cccc3333", "text": "Learn Rust", "priority": "someday"},
+			}},
+			"reminders": map[string]any{
+				"overdue":  []any{map[string]any{"date": "2026-02-01", "text": "Renew domain", "overdue": true}},
+				"upcoming": []any{map[string]any{"date": "2026-02-05", "text": "Dentist"}},
+			},
+			"strategy": map[string]any{"current_phase": "Launch", "active_milestones": []any{map[string]any{"text": "Beta", "due": "2026-03-01"}}},
+		},
+		github: `{"streak_days": 12, "commits_this_week": 34}`,
+	}
+	page := NewStatusPage(source, "", clock.NewFake(time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC)))
+
+	rec := httptest.NewRecorder()
+	page.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"Ship &lt;release&gt;", "Tidy desk", "1 someday item", "Renew domain (overdue)",
+		"2026-02-05 — Dentist", "Current phase: <b>Launch</b>", "Beta (due 2026-03-01)",
+		"<b>12</b>day streak", "<b>34</b>commits this week", "Updated Tue 3 Feb 09:00",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page missing %q", want)
+		}
+	}
+	if strings.Contains(body, "Learn Rust") {
+		t.Error("expected someday todos to be counted, not listed")
+	}
+
+	// Without GitHub activity the streak is left out
+	source.github = ""
+	rec = httptest.NewRecorder()
+	page.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	if strings.Contains(rec.Body.String(), "day streak") {
+		t.Error("expected no streak without GitHub activity")
+	}
+}
+
+func TestStatusPage_Token(t *testing.T) {
+	page := NewStatusPage(&fakeStatusSource{dashboard: map[string]any{}}, "viewer", nil)
+
+	tests := []struct {
+		name   string
+		target string
+		header string
+		want   int
+	}{
+		{"missing", "/status", "", http.StatusUnauthorized},
+		{"wrong", "/status?token=nope", "", http.StatusUnauthorized},
+		{"query", "/status?token=viewer", "", http.StatusOK},
+		{"bearer", "/status", "Bearer viewer", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			page.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const telegramAPIURL = "https://api.telegram.org"
+
+// TelegramBot long-polls the Telegram Bot API and turns short messages into
+// tool calls:
+//
+//	todo: fix CI
+//	remind 2026-03-01: renew domain
+//	read: https://example.com/article
+//	done: fix CI
+//	today
+//
+// Only messages from AllowedChatID are acted on; anything else is ignored, so
+// a leaked bot username can't write to the data repo.
+type TelegramBot struct {
+	token         string
+	allowedChatID int64
+	tools         ToolCaller
+	client        *http.Client
+
+	// apiURL is overridden in tests
+	apiURL string
+	// pollTimeout is the getUpdates long-poll duration.
+	pollTimeout time.Duration
+
+	offset int64
+	cancel context.CancelFunc
+}
+
+// NewTelegramBot creates a bot that only answers allowedChatID.
+func NewTelegramBot(token string, allowedChatID int64, t ToolCaller) *TelegramBot {
+	return &TelegramBot{
+		token:         token,
+		allowedChatID: allowedChatID,
+		tools:         t,
+		client:        &http.Client{Timeout: 60 * time.Second},
+		apiURL:        telegramAPIURL,
+		pollTimeout:   30 * time.Second,
+	}
+}
+
+// Start begins polling in the background.
+func (b *TelegramBot) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	go b.poll(ctx)
+}
+
+// Stop ends polling.
+func (b *TelegramBot) Stop() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+}
+
+func (b *TelegramBot) poll(ctx context.Context) {
+	for ctx.Err() == nil {
+		if err := b.pollOnce(ctx); err != nil && ctx.Err() == nil {
+			slog.Warn("telegram poll failed", "error", err)
+			// Back off before retrying so an outage doesn't spin
+			select {
+			case <-time.After(10 * time.Second):
+			case <-ctx.Done():
+			}
+		}
+	}
+}
+
+// telegramUpdate is the subset of a Bot API update used here.
+type telegramUpdate struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+		Text string `json:"text"`
+	} `json:"message"`
+}
+
+// pollOnce fetches and handles one batch of updates.
+func (b *TelegramBot) pollOnce(ctx context.Context) error {
+	query := url.Values{
+		"timeout":         {strconv.Itoa(int(b.pollTimeout.Seconds()))},
+		"offset":          {strconv.FormatInt(b.offset, 10)},
+		"allowed_updates": {`["message"]`},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.endpoint("getUpdates")+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("creating getUpdates request: %w", err)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("getUpdates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("getUpdates: status %d: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		OK     bool             `json:"ok"`
+		Result []telegramUpdate `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decoding updates: %w", err)
+	}
+
+	for _, u := range result.Result {
+		// Acknowledge every update, even ones we ignore, so it isn't redelivered
+		b.offset = u.UpdateID + 1
+		if u.Message == nil || u.Message.Text == "" {
+			continue
+		}
+		if u.Message.Chat.ID != b.allowedChatID {
+			slog.Warn("ignoring telegram message from unknown chat", "chat_id", u.Message.Chat.ID)
+			continue
+		}
+		reply := b.run(ctx, u.Message.Text)
+		if err := b.send(ctx, u.Message.Chat.ID, reply); err != nil {
+			slog.Warn("telegram reply failed", "error", err)
+		}
+	}
+	return nil
+}
+
+// send posts a text reply to chatID.
+func (b *TelegramBot) send(ctx context.Context, chatID int64, text string) error {
+	payload, err := json.Marshal(map[string]any{"chat_id": chatID, "text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.endpoint("sendMessage"), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("sendMessage: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (b *TelegramBot) endpoint(method string) string {
+	return fmt.Sprintf("%s/bot%s/%s", b.apiURL, b.token, method)
+}
+
+// telegramHelp lists the supported messages.
+const telegramHelp = "Send one of:\n" +
+	"todo: <text>  (todo!: for high priority)\n" +
+	"done: <id or text>\n" +
+	"remind <YYYY-MM-DD|today|tomorrow>: <text>\n" +
+	"read: <url> [notes]\n" +
+	"today"
+
+// remindPattern matches "remind 2026-03-01: renew domain".
+var remindPattern = regexp.MustCompile(`(?i)^remind\s+(\S+?)\s*:\s*(.+)$`)
+
+// run executes a message and returns the reply text.
+func (b *TelegramBot) run(ctx context.Context, text string) string {
+	text = strings.TrimSpace(text)
+
+	if m := remindPattern.FindStringSubmatch(text); m != nil {
+		return callTool(ctx, b.tools, "set_reminder", map[string]any{"date": m[1], "text": strings.TrimSpace(m[2])}, formatReminderSet)
+	}
+
+	prefix, rest, ok := strings.Cut(text, ":")
+	rest = strings.TrimSpace(rest)
+	if ok {
+		switch strings.ToLower(strings.TrimSpace(prefix)) {
+		case "todo":
+			return callTool(ctx, b.tools, "add_todo", map[string]any{"text": rest}, formatTodoAdded)
+		case "todo!":
+			return callTool(ctx, b.tools, "add_todo", map[string]any{"text": rest, "priority": "high"}, formatTodoAdded)
+		case "done":
+			return callTool(ctx, b.tools, "complete_todo", completeTodoArgs(rest), formatTodoCompleted)
+		case "read":
+			link, notes, _ := strings.Cut(rest, " ")
+			return callTool(ctx, b.tools, "add_to_reading_list", map[string]any{"url": link, "notes": strings.TrimSpace(notes)}, func(string) string {
+				return "Added to reading list: " + link
+			})
+		}
+	}
+
+	if strings.EqualFold(text, "today") || strings.EqualFold(text, "/today") {
+		return callTool(ctx, b.tools, "get_dashboard", map[string]any{}, formatToday)
+	}
+	return telegramHelp
+}
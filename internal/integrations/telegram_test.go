@@ -0,0 +1,75 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTelegramBot_Run(t *testing.T) {
+	tests := []struct {
+		text string
+		tool string
+		args map[string]any
+	}{
+		{"todo: fix CI", "add_todo", map[string]any{"text": "fix CI"}},
+		{"todo!: ship release", "add_todo", map[string]any{"text": "ship release", "priority": "high"}},
+		{"remind 2026-03-01: renew domain", "set_reminder", map[string]any{"date": "2026-03-01", "text": "renew domain"}},
+		{"Remind tomorrow: call mum", "set_reminder", map[string]any{"date": "tomorrow", "text": "call mum"}},
+		{"done: fix CI", "complete_todo", map[string]any{"text": "fix CI"}},
+		{"read: https://example.com/a worth a look", "add_to_reading_list", map[string]any{"url": "https://example.com/a", "notes": "worth a look"}},
+		{"today", "get_dashboard", map[string]any{}},
+	}
+	for _, tt := range tests {
+		ft := &fakeTools{message: "{}"}
+		NewTelegramBot("token", 42, ft).run(context.Background(), tt.text)
+		if ft.name != tt.tool || len(ft.args) != len(tt.args) {
+			t.Errorf("%q: expected %s %v, got %s %v", tt.text, tt.tool, tt.args, ft.name, ft.args)
+			continue
+		}
+		for k, v := range tt.args {
+			if ft.args[k] != v {
+				t.Errorf("%q: expected %s=%v, got %v", tt.text, k, v, ft.args[k])
+			}
+		}
+	}
+}
+
+func TestTelegramBot_IgnoresOtherChats(t *testing.T) {
+	var replies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getUpdates"):
+			w.Write([]byte(`{"ok":true,"result":[
+				{"update_id":7,"message":{"chat":{"id":99},"text":"todo: intruder"}},
+				{"update_id":8,"message":{"chat":{"id":42},"text":"todo: fix CI"}}
+			]}`))
+		case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			replies = append(replies, body)
+			w.Write([]byte(`{"ok":true}`))
+		}
+	}))
+	defer srv.Close()
+
+	ft := &fakeTools{message: `{"id":"abcd1234","text":"fix CI","priority":"normal"}`}
+	bot := NewTelegramBot("token", 42, ft)
+	bot.apiURL = srv.URL
+
+	if err := bot.pollOnce(context.Background()); err != nil {
+		t.Fatalf("pollOnce() error = %v", err)
+	}
+	if ft.args["text"] != "fix CI" {
+		t.Errorf("expected only the allowed chat's todo, got %v", ft.args)
+	}
+	if len(replies) != 1 || replies[0]["chat_id"] != float64(42) {
+		t.Errorf("expected one reply to chat 42, got %v", replies)
+	}
+	if bot.offset != 9 {
+		t.Errorf("expected offset 9 after acknowledging both updates, got %d", bot.offset)
+	}
+}
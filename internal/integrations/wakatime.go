@@ -0,0 +1,178 @@
+package integrations
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+const wakaTimeURL = "https://wakatime.com/api/v1"
+
+// WakaTimeConfig holds the WakaTime API key.
+type WakaTimeConfig struct {
+	APIKey string
+	// CacheTTL defaults to 15 minutes.
+	CacheTTL time.Duration
+}
+
+// Enabled reports whether an API key is configured.
+func (c WakaTimeConfig) Enabled() bool {
+	return c.APIKey != ""
+}
+
+// CodingWeek is the coding time recorded by WakaTime in one week.
+type CodingWeek struct {
+	TotalHours float64         `json:"total_hours"`
+	Days       []CodingDay     `json:"days"`
+	Projects   []CodingProject `json:"projects"`
+}
+
+// CodingDay is the coding time on one day.
+type CodingDay struct {
+	Date  string  `json:"date"`
+	Hours float64 `json:"hours"`
+}
+
+// CodingProject is the coding time spent on one project, busiest first.
+type CodingProject struct {
+	Name  string  `json:"name"`
+	Hours float64 `json:"hours"`
+}
+
+// WakaTime reads daily coding time summaries from WakaTime.
+type WakaTime struct {
+	cfg    WakaTimeConfig
+	client *http.Client
+	clock  clock.Clock
+
+	// Endpoint, overridden in tests
+	apiURL string
+
+	mu         sync.Mutex
+	cachedWeek time.Time
+	cached     *CodingWeek
+	cachedAt   time.Time
+}
+
+// NewWakaTime creates a WakaTime client. A nil clock uses the system clock.
+func NewWakaTime(cfg WakaTimeConfig, c clock.Clock) *WakaTime {
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = 15 * time.Minute
+	}
+	return &WakaTime{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 15 * time.Second},
+		clock:  clock.Or(c),
+		apiURL: wakaTimeURL,
+	}
+}
+
+// Week returns the coding time in the week (Monday-Sunday, UTC) starting at
+// weekStart. Results are cached for CacheTTL; if a refresh fails, stale data
+// for the same week is returned instead.
+func (w *WakaTime) Week(ctx context.Context, weekStart time.Time) (*CodingWeek, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.clock.Now()
+	if w.cached != nil && w.cachedWeek.Equal(weekStart) && now.Sub(w.cachedAt) < w.cfg.CacheTTL {
+		return w.cached, nil
+	}
+
+	week, err := w.fetchSummaries(ctx, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		if w.cached != nil && w.cachedWeek.Equal(weekStart) {
+			return w.cached, nil
+		}
+		return nil, err
+	}
+
+	w.cachedWeek = weekStart
+	w.cached = week
+	w.cachedAt = now
+	return week, nil
+}
+
+// wakaTimeSummary is one day of the summaries API response.
+type wakaTimeSummary struct {
+	GrandTotal struct {
+		TotalSeconds float64 `json:"total_seconds"`
+	} `json:"grand_total"`
+	Range struct {
+		Date string `json:"date"`
+	} `json:"range"`
+	Projects []struct {
+		Name         string  `json:"name"`
+		TotalSeconds float64 `json:"total_seconds"`
+	} `json:"projects"`
+}
+
+// fetchSummaries lists the daily summaries from start to end, inclusive.
+func (w *WakaTime) fetchSummaries(ctx context.Context, start, end time.Time) (*CodingWeek, error) {
+	query := url.Values{
+		"start": {start.Format("2006-01-02")},
+		"end":   {end.Format("2006-01-02")},
+	}
+	endpoint := fmt.Sprintf("%s/users/current/summaries?%s", w.apiURL, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating summaries request: %w", err)
+	}
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(w.cfg.APIKey)))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching wakatime summaries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("fetching wakatime summaries: status %d: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Data []wakaTimeSummary `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding summaries: %w", err)
+	}
+
+	week := &CodingWeek{Days: []CodingDay{}, Projects: []CodingProject{}}
+	var totalSeconds float64
+	projectSeconds := map[string]float64{}
+	for _, day := range result.Data {
+		totalSeconds += day.GrandTotal.TotalSeconds
+		week.Days = append(week.Days, CodingDay{Date: day.Range.Date, Hours: hours(day.GrandTotal.TotalSeconds)})
+		for _, p := range day.Projects {
+			projectSeconds[p.Name] += p.TotalSeconds
+		}
+	}
+	week.TotalHours = hours(totalSeconds)
+	for name, seconds := range projectSeconds {
+		week.Projects = append(week.Projects, CodingProject{Name: name, Hours: hours(seconds)})
+	}
+	sort.Slice(week.Projects, func(i, j int) bool {
+		if week.Projects[i].Hours != week.Projects[j].Hours {
+			return week.Projects[i].Hours > week.Projects[j].Hours
+		}
+		return week.Projects[i].Name < week.Projects[j].Name
+	})
+	return week, nil
+}
+
+// hours converts seconds to hours, rounded to one decimal place.
+func hours(seconds float64) float64 {
+	return math.Round(seconds/360) / 10
+}
@@ -0,0 +1,54 @@
+package integrations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+func TestWakaTime_Week(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/users/current/summaries" {
+			http.NotFound(w, r)
+			return
+		}
+		// base64("key")
+		if r.Header.Get("Authorization") != "Basic a2V5" {
+			t.Errorf("unexpected Authorization %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Query().Get("start") != "2026-02-02" || r.URL.Query().Get("end") != "2026-02-08" {
+			t.Errorf("unexpected range %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"data":[
+			{"grand_total":{"total_seconds":7200},"range":{"date":"2026-02-02"},"projects":[{"name":"momentum","total_seconds":5400},{"name":"blog","total_seconds":1800}]},
+			{"grand_total":{"total_seconds":5400},"range":{"date":"2026-02-03"},"projects":[{"name":"momentum","total_seconds":5400}]}
+		]}`))
+	}))
+	defer srv.Close()
+
+	wt := NewWakaTime(WakaTimeConfig{APIKey: "key"}, clock.NewFake(time.Date(2026, 2, 3, 12, 0, 0, 0, time.UTC)))
+	wt.apiURL = srv.URL
+
+	weekStart := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	week, err := wt.Week(context.Background(), weekStart)
+	if err != nil {
+		t.Fatalf("Week() error = %v", err)
+	}
+	if week.TotalHours != 3.5 || len(week.Days) != 2 || week.Days[0].Hours != 2 {
+		t.Errorf("unexpected week %+v", week)
+	}
+	if len(week.Projects) != 2 || week.Projects[0].Name != "momentum" || week.Projects[0].Hours != 3 {
+		t.Errorf("unexpected projects %+v", week.Projects)
+	}
+
+	// Served from cache within the TTL
+	if _, err := wt.Week(context.Background(), weekStart); err != nil || calls != 1 {
+		t.Errorf("expected a cached result, got %d calls, err %v", calls, err)
+	}
+}
@@ -0,0 +1,167 @@
+// Package logging provides structured logging with per-request IDs.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/usage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RequestIDHeader is the HTTP header carrying the request ID.
+const RequestIDHeader = "X-Request-Id"
+
+type requestIDKey struct{}
+
+// Setup installs a JSON slog handler at the given level as the default logger.
+// Recognised levels are debug, info, warn, and error; anything else means info.
+func Setup(level string) {
+	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level: ParseLevel(level),
+	})
+	slog.SetDefault(slog.New(handler))
+}
+
+// ParseLevel converts a LOG_LEVEL string to an slog.Level.
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// WithRequestID returns a context carrying the given request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID stored in ctx, or "" if none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext returns the default logger annotated with the context's request ID.
+func FromContext(ctx context.Context) *slog.Logger {
+	if id := RequestID(ctx); id != "" {
+		return slog.Default().With("request_id", id)
+	}
+	return slog.Default()
+}
+
+// newRequestID generates a short random request ID.
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return hex.EncodeToString([]byte(time.Now().Format("150405.000")))
+	}
+	return hex.EncodeToString(b)
+}
+
+// StatusRecorder captures the response status code of a handler, for
+// middleware that logs or traces it.
+type StatusRecorder struct {
+	http.ResponseWriter
+	Status int
+}
+
+// NewStatusRecorder wraps w, with the status defaulting to 200.
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	return &StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+}
+
+func (s *StatusRecorder) WriteHeader(code int) {
+	s.Status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+// Flush forwards to the underlying writer so SSE streams keep working.
+func (s *StatusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (s *StatusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// RequestIDMiddleware assigns every HTTP request an ID (reusing an incoming
+// X-Request-Id if present), echoes it in the response, and logs the request.
+// The ID is also written to the request headers so MCP handlers can read it
+// from CallToolRequest.Extra.Header.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" || len(id) > 64 {
+			id = newRequestID()
+		}
+		r.Header.Set(RequestIDHeader, id)
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := WithRequestID(r.Context(), id)
+		rec := NewStatusRecorder(w)
+		start := time.Now()
+
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		FromContext(ctx).Debug("http request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.Status,
+			"duration_ms", time.Since(start).Milliseconds(),
+		)
+	})
+}
+
+// ToolMiddleware returns MCP receiving middleware that attaches the HTTP request
+// ID to the handler context and logs each tool call's name, duration and outcome.
+func ToolMiddleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if extra := req.GetExtra(); extra != nil && extra.Header != nil {
+				if id := extra.Header.Get(RequestIDHeader); id != "" {
+					ctx = WithRequestID(ctx, id)
+				}
+			}
+
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+
+			name := ""
+			if ctr, ok := req.(*mcp.CallToolRequest); ok && ctr.Params != nil {
+				name = ctr.Params.Name
+			}
+
+			start := time.Now()
+			result, err := next(ctx, method, req)
+
+			logger := FromContext(ctx).With(
+				"tool", name,
+				"duration_ms", time.Since(start).Milliseconds(),
+			)
+			if err != nil {
+				logger.Error("tool call failed", "success", false, "error", err)
+			} else {
+				logger.Info("tool call", "success", !usage.ToolFailed(result))
+			}
+
+			return result, err
+		}
+	}
+}
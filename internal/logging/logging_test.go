@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected slog.Level
+	}{
+		{"debug", slog.LevelDebug},
+		{"INFO", slog.LevelInfo},
+		{"warning", slog.LevelWarn},
+		{"error", slog.LevelError},
+		{"", slog.LevelInfo},
+		{"bogus", slog.LevelInfo},
+	}
+
+	for _, tt := range tests {
+		if got := ParseLevel(tt.input); got != tt.expected {
+			t.Errorf("ParseLevel(%q) = %v, expected %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestID(r.Context())
+	}))
+
+	t.Run("generates id", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		if seen == "" {
+			t.Fatal("expected request ID in context")
+		}
+		if got := rec.Header().Get(RequestIDHeader); got != seen {
+			t.Errorf("response header = %q, expected %q", got, seen)
+		}
+	})
+
+	t.Run("reuses incoming id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Header.Set(RequestIDHeader, "abc123")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		if seen != "abc123" {
+			t.Errorf("expected incoming ID to be reused, got %q", seen)
+		}
+	})
+}
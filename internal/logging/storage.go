@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"context"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// loggingStorage decorates a Storage with debug logs for every operation,
+// tagged with the request ID from the calling context.
+type loggingStorage struct {
+	next storage.Storage
+}
+
+// WrapStorage returns a Storage that logs each read and write.
+func WrapStorage(s storage.Storage) storage.Storage {
+	return &loggingStorage{next: s}
+}
+
+func (l *loggingStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	start := time.Now()
+	content, sha, err := l.next.ReadFile(ctx, path)
+
+	logger := FromContext(ctx).With(
+		"op", "read",
+		"path", path,
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+	if err != nil {
+		logger.Warn("storage operation failed", "error", err)
+	} else {
+		logger.Debug("storage operation", "bytes", len(content))
+	}
+	return content, sha, err
+}
+
+func (l *loggingStorage) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	start := time.Now()
+	err := l.next.WriteFile(ctx, path, content, sha, message)
+
+	logger := FromContext(ctx).With(
+		"op", "write",
+		"path", path,
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+	if err != nil {
+		logger.Warn("storage operation failed", "error", err)
+	} else {
+		logger.Debug("storage operation", "bytes", len(content), "message", message)
+	}
+	return err
+}
+
+func (l *loggingStorage) WriteFiles(ctx context.Context, changes []storage.FileChange, message string) error {
+	start := time.Now()
+	err := storage.WriteFiles(ctx, l.next, changes, message)
+
+	logger := FromContext(ctx).With(
+		"op", "write_files",
+		"files", len(changes),
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+	if err != nil {
+		logger.Warn("storage operation failed", "error", err)
+	} else {
+		logger.Debug("storage operation", "message", message)
+	}
+	return err
+}
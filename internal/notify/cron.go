@@ -0,0 +1,121 @@
+package notify
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed five-field cron expression: minute, hour, day of month,
+// month, and day of week (0-7, Sunday is 0 or 7). Fields take *, numbers,
+// ranges (1-5), lists (1,15) and steps (*/15, 9-17/2). As in cron, when
+// both the day of month and the day of week are restricted, a time matching
+// either matches.
+type Cron struct {
+	expr                          string
+	minute, hour, dom, month, dow []bool
+	domAny, dowAny                bool
+}
+
+// cronFields are the fields' names and bounds, in order.
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseCron parses a cron expression such as "0 7 * * 1-5" (07:00 on
+// weekdays).
+func ParseCron(expr string) (*Cron, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields (minute hour day-of-month month day-of-week)", expr)
+	}
+	sets := make([][]bool, len(parts))
+	for i, part := range parts {
+		f := cronFields[i]
+		set, err := parseCronField(part, f.min, f.max)
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %s: %w", expr, f.name, err)
+		}
+		sets[i] = set
+	}
+	// Sunday is both 0 and 7
+	sets[4][0] = sets[4][0] || sets[4][7]
+	return &Cron{
+		expr:   strings.Join(parts, " "),
+		minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4],
+		domAny: parts[2] == "*", dowAny: parts[4] == "*",
+	}, nil
+}
+
+// parseCronField parses one comma-separated field into the set of values
+// it allows, indexed by value.
+func parseCronField(field string, min, max int) ([]bool, error) {
+	set := make([]bool, max+1)
+	for _, term := range strings.Split(field, ",") {
+		rng, stepText, hasStep := strings.Cut(term, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepText)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		if rng != "*" {
+			loText, hiText, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(loText); err != nil {
+				return nil, fmt.Errorf("invalid value %q", loText)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiText); err != nil {
+					return nil, fmt.Errorf("invalid value %q", hiText)
+				}
+			} else if hasStep {
+				// "5/15" means from 5 to the end, every 15
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("%q is outside %d-%d", term, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// Matches reports whether t, in its own timezone, falls in a minute the
+// expression selects.
+func (c *Cron) Matches(t time.Time) bool {
+	if !c.minute[t.Minute()] || !c.hour[t.Hour()] || !c.month[int(t.Month())] {
+		return false
+	}
+	dom, dow := c.dom[t.Day()], c.dow[int(t.Weekday())]
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	}
+	return dom || dow
+}
+
+// String returns the expression.
+func (c *Cron) String() string {
+	return c.expr
+}
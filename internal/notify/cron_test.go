@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	at := func(day, hour, minute int) time.Time { return time.Date(2026, 2, day, hour, minute, 0, 0, time.UTC) }
+	tests := []struct {
+		expr string
+		t    time.Time
+		want bool
+	}{
+		{"0 7 * * *", at(3, 7, 0), true},
+		{"0 7 * * *", at(3, 7, 1), false},
+		{"*/15 9-17 * * *", at(3, 10, 45), true},
+		{"*/15 9-17 * * *", at(3, 18, 0), false},
+		{"30 8 * * 1-5", at(6, 8, 30), true},  // Friday
+		{"30 8 * * 1-5", at(7, 8, 30), false}, // Saturday
+		{"0 9 * * 7", at(8, 9, 0), true},      // Sunday as 7
+		{"0 9 1,15 * *", at(15, 9, 0), true},
+		{"0 9 1,15 * *", at(16, 9, 0), false},
+		// Day of month or day of week when both are set
+		{"0 9 1 * 1", at(9, 9, 0), true},
+		{"0 9 1 * 1", at(10, 9, 0), false},
+		{"0 9 * 3 *", at(3, 9, 0), false},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if got := c.Matches(tt.t); got != tt.want {
+			t.Errorf("%q matches %v = %v, want %v", tt.expr, tt.t, got, tt.want)
+		}
+	}
+
+	for _, expr := range []string{"", "0 7 * *", "60 7 * * *", "0 7 * * 8", "*/0 * * * *", "0 9-7 * * *", "a * * * *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want an error", expr)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Package notify sends reminder notifications through a webhook or email,
+// driven by a daily scheduler that remembers what it has already sent, and
+// parses the cron schedules of other pushed messages. Preferences in
+// preferences.md pick the channels, quiet hours and digest frequency.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// Message is a notification to deliver.
+type Message struct {
+	Subject string
+	Body    string
+}
+
+// Notifier delivers a message to one channel.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Channel names, as listed in preferences.md.
+const (
+	ChannelWebhook = "webhook"
+	ChannelEmail   = "email"
+)
+
+// Channels are the channel names preferences may list.
+var Channels = []string{ChannelWebhook, ChannelEmail}
+
+// channelNamer is implemented by notifiers that preferences can select.
+type channelNamer interface {
+	Channel() string
+}
+
+// Select returns the notifiers for the named channels, or all of them if
+// channels is empty. Notifiers without a channel name are always kept.
+func Select(notifiers []Notifier, channels []string) []Notifier {
+	if len(channels) == 0 {
+		return notifiers
+	}
+	var selected []Notifier
+	for _, n := range notifiers {
+		named, ok := n.(channelNamer)
+		if !ok {
+			selected = append(selected, n)
+			continue
+		}
+		for _, channel := range channels {
+			if named.Channel() == channel {
+				selected = append(selected, n)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// LoadPreferences reads the notification preferences from preferences.md.
+// A missing file means the defaults.
+func LoadPreferences(ctx context.Context, s storage.Storage) (storage.NotificationPreferences, error) {
+	content, _, err := s.ReadFile(ctx, storage.PreferencesFile)
+	if errors.Is(err, storage.ErrNotFound) {
+		return storage.NotificationPreferences{}, nil
+	}
+	if err != nil {
+		return storage.NotificationPreferences{}, fmt.Errorf("reading preferences.md: %w", err)
+	}
+	p, err := storage.ParsePreferences(content)
+	if err != nil {
+		return storage.NotificationPreferences{}, fmt.Errorf("parsing preferences: %w", err)
+	}
+	return p.Notifications, nil
+}
+
+// Deliver sends msg through every notifier. It succeeds if any channel
+// delivered, logging the failures, and only fails if all of them did.
+func Deliver(ctx context.Context, notifiers []Notifier, msg Message) error {
+	var errs []error
+	for _, n := range notifiers {
+		if err := n.Notify(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == len(notifiers) {
+		return errors.Join(errs...)
+	}
+	for _, err := range errs {
+		slog.Warn("notification channel failed", "error", err)
+	}
+	return nil
+}
+
+// WebhookNotifier posts messages as JSON to an incoming webhook. The payload
+// sets both "text" (Slack) and "content" (Discord), so either works unchanged.
+type WebhookNotifier struct {
+	URL    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a WebhookNotifier for url.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, client: &http.Client{Timeout: 15 * time.Second}}
+}
+
+// Channel returns the webhook channel's name.
+func (w *WebhookNotifier) Channel() string { return ChannelWebhook }
+
+// Notify posts msg to the webhook.
+func (w *WebhookNotifier) Notify(ctx context.Context, msg Message) error {
+	text := msg.Body
+	if msg.Subject != "" {
+		text = "*" + msg.Subject + "*\n" + msg.Body
+	}
+	payload, err := json.Marshal(map[string]string{"text": text, "content": text})
+	if err != nil {
+		return fmt.Errorf("encoding webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("posting webhook: status %d: %s", resp.StatusCode, body)
+	}
+	return nil
+}
+
+// SMTPConfig configures email delivery.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // Optional - if empty, no AUTH is attempted
+	Password string
+	From     string
+	To       []string
+}
+
+// SMTPNotifier sends messages as plain-text email.
+type SMTPNotifier struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPNotifier creates an SMTPNotifier.
+func NewSMTPNotifier(cfg SMTPConfig) *SMTPNotifier {
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	var to []string
+	for _, addr := range cfg.To {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, addr)
+		}
+	}
+	cfg.To = to
+	return &SMTPNotifier{cfg: cfg}
+}
+
+// Channel returns the email channel's name.
+func (s *SMTPNotifier) Channel() string { return ChannelEmail }
+
+// Notify emails msg to every recipient. The context is not honored by
+// net/smtp; the server's own timeouts apply.
+func (s *SMTPNotifier) Notify(ctx context.Context, msg Message) error {
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	var b strings.Builder
+	b.WriteString("From: " + s.cfg.From + "\r\n")
+	b.WriteString("To: " + strings.Join(s.cfg.To, ", ") + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	if err := smtp.SendMail(addr, auth, s.cfg.From, s.cfg.To, []byte(b.String())); err != nil {
+		return fmt.Errorf("sending email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// Scheduler checks reminders once a day at a configured hour and notifies
+// about those due today or overdue. Each reminder occurrence (ID and date) is
+// notified once; rescheduling a reminder makes it eligible again.
+type Scheduler struct {
+	storage   storage.Storage
+	notifiers []Notifier
+	hour      int
+	clock     clock.Clock
+
+	// checkInterval is how often the scheduler wakes to see if the daily
+	// run is due.
+	checkInterval time.Duration
+
+	mu      sync.Mutex
+	sent    map[string]string // "id@date" -> date notified
+	lastRun string            // date of the last successful daily run
+
+	filePath string
+	stopCh   chan struct{}
+}
+
+// NewScheduler creates a scheduler that runs at hour (0-23, in the clock's
+// timezone). Dedup
+// state is kept in dataDir/notifications.json; if dataDir is empty it is kept
+// in memory only. A nil clock uses the system clock.
+func NewScheduler(s storage.Storage, notifiers []Notifier, hour int, dataDir string, c clock.Clock) *Scheduler {
+	sched := &Scheduler{
+		storage:       s,
+		notifiers:     notifiers,
+		hour:          hour,
+		clock:         clock.Or(c),
+		checkInterval: 5 * time.Minute,
+		sent:          make(map[string]string),
+		stopCh:        make(chan struct{}),
+	}
+	if dataDir != "" {
+		sched.filePath = filepath.Join(dataDir, "notifications.json")
+	}
+	return sched
+}
+
+// Start loads dedup state and begins the daily check loop.
+func (s *Scheduler) Start() {
+	if err := s.load(); err != nil {
+		slog.Warn("could not load notification state", "error", err)
+	}
+	go s.loop()
+}
+
+// Stop ends the check loop.
+func (s *Scheduler) Stop() {
+	close(s.stopCh)
+}
+
+func (s *Scheduler) loop() {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	s.tick()
+	for {
+		select {
+		case <-ticker.C:
+			s.tick()
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+// tick runs the daily check if the configured hour has passed and today's
+// run has not succeeded yet, outside the preferences' quiet hours and on
+// the days their digest frequency selects.
+func (s *Scheduler) tick() {
+	now := s.clock.Now()
+	today := now.Format("2006-01-02")
+
+	s.mu.Lock()
+	done := s.lastRun == today
+	s.mu.Unlock()
+	if done || now.Hour() < s.hour {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	prefs, err := LoadPreferences(ctx, s.storage)
+	if err != nil {
+		slog.Warn("reminder notification check failed", "error", err)
+		return
+	}
+	// Held until the quiet hours end; on days without a digest, skipped
+	if prefs.QuietHours.Contains(now) {
+		return
+	}
+	sent := 0
+	if prefs.DigestDue(now) {
+		if sent, err = s.Check(ctx); err != nil {
+			slog.Warn("reminder notification check failed", "error", err)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	s.lastRun = today
+	s.mu.Unlock()
+	if err := s.save(); err != nil {
+		slog.Warn("saving notification state failed", "error", err)
+	}
+	if sent > 0 {
+		slog.Info("sent reminder notifications", "reminders", sent)
+	}
+}
+
+// Check notifies about due and overdue reminders that have not been notified
+// yet, as a single digest through the channels the preferences select. It
+// returns the number of reminders included.
+func (s *Scheduler) Check(ctx context.Context) (int, error) {
+	prefs, err := LoadPreferences(ctx, s.storage)
+	if err != nil {
+		return 0, err
+	}
+	notifiers := Select(s.notifiers, prefs.Channels)
+	if len(notifiers) == 0 {
+		// None of the preferred channels is configured; keep the reminders
+		// for when one is
+		return 0, nil
+	}
+
+	content, _, err := s.storage.ReadFile(ctx, storage.RemindersFile)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("reading reminders.md: %w", err)
+	}
+	rf, err := storage.ParseReminders(content)
+	if err != nil {
+		return 0, fmt.Errorf("parsing reminders: %w", err)
+	}
+
+	today := clock.Today(s.clock)
+	var due []storage.Reminder
+	current := make(map[string]bool)
+	for _, r := range rf.Upcoming {
+		if r.Date.After(today) {
+			continue
+		}
+		key := dedupKey(r)
+		current[key] = true
+		s.mu.Lock()
+		_, notified := s.sent[key]
+		s.mu.Unlock()
+		if !notified {
+			due = append(due, r)
+		}
+	}
+
+	// Forget reminders that were completed, deleted or moved
+	s.mu.Lock()
+	for key := range s.sent {
+		if !current[key] {
+			delete(s.sent, key)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(due) == 0 {
+		return 0, s.save()
+	}
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].Date.Before(due[j].Date) })
+	msg := digest(due, today)
+
+	if err := Deliver(ctx, notifiers, msg); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	for _, r := range due {
+		s.sent[dedupKey(r)] = today.Format("2006-01-02")
+	}
+	s.mu.Unlock()
+	return len(due), s.save()
+}
+
+func dedupKey(r storage.Reminder) string {
+	return r.ID + "@" + r.Date.Format("2006-01-02")
+}
+
+// digest renders due reminders as one message, overdue first.
+func digest(due []storage.Reminder, today time.Time) Message {
+	var b strings.Builder
+	for _, r := range due {
+		if days := int(today.Sub(r.Date).Hours() / 24); days > 0 {
+			b.WriteString(fmt.Sprintf("⚠️ Overdue: %s (due %s, %d days ago)\n", r.Text, r.Date.Format("Jan 2"), days))
+		} else {
+			b.WriteString(fmt.Sprintf("• Due today: %s\n", r.Text))
+		}
+	}
+
+	noun := "reminders"
+	if len(due) == 1 {
+		noun = "reminder"
+	}
+	return Message{
+		Subject: fmt.Sprintf("Momentum: %d %s due (%s)", len(due), noun, today.Format("Mon Jan 2")),
+		Body:    b.String(),
+	}
+}
+
+// persistedState is the on-disk dedup state.
+type persistedState struct {
+	Sent    map[string]string `json:"sent"`
+	LastRun string            `json:"last_run"`
+}
+
+func (s *Scheduler) load() error {
+	if s.filePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var state persistedState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, date := range state.Sent {
+		s.sent[key] = date
+	}
+	s.lastRun = state.LastRun
+	return nil
+}
+
+// save writes the dedup state to disk atomically.
+func (s *Scheduler) save() error {
+	if s.filePath == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	data, err := json.MarshalIndent(persistedState{Sent: s.sent, LastRun: s.lastRun}, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.filePath), 0700); err != nil {
+		return err
+	}
+	tmpFile := s.filePath + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, s.filePath)
+}
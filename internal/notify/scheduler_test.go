@@ -0,0 +1,151 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// setFile overwrites path in s.
+func setFile(t *testing.T, s storage.Storage, path, content string) {
+	t.Helper()
+	_, sha, _ := s.ReadFile(context.Background(), path)
+	if err := s.WriteFile(context.Background(), path, content, sha, "Edit "+path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// recorder captures delivered messages.
+type recorder struct {
+	messages []Message
+	err      error
+}
+
+func (r *recorder) Notify(ctx context.Context, msg Message) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+const testReminders = `# Reminders
+
+## Upcoming
+- 2026-02-01: Renew passport {id:aaaa1111,added:2026-01-20}
+- 2026-02-03: Call dentist {id:bbbb2222,added:2026-01-25}
+- 2026-02-10: Review progress {id:cccc3333,added:2026-01-25}
+
+## Completed
+`
+
+func TestScheduler_CheckNotifiesOnce(t *testing.T) {
+	files := storage.NewMemoryStorage(map[string]string{"reminders.md": testReminders}, nil)
+	rec := &recorder{}
+	clk := clock.NewFake(time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC))
+	s := NewScheduler(files, []Notifier{rec}, 8, "", clk)
+
+	sent, err := s.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if sent != 2 || len(rec.messages) != 1 {
+		t.Fatalf("expected one digest of 2 reminders, got %d reminders in %d messages", sent, len(rec.messages))
+	}
+	body := rec.messages[0].Body
+	if !strings.Contains(body, "Overdue: Renew passport") || !strings.Contains(body, "Due today: Call dentist") {
+		t.Errorf("unexpected digest body:\n%s", body)
+	}
+	if strings.Contains(body, "Review progress") {
+		t.Errorf("future reminder included in digest:\n%s", body)
+	}
+
+	// The next day nothing new is due, so nothing is re-sent
+	clk.Advance(24 * time.Hour)
+	if sent, _ := s.Check(context.Background()); sent != 0 || len(rec.messages) != 1 {
+		t.Errorf("expected no re-notification, got %d reminders in %d messages", sent, len(rec.messages))
+	}
+
+	// Rescheduling a reminder makes it eligible again
+	setFile(t, files, "reminders.md", strings.Replace(testReminders, "2026-02-01: Renew", "2026-02-04: Renew", 1))
+	if sent, _ := s.Check(context.Background()); sent != 1 {
+		t.Errorf("expected rescheduled reminder to be notified, got %d", sent)
+	}
+}
+
+func TestScheduler_FailedDeliveryIsRetried(t *testing.T) {
+	files := storage.NewMemoryStorage(map[string]string{"reminders.md": testReminders}, nil)
+	rec := &recorder{err: errors.New("webhook down")}
+	clk := clock.NewFake(time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC))
+	s := NewScheduler(files, []Notifier{rec}, 8, "", clk)
+
+	if _, err := s.Check(context.Background()); err == nil {
+		t.Fatal("expected error when every channel fails")
+	}
+
+	rec.err = nil
+	if sent, err := s.Check(context.Background()); err != nil || sent != 2 {
+		t.Errorf("expected retry to send 2 reminders, got %d (err %v)", sent, err)
+	}
+}
+
+func TestScheduler_TickWaitsForHour(t *testing.T) {
+	files := storage.NewMemoryStorage(map[string]string{"reminders.md": testReminders}, nil)
+	rec := &recorder{}
+	clk := clock.NewFake(time.Date(2026, 2, 3, 7, 30, 0, 0, time.UTC))
+	s := NewScheduler(files, []Notifier{rec}, 8, "", clk)
+
+	s.tick()
+	if len(rec.messages) != 0 {
+		t.Fatalf("expected no notification before the configured hour")
+	}
+
+	clk.Advance(time.Hour)
+	s.tick()
+	s.tick()
+	if len(rec.messages) != 1 {
+		t.Errorf("expected exactly one notification after the hour, got %d", len(rec.messages))
+	}
+}
+
+// namedRecorder is a recorder for one channel.
+type namedRecorder struct {
+	recorder
+	channel string
+}
+
+func (r *namedRecorder) Channel() string { return r.channel }
+
+func TestScheduler_Preferences(t *testing.T) {
+	files := storage.NewMemoryStorage(map[string]string{
+		"reminders.md":   testReminders,
+		"preferences.md": "# Preferences\n\n## Notifications\n- Channels: email\n- Quiet hours: 08:00-10:00\n- Digest: weekdays\n",
+	}, nil)
+	webhook, email := &namedRecorder{channel: ChannelWebhook}, &namedRecorder{channel: ChannelEmail}
+	clk := clock.NewFake(time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC)) // a Tuesday
+	s := NewScheduler(files, []Notifier{webhook, email}, 8, "", clk)
+
+	// Held during the quiet hours, then sent through the chosen channel only
+	s.tick()
+	if len(email.messages) != 0 {
+		t.Fatal("expected no notification during the quiet hours")
+	}
+	clk.Advance(time.Hour)
+	s.tick()
+	if len(email.messages) != 1 || len(webhook.messages) != 0 {
+		t.Errorf("expected one email and no webhook, got %d and %d", len(email.messages), len(webhook.messages))
+	}
+
+	// No weekday digest on Saturday
+	setFile(t, files, "reminders.md", strings.Replace(testReminders, "2026-02-10: Review", "2026-02-07: Review", 1))
+	clk.Advance(4 * 24 * time.Hour)
+	s.tick()
+	if len(email.messages) != 1 {
+		t.Errorf("expected no digest on Saturday, got %d messages", len(email.messages))
+	}
+}
@@ -0,0 +1,167 @@
+// Package readonly stops the server changing data, globally or for
+// individual tools - during a migration, say, or when sharing access.
+//
+// Global read-only mode is enforced where data is written: WrapStorage
+// refuses every write, so no list of mutating tools has to be kept up to
+// date, and background jobs stop writing too. Middleware turns a tool call
+// whose write was refused into a clear failure, and refuses the listed tools
+// outright. The mode can be switched at runtime through Handler.
+package readonly
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/dang-w/momentum-mcp-server/internal/usage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ErrReadOnly is returned for writes while the server is read-only.
+var ErrReadOnly = errors.New("server is in read-only mode")
+
+// externalWriters are tools that change data other than through storage
+// (GitHub issues, pull requests, the offline write queue), so global mode
+// refuses them up front. The function reports whether a call with args
+// would write.
+var externalWriters = map[string]func(args map[string]any) bool{
+	"sync_milestone_issues": func(map[string]any) bool { return true },
+	"pending_changes": func(args map[string]any) bool {
+		action, _ := args["action"].(string)
+		action = strings.ToLower(strings.TrimSpace(action))
+		return action != "" && action != "list"
+	},
+	"sync_status": func(args map[string]any) bool {
+		action, _ := args["action"].(string)
+		action = strings.ToLower(strings.TrimSpace(action))
+		return action != "" && action != "status"
+	},
+}
+
+// Switch holds the read-only settings.
+type Switch struct {
+	enabled atomic.Bool
+	tools   map[string]bool
+}
+
+// New creates a Switch, read-only globally if enabled, and always for the
+// named tools.
+func New(enabled bool, tools []string) *Switch {
+	s := &Switch{tools: make(map[string]bool)}
+	s.enabled.Store(enabled)
+	for _, name := range tools {
+		s.tools[name] = true
+	}
+	return s
+}
+
+// Enabled reports whether the whole server is read-only.
+func (s *Switch) Enabled() bool {
+	return s.enabled.Load()
+}
+
+// Set turns global read-only mode on or off.
+func (s *Switch) Set(enabled bool) {
+	if s.enabled.Swap(enabled) != enabled {
+		slog.Warn("read-only mode changed", "read_only", enabled)
+	}
+}
+
+// Tools returns the tools that are always refused, sorted.
+func (s *Switch) Tools() []string {
+	names := make([]string, 0, len(s.tools))
+	for name := range s.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// refusedKey marks a tool call's context with a flag WrapStorage sets when
+// it refuses one of the call's writes.
+type refusedKey struct{}
+
+func markRefused(ctx context.Context) {
+	if refused, ok := ctx.Value(refusedKey{}).(*atomic.Bool); ok {
+		refused.Store(true)
+	}
+}
+
+// Middleware returns MCP receiving middleware that refuses the listed tools
+// and, in global mode, reports tool calls that tried to write as failures.
+func (s *Switch) Middleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			call, ok := req.(*mcp.CallToolRequest)
+			if method != "tools/call" || !ok || call.Params == nil {
+				return next(ctx, method, req)
+			}
+			name := call.Params.Name
+			if s.tools[name] {
+				return usage.FailedResult(fmt.Sprintf("%s is disabled: the server is read-only for this tool.", name)), nil
+			}
+			if s.Enabled() && writesExternally(name, call.Params.Arguments) {
+				return usage.FailedResult(globalMessage(name)), nil
+			}
+
+			refused := new(atomic.Bool)
+			result, err := next(context.WithValue(ctx, refusedKey{}, refused), method, req)
+			if refused.Load() {
+				return usage.FailedResult(globalMessage(name)), nil
+			}
+			return result, err
+		}
+	}
+}
+
+func writesExternally(name string, raw json.RawMessage) bool {
+	writes, ok := externalWriters[name]
+	if !ok {
+		return false
+	}
+	args := map[string]any{}
+	json.Unmarshal(raw, &args)
+	return writes(args)
+}
+
+func globalMessage(tool string) string {
+	return fmt.Sprintf("The server is in read-only mode, so %s can't change any data. Reading tools and resources still work.", tool)
+}
+
+// status is the body of Handler's responses.
+type status struct {
+	ReadOnly bool     `json:"read_only"`
+	Tools    []string `json:"tools"`
+}
+
+// Handler serves the read-only status as JSON on GET, and switches global
+// mode on POST with a body of {"read_only": true|false}.
+func (s *Switch) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			var body struct {
+				ReadOnly *bool `json:"read_only"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.ReadOnly == nil {
+				http.Error(w, `Body must be {"read_only": true} or {"read_only": false}`, http.StatusBadRequest)
+				return
+			}
+			s.Set(*body.ReadOnly)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(status{ReadOnly: s.Enabled(), Tools: s.Tools()})
+	})
+}
@@ -0,0 +1,144 @@
+package readonly
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type noteInput struct {
+	Text   string `json:"text,omitempty"`
+	Action string `json:"action,omitempty"`
+}
+
+type noteOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// connect serves add_note (writes), get_notes (reads) and pending_changes
+// (no storage) over s, behind sw's middleware.
+func connect(t *testing.T, sw *Switch, s storage.Storage) *mcp.ClientSession {
+	t.Helper()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "add_note"}, func(ctx context.Context, req *mcp.CallToolRequest, in noteInput) (*mcp.CallToolResult, noteOutput, error) {
+		_, sha, err := s.ReadFile(ctx, "notes.md")
+		if err != nil {
+			return nil, noteOutput{}, err
+		}
+		if err := s.WriteFile(ctx, "notes.md", in.Text, sha, "Add note"); err != nil {
+			return nil, noteOutput{}, err
+		}
+		return nil, noteOutput{Success: true, Message: "added"}, nil
+	})
+	mcp.AddTool(server, &mcp.Tool{Name: "get_notes"}, func(ctx context.Context, req *mcp.CallToolRequest, in noteInput) (*mcp.CallToolResult, noteOutput, error) {
+		content, _, err := s.ReadFile(ctx, "notes.md")
+		return nil, noteOutput{Success: err == nil, Message: content}, nil
+	})
+	mcp.AddTool(server, &mcp.Tool{Name: "pending_changes"}, func(ctx context.Context, req *mcp.CallToolRequest, in noteInput) (*mcp.CallToolResult, noteOutput, error) {
+		return nil, noteOutput{Success: true, Message: in.Action}, nil
+	})
+	server.AddReceivingMiddleware(sw.Middleware())
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "client"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+func call(t *testing.T, session *mcp.ClientSession, name string, args map[string]any) noteOutput {
+	t.Helper()
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out noteOutput
+	raw, _ := json.Marshal(res.StructuredContent)
+	json.Unmarshal(raw, &out)
+	if res.IsError {
+		out.Message = res.Content[0].(*mcp.TextContent).Text
+	}
+	return out
+}
+
+func TestGlobal(t *testing.T) {
+	sw := New(true, nil)
+	mem := storage.NewMemoryStorage(map[string]string{"notes.md": "old"}, nil)
+	session := connect(t, sw, WrapStorage(mem, sw))
+
+	out := call(t, session, "add_note", map[string]any{"text": "new"})
+	if out.Success || !strings.Contains(out.Message, "read-only mode") {
+		t.Errorf("write in read-only mode = %+v", out)
+	}
+	if content, _, _ := mem.ReadFile(context.Background(), "notes.md"); content != "old" {
+		t.Errorf("notes.md = %q, want it unchanged", content)
+	}
+	if out := call(t, session, "get_notes", nil); !out.Success || out.Message != "old" {
+		t.Errorf("read in read-only mode = %+v", out)
+	}
+
+	// Tools that write outside storage are refused by their arguments
+	if out := call(t, session, "pending_changes", map[string]any{"action": "merge"}); out.Success {
+		t.Errorf("pending_changes merge = %+v", out)
+	}
+	if out := call(t, session, "pending_changes", map[string]any{"action": "list"}); !out.Success {
+		t.Errorf("pending_changes list = %+v", out)
+	}
+
+	// Switched off at runtime
+	sw.Set(false)
+	if out := call(t, session, "add_note", map[string]any{"text": "new"}); !out.Success {
+		t.Errorf("write after switching off = %+v", out)
+	}
+}
+
+func TestTools(t *testing.T) {
+	sw := New(false, []string{"add_note"})
+	mem := storage.NewMemoryStorage(map[string]string{"notes.md": "old"}, nil)
+	session := connect(t, sw, WrapStorage(mem, sw))
+
+	if out := call(t, session, "add_note", map[string]any{"text": "new"}); out.Success || !strings.Contains(out.Message, "add_note is disabled") {
+		t.Errorf("listed tool = %+v", out)
+	}
+	if out := call(t, session, "pending_changes", map[string]any{"action": "merge"}); !out.Success {
+		t.Errorf("unlisted tool = %+v", out)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	sw := New(false, []string{"patch_file"})
+	h := sw.Handler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/read-only", strings.NewReader(`{"read_only": true}`)))
+	var got status
+	json.NewDecoder(rec.Body).Decode(&got)
+	if rec.Code != http.StatusOK || !got.ReadOnly || len(got.Tools) != 1 || !sw.Enabled() {
+		t.Errorf("POST = %d %+v", rec.Code, got)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/read-only", strings.NewReader(`{}`)))
+	if rec.Code != http.StatusBadRequest || !sw.Enabled() {
+		t.Errorf("POST without read_only = %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/read-only", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE = %d", rec.Code)
+	}
+}
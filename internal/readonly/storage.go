@@ -0,0 +1,60 @@
+package readonly
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// readOnlyStorage refuses writes while its Switch is on.
+type readOnlyStorage struct {
+	next storage.Storage
+	sw   *Switch
+}
+
+// WrapStorage returns a Storage that fails writes with ErrReadOnly while sw
+// is in global read-only mode. Reads and history pass through.
+func WrapStorage(s storage.Storage, sw *Switch) storage.Storage {
+	return &readOnlyStorage{next: s, sw: sw}
+}
+
+func (r *readOnlyStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	return r.next.ReadFile(ctx, path)
+}
+
+func (r *readOnlyStorage) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	if r.sw.Enabled() {
+		markRefused(ctx)
+		return ErrReadOnly
+	}
+	return r.next.WriteFile(ctx, path, content, sha, message)
+}
+
+func (r *readOnlyStorage) WriteFiles(ctx context.Context, changes []storage.FileChange, message string) error {
+	if r.sw.Enabled() {
+		markRefused(ctx)
+		return ErrReadOnly
+	}
+	return storage.WriteFiles(ctx, r.next, changes, message)
+}
+
+// errNoHistory is returned by the History methods when the wrapped storage
+// can't read past versions.
+var errNoHistory = errors.New("storage backend does not support history")
+
+func (r *readOnlyStorage) ListCommits(ctx context.Context, path string, limit int) ([]storage.Commit, error) {
+	h, ok := r.next.(storage.History)
+	if !ok {
+		return nil, errNoHistory
+	}
+	return h.ListCommits(ctx, path, limit)
+}
+
+func (r *readOnlyStorage) ReadFileAt(ctx context.Context, path string, ref string) (string, error) {
+	h, ok := r.next.(storage.History)
+	if !ok {
+		return "", errNoHistory
+	}
+	return h.ReadFileAt(ctx, path, ref)
+}
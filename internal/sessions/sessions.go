@@ -0,0 +1,181 @@
+// Package sessions reports on MCP streamable HTTP sessions for the admin
+// endpoints: which sessions are live, when each was last used, and how
+// often SSE streams were resumed after a dropped connection.
+//
+// The SDK owns the sessions themselves; Tracker only watches the HTTP
+// requests that carry them, and asks the server which are still live.
+package sessions
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Headers of the streamable HTTP transport.
+const (
+	sessionIDHeader   = "Mcp-Session-Id"
+	lastEventIDHeader = "Last-Event-ID"
+)
+
+// Session is the activity of one MCP session.
+type Session struct {
+	ID           string    `json:"id"`
+	Client       string    `json:"client,omitempty"`
+	Started      time.Time `json:"started"`
+	LastActivity time.Time `json:"last_activity"`
+	Requests     int       `json:"requests"`
+	OpenStreams  int       `json:"open_streams"`
+	Resumes      int       `json:"resumes"`
+}
+
+// Report is the response body for GET /admin/sessions.
+type Report struct {
+	Active   int       `json:"active"`
+	Resumes  int       `json:"resumes"` // since the server started
+	Sessions []Session `json:"sessions"`
+}
+
+// Tracker records the activity of a server's sessions.
+type Tracker struct {
+	server *mcp.Server
+	clock  clock.Clock
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+	resumes  int
+}
+
+// NewTracker creates a Tracker for server's sessions using the given clock
+// (nil for the system clock).
+func NewTracker(server *mcp.Server, c clock.Clock) *Tracker {
+	return &Tracker{server: server, clock: clock.Or(c), sessions: make(map[string]*Session)}
+}
+
+// Middleware records the requests of each session. Wrap the streamable HTTP
+// handler with it.
+func (t *Tracker) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(sessionIDHeader)
+		if id != "" {
+			// A GET is an SSE stream, held open until the client goes away;
+			// with Last-Event-ID it resumes one that dropped
+			stream := r.Method == http.MethodGet
+			t.touch(id, stream, stream && r.Header.Get(lastEventIDHeader) != "")
+			if stream {
+				defer t.streamClosed(id)
+			}
+		}
+
+		next.ServeHTTP(w, r)
+
+		// A new session's ID comes back on the initialize response. Forget
+		// closed sessions then too, so the map doesn't grow without Report
+		if created := w.Header().Get(sessionIDHeader); id == "" && created != "" {
+			t.touch(created, false, false)
+			t.forgetClosed()
+		}
+	})
+}
+
+func (t *Tracker) touch(id string, stream, resumed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock.Now()
+	s := t.sessions[id]
+	if s == nil {
+		s = &Session{ID: id, Started: now}
+		t.sessions[id] = s
+	}
+	s.LastActivity = now
+	s.Requests++
+	if stream {
+		s.OpenStreams++
+	}
+	if resumed {
+		s.Resumes++
+		t.resumes++
+	}
+}
+
+func (t *Tracker) streamClosed(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if s := t.sessions[id]; s != nil && s.OpenStreams > 0 {
+		s.OpenStreams--
+	}
+}
+
+// liveClients returns the server's live sessions by ID, with the name each
+// client gave when it initialized.
+func (t *Tracker) liveClients() map[string]string {
+	clients := make(map[string]string)
+	for ss := range t.server.Sessions() {
+		name := ""
+		if params := ss.InitializeParams(); params != nil && params.ClientInfo != nil {
+			name = params.ClientInfo.Name
+		}
+		clients[ss.ID()] = name
+	}
+	return clients
+}
+
+// forgetClosed drops sessions the server has closed - deleted by the client
+// or timed out - and requests that named unknown sessions. It returns the
+// live sessions as liveClients does.
+func (t *Tracker) forgetClosed() map[string]string {
+	// Sessions started while the server is asked aren't in its answer yet
+	asked := t.clock.Now()
+	live := t.liveClients()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, s := range t.sessions {
+		if _, ok := live[id]; !ok && s.Started.Before(asked) {
+			delete(t.sessions, id)
+		}
+	}
+	return live
+}
+
+// Report returns the live sessions, most recently active first.
+func (t *Tracker) Report() Report {
+	live := t.forgetClosed()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := Report{Resumes: t.resumes, Sessions: []Session{}}
+	for id, s := range t.sessions {
+		if _, ok := live[id]; !ok {
+			continue
+		}
+		session := *s
+		session.Client = live[id]
+		report.Sessions = append(report.Sessions, session)
+	}
+	sort.Slice(report.Sessions, func(i, j int) bool {
+		return report.Sessions[i].LastActivity.After(report.Sessions[j].LastActivity)
+	})
+	report.Active = len(report.Sessions)
+	return report
+}
+
+// Handler serves the report as JSON.
+func (t *Tracker) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(t.Report())
+	})
+}
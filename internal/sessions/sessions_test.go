@@ -0,0 +1,70 @@
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestTracker(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "test"}, nil)
+	tracker := NewTracker(server, nil)
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, &mcp.StreamableHTTPOptions{
+		EventStore: mcp.NewMemoryEventStore(nil),
+	})
+	srv := httptest.NewServer(tracker.Middleware(handler))
+	t.Cleanup(srv.Close)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "tester", Version: "test"}, nil)
+	session, err := client.Connect(context.Background(), &mcp.StreamableClientTransport{Endpoint: srv.URL, MaxRetries: -1}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := session.Ping(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	report := tracker.Report()
+	if report.Active != 1 || len(report.Sessions) != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	s := report.Sessions[0]
+	if s.ID != session.ID() || s.Client != "tester" || s.Requests < 2 || s.LastActivity.IsZero() {
+		t.Errorf("session = %+v", s)
+	}
+
+	// A resumed stream is counted
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set(sessionIDHeader, session.ID())
+	req.Header.Set(lastEventIDHeader, "bogus")
+	if resp, err := http.DefaultClient.Do(req); err == nil {
+		resp.Body.Close()
+	}
+	if got := tracker.Report(); got.Resumes != 1 || got.Sessions[0].Resumes != 1 {
+		t.Errorf("resumes = %d, session %+v", got.Resumes, got.Sessions[0])
+	}
+
+	// Unknown sessions aren't reported
+	req, _ = http.NewRequest(http.MethodPost, srv.URL, nil)
+	req.Header.Set(sessionIDHeader, "stale")
+	if resp, err := http.DefaultClient.Do(req); err == nil {
+		resp.Body.Close()
+	}
+
+	// A closed session is forgotten
+	session.Close()
+	rec := httptest.NewRecorder()
+	tracker.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/sessions", nil))
+	var got Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Active != 0 || len(got.Sessions) != 0 || got.Resumes != 1 {
+		t.Errorf("after close: %s", rec.Body)
+	}
+}
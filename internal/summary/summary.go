@@ -0,0 +1,94 @@
+// Package summary defines the options shared by everything that renders the
+// weekly summary: the momentum://weekly-summary resource, the
+// get_weekly_summary tool and archived weekly reviews.
+package summary
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Summary sections, in the order they are rendered.
+const (
+	SectionMomentum    = "momentum"
+	SectionFocus       = "focus"
+	SectionTime        = "time"
+	SectionReading     = "reading"
+	SectionCompletions = "completions"
+)
+
+// Sections lists every section name.
+var Sections = []string{SectionMomentum, SectionFocus, SectionTime, SectionReading, SectionCompletions}
+
+// Verbosity levels.
+const (
+	// Brief keeps each section to its headline numbers.
+	Brief = "brief"
+	// Normal is the default rendering.
+	Normal = "normal"
+	// Detailed lifts the caps on listed items.
+	Detailed = "detailed"
+)
+
+// Options selects what a summary includes. The zero value renders every
+// section at normal verbosity.
+type Options struct {
+	// Sections to render; empty means all.
+	Sections []string
+	// Verbosity is Brief, Normal or Detailed; empty means Normal.
+	Verbosity string
+}
+
+// Includes reports whether section should be rendered.
+func (o Options) Includes(section string) bool {
+	if len(o.Sections) == 0 {
+		return true
+	}
+	for _, s := range o.Sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// Brief reports whether only headline numbers should be rendered.
+func (o Options) Brief() bool { return o.Verbosity == Brief }
+
+// Detailed reports whether item lists should be rendered in full.
+func (o Options) Detailed() bool { return o.Verbosity == Detailed }
+
+// ParseOptions validates section names and a verbosity level, normalizing
+// case. Unknown values are reported in the error.
+func ParseOptions(sections []string, verbosity string) (Options, error) {
+	var o Options
+	for _, s := range sections {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "" {
+			continue
+		}
+		if !contains(Sections, s) {
+			return Options{}, fmt.Errorf("unknown section %q. Use: %s", s, strings.Join(Sections, ", "))
+		}
+		o.Sections = append(o.Sections, s)
+	}
+
+	switch v := strings.ToLower(strings.TrimSpace(verbosity)); v {
+	case "", Normal:
+		o.Verbosity = Normal
+	case Brief, Detailed:
+		o.Verbosity = v
+	default:
+		return Options{}, fmt.Errorf("unknown verbosity %q. Use: %s, %s, or %s", verbosity, Brief, Normal, Detailed)
+	}
+	return o, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
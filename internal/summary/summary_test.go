@@ -0,0 +1,24 @@
+package summary
+
+import "testing"
+
+func TestParseOptions(t *testing.T) {
+	o, err := ParseOptions([]string{" Momentum", "completions", ""}, "Brief")
+	if err != nil {
+		t.Fatalf("ParseOptions() error = %v", err)
+	}
+	if !o.Brief() || !o.Includes(SectionMomentum) || !o.Includes(SectionCompletions) || o.Includes(SectionFocus) {
+		t.Errorf("unexpected options %+v", o)
+	}
+
+	if o, _ := ParseOptions(nil, ""); o.Verbosity != Normal || !o.Includes(SectionReading) {
+		t.Errorf("expected all sections at normal verbosity, got %+v", o)
+	}
+
+	if _, err := ParseOptions([]string{"github"}, ""); err == nil {
+		t.Error("expected an error for an unknown section")
+	}
+	if _, err := ParseOptions(nil, "loud"); err == nil {
+		t.Error("expected an error for an unknown verbosity")
+	}
+}
@@ -0,0 +1,275 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// queueSize bounds buffered spans; spans are dropped when full.
+	queueSize = 2048
+	// batchSize is the maximum number of spans sent per export request.
+	batchSize = 256
+	// flushInterval is how often queued spans are exported.
+	flushInterval = 5 * time.Second
+)
+
+// Exporter batches finished spans and sends them to an OTLP/HTTP collector.
+type Exporter struct {
+	url         string
+	headers     map[string]string
+	serviceName string
+	httpClient  *http.Client
+
+	queue  chan *Span
+	stopCh chan struct{}
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewExporter creates an exporter for the given config. Call Start to begin exporting.
+func NewExporter(cfg Config) *Exporter {
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "momentum-mcp-server"
+	}
+	return &Exporter{
+		url:         strings.TrimRight(cfg.Endpoint, "/") + "/v1/traces",
+		headers:     cfg.Headers,
+		serviceName: serviceName,
+		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		queue:       make(chan *Span, queueSize),
+		stopCh:      make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// Start begins the background export loop.
+func (e *Exporter) Start() {
+	go e.run()
+}
+
+// Shutdown flushes queued spans and stops the export loop.
+func (e *Exporter) Shutdown(ctx context.Context) {
+	if e == nil {
+		return
+	}
+	e.once.Do(func() { close(e.stopCh) })
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+	}
+}
+
+func (e *Exporter) enqueue(s *Span) {
+	select {
+	case e.queue <- s:
+	default:
+		// Queue full - drop rather than block request handling
+	}
+}
+
+func (e *Exporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	var batch []*Span
+	for {
+		select {
+		case s := <-e.queue:
+			batch = append(batch, s)
+			if len(batch) >= batchSize {
+				e.export(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				e.export(batch)
+				batch = nil
+			}
+		case <-e.stopCh:
+			// Drain anything still queued
+			for {
+				select {
+				case s := <-e.queue:
+					batch = append(batch, s)
+				default:
+					if len(batch) > 0 {
+						e.export(batch)
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// export sends a batch of spans, logging (not returning) failures.
+func (e *Exporter) export(spans []*Span) {
+	body, err := json.Marshal(e.buildRequest(spans))
+	if err != nil {
+		slog.Error("encoding trace export", "error", err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		slog.Error("creating trace export request", "error", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		slog.Warn("trace export failed", "error", err, "spans", len(spans))
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		slog.Warn("trace export rejected", "status", resp.StatusCode, "spans", len(spans))
+	}
+}
+
+// OTLP/JSON wire types (subset of opentelemetry-proto trace/v1).
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"` // 1 = OK, 2 = ERROR
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+func (e *Exporter) buildRequest(spans []*Span) otlpRequest {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		out = append(out, toOTLP(s))
+	}
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpKeyValue{keyValue("service.name", e.serviceName)},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "momentum"},
+				Spans: out,
+			}},
+		}},
+	}
+}
+
+func toOTLP(s *Span) otlpSpan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	span := otlpSpan{
+		TraceID:           hex.EncodeToString(s.traceID[:]),
+		SpanID:            hex.EncodeToString(s.spanID[:]),
+		Name:              s.name,
+		Kind:              int(s.kind),
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Status:            otlpStatus{Code: 1},
+	}
+	if s.parentID != [8]byte{} {
+		span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+	for k, v := range s.attrs {
+		span.Attributes = append(span.Attributes, keyValue(k, v))
+	}
+	if s.hasError {
+		span.Status = otlpStatus{Code: 2, Message: s.errMsg}
+	}
+	return span
+}
+
+func keyValue(key string, value any) otlpKeyValue {
+	var v otlpAnyValue
+	switch val := value.(type) {
+	case string:
+		v.StringValue = &val
+	case bool:
+		v.BoolValue = &val
+	case int:
+		s := strconv.Itoa(val)
+		v.IntValue = &s
+	case int64:
+		s := strconv.FormatInt(val, 10)
+		v.IntValue = &s
+	case float64:
+		v.DoubleValue = &val
+	default:
+		s := fmt.Sprint(val)
+		v.StringValue = &s
+	}
+	return otlpKeyValue{Key: key, Value: v}
+}
+
+// ParseHeaders parses OTEL_EXPORTER_OTLP_HEADERS-style "k1=v1,k2=v2" pairs.
+func ParseHeaders(s string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			continue
+		}
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return headers
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/dang-w/momentum-mcp-server/internal/usage"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ToolMiddleware returns MCP receiving middleware that starts a span for
+// each tool call, parented to the HTTP request span via the traceparent
+// header written by HTTPMiddleware.
+func ToolMiddleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if !Enabled() || method != "tools/call" {
+				return next(ctx, method, req)
+			}
+
+			if extra := req.GetExtra(); extra != nil && extra.Header != nil {
+				ctx = ContextWithRemoteParent(ctx, extra.Header.Get(TraceparentHeader))
+			}
+
+			name := ""
+			if ctr, ok := req.(*mcp.CallToolRequest); ok && ctr.Params != nil {
+				name = ctr.Params.Name
+			}
+
+			ctx, span := Start(ctx, "tool "+name, KindInternal)
+			span.SetAttr("mcp.tool", name)
+			result, err := next(ctx, method, req)
+			span.SetError(err)
+			span.SetAttr("mcp.success", err == nil && !usage.ToolFailed(result))
+			span.End()
+
+			return result, err
+		}
+	}
+}
+
+// tracingStorage decorates a Storage with a client span per GitHub call.
+type tracingStorage struct {
+	next storage.Storage
+}
+
+// WrapStorage returns a Storage that records a span for each read and write.
+func WrapStorage(s storage.Storage) storage.Storage {
+	return &tracingStorage{next: s}
+}
+
+func (t *tracingStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	ctx, span := Start(ctx, "github.read", KindClient)
+	span.SetAttr("file.path", path)
+	content, sha, err := t.next.ReadFile(ctx, path)
+	span.SetError(err)
+	span.End()
+	return content, sha, err
+}
+
+func (t *tracingStorage) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	ctx, span := Start(ctx, "github.write", KindClient)
+	span.SetAttr("file.path", path)
+	span.SetAttr("file.bytes", len(content))
+	err := t.next.WriteFile(ctx, path, content, sha, message)
+	span.SetError(err)
+	span.End()
+	return err
+}
+
+func (t *tracingStorage) WriteFiles(ctx context.Context, changes []storage.FileChange, message string) error {
+	ctx, span := Start(ctx, "github.write_files", KindClient)
+	span.SetAttr("file.count", len(changes))
+	err := storage.WriteFiles(ctx, t.next, changes, message)
+	span.SetError(err)
+	span.End()
+	return err
+}
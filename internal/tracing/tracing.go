@@ -0,0 +1,214 @@
+// Package tracing provides lightweight OpenTelemetry-compatible tracing with
+// OTLP/HTTP (JSON) export. Tracing is disabled unless Init is called with an
+// endpoint; all span operations are no-ops while disabled.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/logging"
+)
+
+// SpanKind mirrors the OTLP span kinds used by this server.
+type SpanKind int
+
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+	KindClient   SpanKind = 3
+)
+
+// Config configures trace export.
+type Config struct {
+	// Endpoint is the OTLP/HTTP base URL (e.g. http://localhost:4318).
+	// Spans are POSTed to Endpoint + "/v1/traces". Empty disables tracing.
+	Endpoint string
+
+	// Headers are added to every export request (e.g. API keys).
+	Headers map[string]string
+
+	// ServiceName is reported as the service.name resource attribute.
+	ServiceName string
+}
+
+// Span is a single timed operation within a trace.
+type Span struct {
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte
+	name     string
+	kind     SpanKind
+	start    time.Time
+
+	mu       sync.Mutex
+	end      time.Time
+	attrs    map[string]any
+	errMsg   string
+	hasError bool
+	ended    bool
+}
+
+type spanKey struct{}
+
+var (
+	globalMu sync.RWMutex
+	global   *Exporter
+)
+
+// Init starts the global exporter. It returns nil (tracing disabled) if no
+// endpoint is configured.
+func Init(cfg Config) *Exporter {
+	if cfg.Endpoint == "" {
+		return nil
+	}
+	exp := NewExporter(cfg)
+	exp.Start()
+
+	globalMu.Lock()
+	global = exp
+	globalMu.Unlock()
+	return exp
+}
+
+// Enabled reports whether spans are being exported.
+func Enabled() bool {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	return global != nil
+}
+
+// Start begins a span as a child of any span in ctx. If tracing is disabled
+// it returns ctx unchanged and a nil span, which is safe to use.
+func Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if !Enabled() {
+		return ctx, nil
+	}
+
+	s := &Span{name: name, kind: kind, start: time.Now()}
+	if parent := FromContext(ctx); parent != nil {
+		s.traceID = parent.traceID
+		s.parentID = parent.spanID
+	} else {
+		rand.Read(s.traceID[:])
+	}
+	rand.Read(s.spanID[:])
+
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// FromContext returns the active span in ctx, or nil.
+func FromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// SetAttr records a key/value attribute on the span.
+func (s *Span) SetAttr(key string, value any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.attrs == nil {
+		s.attrs = make(map[string]any)
+	}
+	s.attrs[key] = value
+}
+
+// SetError marks the span as failed. A nil error is ignored.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hasError = true
+	s.errMsg = err.Error()
+}
+
+// End finishes the span and queues it for export. Calling End more than
+// once has no effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	globalMu.RLock()
+	exp := global
+	globalMu.RUnlock()
+	if exp != nil {
+		exp.enqueue(s)
+	}
+}
+
+// Traceparent returns the W3C traceparent header value for the span.
+func (s *Span) Traceparent() string {
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(s.traceID[:]), hex.EncodeToString(s.spanID[:]))
+}
+
+// TraceparentHeader is the W3C trace context propagation header.
+const TraceparentHeader = "Traceparent"
+
+// ContextWithRemoteParent returns a context whose next span continues the
+// trace described by a W3C traceparent value. Invalid values are ignored.
+func ContextWithRemoteParent(ctx context.Context, traceparent string) context.Context {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return ctx
+	}
+
+	parent := &Span{ended: true}
+	if _, err := hex.Decode(parent.traceID[:], []byte(parts[1])); err != nil {
+		return ctx
+	}
+	if _, err := hex.Decode(parent.spanID[:], []byte(parts[2])); err != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanKey{}, parent)
+}
+
+// HTTPMiddleware starts a server span for every HTTP request, continuing any
+// incoming traceparent. The span's traceparent is written back onto the
+// request headers so MCP handlers (which don't share the HTTP context) can
+// attach their spans to the same trace.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := ContextWithRemoteParent(r.Context(), r.Header.Get(TraceparentHeader))
+		ctx, span := Start(ctx, r.Method+" "+r.URL.Path, KindServer)
+		span.SetAttr("http.method", r.Method)
+		span.SetAttr("http.target", r.URL.Path)
+		r.Header.Set(TraceparentHeader, span.Traceparent())
+
+		rec := logging.NewStatusRecorder(w)
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		span.SetAttr("http.status_code", rec.Status)
+		if rec.Status >= 500 {
+			span.SetError(fmt.Errorf("HTTP %d", rec.Status))
+		}
+		span.End()
+	})
+}
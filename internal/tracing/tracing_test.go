@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStart_Disabled(t *testing.T) {
+	ctx, span := Start(context.Background(), "noop", KindInternal)
+	if span != nil {
+		t.Fatal("expected nil span when tracing is disabled")
+	}
+	// Nil spans must be safe to use
+	span.SetAttr("k", "v")
+	span.End()
+	if FromContext(ctx) != nil {
+		t.Error("expected no span in context")
+	}
+}
+
+func TestContextWithRemoteParent(t *testing.T) {
+	parent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := ContextWithRemoteParent(context.Background(), parent)
+	if got := FromContext(ctx).Traceparent(); got != parent {
+		t.Errorf("Traceparent() = %q, expected %q", got, parent)
+	}
+
+	if FromContext(ContextWithRemoteParent(context.Background(), "garbage")) != nil {
+		t.Error("expected invalid traceparent to be ignored")
+	}
+}
+
+func TestExporter(t *testing.T) {
+	var mu sync.Mutex
+	var received otlpRequest
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		json.Unmarshal(body, &received)
+		mu.Unlock()
+	}))
+	defer collector.Close()
+
+	exp := Init(Config{Endpoint: collector.URL, ServiceName: "test"})
+	defer func() {
+		globalMu.Lock()
+		global = nil
+		globalMu.Unlock()
+	}()
+
+	ctx, root := Start(context.Background(), "root", KindServer)
+	_, child := Start(ctx, "child", KindInternal)
+	child.SetAttr("file.path", "todos.md")
+	child.End()
+	root.End()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	exp.Shutdown(shutdownCtx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received.ResourceSpans) != 1 {
+		t.Fatalf("expected 1 resource span, got %d", len(received.ResourceSpans))
+	}
+	spans := received.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].ParentSpanID != spans[1].SpanID || spans[0].TraceID != spans[1].TraceID {
+		t.Errorf("child span not linked to root: %+v", spans)
+	}
+}
@@ -0,0 +1,94 @@
+package trends
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// Recorder writes one snapshot a day, at the first check after the
+// configured hour. The history file itself records which days are done, so a
+// restart never double-records.
+type Recorder struct {
+	storage storage.Storage
+	hour    int
+	clock   clock.Clock
+
+	// checkInterval is how often the recorder wakes to see if the daily
+	// snapshot is due.
+	checkInterval time.Duration
+
+	mu      sync.Mutex
+	lastRun string // date of the last successful snapshot
+
+	stopCh chan struct{}
+}
+
+// NewRecorder creates a recorder that snapshots at hour (0-23, UTC). A nil
+// clock uses the system clock.
+func NewRecorder(s storage.Storage, hour int, c clock.Clock) *Recorder {
+	return &Recorder{
+		storage:       s,
+		hour:          hour,
+		clock:         clock.Or(c),
+		checkInterval: 15 * time.Minute,
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// Start begins the daily snapshot loop.
+func (r *Recorder) Start() {
+	go r.loop()
+}
+
+// Stop ends the loop.
+func (r *Recorder) Stop() {
+	close(r.stopCh)
+}
+
+func (r *Recorder) loop() {
+	ticker := time.NewTicker(r.checkInterval)
+	defer ticker.Stop()
+
+	r.tick()
+	for {
+		select {
+		case <-ticker.C:
+			r.tick()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+// tick records today's snapshot if the hour has passed and it isn't done yet.
+func (r *Recorder) tick() {
+	now := r.clock.Now()
+	date := now.Format("2006-01-02")
+
+	r.mu.Lock()
+	done := r.lastRun == date
+	r.mu.Unlock()
+	if done || now.Hour() < r.hour {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	written, err := Record(ctx, r.storage, clock.Today(r.clock))
+	if err != nil {
+		slog.Warn("recording daily snapshot failed", "error", err)
+		return
+	}
+
+	r.mu.Lock()
+	r.lastRun = date
+	r.mu.Unlock()
+	if written {
+		slog.Info("recorded daily snapshot", "date", date, "path", HistoryPath)
+	}
+}
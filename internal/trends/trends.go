@@ -0,0 +1,197 @@
+// Package trends records a daily snapshot of item counts to
+// metrics/history.jsonl in the data repo, so progress over weeks and months
+// can be charted without replaying git history.
+package trends
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// HistoryPath is the snapshot log in the data repo, one JSON object per line.
+const HistoryPath = "metrics/history.jsonl"
+
+// Snapshot is the state of the data files on one day.
+type Snapshot struct {
+	Date       string          `json:"date"`
+	Todos      TodoCounts      `json:"todos"`
+	Reminders  ReminderCounts  `json:"reminders"`
+	Milestones MilestoneCounts `json:"milestones"`
+	Reading    ReadingCounts   `json:"reading"`
+}
+
+// TodoCounts counts todos.
+type TodoCounts struct {
+	Active    int `json:"active"`
+	Urgent    int `json:"urgent"`
+	High      int `json:"high"`
+	Completed int `json:"completed"`
+}
+
+// ReminderCounts counts reminders.
+type ReminderCounts struct {
+	Upcoming  int `json:"upcoming"`
+	Overdue   int `json:"overdue"`
+	Completed int `json:"completed"`
+}
+
+// MilestoneCounts counts milestones.
+type MilestoneCounts struct {
+	Active    int `json:"active"`
+	Overdue   int `json:"overdue"`
+	Completed int `json:"completed"`
+}
+
+// ReadingCounts counts reading list items.
+type ReadingCounts struct {
+	ToRead int `json:"to_read"`
+	Read   int `json:"read"`
+}
+
+// Take counts the current data. Missing files count as empty.
+func Take(ctx context.Context, s storage.Storage, today time.Time) (Snapshot, error) {
+	snap := Snapshot{Date: today.Format("2006-01-02")}
+
+	if content, ok, err := read(ctx, s, storage.TodosFile); err != nil {
+		return snap, err
+	} else if ok {
+		tf, err := storage.ParseTodos(content)
+		if err != nil {
+			return snap, fmt.Errorf("parsing todos: %w", err)
+		}
+		snap.Todos.Active = len(tf.Active)
+		snap.Todos.Completed = len(tf.Completed)
+		for _, t := range tf.Active {
+			switch t.Priority {
+			case storage.PriorityUrgent:
+				snap.Todos.Urgent++
+			case storage.PriorityHigh:
+				snap.Todos.High++
+			}
+		}
+	}
+
+	if content, ok, err := read(ctx, s, storage.RemindersFile); err != nil {
+		return snap, err
+	} else if ok {
+		rf, err := storage.ParseReminders(content)
+		if err != nil {
+			return snap, fmt.Errorf("parsing reminders: %w", err)
+		}
+		snap.Reminders.Upcoming = len(rf.Upcoming)
+		snap.Reminders.Completed = len(rf.Completed)
+		for _, r := range rf.Upcoming {
+			if r.Date.Before(today) {
+				snap.Reminders.Overdue++
+			}
+		}
+	}
+
+	if content, ok, err := read(ctx, s, storage.StrategyFile); err != nil {
+		return snap, err
+	} else if ok {
+		st, err := storage.ParseStrategy(content)
+		if err != nil {
+			return snap, fmt.Errorf("parsing strategy: %w", err)
+		}
+		snap.Milestones.Active = len(st.ActiveMilestones)
+		snap.Milestones.Completed = len(st.CompletedMilestones)
+		for _, m := range st.ActiveMilestones {
+			if m.Due != nil && m.Due.Before(today) {
+				snap.Milestones.Overdue++
+			}
+		}
+	}
+
+	if content, ok, err := read(ctx, s, storage.ReadingListFile); err != nil {
+		return snap, err
+	} else if ok {
+		rl, err := storage.ParseReadingList(content)
+		if err != nil {
+			return snap, fmt.Errorf("parsing reading list: %w", err)
+		}
+		snap.Reading.ToRead = len(rl.ToRead)
+		snap.Reading.Read = len(rl.Read)
+	}
+
+	return snap, nil
+}
+
+func read(ctx context.Context, s storage.Storage, path string) (string, bool, error) {
+	content, _, err := s.ReadFile(ctx, path)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return content, true, nil
+}
+
+// ParseHistory reads the snapshot log, sorted by date with one snapshot per
+// day (the last one written wins). Malformed lines are skipped so a bad hand
+// edit doesn't hide the rest of the history.
+func ParseHistory(content string) []Snapshot {
+	byDate := make(map[string]Snapshot)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var snap Snapshot
+		if err := json.Unmarshal([]byte(line), &snap); err != nil || snap.Date == "" {
+			continue
+		}
+		byDate[snap.Date] = snap
+	}
+
+	history := make([]Snapshot, 0, len(byDate))
+	for _, snap := range byDate {
+		history = append(history, snap)
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].Date < history[j].Date })
+	return history
+}
+
+// Record takes today's snapshot and appends it to the history file, unless
+// today already has one. It reports whether a snapshot was written.
+func Record(ctx context.Context, s storage.Storage, today time.Time) (bool, error) {
+	content, sha, err := s.ReadFile(ctx, HistoryPath)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return false, fmt.Errorf("reading %s: %w", HistoryPath, err)
+	}
+
+	date := today.Format("2006-01-02")
+	for _, snap := range ParseHistory(content) {
+		if snap.Date == date {
+			return false, nil
+		}
+	}
+
+	snap, err := Take(ctx, s, today)
+	if err != nil {
+		return false, err
+	}
+	line, err := json.Marshal(snap)
+	if err != nil {
+		return false, fmt.Errorf("encoding snapshot: %w", err)
+	}
+
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += string(line) + "\n"
+	if err := s.WriteFile(ctx, HistoryPath, content, sha, "Record daily snapshot: "+date); err != nil {
+		return false, fmt.Errorf("writing %s: %w", HistoryPath, err)
+	}
+	return true, nil
+}
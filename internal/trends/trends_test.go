@@ -0,0 +1,74 @@
+package trends
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// historyFile returns the history file in s.
+func historyFile(t *testing.T, s storage.Storage) string {
+	t.Helper()
+	content, _, err := s.ReadFile(context.Background(), HistoryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestRecord(t *testing.T) {
+	fs := storage.NewMemoryStorage(map[string]string{
+		"todos.md":     "## High Priority\n- [ ] Ship {id:aaaa1111}\n\n## Normal Priority\n- [ ] Tidy {id:bbbb2222}\n\n## Completed\n- [x] Done {id:cccc3333}\n",
+		"reminders.md": "## Upcoming\n- 2026-02-01: Late {id:dddd4444}\n- 2026-03-01: Later {id:eeee5555}\n",
+	}, nil)
+	today := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	written, err := Record(context.Background(), fs, today)
+	if err != nil || !written {
+		t.Fatalf("Record() = %v, %v", written, err)
+	}
+	history := ParseHistory(historyFile(t, fs))
+	if len(history) != 1 {
+		t.Fatalf("expected 1 snapshot, got %d", len(history))
+	}
+	snap := history[0]
+	if snap.Date != "2026-02-10" || snap.Todos.Active != 2 || snap.Todos.High != 1 || snap.Todos.Completed != 1 {
+		t.Errorf("unexpected todo counts: %+v", snap)
+	}
+	if snap.Reminders.Upcoming != 2 || snap.Reminders.Overdue != 1 {
+		t.Errorf("unexpected reminder counts: %+v", snap.Reminders)
+	}
+
+	// A second run the same day is a no-op
+	written, err = Record(context.Background(), fs, today)
+	commits, _ := fs.ListCommits(context.Background(), HistoryPath, 0)
+	if err != nil || written || len(commits) != 1 {
+		t.Errorf("expected no second write, got written=%v writes=%d err=%v", written, len(commits), err)
+	}
+
+	// The next day appends
+	if _, err := Record(context.Background(), fs, today.AddDate(0, 0, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(historyFile(t, fs), "\n"); lines != 2 {
+		t.Errorf("expected 2 lines, got %d", lines)
+	}
+}
+
+func TestParseHistory(t *testing.T) {
+	content := `{"date":"2026-02-02","todos":{"active":3}}
+not json
+{"date":"2026-02-01","todos":{"active":1}}
+{"date":"2026-02-02","todos":{"active":5}}
+`
+	history := ParseHistory(content)
+	if len(history) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(history))
+	}
+	if history[0].Date != "2026-02-01" || history[1].Todos.Active != 5 {
+		t.Errorf("expected sorted history with the last snapshot per day, got %+v", history)
+	}
+}
@@ -0,0 +1,141 @@
+package usage
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// maxFailureExamples caps the distinct bad values kept per tool/field.
+	maxFailureExamples = 5
+	// maxExampleLength truncates long example values.
+	maxExampleLength = 80
+)
+
+// FailureStats aggregates validation failures for one tool argument.
+type FailureStats struct {
+	Tool     string    `json:"tool"`
+	Field    string    `json:"field"`
+	Count    int       `json:"count"`
+	Examples []string  `json:"examples"`
+	Hint     string    `json:"hint,omitempty"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// invalidMessage matches tool messages like:
+//
+//	Invalid priority "top". Use: urgent, high, normal, or someday
+//	Invalid date_from format "03/14". Use YYYY-MM-DD.
+var invalidMessage = regexp.MustCompile(`^Invalid ([a-z_]+)(?: format)? "((?:[^"\\]|\\.)*)"\.\s*(.*)$`)
+
+// RecordFailure adds a validation failure for a tool argument.
+func (t *Tracker) RecordFailure(tool, field, value, hint string) {
+	if len(value) > maxExampleLength {
+		value = value[:maxExampleLength] + "..."
+	}
+	key := tool + "|" + field
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f, ok := t.failures[key]
+	if !ok {
+		f = &FailureStats{Tool: tool, Field: field}
+		t.failures[key] = f
+	}
+	f.Count++
+	f.LastSeen = time.Now().UTC()
+	if hint != "" {
+		f.Hint = hint
+	}
+	for _, ex := range f.Examples {
+		if ex == value {
+			return
+		}
+	}
+	if len(f.Examples) < maxFailureExamples {
+		f.Examples = append(f.Examples, value)
+	}
+}
+
+// Feedback returns the most common validation failures, most frequent first.
+// A limit of zero or less returns all of them.
+func (t *Tracker) Feedback(limit int) []FailureStats {
+	t.mu.Lock()
+	result := make([]FailureStats, 0, len(t.failures))
+	for _, f := range t.failures {
+		c := *f
+		c.Examples = append([]string(nil), f.Examples...)
+		result = append(result, c)
+	}
+	t.mu.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		if result[i].Tool != result[j].Tool {
+			return result[i].Tool < result[j].Tool
+		}
+		return result[i].Field < result[j].Field
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// recordValidationFailure inspects a tools/call outcome and records it if it
+// was rejected for bad arguments, either by schema validation in the SDK or
+// by a tool returning an "Invalid <field> ..." message.
+func (t *Tracker) recordValidationFailure(tool string, result mcp.Result, err error) {
+	if err != nil {
+		var wireErr *jsonrpc.Error
+		if errors.As(err, &wireErr) && wireErr.Code == jsonrpc.CodeInvalidParams {
+			t.RecordFailure(tool, "arguments", err.Error(), "Arguments did not match the input schema")
+		}
+		return
+	}
+
+	field, value, hint, ok := parseInvalidMessage(resultMessage(result))
+	if ok {
+		t.RecordFailure(tool, field, value, hint)
+	}
+}
+
+// parseInvalidMessage extracts the field, rejected value, and hint from a
+// tool's "Invalid ..." message.
+func parseInvalidMessage(msg string) (field, value, hint string, ok bool) {
+	m := invalidMessage.FindStringSubmatch(strings.TrimSpace(msg))
+	if m == nil {
+		return "", "", "", false
+	}
+	value = strings.ReplaceAll(m[2], `\"`, `"`)
+	return m[1], value, m[3], true
+}
+
+// resultMessage returns the "message" field from a tool's structured output, if any.
+func resultMessage(result mcp.Result) string {
+	res, ok := result.(*mcp.CallToolResult)
+	if !ok || res == nil || res.StructuredContent == nil {
+		return ""
+	}
+	raw, err := json.Marshal(res.StructuredContent)
+	if err != nil {
+		return ""
+	}
+	var out struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return ""
+	}
+	return out.Message
+}
@@ -0,0 +1,339 @@
+// Package usage tracks per-tool MCP call statistics (counts, errors, latency).
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// retentionDays is how many daily buckets are kept.
+	retentionDays = 30
+	// maxLatencySamples caps the latency samples kept per tool per day.
+	maxLatencySamples = 200
+	dateFormat        = "2006-01-02"
+)
+
+// DayStats holds one tool's statistics for a single day.
+type DayStats struct {
+	Calls     int       `json:"calls"`
+	Errors    int       `json:"errors"`
+	LatencyMs []int64   `json:"latency_ms"`
+	LastCall  time.Time `json:"last_call"`
+}
+
+// ToolUsage is an aggregated view of a tool's usage over a window.
+type ToolUsage struct {
+	Name            string    `json:"name"`
+	Calls           int       `json:"calls"`
+	Errors          int       `json:"errors"`
+	ErrorRate       float64   `json:"error_rate"`
+	MedianLatencyMs int64     `json:"median_latency_ms"`
+	LastCall        time.Time `json:"last_call"`
+}
+
+// Tracker records tool calls in memory and optionally persists them to disk.
+type Tracker struct {
+	mu   sync.Mutex
+	days map[string]map[string]*DayStats // date -> tool -> stats
+
+	// failures aggregates argument validation failures, keyed by "tool|field"
+	failures map[string]*FailureStats
+
+	filePath     string
+	saveInterval time.Duration
+	stopCh       chan struct{}
+}
+
+// NewTracker creates a tracker. If dataDir is empty, stats are kept in memory only.
+func NewTracker(dataDir string) *Tracker {
+	t := &Tracker{
+		days:         make(map[string]map[string]*DayStats),
+		failures:     make(map[string]*FailureStats),
+		saveInterval: time.Minute,
+		stopCh:       make(chan struct{}),
+	}
+	if dataDir != "" {
+		t.filePath = filepath.Join(dataDir, "usage.json")
+	}
+	return t
+}
+
+// Record adds a single tool call to today's bucket.
+func (t *Tracker) Record(tool string, duration time.Duration, failed bool) {
+	now := time.Now().UTC()
+	date := now.Format(dateFormat)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	day, ok := t.days[date]
+	if !ok {
+		day = make(map[string]*DayStats)
+		t.days[date] = day
+		t.pruneLocked(now)
+	}
+	stats, ok := day[tool]
+	if !ok {
+		stats = &DayStats{}
+		day[tool] = stats
+	}
+
+	stats.Calls++
+	if failed {
+		stats.Errors++
+	}
+	stats.LastCall = now
+	if len(stats.LatencyMs) < maxLatencySamples {
+		stats.LatencyMs = append(stats.LatencyMs, duration.Milliseconds())
+	} else {
+		// Overwrite in round-robin so recent calls stay represented
+		stats.LatencyMs[stats.Calls%maxLatencySamples] = duration.Milliseconds()
+	}
+}
+
+// pruneLocked drops buckets older than the retention window. Caller holds mu.
+func (t *Tracker) pruneLocked(now time.Time) {
+	cutoff := now.AddDate(0, 0, -retentionDays).Format(dateFormat)
+	for date := range t.days {
+		if date < cutoff {
+			delete(t.days, date)
+		}
+	}
+}
+
+// Summary aggregates usage over the last n days (including today), sorted by call count.
+func (t *Tracker) Summary(days int) []ToolUsage {
+	if days <= 0 {
+		days = 1
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -(days - 1)).Format(dateFormat)
+
+	t.mu.Lock()
+	totals := make(map[string]*ToolUsage)
+	latencies := make(map[string][]int64)
+	for date, day := range t.days {
+		if date < cutoff {
+			continue
+		}
+		for name, stats := range day {
+			u, ok := totals[name]
+			if !ok {
+				u = &ToolUsage{Name: name}
+				totals[name] = u
+			}
+			u.Calls += stats.Calls
+			u.Errors += stats.Errors
+			if stats.LastCall.After(u.LastCall) {
+				u.LastCall = stats.LastCall
+			}
+			latencies[name] = append(latencies[name], stats.LatencyMs...)
+		}
+	}
+	t.mu.Unlock()
+
+	result := make([]ToolUsage, 0, len(totals))
+	for name, u := range totals {
+		if u.Calls > 0 {
+			u.ErrorRate = float64(u.Errors) / float64(u.Calls)
+		}
+		u.MedianLatencyMs = median(latencies[name])
+		result = append(result, *u)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Calls != result[j].Calls {
+			return result[i].Calls > result[j].Calls
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// median returns the median of the samples, or 0 if there are none.
+func median(samples []int64) int64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := make([]int64, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// Middleware returns MCP receiving middleware that records every tools/call.
+func (t *Tracker) Middleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+
+			start := time.Now()
+			result, err := next(ctx, method, req)
+
+			name := ""
+			if ctr, ok := req.(*mcp.CallToolRequest); ok && ctr.Params != nil {
+				name = ctr.Params.Name
+			}
+			t.Record(name, time.Since(start), err != nil || ToolFailed(result))
+			t.recordValidationFailure(name, result, err)
+
+			return result, err
+		}
+	}
+}
+
+// FailedResult is a tools/call result declining or failing the call with
+// message, shaped like a tool's own failure so clients (and ToolFailed)
+// handle it the same way. Middleware uses it to refuse calls.
+func FailedResult(message string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: message}},
+		StructuredContent: map[string]any{"success": false, "message": message},
+	}
+}
+
+// ToolFailed reports whether a tools/call result represents a failure: either an
+// MCP error result or structured output with "success": false.
+func ToolFailed(result mcp.Result) bool {
+	res, ok := result.(*mcp.CallToolResult)
+	if !ok || res == nil {
+		return false
+	}
+	if res.IsError {
+		return true
+	}
+	if res.StructuredContent == nil {
+		return false
+	}
+
+	raw, err := json.Marshal(res.StructuredContent)
+	if err != nil {
+		return false
+	}
+	var out struct {
+		Success *bool `json:"success"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil || out.Success == nil {
+		return false
+	}
+	return !*out.Success
+}
+
+// persistedUsage is the on-disk format.
+type persistedUsage struct {
+	Days     map[string]map[string]*DayStats `json:"days"`
+	Failures map[string]*FailureStats        `json:"failures,omitempty"`
+	SavedAt  time.Time                       `json:"saved_at"`
+}
+
+// Start loads existing stats and begins periodic saving.
+func (t *Tracker) Start() error {
+	if t.filePath == "" {
+		return nil
+	}
+	if err := t.Load(); err != nil {
+		slog.Warn("could not load usage stats", "error", err)
+	}
+	go t.periodicSave()
+	return nil
+}
+
+// Stop performs a final save and stops periodic saving.
+func (t *Tracker) Stop() {
+	if t.filePath == "" {
+		return
+	}
+	close(t.stopCh)
+	if err := t.Save(); err != nil {
+		slog.Error("saving usage stats failed", "error", err)
+	}
+}
+
+// Load reads persisted stats from disk.
+func (t *Tracker) Load() error {
+	if t.filePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(t.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var persisted persistedUsage
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for date, day := range persisted.Days {
+		if _, exists := t.days[date]; !exists {
+			t.days[date] = day
+		}
+	}
+	for key, f := range persisted.Failures {
+		if _, exists := t.failures[key]; !exists {
+			t.failures[key] = f
+		}
+	}
+	t.pruneLocked(time.Now().UTC())
+	return nil
+}
+
+// Save writes the current stats to disk atomically.
+func (t *Tracker) Save() error {
+	if t.filePath == "" {
+		return nil
+	}
+
+	t.mu.Lock()
+	data, err := json.MarshalIndent(persistedUsage{Days: t.days, Failures: t.failures, SavedAt: time.Now()}, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.filePath), 0700); err != nil {
+		return err
+	}
+	tmpFile := t.filePath + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, t.filePath)
+}
+
+func (t *Tracker) periodicSave() {
+	ticker := time.NewTicker(t.saveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := t.Save(); err != nil {
+				slog.Error("saving usage stats failed", "error", err)
+			}
+		case <-t.stopCh:
+			return
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package usage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		name     string
+		samples  []int64
+		expected int64
+	}{
+		{"empty", nil, 0},
+		{"single", []int64{7}, 7},
+		{"odd", []int64{30, 10, 20}, 20},
+		{"even", []int64{40, 10, 20, 30}, 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := median(tt.samples); got != tt.expected {
+				t.Errorf("median(%v) = %d, expected %d", tt.samples, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTracker_Summary(t *testing.T) {
+	tr := NewTracker("")
+	tr.Record("add_todo", 10*time.Millisecond, false)
+	tr.Record("add_todo", 30*time.Millisecond, true)
+	tr.Record("list_todos", 5*time.Millisecond, false)
+	tr.Record("add_todo", 20*time.Millisecond, false)
+
+	summary := tr.Summary(7)
+	if len(summary) != 2 {
+		t.Fatalf("expected 2 tools, got %d", len(summary))
+	}
+
+	top := summary[0]
+	if top.Name != "add_todo" || top.Calls != 3 || top.Errors != 1 {
+		t.Errorf("unexpected top entry: %+v", top)
+	}
+	if top.MedianLatencyMs != 20 {
+		t.Errorf("expected median 20ms, got %d", top.MedianLatencyMs)
+	}
+}
+
+func TestToolFailed(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   mcp.Result
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"is error", &mcp.CallToolResult{IsError: true}, true},
+		{"success false", &mcp.CallToolResult{StructuredContent: map[string]any{"success": false}}, true},
+		{"success true", &mcp.CallToolResult{StructuredContent: map[string]any{"success": true}}, false},
+		{"no success field", &mcp.CallToolResult{StructuredContent: map[string]any{"message": "pong"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToolFailed(tt.result); got != tt.expected {
+				t.Errorf("ToolFailed() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseInvalidMessage(t *testing.T) {
+	tests := []struct {
+		msg   string
+		field string
+		value string
+		ok    bool
+	}{
+		{`Invalid priority "urgent". Use: high, normal, or someday`, "priority", "urgent", true},
+		{`Invalid date_from format "03/14". Use YYYY-MM-DD.`, "date_from", "03/14", true},
+		{`Invalid date format "next \"week\"". Use YYYY-MM-DD format.`, "date", `next "week"`, true},
+		{"Todo text cannot be empty", "", "", false},
+	}
+
+	for _, tt := range tests {
+		field, value, _, ok := parseInvalidMessage(tt.msg)
+		if ok != tt.ok || field != tt.field || value != tt.value {
+			t.Errorf("parseInvalidMessage(%q) = (%q, %q, %v), expected (%q, %q, %v)",
+				tt.msg, field, value, ok, tt.field, tt.value, tt.ok)
+		}
+	}
+}
+
+func TestTracker_Feedback(t *testing.T) {
+	tr := NewTracker("")
+	tr.RecordFailure("add_todo", "priority", "urgent", "Use: high, normal, or someday")
+	tr.RecordFailure("add_todo", "priority", "urgent", "Use: high, normal, or someday")
+	tr.RecordFailure("add_todo", "priority", "medium", "Use: high, normal, or someday")
+	tr.RecordFailure("set_reminder", "date", "next week", "Use YYYY-MM-DD format.")
+
+	feedback := tr.Feedback(0)
+	if len(feedback) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(feedback))
+	}
+
+	top := feedback[0]
+	if top.Tool != "add_todo" || top.Count != 3 {
+		t.Errorf("unexpected top entry: %+v", top)
+	}
+	if len(top.Examples) != 2 {
+		t.Errorf("expected 2 distinct examples, got %v", top.Examples)
+	}
+
+	if got := tr.Feedback(1); len(got) != 1 {
+		t.Errorf("expected limit to cap results, got %d", len(got))
+	}
+}
@@ -0,0 +1,61 @@
+// Package version reports the build's version. Release builds stamp it at
+// link time:
+//
+//	go build -ldflags "-X github.com/dang-w/momentum-mcp-server/internal/version.Version=1.3.0 \
+//	  -X github.com/dang-w/momentum-mcp-server/internal/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/dang-w/momentum-mcp-server/internal/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Unstamped builds fall back to the VCS information the Go toolchain embeds.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Set via -ldflags -X at build time.
+var (
+	Version   = "0.2.0"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info describes the running build.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build info, filling in the commit and build time from the
+// toolchain's embedded VCS data when they weren't stamped.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+	if info.Commit != "" && info.BuildTime != "" {
+		return info
+	}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if info.Commit == "" && len(s.Value) >= 7 {
+				info.Commit = s.Value[:7]
+			}
+		case "vcs.time":
+			if info.BuildTime == "" {
+				info.BuildTime = s.Value
+			}
+		}
+	}
+	return info
+}
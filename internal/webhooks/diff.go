@@ -0,0 +1,161 @@
+package webhooks
+
+import (
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// Change is an event derived from comparing two versions of a data file.
+type Change struct {
+	Type string
+	Item Item
+}
+
+// snapshot is an item as parsed from one version of a file.
+type snapshot struct {
+	item Item
+	done bool
+	// state captures every field, so any edit shows as a difference.
+	state string
+}
+
+// parsers extract the items of each data file events are emitted for.
+var parsers = map[string]func(content string) ([]snapshot, error){
+	storage.TodosFile:       todoSnapshots,
+	storage.StrategyFile:    milestoneSnapshots,
+	storage.RemindersFile:   reminderSnapshots,
+	storage.ReadingListFile: readingSnapshots,
+}
+
+// tracked reports whether writes to path emit events.
+func tracked(path string) bool {
+	_, ok := parsers[path]
+	return ok
+}
+
+// Diff compares two versions of a data file and returns an event for every
+// item that was created, completed, reopened, updated or deleted, in file
+// order. Reading items are "read" rather than "completed". Content that
+// fails to parse yields no events.
+func Diff(path, old, new string) []Change {
+	parse, ok := parsers[path]
+	if !ok {
+		return nil
+	}
+	var before []snapshot
+	if old != "" {
+		var err error
+		if before, err = parse(old); err != nil {
+			return nil
+		}
+	}
+	after, err := parse(new)
+	if err != nil {
+		return nil
+	}
+
+	prev := make(map[string]snapshot, len(before))
+	for _, s := range before {
+		prev[s.item.ID] = s
+	}
+
+	var changes []Change
+	seen := make(map[string]bool, len(after))
+	for _, s := range after {
+		if s.item.ID == "" {
+			continue
+		}
+		seen[s.item.ID] = true
+		p, existed := prev[s.item.ID]
+		var action string
+		switch {
+		case !existed:
+			action = "created"
+		case s.done && !p.done:
+			action = "completed"
+			if s.item.Kind == "reading" {
+				action = "read"
+			}
+		case !s.done && p.done:
+			action = "reopened"
+		case s.state != p.state:
+			action = "updated"
+		default:
+			continue
+		}
+		changes = append(changes, Change{Type: s.item.Kind + "." + action, Item: s.item})
+	}
+	for _, s := range before {
+		if s.item.ID != "" && !seen[s.item.ID] {
+			changes = append(changes, Change{Type: s.item.Kind + ".deleted", Item: s.item})
+		}
+	}
+	return changes
+}
+
+func todoSnapshots(content string) ([]snapshot, error) {
+	tf, err := storage.ParseTodos(content)
+	if err != nil {
+		return nil, err
+	}
+	var out []snapshot
+	for _, t := range append(tf.Active, tf.Completed...) {
+		out = append(out, snapshot{
+			item:  Item{ID: t.ID, Kind: "todo", Text: t.Text, Project: t.Project},
+			done:  t.Completed,
+			state: storage.SerializeTodos(&storage.TodoFile{Active: []storage.Todo{t}}),
+		})
+	}
+	return out, nil
+}
+
+func milestoneSnapshots(content string) ([]snapshot, error) {
+	s, err := storage.ParseStrategy(content)
+	if err != nil {
+		return nil, err
+	}
+	var out []snapshot
+	for _, m := range append(s.ActiveMilestones, s.CompletedMilestones...) {
+		item := Item{ID: m.ID, Kind: "milestone", Text: m.Text, Project: m.Project}
+		if m.Due != nil {
+			item.Date = m.Due.Format("2006-01-02")
+		}
+		out = append(out, snapshot{
+			item:  item,
+			done:  m.Completed,
+			state: storage.SerializeStrategy(&storage.Strategy{ActiveMilestones: []storage.Milestone{m}}),
+		})
+	}
+	return out, nil
+}
+
+func reminderSnapshots(content string) ([]snapshot, error) {
+	rf, err := storage.ParseReminders(content)
+	if err != nil {
+		return nil, err
+	}
+	var out []snapshot
+	for _, r := range append(rf.Upcoming, rf.Completed...) {
+		out = append(out, snapshot{
+			item:  Item{ID: r.ID, Kind: "reminder", Text: r.Text, Date: r.Date.Format("2006-01-02")},
+			done:  r.Completed,
+			state: storage.SerializeReminders(&storage.ReminderFile{Upcoming: []storage.Reminder{r}}),
+		})
+	}
+	return out, nil
+}
+
+func readingSnapshots(content string) ([]snapshot, error) {
+	rl, err := storage.ParseReadingList(content)
+	if err != nil {
+		return nil, err
+	}
+	var out []snapshot
+	for _, r := range append(rl.ToRead, rl.Read...) {
+		out = append(out, snapshot{
+			item:  Item{ID: r.ID, Kind: "reading", Text: r.URL},
+			done:  r.Read,
+			state: storage.SerializeReadingList(&storage.ReadingList{ToRead: []storage.ReadingItem{r}}),
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,109 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// maxPushBody bounds the push payloads read. GitHub caps deliveries at
+// 25 MB, but a push to the data repo is far smaller.
+const maxPushBody = 5 << 20
+
+// GitHubReceiver serves the endpoint a data repo webhook posts push events
+// to, so edits made outside the server (on github.com, or a local clone)
+// are noticed straight away.
+type GitHubReceiver struct {
+	secret string
+	paths  storage.Paths
+	// onChange is called with the logical names of the data files a push
+	// to the default branch touched.
+	onChange func(ctx context.Context, names []string)
+}
+
+// NewGitHubReceiver creates a GitHubReceiver. Deliveries are verified
+// against the webhook's secret; repo paths are mapped back to data file
+// names through paths.
+func NewGitHubReceiver(secret string, paths storage.Paths, onChange func(ctx context.Context, names []string)) *GitHubReceiver {
+	return &GitHubReceiver{secret: secret, paths: paths, onChange: onChange}
+}
+
+// pushEvent is the part of a GitHub push payload used here.
+type pushEvent struct {
+	Ref        string `json:"ref"`
+	Repository struct {
+		DefaultBranch string `json:"default_branch"`
+	} `json:"repository"`
+	Commits []struct {
+		Added    []string `json:"added"`
+		Modified []string `json:"modified"`
+		Removed  []string `json:"removed"`
+	} `json:"commits"`
+}
+
+// ServeHTTP handles a webhook delivery.
+func (g *GitHubReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPushBody))
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusBadRequest)
+		return
+	}
+	sig := r.Header.Get("X-Hub-Signature-256")
+	if sig == "" || !hmac.Equal([]byte(Sign(g.secret, body)), []byte(sig)) {
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	// Anything but a push (including the ping sent when the hook is
+	// created) is acknowledged and ignored
+	if r.Header.Get("X-GitHub-Event") != "push" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var push pushEvent
+	if err := json.Unmarshal(body, &push); err != nil {
+		http.Error(w, "Invalid push payload", http.StatusBadRequest)
+		return
+	}
+
+	changed := g.changedFiles(push)
+	if len(changed) > 0 {
+		slog.Info("data files changed on GitHub", "files", changed)
+		g.onChange(r.Context(), changed)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string][]string{"changed": changed})
+}
+
+// changedFiles returns the data files a push to the default branch touched,
+// each once. Pushes to other branches change nothing the server reads.
+func (g *GitHubReceiver) changedFiles(push pushEvent) []string {
+	changed := []string{}
+	if push.Ref != "refs/heads/"+push.Repository.DefaultBranch {
+		return changed
+	}
+	seen := map[string]bool{}
+	for _, c := range push.Commits {
+		for _, paths := range [][]string{c.Added, c.Modified, c.Removed} {
+			for _, p := range paths {
+				if name, ok := g.paths.Name(p); ok && !seen[name] {
+					seen[name] = true
+					changed = append(changed, name)
+				}
+			}
+		}
+	}
+	return changed
+}
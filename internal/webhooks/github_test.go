@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestGitHubReceiver(t *testing.T) {
+	paths, _ := storage.ParsePaths("momentum", "")
+	var got []string
+	calls := 0
+	receiver := NewGitHubReceiver("secret", paths, func(ctx context.Context, names []string) {
+		calls++
+		got = names
+	})
+
+	deliver := func(event, body, sig string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/github", strings.NewReader(body))
+		req.Header.Set("X-GitHub-Event", event)
+		if sig != "" {
+			req.Header.Set("X-Hub-Signature-256", sig)
+		}
+		rec := httptest.NewRecorder()
+		receiver.ServeHTTP(rec, req)
+		return rec
+	}
+
+	push := `{"ref":"refs/heads/main","repository":{"default_branch":"main"},"commits":[` +
+		`{"modified":["momentum/todos.md","README.md"]},` +
+		`{"added":["momentum/notes.md"],"modified":["momentum/todos.md"],"removed":["todos.md"]}]}`
+
+	if rec := deliver("push", push, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("unsigned push: status %d, want 401", rec.Code)
+	}
+	if rec := deliver("push", push, Sign("wrong", []byte(push))); rec.Code != http.StatusUnauthorized {
+		t.Errorf("badly signed push: status %d, want 401", rec.Code)
+	}
+	if calls != 0 {
+		t.Fatalf("onChange called %d times for rejected deliveries", calls)
+	}
+
+	if rec := deliver("ping", `{"zen":"hi"}`, Sign("secret", []byte(`{"zen":"hi"}`))); rec.Code != http.StatusNoContent {
+		t.Errorf("ping: status %d, want 204", rec.Code)
+	}
+
+	rec := deliver("push", push, Sign("secret", []byte(push)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("push: status %d: %s", rec.Code, rec.Body)
+	}
+	if calls != 1 || strings.Join(got, ",") != "todos.md,notes.md" {
+		t.Errorf("onChange(%v) after %d calls, want [todos.md notes.md] once", got, calls)
+	}
+
+	other := strings.Replace(push, "refs/heads/main", "refs/heads/draft", 1)
+	deliver("push", other, Sign("secret", []byte(other)))
+	if calls != 1 {
+		t.Error("expected a push to another branch to be ignored")
+	}
+}
@@ -0,0 +1,102 @@
+package webhooks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// emittingStorage decorates a Storage so that each successful write emits
+// events for the items it added, completed, changed or removed.
+type emittingStorage struct {
+	next       storage.Storage
+	dispatcher *Dispatcher
+
+	// last holds the content of each file as last read, so the previous
+	// version is usually known without another read before a write.
+	mu   sync.Mutex
+	last map[string]readFile
+}
+
+type readFile struct {
+	sha     string
+	content string
+}
+
+// WrapStorage returns a Storage that emits events through d on writes.
+func WrapStorage(s storage.Storage, d *Dispatcher) storage.Storage {
+	return &emittingStorage{next: s, dispatcher: d, last: map[string]readFile{}}
+}
+
+func (e *emittingStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	content, sha, err := e.next.ReadFile(ctx, path)
+	if err == nil && tracked(path) {
+		e.mu.Lock()
+		e.last[path] = readFile{sha: sha, content: content}
+		e.mu.Unlock()
+	}
+	return content, sha, err
+}
+
+func (e *emittingStorage) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	old, known := e.previous(ctx, path, sha)
+	if err := e.next.WriteFile(ctx, path, content, sha, message); err != nil {
+		return err
+	}
+	if known {
+		e.emit(path, old, content)
+	}
+	return nil
+}
+
+func (e *emittingStorage) WriteFiles(ctx context.Context, changes []storage.FileChange, message string) error {
+	type before struct {
+		content string
+		known   bool
+	}
+	olds := make([]before, len(changes))
+	for i, c := range changes {
+		olds[i].content, olds[i].known = e.previous(ctx, c.Path, c.SHA)
+	}
+	if err := storage.WriteFiles(ctx, e.next, changes, message); err != nil {
+		return err
+	}
+	for i, c := range changes {
+		if olds[i].known {
+			e.emit(c.Path, olds[i].content, c.Content)
+		}
+	}
+	return nil
+}
+
+// previous returns the content path had at sha, and false if it can't be
+// known (or the file isn't one events are emitted for).
+func (e *emittingStorage) previous(ctx context.Context, path, sha string) (string, bool) {
+	if !tracked(path) {
+		return "", false
+	}
+	if sha == "" {
+		return "", true // new file
+	}
+	e.mu.Lock()
+	last, ok := e.last[path]
+	e.mu.Unlock()
+	if ok && last.sha == sha {
+		return last.content, true
+	}
+	content, current, err := e.next.ReadFile(ctx, path)
+	if err != nil || current != sha {
+		return "", false
+	}
+	return content, true
+}
+
+func (e *emittingStorage) emit(path, old, new string) {
+	e.mu.Lock()
+	delete(e.last, path)
+	e.mu.Unlock()
+	for _, c := range Diff(path, old, new) {
+		e.dispatcher.Emit(c.Type, c.Item)
+	}
+}
@@ -0,0 +1,219 @@
+// Package webhooks posts signed JSON events to configured endpoints whenever
+// a data file changes, e.g. todo.completed when a todo is checked off, and
+// receives the data repo's GitHub push events.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body, keyed
+// with the shared secret, as "sha256=<hex>".
+const SignatureHeader = "X-Momentum-Signature"
+
+// EventHeader carries the event type, so receivers can route without
+// parsing the body.
+const EventHeader = "X-Momentum-Event"
+
+// Config configures outbound webhooks.
+type Config struct {
+	// URLs receive every event.
+	URLs []string
+	// Secret signs each request body. Optional - unsigned if empty.
+	Secret string
+	// Events limits delivery to these types. A trailing ".*" matches a whole
+	// kind, e.g. "todo.*". Empty means all events.
+	Events []string
+}
+
+// Enabled reports whether any endpoint is configured.
+func (c Config) Enabled() bool {
+	return len(c.URLs) > 0
+}
+
+// Event is one change to an item.
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"` // <kind>.<action>, e.g. todo.completed
+	CreatedAt time.Time `json:"created_at"`
+	Data      Item      `json:"data"`
+}
+
+// Item is the changed item as of the event.
+type Item struct {
+	ID      string `json:"id"`
+	Kind    string `json:"kind"` // todo, milestone, reminder, or reading
+	Text    string `json:"text"` // the URL for reading items
+	Project string `json:"project,omitempty"`
+	Date    string `json:"date,omitempty"` // due date for milestones, date for reminders
+}
+
+// maxAttempts bounds deliveries of one event to one endpoint.
+const maxAttempts = 3
+
+// queueSize bounds events waiting for delivery; beyond it events are dropped
+// with a warning rather than blocking tool calls.
+const queueSize = 256
+
+// Dispatcher delivers events in the background, in order.
+type Dispatcher struct {
+	cfg    Config
+	client *http.Client
+	clock  clock.Clock
+
+	// retryDelay is the wait before the first retry; it doubles each attempt.
+	retryDelay time.Duration
+
+	mu      sync.Mutex
+	stopped bool
+	queue   chan Event
+	done    chan struct{}
+}
+
+// NewDispatcher creates a Dispatcher. A nil clock uses the system clock.
+func NewDispatcher(cfg Config, c clock.Clock) *Dispatcher {
+	return &Dispatcher{
+		cfg:        cfg,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		clock:      clock.Or(c),
+		retryDelay: time.Second,
+		queue:      make(chan Event, queueSize),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start begins delivering queued events.
+func (d *Dispatcher) Start() {
+	go d.loop()
+}
+
+// Stop delivers the events already queued and returns. Events emitted
+// afterwards are dropped.
+func (d *Dispatcher) Stop() {
+	d.mu.Lock()
+	if !d.stopped {
+		d.stopped = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+	<-d.done
+}
+
+// Emit queues an event of type typ for item, unless it is filtered out.
+func (d *Dispatcher) Emit(typ string, item Item) {
+	if !d.wants(typ) {
+		return
+	}
+	event := Event{ID: newEventID(), Type: typ, CreatedAt: d.clock.Now().UTC(), Data: item}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stopped {
+		return
+	}
+	select {
+	case d.queue <- event:
+	default:
+		slog.Warn("webhook queue full, dropping event", "type", typ, "item", item.ID)
+	}
+}
+
+// wants reports whether typ passes the configured event filter.
+func (d *Dispatcher) wants(typ string) bool {
+	if len(d.cfg.Events) == 0 {
+		return true
+	}
+	for _, e := range d.cfg.Events {
+		if e == typ || strings.HasSuffix(e, ".*") && strings.HasPrefix(typ, strings.TrimSuffix(e, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Dispatcher) loop() {
+	defer close(d.done)
+	for event := range d.queue {
+		body, err := json.Marshal(event)
+		if err != nil {
+			slog.Warn("encoding webhook event failed", "type", event.Type, "error", err)
+			continue
+		}
+		for _, url := range d.cfg.URLs {
+			if err := d.deliver(url, event.Type, body); err != nil {
+				slog.Warn("webhook delivery failed", "url", url, "type", event.Type, "error", err)
+			}
+		}
+	}
+}
+
+// deliver posts body to url, retrying with backoff on errors and non-2xx
+// responses.
+func (d *Dispatcher) deliver(url, typ string, body []byte) error {
+	delay := d.retryDelay
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err = d.post(url, typ, body); err == nil {
+			return nil
+		}
+		if attempt < maxAttempts {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+	return err
+}
+
+func (d *Dispatcher) post(url, typ string, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, typ)
+	if d.cfg.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(d.cfg.Secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("posting event: status %d: %s", resp.StatusCode, msg)
+	}
+	return nil
+}
+
+// Sign returns the SignatureHeader value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newEventID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return "evt_" + hex.EncodeToString(b)
+}
@@ -0,0 +1,85 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestDiff(t *testing.T) {
+	old := "# Active Todos\n\n## Normal\n- [ ] Ship it {id:aaaa1111}\n- [ ] Write docs {id:bbbb2222}\n- [ ] Drop me {id:cccc3333}\n\n# Completed\n"
+	new := "# Active Todos\n\n## High Priority\n- [ ] Write docs {id:bbbb2222}\n\n## Normal\n- [ ] Blog post {id:dddd4444}\n\n# Completed\n- [x] Ship it {id:aaaa1111,completed:2026-02-05}\n"
+
+	got := map[string]string{}
+	for _, c := range Diff(storage.TodosFile, old, new) {
+		got[c.Item.ID] = c.Type
+	}
+	want := map[string]string{
+		"aaaa1111": "todo.completed",
+		"bbbb2222": "todo.updated",
+		"cccc3333": "todo.deleted",
+		"dddd4444": "todo.created",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Diff() = %v, want %v", got, want)
+	}
+	for id, typ := range want {
+		if got[id] != typ {
+			t.Errorf("event for %s = %q, want %q", id, got[id], typ)
+		}
+	}
+
+	if changes := Diff(storage.ReadingListFile, "## To Read\n- [ ] https://a.example {id:eeee5555}\n\n## Read\n",
+		"## To Read\n\n## Read\n- [x] https://a.example {id:eeee5555}\n"); len(changes) != 1 || changes[0].Type != "reading.read" {
+		t.Errorf("unexpected reading changes %+v", changes)
+	}
+}
+
+func TestDispatcher_SignedDelivery(t *testing.T) {
+	var mu sync.Mutex
+	var received []Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if got := r.Header.Get(SignatureHeader); got != Sign("secret", body) {
+			t.Errorf("signature = %q, want %q", got, Sign("secret", body))
+		}
+		var e Event
+		if err := json.Unmarshal(body, &e); err != nil {
+			t.Errorf("decoding event: %v", err)
+		}
+		if r.Header.Get(EventHeader) != e.Type {
+			t.Errorf("event header = %q, want %q", r.Header.Get(EventHeader), e.Type)
+		}
+		mu.Lock()
+		received = append(received, e)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	d := NewDispatcher(Config{URLs: []string{srv.URL}, Secret: "secret", Events: []string{"todo.*", "reminder.created"}}, nil)
+	d.Start()
+	files := storage.NewMemoryStorage(map[string]string{storage.TodosFile: "# Active Todos\n\n## Normal\n- [ ] Ship it {id:aaaa1111}\n\n# Completed\n"}, nil)
+	s := WrapStorage(files, d)
+
+	ctx := context.Background()
+	_, sha, _ := s.ReadFile(ctx, storage.TodosFile)
+	if err := s.WriteFile(ctx, storage.TodosFile, "# Active Todos\n\n# Completed\n- [x] Ship it {id:aaaa1111,completed:2026-02-05}\n", sha, "Complete todo"); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	// Filtered out
+	d.Emit("reminder.deleted", Item{ID: "ffff0000", Kind: "reminder"})
+	d.Stop()
+
+	if len(received) != 1 || received[0].Type != "todo.completed" || received[0].Data.ID != "aaaa1111" || received[0].Data.Text != "Ship it" {
+		t.Errorf("received %+v, want one todo.completed event", received)
+	}
+
+	// Emitting after Stop is dropped rather than panicking
+	d.Emit("todo.created", Item{ID: "bbbb2222", Kind: "todo"})
+}
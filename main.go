@@ -2,55 +2,460 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
+	// Embedded zoneinfo, so time zones work in a scratch container
+	_ "time/tzdata"
 
+	"github.com/dang-w/momentum-mcp-server/internal/alerts"
+	"github.com/dang-w/momentum-mcp-server/internal/analytics"
+	"github.com/dang-w/momentum-mcp-server/internal/api"
+	"github.com/dang-w/momentum-mcp-server/internal/attribution"
 	"github.com/dang-w/momentum-mcp-server/internal/auth"
+	"github.com/dang-w/momentum-mcp-server/internal/backup"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
 	"github.com/dang-w/momentum-mcp-server/internal/config"
+	"github.com/dang-w/momentum-mcp-server/internal/confirm"
+	"github.com/dang-w/momentum-mcp-server/internal/deadline"
+	"github.com/dang-w/momentum-mcp-server/internal/devdata"
+	"github.com/dang-w/momentum-mcp-server/internal/integrations"
+	"github.com/dang-w/momentum-mcp-server/internal/logging"
+	"github.com/dang-w/momentum-mcp-server/internal/notify"
+	"github.com/dang-w/momentum-mcp-server/internal/readonly"
+	"github.com/dang-w/momentum-mcp-server/internal/sessions"
+	"github.com/dang-w/momentum-mcp-server/internal/tracing"
+	"github.com/dang-w/momentum-mcp-server/internal/trends"
+	"github.com/dang-w/momentum-mcp-server/internal/usage"
+	"github.com/dang-w/momentum-mcp-server/internal/version"
+	"github.com/dang-w/momentum-mcp-server/internal/webhooks"
 	"github.com/dang-w/momentum-mcp-server/server"
 	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/dang-w/momentum-mcp-server/tools"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import-reading-list" {
+		os.Exit(runImportReadingList(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "backup" {
+		os.Exit(runBackup(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "restore-backup" {
+		os.Exit(runRestoreBackup(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "init" {
+		os.Exit(runInit(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && cliCommands[os.Args[1]] {
+		os.Exit(runCLI(os.Args[1:]))
+	}
+
+	showVersion := flag.Bool("version", false, "print version information and exit")
+	healthcheck := flag.Bool("healthcheck", false, "probe the local /health endpoint and exit non-zero if unhealthy (for container HEALTHCHECK)")
+	flag.Parse()
+
+	if *showVersion {
+		info := version.Get()
+		fmt.Printf("momentum-mcp-server %s (commit %s, built %s, %s)\n", info.Version, orUnknown(info.Commit), orUnknown(info.BuildTime), info.GoVersion)
+		return
+	}
+	if *healthcheck {
+		os.Exit(runHealthcheck())
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		slog.Error("failed to load config", "error", err)
+		os.Exit(1)
 	}
 
-	// Create GitHub storage
-	ghStorage, err := storage.NewGitHubStorage(cfg.GitHubToken, cfg.GitHubRepo)
-	if err != nil {
-		log.Fatalf("Failed to create storage: %v", err)
+	// Structured JSON logs at the configured level
+	logging.Setup(cfg.LogLevel)
+
+	// Optional OTLP trace export (no-op unless an endpoint is configured)
+	tracer := tracing.Init(tracing.Config{
+		Endpoint:    cfg.OTLPEndpoint,
+		Headers:     tracing.ParseHeaders(cfg.OTLPHeaders),
+		ServiceName: cfg.ServiceName,
+	})
+	if tracer != nil {
+		slog.Info("tracing enabled", "endpoint", cfg.OTLPEndpoint)
+	}
+
+	// The server clock; FAKE_NOW pins it to a fixed starting instant
+	var clk clock.Clock = clock.Real{}
+	if !cfg.FakeNow.IsZero() {
+		clk = clock.StartingAt(cfg.FakeNow)
+		slog.Warn("FAKE_NOW set, server clock is not the real time", "start", cfg.FakeNow.Format(time.RFC3339))
+	}
+	// Dates are computed in the user's timezone
+	clk = clock.InLocation(clk, cfg.Location)
+
+	// Create GitHub storage, a bucket, a SQLite database, or in dev mode an
+	// in-memory repo of sample data
+	var ghStorage *storage.GitHubStorage
+	var objectStorage *storage.ObjectStorage
+	var sqliteStorage *storage.SQLiteStorage
+	var sqliteExporter *storage.SQLiteExporter
+	var history storage.History
+	var repoStorage storage.Storage
+	var pullRequests storage.PullRequests
+	var writeQueue *storage.WriteQueue
+	if cfg.DevMode {
+		files, err := devdata.Files(clk.Now())
+		if err != nil {
+			slog.Error("failed to generate sample data", "error", err)
+			os.Exit(1)
+		}
+		repoStorage = storage.NewMemoryStorage(files, clk)
+		// Only the built-in dev token is shown; a configured one is a secret
+		authToken := "from AUTH_TOKEN"
+		if cfg.AuthToken == config.DevAuthToken {
+			authToken = config.DevAuthToken
+		}
+		slog.Warn("DEV_MODE set, serving in-memory sample data; changes are lost on exit", "auth_token", authToken)
+	} else if cfg.StorageBackend == config.StorageSQLite {
+		// Files come from the repo on first use, and changes go back to it
+		// in the background
+		var remote storage.Storage
+		if cfg.GitHubRepo != "" {
+			ghStorage, err = storage.NewGitHubStorage(cfg.GitHubToken, cfg.GitHubRepo)
+			if err != nil {
+				slog.Error("failed to create storage", "error", err)
+				os.Exit(1)
+			}
+			if cfg.CommitAuthorName != "" {
+				ghStorage.SetCommitter(cfg.CommitAuthorName, cfg.CommitAuthorEmail)
+			}
+			ghStorage.SetClock(clk)
+			remote = storage.WithPaths(ghStorage, cfg.DataPaths)
+			history = remote.(storage.History)
+		}
+		sqliteStorage, err = storage.NewSQLiteStorage(cfg.SQLitePath, remote)
+		if err != nil {
+			slog.Error("failed to create storage", "error", err)
+			os.Exit(1)
+		}
+		repoStorage = sqliteStorage
+		if remote != nil {
+			sqliteExporter = storage.NewSQLiteExporter(sqliteStorage, remote, cfg.SQLiteExportInterval)
+			sqliteExporter.Start()
+			slog.Info("data files stored in SQLite, exported to the repo", "path", cfg.SQLitePath, "interval", cfg.SQLiteExportInterval)
+		} else {
+			slog.Info("data files stored in SQLite", "path", cfg.SQLitePath)
+		}
+	} else if cfg.StorageBackend != config.StorageGitHub {
+		objectStorage, err = storage.NewObjectStorage(cfg.Object)
+		if err != nil {
+			slog.Error("failed to create storage", "error", err)
+			os.Exit(1)
+		}
+		repoStorage = storage.WithPaths(objectStorage, cfg.DataPaths)
+		slog.Info("data files stored in a bucket, without commit history", "backend", cfg.StorageBackend, "bucket", cfg.Object.Bucket)
+	} else {
+		ghStorage, err = storage.NewGitHubStorage(cfg.GitHubToken, cfg.GitHubRepo)
+		if err != nil {
+			slog.Error("failed to create storage", "error", err)
+			os.Exit(1)
+		}
+		ghStorage.SetClock(clk)
+
+		// Commit as a dedicated identity rather than the token's user
+		if cfg.CommitAuthorName != "" {
+			ghStorage.SetCommitter(cfg.CommitAuthorName, cfg.CommitAuthorEmail)
+		}
+
+		// Open pull requests for changes to reviewed files instead of
+		// committing them
+		var reviewed storage.Storage = ghStorage
+		if len(cfg.ReviewFiles) > 0 {
+			paths := make([]string, len(cfg.ReviewFiles))
+			for i, name := range cfg.ReviewFiles {
+				paths[i] = name
+				if name != "*" {
+					paths[i] = cfg.DataPaths.Resolve(name)
+				}
+			}
+			reviewed = storage.WithReview(ghStorage, ghStorage, paths)
+			pullRequests = ghStorage
+			slog.Info("changes to reviewed files are opened as pull requests", "files", paths)
+		}
+
+		// Save writes locally while GitHub is unavailable, and sync them
+		// when it's back
+		if cfg.OfflineQueue {
+			writeQueue = storage.NewWriteQueue(reviewed, cfg.DataDir, clk)
+			if err := writeQueue.Start(); err != nil {
+				slog.Warn("offline write queue failed to load", "error", err)
+			}
+			if cfg.DataDir == "" {
+				slog.Warn("OFFLINE_QUEUE set without DATA_DIR, queued writes are lost on restart")
+			}
+			reviewed = writeQueue
+		}
+
+		// Map data file names to their configured locations in the repo
+		repoStorage = storage.WithPaths(reviewed, cfg.DataPaths)
+		if !cfg.DataPaths.IsDefault() {
+			slog.Info("custom data file paths", "prefix", cfg.DataPaths.Prefix, "overrides", len(cfg.DataPaths.Overrides))
+		}
+	}
+
+	// Read-only mode refuses every write to the data repo, from tools and
+	// background jobs alike; it can be switched at /admin/read-only
+	readOnly := readonly.New(cfg.ReadOnly, cfg.ReadOnlyTools)
+	repoStorage = readonly.WrapStorage(repoStorage, readOnly)
+	if cfg.ReadOnly {
+		slog.Warn("READ_ONLY set, data can't be changed")
+	}
+
+	// Item history and the analytics backfill read the repo's commits: a
+	// bucket has none, and SQLite has its export repo's
+	if objectStorage == nil && sqliteStorage == nil {
+		history = repoStorage.(storage.History)
+	}
+
+	// In events mode, every write is also logged as a line diff, committed
+	// with the markdown file it changes. The markdown files stay the source
+	// of truth; the log records history for undo_last_change
+	dataStorage := repoStorage
+	var eventStore *storage.EventStore
+	if cfg.StorageMode == "events" {
+		eventStore = storage.NewEventStore(repoStorage, cfg.EventLogPath, clk)
+		dataStorage = eventStore
+		slog.Info("event-sourced storage enabled", "log", cfg.EventLogPath)
+	}
+
+	// Say which tool and client made each change in its commit message
+	if cfg.CommitMessageTemplate != "" {
+		dataStorage = attribution.WrapStorage(dataStorage, cfg.CommitMessageTemplate)
+	}
+
+	// Outbound webhooks: every change to an item is posted as an event
+	var webhookDispatcher *webhooks.Dispatcher
+	webhookConfig := webhooks.Config{
+		URLs:   cfg.EventWebhookURLs,
+		Secret: cfg.EventWebhookSecret,
+		Events: cfg.EventWebhookEvents,
+	}
+	if webhookConfig.Enabled() {
+		webhookDispatcher = webhooks.NewDispatcher(webhookConfig, clk)
+		webhookDispatcher.Start()
+		dataStorage = webhooks.WrapStorage(dataStorage, webhookDispatcher)
+		slog.Info("outbound webhooks enabled", "endpoints", len(webhookConfig.URLs))
+	}
+
+	// Reuse recent reads, so the summary and dashboard share one fetch per file
+	readCache := storage.WithReadCache(dataStorage, cfg.ReadCacheTTL, clk)
+	dataStorage = readCache
+
+	// Take turns on each data file, so concurrent tool calls and background
+	// jobs in this process never conflict over it
+	dataStorage = storage.WithPathLocks(dataStorage, storage.NewPathLocks())
+
+	// Create any missing data files so a fresh repo works without setup
+	if cfg.InitDataFiles {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.RequestTimeout)
+		result, err := tools.InitDataFiles(ctx, dataStorage)
+		cancel()
+		if err != nil {
+			slog.Warn("data file initialization failed", "error", err)
+		} else if len(result.Created) > 0 {
+			slog.Info("created missing data files", "files", result.Created)
+		}
 	}
 
 	// Create OAuth token and client stores
-	tokenStore := auth.NewTokenStore(cfg.OAuthAccessTokenTTL, cfg.OAuthRefreshTokenTTL)
+	tokenStore := auth.NewTokenStore(cfg.OAuthAccessTokenTTL, cfg.OAuthRefreshTokenTTL, clk)
 	clientStore := auth.NewClientStore()
 
 	// Set up persistence for OAuth state (survives restarts)
 	persistence := auth.NewPersistence(cfg.DataDir, tokenStore, clientStore)
+	persistence.SetEncryptionKeys(cfg.OAuthStateKey, cfg.OAuthStateOldKeys...)
 	if err := persistence.Start(); err != nil {
-		log.Printf("Warning: persistence failed to start: %v", err)
+		slog.Error("oauth persistence failed to start; clients and tokens are kept in memory only", "error", err)
+	}
+
+	// Track tool usage analytics (persisted daily alongside OAuth state)
+	usageTracker := usage.NewTracker(cfg.DataDir)
+	if err := usageTracker.Start(); err != nil {
+		slog.Warn("usage tracking failed to start", "error", err)
+	}
+
+	// Reconstruct historic completion counts from the data repo (runs once, cached)
+	var backfill *analytics.Backfill
+	if cfg.AnalyticsBackfill && history != nil {
+		backfill = analytics.NewBackfill(history, cfg.DataDir, cfg.AnalyticsBackfillMaxCommits, clk)
+		go func() {
+			if err := backfill.Run(context.Background()); err != nil {
+				slog.Warn("analytics backfill failed", "error", err)
+			}
+		}()
+	}
+
+	// Daily due/overdue reminder notifications, if a channel is configured
+	var notifiers []notify.Notifier
+	if cfg.NotifyWebhookURL != "" {
+		notifiers = append(notifiers, notify.NewWebhookNotifier(cfg.NotifyWebhookURL))
+	}
+	if cfg.SMTPHost != "" {
+		notifiers = append(notifiers, notify.NewSMTPNotifier(notify.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.NotifyEmailFrom,
+			To:       strings.Split(cfg.NotifyEmailTo, ","),
+		}))
+	}
+	var scheduler *notify.Scheduler
+	if len(notifiers) > 0 {
+		scheduler = notify.NewScheduler(dataStorage, notifiers, cfg.NotifyHour, cfg.DataDir, clk)
+		scheduler.Start()
+		slog.Info("reminder notifications enabled", "hour", cfg.NotifyHour, "channels", len(notifiers))
+	}
+
+	// Daily trend snapshots
+	var trendRecorder *trends.Recorder
+	if cfg.TrendsEnabled {
+		trendRecorder = trends.NewRecorder(dataStorage, cfg.TrendsHour, clk)
+		trendRecorder.Start()
+		slog.Info("daily trend snapshots enabled", "hour", cfg.TrendsHour, "path", trends.HistoryPath)
+	}
+
+	// Scheduled backups of the data files and OAuth state, read straight
+	// from the repo (in events mode, the event log too). The OAuth state is
+	// only backed up encrypted, so it needs OAUTH_STATE_KEY
+	var backupJob *backup.Job
+	if cfg.BackupSchedule != nil && !cfg.DevMode {
+		store, err := openBackupStore(cfg)
+		if err != nil {
+			slog.Error("failed to open the backup destination", "error", err)
+			os.Exit(1)
+		}
+		backupJob = backup.NewJob(repoStorage, eventLogPath(cfg), oauthStatePath(cfg), store, cfg.BackupSchedule, clk)
+		backupJob.Start()
+		slog.Info("scheduled backups enabled", "schedule", cfg.BackupSchedule.String(), "keep", cfg.BackupKeep)
+		if len(cfg.OAuthStateKey) == 0 {
+			slog.Warn("OAUTH_STATE_KEY not set, backups leave out the OAuth state")
+		}
+	}
+
+	// Optional Google Calendar integration
+	var calendar *integrations.Calendar
+	calendarConfig := integrations.CalendarConfig{
+		ClientID:     cfg.GoogleCalendarClientID,
+		ClientSecret: cfg.GoogleCalendarClientSecret,
+		RefreshToken: cfg.GoogleCalendarRefreshToken,
+		CalendarID:   cfg.GoogleCalendarID,
+		CacheTTL:     cfg.GoogleCalendarCacheTTL,
+	}
+	if calendarConfig.Enabled() {
+		calendar = integrations.NewCalendar(calendarConfig, clk)
+		slog.Info("google calendar integration enabled")
+	}
+
+	// Optional WakaTime integration
+	var wakatime *integrations.WakaTime
+	wakatimeConfig := integrations.WakaTimeConfig{
+		APIKey:   cfg.WakaTimeAPIKey,
+		CacheTTL: cfg.WakaTimeCacheTTL,
+	}
+	if wakatimeConfig.Enabled() {
+		wakatime = integrations.NewWakaTime(wakatimeConfig, clk)
+		slog.Info("wakatime integration enabled")
+	}
+
+	// Optional GitHub Issues mirror for milestones
+	var milestoneIssues tools.MilestoneIssues
+	if cfg.MilestoneIssuesRepo != "" {
+		gi, err := integrations.NewGitHubIssues(cfg.GitHubToken, cfg.MilestoneIssuesRepo, cfg.MilestoneIssuesLabels)
+		if err != nil {
+			slog.Error("invalid MILESTONE_ISSUES_REPO", "error", err)
+			os.Exit(1)
+		}
+		milestoneIssues = gi
+		slog.Info("milestone issue sync enabled", "repo", cfg.MilestoneIssuesRepo)
+	}
+
+	// Per-request deadlines, propagated from HTTP requests into tool calls
+	deadlines := deadline.New(cfg.RequestTimeout)
+
+	// Confirmation for destructive tools (deletes and bulk changes)
+	confirmPolicy := confirm.New(cfg.ConfirmMode, cfg.ConfirmTools, clk)
+	if confirmPolicy.Enabled() {
+		slog.Info("destructive tool confirmation enabled", "tools", confirmPolicy.Guarded())
+	}
+
+	// Overdue reminders and milestones due soon, piggybacked on tool results
+	var toolAlerts *alerts.Checker
+	if cfg.ToolAlerts {
+		toolAlerts = alerts.New(dataStorage, clk, cfg.ToolAlertsWindow, cfg.ToolAlertsSkip)
+		slog.Info("tool alerts enabled", "window", cfg.ToolAlertsWindow, "skip", cfg.ToolAlertsSkip)
 	}
 
 	// Create MCP server with storage and GitHub activity config
 	mcpServer := server.New(server.Config{
-		Storage:        ghStorage,
-		GitHubToken:    cfg.GitHubToken,
-		GitHubUsername: cfg.GitHubUsername(),
+		Storage:                tracing.WrapStorage(logging.WrapStorage(dataStorage)),
+		GitHubToken:            cfg.GitHubToken,
+		GitHubUsername:         cfg.GitHubUsername(),
+		GitHubActivityCacheTTL: cfg.GitHubActivityCacheTTL,
+		Usage:                  usageTracker,
+		Backfill:               backfill,
+		History:                history,
+		Events:                 eventStore,
+		Deadline:               deadlines,
+		Confirm:                confirmPolicy,
+		Alerts:                 toolAlerts,
+		PullRequests:           pullRequests,
+		WriteQueue:             writeQueue,
+		ReadOnly:               readOnly,
+		Calendar:               calendar,
+		WakaTime:               wakatime,
+		MilestoneIssues:        milestoneIssues,
+		Clock:                  clk,
+		SizeQuota: tools.SizeQuota{
+			FileWarnBytes:  cfg.DataFileWarnBytes,
+			TotalWarnBytes: cfg.DataTotalWarnBytes,
+		},
+		WorkloadLimits: tools.WorkloadLimits{
+			MaxHighPriority: cfg.WorkloadMaxHighPriority,
+			MaxDueSoon:      cfg.WorkloadMaxDueSoon,
+			DueSoonDays:     cfg.WorkloadDueSoonDays,
+		},
+		StaleThresholds: tools.StaleThresholds{
+			TodoDays:             cfg.StaleTodoDays,
+			ReadingWeeks:         cfg.StaleReadingWeeks,
+			MilestoneOverdueDays: cfg.StaleMilestoneOverdueDays,
+		},
+		WIPLimits: tools.WIPLimits{
+			Max:    cfg.WIPLimits,
+			Refuse: cfg.WIPLimitRefuse,
+		},
 	})
 
-	// Create the streamable HTTP handler for MCP
+	// Create the streamable HTTP handler for MCP, with an event store so
+	// dropped SSE streams can resume with Last-Event-ID
+	mcpOptions := &mcp.StreamableHTTPOptions{SessionTimeout: cfg.MCPSessionTimeout}
+	if cfg.MCPEventStoreBytes > 0 {
+		mcpEventStore := mcp.NewMemoryEventStore(nil)
+		mcpEventStore.SetMaxBytes(cfg.MCPEventStoreBytes)
+		mcpOptions.EventStore = mcpEventStore
+	}
 	mcpHandler := mcp.NewStreamableHTTPHandler(func(req *http.Request) *mcp.Server {
 		return mcpServer
-	}, nil)
+	}, mcpOptions)
+	sessionTracker := sessions.NewTracker(mcpServer, clk)
 
 	// Determine base URL for OAuth metadata
 	baseURL := cfg.BaseURL
@@ -58,12 +463,26 @@ func main() {
 		baseURL = fmt.Sprintf("http://localhost:%s", cfg.Port)
 	}
 
+	// Record client negotiations for the /compat-report diagnostic
+	compatConfig := auth.CompatConfig{
+		RootEndpoint:                cfg.CompatRootEndpoint,
+		AllowRefreshWithoutClientID: cfg.CompatRefreshWithoutClientID,
+		EchoResource:                cfg.CompatEchoResource,
+	}
+	compatRecorder := auth.NewCompatRecorder(cfg.CompatReportSize, compatConfig)
+
 	// Create OAuth server
 	oauthServer := auth.NewOAuthServer(auth.OAuthConfig{
 		TokenStore:   tokenStore,
 		ClientStore:  clientStore,
 		BaseURL:      baseURL,
 		AuthorizePin: cfg.OAuthAuthorizePin,
+		Compat:       compatConfig,
+		Recorder:     compatRecorder,
+		Clock:        clk,
+
+		TrustedProxies:    cfg.TrustedProxies,
+		RegistrationToken: cfg.AuthToken,
 	})
 
 	// Create rate limiter for token endpoint (10 requests per minute per IP)
@@ -73,9 +492,13 @@ func main() {
 	mux := http.NewServeMux()
 
 	// Health check endpoint (no auth required)
+	buildInfo := version.Get()
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("ok"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Status string `json:"status"`
+			version.Info
+		}{"ok", buildInfo})
 	})
 
 	// OAuth metadata endpoints (no auth required - used for discovery)
@@ -85,39 +508,134 @@ func main() {
 	// OAuth flow endpoints (no auth required - these establish auth)
 	mux.HandleFunc("/authorize", oauthServer.Authorize)
 	// Token endpoint with rate limiting to prevent brute force
-	mux.Handle("/token", auth.RateLimitMiddleware(tokenRateLimiter)(http.HandlerFunc(oauthServer.Token)))
+	mux.Handle("/token", auth.RateLimitMiddleware(tokenRateLimiter, cfg.TrustedProxies)(http.HandlerFunc(oauthServer.Token)))
 	mux.HandleFunc("/register", oauthServer.Register)
 
 	// Create unified auth middleware that accepts both static and OAuth tokens
 	authMiddleware := auth.Middleware(auth.MiddlewareConfig{
 		Validator: auth.NewMultiValidator(
 			auth.NewStaticTokenValidator(cfg.AuthToken),
-			auth.NewOAuthTokenValidator(tokenStore),
+			auth.NewOAuthTokenValidator(tokenStore, oauthServer.Audience()),
 		),
 		ResourceMetadataURL: baseURL + "/.well-known/oauth-protected-resource",
 	})
 
+	// Client compatibility diagnostic (auth required - exposes client metadata)
+	mux.Handle("/compat-report", authMiddleware(http.HandlerFunc(compatRecorder.ReportHandler)))
+
+	// Recent OAuth events and PIN lockouts (auth required)
+	mux.Handle("/admin/auth-events", authMiddleware(http.HandlerFunc(oauthServer.AuthEventsHandler)))
+
+	// Read-only mode status and runtime switch (auth required)
+	mux.Handle("/admin/read-only", authMiddleware(readOnly.Handler()))
+
+	// Live MCP sessions and their activity (auth required)
+	mux.Handle("/admin/sessions", authMiddleware(sessionTracker.Handler()))
+
+	// Data export for backups (auth required): /export?format=json|csv
+	exporter := tools.NewExportTools(dataStorage, clk)
+	mux.Handle("/export", authMiddleware(http.HandlerFunc(exporter.ExportHandler)))
+
 	// MCP endpoint (auth required)
 	// The MCP SDK handler handles both GET and POST for the streamable HTTP transport
-	// Serve at both /mcp (explicit) and / (for Claude.ai custom connectors that use base URL)
-	mux.Handle("/mcp", authMiddleware(mcpHandler))
-	mux.Handle("/", authMiddleware(mcpHandler))
+	// Serve at /mcp (explicit) and optionally / (for Claude.ai custom connectors that use base URL)
+	compatMiddleware := auth.CompatEndpointMiddleware(compatRecorder)
+	mux.Handle("/mcp", authMiddleware(compatMiddleware(sessionTracker.Middleware(mcpHandler))))
+	if cfg.CompatRootEndpoint {
+		mux.Handle("/", authMiddleware(compatMiddleware(sessionTracker.Middleware(mcpHandler))))
+	}
+
+	// Data repo push webhook (verified by the webhook secret, not bearer auth)
+	if cfg.GitHubWebhookSecret != "" && ghStorage != nil {
+		mux.Handle("/webhooks/github", webhooks.NewGitHubReceiver(cfg.GitHubWebhookSecret, cfg.DataPaths, func(ctx context.Context, names []string) {
+			for _, name := range names {
+				ghStorage.Invalidate(cfg.DataPaths.Resolve(name))
+			}
+			readCache.Invalidate(names...)
+			server.FilesChanged(ctx, mcpServer, names)
+		}))
+		slog.Info("github webhook enabled", "endpoint", baseURL+"/webhooks/github")
+	}
+
+	// Chat integrations, the background jobs, the status page and the REST API call tools through an in-process MCP session
+	var telegramBot *integrations.TelegramBot
+	var feedPoller *integrations.FeedPoller
+	var briefingJob *integrations.BriefingJob
+	var readingArchiver *integrations.ReadingArchiver
+	if cfg.SlackSigningSecret != "" || cfg.TelegramBotToken != "" || cfg.FeedsInterval > 0 || cfg.StatusPage || cfg.APIEnabled || cfg.BriefingSchedule != nil || cfg.ReadingArchiveMonths > 0 {
+		session, err := server.ConnectInProcess(context.Background(), mcpServer, "chat-bridge")
+		if err != nil {
+			slog.Error("failed to start chat integrations", "error", err)
+			os.Exit(1)
+		}
+
+		// Slack slash command bridge (verified by Slack's signing secret, not bearer auth)
+		if cfg.SlackSigningSecret != "" {
+			mux.Handle("/integrations/slack", integrations.NewSlackBridge(cfg.SlackSigningSecret, session, clk))
+			slog.Info("slack integration enabled", "endpoint", baseURL+"/integrations/slack")
+		}
+
+		// JSON REST API (auth required), backed by the same tools
+		if cfg.APIEnabled {
+			mux.Handle(api.Prefix+"/", authMiddleware(api.New(session).Handler()))
+			slog.Info("rest api enabled", "endpoint", baseURL+api.Prefix)
+		}
+
+		// Read-only status page (its own viewer token, not bearer auth)
+		if cfg.StatusPage {
+			mux.Handle("/status", integrations.NewStatusPage(session, cfg.StatusPageToken, clk))
+			slog.Info("status page enabled", "endpoint", baseURL+"/status", "token_required", cfg.StatusPageToken != "")
+		}
+
+		// Telegram quick-capture bot (long polling, no inbound endpoint)
+		if cfg.TelegramBotToken != "" {
+			telegramBot = integrations.NewTelegramBot(cfg.TelegramBotToken, cfg.TelegramChatID, session)
+			telegramBot.Start()
+			slog.Info("telegram integration enabled", "chat_id", cfg.TelegramChatID)
+		}
+
+		// Background RSS/Atom fetching into the reading list
+		if cfg.FeedsInterval > 0 {
+			feedPoller = integrations.NewFeedPoller(session, cfg.FeedsInterval, cfg.FeedsMaxPerFeed)
+			feedPoller.Start()
+			slog.Info("feed fetching enabled", "interval", cfg.FeedsInterval, "max_per_feed", cfg.FeedsMaxPerFeed)
+		}
+
+		// Daily archival of old read items out of the reading list
+		if cfg.ReadingArchiveMonths > 0 {
+			readingArchiver = integrations.NewReadingArchiver(session, cfg.ReadingArchiveMonths)
+			readingArchiver.Start()
+			slog.Info("reading list archival enabled", "older_than_months", cfg.ReadingArchiveMonths)
+		}
+
+		// Scheduled morning briefing, sent to the notification channels
+		if cfg.BriefingSchedule != nil {
+			briefingJob = integrations.NewBriefingJob(session, cfg.BriefingSchedule, notifiers, clk)
+			briefingJob.Start()
+			slog.Info("scheduled briefing enabled", "schedule", cfg.BriefingSchedule.String(), "channels", len(notifiers))
+		}
+	}
 
 	// Create HTTP server
 	httpServer := &http.Server{
 		Addr:    ":" + cfg.Port,
-		Handler: mux,
+		Handler: logging.RequestIDMiddleware(tracing.HTTPMiddleware(deadlines.HTTPMiddleware(mux))),
 	}
 
 	// Start server in a goroutine
 	go func() {
-		log.Printf("Momentum MCP server starting on port %s", cfg.Port)
-		log.Printf("Health check: %s/health", baseURL)
-		log.Printf("MCP endpoint: %s/mcp", baseURL)
-		log.Printf("OAuth metadata: %s/.well-known/oauth-authorization-server", baseURL)
+		slog.Info("momentum MCP server starting",
+			"version", buildInfo.Version,
+			"commit", buildInfo.Commit,
+			"port", cfg.Port,
+			"health", baseURL+"/health",
+			"mcp", baseURL+"/mcp",
+			"oauth_metadata", baseURL+"/.well-known/oauth-authorization-server",
+		)
 
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server failed: %v", err)
+			slog.Error("server failed", "error", err)
+			os.Exit(1)
 		}
 	}()
 
@@ -126,18 +644,86 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	slog.Info("shutting down server")
 
-	// Save OAuth state before shutdown
+	// Save OAuth state and usage stats before shutdown
 	persistence.Stop()
+	usageTracker.Stop()
+	if scheduler != nil {
+		scheduler.Stop()
+	}
+	if trendRecorder != nil {
+		trendRecorder.Stop()
+	}
+	if backupJob != nil {
+		backupJob.Stop()
+	}
+	if telegramBot != nil {
+		telegramBot.Stop()
+	}
+	if feedPoller != nil {
+		feedPoller.Stop()
+	}
+	if briefingJob != nil {
+		briefingJob.Stop()
+	}
+	if readingArchiver != nil {
+		readingArchiver.Stop()
+	}
+	if webhookDispatcher != nil {
+		webhookDispatcher.Stop()
+	}
+	if writeQueue != nil {
+		writeQueue.Stop()
+	}
 
 	// Give outstanding requests 5 seconds to complete
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := httpServer.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		slog.Error("server forced to shutdown", "error", err)
+		os.Exit(1)
+	}
+
+	// Export the last changes once requests are done
+	if sqliteExporter != nil {
+		sqliteExporter.Stop()
 	}
+	if sqliteStorage != nil {
+		sqliteStorage.Close()
+	}
+
+	// Flush any buffered spans
+	tracer.Shutdown(ctx)
+
+	slog.Info("server stopped")
+}
 
-	log.Println("Server stopped")
+// runHealthcheck probes the server's /health endpoint on localhost. Scratch
+// containers have no curl or wget, so the binary checks itself.
+func runHealthcheck() int {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	client := &http.Client{Timeout: 3 * time.Second}
+	resp, err := client.Get("http://127.0.0.1:" + port + "/health")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "healthcheck failed:", err)
+		return 1
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		fmt.Fprintln(os.Stderr, "healthcheck failed: status", resp.StatusCode)
+		return 1
+	}
+	return 0
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
 }
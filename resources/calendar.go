@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/integrations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CalendarResource exposes today's and this week's calendar events.
+type CalendarResource struct {
+	calendar *integrations.Calendar
+	clock    clock.Clock
+}
+
+// NewCalendarResource creates a new CalendarResource. A nil clock uses the
+// system clock.
+func NewCalendarResource(cal *integrations.Calendar, c clock.Clock) *CalendarResource {
+	return &CalendarResource{calendar: cal, clock: clock.Or(c)}
+}
+
+// calendarView is the JSON payload for momentum://calendar.
+type calendarView struct {
+	Today    []integrations.CalendarEvent `json:"today"`
+	ThisWeek []integrations.CalendarEvent `json:"this_week"`
+}
+
+// Register registers the momentum://calendar resource with the MCP server.
+func (r *CalendarResource) Register(server *mcp.Server) {
+	server.AddResource(&mcp.Resource{
+		URI:         "momentum://calendar",
+		Name:        "Calendar",
+		Description: "Today's and this week's Google Calendar events, for planning todos around meetings",
+		MIMEType:    "application/json",
+	}, r.Read)
+}
+
+// Read fetches this week's events and splits out today's.
+func (r *CalendarResource) Read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	today := clock.Today(r.clock)
+	events, err := r.calendar.Week(ctx, startOfWeek(today))
+	if err != nil {
+		return nil, fmt.Errorf("fetching calendar events: %w", err)
+	}
+
+	view := calendarView{
+		Today:    []integrations.CalendarEvent{},
+		ThisWeek: events,
+	}
+	tomorrow := today.AddDate(0, 0, 1)
+	for _, e := range events {
+		// Include events that overlap today, such as multi-day all-day events
+		if e.Start.Before(tomorrow) && e.End.After(today) {
+			view.Today = append(view.Today, e)
+		}
+	}
+
+	data, err := json.MarshalIndent(view, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("serializing calendar: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      "momentum://calendar",
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
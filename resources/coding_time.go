@@ -0,0 +1,70 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/integrations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CodingTimeResource exposes coding time tracked by WakaTime.
+type CodingTimeResource struct {
+	wakatime *integrations.WakaTime
+	clock    clock.Clock
+}
+
+// NewCodingTimeResource creates a new CodingTimeResource. A nil clock uses
+// the system clock.
+func NewCodingTimeResource(wt *integrations.WakaTime, c clock.Clock) *CodingTimeResource {
+	return &CodingTimeResource{wakatime: wt, clock: clock.Or(c)}
+}
+
+// codingTimeView is the JSON payload for momentum://coding-time.
+type codingTimeView struct {
+	TodayHours float64                  `json:"today_hours"`
+	ThisWeek   *integrations.CodingWeek `json:"this_week"`
+}
+
+// Register registers the momentum://coding-time resource with the MCP server.
+func (r *CodingTimeResource) Register(server *mcp.Server) {
+	server.AddResource(&mcp.Resource{
+		URI:         "momentum://coding-time",
+		Name:        "Coding Time",
+		Description: "Daily coding hours this week from WakaTime, with a per-project breakdown",
+		MIMEType:    "application/json",
+	}, r.Read)
+}
+
+// Read fetches this week's coding time and picks out today's.
+func (r *CodingTimeResource) Read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	today := clock.Today(r.clock)
+	week, err := r.wakatime.Week(ctx, startOfWeek(today))
+	if err != nil {
+		return nil, fmt.Errorf("fetching coding time: %w", err)
+	}
+
+	view := codingTimeView{ThisWeek: week}
+	for _, day := range week.Days {
+		if day.Date == today.Format("2006-01-02") {
+			view.TodayHours = day.Hours
+		}
+	}
+
+	data, err := json.MarshalIndent(view, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("serializing coding time: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      "momentum://coding-time",
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
@@ -12,6 +12,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -20,12 +22,16 @@ type GitHubActivityResource struct {
 	token    string
 	username string
 	client   *http.Client
+	storage  storage.Storage
+
+	clock    clock.Clock
 
 	// Cache
 	mu          sync.RWMutex
 	cachedData  *GitHubActivity
 	cachedAt    time.Time
 	cacheTTL    time.Duration
+	cachedWeeks map[string]*GitHubWeek
 }
 
 // GitHubActivity represents the GitHub activity data returned by this resource.
@@ -36,18 +42,93 @@ type GitHubActivity struct {
 	LastCommit        time.Time `json:"last_commit"`
 	PublicRepos       []string  `json:"public_repos"`
 	PrivateReposCount int       `json:"private_repos_count"`
+
+	// ContributedToday is set once today has a contribution, keeping the
+	// streak going.
+	ContributedToday bool `json:"contributed_today"`
+
+	// Week activity, Monday to Sunday of the current week. Commit counts per
+	// repo cover public repos only; private repo commits are totalled.
+	PullRequestsOpened int           `json:"pull_requests_opened"`
+	PullRequestsMerged int           `json:"pull_requests_merged"`
+	IssuesClosed       int           `json:"issues_closed"`
+	CommitsByRepo      []RepoCommits `json:"commits_by_repo"`
+	PrivateRepoCommits int           `json:"private_repo_commits"`
+
+	// ActiveDaysThisWeek is the number of days this week with at least one
+	// contribution.
+	ActiveDaysThisWeek int `json:"active_days_this_week"`
+
+	// CachedAt is when this data was fetched from GitHub.
+	CachedAt time.Time `json:"cached_at"`
+
+	// Goal is progress against the contribution goal in goals.md, if set.
+	Goal *ContributionProgress `json:"goal,omitempty"`
+}
+
+// ContributionProgress reports this week's progress against the weekly
+// contribution goal. Only the parts of the goal that are set are reported.
+type ContributionProgress struct {
+	Commits    *GoalProgress `json:"commits,omitempty"`
+	ActiveDays *GoalProgress `json:"active_days,omitempty"`
 }
 
+// GoalProgress is progress towards one weekly target.
+type GoalProgress struct {
+	Current int `json:"current"`
+	Target  int `json:"target"`
+	// Status is "met", "on pace" (at least the target's share of the week
+	// so far), or "behind".
+	Status string `json:"status"`
+	// Summary reads like "12/20 commits, on pace".
+	Summary string `json:"summary"`
+}
+
+// RepoCommits is the number of commits made to one repository this week.
+type RepoCommits struct {
+	Repo    string `json:"repo"`
+	Commits int    `json:"commits"`
+}
+
+// GitHubWeek is the GitHub activity in one past week, Monday to Sunday.
+// Commit counts per repo cover public repos only; private repo commits are
+// totalled.
+type GitHubWeek struct {
+	Commits            int           `json:"commits"`
+	ReposActive        int           `json:"repos_active"`
+	PullRequestsOpened int           `json:"pull_requests_opened"`
+	PullRequestsMerged int           `json:"pull_requests_merged"`
+	IssuesClosed       int           `json:"issues_closed"`
+	CommitsByRepo      []RepoCommits `json:"commits_by_repo"`
+	PrivateRepoCommits int           `json:"private_repo_commits"`
+
+	// CachedAt is when this data was fetched from GitHub.
+	CachedAt time.Time `json:"cached_at"`
+}
+
+// DefaultGitHubActivityCacheTTL is how long fetched activity is reused when
+// no TTL is configured.
+const DefaultGitHubActivityCacheTTL = 15 * time.Minute
+
 // NewGitHubActivityResource creates a new GitHubActivityResource.
-// username should be the GitHub username to fetch activity for.
-func NewGitHubActivityResource(token, username string) *GitHubActivityResource {
+// username should be the GitHub username to fetch activity for. The
+// contribution goal is read from goals.md in s; a nil s reports no goal. A
+// zero cacheTTL uses DefaultGitHubActivityCacheTTL and a nil clock uses the
+// system clock.
+func NewGitHubActivityResource(token, username string, s storage.Storage, cacheTTL time.Duration, c clock.Clock) *GitHubActivityResource {
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultGitHubActivityCacheTTL
+	}
 	return &GitHubActivityResource{
 		token:    token,
 		username: username,
+		storage:  s,
+		clock:    clock.Or(c),
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		cacheTTL: 15 * time.Minute,
+		cacheTTL:    cacheTTL,
+		cachedWeeks: map[string]*GitHubWeek{},
 	}
 }
 
@@ -56,7 +137,7 @@ func (r *GitHubActivityResource) Register(server *mcp.Server) {
 	server.AddResource(&mcp.Resource{
 		URI:         "momentum://github-activity",
 		Name:        "GitHub Activity",
-		Description: "Recent GitHub contribution activity including commits, streaks, and active repos",
+		Description: "Recent GitHub contribution activity including commits per repo, pull requests, closed issues, streaks, and active repos",
 		MIMEType:    "application/json",
 	}, r.Read)
 }
@@ -67,6 +148,7 @@ func (r *GitHubActivityResource) Read(ctx context.Context, req *mcp.ReadResource
 	if err != nil {
 		return nil, fmt.Errorf("fetching GitHub activity: %w", err)
 	}
+	activity = r.withGoal(ctx, activity)
 
 	// Serialize to JSON
 	data, err := json.MarshalIndent(activity, "", "  ")
@@ -89,7 +171,7 @@ func (r *GitHubActivityResource) Read(ctx context.Context, req *mcp.ReadResource
 func (r *GitHubActivityResource) getActivity(ctx context.Context) (*GitHubActivity, error) {
 	// Check cache first
 	r.mu.RLock()
-	if r.cachedData != nil && time.Since(r.cachedAt) < r.cacheTTL {
+	if r.cachedData != nil && r.clock.Now().Sub(r.cachedAt) < r.cacheTTL {
 		cached := r.cachedData
 		r.mu.RUnlock()
 		return cached, nil
@@ -97,7 +179,7 @@ func (r *GitHubActivityResource) getActivity(ctx context.Context) (*GitHubActivi
 	r.mu.RUnlock()
 
 	// Fetch fresh data
-	activity, err := r.fetchActivity(ctx)
+	activity, err := r.refresh(ctx)
 	if err != nil {
 		// If fetch fails but we have stale data, return it
 		r.mu.RLock()
@@ -110,15 +192,123 @@ func (r *GitHubActivityResource) getActivity(ctx context.Context) (*GitHubActivi
 		return nil, err
 	}
 
+	return activity, nil
+}
+
+// Streak returns the current contribution streak in days, and whether
+// today has a contribution yet.
+func (r *GitHubActivityResource) Streak(ctx context.Context) (int, bool, error) {
+	activity, err := r.getActivity(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	return activity.StreakDays, activity.ContributedToday, nil
+}
+
+// Refresh fetches activity from GitHub regardless of the cache's age and
+// returns when it was fetched. On failure the cache is left as it was.
+func (r *GitHubActivityResource) Refresh(ctx context.Context) (time.Time, error) {
+	activity, err := r.refresh(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return activity.CachedAt, nil
+}
+
+// refresh fetches activity from GitHub and caches it.
+func (r *GitHubActivityResource) refresh(ctx context.Context) (*GitHubActivity, error) {
+	activity, err := r.fetchActivity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update cache
 	r.mu.Lock()
+	activity.CachedAt = r.clock.Now()
 	r.cachedData = activity
-	r.cachedAt = time.Now()
+	r.cachedAt = activity.CachedAt
 	r.mu.Unlock()
 
 	return activity, nil
 }
 
+// week returns the activity in the week starting at weekStart, fetching it
+// from GitHub unless a cached copy is fresh. As with getActivity, stale data
+// is returned if a refresh fails.
+func (r *GitHubActivityResource) week(ctx context.Context, weekStart time.Time) (*GitHubWeek, error) {
+	key := weekStart.Format("2006-01-02")
+	r.mu.RLock()
+	cached := r.cachedWeeks[key]
+	r.mu.RUnlock()
+	if cached != nil && r.clock.Now().Sub(cached.CachedAt) < r.cacheTTL {
+		return cached, nil
+	}
+
+	week, err := r.fetchWeek(ctx, weekStart)
+	if err != nil {
+		if cached != nil {
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	r.mu.Lock()
+	week.CachedAt = r.clock.Now()
+	r.cachedWeeks[key] = week
+	r.mu.Unlock()
+	return week, nil
+}
+
+// withGoal returns a copy of activity with progress against the contribution
+// goal filled in. The cached activity itself is never modified. If goals.md
+// is missing, unreadable or sets no goal, activity is returned unchanged.
+func (r *GitHubActivityResource) withGoal(ctx context.Context, activity *GitHubActivity) *GitHubActivity {
+	if r.storage == nil {
+		return activity
+	}
+	content, _, err := r.storage.ReadFile(ctx, storage.GoalsFile)
+	if err != nil {
+		return activity
+	}
+	goals, err := storage.ParseGoals(content)
+	if err != nil || goals.Contribution.IsZero() {
+		return activity
+	}
+
+	withGoal := *activity
+	withGoal.Goal = contributionProgress(goals.Contribution, activity, r.clock.Now())
+	return &withGoal
+}
+
+// contributionProgress compares this week's activity with goal. Being on
+// pace means having at least the target's share of the days elapsed so far,
+// counting today.
+func contributionProgress(goal storage.ContributionGoal, activity *GitHubActivity, now time.Time) *ContributionProgress {
+	elapsed := int(clock.Date(now).Sub(startOfWeek(now)).Hours()/24) + 1
+	progress := func(current, target int, noun string) *GoalProgress {
+		p := &GoalProgress{Current: current, Target: target}
+		switch {
+		case current >= target:
+			p.Status = "met"
+		case current*7 >= target*elapsed:
+			p.Status = "on pace"
+		default:
+			p.Status = "behind"
+		}
+		p.Summary = fmt.Sprintf("%d/%d %s, %s", current, target, noun, p.Status)
+		return p
+	}
+
+	cp := &ContributionProgress{}
+	if goal.WeeklyCommits > 0 {
+		cp.Commits = progress(activity.CommitsThisWeek, goal.WeeklyCommits, "commits")
+	}
+	if goal.WeeklyActiveDays > 0 {
+		cp.ActiveDays = progress(activity.ActiveDaysThisWeek, goal.WeeklyActiveDays, "active days")
+	}
+	return cp
+}
+
 // graphQLRequest is the request body for GitHub GraphQL API.
 type graphQLRequest struct {
 	Query     string                 `json:"query"`
@@ -136,14 +326,38 @@ type graphQLError struct {
 }
 
 type graphQLData struct {
-	User *graphQLUser `json:"user"`
+	User         *graphQLUser `json:"user"`
+	PRsMerged    *searchCount `json:"prsMerged"`
+	IssuesClosed *searchCount `json:"issuesClosed"`
 }
 
 type graphQLUser struct {
 	ContributionsCollection *contributionsCollection `json:"contributionsCollection"`
+	Week                    *weekContributions       `json:"week"`
 	Repositories            *repositoriesConnection  `json:"repositories"`
 }
 
+type searchCount struct {
+	IssueCount int `json:"issueCount"`
+}
+
+// weekContributions is the contributions collection for a single week.
+type weekContributions struct {
+	TotalCommitContributions        int                 `json:"totalCommitContributions"`
+	TotalPullRequestContributions   int                 `json:"totalPullRequestContributions"`
+	CommitContributionsByRepository []repoContributions `json:"commitContributionsByRepository"`
+}
+
+type repoContributions struct {
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+		IsPrivate     bool   `json:"isPrivate"`
+	} `json:"repository"`
+	Contributions struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"contributions"`
+}
+
 type contributionsCollection struct {
 	ContributionCalendar *contributionCalendar `json:"contributionCalendar"`
 }
@@ -175,8 +389,20 @@ type repositoryNode struct {
 // fetchActivity fetches contribution data from GitHub GraphQL API.
 func (r *GitHubActivityResource) fetchActivity(ctx context.Context) (*GitHubActivity, error) {
 	query := `
-query($username: String!) {
+query($username: String!, $from: DateTime!, $to: DateTime!, $prsMerged: String!, $issuesClosed: String!) {
   user(login: $username) {
+    week: contributionsCollection(from: $from, to: $to) {
+      totalPullRequestContributions
+      commitContributionsByRepository(maxRepositories: 25) {
+        repository {
+          nameWithOwner
+          isPrivate
+        }
+        contributions {
+          totalCount
+        }
+      }
+    }
     contributionsCollection {
       contributionCalendar {
         totalContributions
@@ -196,16 +422,104 @@ query($username: String!) {
       }
     }
   }
+  prsMerged: search(query: $prsMerged, type: ISSUE) {
+    issueCount
+  }
+  issuesClosed: search(query: $issuesClosed, type: ISSUE) {
+    issueCount
+  }
 }
 `
 
-	reqBody := graphQLRequest{
-		Query: query,
-		Variables: map[string]interface{}{
-			"username": r.username,
-		},
+	data, err := r.query(ctx, query, r.weekVariables(startOfWeek(r.clock.Now())))
+	if err != nil {
+		return nil, err
 	}
 
+	activity, err := r.parseActivity(data.User)
+	if err != nil {
+		return nil, err
+	}
+	if data.PRsMerged != nil {
+		activity.PullRequestsMerged = data.PRsMerged.IssueCount
+	}
+	if data.IssuesClosed != nil {
+		activity.IssuesClosed = data.IssuesClosed.IssueCount
+	}
+	return activity, nil
+}
+
+// fetchWeek fetches the commits, pull requests and closed issues in the week
+// starting at weekStart.
+func (r *GitHubActivityResource) fetchWeek(ctx context.Context, weekStart time.Time) (*GitHubWeek, error) {
+	query := `
+query($username: String!, $from: DateTime!, $to: DateTime!, $prsMerged: String!, $issuesClosed: String!) {
+  user(login: $username) {
+    week: contributionsCollection(from: $from, to: $to) {
+      totalCommitContributions
+      totalPullRequestContributions
+      commitContributionsByRepository(maxRepositories: 25) {
+        repository {
+          nameWithOwner
+          isPrivate
+        }
+        contributions {
+          totalCount
+        }
+      }
+    }
+  }
+  prsMerged: search(query: $prsMerged, type: ISSUE) {
+    issueCount
+  }
+  issuesClosed: search(query: $issuesClosed, type: ISSUE) {
+    issueCount
+  }
+}
+`
+
+	data, err := r.query(ctx, query, r.weekVariables(weekStart))
+	if err != nil {
+		return nil, err
+	}
+
+	week := &GitHubWeek{CommitsByRepo: []RepoCommits{}}
+	if data.User.Week != nil {
+		week.Commits = data.User.Week.TotalCommitContributions
+		week.ReposActive = len(data.User.Week.CommitContributionsByRepository)
+		week.PullRequestsOpened = data.User.Week.TotalPullRequestContributions
+		week.CommitsByRepo, week.PrivateRepoCommits = commitsByRepo(data.User.Week.CommitContributionsByRepository)
+	}
+	if data.PRsMerged != nil {
+		week.PullRequestsMerged = data.PRsMerged.IssueCount
+	}
+	if data.IssuesClosed != nil {
+		week.IssuesClosed = data.IssuesClosed.IssueCount
+	}
+	return week, nil
+}
+
+// weekVariables returns the query variables selecting the user's activity
+// in the week starting at weekStart.
+func (r *GitHubActivityResource) weekVariables(weekStart time.Time) map[string]interface{} {
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	dates := weekStart.Format("2006-01-02") + ".." + weekEnd.AddDate(0, 0, -1).Format("2006-01-02")
+	return map[string]interface{}{
+		"username":     r.username,
+		"from":         weekStart.Format(time.RFC3339),
+		"to":           weekEnd.Format(time.RFC3339),
+		"prsMerged":    fmt.Sprintf("author:%s is:pr is:merged merged:%s", r.username, dates),
+		"issuesClosed": fmt.Sprintf("author:%s is:issue is:closed closed:%s", r.username, dates),
+	}
+}
+
+// query runs a GraphQL query against the GitHub API. The returned data
+// always has a user.
+func (r *GitHubActivityResource) query(ctx context.Context, query string, variables map[string]interface{}) (*graphQLData, error) {
+	reqBody := graphQLRequest{
+		Query:     query,
+		Variables: variables,
+	}
 	bodyJSON, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("encoding GraphQL request: %w", err)
@@ -247,13 +561,20 @@ query($username: String!) {
 		return nil, fmt.Errorf("user %q not found", r.username)
 	}
 
-	return r.parseActivity(gqlResp.Data.User)
+	return gqlResp.Data, nil
 }
 
 // parseActivity converts the GraphQL response into GitHubActivity.
 func (r *GitHubActivityResource) parseActivity(user *graphQLUser) (*GitHubActivity, error) {
 	activity := &GitHubActivity{
-		PublicRepos: []string{},
+		PublicRepos:   []string{},
+		CommitsByRepo: []RepoCommits{},
+	}
+
+	// Parse this week's pull requests and per-repo commits
+	if user.Week != nil {
+		activity.PullRequestsOpened = user.Week.TotalPullRequestContributions
+		activity.CommitsByRepo, activity.PrivateRepoCommits = commitsByRepo(user.Week.CommitContributionsByRepository)
 	}
 
 	// Parse contribution calendar
@@ -267,7 +588,7 @@ func (r *GitHubActivityResource) parseActivity(user *graphQLUser) (*GitHubActivi
 		}
 
 		// Calculate commits this week (Monday-Sunday of current week)
-		now := time.Now()
+		now := r.clock.Now()
 		weekStart := startOfWeek(now)
 		weekEnd := weekStart.AddDate(0, 0, 7)
 
@@ -276,19 +597,25 @@ func (r *GitHubActivityResource) parseActivity(user *graphQLUser) (*GitHubActivi
 			if err != nil {
 				continue
 			}
+			if day.Date == now.Format("2006-01-02") && day.ContributionCount > 0 {
+				activity.ContributedToday = true
+			}
 			if !date.Before(weekStart) && date.Before(weekEnd) {
 				activity.CommitsThisWeek += day.ContributionCount
+				if day.ContributionCount > 0 {
+					activity.ActiveDaysThisWeek++
+				}
 			}
 		}
 
 		// Calculate streak (consecutive days with contributions ending today or yesterday)
-		activity.StreakDays = calculateStreak(allDays)
+		activity.StreakDays = calculateStreak(allDays, now)
 	}
 
 	// Parse repositories
 	if user.Repositories != nil {
 		// Track repos with recent activity (pushed in last 7 days)
-		now := time.Now()
+		now := r.clock.Now()
 		oneWeekAgo := now.AddDate(0, 0, -7)
 		var lastCommitTime time.Time
 
@@ -322,7 +649,29 @@ func (r *GitHubActivityResource) parseActivity(user *graphQLUser) (*GitHubActivi
 	return activity, nil
 }
 
-// startOfWeek returns the Monday 00:00:00 of the week containing t.
+// commitsByRepo splits per-repo commit counts into public repos, busiest
+// first, and a total for private repos.
+func commitsByRepo(contributions []repoContributions) ([]RepoCommits, int) {
+	repos := []RepoCommits{}
+	private := 0
+	for _, rc := range contributions {
+		if rc.Repository.IsPrivate {
+			private += rc.Contributions.TotalCount
+			continue
+		}
+		repos = append(repos, RepoCommits{
+			Repo:    rc.Repository.NameWithOwner,
+			Commits: rc.Contributions.TotalCount,
+		})
+	}
+	sort.SliceStable(repos, func(i, j int) bool {
+		return repos[i].Commits > repos[j].Commits
+	})
+	return repos, private
+}
+
+// startOfWeek returns the Monday of the week containing t, in t's timezone,
+// as midnight UTC like the dates in the data files.
 func startOfWeek(t time.Time) time.Time {
 	// Go's Weekday: Sunday=0, Monday=1, ..., Saturday=6
 	// We want Monday as start of week
@@ -332,12 +681,12 @@ func startOfWeek(t time.Time) time.Time {
 	}
 	daysFromMonday := weekday - 1
 	monday := t.AddDate(0, 0, -daysFromMonday)
-	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, t.Location())
+	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, time.UTC)
 }
 
 // calculateStreak calculates the current contribution streak.
 // A streak is consecutive days with at least 1 contribution, ending on today or yesterday.
-func calculateStreak(days []contributionDay) int {
+func calculateStreak(days []contributionDay, now time.Time) int {
 	if len(days) == 0 {
 		return 0
 	}
@@ -349,8 +698,8 @@ func calculateStreak(days []contributionDay) int {
 		return sorted[i].Date > sorted[j].Date
 	})
 
-	today := time.Now().Format("2006-01-02")
-	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	today := now.Format("2006-01-02")
+	yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
 
 	streak := 0
 	expectDate := today
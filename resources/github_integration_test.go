@@ -17,7 +17,7 @@ func TestGitHubActivityResource_Integration(t *testing.T) {
 	}
 
 	username := "dang-w"
-	resource := NewGitHubActivityResource(token, username)
+	resource := NewGitHubActivityResource(token, username, nil, 0, nil)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
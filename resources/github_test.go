@@ -1,11 +1,19 @@
 package resources
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
 )
 
 func TestStartOfWeek(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
 	tests := []struct {
 		name     string
 		input    time.Time
@@ -26,6 +34,16 @@ func TestStartOfWeek(t *testing.T) {
 			input:    time.Date(2026, 2, 8, 23, 59, 0, 0, time.UTC),
 			expected: "2026-02-02",
 		},
+		{
+			name:     "Sunday night in New York", // Monday in UTC
+			input:    time.Date(2026, 2, 8, 23, 30, 0, 0, newYork),
+			expected: "2026-02-02",
+		},
+		{
+			name:     "Monday morning in New York",
+			input:    time.Date(2026, 2, 9, 0, 30, 0, 0, newYork),
+			expected: "2026-02-09",
+		},
 	}
 
 	for _, tt := range tests {
@@ -39,6 +57,7 @@ func TestStartOfWeek(t *testing.T) {
 }
 
 func TestCalculateStreak(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
 	tests := []struct {
 		name     string
 		days     []contributionDay
@@ -52,18 +71,18 @@ func TestCalculateStreak(t *testing.T) {
 		{
 			name: "three day streak",
 			days: []contributionDay{
-				{Date: time.Now().Format("2006-01-02"), ContributionCount: 5},
-				{Date: time.Now().AddDate(0, 0, -1).Format("2006-01-02"), ContributionCount: 3},
-				{Date: time.Now().AddDate(0, 0, -2).Format("2006-01-02"), ContributionCount: 1},
+				{Date: now.Format("2006-01-02"), ContributionCount: 5},
+				{Date: now.AddDate(0, 0, -1).Format("2006-01-02"), ContributionCount: 3},
+				{Date: now.AddDate(0, 0, -2).Format("2006-01-02"), ContributionCount: 1},
 			},
 			expected: 3,
 		},
 		{
 			name: "streak with gap",
 			days: []contributionDay{
-				{Date: time.Now().Format("2006-01-02"), ContributionCount: 5},
-				{Date: time.Now().AddDate(0, 0, -1).Format("2006-01-02"), ContributionCount: 0}, // gap
-				{Date: time.Now().AddDate(0, 0, -2).Format("2006-01-02"), ContributionCount: 1},
+				{Date: now.Format("2006-01-02"), ContributionCount: 5},
+				{Date: now.AddDate(0, 0, -1).Format("2006-01-02"), ContributionCount: 0}, // gap
+				{Date: now.AddDate(0, 0, -2).Format("2006-01-02"), ContributionCount: 1},
 			},
 			expected: 1,
 		},
@@ -71,7 +90,7 @@ func TestCalculateStreak(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := calculateStreak(tt.days)
+			result := calculateStreak(tt.days, now)
 			if result != tt.expected {
 				t.Errorf("calculateStreak() = %v, expected %v", result, tt.expected)
 			}
@@ -98,3 +117,54 @@ func TestPrevDate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseActivity_WeekBreakdown(t *testing.T) {
+	var user graphQLUser
+	raw := `{
+		"week": {
+			"totalPullRequestContributions": 3,
+			"commitContributionsByRepository": [
+				{"repository": {"nameWithOwner": "me/small", "isPrivate": false}, "contributions": {"totalCount": 2}},
+				{"repository": {"nameWithOwner": "me/secret", "isPrivate": true}, "contributions": {"totalCount": 4}},
+				{"repository": {"nameWithOwner": "me/big", "isPrivate": false}, "contributions": {"totalCount": 9}}
+			]
+		}
+	}`
+	if err := json.Unmarshal([]byte(raw), &user); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewGitHubActivityResource("token", "me", nil, 0, clock.NewFake(time.Date(2026, 2, 5, 12, 0, 0, 0, time.UTC)))
+	activity, err := r.parseActivity(&user)
+	if err != nil {
+		t.Fatalf("parseActivity() error = %v", err)
+	}
+	if activity.PullRequestsOpened != 3 || activity.PrivateRepoCommits != 4 {
+		t.Errorf("unexpected activity %+v", activity)
+	}
+	if len(activity.CommitsByRepo) != 2 || activity.CommitsByRepo[0].Repo != "me/big" || activity.CommitsByRepo[1].Commits != 2 {
+		t.Errorf("unexpected commits by repo %+v", activity.CommitsByRepo)
+	}
+
+	if got := formatRepoCommits(activity.CommitsByRepo, activity.PrivateRepoCommits, 5); got != "me/big (9), me/small (2), private repos (4)" {
+		t.Errorf("formatRepoCommits() = %q", got)
+	}
+}
+
+func TestContributionProgress(t *testing.T) {
+	activity := &GitHubActivity{CommitsThisWeek: 12, ActiveDaysThisWeek: 2}
+	thursday := time.Date(2026, 2, 5, 12, 0, 0, 0, time.UTC) // 4 days into the week
+
+	p := contributionProgress(storage.ContributionGoal{WeeklyCommits: 20, WeeklyActiveDays: 5}, activity, thursday)
+	if p.Commits == nil || p.Commits.Summary != "12/20 commits, on pace" {
+		t.Errorf("commits progress = %+v", p.Commits)
+	}
+	if p.ActiveDays == nil || p.ActiveDays.Summary != "2/5 active days, behind" {
+		t.Errorf("active days progress = %+v", p.ActiveDays)
+	}
+
+	p = contributionProgress(storage.ContributionGoal{WeeklyCommits: 10}, activity, thursday)
+	if p.Commits.Status != "met" || p.ActiveDays != nil {
+		t.Errorf("unexpected progress %+v", p)
+	}
+}
@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dang-w/momentum-mcp-server/internal/analytics"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CompletionHistoryResource exposes weekly completion counts reconstructed
+// from the data repo's commit history.
+type CompletionHistoryResource struct {
+	backfill *analytics.Backfill
+}
+
+// NewCompletionHistoryResource creates a new CompletionHistoryResource.
+func NewCompletionHistoryResource(b *analytics.Backfill) *CompletionHistoryResource {
+	return &CompletionHistoryResource{backfill: b}
+}
+
+// completionHistory is the JSON payload for momentum://completion-history.
+type completionHistory struct {
+	Status string                 `json:"status"` // pending or complete
+	Weeks  []analytics.WeekCounts `json:"weeks"`
+}
+
+// Register registers the momentum://completion-history resource with the MCP server.
+func (r *CompletionHistoryResource) Register(server *mcp.Server) {
+	server.AddResource(&mcp.Resource{
+		URI:         "momentum://completion-history",
+		Name:        "Completion History",
+		Description: "Weekly completion counts for todos, milestones, reminders, and reading, reconstructed from the data repo's full commit history",
+		MIMEType:    "application/json",
+	}, r.Read)
+}
+
+// Read returns weekly completion history as JSON.
+func (r *CompletionHistoryResource) Read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	history := completionHistory{
+		Status: "pending",
+		Weeks:  r.backfill.Weeks(),
+	}
+	if r.backfill.Done() {
+		history.Status = "complete"
+	}
+
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("serializing completion history: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      "momentum://completion-history",
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
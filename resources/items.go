@@ -0,0 +1,236 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// itemHistoryCommits is how many commits of a data file are walked to build
+// an item's history.
+const itemHistoryCommits = 30
+
+// ItemResource serves single items by ID, with their change history from
+// the data repo's commits.
+type ItemResource struct {
+	storage storage.Storage
+	history storage.History
+}
+
+// NewItemResource creates a new ItemResource. h is optional; without it
+// items are served without history.
+func NewItemResource(s storage.Storage, h storage.History) *ItemResource {
+	return &ItemResource{storage: s, history: h}
+}
+
+// itemKind describes one item resource template.
+type itemKind struct {
+	name string // URI segment, e.g. "todo"
+	file string
+	// find returns the item's title and detail fields, or false if no item
+	// in content has the ID.
+	find func(content, id string) (string, [][2]string, bool, error)
+}
+
+var itemKinds = []itemKind{
+	{"todo", storage.TodosFile, findTodo},
+	{"milestone", storage.StrategyFile, findMilestone},
+	{"reading", storage.ReadingListFile, findReadingItem},
+	{"reminder", storage.RemindersFile, findReminder},
+}
+
+// Register registers the momentum://<kind>/{id} templates with the MCP server.
+func (r *ItemResource) Register(server *mcp.Server) {
+	for _, k := range itemKinds {
+		k := k
+		server.AddResourceTemplate(&mcp.ResourceTemplate{
+			URITemplate: "momentum://" + k.name + "/{id}",
+			Name:        "Single " + k.name,
+			Description: fmt.Sprintf("One %s from %s by ID, with its change history from the data repo's commits", k.name, k.file),
+			MIMEType:    "text/markdown",
+		}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			return r.read(ctx, req, k)
+		})
+	}
+}
+
+func (r *ItemResource) read(ctx context.Context, req *mcp.ReadResourceRequest, k itemKind) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	id := strings.TrimPrefix(uri, "momentum://"+k.name+"/")
+
+	content, _, err := r.storage.ReadFile(ctx, k.file)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", k.file, err)
+	}
+	title, fields, ok, err := k.find(content, id)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", k.file, err)
+	}
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	var history []storage.ItemVersion
+	historyErr := errNoItemHistory
+	if r.history != nil {
+		history, historyErr = storage.ItemHistory(ctx, r.history, k.file, id, itemHistoryCommits)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      uri,
+				MIMEType: "text/markdown",
+				Text:     renderItem(title, fields, history, historyErr),
+			},
+		},
+	}, nil
+}
+
+// errNoItemHistory is reported in place of history when the storage backend
+// can't read past versions.
+var errNoItemHistory = errors.New("history not available")
+
+// renderItem formats an item's fields and history as markdown.
+func renderItem(title string, fields [][2]string, history []storage.ItemVersion, historyErr error) string {
+	var b strings.Builder
+	b.WriteString("# " + title + "\n\n")
+	for _, f := range fields {
+		if f[1] != "" {
+			b.WriteString(fmt.Sprintf("- **%s:** %s\n", f[0], f[1]))
+		}
+	}
+
+	b.WriteString("\n## History\n")
+	switch {
+	case historyErr == errNoItemHistory:
+		b.WriteString("*History not available for this storage backend*\n")
+	case historyErr != nil:
+		b.WriteString("*History temporarily unavailable*\n")
+	case len(history) == 0:
+		b.WriteString(fmt.Sprintf("*No changes in the last %d commits*\n", itemHistoryCommits))
+	default:
+		for _, v := range history {
+			sha := v.Commit.SHA
+			if len(sha) > 7 {
+				sha = sha[:7]
+			}
+			message, _, _ := strings.Cut(v.Commit.Message, "\n")
+			b.WriteString(fmt.Sprintf("- %s `%s` %s", v.Commit.Date.UTC().Format("2006-01-02"), sha, message))
+			switch {
+			case v.Line == "":
+				b.WriteString(" _(removed)_")
+			case v.Existing:
+				b.WriteString(" _(oldest commit checked)_")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// itemDate formats an optional date for a detail field.
+func itemDate(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func itemStatus(completed bool, done string) string {
+	if completed {
+		return done
+	}
+	return "active"
+}
+
+func findTodo(content, id string) (string, [][2]string, bool, error) {
+	tf, err := storage.ParseTodos(content)
+	if err != nil {
+		return "", nil, false, err
+	}
+	for _, todo := range append(tf.Active, tf.Completed...) {
+		if todo.ID == id {
+			return "Todo: " + todo.Text, [][2]string{
+				{"ID", todo.ID},
+				{"Status", itemStatus(todo.Completed, "completed")},
+				{"Priority", string(todo.Priority)},
+				{"Project", todo.Project},
+				{"Milestone", todo.Milestone},
+				{"Added", itemDate(&todo.Added)},
+				{"Completed", itemDate(todo.CompletedAt)},
+			}, true, nil
+		}
+	}
+	return "", nil, false, nil
+}
+
+func findMilestone(content, id string) (string, [][2]string, bool, error) {
+	s, err := storage.ParseStrategy(content)
+	if err != nil {
+		return "", nil, false, err
+	}
+	for _, m := range append(s.ActiveMilestones, s.CompletedMilestones...) {
+		if m.ID == id {
+			issue := ""
+			if m.Issue != 0 {
+				issue = fmt.Sprintf("#%d", m.Issue)
+			}
+			return "Milestone: " + m.Text, [][2]string{
+				{"ID", m.ID},
+				{"Status", itemStatus(m.Completed, "completed")},
+				{"Due", itemDate(m.Due)},
+				{"Project", m.Project},
+				{"Issue", issue},
+				{"Added", itemDate(&m.Added)},
+				{"Completed", itemDate(m.CompletedAt)},
+			}, true, nil
+		}
+	}
+	return "", nil, false, nil
+}
+
+func findReadingItem(content, id string) (string, [][2]string, bool, error) {
+	rl, err := storage.ParseReadingList(content)
+	if err != nil {
+		return "", nil, false, err
+	}
+	for _, item := range append(rl.ToRead, rl.Read...) {
+		if item.ID == id {
+			return "Reading: " + item.URL, [][2]string{
+				{"ID", item.ID},
+				{"Status", itemStatus(item.Read, "read")},
+				{"Priority", item.Priority},
+				{"Category", item.Category},
+				{"Notes", item.Notes},
+				{"Added", itemDate(&item.Added)},
+				{"Read", itemDate(item.ReadAt)},
+			}, true, nil
+		}
+	}
+	return "", nil, false, nil
+}
+
+func findReminder(content, id string) (string, [][2]string, bool, error) {
+	rf, err := storage.ParseReminders(content)
+	if err != nil {
+		return "", nil, false, err
+	}
+	for _, reminder := range append(rf.Upcoming, rf.Completed...) {
+		if reminder.ID == id {
+			return "Reminder: " + reminder.Text, [][2]string{
+				{"ID", reminder.ID},
+				{"Status", itemStatus(reminder.Completed, "completed")},
+				{"Date", itemDate(&reminder.Date)},
+				{"Added", itemDate(&reminder.Added)},
+				{"Completed", itemDate(reminder.CompletedAt)},
+			}, true, nil
+		}
+	}
+	return "", nil, false, nil
+}
@@ -0,0 +1,44 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestRenderItem(t *testing.T) {
+	content := "# Todos\n\n## Active\n\n## Completed\n- [x] Write API docs {id:abcd1234,added:2026-02-02,completed:2026-02-05}\n"
+	title, fields, ok, err := findTodo(content, "abcd1234")
+	if err != nil || !ok {
+		t.Fatalf("findTodo() = %v, %v", ok, err)
+	}
+
+	history := []storage.ItemVersion{
+		{Commit: storage.Commit{SHA: "0123456789", Message: "Add todo\n\nbody", Date: time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC)}, Line: "- [ ] Write API docs"},
+		{Commit: storage.Commit{SHA: "fedcba9876", Message: "Complete todo", Date: time.Date(2026, 2, 5, 9, 0, 0, 0, time.UTC)}, Line: "- [x] Write API docs"},
+	}
+	out := renderItem(title, fields, history, nil)
+	for _, want := range []string{
+		"# Todo: Write API docs\n",
+		"- **Status:** completed\n",
+		"- **Completed:** 2026-02-05\n",
+		"- 2026-02-02 `0123456` Add todo\n",
+		"- 2026-02-05 `fedcba9` Complete todo\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "**Project:**") {
+		t.Errorf("empty fields should be omitted:\n%s", out)
+	}
+
+	if out := renderItem(title, fields, nil, errNoItemHistory); !strings.Contains(out, "History not available") {
+		t.Errorf("expected a no-history note:\n%s", out)
+	}
+	if _, _, ok, _ := findTodo(content, "ffff0000"); ok {
+		t.Error("findTodo() found an unknown ID")
+	}
+}
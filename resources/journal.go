@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// JournalResource provides read access to recent journal entries.
+type JournalResource struct {
+	storage storage.Storage
+	clock   clock.Clock
+}
+
+// NewJournalResource creates a new JournalResource.
+func NewJournalResource(s storage.Storage, c clock.Clock) *JournalResource {
+	return &JournalResource{storage: s, clock: clock.Or(c)}
+}
+
+// Register registers the momentum://journal resource with the MCP server.
+func (r *JournalResource) Register(server *mcp.Server) {
+	server.AddResource(&mcp.Resource{
+		URI:         "momentum://journal",
+		Name:        "Journal",
+		Description: "Journal entries from the last 7 days",
+		MIMEType:    "text/markdown",
+	}, r.Read)
+}
+
+// Read fetches and formats the last 7 days of journal entries.
+func (r *JournalResource) Read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	journal := &storage.Journal{}
+	content, _, err := r.storage.ReadFile(ctx, storage.JournalFile)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("reading journal.md: %w", err)
+	}
+	if err == nil {
+		journal, err = storage.ParseJournal(content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing journal: %w", err)
+		}
+	}
+
+	since := clock.Today(r.clock).AddDate(0, 0, -6)
+
+	var b strings.Builder
+	b.WriteString("# Journal — Last 7 Days\n")
+
+	count := 0
+	currentDay := ""
+	for _, e := range journal.Entries {
+		if e.Time.Before(since) {
+			continue
+		}
+		day := e.Time.Format("Monday, Jan 2")
+		if day != currentDay {
+			b.WriteString("\n## " + day + "\n")
+			currentDay = day
+		}
+		b.WriteString(fmt.Sprintf("- **%s** %s\n", e.Time.Format("15:04"), e.Text))
+		count++
+	}
+
+	if count == 0 {
+		b.WriteString("\nNo journal entries in the last 7 days.\n")
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      "momentum://journal",
+				MIMEType: "text/markdown",
+				Text:     b.String(),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// NotesResource provides read access to notes.md.
+type NotesResource struct {
+	storage storage.Storage
+}
+
+// NewNotesResource creates a new NotesResource.
+func NewNotesResource(s storage.Storage) *NotesResource {
+	return &NotesResource{storage: s}
+}
+
+// Register registers the momentum://notes resource with the MCP server.
+func (r *NotesResource) Register(server *mcp.Server) {
+	server.AddResource(&mcp.Resource{
+		URI:         "momentum://notes",
+		Name:        "Notes",
+		Description: "All notes, grouped by category",
+		MIMEType:    "text/markdown",
+	}, r.Read)
+}
+
+// Read fetches and formats the notes.
+func (r *NotesResource) Read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	nf := &storage.NoteFile{}
+	content, _, err := r.storage.ReadFile(ctx, storage.NotesFile)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("reading notes.md: %w", err)
+	}
+	if err == nil {
+		nf, err = storage.ParseNotes(content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing notes: %w", err)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("# Notes\n")
+
+	// Uncategorized notes first, then each category in file order
+	for _, c := range append([]string{""}, nf.Categories...) {
+		var lines []string
+		for _, n := range nf.Notes {
+			if n.Category == c {
+				lines = append(lines, "- "+n.Text)
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		if c != "" {
+			b.WriteString("\n## " + c + "\n")
+		} else {
+			b.WriteString("\n")
+		}
+		b.WriteString(strings.Join(lines, "\n") + "\n")
+	}
+
+	if len(nf.Notes) == 0 {
+		b.WriteString("\nNo notes yet.\n")
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      "momentum://notes",
+				MIMEType: "text/markdown",
+				Text:     b.String(),
+			},
+		},
+	}, nil
+}
@@ -3,6 +3,7 @@ package resources
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/dang-w/momentum-mcp-server/storage"
@@ -31,7 +32,7 @@ func (r *ReadingResource) Register(server *mcp.Server) {
 
 // Read fetches and formats the reading list.
 func (r *ReadingResource) Read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
-	content, _, err := r.storage.ReadFile(ctx, "reading-list.md")
+	content, _, err := r.storage.ReadFile(ctx, storage.ReadingListFile)
 	if err != nil {
 		return nil, fmt.Errorf("reading reading-list.md: %w", err)
 	}
@@ -41,22 +42,49 @@ func (r *ReadingResource) Read(ctx context.Context, req *mcp.ReadResourceRequest
 		return nil, fmt.Errorf("parsing reading list: %w", err)
 	}
 
-	// Build readable markdown output
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      "momentum://reading-list",
+				MIMEType: "text/markdown",
+				Text:     renderReadingList(rl),
+			},
+		},
+	}, nil
+}
+
+// renderReadingList formats the reading list as markdown.
+func renderReadingList(rl *storage.ReadingList) string {
 	var b strings.Builder
 	b.WriteString("# Reading List\n\n")
 
 	// Summary
 	b.WriteString(fmt.Sprintf("**%d unread**, **%d read** total\n\n", len(rl.ToRead), len(rl.Read)))
 
-	// To read section
+	// To read section, grouped so a long backlog stays navigable: next-up
+	// items first, then each category, then someday items last
 	if len(rl.ToRead) > 0 {
 		b.WriteString("## 📚 To Read\n")
-		for _, item := range rl.ToRead {
-			b.WriteString(fmt.Sprintf("- [ ] %s", item.URL))
-			if item.Notes != "" {
-				b.WriteString(fmt.Sprintf("\n  - Notes: %s", item.Notes))
+		if !hasReadingGroups(rl.ToRead) {
+			for _, item := range rl.ToRead {
+				writeReadingItem(&b, item, false)
+			}
+		} else {
+			writeReadingGroup(&b, "⏭ Next Up", rl.ToRead, true, func(item storage.ReadingItem) bool {
+				return item.Priority == storage.ReadingPriorityNext
+			})
+			for _, c := range readingCategories(rl.ToRead) {
+				title := c
+				if c == "" {
+					title = "Uncategorized"
+				}
+				writeReadingGroup(&b, title, rl.ToRead, false, func(item storage.ReadingItem) bool {
+					return item.Priority == "" && item.Category == c
+				})
 			}
-			b.WriteString("\n")
+			writeReadingGroup(&b, "💤 Someday", rl.ToRead, true, func(item storage.ReadingItem) bool {
+				return item.Priority == storage.ReadingPrioritySomeday
+			})
 		}
 		b.WriteString("\n")
 	}
@@ -69,22 +97,79 @@ func (r *ReadingResource) Read(ctx context.Context, req *mcp.ReadResourceRequest
 			limit = len(rl.Read)
 		}
 		for i := 0; i < limit; i++ {
-			item := rl.Read[i]
-			b.WriteString(fmt.Sprintf("- [x] %s", item.URL))
-			if item.Notes != "" {
-				b.WriteString(fmt.Sprintf("\n  - Notes: %s", item.Notes))
-			}
-			b.WriteString("\n")
+			writeReadingItem(&b, rl.Read[i], true)
 		}
 	}
 
-	return &mcp.ReadResourceResult{
-		Contents: []*mcp.ResourceContents{
-			{
-				URI:      "momentum://reading-list",
-				MIMEType: "text/markdown",
-				Text:     b.String(),
-			},
-		},
-	}, nil
+	return b.String()
+}
+
+// hasReadingGroups reports whether any item has a priority or category, so
+// an untagged list keeps its flat layout.
+func hasReadingGroups(items []storage.ReadingItem) bool {
+	for _, item := range items {
+		if item.Priority != "" || item.Category != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// readingCategories returns the categories of items without a priority,
+// sorted, with uncategorized ("") last.
+func readingCategories(items []storage.ReadingItem) []string {
+	seen := map[string]bool{}
+	var categories []string
+	uncategorized := false
+	for _, item := range items {
+		if item.Priority != "" {
+			continue
+		}
+		if item.Category == "" {
+			uncategorized = true
+			continue
+		}
+		if !seen[item.Category] {
+			seen[item.Category] = true
+			categories = append(categories, item.Category)
+		}
+	}
+	sort.Strings(categories)
+	if uncategorized {
+		categories = append(categories, "")
+	}
+	return categories
+}
+
+// writeReadingGroup writes the items matching match under a "###" heading,
+// or nothing if none match.
+func writeReadingGroup(b *strings.Builder, title string, items []storage.ReadingItem, showCategory bool, match func(storage.ReadingItem) bool) {
+	var group []storage.ReadingItem
+	for _, item := range items {
+		if match(item) {
+			group = append(group, item)
+		}
+	}
+	if len(group) == 0 {
+		return
+	}
+	b.WriteString(fmt.Sprintf("\n### %s (%d)\n", title, len(group)))
+	for _, item := range group {
+		writeReadingItem(b, item, showCategory)
+	}
+}
+
+func writeReadingItem(b *strings.Builder, item storage.ReadingItem, showCategory bool) {
+	checkbox := "[ ]"
+	if item.Read {
+		checkbox = "[x]"
+	}
+	b.WriteString(fmt.Sprintf("- %s %s", checkbox, item.URL))
+	if showCategory && item.Category != "" {
+		b.WriteString(fmt.Sprintf(" _(%s)_", item.Category))
+	}
+	if item.Notes != "" {
+		b.WriteString(fmt.Sprintf("\n  - Notes: %s", item.Notes))
+	}
+	b.WriteString("\n")
 }
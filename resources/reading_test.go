@@ -0,0 +1,31 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestRenderReadingList_Groups(t *testing.T) {
+	rl, err := storage.ParseReadingList("# Reading List\n\n## To Read\n" +
+		"- [ ] https://a.example {id:aaaa1111,category:go}\n" +
+		"- [ ] https://b.example {id:bbbb2222}\n" +
+		"- [ ] https://c.example {id:cccc3333,priority:next,category:rust}\n" +
+		"- [ ] https://d.example {id:dddd4444,priority:someday}\n" +
+		"\n## Read\n")
+	if err != nil {
+		t.Fatalf("ParseReadingList failed: %v", err)
+	}
+	out := renderReadingList(rl)
+
+	order := []string{"### ⏭ Next Up (1)", "https://c.example _(rust)_", "### go (1)", "https://a.example", "### Uncategorized (1)", "https://b.example", "### 💤 Someday (1)", "https://d.example"}
+	last := -1
+	for _, want := range order {
+		i := strings.Index(out, want)
+		if i < 0 || i < last {
+			t.Fatalf("expected %q after the previous group:\n%s", want, out)
+		}
+		last = i
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"time"
 
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
 	"github.com/dang-w/momentum-mcp-server/storage"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -14,11 +14,12 @@ import (
 // RemindersResource provides read access to reminders.
 type RemindersResource struct {
 	storage storage.Storage
+	clock   clock.Clock
 }
 
 // NewRemindersResource creates a new RemindersResource.
-func NewRemindersResource(s storage.Storage) *RemindersResource {
-	return &RemindersResource{storage: s}
+func NewRemindersResource(s storage.Storage, c clock.Clock) *RemindersResource {
+	return &RemindersResource{storage: s, clock: clock.Or(c)}
 }
 
 // Register registers the momentum://reminders resource with the MCP server.
@@ -33,7 +34,7 @@ func (r *RemindersResource) Register(server *mcp.Server) {
 
 // Read fetches and formats the reminders.
 func (r *RemindersResource) Read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
-	content, _, err := r.storage.ReadFile(ctx, "reminders.md")
+	content, _, err := r.storage.ReadFile(ctx, storage.RemindersFile)
 	if err != nil {
 		return nil, fmt.Errorf("reading reminders.md: %w", err)
 	}
@@ -48,7 +49,7 @@ func (r *RemindersResource) Read(ctx context.Context, req *mcp.ReadResourceReque
 		return rf.Upcoming[i].Date.Before(rf.Upcoming[j].Date)
 	})
 
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+	today := clock.Today(r.clock)
 
 	// Build readable markdown output
 	var b strings.Builder
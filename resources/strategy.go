@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
 	"github.com/dang-w/momentum-mcp-server/storage"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -12,11 +14,12 @@ import (
 // StrategyResource provides read access to the strategy progress.
 type StrategyResource struct {
 	storage storage.Storage
+	clock   clock.Clock
 }
 
 // NewStrategyResource creates a new StrategyResource.
-func NewStrategyResource(s storage.Storage) *StrategyResource {
-	return &StrategyResource{storage: s}
+func NewStrategyResource(s storage.Storage, c clock.Clock) *StrategyResource {
+	return &StrategyResource{storage: s, clock: clock.Or(c)}
 }
 
 // Register registers the momentum://strategy resource with the MCP server.
@@ -24,14 +27,14 @@ func (r *StrategyResource) Register(server *mcp.Server) {
 	server.AddResource(&mcp.Resource{
 		URI:         "momentum://strategy",
 		Name:        "Strategy Progress",
-		Description: "Current phase, active milestones, and recent completions",
+		Description: "Current phase and time in it, phase history, active milestones, and recent completions",
 		MIMEType:    "text/markdown",
 	}, r.Read)
 }
 
 // Read fetches and formats the strategy progress.
 func (r *StrategyResource) Read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
-	content, _, err := r.storage.ReadFile(ctx, "strategy.md")
+	content, _, err := r.storage.ReadFile(ctx, storage.StrategyFile)
 	if err != nil {
 		return nil, fmt.Errorf("reading strategy.md: %w", err)
 	}
@@ -45,8 +48,23 @@ func (r *StrategyResource) Read(ctx context.Context, req *mcp.ReadResourceReques
 	var b strings.Builder
 	b.WriteString("# Strategy Progress\n\n")
 
-	// Current phase
-	b.WriteString(fmt.Sprintf("**Current Phase:** %s\n\n", s.CurrentPhase))
+	// Current phase, with how long it has run
+	today := clock.Today(r.clock)
+	var current *storage.Phase
+	if n := len(s.Phases); n > 0 && s.Phases[n-1].Ended == nil && !s.Phases[n-1].Started.IsZero() {
+		current = &s.Phases[n-1]
+	}
+	if current != nil {
+		b.WriteString(fmt.Sprintf("**Current Phase:** %s (since %s, %s)\n\n", s.CurrentPhase, current.Started.Format("2006-01-02"), phaseDays(current.Started, today)))
+		for _, objective := range current.Objectives {
+			b.WriteString(fmt.Sprintf("- %s\n", objective))
+		}
+		if len(current.Objectives) > 0 {
+			b.WriteString("\n")
+		}
+	} else {
+		b.WriteString(fmt.Sprintf("**Current Phase:** %s\n\n", s.CurrentPhase))
+	}
 
 	// Summary
 	b.WriteString(fmt.Sprintf("**%d active milestones**, **%d completed**\n\n", len(s.ActiveMilestones), len(s.CompletedMilestones)))
@@ -78,6 +96,26 @@ func (r *StrategyResource) Read(ctx context.Context, req *mcp.ReadResourceReques
 		b.WriteString("\n")
 	}
 
+	// Phase history, most recent first
+	var past []storage.Phase
+	for _, p := range s.Phases {
+		if p.Ended != nil {
+			past = append(past, p)
+		}
+	}
+	if len(past) > 0 {
+		b.WriteString("## 🗺️ Phase History\n")
+		for i := len(past) - 1; i >= 0; i-- {
+			p := past[i]
+			if p.Started.IsZero() {
+				b.WriteString(fmt.Sprintf("- %s (until %s)\n", p.Name, p.Ended.Format("2006-01-02")))
+				continue
+			}
+			b.WriteString(fmt.Sprintf("- %s (%s to %s, %s)\n", p.Name, p.Started.Format("2006-01-02"), p.Ended.Format("2006-01-02"), phaseDays(p.Started, *p.Ended)))
+		}
+		b.WriteString("\n")
+	}
+
 	// Notes
 	if len(s.Notes) > 0 {
 		b.WriteString("## 📝 Notes\n")
@@ -96,3 +134,12 @@ func (r *StrategyResource) Read(ctx context.Context, req *mcp.ReadResourceReques
 		},
 	}, nil
 }
+
+// phaseDays formats the time from start to end as "12 days".
+func phaseDays(start, end time.Time) string {
+	days := int(end.Sub(start).Hours() / 24)
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
@@ -0,0 +1,33 @@
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestStrategyResource_Phases(t *testing.T) {
+	mem := storage.NewMemoryStorage(map[string]string{
+		storage.StrategyFile: "# Plan\n\n## Current Phase\nPhase 2: Launch\n\n" +
+			"## Phases\n- Phase 1: Foundations {started:2026-01-05,ended:2026-02-02}\n- Phase 2: Launch {started:2026-02-02}\n  - Ship the beta\n\n" +
+			"## Active Milestones\n\n## Completed Milestones\n\n## Notes\n",
+	}, nil)
+	r := NewStrategyResource(mem, clock.NewFake(time.Date(2026, 2, 12, 9, 0, 0, 0, time.UTC)))
+	res, err := r.Read(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := res.Contents[0].Text
+	for _, want := range []string{
+		"**Current Phase:** Phase 2: Launch (since 2026-02-02, 10 days)\n\n- Ship the beta\n",
+		"## 🗺️ Phase History\n- Phase 1: Foundations (2026-01-05 to 2026-02-02, 28 days)\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+}
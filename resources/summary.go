@@ -3,10 +3,16 @@ package resources
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/dang-w/momentum-mcp-server/internal/analytics"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/integrations"
+	"github.com/dang-w/momentum-mcp-server/internal/summary"
 	"github.com/dang-w/momentum-mcp-server/storage"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -15,17 +21,58 @@ import (
 type SummaryResource struct {
 	storage        storage.Storage
 	githubActivity *GitHubActivityResource
+	wakatime       *integrations.WakaTime
+	clock          clock.Clock
+
+	mu       sync.Mutex
+	rendered map[string]renderedSummary
+}
+
+// renderedSummaryTTL is how long a rendered summary is reused while the
+// files it was rendered from are unchanged, so reading it again in the same
+// conversation is instant.
+const renderedSummaryTTL = time.Minute
+
+// renderedSummary is a summary as rendered at a time.
+type renderedSummary struct {
+	text string
+	at   time.Time
 }
 
-// NewSummaryResource creates a new SummaryResource.
-func NewSummaryResource(s storage.Storage, ga *GitHubActivityResource) *SummaryResource {
+// summaryFiles are the data files a summary is rendered from.
+var summaryFiles = []string{
+	storage.TodosFile, storage.StrategyFile, storage.RemindersFile,
+	storage.ReadingListFile, storage.TimeLogFile, storage.FocusFile,
+}
+
+// summaryData is the summary's data files, read together up front.
+type summaryData map[string]storage.ReadResult
+
+// read returns the content of path.
+func (d summaryData) read(path string) (string, error) {
+	r := d[path]
+	return r.Content, r.Err
+}
+
+// NewSummaryResource creates a new SummaryResource. ga and wt are optional;
+// the Momentum section skips sources that are nil. A nil clock uses the
+// system clock.
+func NewSummaryResource(s storage.Storage, ga *GitHubActivityResource, wt *integrations.WakaTime, c clock.Clock) *SummaryResource {
 	return &SummaryResource{
 		storage:        s,
 		githubActivity: ga,
+		wakatime:       wt,
+		clock:          clock.Or(c),
+		rendered:       make(map[string]renderedSummary),
 	}
 }
 
-// Register registers the momentum://weekly-summary resource with the MCP server.
+// weekSummaryPrefix is the URI prefix of past week summaries, followed by an
+// ISO week such as 2026-W05.
+const weekSummaryPrefix = "momentum://weekly-summary/"
+
+// Register registers the momentum://weekly-summary resource and the
+// momentum://weekly-summary/{week} template with the MCP server.
 func (r *SummaryResource) Register(server *mcp.Server) {
 	server.AddResource(&mcp.Resource{
 		URI:         "momentum://weekly-summary",
@@ -33,27 +80,137 @@ func (r *SummaryResource) Register(server *mcp.Server) {
 		Description: "Aggregated overview of todos, strategy, reading list, reminders, and GitHub activity",
 		MIMEType:    "text/markdown",
 	}, r.Read)
+
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: weekSummaryPrefix + "{week}",
+		Name:        "Weekly Summary by Week",
+		Description: "The weekly summary for a past or current ISO week, e.g. momentum://weekly-summary/2026-W05",
+		MIMEType:    "text/markdown",
+	}, r.ReadWeek)
 }
 
 // Read fetches data from all sources and produces an aggregated summary.
 func (r *SummaryResource) Read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      "momentum://weekly-summary",
+				MIMEType: "text/markdown",
+				Text:     r.Render(ctx, r.clock.Now(), summary.Options{}),
+			},
+		},
+	}, nil
+}
+
+// ReadWeek produces the summary for the ISO week named in the URI. Past weeks
+// are rendered as of their last moment.
+func (r *SummaryResource) ReadWeek(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	weekStart, err := analytics.ParseWeek(strings.TrimPrefix(uri, weekSummaryPrefix))
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	now := r.clock.Now()
+	if weekStart.After(clock.Date(now)) {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      uri,
+				MIMEType: "text/markdown",
+				Text:     r.Render(ctx, analytics.AsOf(weekStart, now), summary.Options{}),
+			},
+		},
+	}, nil
+}
+
+// Render produces the summary markdown for the week (Monday-Sunday)
+// containing now, treating now as the current time for overdue checks.
+// GitHub activity for past weeks is fetched for that week's date range.
+// opts selects the sections and how much detail each one lists. The data
+// files are read concurrently, and a summary rendered from the same files
+// for the same day is reused for a minute.
+func (r *SummaryResource) Render(ctx context.Context, now time.Time, opts summary.Options) string {
 	// Calculate the week boundaries (Monday-Sunday)
-	now := time.Now()
 	weekStart := startOfWeek(now)
 	weekEnd := weekStart.AddDate(0, 0, 6)
+	currentWeek := startOfWeek(r.clock.Now()).Format("2006-01-02") == weekStart.Format("2006-01-02")
+
+	data := summaryData(storage.ReadFiles(ctx, r.storage, summaryFiles...))
+	key := renderKey(clock.Date(now), opts, data)
+	r.mu.Lock()
+	cached, ok := r.rendered[key]
+	r.mu.Unlock()
+	if ok && r.clock.Now().Sub(cached.at) < renderedSummaryTTL {
+		return cached.text
+	}
 
 	var b strings.Builder
 	b.WriteString(fmt.Sprintf("## Weekly Summary (%s to %s)\n\n",
 		weekStart.Format("2006-01-02"),
 		weekEnd.Format("2006-01-02")))
 
-	// --- Momentum (GitHub Activity) ---
+	if opts.Includes(summary.SectionMomentum) {
+		r.writeMomentum(ctx, &b, weekStart, now, currentWeek, opts)
+	}
+	if opts.Includes(summary.SectionFocus) {
+		writeFocus(&b, data, weekStart, weekEnd, now, opts)
+	}
+	if opts.Includes(summary.SectionTime) {
+		writeTimeTracked(&b, data, weekStart, weekEnd.AddDate(0, 0, 1), now, opts)
+	}
+	if opts.Includes(summary.SectionReading) {
+		writeReading(&b, data, weekStart, weekEnd)
+	}
+	if opts.Includes(summary.SectionCompletions) {
+		writeCompletions(&b, data, weekStart, weekEnd, opts)
+	}
+
+	text := strings.TrimRight(b.String(), "\n") + "\n"
+	r.remember(key, text)
+	return text
+}
+
+// renderKey identifies a summary by the day it's rendered for, opts, and
+// the versions of the data files.
+func renderKey(day time.Time, opts summary.Options, data summaryData) string {
+	key := fmt.Sprintf("%s %v", day.Format("2006-01-02"), opts)
+	for _, path := range summaryFiles {
+		key += " " + data[path].SHA
+	}
+	return key
+}
+
+// remember keeps text as the summary for key, dropping expired ones.
+func (r *SummaryResource) remember(key, text string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.clock.Now()
+	for k, s := range r.rendered {
+		if now.Sub(s.at) >= renderedSummaryTTL {
+			delete(r.rendered, k)
+		}
+	}
+	r.rendered[key] = renderedSummary{text: text, at: now}
+}
+
+// writeMomentum reports GitHub activity and coding time.
+func (r *SummaryResource) writeMomentum(ctx context.Context, b *strings.Builder, weekStart, now time.Time, currentWeek bool, opts summary.Options) {
 	b.WriteString("### Momentum\n")
-	if r.githubActivity != nil {
+	if r.githubActivity != nil && !currentWeek {
+		if week, err := r.githubActivity.week(ctx, weekStart); err != nil {
+			b.WriteString("- GitHub: *Data temporarily unavailable*\n")
+		} else {
+			writeGitHubWeek(b, week, opts)
+		}
+	} else if r.githubActivity != nil {
 		activity, err := r.githubActivity.getActivity(ctx)
 		if err != nil {
 			b.WriteString("- GitHub: *Data temporarily unavailable*\n")
 		} else {
+			activity = r.githubActivity.withGoal(ctx, activity)
 			b.WriteString(fmt.Sprintf("- GitHub: %d commits across %d repos",
 				activity.CommitsThisWeek, activity.ReposActive))
 			if activity.StreakDays > 0 {
@@ -61,27 +218,67 @@ func (r *SummaryResource) Read(ctx context.Context, req *mcp.ReadResourceRequest
 			}
 			b.WriteString("\n")
 
-			if !activity.LastCommit.IsZero() {
-				timeSince := formatTimeSince(activity.LastCommit)
-				b.WriteString(fmt.Sprintf("- Last commit: %s\n", timeSince))
+			if activity.Goal != nil {
+				var goals []string
+				for _, p := range []*GoalProgress{activity.Goal.Commits, activity.Goal.ActiveDays} {
+					if p != nil {
+						goals = append(goals, p.Summary)
+					}
+				}
+				b.WriteString("- Contribution goal: " + strings.Join(goals, "; ") + "\n")
+			}
+			if !opts.Brief() {
+				if activity.PullRequestsOpened > 0 || activity.PullRequestsMerged > 0 || activity.IssuesClosed > 0 {
+					b.WriteString(fmt.Sprintf("- Pull requests: %d opened, %d merged; %d issues closed\n",
+						activity.PullRequestsOpened, activity.PullRequestsMerged, activity.IssuesClosed))
+				}
+				maxRepos := 5
+				if opts.Detailed() {
+					maxRepos = 0
+				}
+				if repos := formatRepoCommits(activity.CommitsByRepo, activity.PrivateRepoCommits, maxRepos); repos != "" {
+					b.WriteString("- Commits by repo: " + repos + "\n")
+				}
+
+				if !activity.LastCommit.IsZero() {
+					timeSince := formatTimeSince(activity.LastCommit, now)
+					b.WriteString(fmt.Sprintf("- Last commit: %s\n", timeSince))
+				}
 			}
 		}
 	} else {
 		b.WriteString("- GitHub: *Not configured*\n")
 	}
+	if r.wakatime != nil {
+		// WakaTime keeps history, so past weeks are reported too
+		if week, err := r.wakatime.Week(ctx, weekStart); err != nil {
+			b.WriteString("- Coding time: *Data temporarily unavailable*\n")
+		} else {
+			b.WriteString(fmt.Sprintf("- Coding time: %.1fh", week.TotalHours))
+			if len(week.Projects) > 0 && !opts.Brief() {
+				b.WriteString(fmt.Sprintf(" (most on %s, %.1fh)", week.Projects[0].Name, week.Projects[0].Hours))
+			}
+			b.WriteString("\n")
+		}
+	}
 	b.WriteString("\n")
+}
 
-	// --- Focus Areas (Todos + Strategy + Reminders) ---
+// writeFocus reports today's focus, pending todos, milestones due this week
+// and overdue reminders. Brief summaries give counts instead of listing
+// items.
+func writeFocus(b *strings.Builder, data summaryData, weekStart, weekEnd, now time.Time, opts summary.Options) {
 	b.WriteString("### Focus Areas\n")
+	writeDailyFocus(b, data, clock.Date(now), opts)
 
 	// High priority todos
-	todosContent, _, err := r.storage.ReadFile(ctx, "todos.md")
+	todosContent, err := data.read(storage.TodosFile)
 	if err == nil {
 		tf, err := storage.ParseTodos(todosContent)
 		if err == nil {
 			highPriorityCount := 0
 			for _, todo := range tf.Active {
-				if todo.Priority == storage.PriorityHigh {
+				if todo.Priority.AtLeast(storage.PriorityHigh) {
 					highPriorityCount++
 				}
 			}
@@ -96,7 +293,7 @@ func (r *SummaryResource) Read(ctx context.Context, req *mcp.ReadResourceRequest
 	}
 
 	// Milestones due this week
-	strategyContent, _, err := r.storage.ReadFile(ctx, "strategy.md")
+	strategyContent, err := data.read(storage.StrategyFile)
 	if err == nil {
 		s, err := storage.ParseStrategy(strategyContent)
 		if err == nil {
@@ -104,18 +301,24 @@ func (r *SummaryResource) Read(ctx context.Context, req *mcp.ReadResourceRequest
 			for _, m := range s.ActiveMilestones {
 				if m.Due != nil && !m.Due.Before(weekStart) && !m.Due.After(weekEnd) {
 					milestonesThisWeek++
-					b.WriteString(fmt.Sprintf("- Milestone due this week: \"%s\"\n", m.Text))
+					if !opts.Brief() {
+						b.WriteString(fmt.Sprintf("- Milestone due this week: \"%s\"\n", m.Text))
+					}
 				}
 			}
+			if milestonesThisWeek > 0 && opts.Brief() {
+				b.WriteString(fmt.Sprintf("- %d milestones due this week\n", milestonesThisWeek))
+			}
 			if milestonesThisWeek == 0 && len(s.ActiveMilestones) > 0 {
 				b.WriteString(fmt.Sprintf("- %d active milestones (none due this week)\n", len(s.ActiveMilestones)))
 			}
+			writeKeyResults(b, s.ActiveMilestones, opts)
 		}
 	}
 
 	// Overdue reminders
-	remindersContent, _, err := r.storage.ReadFile(ctx, "reminders.md")
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+	remindersContent, err := data.read(storage.RemindersFile)
+	today := clock.Date(now)
 	if err == nil {
 		rf, err := storage.ParseReminders(remindersContent)
 		if err == nil {
@@ -125,7 +328,9 @@ func (r *SummaryResource) Read(ctx context.Context, req *mcp.ReadResourceRequest
 					overdue = append(overdue, reminder)
 				}
 			}
-			if len(overdue) > 0 {
+			if len(overdue) > 0 && opts.Brief() {
+				b.WriteString(fmt.Sprintf("- ⚠️ %d overdue reminders\n", len(overdue)))
+			} else if len(overdue) > 0 {
 				// Sort by date (oldest first)
 				sort.Slice(overdue, func(i, j int) bool {
 					return overdue[i].Date.Before(overdue[j].Date)
@@ -139,10 +344,89 @@ func (r *SummaryResource) Read(ctx context.Context, req *mcp.ReadResourceRequest
 		}
 	}
 	b.WriteString("\n")
+}
+
+// writeKeyResults reports the key result progress of active milestones.
+// Brief summaries give the average; detailed ones list each key result.
+func writeKeyResults(b *strings.Builder, milestones []storage.Milestone, opts summary.Options) {
+	total, count := 0, 0
+	for _, m := range milestones {
+		p, ok := m.KeyResultProgress()
+		if !ok {
+			continue
+		}
+		total += p
+		count++
+		if opts.Brief() {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("- Key results of \"%s\": %d%%\n", m.Text, p))
+		if opts.Detailed() {
+			for _, k := range m.KeyResults {
+				value := storage.FormatAmount(k.Current) + "/" + storage.FormatAmount(k.Target)
+				if k.Unit != "" {
+					value += " " + k.Unit
+				}
+				b.WriteString(fmt.Sprintf("  - %s: %s (%d%%)\n", k.Text, value, k.Progress()))
+			}
+		}
+	}
+	if count > 0 && opts.Brief() {
+		milestones := "milestones"
+		if count == 1 {
+			milestones = "milestone"
+		}
+		b.WriteString(fmt.Sprintf("- Key results: %d%% on average across %d %s\n", int(math.Round(float64(total)/float64(count))), count, milestones))
+	}
+}
+
+// writeDailyFocus reports progress on the focus picked for today, if any.
+func writeDailyFocus(b *strings.Builder, data summaryData, today time.Time, opts summary.Options) {
+	content, err := data.read(storage.FocusFile)
+	if err != nil {
+		return
+	}
+	focus, err := storage.ParseFocus(content)
+	if err != nil || len(focus.Items) == 0 || !focus.Date.Equal(today) {
+		return
+	}
+	var todos *storage.TodoFile
+	if content, err := data.read(storage.TodosFile); err == nil {
+		todos, _ = storage.ParseTodos(content)
+	}
+	var strategy *storage.Strategy
+	if content, err := data.read(storage.StrategyFile); err == nil {
+		strategy, _ = storage.ParseStrategy(content)
+	}
+
+	statuses := storage.ResolveFocus(focus, todos, strategy)
+	done := 0
+	for _, s := range statuses {
+		if s.Done {
+			done++
+		}
+	}
+	b.WriteString(fmt.Sprintf("- 🎯 Today's focus: %d of %d done\n", done, len(statuses)))
+	if opts.Brief() {
+		return
+	}
+	for _, s := range statuses {
+		box := "[ ]"
+		if s.Done {
+			box = "[x]"
+		}
+		line := fmt.Sprintf("  - %s %s", box, s.Text)
+		if s.Subtasks > 0 {
+			line += fmt.Sprintf(" (%d/%d subtasks)", s.SubtasksDone, s.Subtasks)
+		}
+		b.WriteString(line + "\n")
+	}
+}
 
-	// --- Reading Queue ---
+// writeReading reports the reading queue and what was read this week.
+func writeReading(b *strings.Builder, data summaryData, weekStart, weekEnd time.Time) {
 	b.WriteString("### Reading Queue\n")
-	readingContent, _, err := r.storage.ReadFile(ctx, "reading-list.md")
+	readingContent, err := data.read(storage.ReadingListFile)
 	if err == nil {
 		rl, err := storage.ParseReadingList(readingContent)
 		if err == nil {
@@ -161,27 +445,84 @@ func (r *SummaryResource) Read(ctx context.Context, req *mcp.ReadResourceRequest
 		}
 	}
 	b.WriteString("\n")
+}
 
-	// --- Recent Completions ---
+// writeCompletions lists the week's completions: the five most recent
+// normally, all of them when detailed, and just the count when brief.
+func writeCompletions(b *strings.Builder, data summaryData, weekStart, weekEnd time.Time, opts summary.Options) {
 	b.WriteString("### Recent Completions\n")
-	completions := r.getRecentCompletions(ctx, weekStart)
-	if len(completions) == 0 {
+	completions := getRecentCompletions(data, weekStart, weekEnd.AddDate(0, 0, 1))
+	switch {
+	case len(completions) == 0:
 		b.WriteString("- *No completions this week*\n")
-	} else {
+	case opts.Brief():
+		b.WriteString(fmt.Sprintf("- ✓ %d completed this week\n", len(completions)))
+	default:
+		if len(completions) > 5 && !opts.Detailed() {
+			completions = completions[:5]
+		}
 		for _, completion := range completions {
 			b.WriteString(fmt.Sprintf("- ✓ %s (%s)\n", completion.text, completion.date.Format("Jan 2")))
 		}
 	}
+	b.WriteString("\n")
+}
 
-	return &mcp.ReadResourceResult{
-		Contents: []*mcp.ResourceContents{
-			{
-				URI:      "momentum://weekly-summary",
-				MIMEType: "text/markdown",
-				Text:     b.String(),
-			},
-		},
-	}, nil
+// writeTimeTracked summarizes the week's time log: total hours, the top
+// projects (all of them when detailed, none when brief), and any running
+// timer. The section is omitted if nothing was logged.
+func writeTimeTracked(b *strings.Builder, data summaryData, weekStart, weekEnd, now time.Time, opts summary.Options) {
+	content, err := data.read(storage.TimeLogFile)
+	if err != nil {
+		return
+	}
+	l, err := storage.ParseTimeLog(content)
+	if err != nil {
+		return
+	}
+
+	var total time.Duration
+	sessions := 0
+	byProject := make(map[string]time.Duration)
+	for _, e := range l.Entries {
+		// Sessions count towards the week of the user's day they started
+		if day := clock.Date(e.Start.In(now.Location())); day.Before(weekStart) || !day.Before(weekEnd) {
+			continue
+		}
+		d := e.Duration(now)
+		total += d
+		sessions++
+		if e.Project != "" {
+			byProject[e.Project] += d
+		}
+	}
+	if sessions == 0 {
+		return
+	}
+
+	b.WriteString("### Time Tracked\n")
+	b.WriteString(fmt.Sprintf("- %.1fh logged across %d sessions\n", total.Hours(), sessions))
+
+	projects := make([]string, 0, len(byProject))
+	for p := range byProject {
+		projects = append(projects, p)
+	}
+	sort.Slice(projects, func(i, j int) bool { return byProject[projects[i]] > byProject[projects[j]] })
+	switch {
+	case opts.Brief():
+		projects = nil
+	case len(projects) > 3 && !opts.Detailed():
+		projects = projects[:3]
+	}
+	for _, p := range projects {
+		b.WriteString(fmt.Sprintf("- %s: %.1fh\n", p, byProject[p].Hours()))
+	}
+
+	if i := l.Running(); i >= 0 && l.Entries[i].Start.Before(weekEnd) {
+		e := l.Entries[i]
+		b.WriteString(fmt.Sprintf("- ⏱ Timer running: \"%s\" (since %s)\n", e.Text, e.Start.Format("Jan 2 15:04")))
+	}
+	b.WriteString("\n")
 }
 
 // completion represents a completed item from any source.
@@ -190,16 +531,17 @@ type completion struct {
 	date time.Time
 }
 
-// getRecentCompletions gathers completions from todos, strategy, reminders.
-func (r *SummaryResource) getRecentCompletions(ctx context.Context, since time.Time) []completion {
+// getRecentCompletions gathers completions in [since, until) from todos,
+// strategy, reminders, most recent first.
+func getRecentCompletions(data summaryData, since, until time.Time) []completion {
 	var completions []completion
 
 	// Completed todos
-	todosContent, _, err := r.storage.ReadFile(ctx, "todos.md")
+	todosContent, err := data.read(storage.TodosFile)
 	if err == nil {
 		tf, _ := storage.ParseTodos(todosContent)
 		for _, todo := range tf.Completed {
-			if todo.CompletedAt != nil && !todo.CompletedAt.Before(since) {
+			if todo.CompletedAt != nil && !todo.CompletedAt.Before(since) && todo.CompletedAt.Before(until) {
 				completions = append(completions, completion{
 					text: todo.Text,
 					date: *todo.CompletedAt,
@@ -209,11 +551,11 @@ func (r *SummaryResource) getRecentCompletions(ctx context.Context, since time.T
 	}
 
 	// Completed milestones
-	strategyContent, _, err := r.storage.ReadFile(ctx, "strategy.md")
+	strategyContent, err := data.read(storage.StrategyFile)
 	if err == nil {
 		s, _ := storage.ParseStrategy(strategyContent)
 		for _, m := range s.CompletedMilestones {
-			if m.CompletedAt != nil && !m.CompletedAt.Before(since) {
+			if m.CompletedAt != nil && !m.CompletedAt.Before(since) && m.CompletedAt.Before(until) {
 				completions = append(completions, completion{
 					text: m.Text,
 					date: *m.CompletedAt,
@@ -223,11 +565,11 @@ func (r *SummaryResource) getRecentCompletions(ctx context.Context, since time.T
 	}
 
 	// Completed reminders
-	remindersContent, _, err := r.storage.ReadFile(ctx, "reminders.md")
+	remindersContent, err := data.read(storage.RemindersFile)
 	if err == nil {
 		rf, _ := storage.ParseReminders(remindersContent)
 		for _, reminder := range rf.Completed {
-			if reminder.CompletedAt != nil && !reminder.CompletedAt.Before(since) {
+			if reminder.CompletedAt != nil && !reminder.CompletedAt.Before(since) && reminder.CompletedAt.Before(until) {
 				completions = append(completions, completion{
 					text: reminder.Text,
 					date: *reminder.CompletedAt,
@@ -241,17 +583,12 @@ func (r *SummaryResource) getRecentCompletions(ctx context.Context, since time.T
 		return completions[i].date.After(completions[j].date)
 	})
 
-	// Limit to 5 most recent
-	if len(completions) > 5 {
-		completions = completions[:5]
-	}
-
 	return completions
 }
 
-// formatTimeSince returns a human-readable time since string.
-func formatTimeSince(t time.Time) string {
-	duration := time.Since(t)
+// formatTimeSince returns a human-readable time between t and now.
+func formatTimeSince(t, now time.Time) string {
+	duration := now.Sub(t)
 
 	if duration < time.Minute {
 		return "just now"
@@ -276,3 +613,40 @@ func formatTimeSince(t time.Time) string {
 	}
 	return fmt.Sprintf("%d days ago", days)
 }
+
+// writeGitHubWeek reports a past week's GitHub activity. Streaks, goals and
+// the last commit only make sense for the current week, so they are left out.
+func writeGitHubWeek(b *strings.Builder, week *GitHubWeek, opts summary.Options) {
+	b.WriteString(fmt.Sprintf("- GitHub: %d commits across %d repos\n", week.Commits, week.ReposActive))
+	if opts.Brief() {
+		return
+	}
+	if week.PullRequestsOpened > 0 || week.PullRequestsMerged > 0 || week.IssuesClosed > 0 {
+		b.WriteString(fmt.Sprintf("- Pull requests: %d opened, %d merged; %d issues closed\n",
+			week.PullRequestsOpened, week.PullRequestsMerged, week.IssuesClosed))
+	}
+	maxRepos := 5
+	if opts.Detailed() {
+		maxRepos = 0
+	}
+	if repos := formatRepoCommits(week.CommitsByRepo, week.PrivateRepoCommits, maxRepos); repos != "" {
+		b.WriteString("- Commits by repo: " + repos + "\n")
+	}
+}
+
+// formatRepoCommits lists the busiest repos as "owner/repo (n)", at most
+// maxRepos of them (0 for all), followed by the private repo total.
+func formatRepoCommits(repos []RepoCommits, private, maxRepos int) string {
+	var parts []string
+	for i, rc := range repos {
+		if i == maxRepos && maxRepos > 0 {
+			parts = append(parts, fmt.Sprintf("%d more", len(repos)-maxRepos))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (%d)", rc.Repo, rc.Commits))
+	}
+	if private > 0 {
+		parts = append(parts, fmt.Sprintf("private repos (%d)", private))
+	}
+	return strings.Join(parts, ", ")
+}
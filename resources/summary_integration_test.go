@@ -34,10 +34,10 @@ func TestSummaryResource_Integration(t *testing.T) {
 	}
 
 	// Create GitHub activity resource
-	githubActivity := NewGitHubActivityResource(token, username)
+	githubActivity := NewGitHubActivityResource(token, username, nil, 0, nil)
 
 	// Create summary resource
-	resource := NewSummaryResource(store, githubActivity)
+	resource := NewSummaryResource(store, githubActivity, nil, nil)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
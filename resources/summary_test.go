@@ -0,0 +1,144 @@
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/summary"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestWriteGitHubWeek(t *testing.T) {
+	week := &GitHubWeek{
+		Commits:            14,
+		ReposActive:        2,
+		PullRequestsMerged: 1,
+		CommitsByRepo:      []RepoCommits{{Repo: "dang-w/momentum", Commits: 10}},
+		PrivateRepoCommits: 4,
+	}
+
+	var b strings.Builder
+	writeGitHubWeek(&b, week, summary.Options{})
+	want := "- GitHub: 14 commits across 2 repos\n" +
+		"- Pull requests: 0 opened, 1 merged; 0 issues closed\n" +
+		"- Commits by repo: dang-w/momentum (10), private repos (4)\n"
+	if b.String() != want {
+		t.Errorf("writeGitHubWeek() =\n%s\nwant\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	writeGitHubWeek(&b, week, summary.Options{Verbosity: summary.Brief})
+	if b.String() != "- GitHub: 14 commits across 2 repos\n" {
+		t.Errorf("brief writeGitHubWeek() = %q", b.String())
+	}
+}
+
+func TestReadWeek_NotFound(t *testing.T) {
+	r := NewSummaryResource(nil, nil, nil, clock.NewFake(time.Date(2026, 2, 4, 10, 0, 0, 0, time.UTC)))
+	for _, week := range []string{"2026-W07", "2026-W99", "last-week"} {
+		req := &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: weekSummaryPrefix + week}}
+		if _, err := r.ReadWeek(context.Background(), req); err == nil {
+			t.Errorf("ReadWeek(%s) succeeded, want not found", week)
+		}
+	}
+}
+
+func TestReadWeek_NotStartedLocally(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Monday in UTC, but still Sunday evening in New York
+	c := clock.InLocation(clock.NewFake(time.Date(2026, 2, 9, 3, 0, 0, 0, time.UTC)), newYork)
+	r := NewSummaryResource(nil, nil, nil, c)
+	req := &mcp.ReadResourceRequest{Params: &mcp.ReadResourceParams{URI: weekSummaryPrefix + "2026-W07"}}
+	if _, err := r.ReadWeek(context.Background(), req); err == nil {
+		t.Error("ReadWeek(2026-W07) succeeded before the week started in New York")
+	}
+}
+
+func TestRender_ReusedUntilFilesChange(t *testing.T) {
+	ctx := context.Background()
+	mem := storage.NewMemoryStorage(map[string]string{
+		storage.TodosFile: "# Active\n\n## Normal\n- [ ] Tidy up {id:aaaa1111}\n\n# Completed\n",
+	}, nil)
+	fake := clock.NewFake(time.Date(2026, 2, 4, 10, 0, 0, 0, time.UTC))
+	r := NewSummaryResource(mem, nil, nil, fake)
+	opts := summary.Options{Sections: []string{summary.SectionFocus}}
+
+	first := r.Render(ctx, fake.Now(), opts)
+	if !strings.Contains(first, "1 todos pending") {
+		t.Fatalf("unexpected summary:\n%s", first)
+	}
+	if again := r.Render(ctx, fake.Now(), opts); again != first {
+		t.Errorf("second render differs:\n%s", again)
+	}
+
+	_, sha, _ := mem.ReadFile(ctx, storage.TodosFile)
+	if err := mem.WriteFile(ctx, storage.TodosFile, "# Active\n\n## High Priority\n- [ ] Ship it {id:bbbb2222}\n\n# Completed\n", sha, "Edit"); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Render(ctx, fake.Now(), opts); !strings.Contains(got, "1 high-priority todos pending") {
+		t.Errorf("summary after the todos changed:\n%s", got)
+	}
+}
+
+func TestRender_DailyFocus(t *testing.T) {
+	ctx := context.Background()
+	focus := "# Focus\n\n## 2026-02-04\n- todo:aaaa1111 Tidy up\n- todo:bbbb2222 Ship it\n"
+	mem := storage.NewMemoryStorage(map[string]string{
+		storage.TodosFile: "# Active\n\n## Normal\n- [ ] Tidy up {id:aaaa1111}\n\n" +
+			"# Completed\n- [x] Ship it {id:bbbb2222,completed:2026-02-04}\n",
+		storage.FocusFile: focus,
+	}, nil)
+	fake := clock.NewFake(time.Date(2026, 2, 4, 10, 0, 0, 0, time.UTC))
+	r := NewSummaryResource(mem, nil, nil, fake)
+	opts := summary.Options{Sections: []string{summary.SectionFocus}}
+
+	got := r.Render(ctx, fake.Now(), opts)
+	for _, want := range []string{"Today's focus: 1 of 2 done", "  - [ ] Tidy up", "  - [x] Ship it"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("summary lacks %q:\n%s", want, got)
+		}
+	}
+
+	// A focus from another day isn't today's
+	if got := r.Render(ctx, fake.Now().AddDate(0, 0, 1), opts); strings.Contains(got, "Today's focus") {
+		t.Errorf("summary shows yesterday's focus:\n%s", got)
+	}
+}
+
+func TestWriteKeyResults(t *testing.T) {
+	milestones := []storage.Milestone{
+		{Text: "Public beta", KeyResults: []storage.KeyResult{
+			{Text: "Beta users", Current: 40, Target: 100, Unit: "users"},
+			{Text: "Invites sent", Current: 80, Target: 100},
+		}},
+		{Text: "Docs site"},
+		{Text: "Pricing", KeyResults: []storage.KeyResult{{Text: "Interviews", Current: 5, Target: 5}}},
+	}
+
+	var b strings.Builder
+	writeKeyResults(&b, milestones, summary.Options{})
+	want := "- Key results of \"Public beta\": 60%\n- Key results of \"Pricing\": 100%\n"
+	if b.String() != want {
+		t.Errorf("writeKeyResults() =\n%s\nwant\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	writeKeyResults(&b, milestones[:1], summary.Options{Verbosity: summary.Detailed})
+	want = "- Key results of \"Public beta\": 60%\n  - Beta users: 40/100 users (40%)\n  - Invites sent: 80/100 (80%)\n"
+	if b.String() != want {
+		t.Errorf("detailed writeKeyResults() =\n%s\nwant\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	writeKeyResults(&b, milestones, summary.Options{Verbosity: summary.Brief})
+	if b.String() != "- Key results: 80% on average across 2 milestones\n" {
+		t.Errorf("brief writeKeyResults() = %q", b.String())
+	}
+}
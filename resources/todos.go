@@ -32,7 +32,7 @@ func (r *TodosResource) Register(server *mcp.Server) {
 
 // Read fetches and formats the todos list.
 func (r *TodosResource) Read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
-	content, _, err := r.storage.ReadFile(ctx, "todos.md")
+	content, _, err := r.storage.ReadFile(ctx, storage.TodosFile)
 	if err != nil {
 		return nil, fmt.Errorf("reading todos.md: %w", err)
 	}
@@ -43,11 +43,14 @@ func (r *TodosResource) Read(ctx context.Context, req *mcp.ReadResourceRequest)
 	}
 
 	// Count by priority
+	urgentCount := 0
 	highCount := 0
 	normalCount := 0
 	somedayCount := 0
 	for _, todo := range tf.Active {
 		switch todo.Priority {
+		case storage.PriorityUrgent:
+			urgentCount++
 		case storage.PriorityHigh:
 			highCount++
 		case storage.PriorityNormal:
@@ -64,6 +67,9 @@ func (r *TodosResource) Read(ctx context.Context, req *mcp.ReadResourceRequest)
 	// Summary line
 	b.WriteString(fmt.Sprintf("**%d active** (", len(tf.Active)))
 	parts := []string{}
+	if urgentCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d urgent", urgentCount))
+	}
 	if highCount > 0 {
 		parts = append(parts, fmt.Sprintf("%d high priority", highCount))
 	}
@@ -76,12 +82,23 @@ func (r *TodosResource) Read(ctx context.Context, req *mcp.ReadResourceRequest)
 	b.WriteString(strings.Join(parts, ", "))
 	b.WriteString(fmt.Sprintf("), **%d completed**\n\n", len(tf.Completed)))
 
+	// Urgent section
+	if urgentCount > 0 {
+		b.WriteString("## 🚨 Urgent\n")
+		for _, todo := range tf.Active {
+			if todo.Priority == storage.PriorityUrgent {
+				writeActiveTodo(&b, todo)
+			}
+		}
+		b.WriteString("\n")
+	}
+
 	// High priority section
 	if highCount > 0 {
 		b.WriteString("## 🔴 High Priority\n")
 		for _, todo := range tf.Active {
 			if todo.Priority == storage.PriorityHigh {
-				b.WriteString(fmt.Sprintf("- [ ] %s\n", todo.Text))
+				writeActiveTodo(&b, todo)
 			}
 		}
 		b.WriteString("\n")
@@ -92,7 +109,7 @@ func (r *TodosResource) Read(ctx context.Context, req *mcp.ReadResourceRequest)
 		b.WriteString("## Normal\n")
 		for _, todo := range tf.Active {
 			if todo.Priority == storage.PriorityNormal {
-				b.WriteString(fmt.Sprintf("- [ ] %s\n", todo.Text))
+				writeActiveTodo(&b, todo)
 			}
 		}
 		b.WriteString("\n")
@@ -103,7 +120,7 @@ func (r *TodosResource) Read(ctx context.Context, req *mcp.ReadResourceRequest)
 		b.WriteString("## 💭 Someday\n")
 		for _, todo := range tf.Active {
 			if todo.Priority == storage.PrioritySomeday {
-				b.WriteString(fmt.Sprintf("- [ ] %s\n", todo.Text))
+				writeActiveTodo(&b, todo)
 			}
 		}
 		b.WriteString("\n")
@@ -132,3 +149,15 @@ func (r *TodosResource) Read(ctx context.Context, req *mcp.ReadResourceRequest)
 		},
 	}, nil
 }
+
+// writeActiveTodo writes an active todo's line and its subtasks.
+func writeActiveTodo(b *strings.Builder, todo storage.Todo) {
+	b.WriteString(fmt.Sprintf("- [ ] %s\n", todo.Text))
+	for _, sub := range todo.Subtasks {
+		checkbox := "[ ]"
+		if sub.Completed {
+			checkbox = "[x]"
+		}
+		b.WriteString(fmt.Sprintf("  - %s %s\n", checkbox, sub.Text))
+	}
+}
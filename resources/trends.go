@@ -0,0 +1,202 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/trends"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TrendsResource charts the daily snapshots in metrics/history.jsonl.
+type TrendsResource struct {
+	storage storage.Storage
+	clock   clock.Clock
+}
+
+// NewTrendsResource creates a new TrendsResource. A nil clock uses the system clock.
+func NewTrendsResource(s storage.Storage, c clock.Clock) *TrendsResource {
+	return &TrendsResource{storage: s, clock: clock.Or(c)}
+}
+
+// Register registers the momentum://trends resource with the MCP server.
+func (r *TrendsResource) Register(server *mcp.Server) {
+	server.AddResource(&mcp.Resource{
+		URI:         "momentum://trends",
+		Name:        "Trends",
+		Description: "Daily snapshots of active, completed and overdue counts over the last 30 and 90 days",
+		MIMEType:    "text/markdown",
+	}, r.Read)
+}
+
+// Read renders the snapshot history.
+func (r *TrendsResource) Read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	content, _, err := r.storage.ReadFile(ctx, trends.HistoryPath)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("reading %s: %w", trends.HistoryPath, err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      "momentum://trends",
+				MIMEType: "text/markdown",
+				Text:     renderTrends(trends.ParseHistory(content), clock.Today(r.clock)),
+			},
+		},
+	}, nil
+}
+
+// trendRow is one table row: the snapshot at the end of a period and the
+// completions during it.
+type trendRow struct {
+	label string
+	snap  trends.Snapshot
+	done  trends.Snapshot // deltas of the Completed/Read counts
+}
+
+// renderTrends draws a daily table for the last 30 days and a weekly one for
+// the last 90, plus a comparison of completions against the previous 30 days.
+func renderTrends(history []trends.Snapshot, today time.Time) string {
+	var b strings.Builder
+	b.WriteString("# Trends\n\n")
+
+	if len(history) == 0 {
+		b.WriteString("*No snapshots yet. Daily snapshots are recorded to " + trends.HistoryPath + " when TRENDS_ENABLED is set.*\n")
+		return b.String()
+	}
+
+	daily := trendRows(history, today.AddDate(0, 0, -29), today, func(d time.Time) string {
+		return d.Format("2006-01-02")
+	})
+	weekly := trendRows(history, today.AddDate(0, 0, -89), today, func(d time.Time) string {
+		return "Week of " + startOfWeek(d).Format("2006-01-02")
+	})
+
+	// Completions in the last 30 days against the 30 before
+	recent := completedBetween(history, today.AddDate(0, 0, -30), today)
+	previous := completedBetween(history, today.AddDate(0, 0, -60), today.AddDate(0, 0, -30))
+	if recent != nil {
+		b.WriteString(fmt.Sprintf("**Last 30 days:** %d todos, %d milestones, %d reminders done, %d articles read", recent.Todos.Completed, recent.Milestones.Completed, recent.Reminders.Completed, recent.Reading.Read))
+		if previous != nil {
+			b.WriteString(fmt.Sprintf(" (previous 30 days: %d todos, %d milestones, %d reminders, %d articles)", previous.Todos.Completed, previous.Milestones.Completed, previous.Reminders.Completed, previous.Reading.Read))
+		}
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString("## Last 30 Days\n\n")
+	writeTrendTable(&b, "Date", daily)
+	b.WriteString("\n## Last 90 Days (weekly)\n\n")
+	writeTrendTable(&b, "Week", weekly)
+	return b.String()
+}
+
+// trendRows groups snapshots in [from, to] by label (one row per day or
+// week). Each row shows the last snapshot in its period and the completions
+// since the previous snapshot before the period.
+func trendRows(history []trends.Snapshot, from, to time.Time, label func(time.Time) string) []trendRow {
+	var rows []trendRow
+	var prev *trends.Snapshot
+	for i := range history {
+		snap := history[i]
+		d, err := time.Parse("2006-01-02", snap.Date)
+		if err != nil || d.After(to) {
+			continue
+		}
+		if d.Before(from) {
+			prev = &history[i]
+			continue
+		}
+
+		var done trends.Snapshot
+		if prev != nil {
+			done = delta(*prev, snap)
+		}
+		l := label(d)
+		if n := len(rows); n > 0 && rows[n-1].label == l {
+			rows[n-1].snap = snap
+			rows[n-1].done = add(rows[n-1].done, done)
+		} else {
+			rows = append(rows, trendRow{label: l, snap: snap, done: done})
+		}
+		prev = &history[i]
+	}
+	return rows
+}
+
+// completedBetween sums completions from the last snapshot on or before
+// from to the last one on or before to. If the history starts inside the
+// window, it counts from the first snapshot. It returns nil if there are no
+// snapshots to compare.
+func completedBetween(history []trends.Snapshot, from, to time.Time) *trends.Snapshot {
+	var start, firstInside, end *trends.Snapshot
+	for i := range history {
+		d, err := time.Parse("2006-01-02", history[i].Date)
+		if err != nil || d.After(to) {
+			continue
+		}
+		if !d.After(from) {
+			start = &history[i]
+		} else if firstInside == nil {
+			firstInside = &history[i]
+		}
+		end = &history[i]
+	}
+	if start == nil {
+		start = firstInside
+	}
+	if start == nil || end == nil {
+		return nil
+	}
+	total := delta(*start, *end)
+	return &total
+}
+
+// delta is the increase in completed counts from a to b. Counts can drop when
+// items are deleted or archived; those show as zero rather than negative.
+func delta(a, b trends.Snapshot) trends.Snapshot {
+	pos := func(n int) int {
+		if n < 0 {
+			return 0
+		}
+		return n
+	}
+	var d trends.Snapshot
+	d.Todos.Completed = pos(b.Todos.Completed - a.Todos.Completed)
+	d.Reminders.Completed = pos(b.Reminders.Completed - a.Reminders.Completed)
+	d.Milestones.Completed = pos(b.Milestones.Completed - a.Milestones.Completed)
+	d.Reading.Read = pos(b.Reading.Read - a.Reading.Read)
+	return d
+}
+
+func add(a, b trends.Snapshot) trends.Snapshot {
+	a.Todos.Completed += b.Todos.Completed
+	a.Reminders.Completed += b.Reminders.Completed
+	a.Milestones.Completed += b.Milestones.Completed
+	a.Reading.Read += b.Reading.Read
+	return a
+}
+
+func writeTrendTable(b *strings.Builder, heading string, rows []trendRow) {
+	if len(rows) == 0 {
+		b.WriteString("*No snapshots in this period.*\n")
+		return
+	}
+	b.WriteString("| " + heading + " | Todos open | Todos done | Overdue | Milestones open | Milestones done | To read | Read |\n")
+	b.WriteString("|---|---|---|---|---|---|---|---|\n")
+	for _, row := range rows {
+		s, d := row.snap, row.done
+		b.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d | %d | %d | %d |\n",
+			row.label,
+			s.Todos.Active, d.Todos.Completed,
+			s.Reminders.Overdue+s.Milestones.Overdue,
+			s.Milestones.Active, d.Milestones.Completed,
+			s.Reading.ToRead, d.Reading.Read,
+		))
+	}
+}
@@ -0,0 +1,43 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/trends"
+)
+
+func TestRenderTrends(t *testing.T) {
+	snap := func(date string, active, completed int) trends.Snapshot {
+		s := trends.Snapshot{Date: date}
+		s.Todos.Active = active
+		s.Todos.Completed = completed
+		return s
+	}
+	history := []trends.Snapshot{
+		snap("2025-12-01", 10, 0), // outside both tables, used as the baseline
+		snap("2026-01-05", 8, 4),
+		snap("2026-02-08", 6, 10),
+		snap("2026-02-09", 7, 12),
+		snap("2026-02-10", 5, 15),
+	}
+	today := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	out := renderTrends(history, today)
+
+	if !strings.Contains(out, "**Last 30 days:** 11 todos") || !strings.Contains(out, "previous 30 days: 4 todos") {
+		t.Errorf("unexpected summary:\n%s", out)
+	}
+	if !strings.Contains(out, "| 2026-02-10 | 5 | 3 |") || !strings.Contains(out, "| 2026-02-08 | 6 | 6 |") {
+		t.Errorf("unexpected daily rows:\n%s", out)
+	}
+	// 2026-02-09 and 2026-02-10 fall in the week of 2026-02-09
+	if !strings.Contains(out, "| Week of 2026-02-09 | 5 | 5 |") {
+		t.Errorf("unexpected weekly rows:\n%s", out)
+	}
+
+	if empty := renderTrends(nil, today); !strings.Contains(empty, "No snapshots yet") {
+		t.Errorf("unexpected empty output:\n%s", empty)
+	}
+}
@@ -0,0 +1,32 @@
+package resources
+
+import "github.com/dang-w/momentum-mcp-server/storage"
+
+// fileURIs lists the fixed resources rendered from each data file.
+var fileURIs = map[string][]string{
+	storage.TodosFile:       {"momentum://todos", "momentum://weekly-summary"},
+	storage.StrategyFile:    {"momentum://strategy", "momentum://weekly-summary"},
+	storage.ReadingListFile: {"momentum://reading-list", "momentum://weekly-summary"},
+	storage.RemindersFile:   {"momentum://reminders", "momentum://weekly-summary"},
+	storage.TimeLogFile:     {"momentum://weekly-summary"},
+	storage.JournalFile:     {"momentum://journal"},
+	storage.NotesFile:       {"momentum://notes"},
+	storage.GoalsFile:       {"momentum://github-activity"},
+	storage.FocusFile:       {"momentum://weekly-summary"},
+}
+
+// URIsForFiles returns the URIs of the resources whose content depends on
+// any of the named data files, each once, in the order first seen.
+func URIsForFiles(names []string) []string {
+	uris := []string{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		for _, uri := range fileURIs[name] {
+			if !seen[uri] {
+				seen[uri] = true
+				uris = append(uris, uri)
+			}
+		}
+	}
+	return uris
+}
@@ -0,0 +1,19 @@
+package resources
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestURIsForFiles(t *testing.T) {
+	got := strings.Join(URIsForFiles([]string{storage.TodosFile, storage.StrategyFile, "README.md"}), ",")
+	want := "momentum://todos,momentum://weekly-summary,momentum://strategy"
+	if got != want {
+		t.Errorf("URIsForFiles() = %s, want %s", got, want)
+	}
+	if uris := URIsForFiles(nil); uris == nil || len(uris) != 0 {
+		t.Errorf("URIsForFiles(nil) = %#v, want empty", uris)
+	}
+}
@@ -0,0 +1,61 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dang-w/momentum-mcp-server/internal/usage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UsageResource exposes per-tool call analytics.
+type UsageResource struct {
+	tracker *usage.Tracker
+}
+
+// NewUsageResource creates a new UsageResource.
+func NewUsageResource(t *usage.Tracker) *UsageResource {
+	return &UsageResource{tracker: t}
+}
+
+// usageReport is the JSON payload for momentum://usage.
+type usageReport struct {
+	Today  []usage.ToolUsage `json:"today"`
+	Last7  []usage.ToolUsage `json:"last_7_days"`
+	Last30 []usage.ToolUsage `json:"last_30_days"`
+}
+
+// Register registers the momentum://usage resource with the MCP server.
+func (r *UsageResource) Register(server *mcp.Server) {
+	server.AddResource(&mcp.Resource{
+		URI:         "momentum://usage",
+		Name:        "Tool Usage",
+		Description: "Per-tool call counts, error rates, and median latency for today, the last 7 days, and the last 30 days",
+		MIMEType:    "application/json",
+	}, r.Read)
+}
+
+// Read returns usage statistics as JSON.
+func (r *UsageResource) Read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	report := usageReport{
+		Today:  r.tracker.Summary(1),
+		Last7:  r.tracker.Summary(7),
+		Last30: r.tracker.Summary(30),
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("serializing usage: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      "momentum://usage",
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,405 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/auth"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/devdata"
+	"github.com/dang-w/momentum-mcp-server/server"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// e2eToken is the bearer token the test server accepts.
+const e2eToken = "e2e-token"
+
+// e2eNow is the fake server time; the sample data is dated around it.
+var e2eNow = time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+
+// harness is the MCP server behind the same auth middleware and streamable
+// HTTP handler main uses, on sample data in memory, with a connected client.
+type harness struct {
+	url     string
+	storage *storage.MemoryStorage
+	session *mcp.ClientSession
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	files, err := devdata.Files(e2eNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mem := storage.NewMemoryStorage(files, nil)
+	mcpServer := server.New(server.Config{
+		Storage: mem,
+		History: mem,
+		Clock:   clock.NewFake(e2eNow),
+	})
+
+	authMiddleware := auth.Middleware(auth.MiddlewareConfig{
+		Validator:           auth.NewStaticTokenValidator(e2eToken),
+		ResourceMetadataURL: "http://example.test/.well-known/oauth-protected-resource",
+	})
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", authMiddleware(mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return mcpServer }, nil)))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	transport := &mcp.StreamableClientTransport{
+		Endpoint:   srv.URL + "/mcp",
+		HTTPClient: &http.Client{Transport: bearer{e2eToken}},
+		MaxRetries: -1,
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "e2e", Version: "test"}, nil)
+	session, err := client.Connect(context.Background(), transport, nil)
+	if err != nil {
+		t.Fatalf("connecting: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+
+	return &harness{url: srv.URL, storage: mem, session: session}
+}
+
+// bearer authenticates every request with token.
+type bearer struct{ token string }
+
+func (b bearer) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// toolOutput is the shape every tool's structured output shares.
+type toolOutput struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+	ID      string          `json:"id"`
+}
+
+// call invokes a tool, failing the test on a transport or tool error.
+func (h *harness) call(t *testing.T, name string, args map[string]any) toolOutput {
+	t.Helper()
+	res, err := h.session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	if res.IsError {
+		t.Fatalf("%s: error result %+v", name, res.Content)
+	}
+	var out toolOutput
+	raw, _ := json.Marshal(res.StructuredContent)
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatalf("%s: decoding structured output %s: %v", name, raw, err)
+	}
+	return out
+}
+
+// read reads a resource, failing the test if it can't.
+func (h *harness) read(t *testing.T, uri string) string {
+	t.Helper()
+	res, err := h.session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		t.Fatalf("reading %s: %v", uri, err)
+	}
+	if len(res.Contents) == 0 || res.Contents[0].Text == "" {
+		t.Fatalf("reading %s: no content", uri)
+	}
+	if res.Contents[0].URI != uri {
+		t.Errorf("reading %s: content URI %q", uri, res.Contents[0].URI)
+	}
+	return res.Contents[0].Text
+}
+
+func TestE2E_RequiresAuth(t *testing.T) {
+	h := newHarness(t)
+	resp, err := http.Post(h.url+"/mcp", "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status %d without a token, want 401", resp.StatusCode)
+	}
+	if !strings.Contains(resp.Header.Get("WWW-Authenticate"), "resource_metadata") {
+		t.Errorf("WWW-Authenticate = %q, want resource metadata", resp.Header.Get("WWW-Authenticate"))
+	}
+}
+
+// toolCalls exercises every tool once, in order, against the sample data
+// (see internal/devdata for the IDs). wantFail marks calls expected to be
+// refused with success=false rather than succeed.
+var toolCalls = []struct {
+	tool     string
+	args     map[string]any
+	wantFail bool
+}{
+	{tool: "ping"},
+
+	// Reads
+	{tool: "get_dashboard"},
+	{tool: "get_today"},
+	{tool: "get_stats"},
+	{tool: "get_stale_items"},
+	{tool: "get_weekly_summary"},
+	{tool: "generate_weekly_review"},
+	{tool: "list_todos"},
+	{tool: "list_reminders"},
+	{tool: "list_reading_list"},
+	{tool: "list_journal"},
+	{tool: "list_notes"},
+	{tool: "list_projects"},
+	{tool: "get_project", args: map[string]any{"project": "momentum"}},
+	{tool: "get_milestones"},
+	{tool: "time_report"},
+	{tool: "read_raw_file", args: map[string]any{"file": "todos.md"}},
+	{tool: "get_item_history", args: map[string]any{"id": "a1000001"}},
+	{tool: "get_changes"},
+	{tool: "get_briefing"},
+	{tool: "validate_data"},
+	{tool: "init_data"},
+	{tool: "export_data"},
+
+	// Todos
+	{tool: "add_todo", args: map[string]any{"text": "Write e2e tests", "priority": "high"}},
+	{tool: "add_todo", args: map[string]any{"text": "Write e2e tests!"}, wantFail: true}, // near-duplicate
+	{tool: "edit_todo", args: map[string]any{"id": "a1000003", "text": "Review and merge open pull requests"}},
+	{tool: "reorder_todo", args: map[string]any{"id": "a1000004", "position": "top"}},
+	{tool: "add_subtask", args: map[string]any{"todo_id": "a1000002", "text": "Draft outline"}},
+	{tool: "complete_subtask", args: map[string]any{"todo_id": "a1000002", "text": "outline"}},
+	{tool: "reorder_todo", args: map[string]any{"id": "a1000004", "position": "after", "relative_to": "a1000006"}, wantFail: true}, // different priority
+	{tool: "complete_todo", args: map[string]any{"id": "a1000001"}},
+	{tool: "complete_todo", args: map[string]any{"id": "ffffffff"}, wantFail: true},
+	{tool: "delete_todo", args: map[string]any{"id": "a1000005", "confirm": true}},
+	{tool: "promote_todo_to_milestone", args: map[string]any{"id": "a1000004"}},
+	{tool: "add_comment", args: map[string]any{"id": "a1000002", "text": "Waiting on the designer"}},
+	{tool: "add_comment", args: map[string]any{"id": "ffffffff", "text": "Nowhere"}, wantFail: true},
+	{tool: "get_item", args: map[string]any{"id": "a1000002"}},
+	{tool: "get_item", args: map[string]any{"id": "c1000002"}},
+	{tool: "get_item", args: map[string]any{"id": "ffffffff"}, wantFail: true},
+
+	// Reminders
+	{tool: "set_reminder", args: map[string]any{"date": "2026-03-10", "text": "Check e2e results"}},
+	{tool: "set_reminder", args: map[string]any{"date": "10 March", "text": "Bad date"}, wantFail: true},
+	{tool: "edit_reminder", args: map[string]any{"id": "d1000003", "text": "Call the accountant"}},
+	{tool: "complete_reminder", args: map[string]any{"id": "d1000002"}},
+	{tool: "delete_reminder", args: map[string]any{"id": "d1000004", "confirm": true}},
+	{tool: "convert_reminder_to_todo", args: map[string]any{"id": "d1000001"}},
+
+	// Reading list
+	{tool: "add_to_reading_list", args: map[string]any{"url": "https://example.com/e2e"}},
+	{tool: "edit_reading_item", args: map[string]any{"id": "c1000002", "notes": "Resources and templates"}},
+	{tool: "mark_read", args: map[string]any{"id": "c1000001"}},
+	{tool: "delete_reading_item", args: map[string]any{"id": "c1000003", "confirm": true}},
+	{tool: "dedupe_reading_list", args: map[string]any{"dry_run": true}},
+	{tool: "plan_reading", args: map[string]any{"weekly_minutes": 60}},
+	{tool: "archive_reading", args: map[string]any{"dry_run": true}},
+	{tool: "import_reading_list", args: map[string]any{"content": "URL,Title,Selection,Folder,Timestamp\nhttps://example.com/imported,Imported,,Unread,1700000000\n"}},
+	{tool: "fetch_feeds", wantFail: true}, // the sample data has no feeds
+
+	// Strategy
+	{tool: "edit_milestone", args: map[string]any{"id": "b1000002", "text": "First 200 users"}},
+	{tool: "update_key_result", args: map[string]any{"milestone_id": "b1000002", "id": "kr_100001", "current": 45}},
+	{tool: "update_key_result", args: map[string]any{"milestone_id": "b1000002", "text": "Referrals", "target": 20}},
+	{tool: "update_key_result", args: map[string]any{"milestone_id": "b1000002", "id": "kr_ffffff", "current": 1}, wantFail: true},
+	{tool: "update_milestone", args: map[string]any{"id": "b1000001", "complete": true}},
+	{tool: "apply_phase_template", wantFail: true}, // nor phase templates
+	{tool: "advance_phase", args: map[string]any{"phase": "Phase 3: Growth", "skip_template": true, "objectives": []string{"Grow the audience"}}},
+	{tool: "list_phases"},
+	{tool: "set_contribution_goal", args: map[string]any{"weekly_commits": 15}},
+
+	// Journal, notes and time
+	{tool: "add_journal_entry", args: map[string]any{"text": "Ran the e2e suite."}},
+	{tool: "add_note", args: map[string]any{"note": "E2E tests run against sample data."}},
+	{tool: "delete_note", args: map[string]any{"id": "f1000001"}},
+	{tool: "list_trash"},
+	{tool: "restore_item", args: map[string]any{"id": "a1000005"}},
+	{tool: "restore_item", args: map[string]any{"id": "a1000005"}, wantFail: true}, // already restored
+	{tool: "start_timer", args: map[string]any{"id": "a1000002"}},
+	{tool: "stop_timer"},
+	{tool: "set_focus", args: map[string]any{"ids": []string{"a1000002", "b1000002"}}},
+	{tool: "get_focus"},
+
+	// Raw files and import
+	{tool: "append_to_file", args: map[string]any{"file": "strategy.md", "section": "Notes", "text": "- Added by the e2e suite."}},
+	{tool: "patch_file", args: map[string]any{"file": "strategy.md", "old_text": "Added by the e2e suite.", "new_text": "Patched by the e2e suite."}},
+	{tool: "import_data", args: map[string]any{"data": `{"todos":{"active":[],"completed":[]}}`, "dry_run": true}},
+	{tool: "migrate_ids", args: map[string]any{"dry_run": true}},
+	{tool: "migrate_ids"},
+
+	// Preferences
+	{tool: "set_preferences", args: map[string]any{"quiet_hours": "22:00-07:00", "digest": "weekdays"}},
+	{tool: "get_preferences"},
+	{tool: "set_preferences", args: map[string]any{"channels": []string{"pager"}}, wantFail: true},
+}
+
+func TestE2E_Tools(t *testing.T) {
+	h := newHarness(t)
+	ctx := context.Background()
+
+	listed, err := h.session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	covered := map[string]bool{}
+	for _, c := range toolCalls {
+		covered[c.tool] = true
+	}
+	for _, tool := range listed.Tools {
+		if !covered[tool.Name] {
+			t.Errorf("tool %s is not exercised; add it to toolCalls", tool.Name)
+		}
+		if tool.Description == "" {
+			t.Errorf("tool %s has no description", tool.Name)
+		}
+		if schemaType(tool.InputSchema) != "object" {
+			t.Errorf("tool %s input schema type = %q, want object", tool.Name, schemaType(tool.InputSchema))
+		}
+		if tool.OutputSchema == nil || schemaType(tool.OutputSchema) != "object" {
+			t.Errorf("tool %s has no object output schema", tool.Name)
+		}
+		delete(covered, tool.Name)
+	}
+	for name := range covered {
+		t.Errorf("toolCalls lists %s, which is not registered", name)
+	}
+
+	// The server validates each result against the tool's output schema, so
+	// a call succeeding at the protocol level is a schema check too
+	for _, c := range toolCalls {
+		args := c.args
+		if args == nil {
+			args = map[string]any{}
+		}
+		out := h.call(t, c.tool, args)
+		if c.tool == "ping" {
+			continue
+		}
+		if out.Success == c.wantFail {
+			t.Errorf("%s(%v): success = %v, want %v: %s", c.tool, c.args, out.Success, !c.wantFail, out.Message)
+		}
+	}
+}
+
+func TestE2E_RoundTrips(t *testing.T) {
+	h := newHarness(t)
+
+	// A todo added through a tool is listed, readable as a resource, and
+	// completes into the Completed section
+	added := h.call(t, "add_todo", map[string]any{"text": "Ship the e2e harness", "priority": "high"})
+	if added.ID == "" {
+		t.Fatalf("add_todo returned no id: %+v", added)
+	}
+	if list := h.call(t, "list_todos", map[string]any{"priority": "high"}); !strings.Contains(string(list.Result), added.ID) {
+		t.Errorf("list_todos doesn't include %s: %s", added.ID, list.Result)
+	}
+	if item := h.read(t, "momentum://todo/"+added.ID); !strings.Contains(item, "Ship the e2e harness") {
+		t.Errorf("momentum://todo/%s = %s", added.ID, item)
+	}
+	h.call(t, "complete_todo", map[string]any{"id": added.ID})
+	content, _, _ := h.storage.ReadFile(context.Background(), storage.TodosFile)
+	tf, err := storage.ParseTodos(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, c := range tf.Completed {
+		found = found || c.ID == added.ID
+	}
+	if !found {
+		t.Errorf("completed todo %s not in the Completed section:\n%s", added.ID, content)
+	}
+
+	// Item history sees the add and the completion as commits
+	history := h.call(t, "get_item_history", map[string]any{"id": added.ID})
+	if !strings.Contains(string(history.Result), `"completed"`) {
+		t.Errorf("get_item_history = %s, want a completed event", history.Result)
+	}
+
+	// A reminder set for a date shows up in the resource
+	h.call(t, "set_reminder", map[string]any{"date": "2026-03-06", "text": "Demo the harness"})
+	if reminders := h.read(t, "momentum://reminders"); !strings.Contains(reminders, "Demo the harness") {
+		t.Errorf("momentum://reminders missing the new reminder:\n%s", reminders)
+	}
+
+	// Export and import are inverse: importing the export changes nothing
+	export := h.call(t, "export_data", map[string]any{})
+	imported := h.call(t, "import_data", map[string]any{"data": export.Message, "dry_run": true})
+	var plan struct {
+		Files []struct {
+			Path   string `json:"path"`
+			Action string `json:"action"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(imported.Message), &plan); err != nil || len(plan.Files) == 0 {
+		t.Fatalf("import_data message %q: %v", imported.Message, err)
+	}
+	for _, f := range plan.Files {
+		if f.Action != "unchanged" {
+			t.Errorf("re-importing the export would %s %s: %s", f.Action, f.Path, imported.Message)
+		}
+	}
+}
+
+func TestE2E_Resources(t *testing.T) {
+	h := newHarness(t)
+	ctx := context.Background()
+
+	resources, err := h.session.ListResources(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources.Resources) == 0 {
+		t.Fatal("no resources listed")
+	}
+	for _, r := range resources.Resources {
+		if r.MIMEType == "" {
+			t.Errorf("resource %s has no MIME type", r.URI)
+		}
+		h.read(t, r.URI)
+	}
+
+	templates, err := h.session.ListResourceTemplates(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// One sample item per template, and a week for the summary
+	samples := map[string]string{
+		"momentum://todo/{id}":             "momentum://todo/a1000001",
+		"momentum://milestone/{id}":        "momentum://milestone/b1000001",
+		"momentum://reading/{id}":          "momentum://reading/c1000001",
+		"momentum://reminder/{id}":         "momentum://reminder/d1000001",
+		"momentum://weekly-summary/{week}": "momentum://weekly-summary/2026-W09",
+	}
+	for _, tmpl := range templates.ResourceTemplates {
+		uri, ok := samples[tmpl.URITemplate]
+		if !ok {
+			t.Errorf("resource template %s is not exercised; add a sample URI", tmpl.URITemplate)
+			continue
+		}
+		h.read(t, uri)
+	}
+
+	// Unknown items are not found rather than empty
+	if _, err := h.session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "momentum://todo/ffffffff"}); err == nil {
+		t.Error("expected an error reading a missing todo")
+	}
+}
+
+// schemaType returns the "type" of a JSON schema as served to clients.
+func schemaType(schema any) string {
+	raw, _ := json.Marshal(schema)
+	var s struct {
+		Type string `json:"type"`
+	}
+	json.Unmarshal(raw, &s)
+	return s.Type
+}
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dang-w/momentum-mcp-server/internal/version"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ConnectInProcess connects a client session to s over an in-memory
+// transport. Integrations use it to call tools through the same handlers and
+// middleware as remote clients.
+func ConnectInProcess(ctx context.Context, s *mcp.Server, clientName string) (*mcp.ClientSession, error) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := s.Connect(ctx, serverTransport, nil); err != nil {
+		return nil, fmt.Errorf("connecting server: %w", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: clientName, Version: version.Version}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("connecting %s: %w", clientName, err)
+	}
+	return session, nil
+}
@@ -3,17 +3,29 @@ package server
 
 import (
 	"context"
+	"time"
 
+	"github.com/dang-w/momentum-mcp-server/internal/alerts"
+	"github.com/dang-w/momentum-mcp-server/internal/analytics"
+	"github.com/dang-w/momentum-mcp-server/internal/attribution"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/confirm"
+	"github.com/dang-w/momentum-mcp-server/internal/deadline"
+	"github.com/dang-w/momentum-mcp-server/internal/integrations"
+	"github.com/dang-w/momentum-mcp-server/internal/logging"
+	"github.com/dang-w/momentum-mcp-server/internal/readonly"
+	"github.com/dang-w/momentum-mcp-server/internal/tracing"
+	"github.com/dang-w/momentum-mcp-server/internal/usage"
+	"github.com/dang-w/momentum-mcp-server/internal/version"
 	"github.com/dang-w/momentum-mcp-server/resources"
 	"github.com/dang-w/momentum-mcp-server/storage"
 	"github.com/dang-w/momentum-mcp-server/tools"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-const (
-	ServerName    = "momentum"
-	ServerVersion = "0.2.0"
-)
+// ServerName is the implementation name reported to MCP clients. The version
+// comes from internal/version and is stamped at build time.
+const ServerName = "momentum"
 
 // Config holds the configuration needed to create the MCP server.
 type Config struct {
@@ -26,14 +38,137 @@ type Config struct {
 
 	// GitHubUsername is the GitHub username to fetch activity for.
 	GitHubUsername string
+
+	// GitHubActivityCacheTTL is how long fetched GitHub activity is reused.
+	// Zero uses resources.DefaultGitHubActivityCacheTTL.
+	GitHubActivityCacheTTL time.Duration
+
+	// Usage records per-tool call statistics. Optional - if nil, the
+	// momentum://usage resource and get_tool_feedback tool are not registered.
+	Usage *usage.Tracker
+
+	// Backfill holds completion history reconstructed from the data repo's
+	// commits. Optional - if nil, momentum://completion-history is not registered.
+	Backfill *analytics.Backfill
+
+	// History reads past versions of the data files, for item history in
+	// the momentum://<kind>/{id} resources. Optional - if nil, items are
+	// served without history and get_item_history and get_changes are not
+	// registered.
+	History storage.History
+
+	// Events is the event-sourced store when STORAGE_MODE=events. Optional -
+	// if nil, the undo_last_change tool is not registered.
+	Events *storage.EventStore
+
+	// Deadline enforces per-request tool timeouts and cancels tool calls
+	// whose HTTP request has gone away. Optional.
+	Deadline *deadline.Enforcer
+
+	// SizeQuota sets soft data file size limits reported by get_dashboard.
+	// Zero values use tools.DefaultSizeQuota.
+	SizeQuota tools.SizeQuota
+
+	// WorkloadLimits sets the burnout guard thresholds reported by
+	// get_dashboard. Zero values use tools.DefaultWorkloadLimits.
+	WorkloadLimits tools.WorkloadLimits
+
+	// StaleThresholds sets the default ages reported by get_stale_items.
+	// Zero values use tools.DefaultStaleThresholds.
+	StaleThresholds tools.StaleThresholds
+
+	// WIPLimits caps the active todos per priority in add_todo and
+	// edit_todo. Zero values leave priorities unlimited.
+	WIPLimits tools.WIPLimits
+
+	// Calendar reads Google Calendar events. Optional - if nil,
+	// momentum://calendar is not registered.
+	Calendar *integrations.Calendar
+
+	// WakaTime reads coding time. Optional - if nil, momentum://coding-time
+	// is not registered and the weekly summary leaves coding time out.
+	WakaTime *integrations.WakaTime
+
+	// MilestoneIssues mirrors milestones as GitHub issues. Optional - if nil,
+	// milestones aren't synced and sync_milestone_issues is not registered.
+	MilestoneIssues tools.MilestoneIssues
+
+	// Confirm asks the user before destructive tools run. Optional - if nil,
+	// tools rely on their own confirm arguments.
+	Confirm *confirm.Policy
+
+	// Alerts attaches overdue reminders and milestones due soon to tool
+	// results. Optional - if nil, results carry no alerts.
+	Alerts *alerts.Checker
+
+	// ReadOnly refuses tool calls that would change data. Optional - if
+	// nil, only storage-level refusals apply.
+	ReadOnly *readonly.Switch
+
+	// PullRequests reviews changes proposed as pull requests by
+	// storage.WithReview. Optional - if nil, pending_changes is not
+	// registered.
+	PullRequests storage.PullRequests
+
+	// WriteQueue holds writes saved while GitHub was unavailable. Optional -
+	// if nil, sync_status is not registered.
+	WriteQueue *storage.WriteQueue
+
+	// Clock supplies the current time for date-sensitive behavior (overdue
+	// items, week boundaries, streaks). Optional - if nil, the system clock.
+	Clock clock.Clock
 }
 
 // New creates and configures a new MCP server with all resources and tools registered.
 func New(cfg Config) *mcp.Server {
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    ServerName,
-		Version: ServerVersion,
-	}, nil)
+		Version: version.Version,
+	}, &mcp.ServerOptions{
+		// Any resource may be subscribed to; FilesChanged notifies subscribers
+		SubscribeHandler:   func(context.Context, *mcp.SubscribeRequest) error { return nil },
+		UnsubscribeHandler: func(context.Context, *mcp.UnsubscribeRequest) error { return nil },
+	})
+
+	// Hold the files each tool call reads until it returns, so concurrent
+	// calls take turns instead of conflicting. Innermost, so nothing is held
+	// while asking for confirmation.
+	server.AddReceivingMiddleware(lockScopeMiddleware)
+
+	// Piggyback urgent items on tool results, read after the call's own
+	// files are released
+	if cfg.Alerts != nil {
+		server.AddReceivingMiddleware(cfg.Alerts.Middleware())
+	}
+
+	// Attach request IDs to handler contexts and log each tool call
+	server.AddReceivingMiddleware(logging.ToolMiddleware())
+
+	// Record the tool and client behind each change, for commit messages
+	server.AddReceivingMiddleware(attribution.Middleware())
+
+	// Trace tool calls (no-op unless tracing is enabled)
+	server.AddReceivingMiddleware(tracing.ToolMiddleware())
+
+	// Bound tool calls by the request deadline and client connection
+	if cfg.Deadline != nil {
+		server.AddReceivingMiddleware(cfg.Deadline.ToolMiddleware())
+	}
+
+	// Track tool call analytics
+	if cfg.Usage != nil {
+		server.AddReceivingMiddleware(cfg.Usage.Middleware())
+	}
+
+	// Confirm destructive tool calls with the user
+	if cfg.Confirm != nil && cfg.Confirm.Enabled() {
+		server.AddReceivingMiddleware(cfg.Confirm.Middleware())
+	}
+
+	// Refuse changes in read-only mode, before asking for any confirmation
+	if cfg.ReadOnly != nil {
+		server.AddReceivingMiddleware(cfg.ReadOnly.Middleware())
+	}
 
 	// Register placeholder ping tool for verification
 	registerPingTool(server)
@@ -41,33 +176,122 @@ func New(cfg Config) *mcp.Server {
 	// Create GitHub activity resource (used by both github-activity and weekly-summary)
 	var githubActivity *resources.GitHubActivityResource
 	if cfg.GitHubToken != "" && cfg.GitHubUsername != "" {
-		githubActivity = resources.NewGitHubActivityResource(cfg.GitHubToken, cfg.GitHubUsername)
+		githubActivity = resources.NewGitHubActivityResource(cfg.GitHubToken, cfg.GitHubUsername, cfg.Storage, cfg.GitHubActivityCacheTTL, cfg.Clock)
 	}
 
 	// Register resources
 	resources.NewTodosResource(cfg.Storage).Register(server)
-	resources.NewStrategyResource(cfg.Storage).Register(server)
+	resources.NewStrategyResource(cfg.Storage, cfg.Clock).Register(server)
 	resources.NewReadingResource(cfg.Storage).Register(server)
-	resources.NewRemindersResource(cfg.Storage).Register(server)
+	resources.NewRemindersResource(cfg.Storage, cfg.Clock).Register(server)
+	resources.NewJournalResource(cfg.Storage, cfg.Clock).Register(server)
+	resources.NewNotesResource(cfg.Storage).Register(server)
+	resources.NewTrendsResource(cfg.Storage, cfg.Clock).Register(server)
+	resources.NewItemResource(cfg.Storage, cfg.History).Register(server)
 
 	// Register GitHub activity resource if configured
 	if githubActivity != nil {
 		githubActivity.Register(server)
 	}
 
+	// Register calendar resource if the integration is configured
+	if cfg.Calendar != nil {
+		resources.NewCalendarResource(cfg.Calendar, cfg.Clock).Register(server)
+	}
+
+	// Register coding time resource if WakaTime is configured
+	if cfg.WakaTime != nil {
+		resources.NewCodingTimeResource(cfg.WakaTime, cfg.Clock).Register(server)
+	}
+
+	// Register tool usage analytics resource if tracking is enabled
+	if cfg.Usage != nil {
+		resources.NewUsageResource(cfg.Usage).Register(server)
+	}
+
+	// Register historic completion analytics if backfill is enabled
+	if cfg.Backfill != nil {
+		resources.NewCompletionHistoryResource(cfg.Backfill).Register(server)
+	}
+
 	// Register weekly summary resource (aggregates all data)
-	resources.NewSummaryResource(cfg.Storage, githubActivity).Register(server)
+	summary := resources.NewSummaryResource(cfg.Storage, githubActivity, cfg.WakaTime, cfg.Clock)
+	summary.Register(server)
 
 	// Register tools
-	tools.NewTodoTools(cfg.Storage).Register(server)
-	tools.NewStrategyTools(cfg.Storage).Register(server)
-	tools.NewReadingTools(cfg.Storage).Register(server)
-	tools.NewReminderTools(cfg.Storage).Register(server)
-	tools.NewDashboardTools(cfg.Storage).Register(server)
+	tools.NewTodoTools(cfg.Storage, cfg.Clock, cfg.WIPLimits).Register(server)
+	tools.NewStrategyTools(cfg.Storage, cfg.MilestoneIssues, cfg.Clock).Register(server)
+	tools.NewReadingTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewFeedTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewReminderTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewJournalTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewNoteTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewTrashTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewCommentTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewItemTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewProjectTools(cfg.Storage).Register(server)
+	tools.NewGoalTools(cfg.Storage).Register(server)
+	tools.NewTimeTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewFocusTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewReviewTools(cfg.Storage, summary, cfg.Clock).Register(server)
+	tools.NewExportTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewStatsTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewDashboardTools(cfg.Storage, cfg.Clock, cfg.SizeQuota, cfg.WorkloadLimits).Register(server)
+	tools.NewStaleTools(cfg.Storage, cfg.Clock, cfg.StaleThresholds).Register(server)
+
+	// The briefing leaves out the calendar and streak if they aren't set up
+	var agenda tools.CalendarAgenda
+	if cfg.Calendar != nil {
+		agenda = cfg.Calendar
+	}
+	var streak tools.ContributionStreak
+	if githubActivity != nil {
+		streak = githubActivity
+	}
+	tools.NewBriefingTools(cfg.Storage, cfg.Clock, agenda, streak).Register(server)
+
+	if cfg.History != nil {
+		tools.NewHistoryTools(cfg.Storage, cfg.History, cfg.Clock).Register(server)
+	}
+	if cfg.PullRequests != nil {
+		tools.NewPendingTools(cfg.PullRequests).Register(server)
+	}
+	if cfg.WriteQueue != nil {
+		tools.NewSyncQueueTools(cfg.WriteQueue).Register(server)
+	}
+	tools.NewConvertTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewInitTools(cfg.Storage).Register(server)
+	tools.NewRawFileTools(cfg.Storage).Register(server)
+	tools.NewValidateTools(cfg.Storage).Register(server)
+	tools.NewIDTools(cfg.Storage, cfg.Clock).Register(server)
+	tools.NewPreferenceTools(cfg.Storage).Register(server)
+
+	// Register the GitHub activity refresh if the resource is configured
+	if githubActivity != nil {
+		tools.NewGitHubActivityTools(githubActivity).Register(server)
+	}
+
+	// Register undo if writes are event-sourced
+	if cfg.Events != nil {
+		tools.NewUndoTools(cfg.Events).Register(server)
+	}
+
+	// Register validation feedback report if tracking is enabled
+	if cfg.Usage != nil {
+		tools.NewFeedbackTools(cfg.Usage).Register(server)
+	}
 
 	return server
 }
 
+// FilesChanged sends a resource-updated notification for every resource
+// rendered from the named data files to the clients subscribed to it.
+func FilesChanged(ctx context.Context, server *mcp.Server, names []string) {
+	for _, uri := range resources.URIsForFiles(names) {
+		server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri})
+	}
+}
+
 // PingInput is the input schema for the ping tool.
 type PingInput struct{}
 
@@ -87,3 +311,16 @@ func registerPingTool(server *mcp.Server) {
 		Description: "Simple ping tool to verify the server is responding",
 	}, ping)
 }
+
+// lockScopeMiddleware runs each tool call in a storage lock scope; see
+// storage.WithPathLocks.
+func lockScopeMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+		ctx, release := storage.WithLockScope(ctx)
+		defer release()
+		return next(ctx, method, req)
+	}
+}
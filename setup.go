@@ -0,0 +1,336 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/dang-w/momentum-mcp-server/tools"
+)
+
+// githubAPI is the GitHub REST API base URL.
+const githubAPI = "https://api.github.com"
+
+// runInit is the init subcommand: an interactive first-time setup that
+// checks a GitHub token, finds or creates the data repo, scaffolds the data
+// files, generates an auth token, and writes a .env file.
+func runInit(args []string) int {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	envPath := fs.String("env", ".env", "where to write the configuration")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: momentum-mcp-server init [-env path]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	w := &wizard{
+		in:     bufio.NewReader(os.Stdin),
+		out:    os.Stdout,
+		client: &http.Client{Timeout: 30 * time.Second},
+		apiURL: githubAPI,
+		storage: func(token, repo string) (storage.Storage, error) {
+			return storage.NewGitHubStorage(token, repo)
+		},
+	}
+	if err := w.run(context.Background(), *envPath); err != nil {
+		fmt.Fprintln(os.Stderr, "init:", err)
+		return 1
+	}
+	return 0
+}
+
+// wizard holds the prompts' input and output.
+type wizard struct {
+	in     *bufio.Reader
+	out    io.Writer
+	client *http.Client
+	// apiURL and storage are overridden in tests
+	apiURL  string
+	storage func(token, repo string) (storage.Storage, error)
+	// closed is set once the input has run out, to end retry loops.
+	closed bool
+}
+
+// errInputClosed stops the wizard when there are no more answers.
+var errInputClosed = errors.New("input closed before setup finished")
+
+func (w *wizard) run(ctx context.Context, envPath string) error {
+	fmt.Fprintln(w.out, "Momentum setup. Press enter to accept [defaults].")
+
+	// 1. GitHub token
+	fmt.Fprintln(w.out, "\nCreate a token at https://github.com/settings/tokens with the 'repo' scope")
+	fmt.Fprintln(w.out, "(or a fine-grained token with read/write Contents on the data repo).")
+	var token, login string
+	for {
+		token = w.ask("GitHub token", os.Getenv("GITHUB_TOKEN"), true)
+		var err error
+		login, err = w.checkToken(ctx, token)
+		if err == nil {
+			break
+		}
+		fmt.Fprintln(w.out, "  ", err)
+		if w.closed {
+			return errInputClosed
+		}
+	}
+	fmt.Fprintf(w.out, "  Token OK, signed in as %s\n", login)
+
+	// 2. Data repo
+	var repo string
+	for {
+		repo = w.ask("\nData repository (owner/repo)", login+"/momentum-data", false)
+		err := w.ensureRepo(ctx, token, login, repo)
+		if err == nil {
+			break
+		}
+		fmt.Fprintln(w.out, "  ", err)
+		if w.closed {
+			return errInputClosed
+		}
+	}
+
+	// 3. Data files
+	fmt.Fprintln(w.out, "\nCreating data files...")
+	gs, err := w.storage(token, repo)
+	if err != nil {
+		return err
+	}
+	result, err := tools.InitDataFiles(ctx, gs)
+	if err != nil {
+		return fmt.Errorf("creating data files: %w", err)
+	}
+	if len(result.Created) > 0 {
+		fmt.Fprintf(w.out, "  Created %s\n", strings.Join(result.Created, ", "))
+	}
+	if len(result.Existing) > 0 {
+		fmt.Fprintf(w.out, "  Kept existing %s\n", strings.Join(result.Existing, ", "))
+	}
+
+	// 4. Server settings
+	authToken, err := randomToken()
+	if err != nil {
+		return err
+	}
+	port := w.ask("\nHTTP port", "8080", false)
+	baseURL := w.ask("Public base URL, if deployed (e.g. https://momentum.fly.dev)", "", false)
+
+	// 5. .env
+	if _, err := os.Stat(envPath); err == nil {
+		if !w.confirm(fmt.Sprintf("\n%s exists. Overwrite it?", envPath), false) {
+			fmt.Fprintln(w.out, "Not written. Add these settings yourself:")
+			fmt.Fprint(w.out, envFile(token, repo, authToken, port, baseURL))
+			return nil
+		}
+	}
+	if err := os.WriteFile(envPath, []byte(envFile(token, repo, authToken, port, baseURL)), 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", envPath, err)
+	}
+
+	fmt.Fprintf(w.out, "\nWrote %s. Start the server with:\n\n", envPath)
+	fmt.Fprintf(w.out, "  set -a; . %s; set +a; momentum-mcp-server\n\n", envPath)
+	fmt.Fprintf(w.out, "MCP clients connect to %s/mcp with the bearer token:\n\n  %s\n", orLocalhost(baseURL, port), authToken)
+	return nil
+}
+
+// ask prompts for a line, returning def if the answer is empty. Secret
+// defaults are shown masked.
+func (w *wizard) ask(prompt, def string, secret bool) string {
+	shown := def
+	if secret && len(def) > 8 {
+		shown = def[:4] + "…" + def[len(def)-4:]
+	}
+	if shown != "" {
+		fmt.Fprintf(w.out, "%s [%s]: ", prompt, shown)
+	} else {
+		fmt.Fprintf(w.out, "%s: ", prompt)
+	}
+	line, err := w.in.ReadString('\n')
+	if err != nil && line == "" {
+		w.closed = true
+		fmt.Fprintln(w.out)
+	}
+	if line = strings.TrimSpace(line); line != "" {
+		return line
+	}
+	return def
+}
+
+// confirm asks a yes/no question.
+func (w *wizard) confirm(prompt string, def bool) bool {
+	options := "y/N"
+	if def {
+		options = "Y/n"
+	}
+	answer := strings.ToLower(w.ask(prompt+" ("+options+")", "", false))
+	if answer == "" {
+		return def
+	}
+	return answer == "y" || answer == "yes"
+}
+
+// checkToken returns the login the token belongs to, and an error if the
+// token is invalid or a classic token lacks the repo scope.
+func (w *wizard) checkToken(ctx context.Context, token string) (string, error) {
+	if token == "" {
+		return "", errors.New("a token is required")
+	}
+	var user struct {
+		Login string `json:"login"`
+	}
+	resp, err := w.github(ctx, token, http.MethodGet, "/user", nil, &user)
+	if err != nil {
+		return "", err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return "", errors.New("GitHub rejected the token")
+	default:
+		return "", fmt.Errorf("checking token: GitHub returned %s", resp.Status)
+	}
+
+	// Classic tokens list their scopes; fine-grained tokens don't
+	if values := resp.Header.Values("X-OAuth-Scopes"); len(values) > 0 {
+		scopes := strings.Join(values, ",")
+		hasRepo := false
+		for _, s := range strings.Split(scopes, ",") {
+			if strings.TrimSpace(s) == "repo" {
+				hasRepo = true
+			}
+		}
+		if !hasRepo {
+			return "", fmt.Errorf("the token needs the 'repo' scope (it has: %s)", scopes)
+		}
+	}
+	return user.Login, nil
+}
+
+// ensureRepo checks the token can write to repo, offering to create it
+// (private) if it doesn't exist.
+func (w *wizard) ensureRepo(ctx context.Context, token, login, repo string) error {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("%q is not in owner/repo format", repo)
+	}
+
+	var existing struct {
+		Permissions struct {
+			Push bool `json:"push"`
+		} `json:"permissions"`
+	}
+	resp, err := w.github(ctx, token, http.MethodGet, "/repos/"+repo, nil, &existing)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if !existing.Permissions.Push {
+			return fmt.Errorf("the token can't write to %s", repo)
+		}
+		fmt.Fprintf(w.out, "  Using %s\n", repo)
+		return nil
+	case http.StatusNotFound:
+	default:
+		return fmt.Errorf("looking up %s: GitHub returned %s", repo, resp.Status)
+	}
+
+	if !w.confirm(fmt.Sprintf("  %s doesn't exist. Create it as a private repo?", repo), true) {
+		return errors.New("choose another repository")
+	}
+	path := "/user/repos"
+	if !strings.EqualFold(owner, login) {
+		path = "/orgs/" + owner + "/repos"
+	}
+	body := map[string]any{
+		"name":        name,
+		"private":     true,
+		"auto_init":   true, // the first commit creates the default branch
+		"description": "Momentum productivity data",
+	}
+	resp, err = w.github(ctx, token, http.MethodPost, path, body, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("creating %s: GitHub returned %s", repo, resp.Status)
+	}
+	fmt.Fprintf(w.out, "  Created %s\n", repo)
+	return nil
+}
+
+// github makes a REST API call, decoding a JSON response into out.
+func (w *wizard) github(ctx context.Context, token, method, path string, body, out any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, w.apiURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("contacting GitHub: %w", err)
+	}
+	defer resp.Body.Close()
+	if out != nil && resp.StatusCode < 300 {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return nil, fmt.Errorf("decoding GitHub response: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+// randomToken returns a 256-bit hex token.
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating auth token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// envFile renders the settings the wizard collected. Everything else keeps
+// its default; see .env.example.
+func envFile(token, repo, authToken, port, baseURL string) string {
+	var sb strings.Builder
+	sb.WriteString("# Written by momentum-mcp-server init. See .env.example for every setting.\n\n")
+	fmt.Fprintf(&sb, "GITHUB_TOKEN=%s\n", token)
+	fmt.Fprintf(&sb, "GITHUB_REPO=%s\n", repo)
+	fmt.Fprintf(&sb, "AUTH_TOKEN=%s\n", authToken)
+	fmt.Fprintf(&sb, "PORT=%s\n", port)
+	if baseURL != "" {
+		fmt.Fprintf(&sb, "BASE_URL=%s\n", strings.TrimRight(baseURL, "/"))
+	}
+	return sb.String()
+}
+
+// orLocalhost returns baseURL, or the local address if it is empty.
+func orLocalhost(baseURL, port string) string {
+	if baseURL != "" {
+		return strings.TrimRight(baseURL, "/")
+	}
+	return "http://localhost:" + port
+}
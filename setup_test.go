@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestEnvFile(t *testing.T) {
+	got := envFile("ghp_tok", "ada/momentum-data", "secret", "9090", "https://momentum.example.com/")
+	want := "# Written by momentum-mcp-server init. See .env.example for every setting.\n\n" +
+		"GITHUB_TOKEN=ghp_tok\n" +
+		"GITHUB_REPO=ada/momentum-data\n" +
+		"AUTH_TOKEN=secret\n" +
+		"PORT=9090\n" +
+		"BASE_URL=https://momentum.example.com\n"
+	if got != want {
+		t.Errorf("envFile() = %q, expected %q", got, want)
+	}
+
+	if got := envFile("ghp_tok", "ada/momentum-data", "secret", "8080", ""); strings.Contains(got, "BASE_URL") {
+		t.Errorf("expected no BASE_URL without a base URL, got %q", got)
+	}
+}
+
+func TestOrLocalhost(t *testing.T) {
+	tests := []struct {
+		baseURL, port, want string
+	}{
+		{"", "8080", "http://localhost:8080"},
+		{"https://momentum.example.com", "8080", "https://momentum.example.com"},
+		{"https://momentum.example.com/", "8080", "https://momentum.example.com"},
+	}
+	for _, tt := range tests {
+		if got := orLocalhost(tt.baseURL, tt.port); got != tt.want {
+			t.Errorf("orLocalhost(%q, %q) = %q, expected %q", tt.baseURL, tt.port, got, tt.want)
+		}
+	}
+}
+
+// fakeGitHub serves the API calls the wizard makes: "good" is a valid
+// token for ada, and ada/momentum-data doesn't exist until it is created.
+func fakeGitHub(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /user":
+			w.Header().Set("X-OAuth-Scopes", "repo, gist")
+			w.Write([]byte(`{"login":"ada"}`))
+		case "GET /repos/ada/momentum-data":
+			w.WriteHeader(http.StatusNotFound)
+		case "POST /user/repos":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+// runWizard runs the wizard against srv with scripted input, returning its
+// output, the data storage it set up, and its error.
+func runWizard(t *testing.T, srv *httptest.Server, input, envPath string) (string, *storage.MemoryStorage, error) {
+	t.Helper()
+	t.Setenv("GITHUB_TOKEN", "")
+	data := storage.NewMemoryStorage(nil, nil)
+	var out strings.Builder
+	w := &wizard{
+		in:     bufio.NewReader(strings.NewReader(input)),
+		out:    &out,
+		client: srv.Client(),
+		apiURL: srv.URL,
+		storage: func(token, repo string) (storage.Storage, error) {
+			if token != "good" || repo != "ada/momentum-data" {
+				t.Errorf("unexpected storage for %s with token %q", repo, token)
+			}
+			return data, nil
+		},
+	}
+	err := w.run(context.Background(), envPath)
+	return out.String(), data, err
+}
+
+func TestWizard_Run(t *testing.T) {
+	srv, calls := fakeGitHub(t)
+	envPath := filepath.Join(t.TempDir(), ".env")
+
+	// A rejected token is asked for again; then defaults for the repo (and
+	// creating it), the port and the base URL
+	out, data, err := runWizard(t, srv, "bad\ngood\n\n\n\n\n", envPath)
+	if err != nil {
+		t.Fatalf("run() error = %v\n%s", err, out)
+	}
+	for _, want := range []string{"GitHub rejected the token", "signed in as ada", "Created ada/momentum-data", "http://localhost:8080/mcp"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if want := "GET /user,GET /user,GET /repos/ada/momentum-data,POST /user/repos"; strings.Join(*calls, ",") != want {
+		t.Errorf("expected calls %s, got %v", want, *calls)
+	}
+	if _, _, err := data.ReadFile(context.Background(), storage.TodosFile); err != nil {
+		t.Errorf("expected the data files to be created: %v", err)
+	}
+
+	env, err := os.ReadFile(envPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"GITHUB_TOKEN=good\n", "GITHUB_REPO=ada/momentum-data\n", "PORT=8080\n"} {
+		if !strings.Contains(string(env), want) {
+			t.Errorf(".env missing %q:\n%s", want, env)
+		}
+	}
+	if strings.Contains(string(env), "BASE_URL") {
+		t.Errorf("expected no BASE_URL:\n%s", env)
+	}
+}
+
+func TestWizard_Overwrite(t *testing.T) {
+	srv, _ := fakeGitHub(t)
+	tests := []struct {
+		name   string
+		answer string // to the overwrite prompt; empty input ends before it
+		keep   bool
+	}{
+		{"yes", "y\n", false},
+		{"no", "n\n", true},
+		{"default", "\n", true},
+		{"input closed", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envPath := filepath.Join(t.TempDir(), ".env")
+			if err := os.WriteFile(envPath, []byte("PORT=1234\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			out, _, err := runWizard(t, srv, "good\n\n\n9090\nhttps://momentum.example.com\n"+tt.answer, envPath)
+			if err != nil {
+				t.Fatalf("run() error = %v\n%s", err, out)
+			}
+			if !strings.Contains(out, ".env exists. Overwrite it?") {
+				t.Errorf("expected the overwrite prompt:\n%s", out)
+			}
+
+			env, _ := os.ReadFile(envPath)
+			if tt.keep {
+				if string(env) != "PORT=1234\n" {
+					t.Errorf("expected .env to be kept, got:\n%s", env)
+				}
+				// The settings are printed for the user to add instead
+				if !strings.Contains(out, "Not written") || !strings.Contains(out, "PORT=9090\nBASE_URL=https://momentum.example.com\n") {
+					t.Errorf("expected the settings in the output:\n%s", out)
+				}
+				return
+			}
+			if !strings.Contains(string(env), "PORT=9090\n") || !strings.Contains(out, "https://momentum.example.com/mcp") {
+				t.Errorf("expected .env to be overwritten, got:\n%s\noutput:\n%s", env, out)
+			}
+		})
+	}
+}
+
+func TestWizard_InputClosed(t *testing.T) {
+	srv, _ := fakeGitHub(t)
+	envPath := filepath.Join(t.TempDir(), ".env")
+
+	// The only token is rejected and there are no more answers to retry with
+	out, _, err := runWizard(t, srv, "bad\n", envPath)
+	if !errors.Is(err, errInputClosed) {
+		t.Fatalf("expected errInputClosed, got %v\n%s", err, out)
+	}
+	if _, err := os.Stat(envPath); !os.IsNotExist(err) {
+		t.Errorf("expected no .env, got %v", err)
+	}
+}
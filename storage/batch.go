@@ -0,0 +1,283 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// FileChange is one file written by a multi-file commit.
+type FileChange struct {
+	Path    string
+	Content string
+	// SHA is the blob SHA from the last ReadFile of Path, or empty if the
+	// file is being created. A mismatch fails the whole write with ErrConflict.
+	SHA string
+}
+
+// BatchWriter is implemented by storage backends that can write several
+// files in a single commit. It is optional - use WriteFiles, which falls back
+// to one WriteFile per file.
+type BatchWriter interface {
+	WriteFiles(ctx context.Context, changes []FileChange, message string) error
+}
+
+// WriteFiles writes changes as one commit when s supports it. Otherwise each
+// file is written in turn with the same message; a conflict part-way through
+// leaves the earlier files written.
+func WriteFiles(ctx context.Context, s Storage, changes []FileChange, message string) error {
+	if bw, ok := s.(BatchWriter); ok {
+		return bw.WriteFiles(ctx, changes, message)
+	}
+	for _, c := range changes {
+		if err := s.WriteFile(ctx, c.Path, c.Content, c.SHA, message); err != nil {
+			return fmt.Errorf("writing %s: %w", c.Path, err)
+		}
+	}
+	return nil
+}
+
+// ReadResult is the outcome of reading one file with ReadFiles.
+type ReadResult struct {
+	Content string
+	SHA     string
+	Err     error
+}
+
+// ReadFiles reads paths concurrently, returning each file's result by path.
+func ReadFiles(ctx context.Context, s Storage, paths ...string) map[string]ReadResult {
+	results := make([]ReadResult, len(paths))
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			content, sha, err := s.ReadFile(ctx, path)
+			results[i] = ReadResult{Content: content, SHA: sha, Err: err}
+		}()
+	}
+	wg.Wait()
+
+	byPath := make(map[string]ReadResult, len(paths))
+	for i, path := range paths {
+		byPath[path] = results[i]
+	}
+	return byPath
+}
+
+// maxRefUpdateAttempts bounds retries when the branch moves between reading
+// its head and updating it. Each retry re-checks every file's SHA, so only
+// unrelated commits (e.g. to other files) are retried past.
+const maxRefUpdateAttempts = 3
+
+// gitTreeEntry is one entry of a Git Data API tree.
+type gitTreeEntry struct {
+	Path    string `json:"path"`
+	Mode    string `json:"mode,omitempty"`
+	Type    string `json:"type"`
+	SHA     string `json:"sha,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// WriteFiles creates a single commit touching every file in changes using
+// the Git Data API (trees, commits and refs). Expected SHAs are checked
+// against the branch head before anything is written.
+func (g *GitHubStorage) WriteFiles(ctx context.Context, changes []FileChange, message string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	branch, err := g.defaultBranch(ctx)
+	if err != nil {
+		return err
+	}
+
+	// None of the cached copies survive the commit
+	for _, c := range changes {
+		g.forget(c.Path)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := g.commitFiles(ctx, branch, changes, message)
+		if err != errNotFastForward {
+			return err
+		}
+		if attempt == maxRefUpdateAttempts {
+			return ErrConflict
+		}
+	}
+}
+
+// errNotFastForward reports that the branch moved while a commit was built.
+var errNotFastForward = errors.New("branch update is not a fast-forward")
+
+// commitFiles makes one attempt at committing changes on top of branch.
+func (g *GitHubStorage) commitFiles(ctx context.Context, branch string, changes []FileChange, message string) error {
+	var ref struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	if err := g.gitRequest(ctx, http.MethodGet, "git/ref/heads/"+url.PathEscape(branch), nil, &ref); err != nil {
+		return fmt.Errorf("reading branch %s: %w", branch, err)
+	}
+	head := ref.Object.SHA
+
+	var commit struct {
+		Tree struct {
+			SHA string `json:"sha"`
+		} `json:"tree"`
+	}
+	if err := g.gitRequest(ctx, http.MethodGet, "git/commits/"+head, nil, &commit); err != nil {
+		return fmt.Errorf("reading commit %s: %w", head, err)
+	}
+
+	var tree struct {
+		Tree      []gitTreeEntry `json:"tree"`
+		Truncated bool           `json:"truncated"`
+	}
+	if err := g.gitRequest(ctx, http.MethodGet, "git/trees/"+commit.Tree.SHA+"?recursive=1", nil, &tree); err != nil {
+		return fmt.Errorf("reading tree: %w", err)
+	}
+	if tree.Truncated {
+		return fmt.Errorf("repository tree is too large to verify file SHAs")
+	}
+	current := make(map[string]string, len(tree.Tree))
+	for _, e := range tree.Tree {
+		if e.Type == "blob" {
+			current[e.Path] = e.SHA
+		}
+	}
+
+	// Same rules as the Contents API: updates must name the current blob and
+	// creates must not overwrite an existing file
+	entries := make([]gitTreeEntry, 0, len(changes))
+	for _, c := range changes {
+		if current[c.Path] != c.SHA {
+			return ErrConflict
+		}
+		entries = append(entries, gitTreeEntry{Path: c.Path, Mode: "100644", Type: "blob", Content: c.Content})
+	}
+
+	var newTree struct {
+		SHA string `json:"sha"`
+	}
+	treeBody := map[string]any{"base_tree": commit.Tree.SHA, "tree": entries}
+	if err := g.gitRequest(ctx, http.MethodPost, "git/trees", treeBody, &newTree); err != nil {
+		return fmt.Errorf("creating tree: %w", err)
+	}
+
+	var newCommit struct {
+		SHA string `json:"sha"`
+	}
+	commitBody := map[string]any{"message": message, "tree": newTree.SHA, "parents": []string{head}}
+	if g.committer != nil {
+		commitBody["author"] = g.committer
+		commitBody["committer"] = g.committer
+	}
+	if err := g.gitRequest(ctx, http.MethodPost, "git/commits", commitBody, &newCommit); err != nil {
+		return fmt.Errorf("creating commit: %w", err)
+	}
+
+	refBody := map[string]any{"sha": newCommit.SHA, "force": false}
+	err := g.gitRequest(ctx, http.MethodPatch, "git/refs/heads/"+url.PathEscape(branch), refBody, nil)
+	if err == errUnprocessable {
+		return errNotFastForward
+	}
+	if err != nil {
+		return fmt.Errorf("updating branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// defaultBranch returns the repository's default branch, which is where the
+// Contents API reads and writes. It is looked up once.
+func (g *GitHubStorage) defaultBranch(ctx context.Context) (string, error) {
+	g.mu.Lock()
+	branch := g.branch
+	g.mu.Unlock()
+	if branch != "" {
+		return branch, nil
+	}
+
+	var repo struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.gitRequest(ctx, http.MethodGet, "", nil, &repo); err != nil {
+		return "", fmt.Errorf("reading repository: %w", err)
+	}
+	if repo.DefaultBranch == "" {
+		return "", fmt.Errorf("repository has no default branch")
+	}
+
+	g.mu.Lock()
+	g.branch = repo.DefaultBranch
+	g.mu.Unlock()
+	return repo.DefaultBranch, nil
+}
+
+// errUnprocessable is returned by gitRequest for 422 responses, which the
+// refs API uses to reject non-fast-forward updates.
+var errUnprocessable = errors.New("GitHub API rejected the request (status 422)")
+
+// gitRequest sends a JSON request to a path under the repository API and
+// decodes the response into out (if non-nil).
+func (g *GitHubStorage) gitRequest(ctx context.Context, method, path string, body any, out any) error {
+	u := fmt.Sprintf("https://api.github.com/repos/%s/%s", g.owner, g.repo)
+	if path != "" {
+		u += "/" + path
+	}
+
+	var reader io.Reader
+	if body != nil {
+		bodyJSON, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request body: %w", err)
+		}
+		reader = bytes.NewReader(bodyJSON)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusUnprocessableEntity:
+		return errUnprocessable
+	case http.StatusMethodNotAllowed:
+		// The merge API's answer for pull requests that can't be merged
+		return ErrNotMergeable
+	case http.StatusNoContent:
+		return nil
+	}
+	if err := g.checkResponseError(resp); err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
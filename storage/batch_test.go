@@ -0,0 +1,209 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeGitRepo answers the Git Data API calls made by WriteFiles.
+type fakeGitRepo struct {
+	blobs       map[string]string // path -> blob SHA at head
+	rejectPatch int               // number of ref updates to reject as non-fast-forward
+
+	treeBody   map[string]any
+	commitBody map[string]any
+	patches    int
+	posts      int
+}
+
+func (f *fakeGitRepo) handle(t *testing.T) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		resp := httptest.NewRecorder()
+		path := strings.TrimPrefix(req.URL.Path, "/repos/owner/repo")
+		switch {
+		case req.Method == http.MethodGet && path == "":
+			json.NewEncoder(resp).Encode(map[string]string{"default_branch": "main"})
+		case req.Method == http.MethodGet && path == "/git/ref/heads/main":
+			json.NewEncoder(resp).Encode(map[string]any{"object": map[string]string{"sha": "head1"}})
+		case req.Method == http.MethodGet && path == "/git/commits/head1":
+			json.NewEncoder(resp).Encode(map[string]any{"tree": map[string]string{"sha": "tree1"}})
+		case req.Method == http.MethodGet && path == "/git/trees/tree1":
+			if req.URL.Query().Get("recursive") != "1" {
+				t.Error("expected a recursive tree read")
+			}
+			var entries []gitTreeEntry
+			for p, sha := range f.blobs {
+				entries = append(entries, gitTreeEntry{Path: p, Type: "blob", SHA: sha})
+			}
+			json.NewEncoder(resp).Encode(map[string]any{"tree": entries})
+		case req.Method == http.MethodPost && path == "/git/trees":
+			f.posts++
+			json.NewDecoder(req.Body).Decode(&f.treeBody)
+			resp.WriteHeader(http.StatusCreated)
+			json.NewEncoder(resp).Encode(map[string]string{"sha": "tree2"})
+		case req.Method == http.MethodPost && path == "/git/commits":
+			f.posts++
+			json.NewDecoder(req.Body).Decode(&f.commitBody)
+			resp.WriteHeader(http.StatusCreated)
+			json.NewEncoder(resp).Encode(map[string]string{"sha": "commit2"})
+		case req.Method == http.MethodPatch && path == "/git/refs/heads/main":
+			f.patches++
+			if f.patches <= f.rejectPatch {
+				resp.WriteHeader(http.StatusUnprocessableEntity)
+				return resp.Result(), nil
+			}
+			json.NewEncoder(resp).Encode(map[string]any{"object": map[string]string{"sha": "commit2"}})
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			resp.WriteHeader(http.StatusNotFound)
+		}
+		return resp.Result(), nil
+	}
+}
+
+func newFakeGitStorage(t *testing.T, repo *fakeGitRepo) *GitHubStorage {
+	gs, _ := NewGitHubStorage("test-token", "owner/repo")
+	gs.httpClient = &http.Client{Transport: &mockTransport{handler: repo.handle(t)}}
+	return gs
+}
+
+func TestGitHubStorage_WriteFiles(t *testing.T) {
+	repo := &fakeGitRepo{blobs: map[string]string{"todos.md": "sha-todos", "other.md": "sha-other"}}
+	gs := newFakeGitStorage(t, repo)
+
+	err := gs.WriteFiles(context.Background(), []FileChange{
+		{Path: "todos.md", Content: "# Todos\n", SHA: "sha-todos"},
+		{Path: "archive/2026.md", Content: "# Archive\n"},
+	}, "Archive todos")
+	if err != nil {
+		t.Fatalf("WriteFiles() error = %v", err)
+	}
+
+	if repo.treeBody["base_tree"] != "tree1" {
+		t.Errorf("base_tree = %v, want tree1", repo.treeBody["base_tree"])
+	}
+	entries, _ := repo.treeBody["tree"].([]any)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 tree entries, got %v", repo.treeBody["tree"])
+	}
+	first := entries[0].(map[string]any)
+	if first["path"] != "todos.md" || first["content"] != "# Todos\n" || first["mode"] != "100644" {
+		t.Errorf("unexpected tree entry %v", first)
+	}
+	if repo.commitBody["message"] != "Archive todos" || repo.commitBody["tree"] != "tree2" {
+		t.Errorf("unexpected commit %v", repo.commitBody)
+	}
+	if parents, _ := repo.commitBody["parents"].([]any); len(parents) != 1 || parents[0] != "head1" {
+		t.Errorf("parents = %v, want [head1]", repo.commitBody["parents"])
+	}
+	if repo.patches != 1 {
+		t.Errorf("expected 1 ref update, got %d", repo.patches)
+	}
+}
+
+func TestGitHubStorage_WriteFiles_Conflict(t *testing.T) {
+	tests := []struct {
+		name   string
+		change FileChange
+	}{
+		{"stale sha", FileChange{Path: "todos.md", Content: "x", SHA: "old-sha"}},
+		{"create over existing", FileChange{Path: "todos.md", Content: "x"}},
+		{"update of missing file", FileChange{Path: "missing.md", Content: "x", SHA: "sha"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeGitRepo{blobs: map[string]string{"todos.md": "sha-todos"}}
+			gs := newFakeGitStorage(t, repo)
+
+			err := gs.WriteFiles(context.Background(), []FileChange{tt.change}, "Update")
+			if err != ErrConflict {
+				t.Errorf("WriteFiles() error = %v, want ErrConflict", err)
+			}
+			if repo.posts != 0 || repo.patches != 0 {
+				t.Errorf("expected nothing written, got %d posts and %d ref updates", repo.posts, repo.patches)
+			}
+		})
+	}
+}
+
+func TestGitHubStorage_WriteFiles_RetriesNonFastForward(t *testing.T) {
+	repo := &fakeGitRepo{blobs: map[string]string{"todos.md": "sha-todos"}, rejectPatch: 1}
+	gs := newFakeGitStorage(t, repo)
+
+	change := FileChange{Path: "todos.md", Content: "x", SHA: "sha-todos"}
+	if err := gs.WriteFiles(context.Background(), []FileChange{change}, "Update"); err != nil {
+		t.Fatalf("WriteFiles() error = %v", err)
+	}
+	if repo.patches != 2 {
+		t.Errorf("expected a retried ref update, got %d updates", repo.patches)
+	}
+
+	// A branch that keeps moving eventually reports a conflict
+	repo = &fakeGitRepo{blobs: map[string]string{"todos.md": "sha-todos"}, rejectPatch: maxRefUpdateAttempts}
+	gs = newFakeGitStorage(t, repo)
+	if err := gs.WriteFiles(context.Background(), []FileChange{change}, "Update"); err != ErrConflict {
+		t.Errorf("WriteFiles() error = %v, want ErrConflict", err)
+	}
+}
+
+// plainStorage records WriteFile calls and has no WriteFiles method.
+type plainStorage struct {
+	writes []string
+}
+
+func (p *plainStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	return "", "", ErrNotFound
+}
+
+func (p *plainStorage) WriteFile(ctx context.Context, path, content, sha, message string) error {
+	p.writes = append(p.writes, path+": "+message)
+	return nil
+}
+
+func TestWriteFiles_FallsBackToWriteFile(t *testing.T) {
+	s := &plainStorage{}
+	err := WriteFiles(context.Background(), s, []FileChange{
+		{Path: "a.md", Content: "a"},
+		{Path: "b.md", Content: "b"},
+	}, "Import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.writes) != 2 || s.writes[0] != "a.md: Import" || s.writes[1] != "b.md: Import" {
+		t.Errorf("unexpected writes %v", s.writes)
+	}
+}
+
+func TestGitHubStorage_Committer(t *testing.T) {
+	repo := &fakeGitRepo{blobs: map[string]string{"todos.md": "sha-todos"}}
+	gs := newFakeGitStorage(t, repo)
+	gs.SetCommitter("Momentum Bot", "bot@example.com")
+
+	if err := gs.WriteFiles(context.Background(), []FileChange{{Path: "todos.md", Content: "x", SHA: "sha-todos"}}, "Edit"); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"name": "Momentum Bot", "email": "bot@example.com"}
+	for _, field := range []string{"author", "committer"} {
+		if got, _ := repo.commitBody[field].(map[string]any); got["name"] != want["name"] || got["email"] != want["email"] {
+			t.Errorf("commit %s = %v, want %v", field, repo.commitBody[field], want)
+		}
+	}
+
+	// The Contents API write carries the same identity
+	var body writeRequest
+	gs.httpClient = &http.Client{Transport: &mockTransport{handler: func(req *http.Request) (*http.Response, error) {
+		json.NewDecoder(req.Body).Decode(&body)
+		return httptest.NewRecorder().Result(), nil
+	}}}
+	if err := gs.WriteFile(context.Background(), "todos.md", "y", "sha", "Edit"); err != nil {
+		t.Fatal(err)
+	}
+	if body.Author == nil || *body.Author != (CommitIdentity{"Momentum Bot", "bot@example.com"}) || body.Committer == nil {
+		t.Errorf("write request author %+v, committer %+v", body.Author, body.Committer)
+	}
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Comment is a timestamped remark on a todo or milestone, written as an
+// indented "> 2026-02-10 09:30 text" line below it.
+type Comment struct {
+	At   time.Time
+	Text string
+}
+
+// commentFormat is the timestamp format of comment lines.
+const commentFormat = "2006-01-02 15:04"
+
+// commentLinePattern matches a comment line: > 2026-02-10 09:30 Blocked on vendor reply
+var commentLinePattern = regexp.MustCompile(`^>\s*(\d{4}-\d{2}-\d{2} \d{2}:\d{2})\s+(.+)$`)
+
+// parseCommentLine parses an indented comment line.
+func parseCommentLine(line string) (Comment, bool) {
+	if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+		return Comment{}, false
+	}
+	matches := commentLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+	if matches == nil {
+		return Comment{}, false
+	}
+	at, err := time.Parse(commentFormat, matches[1])
+	if err != nil {
+		return Comment{}, false
+	}
+	return Comment{At: at, Text: strings.TrimSpace(matches[2])}, true
+}
+
+// formatComments writes comments as indented lines.
+func formatComments(comments []Comment) string {
+	var b strings.Builder
+	for _, c := range comments {
+		b.WriteString("  > " + c.At.Format(commentFormat) + " " + c.Text + "\n")
+	}
+	return b.String()
+}
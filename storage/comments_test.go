@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComments_RoundTrip(t *testing.T) {
+	todos := "# Active Todos\n\n## Normal\n- [ ] Ship it {id:aaaa1111}\n  - [ ] Write tests {id:bbbb2222}\n  > 2026-02-10 09:30 Blocked on vendor reply\n  > 2026-02-11 14:05 Vendor replied\n- [ ] Tidy up {id:cccc3333}\n\n# Completed\n"
+	tf, err := ParseTodos(todos)
+	if err != nil {
+		t.Fatalf("ParseTodos failed: %v", err)
+	}
+	if c := tf.Active[0].Comments; len(c) != 2 || c[0].Text != "Blocked on vendor reply" || c[1].At != time.Date(2026, 2, 11, 14, 5, 0, 0, time.UTC) {
+		t.Errorf("unexpected comments %+v", c)
+	}
+	if len(tf.Active[0].Subtasks) != 1 || len(tf.Active[1].Comments) != 0 {
+		t.Errorf("unexpected todos %+v", tf.Active)
+	}
+	if got := SerializeTodos(tf); got != todos {
+		t.Errorf("todos round trip mismatch:\n%s", got)
+	}
+
+	// Only indented comment lines directly under a milestone belong to it
+	strategy := "# Discoverability Strategy Progress\n\n## Current Phase\nLaunch\n\n## Active Milestones\n- [ ] First users {id:dddd4444}\n  > 2026-02-10 09:30 Waiting on the landing page\n\n## Completed Milestones\n\n## Notes\n- > 2026-02-10 09:30 not a comment\n"
+	s, err := ParseStrategy(strategy)
+	if err != nil {
+		t.Fatalf("ParseStrategy failed: %v", err)
+	}
+	if c := s.ActiveMilestones[0].Comments; len(c) != 1 || c[0].Text != "Waiting on the landing page" {
+		t.Errorf("unexpected milestone comments %+v", c)
+	}
+	if len(s.Notes) != 1 {
+		t.Errorf("unexpected notes %+v", s.Notes)
+	}
+	if got := SerializeStrategy(s); got != strategy {
+		t.Errorf("strategy round trip mismatch:\n%s", got)
+	}
+}
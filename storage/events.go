@@ -0,0 +1,325 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+// DefaultEventLogPath is the default location of the event log in the data repo.
+const DefaultEventLogPath = "events.jsonl"
+
+// DefaultEventSegmentBytes is the size past which the event log is archived
+// and a new segment started, well under the 1 MB the GitHub Contents API
+// returns inline.
+const DefaultEventSegmentBytes = 256 * 1024
+
+// EventSnapshot is the Kind of the record that starts a log segment.
+const EventSnapshot = "snapshot"
+
+// Event is a single mutation of a data file. Events record the change as a
+// reversible line diff, so the log grows with the size of each change
+// rather than of the file.
+type Event struct {
+	Seq     int       `json:"seq"`
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind,omitempty"`
+	Path    string    `json:"path,omitempty"`
+	Message string    `json:"message,omitempty"`
+	// Change is the edit the event made to Path.
+	Change *Hunk `json:"change,omitempty"`
+	// Content is the full new content of Path, as logged before events
+	// recorded diffs. Such events can still be undone.
+	Content string `json:"content,omitempty"`
+	// UndoOf is set when the event reverts an earlier event.
+	UndoOf int `json:"undo_of,omitempty"`
+	// Previous is the archived segment a snapshot record continues from.
+	Previous string `json:"previous,omitempty"`
+}
+
+// Hunk replaces the lines Old, starting at the zero-based line Line, with
+// New. Lines keep their line endings.
+type Hunk struct {
+	Line int      `json:"line"`
+	Old  []string `json:"old,omitempty"`
+	New  []string `json:"new,omitempty"`
+}
+
+// errHunkMismatch is returned when a hunk's lines aren't where it expects,
+// because the file was changed outside the event log.
+var errHunkMismatch = errors.New("file does not match the logged change")
+
+// EventStore implements Storage with an event log beside the markdown
+// files. Every write appends an event to the JSONL log and commits it
+// together with the new markdown file, so the repo stays readable by humans
+// and other tools, and reads are plain reads of the markdown files.
+//
+// The log is kept in segments: once it grows past the segment size it is
+// archived next to the log (events.000001.jsonl for the segment starting at
+// event 1) and a new segment starts with a snapshot record. The markdown
+// files committed with the snapshot are the state up to that point, so
+// nothing before it is ever replayed.
+type EventStore struct {
+	backend      Storage
+	logPath      string
+	segmentBytes int
+	clock        clock.Clock
+
+	// mu serializes appends from this process; cross-process races are caught
+	// by the backend's SHA checks on the log and the markdown file.
+	mu sync.Mutex
+}
+
+// NewEventStore creates an EventStore that keeps its log at logPath in backend.
+// Events are timestamped with c; a nil clock uses the system clock.
+func NewEventStore(backend Storage, logPath string, c clock.Clock) *EventStore {
+	if logPath == "" {
+		logPath = DefaultEventLogPath
+	}
+	return &EventStore{backend: backend, logPath: logPath, segmentBytes: DefaultEventSegmentBytes, clock: clock.Or(c)}
+}
+
+// ReadFile reads path from the backend.
+func (e *EventStore) ReadFile(ctx context.Context, path string) (string, string, error) {
+	return e.backend.ReadFile(ctx, path)
+}
+
+// WriteFile logs the change to path and writes it, in one commit where the
+// backend supports it. sha is the backend SHA from the last ReadFile.
+func (e *EventStore) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	current, currentSHA, err := e.backend.ReadFile(ctx, path)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if sha != currentSHA {
+		return ErrConflict
+	}
+	return e.append(ctx, Event{
+		Path:    path,
+		Message: message,
+		Change:  diffLines(current, content),
+	}, content, currentSHA)
+}
+
+// Undo reverts the most recent change to path by appending an event that
+// restores its previous content. It returns the event that was undone, or
+// ErrNotFound if path has no logged change to revert.
+func (e *EventStore) Undo(ctx context.Context, path string) (*Event, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	target, change, err := e.lastChange(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	current, sha, err := e.backend.ReadFile(ctx, path)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	revert := &Hunk{Line: change.Line, Old: change.New, New: change.Old}
+	previous, err := revert.apply(current)
+	if errors.Is(err, errHunkMismatch) {
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.append(ctx, Event{
+		Path:    path,
+		Message: fmt.Sprintf("Undo: %s", target.Message),
+		Change:  revert,
+		UndoOf:  target.Seq,
+	}, previous, sha)
+	if err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// Events returns the current log segment, oldest first, starting with its
+// snapshot record if it has one.
+func (e *EventStore) Events(ctx context.Context) ([]Event, error) {
+	events, _, _, err := e.readSegment(ctx, e.logPath)
+	return events, err
+}
+
+// lastChange finds the last event for path that is still in effect and the
+// change it made, looking back through archived segments as needed. Undo
+// events, and the events they reverted, are passed over, so repeated undos
+// walk back through the history.
+func (e *EventStore) lastChange(ctx context.Context, path string) (*Event, *Hunk, error) {
+	undone := make(map[int]bool)
+	segment := e.logPath
+	for segment != "" {
+		events, _, _, err := e.readSegment(ctx, segment)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := len(events) - 1; i >= 0; i-- {
+			ev := &events[i]
+			if ev.Kind != "" || ev.Path != path || undone[ev.Seq] {
+				continue
+			}
+			if ev.UndoOf != 0 {
+				undone[ev.UndoOf] = true
+				continue
+			}
+			if ev.Change != nil {
+				return ev, ev.Change, nil
+			}
+			// A full-content event from an older log: diff it against the
+			// version before it in the same segment
+			for j := i - 1; j >= 0; j-- {
+				if events[j].Kind == "" && events[j].Path == path && events[j].Change == nil {
+					return ev, diffLines(events[j].Content, ev.Content), nil
+				}
+			}
+			return nil, nil, ErrNotFound
+		}
+		segment = ""
+		if len(events) > 0 && events[0].Kind == EventSnapshot {
+			segment = events[0].Previous
+		}
+	}
+	return nil, nil, ErrNotFound
+}
+
+// append adds event to the log, archiving a full segment first, and writes
+// content to event.Path (whose current SHA is sha) in the same commit.
+func (e *EventStore) append(ctx context.Context, event Event, content, sha string) error {
+	events, log, logSHA, err := e.readSegment(ctx, e.logPath)
+	if err != nil {
+		return err
+	}
+	event.Seq = 1
+	if len(events) > 0 {
+		event.Seq = events[len(events)-1].Seq + 1
+	}
+	event.Time = e.clock.Now().UTC()
+
+	var changes []FileChange
+	if len(log)+len(encodeEvent(event)) > e.segmentBytes && len(events) > 0 {
+		archive := e.archivePath(events[0])
+		changes = append(changes, FileChange{Path: archive, Content: log})
+		log = encodeEvent(Event{Seq: event.Seq - 1, Time: event.Time, Kind: EventSnapshot, Previous: archive})
+	}
+	changes = append(changes,
+		FileChange{Path: e.logPath, Content: log + encodeEvent(event), SHA: logSHA},
+		FileChange{Path: event.Path, Content: content, SHA: sha},
+	)
+	return WriteFiles(ctx, e.backend, changes, fmt.Sprintf("%s [event %d]", event.Message, event.Seq))
+}
+
+// archivePath names the archive of the segment starting with first, e.g.
+// events.000001.jsonl.
+func (e *EventStore) archivePath(first Event) string {
+	seq := first.Seq
+	if first.Kind == EventSnapshot {
+		seq++
+	}
+	return fmt.Sprintf("%s.%06d.jsonl", strings.TrimSuffix(e.logPath, ".jsonl"), seq)
+}
+
+// readSegment reads and decodes a log segment. A missing log is empty.
+func (e *EventStore) readSegment(ctx context.Context, path string) ([]Event, string, string, error) {
+	content, sha, err := e.backend.ReadFile(ctx, path)
+	if errors.Is(err, ErrNotFound) {
+		return nil, "", "", nil
+	}
+	if err != nil {
+		return nil, "", "", err
+	}
+	events, err := decodeLog(content)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return events, content, sha, nil
+}
+
+// decodeLog parses JSONL, one event per line.
+func decodeLog(content string) ([]Event, error) {
+	var events []Event
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var ev Event
+		if err := json.Unmarshal([]byte(text), &ev); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, ev)
+	}
+	return events, scanner.Err()
+}
+
+// encodeEvent serializes an event as a JSONL line.
+func encodeEvent(ev Event) string {
+	data, _ := json.Marshal(ev)
+	return string(data) + "\n"
+}
+
+// splitLines splits content into lines that keep their line endings.
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the hunk turning a into b: the lines between their
+// common prefix and suffix.
+func diffLines(a, b string) *Hunk {
+	from, to := splitLines(a), splitLines(b)
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix && from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+	return &Hunk{Line: prefix, Old: from[prefix : len(from)-suffix], New: to[prefix : len(to)-suffix]}
+}
+
+// apply returns content with the hunk applied, or errHunkMismatch if the
+// lines it replaces aren't there.
+func (h *Hunk) apply(content string) (string, error) {
+	lines := splitLines(content)
+	end := h.Line + len(h.Old)
+	if h.Line < 0 || end > len(lines) {
+		return "", errHunkMismatch
+	}
+	for i, line := range h.Old {
+		if lines[h.Line+i] != line {
+			return "", errHunkMismatch
+		}
+	}
+	var b strings.Builder
+	for _, line := range lines[:h.Line] {
+		b.WriteString(line)
+	}
+	for _, line := range h.New {
+		b.WriteString(line)
+	}
+	for _, line := range lines[end:] {
+		b.WriteString(line)
+	}
+	return b.String(), nil
+}
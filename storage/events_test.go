@@ -0,0 +1,215 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+func TestEventStore_WriteAndProject(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryStorage(map[string]string{"todos.md": "# Todos\n- one\n- two\n"}, nil)
+	es := NewEventStore(backend, "", nil)
+
+	content, sha, err := es.ReadFile(ctx, "todos.md")
+	if err != nil || content != "# Todos\n- one\n- two\n" {
+		t.Fatalf("ReadFile() = %q, %v", content, err)
+	}
+	if err := es.WriteFile(ctx, "todos.md", "# Todos\n- one\n- 2\n", sha, "Edit todo"); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if got, _, _ := backend.ReadFile(ctx, "todos.md"); got != "# Todos\n- one\n- 2\n" {
+		t.Errorf("projection not written, got %q", got)
+	}
+
+	// The event holds the changed lines only, and the log and the markdown
+	// file are one commit
+	events, _ := es.Events(ctx)
+	if len(events) != 1 || events[0].Content != "" || events[0].Change == nil ||
+		events[0].Change.Line != 2 || len(events[0].Change.Old) != 1 || events[0].Change.New[0] != "- 2\n" {
+		t.Errorf("unexpected events %+v", events)
+	}
+	if commits, _ := backend.ListCommits(ctx, DefaultEventLogPath, 0); len(commits) != 1 || commits[0].Message != "Edit todo [event 1]" {
+		t.Errorf("unexpected log commits %+v", commits)
+	}
+	if commits, _ := backend.ListCommits(ctx, "todos.md", 0); len(commits) != 2 {
+		t.Errorf("expected the seed and one write commit, got %+v", commits)
+	}
+
+	// Writing with the stale SHA must conflict and log nothing
+	if err := es.WriteFile(ctx, "todos.md", "v2", sha, "Stale"); err != ErrConflict {
+		t.Errorf("expected ErrConflict for stale sha, got %v", err)
+	}
+	if events, _ := es.Events(ctx); len(events) != 1 {
+		t.Errorf("stale write was logged: %+v", events)
+	}
+}
+
+func TestEventStore_Undo(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryStorage(nil, nil)
+	es := NewEventStore(backend, "", nil)
+
+	es.WriteFile(ctx, "todos.md", "a\nb\n", "", "First")
+	_, sha, _ := es.ReadFile(ctx, "todos.md")
+	es.WriteFile(ctx, "todos.md", "a\nB\nc\n", sha, "Second")
+
+	undone, err := es.Undo(ctx, "todos.md")
+	if err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if undone.Message != "Second" {
+		t.Errorf("expected to undo %q, got %q", "Second", undone.Message)
+	}
+	if content, _, _ := es.ReadFile(ctx, "todos.md"); content != "a\nb\n" {
+		t.Errorf("expected the first version after undo, got %q", content)
+	}
+
+	events, _ := es.Events(ctx)
+	if len(events) != 3 || events[2].UndoOf != 2 {
+		t.Errorf("expected undo event referencing seq 2, got %+v", events)
+	}
+
+	// A file changed outside the log since can't be undone
+	_, sha, _ = es.ReadFile(ctx, "todos.md")
+	backend.WriteFile(ctx, "todos.md", "x\n", sha, "Edited elsewhere")
+	if _, err := es.Undo(ctx, "todos.md"); err != ErrConflict {
+		t.Errorf("Undo() after an outside edit = %v, want ErrConflict", err)
+	}
+
+	if _, err := es.Undo(ctx, "reminders.md"); err != ErrNotFound {
+		t.Errorf("Undo() without changes = %v, want ErrNotFound", err)
+	}
+}
+
+func TestEventStore_UndoTwice(t *testing.T) {
+	ctx := context.Background()
+	es := NewEventStore(NewMemoryStorage(nil, nil), "", nil)
+	for i, content := range []string{"a\n", "a\nb\n", "a\nb\nc\n"} {
+		_, sha, _ := es.ReadFile(ctx, "todos.md")
+		if err := es.WriteFile(ctx, "todos.md", content, sha, fmt.Sprintf("Write %d", i+1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Each undo reverts the change before the last one undone, never an undo
+	for _, want := range []struct{ message, content string }{
+		{"Write 3", "a\nb\n"},
+		{"Write 2", "a\n"},
+		{"Write 1", ""},
+	} {
+		undone, err := es.Undo(ctx, "todos.md")
+		if err != nil || undone.Message != want.message {
+			t.Fatalf("Undo() = %+v, %v, want %s undone", undone, err, want.message)
+		}
+		if content, _, _ := es.ReadFile(ctx, "todos.md"); content != want.content {
+			t.Errorf("after undoing %s got %q, want %q", want.message, content, want.content)
+		}
+	}
+	if _, err := es.Undo(ctx, "todos.md"); err != ErrNotFound {
+		t.Errorf("Undo() with nothing left = %v, want ErrNotFound", err)
+	}
+}
+
+func TestEventStore_Segments(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryStorage(nil, nil)
+	es := NewEventStore(backend, "", nil)
+	es.segmentBytes = 400
+
+	if err := es.WriteFile(ctx, "notes.md", "# Notes\n", "", "Add notes"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 6; i++ {
+		_, sha, _ := es.ReadFile(ctx, "todos.md")
+		content := strings.Repeat("line\n", i) + "last\n"
+		if err := es.WriteFile(ctx, "todos.md", content, sha, "Write"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The log stays small: full segments are archived and the current one
+	// starts with a snapshot pointing at the last archive
+	log, _, _ := backend.ReadFile(ctx, DefaultEventLogPath)
+	if len(log) > 400 {
+		t.Errorf("log is %d bytes, want at most 400", len(log))
+	}
+	events, _ := es.Events(ctx)
+	if len(events) < 2 || events[0].Kind != EventSnapshot || events[len(events)-1].Seq != 7 {
+		t.Fatalf("unexpected current segment %+v", events)
+	}
+	archived, _, err := backend.ReadFile(ctx, events[0].Previous)
+	if err != nil {
+		t.Fatalf("reading archive %s: %v", events[0].Previous, err)
+	}
+	if archivedEvents, err := decodeLog(archived); err != nil || archivedEvents[len(archivedEvents)-1].Seq != events[0].Seq {
+		t.Errorf("archive %s doesn't end where the snapshot starts: %q, %v", events[0].Previous, archived, err)
+	}
+
+	// Undo reaches back into archived segments
+	undone, err := es.Undo(ctx, "notes.md")
+	if err != nil || undone.Seq != 1 {
+		t.Fatalf("Undo() = %+v, %v", undone, err)
+	}
+	if content, _, _ := backend.ReadFile(ctx, "notes.md"); content != "" {
+		t.Errorf("notes.md after undo = %q, want empty", content)
+	}
+}
+
+func TestEventStore_LegacyLog(t *testing.T) {
+	ctx := context.Background()
+	// Logs from before events held diffs carry each version in full
+	var log strings.Builder
+	for i, content := range []string{"v1\n", "v2\n"} {
+		data, _ := json.Marshal(Event{Seq: i + 1, Path: "todos.md", Message: "Write", Content: content})
+		log.Write(append(data, '\n'))
+	}
+	backend := NewMemoryStorage(map[string]string{DefaultEventLogPath: log.String(), "todos.md": "v2\n"}, nil)
+	es := NewEventStore(backend, "", nil)
+
+	undone, err := es.Undo(ctx, "todos.md")
+	if err != nil || undone.Seq != 2 {
+		t.Fatalf("Undo() = %+v, %v", undone, err)
+	}
+	if content, _, _ := es.ReadFile(ctx, "todos.md"); content != "v1\n" {
+		t.Errorf("expected v1 after undo, got %q", content)
+	}
+}
+
+func TestEventStore_UsesClock(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	es := NewEventStore(NewMemoryStorage(nil, nil), "", clock.NewFake(now))
+
+	if err := es.WriteFile(ctx, "todos.md", "v1", "", "First"); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	events, _ := es.Events(ctx)
+	if len(events) != 1 || !events[0].Time.Equal(now) {
+		t.Errorf("expected event stamped %v, got %+v", now, events)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	for _, c := range [][2]string{
+		{"", "a\n"},
+		{"a\nb\nc\n", "a\nc\n"},
+		{"a\nb", "a\nb\n"},
+		{"same\n", "same\n"},
+		{"x\ny\n", ""},
+	} {
+		h := diffLines(c[0], c[1])
+		if got, err := h.apply(c[0]); err != nil || got != c[1] {
+			t.Errorf("diffLines(%q, %q) applied = %q, %v", c[0], c[1], got, err)
+		}
+		revert := &Hunk{Line: h.Line, Old: h.New, New: h.Old}
+		if got, err := revert.apply(c[1]); err != nil || got != c[0] {
+			t.Errorf("reverted diffLines(%q, %q) = %q, %v", c[0], c[1], got, err)
+		}
+	}
+}
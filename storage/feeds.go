@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Feed is an RSS or Atom feed from feeds.md whose entries are pulled into
+// the reading list.
+type Feed struct {
+	Name string // slug, also used as the reading list tag
+	URL  string
+}
+
+// Matches feed line: - name: https://example.com/feed.xml
+var feedLinePattern = regexp.MustCompile(`^-\s*(.+?)\s*:\s*(https?://\S+)\s*$`)
+
+// ParseFeeds parses a feeds.md file content. Each "- name: URL" line
+// registers a feed; the name is normalized like a project slug.
+func ParseFeeds(content string) ([]Feed, error) {
+	var feeds []Feed
+	for _, line := range strings.Split(content, "\n") {
+		if matches := feedLinePattern.FindStringSubmatch(strings.TrimSpace(line)); matches != nil {
+			name := NormalizeProject(matches[1])
+			if name == "" {
+				continue
+			}
+			feeds = append(feeds, Feed{Name: name, URL: matches[2]})
+		}
+	}
+	return feeds, nil
+}
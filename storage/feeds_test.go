@@ -0,0 +1,22 @@
+package storage
+
+import "testing"
+
+func TestParseFeeds(t *testing.T) {
+	feeds, err := ParseFeeds("# Feeds\n\n- Go Blog: https://go.dev/blog/feed.atom\n- not a feed\n- hn: http://news.ycombinator.com/rss\n")
+	if err != nil {
+		t.Fatalf("ParseFeeds failed: %v", err)
+	}
+	want := []Feed{
+		{Name: "go-blog", URL: "https://go.dev/blog/feed.atom"},
+		{Name: "hn", URL: "http://news.ycombinator.com/rss"},
+	}
+	if len(feeds) != len(want) {
+		t.Fatalf("expected %d feeds, got %+v", len(want), feeds)
+	}
+	for i := range want {
+		if feeds[i] != want[i] {
+			t.Errorf("feed %d: expected %+v, got %+v", i, want[i], feeds[i])
+		}
+	}
+}
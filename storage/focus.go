@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Focus represents the parsed contents of focus.md: the todos and
+// milestones picked as the focus for one day.
+type Focus struct {
+	Date  time.Time // midnight UTC of the day; zero if no focus is set
+	Items []FocusItem
+}
+
+// FocusItem is a todo or milestone in the focus list.
+type FocusItem struct {
+	ItemType string // "todo" or "milestone"
+	ItemID   string
+	Text     string // item text when the focus was set
+}
+
+// Matches focus line: - todo:abcd1234 Text
+var focusLinePattern = regexp.MustCompile(`^-\s*(todo|milestone):(\S+)\s*(.*)$`)
+
+// ParseFocus parses a focus.md file content. The items are listed under a
+// "## YYYY-MM-DD" heading for the day they're the focus of.
+func ParseFocus(content string) (*Focus, error) {
+	f := &Focus{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "## ") {
+			if date, err := time.Parse("2006-01-02", strings.TrimSpace(strings.TrimPrefix(line, "## "))); err == nil {
+				f.Date = date
+			}
+			continue
+		}
+		if matches := focusLinePattern.FindStringSubmatch(line); matches != nil {
+			f.Items = append(f.Items, FocusItem{ItemType: matches[1], ItemID: matches[2], Text: matches[3]})
+		}
+	}
+	return f, nil
+}
+
+// SerializeFocus converts Focus back to markdown.
+func SerializeFocus(f *Focus) string {
+	var b strings.Builder
+	b.WriteString("# Focus\n")
+	if f.Date.IsZero() {
+		return b.String()
+	}
+	b.WriteString("\n## " + f.Date.Format("2006-01-02") + "\n")
+	for _, item := range f.Items {
+		line := "- " + item.ItemType + ":" + item.ItemID
+		if item.Text != "" {
+			line += " " + item.Text
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// FocusStatus is a focus item's progress.
+type FocusStatus struct {
+	FocusItem
+	Done bool
+	// Missing is set when the item no longer exists, e.g. it was deleted.
+	Missing bool
+	// SubtasksDone and Subtasks count a todo's checklist.
+	SubtasksDone, Subtasks int
+}
+
+// ResolveFocus looks each focus item up in todos and s, either of which may
+// be nil, and reports its progress. Text is brought up to date with the
+// item's.
+func ResolveFocus(f *Focus, todos *TodoFile, s *Strategy) []FocusStatus {
+	todoByID := make(map[string]Todo)
+	if todos != nil {
+		for _, t := range append(append([]Todo{}, todos.Active...), todos.Completed...) {
+			todoByID[t.ID] = t
+		}
+	}
+	milestoneByID := make(map[string]Milestone)
+	if s != nil {
+		for _, m := range append(append([]Milestone{}, s.ActiveMilestones...), s.CompletedMilestones...) {
+			milestoneByID[m.ID] = m
+		}
+	}
+
+	statuses := make([]FocusStatus, len(f.Items))
+	for i, item := range f.Items {
+		status := FocusStatus{FocusItem: item, Missing: true}
+		if t, ok := todoByID[item.ItemID]; ok && item.ItemType == "todo" {
+			status.Text, status.Done, status.Missing = t.Text, t.Completed, false
+			for _, sub := range t.Subtasks {
+				status.Subtasks++
+				if sub.Completed {
+					status.SubtasksDone++
+				}
+			}
+		}
+		if m, ok := milestoneByID[item.ItemID]; ok && item.ItemType == "milestone" {
+			status.Text, status.Done, status.Missing = m.Text, m.Completed, false
+		}
+		statuses[i] = status
+	}
+	return statuses
+}
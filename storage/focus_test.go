@@ -0,0 +1,37 @@
+package storage
+
+import "testing"
+
+func TestParseFocus_RoundTrip(t *testing.T) {
+	input := `# Focus
+
+## 2026-02-03
+- todo:aaaa1111 Write landing copy
+- milestone:bbbb2222 Launch site
+- todo:cccc3333 Gone
+`
+	f, err := ParseFocus(input)
+	if err != nil {
+		t.Fatalf("ParseFocus failed: %v", err)
+	}
+	if f.Date.Format("2006-01-02") != "2026-02-03" || len(f.Items) != 3 {
+		t.Fatalf("unexpected focus: %+v", f)
+	}
+	if out := SerializeFocus(f); out != input {
+		t.Errorf("round trip mismatch:\n%s", out)
+	}
+
+	todos, _ := ParseTodos("# Active Todos\n\n## Normal\n- [ ] Write the landing copy {id:aaaa1111}\n" +
+		"  - [x] Outline {id:dddd4444}\n  - [ ] Draft {id:eeee5555}\n\n# Completed\n")
+	strategy, _ := ParseStrategy("## Completed\n- [x] Launch site {id:bbbb2222}\n")
+	statuses := ResolveFocus(f, todos, strategy)
+	if s := statuses[0]; s.Text != "Write the landing copy" || s.Done || s.SubtasksDone != 1 || s.Subtasks != 2 {
+		t.Errorf("unexpected todo status: %+v", s)
+	}
+	if s := statuses[1]; !s.Done || s.Missing {
+		t.Errorf("unexpected milestone status: %+v", s)
+	}
+	if s := statuses[2]; !s.Missing || s.Text != "Gone" {
+		t.Errorf("unexpected missing status: %+v", s)
+	}
+}
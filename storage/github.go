@@ -10,7 +10,10 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
 )
 
 // Common errors returned by the storage layer.
@@ -19,6 +22,7 @@ var (
 	ErrConflict      = errors.New("file was modified concurrently (SHA mismatch)")
 	ErrUnauthorized  = errors.New("GitHub API authentication failed")
 	ErrRateLimited   = errors.New("GitHub API rate limit exceeded")
+	ErrUnavailable   = errors.New("GitHub API unavailable")
 )
 
 // Storage defines the interface for reading and writing data files.
@@ -29,11 +33,56 @@ type Storage interface {
 }
 
 // GitHubStorage implements Storage using the GitHub Contents API.
+//
+// Reads are conditional: the last ETag seen for each path is sent as
+// If-None-Match, and a 304 is served from the copy kept here. GitHub doesn't
+// count 304s against the rate limit, and they skip the download.
 type GitHubStorage struct {
 	token      string
 	owner      string
 	repo       string
 	httpClient *http.Client
+
+	// committer, if set, is the author and committer of every commit
+	// instead of the token's user
+	committer *CommitIdentity
+	// clock dates new pull request branches; nil is the system clock
+	clock clock.Clock
+
+	mu    sync.Mutex
+	cache map[string]cachedFile
+	// branch is the default branch, looked up on the first WriteFiles
+	branch string
+}
+
+// CommitIdentity is the name and email recorded on a commit.
+type CommitIdentity struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// SetCommitter makes name and email the author and committer of every
+// commit written from now on, instead of the token's user. Empty values
+// restore the default.
+func (g *GitHubStorage) SetCommitter(name, email string) {
+	if name == "" || email == "" {
+		g.committer = nil
+		return
+	}
+	g.committer = &CommitIdentity{Name: name, Email: email}
+}
+
+// SetClock makes c the clock that names the branches ProposeFiles creates.
+// A nil clock uses the system clock.
+func (g *GitHubStorage) SetClock(c clock.Clock) {
+	g.clock = c
+}
+
+// cachedFile is the last successful read of a path.
+type cachedFile struct {
+	etag    string
+	content string
+	sha     string
 }
 
 // NewGitHubStorage creates a new GitHubStorage instance.
@@ -51,6 +100,7 @@ func NewGitHubStorage(token, repoPath string) (*GitHubStorage, error) {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		cache: make(map[string]cachedFile),
 	}, nil
 }
 
@@ -76,13 +126,27 @@ func (g *GitHubStorage) ReadFile(ctx context.Context, path string) (string, stri
 	req.Header.Set("Accept", "application/vnd.github+json")
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
+	g.mu.Lock()
+	cached, haveCached := g.cache[path]
+	g.mu.Unlock()
+	if haveCached {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
 	resp, err := g.httpClient.Do(req)
 	if err != nil {
 		return "", "", fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && haveCached {
+		return cached.content, cached.sha, nil
+	}
+
 	if err := g.checkResponseError(resp); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			g.forget(path)
+		}
 		return "", "", err
 	}
 
@@ -91,6 +155,14 @@ func (g *GitHubStorage) ReadFile(ctx context.Context, path string) (string, stri
 		return "", "", fmt.Errorf("decoding response: %w", err)
 	}
 
+	// Files over 1 MB come without content; the blobs API serves them
+	if data.Encoding == "none" && data.SHA != "" {
+		var blob contentsResponse
+		if err := g.gitRequest(ctx, http.MethodGet, "git/blobs/"+data.SHA, nil, &blob); err != nil {
+			return "", "", fmt.Errorf("reading large file %s: %w", path, err)
+		}
+		data.Content, data.Encoding = blob.Content, blob.Encoding
+	}
 	if data.Encoding != "base64" {
 		return "", "", fmt.Errorf("unexpected encoding: %s", data.Encoding)
 	}
@@ -102,14 +174,40 @@ func (g *GitHubStorage) ReadFile(ctx context.Context, path string) (string, stri
 		return "", "", fmt.Errorf("decoding base64 content: %w", err)
 	}
 
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		g.mu.Lock()
+		if g.cache == nil {
+			g.cache = make(map[string]cachedFile)
+		}
+		g.cache[path] = cachedFile{etag: etag, content: string(decoded), sha: data.SHA}
+		g.mu.Unlock()
+	}
+
 	return string(decoded), data.SHA, nil
 }
 
+// Invalidate drops the cached copies of paths, so the next reads fetch
+// them in full. Use it when the repo is known to have changed elsewhere.
+func (g *GitHubStorage) Invalidate(paths ...string) {
+	for _, path := range paths {
+		g.forget(path)
+	}
+}
+
+// forget drops the cached copy of path.
+func (g *GitHubStorage) forget(path string) {
+	g.mu.Lock()
+	delete(g.cache, path)
+	g.mu.Unlock()
+}
+
 // writeRequest represents the GitHub Contents API PUT request body.
 type writeRequest struct {
-	Message string `json:"message"`
-	Content string `json:"content"`
-	SHA     string `json:"sha,omitempty"` // Required for updates, omit for creates
+	Message   string          `json:"message"`
+	Content   string          `json:"content"`
+	SHA       string          `json:"sha,omitempty"` // Required for updates, omit for creates
+	Author    *CommitIdentity `json:"author,omitempty"`
+	Committer *CommitIdentity `json:"committer,omitempty"`
 }
 
 // WriteFile writes content to a file in the GitHub repository.
@@ -119,9 +217,11 @@ func (g *GitHubStorage) WriteFile(ctx context.Context, path string, content stri
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", g.owner, g.repo, path)
 
 	body := writeRequest{
-		Message: message,
-		Content: base64.StdEncoding.EncodeToString([]byte(content)),
-		SHA:     sha,
+		Message:   message,
+		Content:   base64.StdEncoding.EncodeToString([]byte(content)),
+		SHA:       sha,
+		Author:    g.committer,
+		Committer: g.committer,
 	}
 
 	bodyJSON, err := json.Marshal(body)
@@ -139,6 +239,9 @@ func (g *GitHubStorage) WriteFile(ctx context.Context, path string, content stri
 	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 	req.Header.Set("Content-Type", "application/json")
 
+	// The written content has a new ETag, so the cached copy is of no further use
+	g.forget(path)
+
 	resp, err := g.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("executing request: %w", err)
@@ -170,6 +273,9 @@ func (g *GitHubStorage) checkResponseError(resp *http.Response) error {
 	default:
 		// Read body for error details
 		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode >= 500 {
+			return fmt.Errorf("%w (status %d): %s", ErrUnavailable, resp.StatusCode, string(body))
+		}
 		return fmt.Errorf("GitHub API error (status %d): %s", resp.StatusCode, string(body))
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -124,6 +125,98 @@ func TestGitHubStorage_ReadFile_WithMockTransport(t *testing.T) {
 	}
 }
 
+func TestGitHubStorage_ReadFile_LargeFile(t *testing.T) {
+	content := "# Large"
+	gs, _ := NewGitHubStorage("test-token", "owner/repo")
+	gs.httpClient = &http.Client{
+		Transport: &mockTransport{
+			handler: func(req *http.Request) (*http.Response, error) {
+				resp := httptest.NewRecorder()
+				if strings.HasSuffix(req.URL.Path, "/git/blobs/sha123") {
+					json.NewEncoder(resp).Encode(map[string]string{
+						"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+						"sha":      "sha123",
+						"encoding": "base64",
+					})
+					return resp.Result(), nil
+				}
+				// The Contents API leaves files over 1 MB out
+				json.NewEncoder(resp).Encode(map[string]string{"content": "", "sha": "sha123", "encoding": "none"})
+				return resp.Result(), nil
+			},
+		},
+	}
+
+	got, sha, err := gs.ReadFile(context.Background(), "events.jsonl")
+	if err != nil || got != content || sha != "sha123" {
+		t.Errorf("ReadFile() = %q, %q, %v", got, sha, err)
+	}
+}
+
+func TestGitHubStorage_ReadFile_ETagCache(t *testing.T) {
+	content := "# Cached"
+	encodedContent := base64.StdEncoding.EncodeToString([]byte(content))
+	var requests, fullResponses int
+	var lastIfNoneMatch string
+
+	gs, _ := NewGitHubStorage("test-token", "owner/repo")
+	gs.httpClient = &http.Client{
+		Transport: &mockTransport{
+			handler: func(req *http.Request) (*http.Response, error) {
+				resp := httptest.NewRecorder()
+				if req.Method == http.MethodPut {
+					resp.WriteHeader(http.StatusOK)
+					return resp.Result(), nil
+				}
+				requests++
+				lastIfNoneMatch = req.Header.Get("If-None-Match")
+				if lastIfNoneMatch == `"etag-1"` {
+					resp.WriteHeader(http.StatusNotModified)
+					return resp.Result(), nil
+				}
+				fullResponses++
+				resp.Header().Set("ETag", `"etag-1"`)
+				json.NewEncoder(resp).Encode(map[string]string{
+					"content":  encodedContent,
+					"sha":      "sha123",
+					"encoding": "base64",
+				})
+				return resp.Result(), nil
+			},
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		gotContent, gotSHA, err := gs.ReadFile(context.Background(), "test.md")
+		if err != nil {
+			t.Fatalf("ReadFile() #%d error = %v", i, err)
+		}
+		if gotContent != content || gotSHA != "sha123" {
+			t.Errorf("ReadFile() #%d = %q, %q", i, gotContent, gotSHA)
+		}
+	}
+	if requests != 3 || fullResponses != 1 {
+		t.Errorf("expected 3 requests with 1 full download, got %d requests, %d full", requests, fullResponses)
+	}
+
+	// Writing drops the cached copy, so the next read is unconditional
+	if err := gs.WriteFile(context.Background(), "test.md", "new", "sha123", "Update"); err != nil {
+		t.Fatal(err)
+	}
+	gs.ReadFile(context.Background(), "test.md")
+	if lastIfNoneMatch != "" || fullResponses != 2 {
+		t.Errorf("expected an unconditional read after a write, got If-None-Match %q", lastIfNoneMatch)
+	}
+
+	// As does invalidating it
+	gs.ReadFile(context.Background(), "test.md")
+	gs.Invalidate("test.md")
+	gs.ReadFile(context.Background(), "test.md")
+	if lastIfNoneMatch != "" || fullResponses != 3 {
+		t.Errorf("expected an unconditional read after Invalidate, got If-None-Match %q", lastIfNoneMatch)
+	}
+}
+
 func TestGitHubStorage_WriteFile_WithMockTransport(t *testing.T) {
 	var capturedBody writeRequest
 
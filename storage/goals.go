@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Goals represents the parsed contents of goals.md.
+type Goals struct {
+	Contribution ContributionGoal
+}
+
+// ContributionGoal is a weekly GitHub contribution target. Zero fields are
+// unset.
+type ContributionGoal struct {
+	WeeklyCommits    int
+	WeeklyActiveDays int
+}
+
+// IsZero reports whether no contribution goal is set.
+func (g ContributionGoal) IsZero() bool {
+	return g.WeeklyCommits == 0 && g.WeeklyActiveDays == 0
+}
+
+// Matches goal line: - Weekly commits: 20
+var goalLinePattern = regexp.MustCompile(`^-\s*(.+?)\s*:\s*(\d+)\s*$`)
+
+// ParseGoals parses a goals.md file content. Goals are "- Name: N" lines
+// under a "## Contribution" heading.
+func ParseGoals(content string) (*Goals, error) {
+	g := &Goals{}
+	section := ""
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "## ") {
+			section = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(line, "## ")))
+			continue
+		}
+		matches := goalLinePattern.FindStringSubmatch(line)
+		if matches == nil || section != "contribution" {
+			continue
+		}
+		n, _ := strconv.Atoi(matches[2])
+		switch strings.ToLower(matches[1]) {
+		case "weekly commits":
+			g.Contribution.WeeklyCommits = n
+		case "weekly active days":
+			g.Contribution.WeeklyActiveDays = n
+		}
+	}
+	return g, nil
+}
+
+// SerializeGoals converts Goals back to markdown.
+func SerializeGoals(g *Goals) string {
+	var b strings.Builder
+	b.WriteString("# Goals\n")
+	if c := g.Contribution; !c.IsZero() {
+		b.WriteString("\n## Contribution\n")
+		if c.WeeklyCommits > 0 {
+			b.WriteString("- Weekly commits: " + strconv.Itoa(c.WeeklyCommits) + "\n")
+		}
+		if c.WeeklyActiveDays > 0 {
+			b.WriteString("- Weekly active days: " + strconv.Itoa(c.WeeklyActiveDays) + "\n")
+		}
+	}
+	return b.String()
+}
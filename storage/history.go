@@ -0,0 +1,241 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Commit is a single commit that touched a data file.
+type Commit struct {
+	SHA     string
+	Message string
+	Date    time.Time
+}
+
+// History is implemented by storage backends that can read past versions of
+// data files. It is optional - callers should type-assert for it.
+type History interface {
+	// ListCommits returns up to limit commits touching path, newest first.
+	ListCommits(ctx context.Context, path string, limit int) ([]Commit, error)
+
+	// ReadFileAt returns the content of path as of the given commit.
+	ReadFileAt(ctx context.Context, path string, ref string) (string, error)
+}
+
+// ItemVersion is an item's line in a data file as of one commit.
+type ItemVersion struct {
+	Commit Commit
+	// Section is the heading the item was under, e.g. "Completed".
+	Section string
+	// Line is the item's markdown line, or "" if the item was not in the
+	// file at this commit (not yet added, or deleted).
+	Line string
+	// Existing is set on the first version when the item was already in the
+	// file at the oldest commit walked, so it was added earlier.
+	Existing bool
+}
+
+// ItemHistory walks up to limit commits touching path and returns the
+// versions of the item with the given ID at which its line or section
+// changed, oldest first. The first version is the commit that added the
+// item, if it falls within the walked commits.
+func ItemHistory(ctx context.Context, h History, path, id string, limit int) ([]ItemVersion, error) {
+	commits, err := h.ListCommits(ctx, path, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing commits for %s: %w", path, err)
+	}
+
+	versions := []ItemVersion{}
+	var prev *ItemVersion
+	for i := len(commits) - 1; i >= 0; i-- {
+		content, err := h.ReadFileAt(ctx, path, commits[i].SHA)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				content = ""
+			} else {
+				return nil, fmt.Errorf("reading %s at %s: %w", path, commits[i].SHA, err)
+			}
+		}
+		v := ItemVersion{Commit: commits[i]}
+		v.Section, v.Line = FindItemLine(content, id)
+
+		changed := prev == nil && v.Line != "" ||
+			prev != nil && (prev.Line != v.Line || prev.Section != v.Section)
+		if changed {
+			// Without a limit the walk reaches the file's first commit
+			v.Existing = prev == nil && limit > 0 && len(commits) == limit
+			versions = append(versions, v)
+		}
+		prev = &v
+	}
+	return versions, nil
+}
+
+// ReadFileAsOf returns the content of path as of at: the version at the
+// newest of up to limit commits made by then, or "" if the file didn't
+// exist yet. ok is false if every commit walked is newer than at, so the
+// version at that time isn't known.
+func ReadFileAsOf(ctx context.Context, h History, path string, at time.Time, limit int) (content string, ok bool, err error) {
+	commits, err := h.ListCommits(ctx, path, limit)
+	if err != nil {
+		return "", false, fmt.Errorf("listing commits for %s: %w", path, err)
+	}
+	for _, c := range commits {
+		if c.Date.After(at) {
+			continue
+		}
+		content, err := h.ReadFileAt(ctx, path, c.SHA)
+		if errors.Is(err, ErrNotFound) {
+			return "", true, nil
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("reading %s at %s: %w", path, c.SHA, err)
+		}
+		return content, true, nil
+	}
+	// Without a limit the walk reached the file's first commit
+	return "", limit <= 0 || len(commits) < limit, nil
+}
+
+// ItemLine is a line of a data file carrying an ID in its metadata block.
+type ItemLine struct {
+	ID string
+	// Section is the heading the line is under.
+	Section string
+	Line    string
+}
+
+// ItemLines returns the lines of content carrying an ID, in file order.
+func ItemLines(content string) []ItemLine {
+	var lines []ItemLine
+	section := ""
+	for _, l := range strings.Split(content, "\n") {
+		if strings.HasPrefix(l, "#") {
+			section = strings.TrimSpace(strings.TrimLeft(l, "#"))
+			continue
+		}
+		if m := metadataPattern.FindStringSubmatch(l); m != nil {
+			if id := metadataValue(m[1], "id"); id != "" {
+				lines = append(lines, ItemLine{ID: id, Section: section, Line: strings.TrimSpace(l)})
+			}
+		}
+	}
+	return lines
+}
+
+// FindItemLine returns the line carrying id in its metadata block and the
+// heading of the section it is in, or empty strings if no line has the ID.
+func FindItemLine(content, id string) (section, line string) {
+	for _, l := range ItemLines(content) {
+		if l.ID == id {
+			return l.Section, l.Line
+		}
+	}
+	return "", ""
+}
+
+// commitResponse is one entry of the GitHub list-commits response.
+type commitResponse struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message   string `json:"message"`
+		Committer struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+}
+
+// ListCommits pages through the commits API for path, newest first.
+func (g *GitHubStorage) ListCommits(ctx context.Context, path string, limit int) ([]Commit, error) {
+	var commits []Commit
+	for page := 1; limit <= 0 || len(commits) < limit; page++ {
+		u := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits?path=%s&per_page=100&page=%d",
+			g.owner, g.repo, url.QueryEscape(path), page)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+g.token)
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+		resp, err := g.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("executing request: %w", err)
+		}
+
+		if err := g.checkResponseError(resp); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+
+		var data []commitResponse
+		err = json.NewDecoder(resp.Body).Decode(&data)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decoding response: %w", err)
+		}
+
+		for _, c := range data {
+			commits = append(commits, Commit{
+				SHA:     c.SHA,
+				Message: c.Commit.Message,
+				Date:    c.Commit.Committer.Date,
+			})
+		}
+		if len(data) < 100 {
+			break
+		}
+	}
+
+	if limit > 0 && len(commits) > limit {
+		commits = commits[:limit]
+	}
+	return commits, nil
+}
+
+// ReadFileAt fetches a file's content as of the given commit SHA.
+func (g *GitHubStorage) ReadFileAt(ctx context.Context, path string, ref string) (string, error) {
+	u := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s?ref=%s",
+		g.owner, g.repo, path, url.QueryEscape(ref))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := g.checkResponseError(resp); err != nil {
+		return "", err
+	}
+
+	var data contentsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return "", fmt.Errorf("decoding response: %w", err)
+	}
+	if data.Encoding != "base64" {
+		return "", fmt.Errorf("unexpected encoding: %s", data.Encoding)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(data.Content, "\n", ""))
+	if err != nil {
+		return "", fmt.Errorf("decoding base64 content: %w", err)
+	}
+	return string(decoded), nil
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// versionedFiles is a History serving fixed file versions, keyed by SHA.
+type versionedFiles struct {
+	commits  []Commit // newest first
+	versions map[string]string
+}
+
+func (v *versionedFiles) ListCommits(ctx context.Context, path string, limit int) ([]Commit, error) {
+	return v.commits, nil
+}
+
+func (v *versionedFiles) ReadFileAt(ctx context.Context, path string, ref string) (string, error) {
+	content, ok := v.versions[ref]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return content, nil
+}
+
+func TestItemHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 2, d, 0, 0, 0, 0, time.UTC) }
+	h := &versionedFiles{
+		commits: []Commit{
+			{SHA: "d", Message: "Complete todo", Date: day(5)},
+			{SHA: "c", Message: "Add other todo", Date: day(4)},
+			{SHA: "b", Message: "Edit todo", Date: day(3)},
+			{SHA: "a", Message: "Add todo", Date: day(2)},
+			{SHA: "0", Message: "Initial", Date: day(1)},
+		},
+		versions: map[string]string{
+			"0": "# Todos\n\n## Active\n",
+			"a": "# Todos\n\n## Active\n- [ ] Write docs {id:abcd1234}\n",
+			"b": "# Todos\n\n## Active\n- [ ] Write API docs {id:abcd1234}\n",
+			"c": "# Todos\n\n## Active\n- [ ] Write API docs {id:abcd1234}\n- [ ] Other {id:ffff0000}\n",
+			"d": "# Todos\n\n## Active\n- [ ] Other {id:ffff0000}\n\n## Completed\n- [x] Write API docs {id:abcd1234,completed:2026-02-05}\n",
+		},
+	}
+
+	versions, err := ItemHistory(context.Background(), h, TodosFile, "abcd1234", 0)
+	if err != nil {
+		t.Fatalf("ItemHistory() error = %v", err)
+	}
+	var shas []string
+	for _, v := range versions {
+		shas = append(shas, v.Commit.SHA)
+	}
+	if len(versions) != 3 || shas[0] != "a" || shas[1] != "b" || shas[2] != "d" {
+		t.Fatalf("ItemHistory() commits = %v, want [a b d]", shas)
+	}
+	if versions[2].Section != "Completed" || versions[0].Line != "- [ ] Write docs {id:abcd1234}" || versions[0].Existing {
+		t.Errorf("unexpected versions %+v", versions)
+	}
+
+	// A limited walk that starts after the item was added
+	h.commits = h.commits[:2]
+	versions, err = ItemHistory(context.Background(), h, TodosFile, "abcd1234", 2)
+	if err != nil || len(versions) != 2 || !versions[0].Existing {
+		t.Errorf("ItemHistory(limit 2) = %+v, %v; want the first version marked existing", versions, err)
+	}
+}
+
+func TestReadFileAsOf(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 2, d, 0, 0, 0, 0, time.UTC) }
+	h := &versionedFiles{
+		commits:  []Commit{{SHA: "b", Date: day(3)}, {SHA: "a", Date: day(2)}},
+		versions: map[string]string{"a": "first\n", "b": "second\n"},
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		at     time.Time
+		limit  int
+		want   string
+		wantOK bool
+	}{
+		{day(3), 0, "second\n", true},
+		{day(2).Add(12 * time.Hour), 0, "first\n", true},
+		{day(1), 0, "", true},  // before the file existed
+		{day(1), 2, "", false}, // older than the commits walked
+	}
+	for _, tt := range tests {
+		content, ok, err := ReadFileAsOf(ctx, h, TodosFile, tt.at, tt.limit)
+		if err != nil || content != tt.want || ok != tt.wantOK {
+			t.Errorf("ReadFileAsOf(%v, %d) = %q, %v, %v; want %q, %v", tt.at, tt.limit, content, ok, err, tt.want, tt.wantOK)
+		}
+	}
+}
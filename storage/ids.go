@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ID prefixes by item type. IDs are a prefix and 6 hex chars, e.g.
+// "td_3f9a01". Items of one type live in one file (and the trash and
+// reading archives), so the prefix keeps IDs unique across files as long as
+// each is unique within its type. Legacy IDs, 8 hex chars without a prefix,
+// are still accepted; migrate_ids rewrites them.
+const (
+	PrefixTodo      = "td"
+	PrefixSubtask   = "st"
+	PrefixMilestone = "ms"
+	PrefixReminder  = "rm"
+	PrefixReading   = "rd"
+	PrefixNote      = "nt"
+	PrefixJournal   = "jn"
+	PrefixTime      = "tm"
+	PrefixKeyResult = "kr"
+)
+
+// idPattern matches a prefixed or legacy ID.
+var idPattern = regexp.MustCompile(`^([a-z]{2}_[0-9a-f]{6}|[0-9a-f]{8})$`)
+
+// IsID reports whether s looks like an item ID, as opposed to match text.
+func IsID(s string) bool {
+	return idPattern.MatchString(s)
+}
+
+// IDPrefix returns id's type prefix, or "" for a legacy ID.
+func IDPrefix(id string) string {
+	prefix, _, ok := strings.Cut(id, "_")
+	if !ok {
+		return ""
+	}
+	return prefix
+}
+
+// NewID returns a new ID with prefix. taken, if not nil, reports IDs
+// already in use, which are skipped.
+func NewID(prefix string, taken func(id string) bool) string {
+	for {
+		id := prefix + "_" + GenerateID()[:6]
+		if taken == nil || !taken(id) {
+			return id
+		}
+	}
+}
+
+// ConvertID returns the ID for an item converted to the type with prefix,
+// e.g. a todo promoted to a milestone: id with its prefix swapped, or id
+// itself if it's a legacy ID. If that's taken, a new ID is made.
+func ConvertID(id, prefix string, taken func(id string) bool) string {
+	if p := IDPrefix(id); p != "" {
+		id = prefix + strings.TrimPrefix(id, p)
+	}
+	if taken != nil && taken(id) {
+		return NewID(prefix, taken)
+	}
+	return id
+}
@@ -0,0 +1,48 @@
+package storage
+
+import "testing"
+
+func TestNewID(t *testing.T) {
+	id := NewID(PrefixTodo, nil)
+	if !IsID(id) || IDPrefix(id) != PrefixTodo {
+		t.Fatalf("NewID() = %q, want a td_ ID", id)
+	}
+
+	// Taken IDs are skipped
+	var tried []string
+	id = NewID(PrefixReminder, func(id string) bool {
+		tried = append(tried, id)
+		return len(tried) < 3
+	})
+	if len(tried) != 3 || id != tried[2] {
+		t.Errorf("NewID() = %q after %v, want the third candidate", id, tried)
+	}
+}
+
+func TestIsID(t *testing.T) {
+	for s, want := range map[string]bool{
+		"td_3f9a01": true,
+		"a1b2c3d4":  true,
+		"td_3f9a0":  false,
+		"TD_3f9a01": false,
+		"a1b2c3d":   false,
+		"groceries": false,
+	} {
+		if got := IsID(s); got != want {
+			t.Errorf("IsID(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestConvertID(t *testing.T) {
+	if got := ConvertID("td_3f9a01", PrefixMilestone, nil); got != "ms_3f9a01" {
+		t.Errorf("ConvertID(prefixed) = %q, want ms_3f9a01", got)
+	}
+	if got := ConvertID("a1b2c3d4", PrefixMilestone, nil); got != "a1b2c3d4" {
+		t.Errorf("ConvertID(legacy) = %q, want it unchanged", got)
+	}
+	taken := func(id string) bool { return id == "ms_3f9a01" }
+	if got := ConvertID("td_3f9a01", PrefixMilestone, taken); got == "ms_3f9a01" || IDPrefix(got) != PrefixMilestone {
+		t.Errorf("ConvertID(taken) = %q, want a new ms_ ID", got)
+	}
+}
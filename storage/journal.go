@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JournalEntry is a single timestamped journal entry.
+type JournalEntry struct {
+	ID   string
+	Time time.Time // UTC, minute precision
+	Text string
+}
+
+// Journal represents the parsed contents of journal.md.
+// Entries are kept in chronological order.
+type Journal struct {
+	Entries []JournalEntry
+	Raw     string
+}
+
+// Matches journal entry line: - 09:15 Entry text {metadata}
+var journalLinePattern = regexp.MustCompile(`^-\s*(\d{1,2}:\d{2})\s+(.+)$`)
+
+// ParseJournal parses a journal.md file content. Entries are grouped under
+// "## YYYY-MM-DD" headings.
+func ParseJournal(content string) (*Journal, error) {
+	j := &Journal{Raw: content}
+	lines := strings.Split(content, "\n")
+
+	var currentDate time.Time
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "## ") {
+			if t, err := time.Parse(dateFormat, strings.TrimSpace(strings.TrimPrefix(trimmed, "## "))); err == nil {
+				currentDate = t
+			}
+			continue
+		}
+
+		if currentDate.IsZero() {
+			continue
+		}
+
+		if matches := journalLinePattern.FindStringSubmatch(trimmed); matches != nil {
+			entry := JournalEntry{Time: currentDate}
+			if clock, err := time.Parse("15:04", matches[1]); err == nil {
+				entry.Time = currentDate.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute)
+			}
+
+			text := matches[2]
+			if meta := metadataPattern.FindStringSubmatch(text); meta != nil {
+				text = strings.TrimSpace(metadataPattern.ReplaceAllString(text, ""))
+				var added time.Time
+				var completed *time.Time
+				parseMetadata(meta[1], &entry.ID, &added, &completed)
+			}
+			if entry.ID == "" {
+				entry.ID = NewID(PrefixJournal, nil)
+			}
+			entry.Text = text
+			j.Entries = append(j.Entries, entry)
+		}
+	}
+
+	sort.SliceStable(j.Entries, func(a, b int) bool {
+		return j.Entries[a].Time.Before(j.Entries[b].Time)
+	})
+
+	return j, nil
+}
+
+// SerializeJournal converts a Journal back to markdown.
+func SerializeJournal(j *Journal) string {
+	var b strings.Builder
+
+	b.WriteString("# Journal\n")
+
+	var currentDay string
+	for _, e := range j.Entries {
+		day := e.Time.Format(dateFormat)
+		if day != currentDay {
+			b.WriteString("\n## " + day + "\n")
+			currentDay = day
+		}
+		line := "- " + e.Time.Format("15:04") + " " + e.Text
+		if meta := formatMetadata(e.ID, "", time.Time{}, nil, false); meta != "" {
+			line += " " + meta
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return b.String()
+}
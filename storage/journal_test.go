@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseJournal(t *testing.T) {
+	input := `# Journal
+
+## 2026-02-03
+- 18:02 Shipped the release {id:bbbb2222}
+- 09:15 Morning planning {id:aaaa1111}
+
+## 2026-02-04
+- 08:30 Felt focused today
+`
+
+	j, err := ParseJournal(input)
+	if err != nil {
+		t.Fatalf("ParseJournal failed: %v", err)
+	}
+	if len(j.Entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(j.Entries))
+	}
+
+	// Entries are sorted chronologically
+	first := j.Entries[0]
+	if first.ID != "aaaa1111" || first.Text != "Morning planning" {
+		t.Errorf("unexpected first entry: %+v", first)
+	}
+	if want := time.Date(2026, 2, 3, 9, 15, 0, 0, time.UTC); !first.Time.Equal(want) {
+		t.Errorf("expected time %v, got %v", want, first.Time)
+	}
+	if j.Entries[2].ID == "" {
+		t.Error("expected ID to be generated for entry without metadata")
+	}
+}
+
+func TestSerializeJournal_RoundTrip(t *testing.T) {
+	j := &Journal{Entries: []JournalEntry{
+		{ID: "aaaa1111", Time: time.Date(2026, 2, 3, 9, 15, 0, 0, time.UTC), Text: "Morning planning"},
+		{ID: "bbbb2222", Time: time.Date(2026, 2, 4, 8, 30, 0, 0, time.UTC), Text: "Felt focused"},
+	}}
+
+	out := SerializeJournal(j)
+	if !strings.Contains(out, "## 2026-02-03\n- 09:15 Morning planning {id:aaaa1111}") {
+		t.Errorf("unexpected serialization:\n%s", out)
+	}
+
+	parsed, err := ParseJournal(out)
+	if err != nil {
+		t.Fatalf("ParseJournal failed: %v", err)
+	}
+	if len(parsed.Entries) != 2 || parsed.Entries[1].Text != "Felt focused" {
+		t.Errorf("round trip mismatch: %+v", parsed.Entries)
+	}
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// KeyResult is a measurable result of a milestone, such as 40 of 100 beta
+// users, written under it as "  - KR: Beta users: 40/100 users {id:...}".
+type KeyResult struct {
+	ID      string
+	Text    string
+	Current float64
+	Target  float64
+	Unit    string // e.g. "users"; empty if none
+}
+
+// Progress is how far the key result is towards its target, as a
+// percentage from 0 to 100.
+func (k KeyResult) Progress() int {
+	if k.Target <= 0 {
+		return 0
+	}
+	return int(math.Round(math.Max(0, math.Min(1, k.Current/k.Target)) * 100))
+}
+
+// KeyResultProgress rolls up the milestone's key results: the average of
+// their progress percentages. ok is false if it has none.
+func (m Milestone) KeyResultProgress() (percent int, ok bool) {
+	if len(m.KeyResults) == 0 {
+		return 0, false
+	}
+	total := 0
+	for _, k := range m.KeyResults {
+		total += k.Progress()
+	}
+	return int(math.Round(float64(total) / float64(len(m.KeyResults)))), true
+}
+
+// keyResultPattern matches a key result line: - KR: Beta users: 40/100 users
+var keyResultPattern = regexp.MustCompile(`^-\s*KR:\s*(.+):\s*(-?\d+(?:\.\d+)?)\s*/\s*(\d+(?:\.\d+)?)\s*(.*)$`)
+
+// parseKeyResultLine parses an indented key result line.
+func parseKeyResultLine(line string) (KeyResult, bool) {
+	if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+		return KeyResult{}, false
+	}
+	rest := strings.TrimSpace(line)
+	var k KeyResult
+	if matches := metadataPattern.FindStringSubmatch(rest); matches != nil {
+		k.ID = metadataValue(matches[1], "id")
+		rest = strings.TrimSpace(metadataPattern.ReplaceAllString(rest, ""))
+	}
+	matches := keyResultPattern.FindStringSubmatch(rest)
+	if matches == nil {
+		return KeyResult{}, false
+	}
+	k.Text = strings.TrimSpace(matches[1])
+	k.Current, _ = strconv.ParseFloat(matches[2], 64)
+	k.Target, _ = strconv.ParseFloat(matches[3], 64)
+	k.Unit = strings.TrimSpace(matches[4])
+	if k.ID == "" {
+		k.ID = NewID(PrefixKeyResult, nil)
+	}
+	return k, true
+}
+
+// formatKeyResultLine formats a key result as an indented line.
+func formatKeyResultLine(k KeyResult) string {
+	line := "  - KR: " + k.Text + ": " + FormatAmount(k.Current) + "/" + FormatAmount(k.Target)
+	if k.Unit != "" {
+		line += " " + k.Unit
+	}
+	return line + " {id:" + k.ID + "}\n"
+}
+
+// FormatAmount formats a key result value without trailing zeros, e.g. 40
+// or 2.5.
+func FormatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
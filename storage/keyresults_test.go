@@ -0,0 +1,31 @@
+package storage
+
+import "testing"
+
+func TestKeyResults_RoundTrip(t *testing.T) {
+	content := "# Discoverability Strategy Progress\n\n## Current Phase\nLaunch\n\n" +
+		"## Active Milestones\n- [ ] Public beta — Due: 2026-03-01 {id:ms_aaaa11}\n" +
+		"  - KR: Beta users: 40/100 users {id:kr_bbbb22}\n  - KR: Crash-free sessions: 99.5/99.9 {id:kr_cccc33}\n" +
+		"  > 2026-02-10 09:30 Invites going out\n\n" +
+		"## Completed Milestones\n\n## Notes\n"
+	s, err := ParseStrategy(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := s.ActiveMilestones[0]
+	if len(m.KeyResults) != 2 || len(m.Comments) != 1 {
+		t.Fatalf("unexpected milestone %+v", m)
+	}
+	if k := m.KeyResults[0]; k.ID != "kr_bbbb22" || k.Text != "Beta users" || k.Current != 40 || k.Target != 100 || k.Unit != "users" || k.Progress() != 40 {
+		t.Errorf("unexpected key result %+v", k)
+	}
+	if k := m.KeyResults[1]; k.Current != 99.5 || k.Unit != "" || k.Progress() != 100 {
+		t.Errorf("unexpected key result %+v (progress %d)", k, k.Progress())
+	}
+	if p, ok := m.KeyResultProgress(); !ok || p != 70 {
+		t.Errorf("KeyResultProgress() = %d, %v, want 70", p, ok)
+	}
+	if got := SerializeStrategy(s); got != content {
+		t.Errorf("round trip changed the file:\n%s", got)
+	}
+}
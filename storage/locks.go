@@ -0,0 +1,243 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// PathLocks serializes read-modify-write cycles on each file within this
+// process. Writes are checked against the SHA read, so two tool calls that
+// read todos.md together would otherwise both try to write and one would
+// fail with ErrConflict. WithPathLocks holds a file's lock from the first
+// read in a lock scope (see WithLockScope) until the scope is released.
+// Conflicts with writers outside the process are still reported.
+type PathLocks struct {
+	mu    sync.Mutex
+	locks map[string]*pathLock
+}
+
+// pathLock is one file's lock: a token in a channel, so waiting can give up
+// when the context is done.
+type pathLock struct {
+	token chan struct{}
+	users int // holders and waiters, so idle locks can be dropped
+}
+
+// NewPathLocks creates an empty set of path locks.
+func NewPathLocks() *PathLocks {
+	return &PathLocks{locks: make(map[string]*pathLock)}
+}
+
+func (l *PathLocks) get(path string) *pathLock {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pl := l.locks[path]
+	if pl == nil {
+		pl = &pathLock{token: make(chan struct{}, 1)}
+		l.locks[path] = pl
+	}
+	pl.users++
+	return pl
+}
+
+func (l *PathLocks) put(path string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if pl := l.locks[path]; pl != nil {
+		if pl.users--; pl.users == 0 {
+			delete(l.locks, path)
+		}
+	}
+}
+
+// lock waits for path's lock until ctx is done.
+func (l *PathLocks) lock(ctx context.Context, path string) error {
+	pl := l.get(path)
+	select {
+	case pl.token <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		l.put(path)
+		return ctx.Err()
+	}
+}
+
+// tryLock takes path's lock if it is free.
+func (l *PathLocks) tryLock(path string) bool {
+	pl := l.get(path)
+	select {
+	case pl.token <- struct{}{}:
+		return true
+	default:
+		l.put(path)
+		return false
+	}
+}
+
+func (l *PathLocks) unlock(path string) {
+	l.mu.Lock()
+	pl := l.locks[path]
+	l.mu.Unlock()
+	if pl != nil {
+		<-pl.token
+		l.put(path)
+	}
+}
+
+// lockScope is the set of locks held for one read-modify-write cycle.
+type lockScope struct {
+	mu       sync.Mutex
+	locks    *PathLocks
+	held     []string
+	released bool
+}
+
+type lockScopeKey struct{}
+
+// WithLockScope starts a lock scope: files read or written through
+// WithPathLocks storage with the returned context stay locked until release
+// is called. Tool calls each run in one.
+func WithLockScope(ctx context.Context) (context.Context, func()) {
+	scope := &lockScope{}
+	release := func() {
+		scope.mu.Lock()
+		defer scope.mu.Unlock()
+		for i := len(scope.held) - 1; i >= 0; i-- {
+			scope.locks.unlock(scope.held[i])
+		}
+		scope.held = nil
+		scope.released = true
+	}
+	return context.WithValue(ctx, lockScopeKey{}, scope), release
+}
+
+// acquire adds path's lock to the scope. Waiting for a lock is only safe in
+// path order - a call holding todos.md and waiting for strategy.md would
+// deadlock with one doing the opposite - so out of order the lock is taken
+// only if it's free, and otherwise the cycle goes on without it, relying on
+// the SHA check as before.
+func (s *lockScope) acquire(ctx context.Context, locks *PathLocks, path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Storage used after the call returned, or through another set of
+	// locks, isn't held
+	if s.released || (s.locks != nil && s.locks != locks) {
+		return nil
+	}
+	inOrder := true
+	for _, held := range s.held {
+		if held == path {
+			return nil
+		}
+		if held > path {
+			inOrder = false
+		}
+	}
+
+	if inOrder {
+		if err := locks.lock(ctx, path); err != nil {
+			return err
+		}
+	} else if !locks.tryLock(path) {
+		return nil
+	}
+	s.locks = locks
+	s.held = append(s.held, path)
+	return nil
+}
+
+// lockingStorage takes path locks around reads and writes.
+type lockingStorage struct {
+	next  Storage
+	locks *PathLocks
+}
+
+// WithPathLocks returns a Storage that locks each file read or written in a
+// lock scope until the scope ends, and each file written outside one for
+// the write. History passes through.
+func WithPathLocks(s Storage, locks *PathLocks) Storage {
+	return &lockingStorage{next: s, locks: locks}
+}
+
+func (l *lockingStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	if scope, ok := ctx.Value(lockScopeKey{}).(*lockScope); ok {
+		if err := scope.acquire(ctx, l.locks, path); err != nil {
+			return "", "", err
+		}
+	}
+	return l.next.ReadFile(ctx, path)
+}
+
+func (l *lockingStorage) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	release, err := l.lockAll(ctx, []string{path})
+	if err != nil {
+		return err
+	}
+	defer release()
+	return l.next.WriteFile(ctx, path, content, sha, message)
+}
+
+func (l *lockingStorage) WriteFiles(ctx context.Context, changes []FileChange, message string) error {
+	paths := make([]string, len(changes))
+	for i, c := range changes {
+		paths[i] = c.Path
+	}
+	release, err := l.lockAll(ctx, paths)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return WriteFiles(ctx, l.next, changes, message)
+}
+
+// lockAll locks paths for a write, in path order. In a lock scope they stay
+// locked until the scope ends.
+func (l *lockingStorage) lockAll(ctx context.Context, paths []string) (func(), error) {
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+
+	if scope, ok := ctx.Value(lockScopeKey{}).(*lockScope); ok {
+		for _, p := range sorted {
+			if err := scope.acquire(ctx, l.locks, p); err != nil {
+				return nil, err
+			}
+		}
+		return func() {}, nil
+	}
+
+	var locked []string
+	release := func() {
+		for i := len(locked) - 1; i >= 0; i-- {
+			l.locks.unlock(locked[i])
+		}
+	}
+	for i, p := range sorted {
+		if i > 0 && p == sorted[i-1] {
+			continue
+		}
+		if err := l.locks.lock(ctx, p); err != nil {
+			release()
+			return nil, err
+		}
+		locked = append(locked, p)
+	}
+	return release, nil
+}
+
+func (l *lockingStorage) ListCommits(ctx context.Context, path string, limit int) ([]Commit, error) {
+	h, ok := l.next.(History)
+	if !ok {
+		return nil, errNoHistory
+	}
+	return h.ListCommits(ctx, path, limit)
+}
+
+func (l *lockingStorage) ReadFileAt(ctx context.Context, path string, ref string) (string, error) {
+	h, ok := l.next.(History)
+	if !ok {
+		return "", errNoHistory
+	}
+	return h.ReadFileAt(ctx, path, ref)
+}
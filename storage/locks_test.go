@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// appendLine is a read-modify-write cycle in its own lock scope, as a tool
+// call is, pausing between the read and the write.
+func appendLine(s Storage, path, line string) error {
+	ctx, release := WithLockScope(context.Background())
+	defer release()
+	content, sha, err := s.ReadFile(ctx, path)
+	if err != nil {
+		return err
+	}
+	time.Sleep(time.Millisecond)
+	return s.WriteFile(ctx, path, content+line+"\n", sha, "Append "+line)
+}
+
+func TestPathLocks_SerializeCycles(t *testing.T) {
+	mem := NewMemoryStorage(map[string]string{TodosFile: ""}, nil)
+	s := WithPathLocks(mem, NewPathLocks())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- appendLine(s, TodosFile, strings.Repeat("x", i+1))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("cycle failed: %v", err)
+		}
+	}
+	content, _, _ := mem.ReadFile(context.Background(), TodosFile)
+	if n := strings.Count(content, "\n"); n != 20 {
+		t.Errorf("%d lines written, want 20", n)
+	}
+}
+
+func TestPathLocks_OutOfOrderDoesNotDeadlock(t *testing.T) {
+	mem := NewMemoryStorage(map[string]string{TodosFile: "", StrategyFile: ""}, nil)
+	s := WithPathLocks(mem, NewPathLocks())
+
+	// One call reads todos then strategy, the other the reverse
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, order := range [][]string{{TodosFile, StrategyFile}, {StrategyFile, TodosFile}} {
+		wg.Add(1)
+		go func(order []string) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				ctx, release := WithLockScope(context.Background())
+				for _, p := range order {
+					s.ReadFile(ctx, p)
+				}
+				release()
+			}
+		}(order)
+	}
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("deadlocked")
+	}
+}
+
+func TestPathLocks_WaitGivesUpWithContext(t *testing.T) {
+	s := WithPathLocks(NewMemoryStorage(map[string]string{TodosFile: ""}, nil), NewPathLocks())
+
+	held, release := WithLockScope(context.Background())
+	defer release()
+	if _, _, err := s.ReadFile(held, TodosFile); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.WriteFile(ctx, TodosFile, "x", "", "Write"); err != context.DeadlineExceeded {
+		t.Errorf("WriteFile while locked = %v, want DeadlineExceeded", err)
+	}
+
+	// Released locks are free again, and dropped when idle
+	release()
+	if _, sha, err := s.ReadFile(context.Background(), TodosFile); err != nil {
+		t.Fatal(err)
+	} else if err := s.WriteFile(context.Background(), TodosFile, "x", sha, "Write"); err != nil {
+		t.Errorf("WriteFile after release = %v", err)
+	}
+	if n := len(s.(*lockingStorage).locks.locks); n != 0 {
+		t.Errorf("%d idle locks kept", n)
+	}
+}
@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+// MemoryStorage is a Storage held entirely in memory, for tests and
+// DEV_MODE. Like a repo, every write is a commit: it supports multi-file
+// writes and History, and SHAs change whenever content does. Nothing is
+// persisted.
+type MemoryStorage struct {
+	mu      sync.Mutex
+	files   map[string]string
+	commits []memoryCommit // oldest first
+	clock   clock.Clock
+}
+
+// memoryCommit records the files one write changed.
+type memoryCommit struct {
+	Commit
+	files map[string]string
+}
+
+// NewMemoryStorage creates a MemoryStorage holding the seed files (path to
+// content), committed as one initial commit. seed may be nil. Commits are
+// dated with c; a nil clock uses the system clock.
+func NewMemoryStorage(seed map[string]string, c clock.Clock) *MemoryStorage {
+	m := &MemoryStorage{files: make(map[string]string), clock: clock.Or(c)}
+	if len(seed) > 0 {
+		changes := make(map[string]string, len(seed))
+		for path, content := range seed {
+			changes[path] = content
+		}
+		m.commit(changes, "Seed data")
+	}
+	return m
+}
+
+// ReadFile returns the current content of path and its blob SHA.
+func (m *MemoryStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	content, ok := m.files[path]
+	if !ok {
+		return "", "", ErrNotFound
+	}
+	return content, blobSHA(content), nil
+}
+
+// WriteFile writes path if sha matches its current SHA (or, for an empty
+// sha, if the file doesn't exist yet), and returns ErrConflict otherwise.
+func (m *MemoryStorage) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	return m.WriteFiles(ctx, []FileChange{{Path: path, Content: content, SHA: sha}}, message)
+}
+
+// WriteFiles writes every change as one commit, or none of them if any
+// SHA doesn't match.
+func (m *MemoryStorage) WriteFiles(ctx context.Context, changes []FileChange, message string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range changes {
+		if !m.matches(c.Path, c.SHA) {
+			return ErrConflict
+		}
+	}
+	files := make(map[string]string, len(changes))
+	for _, c := range changes {
+		files[c.Path] = c.Content
+	}
+	m.commit(files, message)
+	return nil
+}
+
+// matches reports whether sha is the expected SHA for writing path.
+func (m *MemoryStorage) matches(path, sha string) bool {
+	content, ok := m.files[path]
+	if sha == "" {
+		return !ok
+	}
+	return ok && blobSHA(content) == sha
+}
+
+// commit applies files and records them. The caller holds mu, except
+// during construction.
+func (m *MemoryStorage) commit(files map[string]string, message string) {
+	for path, content := range files {
+		m.files[path] = content
+	}
+
+	// Hash the parent, message and changes, like a git commit, so SHAs are
+	// unique and stable for the same sequence of writes
+	h := sha1.New()
+	if n := len(m.commits); n > 0 {
+		h.Write([]byte(m.commits[n-1].SHA))
+	}
+	fmt.Fprintf(h, "\x00%s", message)
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(h, "\x00%s\x00%s", path, files[path])
+	}
+
+	m.commits = append(m.commits, memoryCommit{
+		Commit: Commit{SHA: hex.EncodeToString(h.Sum(nil)), Message: message, Date: m.clock.Now().UTC()},
+		files:  files,
+	})
+}
+
+// ListCommits returns up to limit commits touching path, newest first. A
+// limit of zero or less returns them all.
+func (m *MemoryStorage) ListCommits(ctx context.Context, path string, limit int) ([]Commit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	commits := []Commit{}
+	for i := len(m.commits) - 1; i >= 0; i-- {
+		if limit > 0 && len(commits) == limit {
+			break
+		}
+		if _, ok := m.commits[i].files[path]; ok {
+			commits = append(commits, m.commits[i].Commit)
+		}
+	}
+	return commits, nil
+}
+
+// ReadFileAt returns the content of path as of the commit ref.
+func (m *MemoryStorage) ReadFileAt(ctx context.Context, path string, ref string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.commits) - 1; i >= 0; i-- {
+		if m.commits[i].SHA != ref {
+			continue
+		}
+		for ; i >= 0; i-- {
+			if content, ok := m.commits[i].files[path]; ok {
+				return content, nil
+			}
+		}
+		return "", ErrNotFound
+	}
+	return "", fmt.Errorf("unknown commit %q: %w", ref, ErrNotFound)
+}
+
+// blobSHA is the git blob SHA of content, as GitHub reports for a file.
+func blobSHA(content string) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00%s", len(content), content)
+	return hex.EncodeToString(h.Sum(nil))
+}
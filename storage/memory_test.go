@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryStorage(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryStorage(map[string]string{TodosFile: "v1"}, nil)
+
+	content, sha, err := m.ReadFile(ctx, TodosFile)
+	if err != nil || content != "v1" {
+		t.Fatalf("ReadFile() = %q, %v", content, err)
+	}
+	// The git blob SHA of "v1"
+	if sha != "28c218c44b49222f91536daf5b4d9871638edc8e" {
+		t.Errorf("sha = %q", sha)
+	}
+	if _, _, err := m.ReadFile(ctx, NotesFile); err != ErrNotFound {
+		t.Errorf("ReadFile(missing) error = %v, want ErrNotFound", err)
+	}
+
+	// Writes need the current SHA, or none for a new file
+	if err := m.WriteFile(ctx, TodosFile, "v2", "stale", "Edit"); err != ErrConflict {
+		t.Errorf("WriteFile(stale sha) error = %v, want ErrConflict", err)
+	}
+	if err := m.WriteFile(ctx, TodosFile, "v2", "", "Create"); err != ErrConflict {
+		t.Errorf("WriteFile(existing, no sha) error = %v, want ErrConflict", err)
+	}
+	if err := m.WriteFile(ctx, TodosFile, "v2", sha, "Edit todos"); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	_, sha2, _ := m.ReadFile(ctx, TodosFile)
+	if sha2 == sha {
+		t.Error("expected the SHA to change with the content")
+	}
+
+	// A batch with one bad SHA writes nothing
+	err = WriteFiles(ctx, m, []FileChange{{Path: TodosFile, Content: "v3", SHA: sha2}, {Path: NotesFile, Content: "n", SHA: "x"}}, "Batch")
+	if err != ErrConflict {
+		t.Errorf("WriteFiles() error = %v, want ErrConflict", err)
+	}
+	if err := WriteFiles(ctx, m, []FileChange{{Path: TodosFile, Content: "v3", SHA: sha2}, {Path: NotesFile, Content: "n1"}}, "Batch"); err != nil {
+		t.Fatalf("WriteFiles() error = %v", err)
+	}
+	if content, _, _ := m.ReadFile(ctx, NotesFile); content != "n1" {
+		t.Errorf("notes = %q after batch", content)
+	}
+
+	// History walks the commits touching a file
+	commits, err := m.ListCommits(ctx, TodosFile, 0)
+	if err != nil || len(commits) != 3 {
+		t.Fatalf("ListCommits() = %v, %v", commits, err)
+	}
+	if commits[0].Message != "Batch" || commits[2].Message != "Seed data" {
+		t.Errorf("commits not newest first: %+v", commits)
+	}
+	if limited, _ := m.ListCommits(ctx, TodosFile, 1); len(limited) != 1 {
+		t.Errorf("ListCommits(limit 1) returned %d", len(limited))
+	}
+	for i, want := range []string{"v3", "v2", "v1"} {
+		if got, err := m.ReadFileAt(ctx, TodosFile, commits[i].SHA); err != nil || got != want {
+			t.Errorf("ReadFileAt(%d) = %q, %v, want %q", i, got, err, want)
+		}
+	}
+	if _, err := m.ReadFileAt(ctx, NotesFile, commits[2].SHA); err != ErrNotFound {
+		t.Errorf("ReadFileAt(before creation) error = %v, want ErrNotFound", err)
+	}
+
+	var _ History = m
+	var _ BatchWriter = m
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Note is an entry in notes.md.
+type Note struct {
+	ID       string
+	Text     string
+	Category string // heading the note is filed under; empty if uncategorized
+	Added    time.Time
+}
+
+// NoteFile represents the parsed contents of notes.md.
+type NoteFile struct {
+	Notes []Note
+	// Categories lists the category headings in file order, including
+	// empty ones, so serializing keeps the user's layout.
+	Categories []string
+	Raw        string
+}
+
+// Matches note line: - Note text {metadata}
+var noteLinePattern = regexp.MustCompile(`^[-*]\s+(.+)$`)
+
+// ParseNotes parses a notes.md file content. Notes are list items; a "## "
+// heading starts a category, and notes before the first heading are
+// uncategorized.
+func ParseNotes(content string) (*NoteFile, error) {
+	nf := &NoteFile{Raw: content}
+	category := ""
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "## ") {
+			category = strings.TrimSpace(strings.TrimPrefix(trimmed, "## "))
+			// A repeated heading continues the same category
+			if !containsString(nf.Categories, category) {
+				nf.Categories = append(nf.Categories, category)
+			}
+			continue
+		}
+
+		if matches := noteLinePattern.FindStringSubmatch(trimmed); matches != nil {
+			note := Note{Category: category}
+			text := matches[1]
+			if meta := metadataPattern.FindStringSubmatch(text); meta != nil {
+				text = strings.TrimSpace(metadataPattern.ReplaceAllString(text, ""))
+				var completed *time.Time
+				parseMetadata(meta[1], &note.ID, &note.Added, &completed)
+			}
+			if note.ID == "" {
+				note.ID = NewID(PrefixNote, nil)
+			}
+			note.Text = text
+			nf.Notes = append(nf.Notes, note)
+		}
+	}
+
+	return nf, nil
+}
+
+// SerializeNotes converts a NoteFile back to markdown. Categories are written
+// in the order of nf.Categories, then any others in the order their notes
+// appear.
+func SerializeNotes(nf *NoteFile) string {
+	var b strings.Builder
+	b.WriteString("# Notes\n")
+
+	writeNotes := func(category string) {
+		for _, n := range nf.Notes {
+			if n.Category != category {
+				continue
+			}
+			line := "- " + n.Text
+			if meta := formatMetadata(n.ID, "", n.Added, nil, false); meta != "" {
+				line += " " + meta
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	for _, n := range nf.Notes {
+		if n.Category == "" {
+			b.WriteString("\n")
+			writeNotes("")
+			break
+		}
+	}
+
+	categories := append([]string(nil), nf.Categories...)
+	for _, n := range nf.Notes {
+		if n.Category != "" && !containsString(categories, n.Category) {
+			categories = append(categories, n.Category)
+		}
+	}
+	for _, c := range categories {
+		b.WriteString("\n## " + c + "\n")
+		writeNotes(c)
+	}
+
+	return b.String()
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNotes_RoundTrip(t *testing.T) {
+	input := "# Notes\n\n- Loose thought {id:aaaa1111,added:2026-02-01}\n\n## Ideas\n- Dark mode {id:bbbb2222}\n\n## Meetings\n"
+	nf, err := ParseNotes(input)
+	if err != nil {
+		t.Fatalf("ParseNotes failed: %v", err)
+	}
+	if len(nf.Notes) != 2 || nf.Notes[0].Category != "" || nf.Notes[1].Category != "Ideas" || nf.Notes[1].Text != "Dark mode" {
+		t.Errorf("unexpected notes %+v", nf.Notes)
+	}
+	if got := fmt.Sprint(nf.Categories); got != "[Ideas Meetings]" {
+		t.Errorf("categories = %s", got)
+	}
+	if out := SerializeNotes(nf); out != input {
+		t.Errorf("round trip mismatch:\n%q\n%q", out, input)
+	}
+
+	// New categories go after existing ones
+	nf.Notes = append(nf.Notes, Note{ID: "cccc3333", Text: "Call Sam", Category: "Follow-ups"})
+	if out := SerializeNotes(nf); !strings.HasSuffix(out, "## Meetings\n\n## Follow-ups\n- Call Sam {id:cccc3333}\n") {
+		t.Errorf("unexpected serialization:\n%s", out)
+	}
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Notification digest frequencies.
+const (
+	DigestDaily    = "daily"
+	DigestWeekdays = "weekdays"
+	DigestWeekly   = "weekly" // Mondays
+	DigestOff      = "off"
+)
+
+// NotificationPreferences say where and when notifications are sent. Zero
+// fields are unset: every configured channel, no quiet hours and a daily
+// digest.
+type NotificationPreferences struct {
+	Channels   []string // "webhook", "email"
+	QuietHours QuietHours
+	Digest     string
+}
+
+// DigestDue reports whether the reminder digest goes out on day.
+func (p NotificationPreferences) DigestDue(day time.Time) bool {
+	switch p.Digest {
+	case DigestOff:
+		return false
+	case DigestWeekdays:
+		return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+	case DigestWeekly:
+		return day.Weekday() == time.Monday
+	}
+	return true
+}
+
+// QuietHours is a daily window without notifications, in minutes after
+// midnight; it may wrap past midnight (22:00-07:00). Start == End is none.
+type QuietHours struct {
+	Start, End int
+}
+
+// quietHoursPattern matches quiet hours: 22:00-07:00
+var quietHoursPattern = regexp.MustCompile(`^(\d{1,2}):(\d{2})\s*-\s*(\d{1,2}):(\d{2})$`)
+
+// ParseQuietHours parses quiet hours such as "22:00-07:00".
+func ParseQuietHours(s string) (QuietHours, error) {
+	matches := quietHoursPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if matches == nil {
+		return QuietHours{}, fmt.Errorf("quiet hours %q must be HH:MM-HH:MM, e.g. 22:00-07:00", s)
+	}
+	var minutes [4]int
+	for i := range minutes {
+		minutes[i], _ = strconv.Atoi(matches[i+1])
+	}
+	if minutes[0] > 23 || minutes[2] > 23 || minutes[1] > 59 || minutes[3] > 59 {
+		return QuietHours{}, fmt.Errorf("quiet hours %q must use times from 00:00 to 23:59", s)
+	}
+	return QuietHours{Start: minutes[0]*60 + minutes[1], End: minutes[2]*60 + minutes[3]}, nil
+}
+
+// IsZero reports whether no quiet hours are set.
+func (q QuietHours) IsZero() bool {
+	return q.Start == q.End
+}
+
+// Contains reports whether t's time of day is within the quiet hours.
+func (q QuietHours) Contains(t time.Time) bool {
+	m := t.Hour()*60 + t.Minute()
+	if q.Start < q.End {
+		return m >= q.Start && m < q.End
+	}
+	return q.Start != q.End && (m >= q.Start || m < q.End)
+}
+
+// String formats the quiet hours as HH:MM-HH:MM, or "" if none are set.
+func (q QuietHours) String() string {
+	if q.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", q.Start/60, q.Start%60, q.End/60, q.End%60)
+}
@@ -0,0 +1,287 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Object storage providers.
+const (
+	ProviderS3  = "s3"
+	ProviderGCS = "gcs"
+)
+
+// ObjectConfig configures ObjectStorage.
+type ObjectConfig struct {
+	// Provider is ProviderS3 (AWS S3 or a compatible store such as MinIO or
+	// R2) or ProviderGCS (Google Cloud Storage's XML API).
+	Provider string
+	Bucket   string
+	// Prefix is prepended to every object key, e.g. "momentum/".
+	Prefix string
+	// Endpoint is the service URL. Empty uses AWS S3 in Region, or
+	// storage.googleapis.com.
+	Endpoint string
+	// Region is the S3 region (default us-east-1); GCS ignores it.
+	Region string
+	// AccessKeyID and SecretAccessKey are the S3 access key, or a GCS HMAC
+	// key.
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// ObjectStorage implements Storage on an S3 or GCS bucket, one object per
+// file. The SHA returned by ReadFile is the object's version - the ETag on
+// S3, the generation on GCS - and writes are conditional on it, so
+// concurrent changes fail with ErrConflict as they do on GitHub. There is
+// no commit history: commit messages are dropped, and ObjectStorage doesn't
+// implement History.
+type ObjectStorage struct {
+	config     ObjectConfig
+	endpoint   *url.URL
+	signer     requestSigner
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewObjectStorage creates an ObjectStorage from config.
+func NewObjectStorage(config ObjectConfig) (*ObjectStorage, error) {
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("object storage needs a bucket")
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("object storage needs an access key ID and secret")
+	}
+
+	var signer requestSigner
+	switch config.Provider {
+	case ProviderS3:
+		if config.Region == "" {
+			config.Region = "us-east-1"
+		}
+		if config.Endpoint == "" {
+			config.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", config.Region)
+		}
+		signer = awsSigner(config.AccessKeyID, config.SecretAccessKey, config.Region)
+	case ProviderGCS:
+		if config.Endpoint == "" {
+			config.Endpoint = "https://storage.googleapis.com"
+		}
+		signer = googSigner(config.AccessKeyID, config.SecretAccessKey)
+	default:
+		return nil, fmt.Errorf("unknown object storage provider %q: use %s or %s", config.Provider, ProviderS3, ProviderGCS)
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(config.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid object storage endpoint %q", config.Endpoint)
+	}
+	config.Prefix = strings.Trim(config.Prefix, "/")
+	if config.Prefix != "" {
+		config.Prefix += "/"
+	}
+
+	return &ObjectStorage{
+		config:     config,
+		endpoint:   endpoint,
+		signer:     signer,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		now:        time.Now,
+	}, nil
+}
+
+// objectURL is the path-style URL of path's object.
+func (o *ObjectStorage) objectURL(path string) string {
+	u := *o.endpoint
+	u.Path = u.Path + "/" + o.config.Bucket + "/" + o.config.Prefix + path
+	return u.String()
+}
+
+// ReadFile fetches path's object. The SHA is its version.
+func (o *ObjectStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.objectURL(path), nil)
+	if err != nil {
+		return "", "", fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := o.do(req, nil)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if err := objectResponseError(resp); err != nil {
+		return "", "", err
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("reading object: %w", err)
+	}
+	return string(body), o.version(resp), nil
+}
+
+// version is the object version in resp that writes are conditional on.
+func (o *ObjectStorage) version(resp *http.Response) string {
+	if o.config.Provider == ProviderGCS {
+		return resp.Header.Get("x-goog-generation")
+	}
+	return resp.Header.Get("ETag")
+}
+
+// WriteFile writes path's object if it is still at version sha, or if sha
+// is empty, only if it doesn't exist yet. The message is dropped.
+func (o *ObjectStorage) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	body := []byte(content)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, o.objectURL(path), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/markdown; charset=utf-8")
+
+	// Preconditions: GCS generation 0 means "doesn't exist"; S3's
+	// If-None-Match: * the same
+	if o.config.Provider == ProviderGCS {
+		generation := sha
+		if generation == "" {
+			generation = "0"
+		}
+		req.Header.Set("x-goog-if-generation-match", generation)
+	} else if sha == "" {
+		req.Header.Set("If-None-Match", "*")
+	} else {
+		req.Header.Set("If-Match", sha)
+	}
+
+	resp, err := o.do(req, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return objectResponseError(resp)
+}
+
+// do signs and sends req, whose body is body.
+func (o *ObjectStorage) do(req *http.Request, body []byte) (*http.Response, error) {
+	o.signer.sign(req, body, o.now())
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	return resp, nil
+}
+
+// objectResponseError converts object storage error responses to storage
+// errors.
+func objectResponseError(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		// 409 is S3's answer when a conditional write races another
+		return ErrConflict
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode == http.StatusServiceUnavailable && bytes.Contains(body, []byte("SlowDown")) {
+		return ErrRateLimited
+	}
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("%w (status %d): %s", ErrUnavailable, resp.StatusCode, body)
+	}
+	return fmt.Errorf("object storage error (status %d): %s", resp.StatusCode, body)
+}
+
+// requestSigner signs requests with Signature Version 4, which S3 and GCS
+// HMAC keys share apart from names.
+type requestSigner struct {
+	accessKeyID string
+	secret      string
+	region      string
+	service     string
+	// Names that differ between AWS4 and GOOG4
+	algorithm    string // e.g. AWS4-HMAC-SHA256
+	keyPrefix    string // e.g. AWS4
+	terminator   string // e.g. aws4_request
+	headerPrefix string // e.g. x-amz-
+}
+
+func awsSigner(accessKeyID, secret, region string) requestSigner {
+	return requestSigner{
+		accessKeyID: accessKeyID, secret: secret, region: region, service: "s3",
+		algorithm: "AWS4-HMAC-SHA256", keyPrefix: "AWS4", terminator: "aws4_request", headerPrefix: "x-amz-",
+	}
+}
+
+func googSigner(accessKeyID, secret string) requestSigner {
+	return requestSigner{
+		accessKeyID: accessKeyID, secret: secret, region: "auto", service: "storage",
+		algorithm: "GOOG4-HMAC-SHA256", keyPrefix: "GOOG4", terminator: "goog4_request", headerPrefix: "x-goog-",
+	}
+}
+
+// sign adds the date, payload hash and Authorization headers to req. Every
+// header already set is signed, along with Host.
+func (s requestSigner) sign(req *http.Request, body []byte, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set(s.headerPrefix+"date", amzDate)
+	req.Header.Set(s.headerPrefix+"content-sha256", hex.EncodeToString(payloadHash[:]))
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := strings.Join([]string{date, s.region, s.service, s.terminator}, "/")
+	stringToSign := strings.Join([]string{s.algorithm, amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := []byte(s.keyPrefix + s.secret)
+	for _, part := range []string{date, s.region, s.service, s.terminator} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.algorithm, s.accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
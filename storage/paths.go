@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Data file names. Tools and resources use these logical names; Paths maps
+// them to where the files actually live in the data repo.
+const (
+	TodosFile          = "todos.md"
+	StrategyFile       = "strategy.md"
+	ReadingListFile    = "reading-list.md"
+	RemindersFile      = "reminders.md"
+	JournalFile        = "journal.md"
+	TimeLogFile        = "timelog.md"
+	ProjectsFile       = "projects.md"
+	PhaseTemplatesFile = "phase-templates.md"
+	FeedsFile          = "feeds.md"
+	NotesFile          = "notes.md"
+	GoalsFile          = "goals.md"
+	FocusFile          = "focus.md"
+	TrashFile          = "trash.md"
+	PreferencesFile    = "preferences.md"
+)
+
+// DataFiles lists the data file names, in the order they're usually shown.
+var DataFiles = []string{
+	TodosFile, StrategyFile, ReadingListFile, RemindersFile, JournalFile,
+	NotesFile, TimeLogFile, ProjectsFile, PhaseTemplatesFile, FeedsFile,
+	GoalsFile, FocusFile, TrashFile, PreferencesFile,
+}
+
+// ReadingArchivePath is the data repo file reading list items read in year
+// are archived to. Archives aren't data files: they're placed under the
+// prefix like any other path, but have no overrides.
+func ReadingArchivePath(year int) string {
+	return fmt.Sprintf("archive/reading-%d.md", year)
+}
+
+// Paths maps logical data file names to paths in the data repo. Every file
+// is placed under Prefix; Overrides renames individual files (relative to
+// Prefix). The zero value keeps files at the repo root under their own names.
+type Paths struct {
+	Prefix    string
+	Overrides map[string]string
+}
+
+// ParsePaths builds Paths from a directory prefix (e.g. "momentum/") and a
+// comma-separated list of name=path overrides (e.g. "todos.md=tasks.md").
+func ParsePaths(prefix, overrides string) (Paths, error) {
+	p := Paths{Overrides: make(map[string]string)}
+
+	if prefix = strings.Trim(strings.TrimSpace(prefix), "/"); prefix != "" {
+		if err := checkRepoPath(prefix); err != nil {
+			return Paths{}, fmt.Errorf("invalid path prefix %q: %w", prefix, err)
+		}
+		p.Prefix = prefix
+	}
+
+	for _, pair := range strings.Split(overrides, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, target, ok := strings.Cut(pair, "=")
+		name, target = strings.TrimSpace(name), strings.Trim(strings.TrimSpace(target), "/")
+		if !ok || name == "" || target == "" {
+			return Paths{}, fmt.Errorf("invalid path override %q: expected name=path", pair)
+		}
+		if err := checkRepoPath(target); err != nil {
+			return Paths{}, fmt.Errorf("invalid path override %q: %w", pair, err)
+		}
+		p.Overrides[name] = target
+	}
+	return p, nil
+}
+
+// checkRepoPath rejects paths that would escape the repo or the prefix.
+func checkRepoPath(p string) error {
+	for _, segment := range strings.Split(p, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return errors.New("path segments must not be empty, \".\" or \"..\"")
+		}
+	}
+	return nil
+}
+
+// Resolve returns the repo path for a logical file name.
+func (p Paths) Resolve(name string) string {
+	if target, ok := p.Overrides[name]; ok {
+		name = target
+	}
+	if p.Prefix == "" {
+		return name
+	}
+	return path.Join(p.Prefix, name)
+}
+
+// Name returns the logical file name stored at repoPath, the inverse of
+// Resolve. It reports false for paths that aren't data files.
+func (p Paths) Name(repoPath string) (string, bool) {
+	for _, name := range DataFiles {
+		if p.Resolve(name) == repoPath {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// IsDefault reports whether p leaves every file at its default location.
+func (p Paths) IsDefault() bool {
+	return p.Prefix == "" && len(p.Overrides) == 0
+}
+
+// WithPaths returns a Storage that reads and writes each logical file name
+// at the repo path p resolves it to. It returns s unchanged if p is the
+// default layout. Multi-file writes and history reads are passed through.
+func WithPaths(s Storage, p Paths) Storage {
+	if p.IsDefault() {
+		return s
+	}
+	return &pathStorage{next: s, paths: p}
+}
+
+// pathStorage decorates a Storage with Paths resolution.
+type pathStorage struct {
+	next  Storage
+	paths Paths
+}
+
+func (m *pathStorage) ReadFile(ctx context.Context, name string) (string, string, error) {
+	return m.next.ReadFile(ctx, m.paths.Resolve(name))
+}
+
+func (m *pathStorage) WriteFile(ctx context.Context, name string, content string, sha string, message string) error {
+	return m.next.WriteFile(ctx, m.paths.Resolve(name), content, sha, message)
+}
+
+func (m *pathStorage) WriteFiles(ctx context.Context, changes []FileChange, message string) error {
+	resolved := make([]FileChange, len(changes))
+	for i, c := range changes {
+		c.Path = m.paths.Resolve(c.Path)
+		resolved[i] = c
+	}
+	return WriteFiles(ctx, m.next, resolved, message)
+}
+
+// errNoHistory is returned by the History methods when the wrapped storage
+// can't read past versions.
+var errNoHistory = errors.New("storage backend does not support history")
+
+func (m *pathStorage) ListCommits(ctx context.Context, name string, limit int) ([]Commit, error) {
+	h, ok := m.next.(History)
+	if !ok {
+		return nil, errNoHistory
+	}
+	return h.ListCommits(ctx, m.paths.Resolve(name), limit)
+}
+
+func (m *pathStorage) ReadFileAt(ctx context.Context, name string, ref string) (string, error) {
+	h, ok := m.next.(History)
+	if !ok {
+		return "", errNoHistory
+	}
+	return h.ReadFileAt(ctx, m.paths.Resolve(name), ref)
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParsePaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		prefix    string
+		overrides string
+		wantErr   bool
+		resolve   map[string]string
+	}{
+		{
+			name:    "default",
+			resolve: map[string]string{TodosFile: "todos.md", "metrics/history.jsonl": "metrics/history.jsonl"},
+		},
+		{
+			name:    "prefix",
+			prefix:  "/momentum/",
+			resolve: map[string]string{TodosFile: "momentum/todos.md", "events.jsonl": "momentum/events.jsonl"},
+		},
+		{
+			name:      "overrides relative to prefix",
+			prefix:    "momentum",
+			overrides: "todos.md=tasks.md, reading-list.md = reading/queue.md",
+			resolve: map[string]string{
+				TodosFile:       "momentum/tasks.md",
+				ReadingListFile: "momentum/reading/queue.md",
+				StrategyFile:    "momentum/strategy.md",
+			},
+		},
+		{name: "override without path", overrides: "todos.md=", wantErr: true},
+		{name: "override without separator", overrides: "todos.md", wantErr: true},
+		{name: "escaping prefix", prefix: "../other", wantErr: true},
+		{name: "escaping override", overrides: "todos.md=../todos.md", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParsePaths(tt.prefix, tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePaths() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for name, want := range tt.resolve {
+				if got := p.Resolve(name); got != want {
+					t.Errorf("Resolve(%q) = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+// recordingStorage remembers the paths it was asked for.
+type recordingStorage struct {
+	paths []string
+}
+
+func (r *recordingStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	r.paths = append(r.paths, "read "+path)
+	return "", "sha", nil
+}
+
+func (r *recordingStorage) WriteFile(ctx context.Context, path, content, sha, message string) error {
+	r.paths = append(r.paths, "write "+path)
+	return nil
+}
+
+func TestWithPaths(t *testing.T) {
+	inner := &recordingStorage{}
+	if s := WithPaths(inner, Paths{}); s != Storage(inner) {
+		t.Error("expected the default layout to return the storage unchanged")
+	}
+
+	p, _ := ParsePaths("momentum", "todos.md=tasks.md")
+	s := WithPaths(inner, p)
+	ctx := context.Background()
+
+	s.ReadFile(ctx, TodosFile)
+	s.WriteFile(ctx, StrategyFile, "", "", "Update")
+	WriteFiles(ctx, s, []FileChange{{Path: TodosFile}, {Path: RemindersFile}}, "Import")
+
+	want := []string{
+		"read momentum/tasks.md",
+		"write momentum/strategy.md",
+		"write momentum/tasks.md",
+		"write momentum/reminders.md",
+	}
+	if len(inner.paths) != len(want) {
+		t.Fatalf("paths = %v, want %v", inner.paths, want)
+	}
+	for i := range want {
+		if inner.paths[i] != want[i] {
+			t.Errorf("paths[%d] = %q, want %q", i, inner.paths[i], want[i])
+		}
+	}
+
+	if _, err := s.(History).ListCommits(ctx, TodosFile, 1); err != errNoHistory {
+		t.Errorf("ListCommits() error = %v, want errNoHistory", err)
+	}
+}
+
+func TestPathsName(t *testing.T) {
+	p, _ := ParsePaths("momentum", "todos.md=tasks.md")
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{"momentum/tasks.md", TodosFile, true},
+		{"momentum/strategy.md", StrategyFile, true},
+		{"momentum/todos.md", "", false},
+		{"strategy.md", "", false},
+		{"momentum/README.md", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := p.Name(tt.path)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Name(%q) = %q, %v, want %q, %v", tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Preferences represents the parsed contents of preferences.md.
+type Preferences struct {
+	Notifications NotificationPreferences
+}
+
+// Matches preference line: - Quiet hours: 22:00-07:00
+var preferenceLinePattern = regexp.MustCompile(`^-\s*(.+?)\s*:\s*(.*?)\s*$`)
+
+// ParsePreferences parses a preferences.md file content. Preferences are
+// "- Name: value" lines under a "## Notifications" heading; unknown ones
+// and invalid values are ignored.
+func ParsePreferences(content string) (*Preferences, error) {
+	p := &Preferences{}
+	section := ""
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "## ") {
+			section = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(line, "## ")))
+			continue
+		}
+		matches := preferenceLinePattern.FindStringSubmatch(line)
+		if matches == nil || section != "notifications" {
+			continue
+		}
+		n := &p.Notifications
+		switch value := matches[2]; strings.ToLower(matches[1]) {
+		case "channels":
+			n.Channels = nil
+			for _, channel := range strings.Split(value, ",") {
+				if channel = strings.ToLower(strings.TrimSpace(channel)); channel != "" {
+					n.Channels = append(n.Channels, channel)
+				}
+			}
+		case "quiet hours":
+			n.QuietHours, _ = ParseQuietHours(value)
+		case "digest":
+			n.Digest = strings.ToLower(value)
+		}
+	}
+	return p, nil
+}
+
+// SerializePreferences converts Preferences back to markdown.
+func SerializePreferences(p *Preferences) string {
+	var b strings.Builder
+	b.WriteString("# Preferences\n")
+	n := p.Notifications
+	if len(n.Channels) > 0 || !n.QuietHours.IsZero() || n.Digest != "" {
+		b.WriteString("\n## Notifications\n")
+		if len(n.Channels) > 0 {
+			b.WriteString("- Channels: " + strings.Join(n.Channels, ", ") + "\n")
+		}
+		if !n.QuietHours.IsZero() {
+			b.WriteString("- Quiet hours: " + n.QuietHours.String() + "\n")
+		}
+		if n.Digest != "" {
+			b.WriteString("- Digest: " + n.Digest + "\n")
+		}
+	}
+	return b.String()
+}
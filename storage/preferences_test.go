@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreferences_RoundTrip(t *testing.T) {
+	content := "# Preferences\n\n## Notifications\n- Channels: webhook, email\n- Quiet hours: 22:00-07:00\n- Digest: weekly\n"
+	p, err := ParsePreferences(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := p.Notifications
+	if len(n.Channels) != 2 || n.QuietHours != (QuietHours{Start: 22 * 60, End: 7 * 60}) || n.Digest != DigestWeekly {
+		t.Fatalf("unexpected preferences %+v", n)
+	}
+	if got := SerializePreferences(p); got != content {
+		t.Errorf("round trip changed the file:\n%s", got)
+	}
+
+	// Quiet hours wrap past midnight
+	for hour, want := range map[int]bool{21: false, 22: true, 3: true, 7: false} {
+		if got := n.QuietHours.Contains(time.Date(2026, 2, 3, hour, 0, 0, 0, time.UTC)); got != want {
+			t.Errorf("Contains(%02d:00) = %v, want %v", hour, got, want)
+		}
+	}
+	if n.DigestDue(time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)) || !n.DigestDue(time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected a weekly digest on Mondays only")
+	}
+	if _, err := ParseQuietHours("25:00-07:00"); err == nil {
+		t.Error("accepted an invalid hour")
+	}
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Project is an entry in the projects.md registry.
+type Project struct {
+	Slug        string
+	Description string
+}
+
+// Matches project registry line: - slug: Description
+var projectLinePattern = regexp.MustCompile(`^-\s*([^:\s]+)\s*(?::\s*(.*))?$`)
+
+// ParseProjects parses a projects.md file content. Each "- slug: Description"
+// line registers a project; the description is optional.
+func ParseProjects(content string) ([]Project, error) {
+	var projects []Project
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if matches := projectLinePattern.FindStringSubmatch(trimmed); matches != nil {
+			slug := NormalizeProject(matches[1])
+			if slug == "" {
+				continue
+			}
+			projects = append(projects, Project{Slug: slug, Description: strings.TrimSpace(matches[2])})
+		}
+	}
+	return projects, nil
+}
+
+// NormalizeProject turns a project name into its slug: lowercased, with
+// spaces as hyphens and characters that would break item metadata removed.
+func NormalizeProject(name string) string {
+	name = strings.ToLower(strings.Join(strings.Fields(name), "-"))
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ',', ':', '{', '}':
+			return -1
+		}
+		return r
+	}, name)
+}
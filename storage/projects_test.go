@@ -0,0 +1,26 @@
+package storage
+
+import "testing"
+
+func TestParseProjects(t *testing.T) {
+	projects, err := ParseProjects("# Projects\n\n- website: Personal site relaunch\n- Momentum Server\n- side-quest:\n")
+	if err != nil {
+		t.Fatalf("ParseProjects failed: %v", err)
+	}
+	want := []Project{
+		{Slug: "website", Description: "Personal site relaunch"},
+		{Slug: "side-quest"},
+	}
+	if len(projects) != len(want) {
+		t.Fatalf("expected %d projects, got %+v", len(want), projects)
+	}
+	for i := range want {
+		if projects[i] != want[i] {
+			t.Errorf("project %d: expected %+v, got %+v", i, want[i], projects[i])
+		}
+	}
+
+	if got := NormalizeProject("  Momentum Server, v2 "); got != "momentum-server-v2" {
+		t.Errorf("NormalizeProject: got %q", got)
+	}
+}
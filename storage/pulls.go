@@ -0,0 +1,320 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+// PullRequestBranchPrefix starts the name of every branch ProposeFiles
+// creates. Only pull requests from such branches are listed, merged or
+// closed, so other pull requests in the data repo are left alone.
+const PullRequestBranchPrefix = "momentum-review-"
+
+// ErrNotMergeable is returned when GitHub refuses to merge a pull request,
+// usually because it conflicts with changes made since it was opened.
+var ErrNotMergeable = errors.New("pull request can't be merged")
+
+// ErrProposed matches the ProposedError a write returns when WithReview
+// opened a pull request for it instead of committing it.
+var ErrProposed = errors.New("change proposed for review")
+
+// ProposedError is returned by writes that WithReview diverted to a pull
+// request: nothing was committed, and the data only changes once the pull
+// request is merged.
+type ProposedError struct {
+	PullRequest *PullRequest
+}
+
+func (e *ProposedError) Error() string {
+	return fmt.Sprintf("proposed as pull request #%d, pending review (%s)", e.PullRequest.Number, e.PullRequest.URL)
+}
+
+func (e *ProposedError) Unwrap() error { return ErrProposed }
+
+// PullRequest is an open pull request proposing changes to the data files.
+type PullRequest struct {
+	Number    int
+	Title     string
+	Branch    string
+	URL       string
+	Files     []string
+	CreatedAt time.Time
+}
+
+// PullRequests is implemented by storage backends that can propose changes
+// for review instead of committing them to the default branch.
+type PullRequests interface {
+	// ProposeFiles commits changes to a new branch and opens a pull request
+	// for it. SHAs are checked as in WriteFiles.
+	ProposeFiles(ctx context.Context, changes []FileChange, message string) (*PullRequest, error)
+	// ListPullRequests returns the open proposed changes, oldest first.
+	ListPullRequests(ctx context.Context) ([]PullRequest, error)
+	// MergePullRequest merges a proposed change and deletes its branch.
+	MergePullRequest(ctx context.Context, number int) error
+	// ClosePullRequest discards a proposed change and deletes its branch.
+	ClosePullRequest(ctx context.Context, number int) error
+}
+
+// pullResponse is the part of a GitHub pull request the server uses.
+type pullResponse struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	HTMLURL   string    `json:"html_url"`
+	CreatedAt time.Time `json:"created_at"`
+	Head      struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+}
+
+func (p pullResponse) pullRequest() PullRequest {
+	return PullRequest{Number: p.Number, Title: p.Title, Branch: p.Head.Ref, URL: p.HTMLURL, CreatedAt: p.CreatedAt}
+}
+
+// ProposeFiles commits changes to a new branch off the default branch and
+// opens a pull request into it. The first line of message is the title.
+func (g *GitHubStorage) ProposeFiles(ctx context.Context, changes []FileChange, message string) (*PullRequest, error) {
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("no changes to propose")
+	}
+
+	base, err := g.defaultBranch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ref struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	if err := g.gitRequest(ctx, http.MethodGet, "git/ref/heads/"+url.PathEscape(base), nil, &ref); err != nil {
+		return nil, fmt.Errorf("reading branch %s: %w", base, err)
+	}
+
+	branch := newBranchName(clock.Or(g.clock).Now(), changes, message)
+	refBody := map[string]string{"ref": "refs/heads/" + branch, "sha": ref.Object.SHA}
+	if err := g.gitRequest(ctx, http.MethodPost, "git/refs", refBody, nil); err != nil {
+		return nil, fmt.Errorf("creating branch %s: %w", branch, err)
+	}
+
+	// Nothing else writes to the new branch, so it can't move under us
+	if err := g.commitFiles(ctx, branch, changes, message); err != nil {
+		g.deleteBranch(ctx, branch)
+		if err == errNotFastForward {
+			return nil, ErrConflict
+		}
+		return nil, err
+	}
+
+	title, body, _ := strings.Cut(message, "\n")
+	var pull pullResponse
+	pullBody := map[string]string{"title": title, "body": strings.TrimSpace(body), "head": branch, "base": base}
+	if err := g.gitRequest(ctx, http.MethodPost, "pulls", pullBody, &pull); err != nil {
+		g.deleteBranch(ctx, branch)
+		return nil, fmt.Errorf("opening pull request: %w", err)
+	}
+
+	pr := pull.pullRequest()
+	for _, c := range changes {
+		pr.Files = append(pr.Files, c.Path)
+	}
+	return &pr, nil
+}
+
+// newBranchName returns the branch name under PullRequestBranchPrefix for
+// proposing changes at now: the time, then a short hash of the proposal so
+// proposals made in the same second get different branches.
+func newBranchName(now time.Time, changes []FileChange, message string) string {
+	h := sha1.New()
+	h.Write([]byte(message))
+	for _, c := range changes {
+		fmt.Fprintf(h, "\x00%s\x00%s", c.Path, c.Content)
+	}
+	return PullRequestBranchPrefix + now.UTC().Format("20060102-150405") + "-" + hex.EncodeToString(h.Sum(nil))[:6]
+}
+
+// ListPullRequests returns the open pull requests opened by ProposeFiles,
+// with the files each one changes.
+func (g *GitHubStorage) ListPullRequests(ctx context.Context) ([]PullRequest, error) {
+	base, err := g.defaultBranch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pulls []pullResponse
+	query := "pulls?state=open&sort=created&direction=asc&per_page=100&base=" + url.QueryEscape(base)
+	if err := g.gitRequest(ctx, http.MethodGet, query, nil, &pulls); err != nil {
+		return nil, fmt.Errorf("listing pull requests: %w", err)
+	}
+
+	var prs []PullRequest
+	for _, p := range pulls {
+		if !strings.HasPrefix(p.Head.Ref, PullRequestBranchPrefix) {
+			continue
+		}
+		pr := p.pullRequest()
+		if pr.Files, err = g.pullFiles(ctx, p.Number); err != nil {
+			return nil, err
+		}
+		prs = append(prs, pr)
+	}
+	return prs, nil
+}
+
+// pullFiles returns the paths a pull request changes.
+func (g *GitHubStorage) pullFiles(ctx context.Context, number int) ([]string, error) {
+	var files []struct {
+		Filename string `json:"filename"`
+	}
+	if err := g.gitRequest(ctx, http.MethodGet, fmt.Sprintf("pulls/%d/files?per_page=100", number), nil, &files); err != nil {
+		return nil, fmt.Errorf("listing files of pull request #%d: %w", number, err)
+	}
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Filename
+	}
+	return paths, nil
+}
+
+// proposedPull returns pull request number if it is open and was opened by
+// ProposeFiles, or ErrNotFound.
+func (g *GitHubStorage) proposedPull(ctx context.Context, number int) (*pullResponse, error) {
+	var pull struct {
+		pullResponse
+		State string `json:"state"`
+	}
+	if err := g.gitRequest(ctx, http.MethodGet, fmt.Sprintf("pulls/%d", number), nil, &pull); err != nil {
+		return nil, err
+	}
+	if pull.State != "open" || !strings.HasPrefix(pull.Head.Ref, PullRequestBranchPrefix) {
+		return nil, ErrNotFound
+	}
+	return &pull.pullResponse, nil
+}
+
+// MergePullRequest squash-merges a pull request opened by ProposeFiles, so
+// the default branch gets one commit with its title, and deletes its branch.
+func (g *GitHubStorage) MergePullRequest(ctx context.Context, number int) error {
+	pull, err := g.proposedPull(ctx, number)
+	if err != nil {
+		return err
+	}
+	files, err := g.pullFiles(ctx, number)
+	if err != nil {
+		return err
+	}
+
+	mergeBody := map[string]string{"merge_method": "squash", "commit_title": pull.Title}
+	err = g.gitRequest(ctx, http.MethodPut, fmt.Sprintf("pulls/%d/merge", number), mergeBody, nil)
+	if errors.Is(err, ErrConflict) {
+		// 409: the pull request's branch moved while merging
+		return ErrNotMergeable
+	}
+	if err != nil {
+		return err
+	}
+
+	// The merge changed these files on the default branch
+	g.Invalidate(files...)
+	g.deleteBranch(ctx, pull.Head.Ref)
+	return nil
+}
+
+// ClosePullRequest closes a pull request opened by ProposeFiles without
+// merging it, and deletes its branch.
+func (g *GitHubStorage) ClosePullRequest(ctx context.Context, number int) error {
+	pull, err := g.proposedPull(ctx, number)
+	if err != nil {
+		return err
+	}
+	if err := g.gitRequest(ctx, http.MethodPatch, fmt.Sprintf("pulls/%d", number), map[string]string{"state": "closed"}, nil); err != nil {
+		return fmt.Errorf("closing pull request #%d: %w", number, err)
+	}
+	g.deleteBranch(ctx, pull.Head.Ref)
+	return nil
+}
+
+// deleteBranch deletes a branch created by ProposeFiles. Failures only leave
+// a stray branch behind, so they are ignored.
+func (g *GitHubStorage) deleteBranch(ctx context.Context, branch string) {
+	g.gitRequest(ctx, http.MethodDelete, "git/refs/heads/"+url.PathEscape(branch), nil, nil)
+}
+
+// WithReview returns a Storage that proposes writes touching any of paths
+// as pull requests through pr, instead of committing them to s, and returns
+// a *ProposedError for them. Other writes and all reads go to s. The path "*" matches every file. Paths are
+// repo paths, so WithReview goes inside WithPaths.
+func WithReview(s Storage, pr PullRequests, paths []string) Storage {
+	r := &reviewStorage{next: s, pr: pr, paths: make(map[string]bool)}
+	for _, p := range paths {
+		r.paths[p] = true
+	}
+	return r
+}
+
+// reviewStorage decorates a Storage, diverting reviewed writes to pull
+// requests.
+type reviewStorage struct {
+	next  Storage
+	pr    PullRequests
+	paths map[string]bool
+}
+
+func (r *reviewStorage) reviewed(path string) bool {
+	return r.paths["*"] || r.paths[path]
+}
+
+func (r *reviewStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	return r.next.ReadFile(ctx, path)
+}
+
+func (r *reviewStorage) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	if !r.reviewed(path) {
+		return r.next.WriteFile(ctx, path, content, sha, message)
+	}
+	return r.propose(ctx, []FileChange{{Path: path, Content: content, SHA: sha}}, message)
+}
+
+// WriteFiles proposes the whole batch if any file in it is reviewed, so a
+// multi-file change is never split between a commit and a pull request.
+func (r *reviewStorage) WriteFiles(ctx context.Context, changes []FileChange, message string) error {
+	for _, c := range changes {
+		if r.reviewed(c.Path) {
+			return r.propose(ctx, changes, message)
+		}
+	}
+	return WriteFiles(ctx, r.next, changes, message)
+}
+
+// propose opens a pull request for changes, reporting it as a
+// *ProposedError.
+func (r *reviewStorage) propose(ctx context.Context, changes []FileChange, message string) error {
+	pr, err := r.pr.ProposeFiles(ctx, changes, message)
+	if err != nil {
+		return err
+	}
+	return &ProposedError{PullRequest: pr}
+}
+
+func (r *reviewStorage) ListCommits(ctx context.Context, path string, limit int) ([]Commit, error) {
+	h, ok := r.next.(History)
+	if !ok {
+		return nil, errNoHistory
+	}
+	return h.ListCommits(ctx, path, limit)
+}
+
+func (r *reviewStorage) ReadFileAt(ctx context.Context, path string, ref string) (string, error) {
+	h, ok := r.next.(History)
+	if !ok {
+		return "", errNoHistory
+	}
+	return h.ReadFileAt(ctx, path, ref)
+}
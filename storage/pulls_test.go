@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+// fakePullsRepo answers the refs and pulls API calls made by the
+// PullRequests methods, and passes the rest to a fakeGitRepo.
+type fakePullsRepo struct {
+	fakeGitRepo
+	unmergeable bool
+
+	branch     string // created by ProposeFiles
+	pullBody   map[string]string
+	mergeBody  map[string]string
+	closed     bool
+	deleted    []string
+	branchHead string
+}
+
+const foreignBranch = "feature-x"
+
+func (f *fakePullsRepo) handle(t *testing.T) func(*http.Request) (*http.Response, error) {
+	git := f.fakeGitRepo.handle(t)
+	return func(req *http.Request) (*http.Response, error) {
+		resp := httptest.NewRecorder()
+		path := strings.TrimPrefix(req.URL.Path, "/repos/owner/repo")
+		pull := func(number int, branch string) map[string]any {
+			return map[string]any{"number": number, "title": "Edit strategy", "state": "open", "html_url": "https://github.com/owner/repo/pull/7",
+				"created_at": "2026-03-04T09:00:00Z", "head": map[string]string{"ref": branch}}
+		}
+		switch {
+		case req.Method == http.MethodPost && path == "/git/refs":
+			var body map[string]string
+			json.NewDecoder(req.Body).Decode(&body)
+			f.branch = strings.TrimPrefix(body["ref"], "refs/heads/")
+			f.branchHead = body["sha"]
+			resp.WriteHeader(http.StatusCreated)
+		case req.Method == http.MethodGet && f.branch != "" && path == "/git/ref/heads/"+f.branch:
+			json.NewEncoder(resp).Encode(map[string]any{"object": map[string]string{"sha": f.branchHead}})
+		case req.Method == http.MethodPatch && f.branch != "" && path == "/git/refs/heads/"+f.branch:
+			f.patches++
+			json.NewEncoder(resp).Encode(map[string]any{"object": map[string]string{"sha": "commit2"}})
+		case req.Method == http.MethodDelete && strings.HasPrefix(path, "/git/refs/heads/"):
+			f.deleted = append(f.deleted, strings.TrimPrefix(path, "/git/refs/heads/"))
+			resp.WriteHeader(http.StatusNoContent)
+		case req.Method == http.MethodPost && path == "/pulls":
+			json.NewDecoder(req.Body).Decode(&f.pullBody)
+			resp.WriteHeader(http.StatusCreated)
+			json.NewEncoder(resp).Encode(pull(7, f.pullBody["head"]))
+		case req.Method == http.MethodGet && path == "/pulls":
+			if req.URL.Query().Get("base") != "main" {
+				t.Errorf("pull requests listed for base %q", req.URL.Query().Get("base"))
+			}
+			json.NewEncoder(resp).Encode([]any{pull(7, PullRequestBranchPrefix+"1"), pull(8, foreignBranch)})
+		case req.Method == http.MethodGet && path == "/pulls/7":
+			json.NewEncoder(resp).Encode(pull(7, PullRequestBranchPrefix+"1"))
+		case req.Method == http.MethodGet && path == "/pulls/8":
+			json.NewEncoder(resp).Encode(pull(8, foreignBranch))
+		case req.Method == http.MethodGet && path == "/pulls/7/files":
+			json.NewEncoder(resp).Encode([]map[string]string{{"filename": "strategy.md"}})
+		case req.Method == http.MethodPut && path == "/pulls/7/merge":
+			if f.unmergeable {
+				resp.WriteHeader(http.StatusMethodNotAllowed)
+				break
+			}
+			json.NewDecoder(req.Body).Decode(&f.mergeBody)
+			json.NewEncoder(resp).Encode(map[string]any{"merged": true})
+		case req.Method == http.MethodPatch && path == "/pulls/7":
+			f.closed = true
+			json.NewEncoder(resp).Encode(pull(7, PullRequestBranchPrefix+"1"))
+		default:
+			return git(req)
+		}
+		return resp.Result(), nil
+	}
+}
+
+func newFakePullsStorage(t *testing.T, repo *fakePullsRepo) *GitHubStorage {
+	gs, _ := NewGitHubStorage("test-token", "owner/repo")
+	gs.httpClient = &http.Client{Transport: &mockTransport{handler: repo.handle(t)}}
+	return gs
+}
+
+func TestGitHubStorage_ProposeFiles(t *testing.T) {
+	repo := &fakePullsRepo{fakeGitRepo: fakeGitRepo{blobs: map[string]string{"strategy.md": "sha-strategy"}}}
+	gs := newFakePullsStorage(t, repo)
+	gs.SetClock(clock.NewFake(time.Date(2026, 2, 10, 9, 30, 0, 0, time.UTC)))
+	changes := []FileChange{{Path: "strategy.md", Content: "x", SHA: "sha-strategy"}}
+
+	pr, err := gs.ProposeFiles(context.Background(), changes, "Edit strategy\n\nTool: edit_milestone")
+	if err != nil {
+		t.Fatalf("ProposeFiles() error = %v", err)
+	}
+	if !strings.HasPrefix(repo.branch, PullRequestBranchPrefix+"20260210-093000-") || repo.branchHead != "head1" {
+		t.Errorf("branch %q created at %q, want a %s20260210-093000- branch at head1", repo.branch, repo.branchHead, PullRequestBranchPrefix)
+	}
+	if want := newBranchName(time.Date(2026, 2, 10, 9, 30, 0, 0, time.UTC), changes, "Edit strategy\n\nTool: edit_milestone"); repo.branch != want {
+		t.Errorf("branch %q, want %q", repo.branch, want)
+	}
+	if other := newBranchName(time.Date(2026, 2, 10, 9, 30, 0, 0, time.UTC), changes, "Edit strategy"); other == repo.branch {
+		t.Error("different proposals in the same second share a branch name")
+	}
+	if repo.commitBody["message"] != "Edit strategy\n\nTool: edit_milestone" || repo.patches != 1 {
+		t.Errorf("commit %v with %d ref updates", repo.commitBody, repo.patches)
+	}
+	want := map[string]string{"title": "Edit strategy", "body": "Tool: edit_milestone", "head": repo.branch, "base": "main"}
+	for k, v := range want {
+		if repo.pullBody[k] != v {
+			t.Errorf("pull request %s = %q, want %q", k, repo.pullBody[k], v)
+		}
+	}
+	if pr.Number != 7 || len(pr.Files) != 1 || pr.Files[0] != "strategy.md" {
+		t.Errorf("ProposeFiles() = %+v", pr)
+	}
+
+	// A stale SHA fails before the pull request is opened, and the branch is
+	// cleaned up
+	repo = &fakePullsRepo{fakeGitRepo: fakeGitRepo{blobs: map[string]string{"strategy.md": "sha-strategy"}}}
+	gs = newFakePullsStorage(t, repo)
+	_, err = gs.ProposeFiles(context.Background(), []FileChange{{Path: "strategy.md", Content: "x", SHA: "old"}}, "Edit")
+	if err != ErrConflict || repo.pullBody != nil || len(repo.deleted) != 1 || repo.deleted[0] != repo.branch {
+		t.Errorf("stale ProposeFiles() = %v, pull %v, deleted %v", err, repo.pullBody, repo.deleted)
+	}
+}
+
+func TestGitHubStorage_PullRequests(t *testing.T) {
+	repo := &fakePullsRepo{}
+	gs := newFakePullsStorage(t, repo)
+	ctx := context.Background()
+
+	prs, err := gs.ListPullRequests(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prs) != 1 || prs[0].Number != 7 || prs[0].Files[0] != "strategy.md" || prs[0].CreatedAt.IsZero() {
+		t.Errorf("ListPullRequests() = %+v, want only #7", prs)
+	}
+
+	// Pull requests from other branches are left alone
+	if err := gs.MergePullRequest(ctx, 8); err != ErrNotFound {
+		t.Errorf("MergePullRequest(8) error = %v, want ErrNotFound", err)
+	}
+	if err := gs.ClosePullRequest(ctx, 8); err != ErrNotFound {
+		t.Errorf("ClosePullRequest(8) error = %v, want ErrNotFound", err)
+	}
+
+	if err := gs.MergePullRequest(ctx, 7); err != nil {
+		t.Fatalf("MergePullRequest(7) error = %v", err)
+	}
+	if repo.mergeBody["merge_method"] != "squash" || len(repo.deleted) != 1 || repo.deleted[0] != PullRequestBranchPrefix+"1" {
+		t.Errorf("merge %v, deleted %v", repo.mergeBody, repo.deleted)
+	}
+
+	if err := gs.ClosePullRequest(ctx, 7); err != nil || !repo.closed {
+		t.Errorf("ClosePullRequest(7) error = %v, closed %v", err, repo.closed)
+	}
+
+	repo.unmergeable = true
+	if err := gs.MergePullRequest(ctx, 7); err != ErrNotMergeable {
+		t.Errorf("unmergeable MergePullRequest() error = %v, want ErrNotMergeable", err)
+	}
+}
+
+// fakeProposer records proposed changes, or fails with err.
+type fakeProposer struct {
+	proposed [][]FileChange
+	err      error
+}
+
+func (f *fakeProposer) ProposeFiles(ctx context.Context, changes []FileChange, message string) (*PullRequest, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.proposed = append(f.proposed, changes)
+	return &PullRequest{Number: len(f.proposed)}, nil
+}
+
+func (f *fakeProposer) ListPullRequests(ctx context.Context) ([]PullRequest, error) { return nil, nil }
+func (f *fakeProposer) MergePullRequest(ctx context.Context, number int) error      { return nil }
+func (f *fakeProposer) ClosePullRequest(ctx context.Context, number int) error      { return nil }
+
+func TestWithReview(t *testing.T) {
+	ctx := context.Background()
+	next := &plainStorage{}
+	pr := &fakeProposer{}
+	s := WithReview(next, pr, []string{"strategy.md"})
+
+	if err := s.WriteFile(ctx, "todos.md", "x", "", "Add todo"); err != nil {
+		t.Fatal(err)
+	}
+	// A proposed write reports the pull request, not a commit
+	var proposed *ProposedError
+	err := s.WriteFile(ctx, "strategy.md", "x", "", "Edit strategy")
+	if !errors.As(err, &proposed) || proposed.PullRequest.Number != 1 || !errors.Is(err, ErrProposed) {
+		t.Fatalf("reviewed WriteFile() = %v, want a *ProposedError for #1", err)
+	}
+	if len(next.writes) != 1 || next.writes[0] != "todos.md: Add todo" || len(pr.proposed) != 1 {
+		t.Errorf("writes %v, proposed %v", next.writes, pr.proposed)
+	}
+
+	// A batch touching a reviewed file is proposed whole
+	err = WriteFiles(ctx, s, []FileChange{{Path: "todos.md"}, {Path: "strategy.md"}}, "Promote todo")
+	if !errors.As(err, &proposed) || proposed.PullRequest.Number != 2 {
+		t.Fatalf("batch WriteFiles() = %v, want a *ProposedError for #2", err)
+	}
+	if len(next.writes) != 1 || len(pr.proposed) != 2 || len(pr.proposed[1]) != 2 {
+		t.Errorf("batch: writes %v, proposed %v", next.writes, pr.proposed)
+	}
+
+	// * reviews everything
+	s = WithReview(next, pr, []string{"*"})
+	if err := s.WriteFile(ctx, "reading-list.md", "x", "", "Add"); !errors.Is(err, ErrProposed) || len(pr.proposed) != 3 {
+		t.Errorf("* did not review reading-list.md: %v", pr.proposed)
+	}
+}
@@ -0,0 +1,482 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+// DefaultQueueRetryInterval is how often queued writes are retried.
+const DefaultQueueRetryInterval = 30 * time.Second
+
+// Unavailable reports whether err means the backend couldn't be reached or
+// refused for now - a network failure, a server error or the rate limit -
+// rather than rejecting the request itself.
+func Unavailable(err error) bool {
+	if errors.Is(err, ErrUnavailable) || errors.Is(err, ErrRateLimited) {
+		return true
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// QueuedFile is a file with writes waiting for the backend. Later writes to
+// a queued file are folded into it, so only its latest content is kept.
+type QueuedFile struct {
+	Path string `json:"path"`
+	// BaseSHA is the backend SHA the writes build on, or empty for a new
+	// file. The file is replayed only while the backend still has it.
+	BaseSHA  string    `json:"base_sha"`
+	Content  string    `json:"content"`
+	Messages []string  `json:"messages"`
+	QueuedAt time.Time `json:"queued_at"`
+	// Conflict is set when the file changed in the backend since BaseSHA.
+	// It stays queued until overwritten or discarded.
+	Conflict bool `json:"conflict,omitempty"`
+}
+
+// QueueStatus describes a WriteQueue.
+type QueueStatus struct {
+	Files       []QueuedFile
+	LastAttempt time.Time // zero if the queue hasn't been replayed
+	LastError   string    // why the last write or replay failed, if it did
+	LastSynced  time.Time // zero if nothing has been replayed
+}
+
+// WriteQueue keeps accepting writes while the backend is unavailable. A
+// write that fails because GitHub is down or rate-limited is queued locally
+// (in dataDir/write_queue.json, so it survives restarts) and reported as
+// done; reads of a queued file see the queued content. A background loop
+// replays the queue when the backend recovers, in one commit, checking each
+// file still has the SHA its writes were based on.
+//
+// Reads that fail the same way are served from the last content read, so a
+// read-modify-write cycle can still complete.
+type WriteQueue struct {
+	next  Storage
+	clock clock.Clock
+
+	retryInterval time.Duration
+	filePath      string
+
+	mu          sync.Mutex
+	files       map[string]*QueuedFile
+	known       map[string]knownFile // last content read from next, by path
+	lastAttempt time.Time
+	lastError   string
+	lastSynced  time.Time
+
+	// syncMu allows one replay at a time
+	syncMu sync.Mutex
+	stopCh chan struct{}
+}
+
+// knownFile is the last content read for a path, and its SHA.
+type knownFile struct {
+	content string
+	sha     string
+}
+
+// NewWriteQueue creates a WriteQueue in front of next. The queue is kept in
+// dataDir/write_queue.json; if dataDir is empty it is kept in memory only.
+// A nil clock uses the system clock.
+func NewWriteQueue(next Storage, dataDir string, c clock.Clock) *WriteQueue {
+	q := &WriteQueue{
+		next:          next,
+		clock:         clock.Or(c),
+		retryInterval: DefaultQueueRetryInterval,
+		files:         make(map[string]*QueuedFile),
+		known:         make(map[string]knownFile),
+		stopCh:        make(chan struct{}),
+	}
+	if dataDir != "" {
+		q.filePath = filepath.Join(dataDir, "write_queue.json")
+	}
+	return q
+}
+
+// Start loads the saved queue and begins retrying it in the background.
+func (q *WriteQueue) Start() error {
+	if err := q.load(); err != nil {
+		return err
+	}
+	go q.loop()
+	return nil
+}
+
+// Stop ends the retry loop. Queued writes stay saved for the next start.
+func (q *WriteQueue) Stop() {
+	close(q.stopCh)
+}
+
+func (q *WriteQueue) loop() {
+	ticker := time.NewTicker(q.retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			if n, err := q.Sync(ctx); err != nil {
+				slog.Warn("replaying queued writes failed", "error", err)
+			} else if n > 0 {
+				slog.Info("replayed queued writes", "files", n)
+			}
+			cancel()
+		case <-q.stopCh:
+			return
+		}
+	}
+}
+
+// ReadFile reads path from the queue if it has writes waiting, and from the
+// backend otherwise - falling back to the last content read if the backend
+// is unavailable.
+func (q *WriteQueue) ReadFile(ctx context.Context, path string) (string, string, error) {
+	q.mu.Lock()
+	if f := q.files[path]; f != nil {
+		content := f.Content
+		q.mu.Unlock()
+		return content, blobSHA(content), nil
+	}
+	q.mu.Unlock()
+
+	content, sha, err := q.next.ReadFile(ctx, path)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err != nil {
+		if known, ok := q.known[path]; ok && Unavailable(err) {
+			slog.Warn("backend unavailable, serving last read content", "path", path, "error", err)
+			return known.content, known.sha, nil
+		}
+		return "", "", err
+	}
+	q.known[path] = knownFile{content: content, sha: sha}
+	return content, sha, nil
+}
+
+func (q *WriteQueue) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	return q.WriteFiles(ctx, []FileChange{{Path: path, Content: content, SHA: sha}}, message)
+}
+
+// WriteFiles writes changes to the backend, or queues them if any of the
+// files is already queued or the backend is unavailable.
+func (q *WriteQueue) WriteFiles(ctx context.Context, changes []FileChange, message string) error {
+	q.mu.Lock()
+	queued := false
+	for _, c := range changes {
+		if f := q.files[c.Path]; f != nil {
+			if c.SHA != blobSHA(f.Content) {
+				q.mu.Unlock()
+				return ErrConflict
+			}
+			queued = true
+		}
+	}
+	if queued {
+		defer q.mu.Unlock()
+		return q.enqueue(changes, message)
+	}
+	q.mu.Unlock()
+
+	err := WriteFiles(ctx, q.next, changes, message)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err == nil {
+		for _, c := range changes {
+			q.known[c.Path] = knownFile{content: c.Content, sha: blobSHA(c.Content)}
+		}
+		return nil
+	}
+	if !Unavailable(err) {
+		return err
+	}
+	// A file queued meanwhile must be written on top of its queued content
+	for _, c := range changes {
+		if q.files[c.Path] != nil {
+			return ErrConflict
+		}
+	}
+	slog.Warn("backend unavailable, queueing write", "files", len(changes), "error", err)
+	q.lastError = err.Error()
+	return q.enqueue(changes, message)
+}
+
+// enqueue adds changes to the queue and saves it. q.mu must be held.
+func (q *WriteQueue) enqueue(changes []FileChange, message string) error {
+	now := q.clock.Now()
+	for _, c := range changes {
+		f := q.files[c.Path]
+		if f == nil {
+			f = &QueuedFile{Path: c.Path, BaseSHA: c.SHA, QueuedAt: now}
+			q.files[c.Path] = f
+		}
+		f.Content = c.Content
+		f.Messages = append(f.Messages, message)
+	}
+	if err := q.save(); err != nil {
+		return fmt.Errorf("saving write queue: %w", err)
+	}
+	return nil
+}
+
+// Status returns the queued files, oldest first, and the last replay.
+func (q *WriteQueue) Status() QueueStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return QueueStatus{
+		Files:       q.sortedFiles(),
+		LastAttempt: q.lastAttempt,
+		LastError:   q.lastError,
+		LastSynced:  q.lastSynced,
+	}
+}
+
+// sortedFiles copies the queued files, oldest first. q.mu must be held.
+func (q *WriteQueue) sortedFiles() []QueuedFile {
+	files := make([]QueuedFile, 0, len(q.files))
+	for _, f := range q.files {
+		c := *f
+		c.Messages = append([]string(nil), f.Messages...)
+		files = append(files, c)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if !files[i].QueuedAt.Equal(files[j].QueuedAt) {
+			return files[i].QueuedAt.Before(files[j].QueuedAt)
+		}
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
+
+// Sync replays the queued files that aren't in conflict, in one commit, and
+// returns how many were written. Files changed in the backend since their
+// writes were queued are marked as conflicts and left queued.
+func (q *WriteQueue) Sync(ctx context.Context) (int, error) {
+	q.syncMu.Lock()
+	defer q.syncMu.Unlock()
+
+	for {
+		q.mu.Lock()
+		var ready []QueuedFile
+		for _, f := range q.sortedFiles() {
+			if !f.Conflict {
+				ready = append(ready, f)
+			}
+		}
+		if len(ready) == 0 {
+			q.mu.Unlock()
+			return 0, nil
+		}
+		q.lastAttempt = q.clock.Now()
+		q.mu.Unlock()
+
+		changes := make([]FileChange, len(ready))
+		var messages []string
+		for i, f := range ready {
+			changes[i] = FileChange{Path: f.Path, Content: f.Content, SHA: f.BaseSHA}
+			messages = append(messages, f.Messages...)
+		}
+		err := WriteFiles(ctx, q.next, changes, replayMessage(messages))
+		if errors.Is(err, ErrConflict) {
+			// Find the files that changed and replay the rest
+			if marked, markErr := q.markConflicts(ctx, ready); markErr != nil {
+				err = markErr
+			} else if marked > 0 {
+				continue
+			}
+		}
+
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		// Writes proposed as a pull request are handed over, but leave the
+		// backend as it was
+		proposed := errors.Is(err, ErrProposed)
+		if err != nil && !proposed {
+			q.lastError = err.Error()
+			return 0, err
+		}
+		if proposed {
+			slog.Info("queued writes proposed for review", "files", len(ready), "result", err)
+		}
+		q.lastError = ""
+		q.lastSynced = q.clock.Now()
+		for _, written := range ready {
+			f := q.files[written.Path]
+			if f == nil {
+				continue
+			}
+			if !proposed {
+				q.known[f.Path] = knownFile{content: written.Content, sha: blobSHA(written.Content)}
+			}
+			if f.Content == written.Content {
+				delete(q.files, f.Path)
+				continue
+			}
+			// Written to while replaying: what's left builds on what was written
+			if !proposed {
+				f.BaseSHA = blobSHA(written.Content)
+			}
+			f.Messages = f.Messages[len(written.Messages):]
+		}
+		if err := q.save(); err != nil {
+			return len(ready), fmt.Errorf("saving write queue: %w", err)
+		}
+		return len(ready), nil
+	}
+}
+
+// markConflicts marks the files whose backend SHA is no longer their base,
+// and returns how many it marked.
+func (q *WriteQueue) markConflicts(ctx context.Context, files []QueuedFile) (int, error) {
+	marked := 0
+	for _, f := range files {
+		_, sha, err := q.next.ReadFile(ctx, f.Path)
+		if errors.Is(err, ErrNotFound) {
+			sha, err = "", nil
+		}
+		if err != nil {
+			return marked, err
+		}
+		if sha == f.BaseSHA {
+			continue
+		}
+		q.mu.Lock()
+		if queued := q.files[f.Path]; queued != nil {
+			queued.Conflict = true
+			marked++
+		}
+		q.mu.Unlock()
+		slog.Warn("queued write conflicts with a change in the repo", "path", f.Path)
+	}
+	if marked > 0 {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if err := q.save(); err != nil {
+			return marked, fmt.Errorf("saving write queue: %w", err)
+		}
+	}
+	return marked, nil
+}
+
+// replayMessage is the commit message for replaying queued writes.
+func replayMessage(messages []string) string {
+	if len(messages) == 1 {
+		return messages[0]
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Sync %d offline changes\n", len(messages))
+	for _, m := range messages {
+		b.WriteString("\n- " + m)
+	}
+	return b.String()
+}
+
+// Overwrite rebases a conflicted file on the backend's current version, so
+// the next replay replaces whatever changed there with the queued content.
+func (q *WriteQueue) Overwrite(ctx context.Context, path string) error {
+	_, sha, err := q.next.ReadFile(ctx, path)
+	if errors.Is(err, ErrNotFound) {
+		sha, err = "", nil
+	}
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	f := q.files[path]
+	if f == nil {
+		return ErrNotFound
+	}
+	f.BaseSHA = sha
+	f.Conflict = false
+	return q.save()
+}
+
+// Discard drops a file's queued writes.
+func (q *WriteQueue) Discard(path string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.files[path] == nil {
+		return ErrNotFound
+	}
+	delete(q.files, path)
+	return q.save()
+}
+
+// load reads the saved queue, if any.
+func (q *WriteQueue) load() error {
+	if q.filePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(q.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading write queue: %w", err)
+	}
+	var files []*QueuedFile
+	if err := json.Unmarshal(data, &files); err != nil {
+		return fmt.Errorf("parsing write queue: %w", err)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, f := range files {
+		q.files[f.Path] = f
+	}
+	if len(files) > 0 {
+		slog.Info("loaded queued writes", "files", len(files))
+	}
+	return nil
+}
+
+// save writes the queue to disk atomically. q.mu must be held.
+func (q *WriteQueue) save() error {
+	if q.filePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(q.sortedFiles(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(q.filePath), 0700); err != nil {
+		return err
+	}
+	tmpFile := q.filePath + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, q.filePath)
+}
+
+func (q *WriteQueue) ListCommits(ctx context.Context, path string, limit int) ([]Commit, error) {
+	h, ok := q.next.(History)
+	if !ok {
+		return nil, errNoHistory
+	}
+	return h.ListCommits(ctx, path, limit)
+}
+
+func (q *WriteQueue) ReadFileAt(ctx context.Context, path string, ref string) (string, error) {
+	h, ok := q.next.(History)
+	if !ok {
+		return "", errNoHistory
+	}
+	return h.ReadFileAt(ctx, path, ref)
+}
@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+// flakyStorage is a MemoryStorage that can be taken down.
+type flakyStorage struct {
+	*MemoryStorage
+	down bool
+}
+
+func (f *flakyStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	if f.down {
+		return "", "", ErrUnavailable
+	}
+	return f.MemoryStorage.ReadFile(ctx, path)
+}
+
+func (f *flakyStorage) WriteFile(ctx context.Context, path, content, sha, message string) error {
+	if f.down {
+		return ErrRateLimited
+	}
+	return f.MemoryStorage.WriteFile(ctx, path, content, sha, message)
+}
+
+func (f *flakyStorage) WriteFiles(ctx context.Context, changes []FileChange, message string) error {
+	if f.down {
+		return ErrUnavailable
+	}
+	return f.MemoryStorage.WriteFiles(ctx, changes, message)
+}
+
+func TestWriteQueue_QueuesAndReplays(t *testing.T) {
+	ctx := context.Background()
+	backend := &flakyStorage{MemoryStorage: NewMemoryStorage(map[string]string{TodosFile: "a\n"}, nil)}
+	dir := t.TempDir()
+	q := NewWriteQueue(backend, dir, clock.NewFake(time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)))
+
+	// Read while up, then go down: the last read is served
+	if _, _, err := q.ReadFile(ctx, TodosFile); err != nil {
+		t.Fatal(err)
+	}
+	backend.down = true
+	for _, line := range []string{"b", "c"} {
+		content, sha, err := q.ReadFile(ctx, TodosFile)
+		if err != nil {
+			t.Fatalf("offline read: %v", err)
+		}
+		if err := q.WriteFile(ctx, TodosFile, content+line+"\n", sha, "Add "+line); err != nil {
+			t.Fatalf("offline write: %v", err)
+		}
+	}
+	if content, _, _ := q.ReadFile(ctx, TodosFile); content != "a\nb\nc\n" {
+		t.Errorf("queued content = %q", content)
+	}
+	if err := q.WriteFile(ctx, TodosFile, "x", "stale", "Edit"); err != ErrConflict {
+		t.Errorf("stale write to queued file = %v, want ErrConflict", err)
+	}
+
+	// The queue survives a restart
+	restarted := NewWriteQueue(backend, dir, nil)
+	if err := restarted.load(); err != nil {
+		t.Fatal(err)
+	}
+	status := restarted.Status()
+	if len(status.Files) != 1 || len(status.Files[0].Messages) != 2 {
+		t.Fatalf("reloaded status = %+v", status)
+	}
+
+	if _, err := restarted.Sync(ctx); err == nil {
+		t.Error("sync while down succeeded")
+	}
+	backend.down = false
+	if n, err := restarted.Sync(ctx); n != 1 || err != nil {
+		t.Fatalf("Sync() = %d, %v", n, err)
+	}
+	if content, _, _ := backend.MemoryStorage.ReadFile(ctx, TodosFile); content != "a\nb\nc\n" {
+		t.Errorf("synced content = %q", content)
+	}
+	commits, _ := backend.ListCommits(ctx, TodosFile, 1)
+	if len(commits) != 1 || !strings.Contains(commits[0].Message, "Sync 2 offline changes") {
+		t.Errorf("commits = %+v", commits)
+	}
+	if status := restarted.Status(); len(status.Files) != 0 || status.LastSynced.IsZero() {
+		t.Errorf("status after sync = %+v", status)
+	}
+}
+
+func TestWriteQueue_SyncProposed(t *testing.T) {
+	ctx := context.Background()
+	backend := &flakyStorage{MemoryStorage: NewMemoryStorage(map[string]string{StrategyFile: "a\n"}, nil)}
+	pr := &fakeProposer{err: ErrUnavailable}
+	q := NewWriteQueue(WithReview(backend, pr, []string{StrategyFile}), t.TempDir(), nil)
+
+	_, sha, _ := q.ReadFile(ctx, StrategyFile)
+	if err := q.WriteFile(ctx, StrategyFile, "a\nb\n", sha, "Edit strategy"); err != nil {
+		t.Fatalf("offline write: %v", err)
+	}
+
+	// Replayed into a pull request, the write leaves the queue but not the
+	// backend
+	pr.err = nil
+	if n, err := q.Sync(ctx); n != 1 || err != nil {
+		t.Fatalf("Sync() = %d, %v", n, err)
+	}
+	if status := q.Status(); len(status.Files) != 0 || status.LastError != "" {
+		t.Errorf("status after a proposed sync = %+v", status)
+	}
+	if content, _, _ := q.ReadFile(ctx, StrategyFile); content != "a\n" {
+		t.Errorf("content after a proposed sync = %q, want it unchanged", content)
+	}
+}
+
+func TestWriteQueue_Conflict(t *testing.T) {
+	ctx := context.Background()
+	backend := &flakyStorage{MemoryStorage: NewMemoryStorage(map[string]string{TodosFile: "a\n", NotesFile: "n\n"}, nil)}
+	q := NewWriteQueue(backend, "", nil)
+
+	backend.down = true
+	_, todosSHA, _ := backend.MemoryStorage.ReadFile(ctx, TodosFile)
+	_, notesSHA, _ := backend.MemoryStorage.ReadFile(ctx, NotesFile)
+	q.WriteFile(ctx, TodosFile, "local\n", todosSHA, "Edit todos")
+	q.WriteFile(ctx, NotesFile, "n2\n", notesSHA, "Edit notes")
+
+	// todos.md changes on GitHub meanwhile
+	backend.MemoryStorage.WriteFile(ctx, TodosFile, "remote\n", todosSHA, "Edit elsewhere")
+	backend.down = false
+
+	// The rest syncs; the conflicted file waits
+	if n, err := q.Sync(ctx); n != 1 || err != nil {
+		t.Fatalf("Sync() = %d, %v", n, err)
+	}
+	if content, _, _ := backend.MemoryStorage.ReadFile(ctx, NotesFile); content != "n2\n" {
+		t.Errorf("notes = %q", content)
+	}
+	status := q.Status()
+	if len(status.Files) != 1 || !status.Files[0].Conflict {
+		t.Fatalf("status = %+v", status)
+	}
+	if content, _, _ := backend.MemoryStorage.ReadFile(ctx, TodosFile); content != "remote\n" {
+		t.Errorf("conflicted file overwritten: %q", content)
+	}
+
+	// Overwriting rebases it on the repo's version
+	if err := q.Overwrite(ctx, TodosFile); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := q.Sync(ctx); n != 1 || err != nil {
+		t.Fatalf("Sync() after overwrite = %d, %v", n, err)
+	}
+	if content, _, _ := backend.MemoryStorage.ReadFile(ctx, TodosFile); content != "local\n" {
+		t.Errorf("todos after overwrite = %q", content)
+	}
+	if err := q.Discard(TodosFile); err != ErrNotFound {
+		t.Errorf("Discard(synced) = %v, want ErrNotFound", err)
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	for err, want := range map[error]bool{
+		ErrUnavailable:           true,
+		ErrRateLimited:           true,
+		ErrConflict:              false,
+		context.DeadlineExceeded: false,
+	} {
+		if got := Unavailable(err); got != want {
+			t.Errorf("Unavailable(%v) = %v", err, got)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+// DefaultReadCacheTTL is how long WithReadCache keeps a file's last read.
+const DefaultReadCacheTTL = 10 * time.Second
+
+// ReadCache keeps each file's last read for a short time, so the weekly
+// summary and the dashboard, which each read most of the data files and
+// are often asked for in the same conversation turn, share one fetch per
+// file.
+type ReadCache struct {
+	next  Storage
+	ttl   time.Duration
+	clock clock.Clock
+
+	mu    sync.Mutex
+	files map[string]cachedRead
+	// writes counts the writes through the cache, so a read that started
+	// before one doesn't keep what it read
+	writes int
+}
+
+// cachedRead is one file's last read.
+type cachedRead struct {
+	content string
+	sha     string
+	at      time.Time
+}
+
+// WithReadCache returns a Storage that serves a file's last read for ttl
+// (DefaultReadCacheTTL if zero). Writes through it drop the copies of the
+// files written, as does a conflict, which means the copy was stale;
+// changes made outside the process show up once the copy expires, or
+// straight away when reported to Invalidate. Missing files and failed
+// reads aren't kept. History passes through.
+func WithReadCache(s Storage, ttl time.Duration, c clock.Clock) *ReadCache {
+	if ttl <= 0 {
+		ttl = DefaultReadCacheTTL
+	}
+	return &ReadCache{next: s, ttl: ttl, clock: clock.Or(c), files: make(map[string]cachedRead)}
+}
+
+func (r *ReadCache) ReadFile(ctx context.Context, path string) (string, string, error) {
+	r.mu.Lock()
+	cached, ok := r.files[path]
+	writes := r.writes
+	r.mu.Unlock()
+	if ok && r.clock.Now().Sub(cached.at) < r.ttl {
+		return cached.content, cached.sha, nil
+	}
+
+	content, sha, err := r.next.ReadFile(ctx, path)
+	if err != nil {
+		return "", "", err
+	}
+	r.mu.Lock()
+	if r.writes == writes {
+		r.files[path] = cachedRead{content: content, sha: sha, at: r.clock.Now()}
+	}
+	r.mu.Unlock()
+	return content, sha, nil
+}
+
+func (r *ReadCache) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	defer r.forget(path)
+	return r.next.WriteFile(ctx, path, content, sha, message)
+}
+
+func (r *ReadCache) WriteFiles(ctx context.Context, changes []FileChange, message string) error {
+	paths := make([]string, len(changes))
+	for i, c := range changes {
+		paths[i] = c.Path
+	}
+	defer r.forget(paths...)
+	return WriteFiles(ctx, r.next, changes, message)
+}
+
+// Invalidate drops the cached copies of paths, e.g. when a push to the data
+// repo changed them.
+func (r *ReadCache) Invalidate(paths ...string) {
+	r.forget(paths...)
+}
+
+// forget drops the cached copies of paths after a write or an Invalidate.
+func (r *ReadCache) forget(paths ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writes++
+	for _, p := range paths {
+		delete(r.files, p)
+	}
+}
+
+func (r *ReadCache) ListCommits(ctx context.Context, path string, limit int) ([]Commit, error) {
+	h, ok := r.next.(History)
+	if !ok {
+		return nil, errNoHistory
+	}
+	return h.ListCommits(ctx, path, limit)
+}
+
+func (r *ReadCache) ReadFileAt(ctx context.Context, path string, ref string) (string, error) {
+	h, ok := r.next.(History)
+	if !ok {
+		return "", errNoHistory
+	}
+	return h.ReadFileAt(ctx, path, ref)
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+func TestReadCache(t *testing.T) {
+	ctx := context.Background()
+	mem := NewMemoryStorage(map[string]string{TodosFile: "one\n"}, nil)
+	fake := clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC))
+	s := WithReadCache(mem, 10*time.Second, fake)
+
+	read := func() string {
+		t.Helper()
+		content, _, err := s.ReadFile(ctx, TodosFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return content
+	}
+
+	// A change made behind the cache's back shows up once the copy expires
+	_, sha, _ := mem.ReadFile(ctx, TodosFile)
+	if read() != "one\n" {
+		t.Fatal("first read")
+	}
+	if err := mem.WriteFile(ctx, TodosFile, "two\n", sha, "Outside"); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(); got != "one\n" {
+		t.Errorf("read within the TTL = %q, want the cached copy", got)
+	}
+	fake.Advance(10 * time.Second)
+	if got := read(); got != "two\n" {
+		t.Errorf("read after the TTL = %q, want the new content", got)
+	}
+
+	// Writes through the cache are seen straight away
+	_, sha, _ = s.ReadFile(ctx, TodosFile)
+	if err := s.WriteFile(ctx, TodosFile, "three\n", sha, "Inside"); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(); got != "three\n" {
+		t.Errorf("read after a write = %q", got)
+	}
+
+	// A conflict means the copy was stale, so it's dropped
+	_, sha, _ = mem.ReadFile(ctx, TodosFile)
+	if err := mem.WriteFile(ctx, TodosFile, "four\n", sha, "Outside"); err != nil {
+		t.Fatal(err)
+	}
+	_, stale, _ := s.ReadFile(ctx, TodosFile)
+	if err := s.WriteFile(ctx, TodosFile, "five\n", stale, "Inside"); !errors.Is(err, ErrConflict) {
+		t.Fatalf("write over a stale copy = %v, want a conflict", err)
+	}
+	if got := read(); got != "four\n" {
+		t.Errorf("read after a conflict = %q", got)
+	}
+
+	// A change reported by the repo's push webhook shows up straight away
+	_, sha, _ = mem.ReadFile(ctx, TodosFile)
+	if err := mem.WriteFile(ctx, TodosFile, "six\n", sha, "Pushed"); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(); got != "four\n" {
+		t.Fatalf("read before Invalidate = %q, want the cached copy", got)
+	}
+	s.Invalidate(TodosFile)
+	if got := read(); got != "six\n" {
+		t.Errorf("read after Invalidate = %q, want the pushed content", got)
+	}
+}
+
+func TestReadFiles(t *testing.T) {
+	mem := NewMemoryStorage(map[string]string{TodosFile: "todos\n", StrategyFile: "strategy\n"}, nil)
+	results := ReadFiles(context.Background(), mem, TodosFile, StrategyFile, NotesFile)
+	if r := results[TodosFile]; r.Err != nil || r.Content != "todos\n" || r.SHA == "" {
+		t.Errorf("todos = %+v", r)
+	}
+	if r := results[StrategyFile]; r.Err != nil || r.Content != "strategy\n" {
+		t.Errorf("strategy = %+v", r)
+	}
+	if r := results[NotesFile]; !errors.Is(r.Err, ErrNotFound) {
+		t.Errorf("missing file error = %v", r.Err)
+	}
+}
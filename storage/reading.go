@@ -0,0 +1,221 @@
+package storage
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReadingItem represents a reading list entry.
+type ReadingItem struct {
+	ID       string
+	URL      string
+	Notes    string
+	Read     bool
+	Added    time.Time
+	ReadAt   *time.Time
+	Priority string // ReadingPriorityNext, ReadingPrioritySomeday, or "" for neither
+	Category string // topic slug, see NormalizeProject
+	Minutes  int    // estimated reading time; 0 if unknown
+}
+
+// Reading list priorities. Items without one rank between the two.
+const (
+	ReadingPriorityNext    = "next"
+	ReadingPrioritySomeday = "someday"
+)
+
+// ReadingList represents the parsed contents of reading-list.md.
+type ReadingList struct {
+	ToRead []ReadingItem
+	Read   []ReadingItem
+	Extra  []ExtraBlock
+	Raw    string
+}
+
+// Patterns for the fields of a reading list line.
+var (
+	// Matches: — Added: 2026-02-01
+	addedPattern = regexp.MustCompile(`—\s*Added:\s*(\d{4}-\d{2}-\d{2})`)
+	// Matches: — Read: 2026-02-01
+	readPattern = regexp.MustCompile(`—\s*Read:\s*(\d{4}-\d{2}-\d{2})`)
+	// Matches: — Notes: some text
+	notesPattern = regexp.MustCompile(`—\s*Notes:\s*(.+)$`)
+)
+
+// ParseReadingList parses a reading-list.md file content.
+func ParseReadingList(content string) (*ReadingList, error) {
+	rl := &ReadingList{Raw: content}
+	lines := strings.Split(content, "\n")
+
+	var currentSection string
+	var extra extraCollector
+	inExtra := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "## ") {
+			heading := strings.TrimPrefix(trimmed, "## ")
+			switch {
+			case strings.Contains(heading, "To Read"):
+				currentSection = "toread"
+			case heading == "Read":
+				currentSection = "read"
+			default:
+				extra.start(currentSection, line)
+				inExtra = true
+				continue
+			}
+			extra.stop()
+			inExtra = false
+			continue
+		}
+
+		if inExtra {
+			extra.add(currentSection, line)
+			continue
+		}
+
+		if matches := checkboxPattern.FindStringSubmatch(trimmed); matches != nil {
+			extra.stop()
+			item := parseReadingLine(matches[1], matches[2])
+			if currentSection == "read" || item.Read {
+				rl.Read = append(rl.Read, item)
+			} else {
+				rl.ToRead = append(rl.ToRead, item)
+			}
+			continue
+		}
+
+		if !strings.HasPrefix(trimmed, "# ") {
+			extra.add(currentSection, line)
+		}
+	}
+
+	rl.Extra = extra.result()
+	return rl, nil
+}
+
+func parseReadingLine(checkbox, rest string) ReadingItem {
+	item := ReadingItem{
+		Read: checkbox == "x" || checkbox == "X",
+	}
+
+	// Extract metadata block first (if present)
+	if matches := metadataPattern.FindStringSubmatch(rest); matches != nil {
+		rest = strings.TrimSpace(metadataPattern.ReplaceAllString(rest, ""))
+		parseMetadata(matches[1], &item.ID, &item.Added, nil)
+		item.Priority = metadataValue(matches[1], "priority")
+		item.Category = metadataValue(matches[1], "category")
+		item.Minutes, _ = strconv.Atoi(metadataValue(matches[1], "minutes"))
+	}
+
+	// Split by — delimiter
+	parts := strings.Split(rest, "—")
+	if len(parts) > 0 {
+		item.URL = strings.TrimSpace(parts[0])
+	}
+
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if matches := addedPattern.FindStringSubmatch("— " + part); matches != nil {
+			if t, err := time.Parse(dateFormat, matches[1]); err == nil {
+				item.Added = t
+			}
+		} else if matches := readPattern.FindStringSubmatch("— " + part); matches != nil {
+			if t, err := time.Parse(dateFormat, matches[1]); err == nil {
+				item.ReadAt = &t
+			}
+		} else if matches := notesPattern.FindStringSubmatch("— " + part); matches != nil {
+			item.Notes = matches[1]
+		} else if strings.HasPrefix(part, "Notes:") {
+			item.Notes = strings.TrimSpace(strings.TrimPrefix(part, "Notes:"))
+		} else if strings.HasPrefix(part, "Added:") {
+			if t, err := time.Parse(dateFormat, strings.TrimSpace(strings.TrimPrefix(part, "Added:"))); err == nil {
+				item.Added = t
+			}
+		} else if strings.HasPrefix(part, "Read:") {
+			if t, err := time.Parse(dateFormat, strings.TrimSpace(strings.TrimPrefix(part, "Read:"))); err == nil {
+				item.ReadAt = &t
+			}
+		}
+	}
+
+	// Generate ID if not present
+	if item.ID == "" {
+		item.ID = NewID(PrefixReading, nil)
+	}
+
+	return item
+}
+
+// SerializeReadingList converts a ReadingList back to markdown.
+func SerializeReadingList(rl *ReadingList) string {
+	var b strings.Builder
+
+	b.WriteString("# Reading List\n\n")
+	writeExtras(&b, rl.Extra, "")
+	b.WriteString("## To Read\n")
+	for _, item := range rl.ToRead {
+		b.WriteString(formatReadingLine(item, false))
+	}
+	b.WriteString("\n")
+	writeExtras(&b, rl.Extra, "toread")
+
+	b.WriteString("## Read\n")
+	for _, item := range rl.Read {
+		b.WriteString(formatReadingLine(item, true))
+	}
+	writeTrailingExtras(&b, rl.Extra, "read")
+
+	return b.String()
+}
+
+// SerializeReadingArchive converts the items archived for year to markdown.
+// ParseReadingList reads it back, with the items in Read.
+func SerializeReadingArchive(year int, items []ReadingItem) string {
+	var b strings.Builder
+	b.WriteString("# Reading Archive " + strconv.Itoa(year) + "\n\n## Read\n")
+	for _, item := range items {
+		b.WriteString(formatReadingLine(item, true))
+	}
+	return b.String()
+}
+
+func formatReadingLine(item ReadingItem, isRead bool) string {
+	checkbox := "[ ]"
+	if item.Read {
+		checkbox = "[x]"
+	}
+
+	line := "- " + checkbox + " " + item.URL
+
+	if isRead && item.ReadAt != nil {
+		line += " — Read: " + item.ReadAt.Format(dateFormat)
+	} else if !item.Added.IsZero() {
+		line += " — Added: " + item.Added.Format(dateFormat)
+	}
+
+	if item.Notes != "" {
+		line += " — Notes: " + item.Notes
+	}
+
+	// Append metadata block with ID, priority, category and reading time
+	meta := formatMetadata(item.ID, "", time.Time{}, nil, false)
+	if item.Priority != "" {
+		meta = appendMetadata(meta, "priority", item.Priority)
+	}
+	if item.Category != "" {
+		meta = appendMetadata(meta, "category", item.Category)
+	}
+	if item.Minutes > 0 {
+		meta = appendMetadata(meta, "minutes", strconv.Itoa(item.Minutes))
+	}
+	if meta != "" {
+		line += " " + meta
+	}
+
+	return line + "\n"
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseReadingList(t *testing.T) {
+	input := `# Reading List
+
+## To Read
+- [ ] https://example.com/mcp-article — Added: 2026-02-01
+- [ ] https://example.com/go-patterns — Added: 2026-01-28 — Notes: Recommended by colleague
+
+## Read
+- [x] https://example.com/other-article — Read: 2026-01-25 — Notes: Good overview of deployment patterns
+`
+
+	rl, err := ParseReadingList(input)
+	if err != nil {
+		t.Fatalf("ParseReadingList failed: %v", err)
+	}
+
+	if len(rl.ToRead) != 2 {
+		t.Errorf("expected 2 to-read items, got %d", len(rl.ToRead))
+	}
+
+	if len(rl.Read) != 1 {
+		t.Errorf("expected 1 read item, got %d", len(rl.Read))
+	}
+
+	// Check URL parsing
+	if rl.ToRead[0].URL != "https://example.com/mcp-article" {
+		t.Errorf("expected URL 'https://example.com/mcp-article', got %q", rl.ToRead[0].URL)
+	}
+
+	// Check notes parsing
+	if rl.ToRead[1].Notes != "Recommended by colleague" {
+		t.Errorf("expected notes 'Recommended by colleague', got %q", rl.ToRead[1].Notes)
+	}
+}
+
+func TestSerializeReadingList_RoundTrip(t *testing.T) {
+	input := `# Reading List
+
+## To Read
+- [ ] https://example.com/article1 — Added: 2026-02-01 — Notes: Test note
+
+## Read
+- [x] https://example.com/article2 — Read: 2026-01-25 — Notes: Finished
+`
+
+	rl, err := ParseReadingList(input)
+	if err != nil {
+		t.Fatalf("ParseReadingList failed: %v", err)
+	}
+
+	output := SerializeReadingList(rl)
+
+	rl2, err := ParseReadingList(output)
+	if err != nil {
+		t.Fatalf("Second ParseReadingList failed: %v", err)
+	}
+
+	if len(rl.ToRead) != len(rl2.ToRead) {
+		t.Errorf("to-read count mismatch: %d vs %d", len(rl.ToRead), len(rl2.ToRead))
+	}
+	if len(rl.Read) != len(rl2.Read) {
+		t.Errorf("read count mismatch: %d vs %d", len(rl.Read), len(rl2.Read))
+	}
+}
+
+func TestSerializeReadingArchive_RoundTrip(t *testing.T) {
+	readAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	items := []ReadingItem{{ID: "cccc3333", URL: "https://example.com/go", Notes: "generics", Read: true, ReadAt: &readAt, Category: "go"}}
+
+	output := SerializeReadingArchive(2025, items)
+	if !strings.HasPrefix(output, "# Reading Archive 2025\n") {
+		t.Errorf("unexpected heading:\n%s", output)
+	}
+	rl, err := ParseReadingList(output)
+	if err != nil {
+		t.Fatalf("ParseReadingList failed: %v", err)
+	}
+	if len(rl.ToRead) != 0 || len(rl.Read) != 1 {
+		t.Fatalf("unexpected items %+v", rl)
+	}
+	got := rl.Read[0]
+	if got.ID != "cccc3333" || got.Notes != "generics" || got.Category != "go" || got.ReadAt == nil || !got.ReadAt.Equal(readAt) {
+		t.Errorf("archived item = %+v", got)
+	}
+}
+
+func TestReadingPriorityCategory_RoundTrip(t *testing.T) {
+	input := "# Reading List\n\n## To Read\n- [ ] https://a.example — Added: 2026-02-01 {id:aaaa1111,priority:next,category:go}\n- [ ] https://b.example {id:bbbb2222,category:rust,minutes:12}\n\n## Read\n"
+	rl, err := ParseReadingList(input)
+	if err != nil {
+		t.Fatalf("ParseReadingList failed: %v", err)
+	}
+	if a := rl.ToRead[0]; a.Priority != ReadingPriorityNext || a.Category != "go" || a.URL != "https://a.example" {
+		t.Errorf("unexpected item %+v", a)
+	}
+	if b := rl.ToRead[1]; b.Priority != "" || b.Category != "rust" || b.Minutes != 12 {
+		t.Errorf("unexpected item %+v", b)
+	}
+	if out := SerializeReadingList(rl); out != input {
+		t.Errorf("priority and category not preserved:\n%s", out)
+	}
+}
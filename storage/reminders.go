@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Reminder represents a reminder entry.
+type Reminder struct {
+	ID          string
+	Date        time.Time
+	Text        string
+	Completed   bool
+	Added       time.Time
+	CompletedAt *time.Time
+}
+
+// ReminderFile represents the parsed contents of reminders.md.
+type ReminderFile struct {
+	Upcoming  []Reminder
+	Completed []Reminder
+	Extra     []ExtraBlock
+	Raw       string
+}
+
+// reminderLinePattern matches a reminder line: - 2026-02-10: Description {metadata}
+var reminderLinePattern = regexp.MustCompile(`^-\s*(\d{4}-\d{2}-\d{2}):\s*(.+)$`)
+
+// ParseReminders parses a reminders.md file content.
+func ParseReminders(content string) (*ReminderFile, error) {
+	rf := &ReminderFile{Raw: content}
+	lines := strings.Split(content, "\n")
+
+	var currentSection string
+	var extra extraCollector
+	inExtra := false
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "## ") {
+			heading := strings.TrimPrefix(trimmed, "## ")
+			switch {
+			case strings.Contains(heading, "Upcoming"):
+				currentSection = "upcoming"
+			case strings.Contains(heading, "Completed"):
+				currentSection = "completed"
+			default:
+				extra.start(currentSection, line)
+				inExtra = true
+				continue
+			}
+			extra.stop()
+			inExtra = false
+			continue
+		}
+
+		if inExtra {
+			extra.add(currentSection, line)
+			continue
+		}
+
+		if matches := reminderLinePattern.FindStringSubmatch(trimmed); matches != nil {
+			extra.stop()
+			reminder := parseReminderLine(matches[1], matches[2])
+			if currentSection == "completed" {
+				reminder.Completed = true
+				rf.Completed = append(rf.Completed, reminder)
+			} else {
+				rf.Upcoming = append(rf.Upcoming, reminder)
+			}
+			continue
+		}
+
+		if !strings.HasPrefix(trimmed, "# ") {
+			extra.add(currentSection, line)
+		}
+	}
+
+	rf.Extra = extra.result()
+	return rf, nil
+}
+
+func parseReminderLine(dateStr, rest string) Reminder {
+	r := Reminder{}
+
+	if t, err := time.Parse(dateFormat, dateStr); err == nil {
+		r.Date = t
+	}
+
+	text := rest
+	if matches := metadataPattern.FindStringSubmatch(rest); matches != nil {
+		text = strings.TrimSpace(metadataPattern.ReplaceAllString(rest, ""))
+		parseMetadata(matches[1], &r.ID, &r.Added, &r.CompletedAt)
+	}
+
+	// Generate ID if not present in metadata
+	if r.ID == "" {
+		r.ID = NewID(PrefixReminder, nil)
+	}
+
+	r.Text = text
+	return r
+}
+
+// SerializeReminders converts a ReminderFile back to markdown.
+func SerializeReminders(rf *ReminderFile) string {
+	var b strings.Builder
+
+	b.WriteString("# Reminders\n\n")
+	writeExtras(&b, rf.Extra, "")
+	b.WriteString("## Upcoming\n")
+	for _, r := range rf.Upcoming {
+		b.WriteString(formatReminderLine(r, false))
+	}
+	b.WriteString("\n")
+	writeExtras(&b, rf.Extra, "upcoming")
+
+	b.WriteString("## Completed\n")
+	for _, r := range rf.Completed {
+		b.WriteString(formatReminderLine(r, true))
+	}
+	writeTrailingExtras(&b, rf.Extra, "completed")
+
+	return b.String()
+}
+
+func formatReminderLine(r Reminder, includeCompleted bool) string {
+	line := "- " + r.Date.Format(dateFormat) + ": " + r.Text
+
+	meta := formatMetadata(r.ID, "", r.Added, r.CompletedAt, includeCompleted)
+	if meta != "" {
+		line += " " + meta
+	}
+
+	return line + "\n"
+}
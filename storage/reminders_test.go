@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseReminders(t *testing.T) {
+	input := `# Reminders
+
+## Upcoming
+- 2026-02-10: Follow up on LinkedIn connection requests {added:2026-02-03}
+- 2026-02-15: Review week 3 progress {added:2026-02-01}
+
+## Completed
+- 2026-02-01: Check site meta tags {added:2026-01-25,completed:2026-02-01}
+`
+
+	rf, err := ParseReminders(input)
+	if err != nil {
+		t.Fatalf("ParseReminders failed: %v", err)
+	}
+
+	if len(rf.Upcoming) != 2 {
+		t.Errorf("expected 2 upcoming reminders, got %d", len(rf.Upcoming))
+	}
+
+	if len(rf.Completed) != 1 {
+		t.Errorf("expected 1 completed reminder, got %d", len(rf.Completed))
+	}
+
+	// Check date parsing
+	expectedDate := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	if !rf.Upcoming[0].Date.Equal(expectedDate) {
+		t.Errorf("expected date %v, got %v", expectedDate, rf.Upcoming[0].Date)
+	}
+
+	// Check text extraction
+	if rf.Upcoming[0].Text != "Follow up on LinkedIn connection requests" {
+		t.Errorf("expected text 'Follow up on LinkedIn connection requests', got %q", rf.Upcoming[0].Text)
+	}
+}
+
+func TestSerializeReminders_RoundTrip(t *testing.T) {
+	input := `# Reminders
+
+## Upcoming
+- 2026-02-10: Test reminder {added:2026-02-03}
+
+## Completed
+- 2026-02-01: Done reminder {added:2026-01-25,completed:2026-02-01}
+`
+
+	rf, err := ParseReminders(input)
+	if err != nil {
+		t.Fatalf("ParseReminders failed: %v", err)
+	}
+
+	output := SerializeReminders(rf)
+
+	rf2, err := ParseReminders(output)
+	if err != nil {
+		t.Fatalf("Second ParseReminders failed: %v", err)
+	}
+
+	if len(rf.Upcoming) != len(rf2.Upcoming) {
+		t.Errorf("upcoming count mismatch: %d vs %d", len(rf.Upcoming), len(rf2.Upcoming))
+	}
+	if len(rf.Completed) != len(rf2.Completed) {
+		t.Errorf("completed count mismatch: %d vs %d", len(rf.Completed), len(rf2.Completed))
+	}
+}
@@ -0,0 +1,84 @@
+package storage
+
+import "strings"
+
+// ExtraBlock is a run of markdown the parser doesn't model, such as a
+// hand-written "## Ideas" section, kept verbatim so serializing the file
+// doesn't destroy it.
+type ExtraBlock struct {
+	// After names the known section the block followed in the source (e.g.
+	// "notes"), or "" if it came before all of them. The serializer writes
+	// the block back at the same point.
+	After string
+	// Text is the block's lines, heading included, without trailing blank lines.
+	Text string
+}
+
+// extraCollector gathers ExtraBlocks while a parser walks a file.
+type extraCollector struct {
+	blocks []ExtraBlock
+	lines  []string
+	after  string
+	open   bool
+}
+
+// start begins a new block with the given heading line, following the
+// known section after.
+func (c *extraCollector) start(after, heading string) {
+	c.stop()
+	c.after = after
+	c.lines = []string{heading}
+	c.open = true
+}
+
+// add appends line to the open block, or starts a headingless block (text
+// outside any section) if none is open and line isn't blank.
+func (c *extraCollector) add(after, line string) {
+	if !c.open {
+		if strings.TrimSpace(line) == "" {
+			return
+		}
+		c.after = after
+		c.lines = nil
+		c.open = true
+	}
+	c.lines = append(c.lines, line)
+}
+
+// stop closes the open block, if any.
+func (c *extraCollector) stop() {
+	if !c.open {
+		return
+	}
+	c.open = false
+	text := strings.TrimRight(strings.Join(c.lines, "\n"), " \t\n")
+	if strings.TrimSpace(text) != "" {
+		c.blocks = append(c.blocks, ExtraBlock{After: c.after, Text: text})
+	}
+}
+
+// result closes the open block and returns everything collected.
+func (c *extraCollector) result() []ExtraBlock {
+	c.stop()
+	return c.blocks
+}
+
+// writeExtras writes the blocks that followed the known section after,
+// each followed by a blank line.
+func writeExtras(b *strings.Builder, extras []ExtraBlock, after string) {
+	for _, e := range extras {
+		if e.After == after {
+			b.WriteString(e.Text + "\n\n")
+		}
+	}
+}
+
+// writeTrailingExtras writes the blocks that followed the last section of a
+// file, which has no blank line after it, so each is preceded by one.
+func writeTrailingExtras(b *strings.Builder, extras []ExtraBlock, after string) {
+	for _, e := range extras {
+		if e.After == after {
+			b.WriteString("\n" + e.Text + "\n")
+		}
+	}
+}
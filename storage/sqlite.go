@@ -0,0 +1,323 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema creates the tables SQLiteStorage uses. A file is waiting to
+// be exported while its sha differs from exported_sha; changes keeps the
+// messages of the writes since the last export, for its commit message.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS files (
+	path         TEXT PRIMARY KEY,
+	content      TEXT NOT NULL,
+	sha          TEXT NOT NULL,
+	exported_sha TEXT NOT NULL DEFAULT '',
+	updated_at   TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS changes (
+	id      INTEGER PRIMARY KEY AUTOINCREMENT,
+	message TEXT NOT NULL
+);`
+
+// SQLiteStorage keeps the data files in a local SQLite database, so reads
+// and writes don't wait on the GitHub API. SHAs are git blob SHAs of the
+// content and writes are checked against them, as in the repo. Files it
+// doesn't have yet are read from the seed storage (the repo) on first use.
+// Export pushes the files changed since the last export to the repo, so the
+// markdown stays readable there. It doesn't implement History.
+type SQLiteStorage struct {
+	db   *sql.DB
+	seed Storage
+	now  func() time.Time
+
+	mu      sync.Mutex
+	missing map[string]bool // paths the seed didn't have
+}
+
+// NewSQLiteStorage opens (creating if needed) the database at path. seed,
+// if not nil, supplies files the database doesn't have.
+func NewSQLiteStorage(path string, seed Storage) (*SQLiteStorage, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	// One connection: writes are serialized anyway, and it keeps
+	// transactions from waiting on each other's locks
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating tables in %s: %w", path, err)
+	}
+	return &SQLiteStorage{
+		db:      db,
+		seed:    seed,
+		now:     time.Now,
+		missing: make(map[string]bool),
+	}, nil
+}
+
+// Close closes the database.
+func (s *SQLiteStorage) Close() error {
+	return s.db.Close()
+}
+
+// ReadFile reads path from the database, or the seed on first use.
+func (s *SQLiteStorage) ReadFile(ctx context.Context, path string) (string, string, error) {
+	var content, sha string
+	err := s.db.QueryRowContext(ctx, `SELECT content, sha FROM files WHERE path = ?`, path).Scan(&content, &sha)
+	if err == nil {
+		return content, sha, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	return s.readSeed(ctx, path)
+}
+
+// readSeed copies path from the seed into the database, as already
+// exported.
+func (s *SQLiteStorage) readSeed(ctx context.Context, path string) (string, string, error) {
+	s.mu.Lock()
+	missing := s.missing[path]
+	s.mu.Unlock()
+	if s.seed == nil || missing {
+		return "", "", ErrNotFound
+	}
+
+	content, _, err := s.seed.ReadFile(ctx, path)
+	if errors.Is(err, ErrNotFound) {
+		s.mu.Lock()
+		s.missing[path] = true
+		s.mu.Unlock()
+		return "", "", ErrNotFound
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	sha := blobSHA(content)
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO files (path, content, sha, exported_sha, updated_at) VALUES (?, ?, ?, ?, ?)`,
+		path, content, sha, sha, s.now().UTC().Format(time.RFC3339)); err != nil {
+		return "", "", fmt.Errorf("saving %s: %w", path, err)
+	}
+	// A write may have got in first
+	return s.ReadFile(ctx, path)
+}
+
+// WriteFile writes path if its SHA is still sha ("" for a new file).
+func (s *SQLiteStorage) WriteFile(ctx context.Context, path string, content string, sha string, message string) error {
+	return s.WriteFiles(ctx, []FileChange{{Path: path, Content: content, SHA: sha}}, message)
+}
+
+// WriteFiles writes every change in one transaction, or none if any file's
+// SHA has changed.
+func (s *SQLiteStorage) WriteFiles(ctx context.Context, changes []FileChange, message string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := s.now().UTC().Format(time.RFC3339)
+	for _, c := range changes {
+		var current string
+		err := tx.QueryRowContext(ctx, `SELECT sha FROM files WHERE path = ?`, c.Path).Scan(&current)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("reading %s: %w", c.Path, err)
+		}
+		if current != c.SHA {
+			return ErrConflict
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO files (path, content, sha, updated_at) VALUES (?, ?, ?, ?)
+			ON CONFLICT (path) DO UPDATE SET content = excluded.content, sha = excluded.sha, updated_at = excluded.updated_at`,
+			c.Path, c.Content, blobSHA(c.Content), now); err != nil {
+			return fmt.Errorf("writing %s: %w", c.Path, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO changes (message) VALUES (?)`, message); err != nil {
+		return fmt.Errorf("recording change: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing: %w", err)
+	}
+
+	s.mu.Lock()
+	for _, c := range changes {
+		delete(s.missing, c.Path)
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// Export writes the files changed since the last export to remote, in one
+// commit listing the changes, and returns how many it wrote. Whatever
+// remote has is replaced: the database is the source of truth.
+func (s *SQLiteStorage) Export(ctx context.Context, remote Storage) (int, error) {
+	// Messages first: a write landing between the two queries is exported
+	// now and described next time, rather than described and left behind
+	var lastChange int64
+	var messages []string
+	rows, err := s.db.QueryContext(ctx, `SELECT id, message FROM changes ORDER BY id`)
+	if err != nil {
+		return 0, fmt.Errorf("listing changes: %w", err)
+	}
+	for rows.Next() {
+		var m string
+		if err := rows.Scan(&lastChange, &m); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("listing changes: %w", err)
+		}
+		messages = append(messages, m)
+	}
+	rows.Close()
+
+	rows, err = s.db.QueryContext(ctx, `SELECT path, content, sha FROM files WHERE sha != exported_sha ORDER BY path`)
+	if err != nil {
+		return 0, fmt.Errorf("listing changed files: %w", err)
+	}
+	var changes []FileChange
+	var shas []string
+	for rows.Next() {
+		var c FileChange
+		var sha string
+		if err := rows.Scan(&c.Path, &c.Content, &sha); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("listing changed files: %w", err)
+		}
+		changes = append(changes, c)
+		shas = append(shas, sha)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("listing changed files: %w", err)
+	}
+	if len(changes) == 0 {
+		return 0, nil
+	}
+
+	// Base each write on the remote's current version
+	for i, c := range changes {
+		_, sha, err := remote.ReadFile(ctx, c.Path)
+		if errors.Is(err, ErrNotFound) {
+			sha, err = "", nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("reading %s: %w", c.Path, err)
+		}
+		changes[i].SHA = sha
+	}
+	if err := WriteFiles(ctx, remote, changes, exportMessage(messages)); err != nil {
+		return 0, err
+	}
+
+	// Files written to since they were read above stay pending
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+	for i, c := range changes {
+		if _, err := tx.ExecContext(ctx, `UPDATE files SET exported_sha = ? WHERE path = ?`, shas[i], c.Path); err != nil {
+			return 0, fmt.Errorf("marking %s exported: %w", c.Path, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM changes WHERE id <= ?`, lastChange); err != nil {
+		return 0, fmt.Errorf("clearing changes: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing: %w", err)
+	}
+	return len(changes), nil
+}
+
+// exportMessage is the commit message for an export.
+func exportMessage(messages []string) string {
+	switch len(messages) {
+	case 0:
+		return "Export data files"
+	case 1:
+		return messages[0]
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Export %d changes\n", len(messages))
+	for _, m := range messages {
+		b.WriteString("\n- " + m)
+	}
+	return b.String()
+}
+
+// DefaultExportInterval is how often SQLiteExporter exports by default.
+const DefaultExportInterval = 5 * time.Minute
+
+// SQLiteExporter exports a SQLiteStorage to the repo in the background.
+type SQLiteExporter struct {
+	local    *SQLiteStorage
+	remote   Storage
+	interval time.Duration
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// NewSQLiteExporter creates an exporter from local to remote every
+// interval (DefaultExportInterval if not positive).
+func NewSQLiteExporter(local *SQLiteStorage, remote Storage, interval time.Duration) *SQLiteExporter {
+	if interval <= 0 {
+		interval = DefaultExportInterval
+	}
+	return &SQLiteExporter{
+		local:    local,
+		remote:   remote,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+}
+
+// Start begins exporting in the background.
+func (e *SQLiteExporter) Start() {
+	go e.loop()
+}
+
+// Stop ends the loop after a last export, so changes made just before
+// shutdown reach the repo.
+func (e *SQLiteExporter) Stop() {
+	close(e.stopCh)
+	<-e.doneCh
+}
+
+func (e *SQLiteExporter) loop() {
+	defer close(e.doneCh)
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.export()
+		case <-e.stopCh:
+			e.export()
+			return
+		}
+	}
+}
+
+func (e *SQLiteExporter) export() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if n, err := e.local.Export(ctx, e.remote); err != nil {
+		slog.Warn("exporting data files to the repo failed", "error", err)
+	} else if n > 0 {
+		slog.Info("exported data files to the repo", "files", n)
+	}
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSQLiteStorage_ReadWrite(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "momentum.db")
+	s, err := NewSQLiteStorage(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := s.ReadFile(ctx, TodosFile); err != ErrNotFound {
+		t.Fatalf("read missing = %v, want ErrNotFound", err)
+	}
+	if err := s.WriteFile(ctx, TodosFile, "a\n", "", "Create todos"); err != nil {
+		t.Fatal(err)
+	}
+	content, sha, err := s.ReadFile(ctx, TodosFile)
+	if err != nil || content != "a\n" || sha != blobSHA("a\n") {
+		t.Fatalf("read = %q, %q, %v", content, sha, err)
+	}
+	if err := s.WriteFile(ctx, TodosFile, "b\n", "", "Create again"); err != ErrConflict {
+		t.Errorf("create existing = %v, want ErrConflict", err)
+	}
+
+	// A batch with one stale file writes nothing
+	err = s.WriteFiles(ctx, []FileChange{
+		{Path: NotesFile, Content: "n\n"},
+		{Path: TodosFile, Content: "c\n", SHA: "stale"},
+	}, "Batch")
+	if err != ErrConflict {
+		t.Errorf("stale batch = %v, want ErrConflict", err)
+	}
+	if _, _, err := s.ReadFile(ctx, NotesFile); err != ErrNotFound {
+		t.Errorf("notes after failed batch: %v", err)
+	}
+
+	// Reopening keeps the data
+	s.Close()
+	s, err = NewSQLiteStorage(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if content, _, _ := s.ReadFile(ctx, TodosFile); content != "a\n" {
+		t.Errorf("reopened content = %q", content)
+	}
+}
+
+func TestSQLiteStorage_SeedAndExport(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryStorage(map[string]string{TodosFile: "from repo\n"}, nil)
+	s, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "momentum.db"), repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	// Seeded files aren't exported back
+	content, sha, err := s.ReadFile(ctx, TodosFile)
+	if err != nil || content != "from repo\n" {
+		t.Fatalf("seeded read = %q, %v", content, err)
+	}
+	if n, err := s.Export(ctx, repo); n != 0 || err != nil {
+		t.Fatalf("export of seed only = %d, %v", n, err)
+	}
+
+	if err := s.WriteFile(ctx, TodosFile, content+"b\n", sha, "Add b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.WriteFile(ctx, NotesFile, "n\n", "", "Add note"); err != nil {
+		t.Fatal(err)
+	}
+	// The repo changing meanwhile doesn't stop the export: the database wins
+	_, repoSHA, _ := repo.ReadFile(ctx, TodosFile)
+	if err := repo.WriteFile(ctx, TodosFile, "edited on GitHub\n", repoSHA, "Edit"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := s.Export(ctx, repo); n != 2 || err != nil {
+		t.Fatalf("export = %d, %v, want 2 files", n, err)
+	}
+	if got, _, _ := repo.ReadFile(ctx, TodosFile); got != "from repo\nb\n" {
+		t.Errorf("exported todos = %q", got)
+	}
+	if got, _, _ := repo.ReadFile(ctx, NotesFile); got != "n\n" {
+		t.Errorf("exported notes = %q", got)
+	}
+	commits, err := repo.ListCommits(ctx, NotesFile, 1)
+	if err != nil || len(commits) != 1 {
+		t.Fatalf("commits = %v, %v", commits, err)
+	}
+	if msg := commits[0].Message; !strings.Contains(msg, "Add b") || !strings.Contains(msg, "Add note") {
+		t.Errorf("export message = %q, want both changes", msg)
+	}
+
+	if n, err := s.Export(ctx, repo); n != 0 || err != nil {
+		t.Errorf("second export = %d, %v, want nothing", n, err)
+	}
+}
@@ -0,0 +1,295 @@
+package storage
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Milestone represents a strategy milestone.
+type Milestone struct {
+	ID          string
+	Text        string
+	Due         *time.Time
+	Project     string // project slug, see projects.md; empty if none
+	Issue       int    // mirrored GitHub issue number; 0 if not synced
+	Completed   bool
+	Added       time.Time
+	CompletedAt *time.Time
+	// KeyResults are the measurable results indented under the milestone.
+	KeyResults []KeyResult
+	// Comments are the remarks indented under the milestone, oldest first.
+	Comments []Comment
+}
+
+// Phase is a strategy phase in the history kept under "## Phases".
+type Phase struct {
+	Name       string
+	Started    time.Time  // zero if unknown
+	Ended      *time.Time // nil for the current phase
+	Objectives []string
+}
+
+// Strategy represents the parsed contents of strategy.md.
+type Strategy struct {
+	CurrentPhase string
+	// Phases is the phase history, oldest first; the last is the current
+	// phase unless it has ended. Files from before phases were tracked
+	// have none.
+	Phases              []Phase
+	ActiveMilestones    []Milestone
+	CompletedMilestones []Milestone
+	Notes               []string
+	Extra               []ExtraBlock
+	Raw                 string
+}
+
+// duePattern matches a milestone's due date: — Due: 2026-02-15
+var duePattern = regexp.MustCompile(`—\s*Due:\s*(\d{4}-\d{2}-\d{2})`)
+
+// ParseStrategy parses a strategy.md file content.
+func ParseStrategy(content string) (*Strategy, error) {
+	s := &Strategy{Raw: content}
+	lines := strings.Split(content, "\n")
+
+	// currentSection is the last recognized "## " heading, or "extra" inside
+	// an unrecognized one, whose lines are kept verbatim
+	var currentSection string
+	var known string
+	var extra extraCollector
+	// parent is the list whose last milestone indented comments belong to
+	var parent *[]Milestone
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			parent = nil
+		}
+
+		if strings.HasPrefix(trimmed, "## ") {
+			heading := strings.TrimPrefix(trimmed, "## ")
+			switch {
+			case strings.Contains(heading, "Current Phase"):
+				currentSection = "phase"
+			case strings.TrimSpace(heading) == "Phases":
+				currentSection = "phases"
+			case strings.Contains(heading, "Active"):
+				currentSection = "active"
+			case strings.Contains(heading, "Completed"):
+				currentSection = "completed"
+			case strings.Contains(heading, "Notes"):
+				currentSection = "notes"
+			default:
+				extra.start(known, line)
+				currentSection = "extra"
+				continue
+			}
+			known = currentSection
+			extra.stop()
+			continue
+		}
+
+		if currentSection == "extra" {
+			extra.add(known, line)
+			continue
+		}
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "# ") {
+			continue
+		}
+
+		if parent != nil {
+			m := &(*parent)[len(*parent)-1]
+			if c, ok := parseCommentLine(line); ok {
+				m.Comments = append(m.Comments, c)
+				continue
+			}
+			if k, ok := parseKeyResultLine(line); ok {
+				m.KeyResults = append(m.KeyResults, k)
+				continue
+			}
+			parent = nil
+		}
+
+		switch currentSection {
+		case "phase":
+			if s.CurrentPhase == "" {
+				s.CurrentPhase = trimmed
+				continue
+			}
+		case "phases":
+			if !strings.HasPrefix(trimmed, "- ") {
+				break
+			}
+			extra.stop()
+			if indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t"); indented && len(s.Phases) > 0 {
+				p := &s.Phases[len(s.Phases)-1]
+				p.Objectives = append(p.Objectives, strings.TrimPrefix(trimmed, "- "))
+			} else {
+				s.Phases = append(s.Phases, parsePhaseLine(strings.TrimPrefix(trimmed, "- ")))
+			}
+			continue
+		case "active", "completed":
+			if matches := checkboxPattern.FindStringSubmatch(trimmed); matches != nil {
+				extra.stop()
+				milestone := parseMilestoneLine(matches[1], matches[2], lines, i)
+				if currentSection == "active" {
+					s.ActiveMilestones = append(s.ActiveMilestones, milestone)
+					parent = &s.ActiveMilestones
+				} else {
+					s.CompletedMilestones = append(s.CompletedMilestones, milestone)
+					parent = &s.CompletedMilestones
+				}
+				continue
+			}
+		case "notes":
+			if strings.HasPrefix(trimmed, "- ") {
+				extra.stop()
+				s.Notes = append(s.Notes, strings.TrimPrefix(trimmed, "- "))
+				continue
+			}
+		}
+		extra.add(known, line)
+	}
+
+	s.Extra = extra.result()
+	return s, nil
+}
+
+// parsePhaseLine parses a phase history line (after "- "):
+// Phase 1: Foundations {started:2026-01-05,ended:2026-02-02}
+func parsePhaseLine(rest string) Phase {
+	p := Phase{Name: strings.TrimSpace(rest)}
+	if matches := metadataPattern.FindStringSubmatch(rest); matches != nil {
+		p.Name = strings.TrimSpace(metadataPattern.ReplaceAllString(rest, ""))
+		if t, err := time.Parse(dateFormat, metadataValue(matches[1], "started")); err == nil {
+			p.Started = t
+		}
+		if t, err := time.Parse(dateFormat, metadataValue(matches[1], "ended")); err == nil {
+			p.Ended = &t
+		}
+	}
+	return p
+}
+
+// formatPhaseLines formats a phase history entry and its objectives.
+func formatPhaseLines(p Phase) string {
+	meta := ""
+	if !p.Started.IsZero() {
+		meta = appendMetadata(meta, "started", p.Started.Format(dateFormat))
+	}
+	if p.Ended != nil {
+		meta = appendMetadata(meta, "ended", p.Ended.Format(dateFormat))
+	}
+	line := "- " + p.Name
+	if meta != "" {
+		line += " " + meta
+	}
+	line += "\n"
+	for _, objective := range p.Objectives {
+		line += "  - " + objective + "\n"
+	}
+	return line
+}
+
+func parseMilestoneLine(checkbox, rest string, lines []string, lineIndex int) Milestone {
+	m := Milestone{
+		Completed: checkbox == "x" || checkbox == "X",
+	}
+
+	text := rest
+
+	// Extract due date
+	if matches := duePattern.FindStringSubmatch(rest); matches != nil {
+		if t, err := time.Parse(dateFormat, matches[1]); err == nil {
+			m.Due = &t
+		}
+		text = duePattern.ReplaceAllString(text, "")
+	}
+
+	// Extract metadata
+	if matches := metadataPattern.FindStringSubmatch(text); matches != nil {
+		text = strings.TrimSpace(metadataPattern.ReplaceAllString(text, ""))
+		parseMetadata(matches[1], &m.ID, &m.Added, &m.CompletedAt)
+		m.Project = metadataValue(matches[1], "project")
+		m.Issue, _ = strconv.Atoi(metadataValue(matches[1], "issue"))
+	}
+
+	// Generate ID if not present in metadata
+	if m.ID == "" {
+		m.ID = NewID(PrefixMilestone, nil)
+	}
+
+	m.Text = strings.TrimSpace(text)
+	return m
+}
+
+// SerializeStrategy converts a Strategy back to markdown.
+func SerializeStrategy(s *Strategy) string {
+	var b strings.Builder
+
+	b.WriteString("# Discoverability Strategy Progress\n\n")
+	writeExtras(&b, s.Extra, "")
+	b.WriteString("## Current Phase\n")
+	b.WriteString(s.CurrentPhase + "\n\n")
+	writeExtras(&b, s.Extra, "phase")
+
+	if len(s.Phases) > 0 {
+		b.WriteString("## Phases\n")
+		for _, p := range s.Phases {
+			b.WriteString(formatPhaseLines(p))
+		}
+		b.WriteString("\n")
+	}
+	writeExtras(&b, s.Extra, "phases")
+
+	b.WriteString("## Active Milestones\n")
+	for _, m := range s.ActiveMilestones {
+		b.WriteString(formatMilestoneLine(m, false))
+	}
+	b.WriteString("\n")
+	writeExtras(&b, s.Extra, "active")
+
+	b.WriteString("## Completed Milestones\n")
+	for _, m := range s.CompletedMilestones {
+		b.WriteString(formatMilestoneLine(m, true))
+	}
+	b.WriteString("\n")
+	writeExtras(&b, s.Extra, "completed")
+
+	b.WriteString("## Notes\n")
+	for _, note := range s.Notes {
+		b.WriteString("- " + note + "\n")
+	}
+	writeTrailingExtras(&b, s.Extra, "notes")
+
+	return b.String()
+}
+
+func formatMilestoneLine(m Milestone, includeCompleted bool) string {
+	checkbox := "[ ]"
+	if m.Completed {
+		checkbox = "[x]"
+	}
+
+	line := "- " + checkbox + " " + m.Text
+
+	if m.Due != nil {
+		line += " — Due: " + m.Due.Format(dateFormat)
+	}
+
+	meta := formatMetadata(m.ID, m.Project, m.Added, m.CompletedAt, includeCompleted)
+	if m.Issue > 0 {
+		meta = appendMetadata(meta, "issue", strconv.Itoa(m.Issue))
+	}
+	if meta != "" {
+		line += " " + meta
+	}
+
+	line += "\n"
+	for _, k := range m.KeyResults {
+		line += formatKeyResultLine(k)
+	}
+	return line + formatComments(m.Comments)
+}
@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseStrategy(t *testing.T) {
+	input := `# Discoverability Strategy Progress
+
+## Current Phase
+Foundation (Month 1-2)
+
+## Active Milestones
+- [ ] Publish first blog post — Due: 2026-02-15 {added:2026-01-15}
+- [ ] Deploy MCP server to Fly.io — Due: 2026-02-28 {added:2026-02-01}
+
+## Completed Milestones
+- [x] Site migration to Vercel {added:2026-01-20,completed:2026-02-03}
+- [x] Align all professional profiles {added:2026-01-15,completed:2026-01-25}
+
+## Notes
+- First blog post published and distributed
+- Good momentum on technical foundation work
+`
+
+	s, err := ParseStrategy(input)
+	if err != nil {
+		t.Fatalf("ParseStrategy failed: %v", err)
+	}
+
+	if s.CurrentPhase != "Foundation (Month 1-2)" {
+		t.Errorf("expected phase 'Foundation (Month 1-2)', got %q", s.CurrentPhase)
+	}
+
+	if len(s.ActiveMilestones) != 2 {
+		t.Errorf("expected 2 active milestones, got %d", len(s.ActiveMilestones))
+	}
+
+	if len(s.CompletedMilestones) != 2 {
+		t.Errorf("expected 2 completed milestones, got %d", len(s.CompletedMilestones))
+	}
+
+	if len(s.Notes) != 2 {
+		t.Errorf("expected 2 notes, got %d", len(s.Notes))
+	}
+
+	// Check due date parsing
+	if s.ActiveMilestones[0].Due == nil {
+		t.Error("expected due date to be parsed")
+	} else {
+		expectedDue := time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)
+		if !s.ActiveMilestones[0].Due.Equal(expectedDue) {
+			t.Errorf("expected due date %v, got %v", expectedDue, s.ActiveMilestones[0].Due)
+		}
+	}
+}
+
+func TestSerializeStrategy_RoundTrip(t *testing.T) {
+	input := `# Discoverability Strategy Progress
+
+## Current Phase
+Test Phase
+
+## Active Milestones
+- [ ] Milestone one — Due: 2026-02-15 {added:2026-01-15}
+
+## Completed Milestones
+- [x] Done milestone {added:2026-01-10,completed:2026-01-20}
+
+## Notes
+- A note here
+`
+
+	s, err := ParseStrategy(input)
+	if err != nil {
+		t.Fatalf("ParseStrategy failed: %v", err)
+	}
+
+	output := SerializeStrategy(s)
+
+	s2, err := ParseStrategy(output)
+	if err != nil {
+		t.Fatalf("Second ParseStrategy failed: %v", err)
+	}
+
+	if s.CurrentPhase != s2.CurrentPhase {
+		t.Errorf("phase mismatch: %q vs %q", s.CurrentPhase, s2.CurrentPhase)
+	}
+	if len(s.ActiveMilestones) != len(s2.ActiveMilestones) {
+		t.Errorf("active milestone count mismatch: %d vs %d", len(s.ActiveMilestones), len(s2.ActiveMilestones))
+	}
+}
+
+func TestMilestoneIssue_RoundTrip(t *testing.T) {
+	input := `## Active Milestones
+- [ ] Ship v1 — Due: 2026-03-01 {id:abcd1234,added:2026-02-01,issue:42}
+- [ ] Unsynced {id:bcde2345,added:2026-02-01}
+`
+
+	s, err := ParseStrategy(input)
+	if err != nil {
+		t.Fatalf("ParseStrategy failed: %v", err)
+	}
+	if s.ActiveMilestones[0].Issue != 42 || s.ActiveMilestones[1].Issue != 0 {
+		t.Fatalf("unexpected issue numbers: %d, %d", s.ActiveMilestones[0].Issue, s.ActiveMilestones[1].Issue)
+	}
+
+	output := SerializeStrategy(s)
+	if !strings.Contains(output, "{id:abcd1234,added:2026-02-01,issue:42}") {
+		t.Errorf("issue number not serialized:\n%s", output)
+	}
+
+	s2, err := ParseStrategy(output)
+	if err != nil {
+		t.Fatalf("Second ParseStrategy failed: %v", err)
+	}
+	if s2.ActiveMilestones[0].Issue != 42 {
+		t.Errorf("issue number lost on round trip, got %d", s2.ActiveMilestones[0].Issue)
+	}
+}
+
+func TestMilestoneLink_RoundTrip(t *testing.T) {
+	input := "# Active Todos\n\n## Normal\n- [ ] Write docs {id:aaaa1111,added:2026-02-01,milestone:cccc3333}\n\n# Completed\n"
+	tf, err := ParseTodos(input)
+	if err != nil {
+		t.Fatalf("ParseTodos failed: %v", err)
+	}
+	if tf.Active[0].Milestone != "cccc3333" || tf.Active[0].Text != "Write docs" {
+		t.Errorf("unexpected todo %+v", tf.Active[0])
+	}
+	if out := SerializeTodos(tf); out != input {
+		t.Errorf("milestone link not preserved:\n%s", out)
+	}
+}
+
+func TestPhases_RoundTrip(t *testing.T) {
+	content := "# Discoverability Strategy Progress\n\n## Current Phase\nPhase 2: Launch\n\n" +
+		"## Phases\n- Phase 1: Foundations {started:2026-01-05,ended:2026-02-02}\n  - Pick a niche\n- Phase 2: Launch {started:2026-02-02}\n\n" +
+		"## Active Milestones\n\n## Completed Milestones\n\n## Notes\n"
+	s, err := ParseStrategy(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Phases) != 2 || s.Phases[0].Ended == nil || len(s.Phases[0].Objectives) != 1 || s.Phases[1].Ended != nil || s.Phases[1].Started.Day() != 2 {
+		t.Fatalf("unexpected phases %+v", s.Phases)
+	}
+	if got := SerializeStrategy(s); got != content {
+		t.Errorf("round trip changed the file:\n%s", got)
+	}
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PhaseTemplate lists the default milestones for one strategy phase.
+type PhaseTemplate struct {
+	Phase      string
+	Milestones []TemplateMilestone
+}
+
+// TemplateMilestone is a milestone in a phase template. DueInDays is relative
+// to the day the template is applied; zero means no due date.
+type TemplateMilestone struct {
+	Text      string
+	DueInDays int
+}
+
+// Matches a relative due date at the end of a template line: (+14d) or (+2w)
+var relativeDuePattern = regexp.MustCompile(`\s*\(\+(\d+)([dw])\)\s*$`)
+
+// ParsePhaseTemplates parses a phase-templates.md file content. Each
+// "## <phase>" heading starts a template and each "- " line beneath it is a
+// milestone, optionally ending in a relative due date like (+14d) or (+2w).
+func ParsePhaseTemplates(content string) ([]PhaseTemplate, error) {
+	var templates []PhaseTemplate
+	var current *PhaseTemplate
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "## ") {
+			templates = append(templates, PhaseTemplate{Phase: strings.TrimSpace(strings.TrimPrefix(trimmed, "## "))})
+			current = &templates[len(templates)-1]
+			continue
+		}
+
+		if current == nil || !strings.HasPrefix(trimmed, "- ") {
+			continue
+		}
+
+		// Accept checkbox lines too, so a phase's milestones can be pasted in
+		text := strings.TrimSpace(strings.TrimPrefix(trimmed, "- "))
+		if matches := checkboxPattern.FindStringSubmatch(trimmed); matches != nil {
+			text = matches[2]
+		}
+
+		m := TemplateMilestone{}
+		if matches := relativeDuePattern.FindStringSubmatch(text); matches != nil {
+			n, _ := strconv.Atoi(matches[1])
+			if matches[2] == "w" {
+				n *= 7
+			}
+			m.DueInDays = n
+			text = relativeDuePattern.ReplaceAllString(text, "")
+		}
+		m.Text = strings.TrimSpace(text)
+		if m.Text != "" {
+			current.Milestones = append(current.Milestones, m)
+		}
+	}
+
+	return templates, nil
+}
+
+// FindPhaseTemplate returns the template whose phase name matches phase,
+// ignoring case and surrounding whitespace, or nil if there is none.
+func FindPhaseTemplate(templates []PhaseTemplate, phase string) *PhaseTemplate {
+	phase = strings.TrimSpace(phase)
+	for i := range templates {
+		if strings.EqualFold(templates[i].Phase, phase) {
+			return &templates[i]
+		}
+	}
+	return nil
+}
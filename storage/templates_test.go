@@ -0,0 +1,48 @@
+package storage
+
+import "testing"
+
+func TestParsePhaseTemplates(t *testing.T) {
+	input := `# Phase Templates
+
+Notes about how these are used are ignored.
+
+## Phase 2: Launch
+- Publish landing page (+7d)
+- [ ] Announce on Bluesky (+2w)
+- Collect feedback
+
+## Phase 3: Grow
+- Write case study (+30d)
+`
+
+	templates, err := ParsePhaseTemplates(input)
+	if err != nil {
+		t.Fatalf("ParsePhaseTemplates failed: %v", err)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("expected 2 templates, got %d", len(templates))
+	}
+
+	launch := FindPhaseTemplate(templates, "  phase 2: launch ")
+	if launch == nil {
+		t.Fatal("expected to find Phase 2 template case-insensitively")
+	}
+	want := []TemplateMilestone{
+		{Text: "Publish landing page", DueInDays: 7},
+		{Text: "Announce on Bluesky", DueInDays: 14},
+		{Text: "Collect feedback"},
+	}
+	if len(launch.Milestones) != len(want) {
+		t.Fatalf("expected %d milestones, got %+v", len(want), launch.Milestones)
+	}
+	for i, m := range want {
+		if launch.Milestones[i] != m {
+			t.Errorf("milestone %d: expected %+v, got %+v", i, m, launch.Milestones[i])
+		}
+	}
+
+	if FindPhaseTemplate(templates, "Phase 4") != nil {
+		t.Error("expected no template for unknown phase")
+	}
+}
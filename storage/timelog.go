@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimeEntry is a work session logged against a todo or milestone.
+type TimeEntry struct {
+	ID       string
+	Start    time.Time  // UTC, minute precision
+	End      *time.Time // nil while the timer is running
+	ItemType string     // "todo" or "milestone"
+	ItemID   string
+	Text     string // item text when the session was logged
+	Project  string
+}
+
+// Duration returns the session length, counting a running session up to now.
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	end := now
+	if e.End != nil {
+		end = *e.End
+	}
+	if end.Before(e.Start) {
+		return 0
+	}
+	return end.Sub(e.Start)
+}
+
+// TimeLog represents the parsed contents of timelog.md.
+// Entries are kept in chronological order.
+type TimeLog struct {
+	Entries []TimeEntry
+	Raw     string
+}
+
+// Running returns the index of the running session, or -1 if none.
+func (l *TimeLog) Running() int {
+	for i := len(l.Entries) - 1; i >= 0; i-- {
+		if l.Entries[i].End == nil {
+			return i
+		}
+	}
+	return -1
+}
+
+const timeLogFormat = "2006-01-02 15:04"
+
+// Matches time log line: - 2026-02-03 09:15 - 2026-02-03 10:40 todo:abcd1234 Text {metadata}
+// A running session has "running" in place of the end time.
+var timeLogLinePattern = regexp.MustCompile(`^-\s*(\d{4}-\d{2}-\d{2} \d{2}:\d{2})\s+-\s+(\d{4}-\d{2}-\d{2} \d{2}:\d{2}|running)\s+(todo|milestone):(\S+)\s*(.*)$`)
+
+// ParseTimeLog parses a timelog.md file content.
+func ParseTimeLog(content string) (*TimeLog, error) {
+	l := &TimeLog{Raw: content}
+
+	for _, line := range strings.Split(content, "\n") {
+		matches := timeLogLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+
+		start, err := time.Parse(timeLogFormat, matches[1])
+		if err != nil {
+			continue
+		}
+		e := TimeEntry{Start: start, ItemType: matches[3], ItemID: matches[4]}
+		if matches[2] != "running" {
+			if end, err := time.Parse(timeLogFormat, matches[2]); err == nil {
+				e.End = &end
+			}
+		}
+
+		text := matches[5]
+		if meta := metadataPattern.FindStringSubmatch(text); meta != nil {
+			text = strings.TrimSpace(metadataPattern.ReplaceAllString(text, ""))
+			var added time.Time
+			var completed *time.Time
+			parseMetadata(meta[1], &e.ID, &added, &completed)
+			e.Project = metadataValue(meta[1], "project")
+		}
+		if e.ID == "" {
+			e.ID = NewID(PrefixTime, nil)
+		}
+		e.Text = strings.TrimSpace(text)
+		l.Entries = append(l.Entries, e)
+	}
+
+	sort.SliceStable(l.Entries, func(a, b int) bool {
+		return l.Entries[a].Start.Before(l.Entries[b].Start)
+	})
+
+	return l, nil
+}
+
+// SerializeTimeLog converts a TimeLog back to markdown.
+func SerializeTimeLog(l *TimeLog) string {
+	var b strings.Builder
+
+	b.WriteString("# Time Log\n\n")
+	for _, e := range l.Entries {
+		end := "running"
+		if e.End != nil {
+			end = e.End.Format(timeLogFormat)
+		}
+		line := "- " + e.Start.Format(timeLogFormat) + " - " + end + " " + e.ItemType + ":" + e.ItemID
+		if e.Text != "" {
+			line += " " + e.Text
+		}
+		if meta := formatMetadata(e.ID, e.Project, time.Time{}, nil, false); meta != "" {
+			line += " " + meta
+		}
+		b.WriteString(line + "\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeLog_RoundTrip(t *testing.T) {
+	input := `# Time Log
+
+- 2026-02-03 09:15 - 2026-02-03 10:45 todo:aaaa1111 Write landing copy {id:e1,project:website}
+- 2026-02-03 14:00 - running milestone:bbbb2222 Launch site {id:e2}
+`
+	l, err := ParseTimeLog(input)
+	if err != nil {
+		t.Fatalf("ParseTimeLog failed: %v", err)
+	}
+	if len(l.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(l.Entries))
+	}
+
+	first := l.Entries[0]
+	if first.ItemType != "todo" || first.ItemID != "aaaa1111" || first.Text != "Write landing copy" || first.Project != "website" {
+		t.Errorf("unexpected first entry: %+v", first)
+	}
+	if got := first.Duration(time.Now()); got != 90*time.Minute {
+		t.Errorf("expected 90m session, got %v", got)
+	}
+	if l.Running() != 1 {
+		t.Errorf("expected second entry to be running, got index %d", l.Running())
+	}
+
+	if out := SerializeTimeLog(l); out != input {
+		t.Errorf("round trip mismatch:\n%s", out)
+	}
+}
@@ -0,0 +1,296 @@
+package storage
+
+import (
+	"strings"
+	"time"
+)
+
+// Priority levels for todos.
+type Priority string
+
+const (
+	PriorityUrgent  Priority = "urgent"
+	PriorityHigh    Priority = "high"
+	PriorityNormal  Priority = "normal"
+	PrioritySomeday Priority = "someday"
+)
+
+// Priorities lists the priority levels from most to least urgent.
+var Priorities = []Priority{PriorityUrgent, PriorityHigh, PriorityNormal, PrioritySomeday}
+
+// AtLeast reports whether p is as urgent as q or more. An empty priority
+// counts as normal.
+func (p Priority) AtLeast(q Priority) bool {
+	return priorityIndex(p) <= priorityIndex(q)
+}
+
+func priorityIndex(p Priority) int {
+	if p == "" {
+		p = PriorityNormal
+	}
+	for i, q := range Priorities {
+		if p == q {
+			return i
+		}
+	}
+	return len(Priorities)
+}
+
+// Todo represents a single todo item.
+type Todo struct {
+	ID          string
+	Text        string
+	Priority    Priority
+	Project     string // project slug, see projects.md; empty if none
+	Milestone   string // ID of the milestone the todo works towards; empty if none
+	Completed   bool
+	Added       time.Time
+	CompletedAt *time.Time
+	// Subtasks are the checklist items indented under the todo.
+	Subtasks []Subtask
+	// Comments are the remarks indented under the todo, oldest first.
+	Comments []Comment
+}
+
+// Subtask is a checklist item under a todo, written as an indented
+// checkbox line below it.
+type Subtask struct {
+	ID        string
+	Text      string
+	Completed bool
+}
+
+// TodoFile represents the parsed contents of todos.md.
+type TodoFile struct {
+	Active    []Todo
+	Completed []Todo
+	// Extra holds unrecognized sections and text, written back verbatim
+	Extra []ExtraBlock
+	// Raw preserves any content we don't parse (for round-trip fidelity)
+	Raw string
+}
+
+// ParseTodos parses a todos.md file content.
+func ParseTodos(content string) (*TodoFile, error) {
+	tf := &TodoFile{Raw: content}
+	lines := strings.Split(content, "\n")
+
+	var currentSection string
+	var currentPriority Priority
+
+	// known is the last recognized heading; unrecognized headings start an
+	// extra block that runs to the next heading
+	var known string
+	var extra extraCollector
+	inExtra := false
+	// parent is the list whose last todo indented checkboxes belong to,
+	// nil when the last line wasn't a todo or one of its subtasks
+	var parent *[]Todo
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			parent = nil
+		}
+
+		// Track which section we're in
+		if strings.HasPrefix(trimmed, "# ") {
+			switch {
+			case strings.Contains(trimmed, "Active"):
+				currentSection, known = "active", "active"
+			case strings.Contains(trimmed, "Completed"):
+				currentSection, known = "completed", "completed"
+			default:
+				extra.start(known, line)
+				inExtra = true
+				continue
+			}
+			extra.stop()
+			inExtra = false
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "## ") {
+			heading := strings.ToLower(strings.TrimPrefix(trimmed, "## "))
+			switch {
+			case strings.Contains(heading, "urgent"):
+				currentPriority, known = PriorityUrgent, "urgent"
+			case strings.Contains(heading, "high"):
+				currentPriority, known = PriorityHigh, "high"
+			case strings.Contains(heading, "normal"):
+				currentPriority, known = PriorityNormal, "normal"
+			case strings.Contains(heading, "someday"):
+				currentPriority, known = PrioritySomeday, "someday"
+			case strings.Contains(heading, "completed"):
+				currentSection, known = "completed", "completed"
+			default:
+				extra.start(known, line)
+				inExtra = true
+				continue
+			}
+			extra.stop()
+			inExtra = false
+			continue
+		}
+
+		if inExtra {
+			extra.add(known, line)
+			continue
+		}
+
+		// Indented comment lines under a todo are its comments
+		if parent != nil {
+			if c, ok := parseCommentLine(line); ok {
+				todo := &(*parent)[len(*parent)-1]
+				todo.Comments = append(todo.Comments, c)
+				continue
+			}
+		}
+
+		// Parse checkbox lines; indented ones under a todo are its subtasks
+		if matches := checkboxPattern.FindStringSubmatch(trimmed); matches != nil {
+			extra.stop()
+			if parent != nil {
+				todo := &(*parent)[len(*parent)-1]
+				todo.Subtasks = append(todo.Subtasks, parseSubtaskLine(matches[1], matches[2]))
+				continue
+			}
+			todo := parseTodoLine(matches[1], matches[2], currentPriority)
+
+			if currentSection == "completed" || todo.Completed {
+				tf.Completed = append(tf.Completed, todo)
+				parent = &tf.Completed
+			} else {
+				tf.Active = append(tf.Active, todo)
+				parent = &tf.Active
+			}
+			continue
+		}
+
+		if trimmed != "" {
+			parent = nil
+		}
+		extra.add(known, line)
+	}
+
+	tf.Extra = extra.result()
+	return tf, nil
+}
+
+// parseSubtaskLine extracts subtask data from a checkbox match.
+func parseSubtaskLine(checkbox, rest string) Subtask {
+	sub := Subtask{
+		Completed: checkbox == "x" || checkbox == "X",
+		Text:      rest,
+	}
+	if matches := metadataPattern.FindStringSubmatch(rest); matches != nil {
+		sub.Text = strings.TrimSpace(metadataPattern.ReplaceAllString(rest, ""))
+		sub.ID = metadataValue(matches[1], "id")
+	}
+	if sub.ID == "" {
+		sub.ID = NewID(PrefixSubtask, nil)
+	}
+	return sub
+}
+
+// parseTodoLine extracts todo data from a checkbox match.
+func parseTodoLine(checkbox, rest string, priority Priority) Todo {
+	todo := Todo{
+		Completed: checkbox == "x" || checkbox == "X",
+		Priority:  priority,
+	}
+
+	// Extract and remove metadata
+	text := rest
+	if matches := metadataPattern.FindStringSubmatch(rest); matches != nil {
+		text = strings.TrimSpace(metadataPattern.ReplaceAllString(rest, ""))
+		parseMetadata(matches[1], &todo.ID, &todo.Added, &todo.CompletedAt)
+		todo.Project = metadataValue(matches[1], "project")
+		todo.Milestone = metadataValue(matches[1], "milestone")
+	}
+
+	// Generate ID if not present in metadata
+	if todo.ID == "" {
+		todo.ID = NewID(PrefixTodo, nil)
+	}
+
+	todo.Text = text
+	return todo
+}
+
+// SerializeTodos converts a TodoFile back to markdown.
+func SerializeTodos(tf *TodoFile) string {
+	var b strings.Builder
+
+	writeExtras(&b, tf.Extra, "")
+	b.WriteString("# Active Todos\n\n")
+	writeExtras(&b, tf.Extra, "active")
+
+	// Group active todos by priority
+	byPriority := map[Priority][]Todo{
+		PriorityUrgent:  {},
+		PriorityHigh:    {},
+		PriorityNormal:  {},
+		PrioritySomeday: {},
+	}
+	for _, todo := range tf.Active {
+		p := todo.Priority
+		if p == "" {
+			p = PriorityNormal
+		}
+		byPriority[p] = append(byPriority[p], todo)
+	}
+
+	writePrioritySection(&b, "## Urgent", byPriority[PriorityUrgent])
+	writeExtras(&b, tf.Extra, "urgent")
+	writePrioritySection(&b, "## High Priority", byPriority[PriorityHigh])
+	writeExtras(&b, tf.Extra, "high")
+	writePrioritySection(&b, "## Normal", byPriority[PriorityNormal])
+	writeExtras(&b, tf.Extra, "normal")
+	writePrioritySection(&b, "## Someday", byPriority[PrioritySomeday])
+	writeExtras(&b, tf.Extra, "someday")
+
+	b.WriteString("# Completed\n")
+	for _, todo := range tf.Completed {
+		b.WriteString(formatTodoLine(todo, true))
+	}
+	writeTrailingExtras(&b, tf.Extra, "completed")
+
+	return b.String()
+}
+
+func writePrioritySection(b *strings.Builder, heading string, todos []Todo) {
+	if len(todos) == 0 {
+		return
+	}
+	b.WriteString(heading + "\n")
+	for _, todo := range todos {
+		b.WriteString(formatTodoLine(todo, false))
+	}
+	b.WriteString("\n")
+}
+
+func formatTodoLine(todo Todo, includeCompleted bool) string {
+	checkbox := "[ ]"
+	if todo.Completed {
+		checkbox = "[x]"
+	}
+
+	meta := formatMetadata(todo.ID, todo.Project, todo.Added, todo.CompletedAt, includeCompleted)
+	if todo.Milestone != "" {
+		meta = appendMetadata(meta, "milestone", todo.Milestone)
+	}
+
+	line := "- " + checkbox + " " + todo.Text + "\n"
+	if meta != "" {
+		line = "- " + checkbox + " " + todo.Text + " " + meta + "\n"
+	}
+	for _, sub := range todo.Subtasks {
+		subCheckbox := "[ ]"
+		if sub.Completed {
+			subCheckbox = "[x]"
+		}
+		line += "  - " + subCheckbox + " " + sub.Text + " {id:" + sub.ID + "}\n"
+	}
+	return line + formatComments(todo.Comments)
+}
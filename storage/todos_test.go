@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTodos(t *testing.T) {
+	input := `# Active Todos
+
+## High Priority
+- [ ] Draft LinkedIn About section {added:2026-02-01}
+- [ ] Research MCP Go SDK {added:2026-01-28}
+
+## Normal
+- [ ] Review Bluesky posting best practices {added:2026-02-03}
+
+## Someday
+- [ ] Explore calendar integration {added:2026-01-20}
+
+# Completed
+- [x] Fix SPA routing {added:2026-01-15,completed:2026-02-01}
+- [x] Update GitHub README {added:2026-01-14,completed:2026-01-14}
+`
+
+	tf, err := ParseTodos(input)
+	if err != nil {
+		t.Fatalf("ParseTodos failed: %v", err)
+	}
+
+	// Check active todos count
+	if len(tf.Active) != 4 {
+		t.Errorf("expected 4 active todos, got %d", len(tf.Active))
+	}
+
+	// Check completed todos count
+	if len(tf.Completed) != 2 {
+		t.Errorf("expected 2 completed todos, got %d", len(tf.Completed))
+	}
+
+	// Check priority assignment
+	highCount := 0
+	for _, todo := range tf.Active {
+		if todo.Priority == PriorityHigh {
+			highCount++
+		}
+	}
+	if highCount != 2 {
+		t.Errorf("expected 2 high priority todos, got %d", highCount)
+	}
+
+	// Check a specific todo
+	found := false
+	for _, todo := range tf.Active {
+		if strings.Contains(todo.Text, "Draft LinkedIn") {
+			found = true
+			if todo.Priority != PriorityHigh {
+				t.Errorf("expected high priority, got %s", todo.Priority)
+			}
+			expectedDate := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+			if !todo.Added.Equal(expectedDate) {
+				t.Errorf("expected added date %v, got %v", expectedDate, todo.Added)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected to find 'Draft LinkedIn' todo")
+	}
+
+	// Check completed todo has both dates
+	for _, todo := range tf.Completed {
+		if strings.Contains(todo.Text, "Fix SPA") {
+			if todo.CompletedAt == nil {
+				t.Error("expected completed date to be set")
+			}
+		}
+	}
+}
+
+func TestSerializeTodos_RoundTrip(t *testing.T) {
+	input := `# Active Todos
+
+## Urgent
+- [ ] Task zero {added:2026-02-01}
+
+## High Priority
+- [ ] Task one {added:2026-02-01}
+
+## Normal
+- [ ] Task two {added:2026-02-02}
+
+## Someday
+- [ ] Task three {added:2026-02-03}
+
+# Completed
+- [x] Done task {added:2026-01-15,completed:2026-02-01}
+`
+
+	tf, err := ParseTodos(input)
+	if err != nil {
+		t.Fatalf("ParseTodos failed: %v", err)
+	}
+
+	output := SerializeTodos(tf)
+
+	// Parse again
+	tf2, err := ParseTodos(output)
+	if err != nil {
+		t.Fatalf("Second ParseTodos failed: %v", err)
+	}
+
+	// Verify same number of items
+	if len(tf.Active) != len(tf2.Active) {
+		t.Errorf("active count mismatch: %d vs %d", len(tf.Active), len(tf2.Active))
+	}
+	if len(tf.Completed) != len(tf2.Completed) {
+		t.Errorf("completed count mismatch: %d vs %d", len(tf.Completed), len(tf2.Completed))
+	}
+	if tf2.Active[0].Priority != PriorityUrgent || tf2.Active[1].Priority != PriorityHigh {
+		t.Errorf("priorities after round trip = %q, %q", tf2.Active[0].Priority, tf2.Active[1].Priority)
+	}
+}
+
+func TestSubtasks_RoundTrip(t *testing.T) {
+	input := `# Active Todos
+
+## Normal
+- [ ] Plan trip {id:aaaa1111}
+  - [x] Book flights {id:bbbb1111}
+  - [ ] Book hotel
+- [ ] Water plants {id:aaaa2222}
+
+# Completed
+- [x] Move house {id:aaaa3333,completed:2026-02-01}
+  - [x] Pack boxes {id:cccc1111}
+`
+	tf, err := ParseTodos(input)
+	if err != nil {
+		t.Fatalf("ParseTodos failed: %v", err)
+	}
+	if len(tf.Active) != 2 || len(tf.Completed) != 1 {
+		t.Fatalf("got %d active, %d completed todos; subtasks should not be todos", len(tf.Active), len(tf.Completed))
+	}
+	subs := tf.Active[0].Subtasks
+	if len(subs) != 2 || !subs[0].Completed || subs[0].ID != "bbbb1111" || subs[1].Completed || subs[1].Text != "Book hotel" || subs[1].ID == "" {
+		t.Errorf("subtasks = %+v", subs)
+	}
+	if len(tf.Active[1].Subtasks) != 0 || len(tf.Completed[0].Subtasks) != 1 {
+		t.Errorf("subtasks attached to the wrong todos: %+v", tf)
+	}
+
+	tf2, _ := ParseTodos(SerializeTodos(tf))
+	if len(tf2.Active[0].Subtasks) != 2 || tf2.Active[0].Subtasks[1].ID != subs[1].ID || len(tf2.Completed[0].Subtasks) != 1 {
+		t.Errorf("subtasks after round trip = %+v", tf2)
+	}
+}
+
+func TestPriorityAtLeast(t *testing.T) {
+	if !PriorityUrgent.AtLeast(PriorityHigh) || PriorityNormal.AtLeast(PriorityHigh) {
+		t.Error("urgent should rank at least high, normal should not")
+	}
+	if !Priority("").AtLeast(PriorityNormal) || Priority("").AtLeast(PriorityHigh) {
+		t.Error("an empty priority should count as normal")
+	}
+}
+
+func TestProjectMetadata_RoundTrip(t *testing.T) {
+	input := `# Active Todos
+
+## Normal
+- [ ] Write landing copy {id:aaaa1111,project:website,added:2026-02-01}
+- [ ] Unrelated chore {id:bbbb2222,added:2026-02-01}
+
+# Completed
+`
+	tf, err := ParseTodos(input)
+	if err != nil {
+		t.Fatalf("ParseTodos failed: %v", err)
+	}
+	if tf.Active[0].Project != "website" || tf.Active[1].Project != "" {
+		t.Errorf("unexpected projects: %q, %q", tf.Active[0].Project, tf.Active[1].Project)
+	}
+	if out := SerializeTodos(tf); !strings.Contains(out, "{id:aaaa1111,project:website,added:2026-02-01}") {
+		t.Errorf("project not preserved in serialization:\n%s", out)
+	}
+
+	s, err := ParseStrategy("## Active Milestones\n- [ ] Launch site — Due: 2026-03-01 {id:cccc3333,project:website}\n")
+	if err != nil {
+		t.Fatalf("ParseStrategy failed: %v", err)
+	}
+	if s.ActiveMilestones[0].Project != "website" {
+		t.Errorf("expected milestone project website, got %q", s.ActiveMilestones[0].Project)
+	}
+}
@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"strings"
+	"time"
+)
+
+// Trash represents the parsed contents of trash.md: deleted todos,
+// reminders and notes, kept until they're restored or purged.
+type Trash struct {
+	Items []TrashItem
+}
+
+// TrashItem is a deleted item. Exactly one of Todo, Reminder and Note is
+// set.
+type TrashItem struct {
+	DeletedAt time.Time
+	Todo      *Todo
+	Reminder  *Reminder
+	Note      *Note
+}
+
+// Type is "todo", "reminder" or "note".
+func (t TrashItem) Type() string {
+	switch {
+	case t.Todo != nil:
+		return "todo"
+	case t.Reminder != nil:
+		return "reminder"
+	default:
+		return "note"
+	}
+}
+
+// ID returns the deleted item's ID.
+func (t TrashItem) ID() string {
+	switch {
+	case t.Todo != nil:
+		return t.Todo.ID
+	case t.Reminder != nil:
+		return t.Reminder.ID
+	case t.Note != nil:
+		return t.Note.ID
+	}
+	return ""
+}
+
+// Text returns the deleted item's text.
+func (t TrashItem) Text() string {
+	switch {
+	case t.Todo != nil:
+		return t.Todo.Text
+	case t.Reminder != nil:
+		return t.Reminder.Text
+	case t.Note != nil:
+		return t.Note.Text
+	}
+	return ""
+}
+
+// ParseTrash parses a trash.md file content. Items are written as in their
+// own files under a "## Todos", "## Reminders" or "## Notes" heading, with
+// what their file records by position (a todo's priority, a note's
+// category) and the deletion time added to the metadata.
+func ParseTrash(content string) (*Trash, error) {
+	tf := &Trash{}
+	section := ""
+	var lastTodo *Todo
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "## ") {
+			section = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(trimmed, "## ")))
+			lastTodo = nil
+			continue
+		}
+		meta := ""
+		if matches := metadataPattern.FindStringSubmatch(trimmed); matches != nil {
+			meta = matches[1]
+		}
+		deletedAt, _ := time.Parse(time.RFC3339, metadataValue(meta, "deleted"))
+
+		switch section {
+		case "todos":
+			if c, ok := parseCommentLine(line); ok && lastTodo != nil {
+				lastTodo.Comments = append(lastTodo.Comments, c)
+				continue
+			}
+			matches := checkboxPattern.FindStringSubmatch(trimmed)
+			if matches == nil {
+				continue
+			}
+			if lastTodo != nil && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+				lastTodo.Subtasks = append(lastTodo.Subtasks, parseSubtaskLine(matches[1], matches[2]))
+				continue
+			}
+			todo := parseTodoLine(matches[1], matches[2], Priority(metadataValue(meta, "priority")))
+			tf.Items = append(tf.Items, TrashItem{DeletedAt: deletedAt, Todo: &todo})
+			lastTodo = &todo
+		case "reminders":
+			if matches := reminderLinePattern.FindStringSubmatch(trimmed); matches != nil {
+				r := parseReminderLine(matches[1], matches[2])
+				r.Completed = r.CompletedAt != nil
+				tf.Items = append(tf.Items, TrashItem{DeletedAt: deletedAt, Reminder: &r})
+			}
+		case "notes":
+			if matches := noteLinePattern.FindStringSubmatch(trimmed); matches != nil {
+				note := Note{Text: strings.TrimSpace(metadataPattern.ReplaceAllString(matches[1], ""))}
+				var completed *time.Time
+				parseMetadata(meta, &note.ID, &note.Added, &completed)
+				note.Category = metadataValue(meta, "category")
+				if note.ID == "" {
+					note.ID = NewID(PrefixNote, nil)
+				}
+				tf.Items = append(tf.Items, TrashItem{DeletedAt: deletedAt, Note: &note})
+			}
+		}
+	}
+	return tf, nil
+}
+
+// SerializeTrash converts Trash back to markdown.
+func SerializeTrash(tf *Trash) string {
+	var todos, reminders, notes strings.Builder
+	for _, item := range tf.Items {
+		deleted := item.DeletedAt.UTC().Format(time.RFC3339)
+		switch {
+		case item.Todo != nil:
+			// The todo's line, then any subtask lines
+			line, subtasks, _ := strings.Cut(formatTodoLine(*item.Todo, true), "\n")
+			meta := metadataPattern.FindString(line)
+			extra := appendMetadata(appendMetadata(meta, "priority", string(item.Todo.Priority)), "deleted", deleted)
+			if meta == "" {
+				line += " " + extra
+			} else {
+				line = strings.Replace(line, meta, extra, 1)
+			}
+			todos.WriteString(line + "\n" + subtasks)
+		case item.Reminder != nil:
+			line := strings.TrimSuffix(formatReminderLine(*item.Reminder, true), "\n")
+			meta := metadataPattern.FindString(line)
+			if meta == "" {
+				line += " " + appendMetadata("", "deleted", deleted)
+			} else {
+				line = strings.Replace(line, meta, appendMetadata(meta, "deleted", deleted), 1)
+			}
+			reminders.WriteString(line + "\n")
+		case item.Note != nil:
+			meta := formatMetadata(item.Note.ID, "", item.Note.Added, nil, false)
+			if item.Note.Category != "" {
+				meta = appendMetadata(meta, "category", item.Note.Category)
+			}
+			notes.WriteString("- " + item.Note.Text + " " + appendMetadata(meta, "deleted", deleted) + "\n")
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("# Trash\n")
+	for _, section := range []struct {
+		heading string
+		lines   string
+	}{{"Todos", todos.String()}, {"Reminders", reminders.String()}, {"Notes", notes.String()}} {
+		if section.lines != "" {
+			b.WriteString("\n## " + section.heading + "\n" + section.lines)
+		}
+	}
+	return b.String()
+}
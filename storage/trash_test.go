@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSerializeTrash_RoundTrip(t *testing.T) {
+	deleted := time.Date(2026, 2, 10, 9, 30, 0, 0, time.UTC)
+	added := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	trash := &Trash{Items: []TrashItem{
+		{DeletedAt: deleted, Todo: &Todo{ID: "aaaa1111", Text: "Ship it", Priority: PriorityHigh, Added: added,
+			Subtasks: []Subtask{{ID: "dddd4444", Text: "Write tests", Completed: true}}}},
+		{DeletedAt: deleted, Reminder: &Reminder{ID: "bbbb2222", Text: "Renew passport", Date: added}},
+		{DeletedAt: deleted, Note: &Note{ID: "cccc3333", Text: "Dark mode", Category: "Ideas"}},
+	}}
+
+	output := SerializeTrash(trash)
+	got, err := ParseTrash(output)
+	if err != nil {
+		t.Fatalf("ParseTrash failed: %v", err)
+	}
+	if len(got.Items) != 3 {
+		t.Fatalf("expected 3 items, got %d:\n%s", len(got.Items), output)
+	}
+	todo := got.Items[0].Todo
+	if todo == nil || todo.Priority != PriorityHigh || len(todo.Subtasks) != 1 || !got.Items[0].DeletedAt.Equal(deleted) {
+		t.Errorf("unexpected todo %+v", got.Items[0])
+	}
+	if r := got.Items[1].Reminder; r == nil || r.ID != "bbbb2222" || !r.Date.Equal(added) {
+		t.Errorf("unexpected reminder %+v", got.Items[1])
+	}
+	if n := got.Items[2].Note; n == nil || n.Category != "Ideas" || n.Text != "Dark mode" {
+		t.Errorf("unexpected note %+v", got.Items[2])
+	}
+	if again := SerializeTrash(got); again != output {
+		t.Errorf("round trip changed the output:\n%s\n%s", output, again)
+	}
+}
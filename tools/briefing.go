@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// briefingTodos is how many active todos the briefing lists.
+const briefingTodos = 5
+
+// CalendarAgenda lists a day's calendar events, one line each.
+// integrations.Calendar implements it.
+type CalendarAgenda interface {
+	Agenda(ctx context.Context, day time.Time) ([]string, error)
+}
+
+// ContributionStreak reports the GitHub contribution streak.
+// resources.GitHubActivityResource implements it.
+type ContributionStreak interface {
+	Streak(ctx context.Context) (days int, contributedToday bool, err error)
+}
+
+// BriefingTools produces the morning briefing: today's reminders, the top
+// todos, the calendar, and the GitHub streak.
+type BriefingTools struct {
+	storage  storage.Storage
+	clock    clock.Clock
+	calendar CalendarAgenda
+	streak   ContributionStreak
+}
+
+// NewBriefingTools creates a new BriefingTools instance. calendar and streak
+// are optional; their sections are left out when nil. A nil clock uses the
+// system clock.
+func NewBriefingTools(s storage.Storage, c clock.Clock, calendar CalendarAgenda, streak ContributionStreak) *BriefingTools {
+	return &BriefingTools{storage: s, clock: clock.Or(c), calendar: calendar, streak: streak}
+}
+
+// GetBriefingInput is the input schema for the get_briefing tool.
+type GetBriefingInput struct{}
+
+// GetBriefingOutput is the output for the get_briefing tool.
+type GetBriefingOutput struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Result  *BriefingResult `json:"result,omitempty"`
+}
+
+// BriefingResult is the morning briefing. Empty sections are omitted.
+type BriefingResult struct {
+	Date             string      `json:"date"`
+	Events           []string    `json:"events,omitempty"`
+	OverdueReminders []TodayItem `json:"overdue_reminders,omitempty"`
+	DueReminders     []TodayItem `json:"due_reminders,omitempty"`
+	TopTodos         []TodayItem `json:"top_todos,omitempty"`
+	Milestones       []TodayItem `json:"milestones_due,omitempty"`
+	Streak           *StreakItem `json:"streak,omitempty"`
+	// Unavailable names the sources that couldn't be read this time.
+	Unavailable []string `json:"unavailable,omitempty"`
+}
+
+// StreakItem is the state of the GitHub contribution streak.
+type StreakItem struct {
+	Days             int  `json:"days"`
+	ContributedToday bool `json:"contributed_today"`
+}
+
+// Register registers the briefing tool with the MCP server.
+func (b *BriefingTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_briefing",
+		Description: "Get the morning briefing: today's calendar, overdue and due reminders, the top todos, milestones due within 7 days, and whether the GitHub streak needs a contribution today. The same briefing can be pushed on a schedule (BRIEFING_SCHEDULE).",
+	}, b.getBriefing)
+}
+
+func (b *BriefingTools) getBriefing(ctx context.Context, req *mcp.CallToolRequest, input GetBriefingInput) (*mcp.CallToolResult, GetBriefingOutput, error) {
+	result, err := b.briefing(ctx)
+	if err != nil {
+		return nil, GetBriefingOutput{}, err
+	}
+	text := result.text()
+	return textResult(text), GetBriefingOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+// briefing gathers today's briefing. Calendar and GitHub failures are noted
+// in the result rather than failing it.
+func (b *BriefingTools) briefing(ctx context.Context) (BriefingResult, error) {
+	today := clock.Today(b.clock)
+	agenda := todayAgenda(ctx, b.storage, today)
+	result := BriefingResult{
+		Date:             agenda.Date,
+		OverdueReminders: agenda.OverdueReminders,
+		DueReminders:     agenda.DueReminders,
+		Milestones:       agenda.Milestones,
+	}
+
+	content, _, err := b.storage.ReadFile(ctx, storage.TodosFile)
+	if err == nil {
+		if tf, err := parseTodos(ctx, content); err == nil {
+			result.TopTodos = topTodos(tf.Active, briefingTodos)
+		}
+	}
+
+	if b.calendar != nil {
+		if events, err := b.calendar.Agenda(ctx, today); err != nil {
+			slog.Warn("briefing: reading the calendar failed", "error", err)
+			result.Unavailable = append(result.Unavailable, "calendar")
+		} else {
+			result.Events = events
+		}
+	}
+	if b.streak != nil {
+		if days, contributed, err := b.streak.Streak(ctx); err != nil {
+			slog.Warn("briefing: reading GitHub activity failed", "error", err)
+			result.Unavailable = append(result.Unavailable, "GitHub")
+		} else {
+			result.Streak = &StreakItem{Days: days, ContributedToday: contributed}
+		}
+	}
+	return result, nil
+}
+
+// topTodos returns up to n active todos, most urgent first and otherwise
+// in file order, leaving out someday todos.
+func topTodos(active []storage.Todo, n int) []TodayItem {
+	var todos []storage.Todo
+	for _, t := range active {
+		if priorityOrNormal(t.Priority) != storage.PrioritySomeday {
+			todos = append(todos, t)
+		}
+	}
+	sort.SliceStable(todos, func(i, j int) bool {
+		return priorityRank(string(priorityOrNormal(todos[i].Priority))) < priorityRank(string(priorityOrNormal(todos[j].Priority)))
+	})
+	if len(todos) > n {
+		todos = todos[:n]
+	}
+	items := make([]TodayItem, len(todos))
+	for i, t := range todos {
+		items[i] = TodayItem{ID: t.ID, Text: t.Text}
+	}
+	return items
+}
+
+// Subject is the briefing's one-line title, e.g. for an email subject.
+func (r BriefingResult) Subject() string {
+	title := "Momentum briefing"
+	if d, err := time.Parse("2006-01-02", r.Date); err == nil {
+		title += " (" + d.Format("Mon Jan 2") + ")"
+	}
+	return title
+}
+
+func (r BriefingResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Good morning! Briefing for %s\n", r.Date)
+	lines := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n%s\n", title)
+		for _, l := range lines {
+			sb.WriteString("- " + l + "\n")
+		}
+	}
+	items := func(title string, items []TodayItem) {
+		var ls []string
+		for _, item := range items {
+			ls = append(ls, item.Text+itemDetails("id "+item.ID, labeled("due", item.Due)))
+		}
+		lines(title, ls)
+	}
+	lines("Calendar", r.Events)
+	items("Overdue reminders", r.OverdueReminders)
+	items("Reminders due today", r.DueReminders)
+	items("Top todos", r.TopTodos)
+	items(fmt.Sprintf("Milestones due within %d days", todayMilestoneDays), r.Milestones)
+
+	if s := r.Streak; s != nil {
+		sb.WriteString("\nGitHub streak\n")
+		switch {
+		case s.Days == 0:
+			sb.WriteString("- No current streak: a contribution today starts one\n")
+		case s.ContributedToday:
+			fmt.Fprintf(&sb, "- %s, safe for today\n", plural(s.Days, "day"))
+		default:
+			fmt.Fprintf(&sb, "- %s: contribute today to keep it going\n", plural(s.Days, "day"))
+		}
+	}
+	if len(r.Unavailable) > 0 {
+		fmt.Fprintf(&sb, "\n(Unavailable: %s)\n", strings.Join(r.Unavailable, ", "))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type fakeAgenda []string
+
+func (f fakeAgenda) Agenda(ctx context.Context, day time.Time) ([]string, error) {
+	return f, nil
+}
+
+type fakeStreak struct {
+	days        int
+	contributed bool
+	err         error
+}
+
+func (f fakeStreak) Streak(ctx context.Context) (int, bool, error) {
+	return f.days, f.contributed, f.err
+}
+
+func TestGetBriefing(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.TodosFile: "# Active Todos\n\n## Normal\n- [ ] Tidy up {id:bbbb2222}\n\n## Someday\n- [ ] Learn Rust {id:cccc3333}\n\n" +
+			"## Urgent\n- [ ] Fix prod {id:aaaa1111}\n\n# Completed\n",
+		storage.RemindersFile: "## Upcoming\n- 2026-02-10: Call bank {id:dddd4444}\n",
+	})
+	clk := clock.NewFake(time.Date(2026, 2, 10, 7, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name   string
+		streak ContributionStreak
+		want   []string
+	}{
+		{"streak at risk", fakeStreak{days: 12}, []string{"12 days: contribute today"}},
+		{"streak safe", fakeStreak{days: 12, contributed: true}, []string{"12 days, safe for today"}},
+		{"github down", fakeStreak{err: errors.New("boom")}, []string{"(Unavailable: GitHub)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+			NewBriefingTools(files, clk, fakeAgenda{"09:30-10:00 Standup"}, tt.streak).Register(server)
+
+			res := callOverMCP(t, server, "get_briefing", map[string]any{})
+			raw, _ := json.Marshal(res.StructuredContent)
+			var out GetBriefingOutput
+			if err := json.Unmarshal(raw, &out); err != nil {
+				t.Fatal(err)
+			}
+			r := out.Result
+			if !out.Success || r == nil || r.Date != "2026-02-10" {
+				t.Fatalf("unexpected result %s", raw)
+			}
+			var todos []string
+			for _, item := range r.TopTodos {
+				todos = append(todos, item.ID)
+			}
+			if got := strings.Join(todos, ","); got != "aaaa1111,bbbb2222" {
+				t.Errorf("top todos = %s", got)
+			}
+			if len(r.DueReminders) != 1 || len(r.Events) != 1 {
+				t.Errorf("unexpected result %s", raw)
+			}
+			for _, want := range append(tt.want, "09:30-10:00 Standup", "Call bank") {
+				if !strings.Contains(out.Message, want) {
+					t.Errorf("message %q lacks %q", out.Message, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetChangesInput is the input schema for the get_changes tool.
+type GetChangesInput struct {
+	Since string `json:"since,omitempty" jsonschema:"Report changes made after this: a date (YYYY-MM-DD, today, or yesterday), meaning the start of that day, or an RFC 3339 time. Default: yesterday"`
+}
+
+// GetChangesOutput is the output for the get_changes tool.
+type GetChangesOutput struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Result  *ChangesResult `json:"result,omitempty"`
+}
+
+// ChangesResult is the response payload for get_changes.
+type ChangesResult struct {
+	Since   string       `json:"since"`
+	Changes []ItemChange `json:"changes"`
+	// Unknown lists the files whose version at since is older than the
+	// commits checked, so their changes aren't reported.
+	Unknown []string `json:"unknown,omitempty"`
+}
+
+// ItemChange is one item's change since the given time.
+type ItemChange struct {
+	File string `json:"file"`
+	ID   string `json:"id"`
+	// Change is added, edited, moved, completed, reopened, or removed.
+	Change string `json:"change"`
+	// Line is the item's markdown line now; empty if removed.
+	Line string `json:"line,omitempty"`
+	// Before is the item's line at since, if it was in the file then.
+	Before string `json:"before,omitempty"`
+	// Section is the heading the item is under now.
+	Section string `json:"section,omitempty"`
+}
+
+func (t *HistoryTools) getChanges(ctx context.Context, req *mcp.CallToolRequest, input GetChangesInput) (*mcp.CallToolResult, GetChangesOutput, error) {
+	now := t.clock.Now()
+	since, err := parseSince(input.Since, now)
+	if err != nil {
+		return nil, GetChangesOutput{
+			Success: false,
+			Message: "Invalid since: use YYYY-MM-DD, today, yesterday, or an RFC 3339 time",
+		}, nil
+	}
+	if since.After(now) {
+		return nil, GetChangesOutput{
+			Success: false,
+			Message: "since is in the future",
+		}, nil
+	}
+
+	result := ChangesResult{Since: since.Format(time.RFC3339), Changes: []ItemChange{}}
+	for _, file := range historyFiles {
+		current, _, err := t.storage.ReadFile(ctx, file)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return nil, GetChangesOutput{}, fmt.Errorf("reading %s: %w", file, err)
+		}
+		before, ok, err := storage.ReadFileAsOf(ctx, t.history, file, since, maxHistoryCommits)
+		if err != nil {
+			return nil, GetChangesOutput{}, err
+		}
+		if !ok {
+			result.Unknown = append(result.Unknown, file)
+			continue
+		}
+		result.Changes = append(result.Changes, itemChanges(file, before, current)...)
+	}
+
+	text := result.text(now.Location())
+	return textResult(text), GetChangesOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+// parseSince parses get_changes' since: an RFC 3339 time, or a date (see
+// parseDate) meaning the start of that day in now's timezone. Empty means
+// yesterday.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if strings.TrimSpace(s) == "" {
+		s = "yesterday"
+	}
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(s)); err == nil {
+		return t, nil
+	}
+	d, err := parseDate(s, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, now.Location()), nil
+}
+
+// itemChanges compares the items of two versions of a data file: those
+// changed or added in file order, then those removed.
+func itemChanges(file, before, after string) []ItemChange {
+	old := make(map[string]storage.ItemLine)
+	for _, l := range storage.ItemLines(before) {
+		old[l.ID] = l
+	}
+
+	var changes []ItemChange
+	seen := make(map[string]bool)
+	for _, l := range storage.ItemLines(after) {
+		seen[l.ID] = true
+		prev, existed := old[l.ID]
+		if existed && prev.Line == l.Line && prev.Section == l.Section {
+			continue
+		}
+		change := ItemChange{File: file, ID: l.ID, Change: "added", Line: l.Line, Section: l.Section}
+		if existed {
+			change.Change = changeEvent(
+				storage.ItemVersion{Section: prev.Section, Line: prev.Line},
+				storage.ItemVersion{Section: l.Section, Line: l.Line})
+			change.Before = prev.Line
+		}
+		changes = append(changes, change)
+	}
+	for _, l := range storage.ItemLines(before) {
+		if !seen[l.ID] {
+			changes = append(changes, ItemChange{File: file, ID: l.ID, Change: "removed", Before: l.Line})
+		}
+	}
+	return changes
+}
+
+func (r ChangesResult) text(loc *time.Location) string {
+	since, _ := time.Parse(time.RFC3339, r.Since)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Changes since %s: %s\n", since.In(loc).Format("2006-01-02 15:04"), plural(len(r.Changes), "item"))
+	for _, c := range r.Changes {
+		line := c.Line
+		if c.Change == "removed" {
+			line = c.Before
+		}
+		fmt.Fprintf(&sb, "- %s (%s): %s\n", c.Change, c.File, line)
+	}
+	if len(r.Unknown) > 0 {
+		fmt.Fprintf(&sb, "Not checked (too many commits since then): %s\n", strings.Join(r.Unknown, ", "))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
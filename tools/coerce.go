@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// priorityAliases maps common alternative spellings seen in tool calls to the
+// canonical priorities. Only unambiguous synonyms are accepted.
+var priorityAliases = map[string]storage.Priority{
+	"urgent":    storage.PriorityUrgent,
+	"critical":  storage.PriorityUrgent,
+	"asap":      storage.PriorityUrgent,
+	"p0":        storage.PriorityUrgent,
+	"high":      storage.PriorityHigh,
+	"important": storage.PriorityHigh,
+	"p1":        storage.PriorityHigh,
+	"normal":    storage.PriorityNormal,
+	"medium":    storage.PriorityNormal,
+	"default":   storage.PriorityNormal,
+	"p2":        storage.PriorityNormal,
+	"someday":   storage.PrioritySomeday,
+	"low":       storage.PrioritySomeday,
+	"later":     storage.PrioritySomeday,
+	"p3":        storage.PrioritySomeday,
+}
+
+// parsePriority converts a priority string (case-insensitive, aliases allowed)
+// to a storage.Priority. Returns false if the value is not recognised.
+func parsePriority(s string) (storage.Priority, bool) {
+	p, ok := priorityAliases[strings.ToLower(strings.TrimSpace(s))]
+	return p, ok
+}
+
+// parseReadingPriority converts a reading list priority (next or someday,
+// case-insensitive) to its stored form. "none" clears the priority and maps
+// to "". Returns false if the value is not recognised.
+func parseReadingPriority(s string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case storage.ReadingPriorityNext:
+		return storage.ReadingPriorityNext, true
+	case storage.ReadingPrioritySomeday, "later":
+		return storage.ReadingPrioritySomeday, true
+	case "none":
+		return "", true
+	}
+	return "", false
+}
+
+// dateLayouts are the accepted date formats, canonical first.
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006-1-2",
+	"2006/01/02",
+	"2006/1/2",
+	time.RFC3339,
+}
+
+// parseDate parses a date in YYYY-MM-DD form, tolerating slashes, missing
+// zero padding, full RFC 3339 timestamps, and the words today, tomorrow,
+// and yesterday (relative to now, in its timezone). The result is midnight
+// UTC of the date.
+func parseDate(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	today := clock.Date(now)
+
+	switch strings.ToLower(s) {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			// Keep the calendar date as written, regardless of any offset
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date %q", s)
+}
@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected storage.Priority
+		ok       bool
+	}{
+		{"high", storage.PriorityHigh, true},
+		{" HIGH ", storage.PriorityHigh, true},
+		{"urgent", storage.PriorityUrgent, true},
+		{"P0", storage.PriorityUrgent, true},
+		{"medium", storage.PriorityNormal, true},
+		{"low", storage.PrioritySomeday, true},
+		{"whenever", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parsePriority(tt.input)
+		if ok != tt.ok || got != tt.expected {
+			t.Errorf("parsePriority(%q) = (%q, %v), expected (%q, %v)", tt.input, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2025, 6, 30, 23, 15, 0, 0, time.UTC)
+	expected := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	for _, input := range []string{"2025-03-04", "2025-3-4", "2025/03/04", "2025-03-04T15:30:00Z"} {
+		got, err := parseDate(input, now)
+		if err != nil {
+			t.Errorf("parseDate(%q) returned error: %v", input, err)
+			continue
+		}
+		if !got.Equal(expected) {
+			t.Errorf("parseDate(%q) = %v, expected %v", input, got, expected)
+		}
+	}
+
+	if got, _ := parseDate("tomorrow", now); !got.Equal(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("parseDate(tomorrow) = %v", got)
+	}
+
+	// 23:15 UTC on June 30th is already July 1st in Auckland
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := parseDate("today", now.In(auckland)); !got.Equal(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("parseDate(today) in Auckland = %v", got)
+	}
+
+	if _, err := parseDate("next week", now); err == nil {
+		t.Error("expected error for unrecognised date")
+	}
+}
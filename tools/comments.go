@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CommentTools records comments on todos and milestones: context such as
+// "blocked on vendor reply" that doesn't belong in the item's text.
+type CommentTools struct {
+	todos      *entitystore.Store[storage.TodoFile, storage.Todo]
+	milestones *entitystore.Store[storage.Strategy, storage.Milestone]
+	clock      clock.Clock
+}
+
+// NewCommentTools creates a new CommentTools instance. A nil clock uses the system clock.
+func NewCommentTools(s storage.Storage, c clock.Clock) *CommentTools {
+	return &CommentTools{
+		todos:      entitystore.New(s, todoKind),
+		milestones: entitystore.New(s, milestoneKind),
+		clock:      clock.Or(c),
+	}
+}
+
+// AddCommentInput is the input schema for the add_comment tool.
+type AddCommentInput struct {
+	ID             string `json:"id" jsonschema:"ID of the todo or milestone to comment on. Use list_todos or get_milestones to find IDs."`
+	Text           string `json:"text" jsonschema:"The comment, e.g. blocked on vendor reply"`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// AddCommentOutput is the output for the add_comment tool.
+type AddCommentOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// ID and Item are the commented item, with all its comments, set on
+	// success. Type says which kind it is: todo (a TodoItem) or milestone
+	// (a MilestoneItem).
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type,omitempty"`
+	Item any    `json:"item,omitempty"`
+}
+
+// Register registers comment tools with the MCP server.
+func (t *CommentTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "add_comment",
+		Description: "Add a timestamped comment to a todo or milestone, e.g. progress or what it's waiting on. get_item shows an item's comments.",
+	}, t.addComment)
+}
+
+func (t *CommentTools) addComment(ctx context.Context, req *mcp.CallToolRequest, input AddCommentInput) (*mcp.CallToolResult, AddCommentOutput, error) {
+	id := strings.TrimSpace(input.ID)
+	if id == "" {
+		return nil, AddCommentOutput{Success: false, Message: "id is required"}, nil
+	}
+	// Comments are one line each
+	text := strings.Join(strings.Fields(input.Text), " ")
+	if text == "" {
+		return nil, AddCommentOutput{Success: false, Message: "Comment text cannot be empty"}, nil
+	}
+	comment := storage.Comment{At: t.clock.Now().Truncate(time.Minute), Text: text}
+
+	todo, found, err := addComment(ctx, t.todos, storage.TodosFile, id, input.IfUnchangedSHA, func(todo *storage.Todo) string {
+		todo.Comments = append(todo.Comments, comment)
+		return fmt.Sprintf("Comment on todo: %s", truncate(todo.Text, 50))
+	})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, AddCommentOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, AddCommentOutput{}, err
+	}
+	if found {
+		item := todoToItem(todo)
+		return nil, AddCommentOutput{
+			Success: true,
+			Message: fmt.Sprintf("Added comment to todo %q (%s)", todo.Text, plural(len(todo.Comments), "comment")),
+			ID:      item.ID,
+			Type:    "todo",
+			Item:    &item,
+		}, nil
+	}
+
+	milestone, found, err := addComment(ctx, t.milestones, storage.StrategyFile, id, input.IfUnchangedSHA, func(m *storage.Milestone) string {
+		m.Comments = append(m.Comments, comment)
+		return fmt.Sprintf("Comment on milestone: %s", truncate(m.Text, 50))
+	})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, AddCommentOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, AddCommentOutput{}, err
+	}
+	if found {
+		item := milestoneToItem(milestone)
+		return nil, AddCommentOutput{
+			Success: true,
+			Message: fmt.Sprintf("Added comment to milestone %q (%s)", milestone.Text, plural(len(milestone.Comments), "comment")),
+			ID:      item.ID,
+			Type:    "milestone",
+			Item:    &item,
+		}, nil
+	}
+
+	return nil, AddCommentOutput{
+		Success: false,
+		Message: fmt.Sprintf("No todo or milestone found with id %q", id),
+	}, nil
+}
+
+// addComment applies add to the item with id in store's file and saves it.
+// found is false if the file doesn't exist or has no such item. The
+// if_unchanged_sha check applies to the file holding the item.
+func addComment[F, T any](ctx context.Context, store *entitystore.Store[F, T], path, id, ifUnchangedSHA string, add func(*T) string) (item T, found bool, err error) {
+	f, sha, err := store.Load(ctx, "")
+	if errors.Is(err, storage.ErrNotFound) {
+		return item, false, nil
+	}
+	if err != nil {
+		return item, false, err
+	}
+	list, i, err := store.Find(f, entitystore.Both, entitystore.Ref{ID: id})
+	if err != nil {
+		return item, false, nil
+	}
+	if msg := entitystore.CheckUnchanged(path, ifUnchangedSHA, sha); msg != "" {
+		return item, true, &entitystore.Error{Message: msg}
+	}
+	message := add(&(*list)[i])
+	if err := store.Save(ctx, f, sha, message); err != nil {
+		return item, true, err
+	}
+	return (*list)[i], true, nil
+}
@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestComments(t *testing.T) {
+	ctx := context.Background()
+	files := newFileStorage(map[string]string{
+		storage.TodosFile:    "# Active Todos\n\n## Normal\n- [ ] Ship it {id:aaaa1111}\n",
+		storage.StrategyFile: "## Active Milestones\n- [ ] Launch {id:bbbb2222}\n",
+	})
+	clk := clock.NewFake(time.Date(2026, 2, 10, 9, 30, 15, 0, time.UTC))
+	comments := NewCommentTools(files, clk)
+	items := NewItemTools(files, clk)
+
+	_, out, err := comments.addComment(ctx, nil, AddCommentInput{ID: "aaaa1111", Text: "Blocked on\nvendor reply"})
+	if todo, ok := out.Item.(*TodoItem); err != nil || !out.Success || out.ID != "aaaa1111" || out.Type != "todo" || !ok || len(todo.Comments) != 1 {
+		t.Fatalf("addComment(todo) = %+v, %v", out, err)
+	}
+	if !strings.Contains(fileContent(t, files, storage.TodosFile), "- [ ] Ship it {id:aaaa1111}\n  > 2026-02-10 09:30 Blocked on vendor reply\n") {
+		t.Errorf("unexpected todos.md:\n%s", fileContent(t, files, storage.TodosFile))
+	}
+
+	clk.Advance(time.Hour)
+	if _, out, _ := comments.addComment(ctx, nil, AddCommentInput{ID: "bbbb2222", Text: "Needs a landing page"}); !out.Success || out.ID != "bbbb2222" || out.Type != "milestone" {
+		t.Fatalf("addComment(milestone) = %+v", out)
+	} else if m, ok := out.Item.(*MilestoneItem); !ok || len(m.Comments) != 1 {
+		t.Fatalf("addComment(milestone) = %+v", out)
+	}
+	if _, out, _ := comments.addComment(ctx, nil, AddCommentInput{ID: "bbbb2222", Text: "Stale", IfUnchangedSHA: "old"}); out.Success {
+		t.Error("addComment ignored if_unchanged_sha")
+	}
+	if _, out, _ := comments.addComment(ctx, nil, AddCommentInput{ID: "zzzz9999", Text: "Nowhere"}); out.Success {
+		t.Error("addComment accepted an unknown ID")
+	}
+	if _, out, _ := comments.addComment(ctx, nil, AddCommentInput{ID: "aaaa1111", Text: "  "}); out.Success {
+		t.Error("addComment accepted an empty comment")
+	}
+
+	_, got, err := items.getItem(ctx, nil, GetItemInput{ID: "bbbb2222"})
+	if err != nil || !got.Success || got.Result.Type != "milestone" {
+		t.Fatalf("getItem() = %+v, %v", got, err)
+	}
+	if c := got.Result.Milestone.Comments; len(c) != 1 || c[0].At != "2026-02-10 10:30" {
+		t.Errorf("unexpected milestone comments %+v", c)
+	}
+	if !strings.Contains(got.Message, "  > 2026-02-10 10:30 Needs a landing page") {
+		t.Errorf("unexpected text:\n%s", got.Message)
+	}
+	if _, got, _ := items.getItem(ctx, nil, GetItemInput{ID: "aaaa1111"}); got.Result == nil || got.Result.Todo.Comments[0].Text != "Blocked on vendor reply" {
+		t.Errorf("getItem(todo) = %+v", got)
+	}
+	if _, got, _ := items.getItem(ctx, nil, GetItemInput{ID: "zzzz9999"}); got.Success {
+		t.Error("getItem found an unknown ID")
+	}
+}
@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// TestIfUnchangedSHA writes through tools that check if_unchanged_sha
+// themselves and through entitystore, with a matching, a stale and no sha.
+func TestIfUnchangedSHA(t *testing.T) {
+	ctx := context.Background()
+	fake := clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC))
+	writes := []struct {
+		name  string
+		path  string
+		seed  string
+		write func(s storage.Storage, sha string) (bool, string)
+	}{
+		{"add_journal_entry", storage.JournalFile, "# Journal\n", func(s storage.Storage, sha string) (bool, string) {
+			_, out, err := NewJournalTools(s, fake).addJournalEntry(ctx, nil, AddJournalEntryInput{Text: "Shipped", IfUnchangedSHA: sha})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return out.Success, out.Message
+		}},
+		{"set_contribution_goal", storage.GoalsFile, "# Goals\n", func(s storage.Storage, sha string) (bool, string) {
+			commits := 10
+			_, out, err := NewGoalTools(s).setContributionGoal(ctx, nil, SetContributionGoalInput{WeeklyCommits: &commits, IfUnchangedSHA: sha})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return out.Success, out.Message
+		}},
+		{"complete_todo", storage.TodosFile, "# Active Todos\n\n## High Priority\n- [ ] Ship it {id:aaaa1111,added:2026-02-01}\n", func(s storage.Storage, sha string) (bool, string) {
+			_, out, err := NewTodoTools(s, fake, WIPLimits{}).completeTodo(ctx, nil, CompleteTodoInput{ID: "aaaa1111", IfUnchangedSHA: sha})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return out.Success, out.Message
+		}},
+	}
+
+	for _, w := range writes {
+		t.Run(w.name, func(t *testing.T) {
+			for _, tc := range []struct {
+				name  string
+				sha   func(current string) string
+				wrote bool
+			}{
+				{"matching", func(current string) string { return current }, true},
+				{"stale", func(string) string { return "0000000000000000000000000000000000000000" }, false},
+				{"empty", func(string) string { return "" }, true},
+			} {
+				mem := storage.NewMemoryStorage(map[string]string{w.path: w.seed}, nil)
+				_, sha, _ := mem.ReadFile(ctx, w.path)
+				ok, msg := w.write(mem, tc.sha(sha))
+				content, _, _ := mem.ReadFile(ctx, w.path)
+				if wrote := content != w.seed; ok != tc.wrote || wrote != tc.wrote {
+					t.Errorf("%s sha: success %v, file changed %v, want %v (%s)", tc.name, ok, wrote, tc.wrote, msg)
+				}
+				if !tc.wrote && !strings.Contains(msg, "has changed since you read it") {
+					t.Errorf("%s sha: message %q", tc.name, msg)
+				}
+			}
+		})
+	}
+}
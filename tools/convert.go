@@ -0,0 +1,253 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ConvertTools moves items between entity types. The item leaves one file and
+// joins the other in a single commit, keeping its added date and its ID
+// under the new type's prefix.
+type ConvertTools struct {
+	storage storage.Storage
+	clock   clock.Clock
+}
+
+// NewConvertTools creates a new ConvertTools instance. A nil clock uses the system clock.
+func NewConvertTools(s storage.Storage, c clock.Clock) *ConvertTools {
+	return &ConvertTools{storage: s, clock: clock.Or(c)}
+}
+
+// PromoteTodoInput is the input schema for the promote_todo_to_milestone tool.
+type PromoteTodoInput struct {
+	ID  string `json:"id" jsonschema:"ID of the active todo to promote. Use list_todos to find IDs."`
+	Due string `json:"due,omitempty" jsonschema:"Due date for the milestone in YYYY-MM-DD format. Optional."`
+}
+
+// PromoteTodoOutput is the output for the promote_todo_to_milestone tool.
+type PromoteTodoOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// ID and Item are the new milestone, set on success.
+	ID   string         `json:"id,omitempty"`
+	Item *MilestoneItem `json:"item,omitempty"`
+}
+
+// ConvertReminderInput is the input schema for the convert_reminder_to_todo tool.
+type ConvertReminderInput struct {
+	ID       string `json:"id" jsonschema:"ID of the pending reminder to convert. Use list_reminders to find IDs."`
+	Priority string `json:"priority,omitempty" jsonschema:"Priority for the todo: urgent, high, normal, or someday. Defaults to normal."`
+}
+
+// ConvertReminderOutput is the output for the convert_reminder_to_todo tool.
+type ConvertReminderOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// ID and Item are the new todo, set on success.
+	ID   string    `json:"id,omitempty"`
+	Item *TodoItem `json:"item,omitempty"`
+}
+
+// Register registers conversion tools with the MCP server.
+func (t *ConvertTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "promote_todo_to_milestone",
+		Description: "Turn an active todo into a strategy milestone in one step, keeping its ID, project and added date",
+	}, t.promoteTodo)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "convert_reminder_to_todo",
+		Description: "Turn a pending reminder into an active todo in one step, keeping its ID and added date",
+	}, t.convertReminder)
+}
+
+func (t *ConvertTools) promoteTodo(ctx context.Context, req *mcp.CallToolRequest, input PromoteTodoInput) (*mcp.CallToolResult, PromoteTodoOutput, error) {
+	id := strings.TrimSpace(input.ID)
+	if id == "" {
+		return nil, PromoteTodoOutput{
+			Success: false,
+			Message: "id is required",
+		}, nil
+	}
+
+	var due *time.Time
+	if strings.TrimSpace(input.Due) != "" {
+		d, err := parseDate(input.Due, t.clock.Now())
+		if err != nil {
+			return nil, PromoteTodoOutput{
+				Success: false,
+				Message: fmt.Sprintf("Invalid due date %q. Use YYYY-MM-DD format.", input.Due),
+			}, nil
+		}
+		due = &d
+	}
+
+	todosContent, todosSHA, err := t.storage.ReadFile(ctx, storage.TodosFile)
+	if err != nil {
+		return nil, PromoteTodoOutput{}, fmt.Errorf("reading todos.md: %w", err)
+	}
+	tf, err := parseTodos(ctx, todosContent)
+	if err != nil {
+		return nil, PromoteTodoOutput{}, fmt.Errorf("parsing todos: %w", err)
+	}
+
+	idx := -1
+	for i, todo := range tf.Active {
+		if todo.ID == id {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, PromoteTodoOutput{
+			Success: false,
+			Message: fmt.Sprintf("No active todo found with id %q", id),
+		}, nil
+	}
+	todo := tf.Active[idx]
+
+	strategyContent, strategySHA, err := t.storage.ReadFile(ctx, storage.StrategyFile)
+	if err != nil {
+		return nil, PromoteTodoOutput{}, fmt.Errorf("reading strategy.md: %w", err)
+	}
+	s, err := parseStrategy(ctx, strategyContent)
+	if err != nil {
+		return nil, PromoteTodoOutput{}, fmt.Errorf("parsing strategy: %w", err)
+	}
+
+	milestone := storage.Milestone{
+		ID:      storage.ConvertID(todo.ID, storage.PrefixMilestone, takenIn(milestoneKind.ID, s.ActiveMilestones, s.CompletedMilestones)),
+		Text:    todo.Text,
+		Due:     due,
+		Project: todo.Project,
+		Added:   todo.Added,
+	}
+	tf.Active = append(tf.Active[:idx], tf.Active[idx+1:]...)
+	s.ActiveMilestones = append(s.ActiveMilestones, milestone)
+
+	changes := []storage.FileChange{
+		{Path: storage.TodosFile, Content: storage.SerializeTodos(tf), SHA: todosSHA},
+		{Path: storage.StrategyFile, Content: storage.SerializeStrategy(s), SHA: strategySHA},
+	}
+	if err := storage.WriteFiles(ctx, t.storage, changes, fmt.Sprintf("Promote todo to milestone: %s", truncate(todo.Text, 50))); err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, PromoteTodoOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, PromoteTodoOutput{}, fmt.Errorf("promoting todo: %w", err)
+	}
+
+	item := milestoneToItem(milestone)
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		return nil, PromoteTodoOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, PromoteTodoOutput{
+		Success: true,
+		Message: string(itemJSON),
+		ID:      item.ID,
+		Item:    &item,
+	}, nil
+}
+
+func (t *ConvertTools) convertReminder(ctx context.Context, req *mcp.CallToolRequest, input ConvertReminderInput) (*mcp.CallToolResult, ConvertReminderOutput, error) {
+	id := strings.TrimSpace(input.ID)
+	if id == "" {
+		return nil, ConvertReminderOutput{
+			Success: false,
+			Message: "id is required",
+		}, nil
+	}
+
+	priority := storage.PriorityNormal
+	if strings.TrimSpace(input.Priority) != "" {
+		p, ok := parsePriority(input.Priority)
+		if !ok {
+			return nil, ConvertReminderOutput{
+				Success: false,
+				Message: fmt.Sprintf("Invalid priority %q. Use: urgent, high, normal, or someday", input.Priority),
+			}, nil
+		}
+		priority = p
+	}
+
+	remindersContent, remindersSHA, err := t.storage.ReadFile(ctx, storage.RemindersFile)
+	if err != nil {
+		return nil, ConvertReminderOutput{}, fmt.Errorf("reading reminders.md: %w", err)
+	}
+	rf, err := parseReminders(ctx, remindersContent)
+	if err != nil {
+		return nil, ConvertReminderOutput{}, fmt.Errorf("parsing reminders: %w", err)
+	}
+
+	idx := -1
+	for i, r := range rf.Upcoming {
+		if r.ID == id {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, ConvertReminderOutput{
+			Success: false,
+			Message: fmt.Sprintf("No pending reminder found with id %q", id),
+		}, nil
+	}
+	reminder := rf.Upcoming[idx]
+
+	todosContent, todosSHA, err := t.storage.ReadFile(ctx, storage.TodosFile)
+	if err != nil {
+		return nil, ConvertReminderOutput{}, fmt.Errorf("reading todos.md: %w", err)
+	}
+	tf, err := parseTodos(ctx, todosContent)
+	if err != nil {
+		return nil, ConvertReminderOutput{}, fmt.Errorf("parsing todos: %w", err)
+	}
+
+	todo := storage.Todo{
+		ID:       storage.ConvertID(reminder.ID, storage.PrefixTodo, takenIn(todoKind.ID, tf.Active, tf.Completed)),
+		Text:     reminder.Text,
+		Priority: priority,
+		Added:    reminder.Added,
+	}
+	rf.Upcoming = append(rf.Upcoming[:idx], rf.Upcoming[idx+1:]...)
+	tf.Active = append(tf.Active, todo)
+
+	changes := []storage.FileChange{
+		{Path: storage.RemindersFile, Content: storage.SerializeReminders(rf), SHA: remindersSHA},
+		{Path: storage.TodosFile, Content: storage.SerializeTodos(tf), SHA: todosSHA},
+	}
+	if err := storage.WriteFiles(ctx, t.storage, changes, fmt.Sprintf("Convert reminder to todo: %s", truncate(reminder.Text, 50))); err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, ConvertReminderOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, ConvertReminderOutput{}, fmt.Errorf("converting reminder: %w", err)
+	}
+
+	item := todoToItem(todo)
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		return nil, ConvertReminderOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, ConvertReminderOutput{
+		Success: true,
+		Message: string(itemJSON),
+		ID:      item.ID,
+		Item:    &item,
+	}, nil
+}
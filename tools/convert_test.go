@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestPromoteTodoToMilestone(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.TodosFile:    "# Active Todos\n\n## High Priority\n- [ ] Launch beta {id:aaaa1111,project:app,added:2026-02-01}\n\n## Normal\n- [ ] Tidy {id:bbbb2222}\n\n# Completed\n",
+		storage.StrategyFile: "## Current Phase\nLaunch\n\n## Active Milestones\n\n## Notes\n",
+	})
+	tools := NewConvertTools(files, nil)
+
+	_, out, err := tools.promoteTodo(context.Background(), nil, PromoteTodoInput{ID: "aaaa1111", Due: "2026-03-01"})
+	if err != nil || !out.Success {
+		t.Fatalf("promoteTodo() = %+v, %v", out, err)
+	}
+
+	tf, _ := storage.ParseTodos(fileContent(t, files, storage.TodosFile))
+	if len(tf.Active) != 1 || tf.Active[0].ID != "bbbb2222" {
+		t.Errorf("expected the todo to be removed, got %+v", tf.Active)
+	}
+	s, _ := storage.ParseStrategy(fileContent(t, files, storage.StrategyFile))
+	if len(s.ActiveMilestones) != 1 {
+		t.Fatalf("expected one milestone, got %+v", s.ActiveMilestones)
+	}
+	m := s.ActiveMilestones[0]
+	if m.ID != "aaaa1111" || m.Text != "Launch beta" || m.Project != "app" || formatDate(m.Added) != "2026-02-01" || m.Due == nil || formatDate(*m.Due) != "2026-03-01" {
+		t.Errorf("unexpected milestone %+v", m)
+	}
+
+	if _, out, _ := tools.promoteTodo(context.Background(), nil, PromoteTodoInput{ID: "aaaa1111"}); out.Success || !strings.Contains(out.Message, "No active todo") {
+		t.Errorf("expected a missing todo error, got %+v", out)
+	}
+}
+
+func TestConvertReminderToTodo(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.RemindersFile: "## Upcoming\n- 2026-02-10: Call bank {id:cccc3333,added:2026-02-01}\n\n## Completed\n",
+		storage.TodosFile:     "# Active Todos\n\n# Completed\n",
+	})
+
+	_, out, err := NewConvertTools(files, nil).convertReminder(context.Background(), nil, ConvertReminderInput{ID: "cccc3333", Priority: "high"})
+	if err != nil || !out.Success {
+		t.Fatalf("convertReminder() = %+v, %v", out, err)
+	}
+
+	rf, _ := storage.ParseReminders(fileContent(t, files, storage.RemindersFile))
+	if len(rf.Upcoming) != 0 {
+		t.Errorf("expected the reminder to be removed, got %+v", rf.Upcoming)
+	}
+	tf, _ := storage.ParseTodos(fileContent(t, files, storage.TodosFile))
+	if len(tf.Active) != 1 {
+		t.Fatalf("expected one todo, got %+v", tf.Active)
+	}
+	todo := tf.Active[0]
+	if todo.ID != "cccc3333" || todo.Text != "Call bank" || todo.Priority != storage.PriorityHigh || formatDate(todo.Added) != "2026-02-01" {
+		t.Errorf("unexpected todo %+v", todo)
+	}
+}
@@ -2,22 +2,26 @@ package tools
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"time"
+	"log/slog"
 
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
 	"github.com/dang-w/momentum-mcp-server/storage"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // DashboardTools provides an aggregate dashboard view across all entity types.
 type DashboardTools struct {
-	storage storage.Storage
+	storage  storage.Storage
+	clock    clock.Clock
+	quota    SizeQuota
+	workload WorkloadLimits
 }
 
 // NewDashboardTools creates a new DashboardTools instance.
-func NewDashboardTools(s storage.Storage) *DashboardTools {
-	return &DashboardTools{storage: s}
+// A zero quota uses DefaultSizeQuota, zero limits use DefaultWorkloadLimits,
+// and a nil clock uses the system clock.
+func NewDashboardTools(s storage.Storage, c clock.Clock, quota SizeQuota, workload WorkloadLimits) *DashboardTools {
+	return &DashboardTools{storage: s, clock: clock.Or(c), quota: quota, workload: workload}
 }
 
 // GetDashboardInput is the input schema for the get_dashboard tool.
@@ -27,8 +31,9 @@ type GetDashboardInput struct {
 
 // GetDashboardOutput is the output for the get_dashboard tool.
 type GetDashboardOutput struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Result  *DashboardResult `json:"result,omitempty"`
 }
 
 // Dashboard response types
@@ -39,6 +44,17 @@ type DashboardResult struct {
 	Reminders   DashboardReminders `json:"reminders"`
 	ReadingList DashboardReading  `json:"reading_list"`
 	Strategy    DashboardStrategy `json:"strategy"`
+	Storage     DashboardStorage  `json:"storage"`
+	Workload    WorkloadCheck     `json:"workload"`
+	// Focus is today's focus, if one is set.
+	Focus *FocusResult `json:"focus,omitempty"`
+}
+
+// DashboardStorage reports data file sizes and soft quota warnings.
+type DashboardStorage struct {
+	FileBytes  map[string]int `json:"file_bytes"`
+	TotalBytes int            `json:"total_bytes"`
+	Warnings   []SizeWarning  `json:"warnings,omitempty"`
 }
 
 // DashboardTodos is the todos section of the dashboard.
@@ -47,6 +63,7 @@ type DashboardTodos struct {
 	Completed      []TodoItem `json:"completed,omitempty"`
 	ActiveCount    int        `json:"active_count"`
 	CompletedCount int        `json:"completed_count"`
+	SourceSHA      string     `json:"source_sha"`
 }
 
 // DashboardReminders is the reminders section of the dashboard.
@@ -55,6 +72,7 @@ type DashboardReminders struct {
 	Overdue        []ReminderItem `json:"overdue"`
 	Completed      []ReminderItem `json:"completed,omitempty"`
 	CompletedCount int            `json:"completed_count"`
+	SourceSHA      string         `json:"source_sha"`
 }
 
 // DashboardReading is the reading list section of the dashboard.
@@ -62,6 +80,7 @@ type DashboardReading struct {
 	Unread    []ReadingListItem `json:"unread"`
 	Read      []ReadingListItem `json:"read,omitempty"`
 	ReadCount int               `json:"read_count"`
+	SourceSHA string            `json:"source_sha"`
 }
 
 // DashboardStrategy is the strategy section of the dashboard.
@@ -72,6 +91,7 @@ type DashboardStrategy struct {
 	CompletedCount  int             `json:"completed_count"`
 	RecentNotes     []string        `json:"recent_notes"`
 	TotalNotes      int             `json:"total_notes"`
+	SourceSHA       string          `json:"source_sha"`
 }
 
 // Register registers dashboard tools with the MCP server.
@@ -80,18 +100,34 @@ func (d *DashboardTools) Register(server *mcp.Server) {
 		Name:        "get_dashboard",
 		Description: "Get an aggregate summary of all Momentum data: todos, reminders, reading list, and strategy milestones. Ideal for morning check-ins and productivity overviews.",
 	}, d.getDashboard)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_today",
+		Description: "Get a compact agenda for today: overdue and due reminders, high-priority todos, milestones due within 7 days, and one suggested read. Use for morning check-ins; use get_dashboard for the full picture.",
+	}, d.getToday)
 }
 
 func (d *DashboardTools) getDashboard(ctx context.Context, req *mcp.CallToolRequest, input GetDashboardInput) (*mcp.CallToolResult, GetDashboardOutput, error) {
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+	today := clock.Today(d.clock)
 	sevenDaysFromNow := today.AddDate(0, 0, 7)
 
-	result := DashboardResult{}
+	// Empty sections are empty arrays, not null, in JSON and the output schema
+	result := DashboardResult{
+		Todos:       DashboardTodos{Active: []TodoItem{}},
+		Reminders:   DashboardReminders{Upcoming: []ReminderItem{}, Overdue: []ReminderItem{}},
+		ReadingList: DashboardReading{Unread: []ReadingListItem{}},
+		Strategy:    DashboardStrategy{Active: []MilestoneItem{}, RecentNotes: []string{}},
+	}
+	sizes := make(map[string]int)
+	var workload workloadInputs
+	files := storage.ReadFiles(ctx, d.storage, storage.TodosFile, storage.RemindersFile, storage.ReadingListFile, storage.StrategyFile, storage.FocusFile)
 
 	// Todos
-	todosContent, _, err := d.storage.ReadFile(ctx, "todos.md")
-	if err == nil {
-		tf, parseErr := storage.ParseTodos(todosContent)
+	todosContent, todosSHA := files[storage.TodosFile].Content, files[storage.TodosFile].SHA
+	if files[storage.TodosFile].Err == nil {
+		sizes[storage.TodosFile] = len(todosContent)
+		result.Todos.SourceSHA = todosSHA
+		tf, parseErr := parseTodos(ctx, todosContent)
 		if parseErr == nil {
 			active := make([]TodoItem, len(tf.Active))
 			for i, t := range tf.Active {
@@ -99,6 +135,7 @@ func (d *DashboardTools) getDashboard(ctx context.Context, req *mcp.CallToolRequ
 			}
 			result.Todos.Active = active
 			result.Todos.ActiveCount = len(tf.Active)
+			workload.todos = tf.Active
 			result.Todos.CompletedCount = len(tf.Completed)
 
 			if input.IncludeCompleted {
@@ -112,9 +149,11 @@ func (d *DashboardTools) getDashboard(ctx context.Context, req *mcp.CallToolRequ
 	}
 
 	// Reminders
-	remindersContent, _, err := d.storage.ReadFile(ctx, "reminders.md")
-	if err == nil {
-		rf, parseErr := storage.ParseReminders(remindersContent)
+	remindersContent, remindersSHA := files[storage.RemindersFile].Content, files[storage.RemindersFile].SHA
+	if files[storage.RemindersFile].Err == nil {
+		sizes[storage.RemindersFile] = len(remindersContent)
+		result.Reminders.SourceSHA = remindersSHA
+		rf, parseErr := parseReminders(ctx, remindersContent)
 		if parseErr == nil {
 			for _, r := range rf.Upcoming {
 				item := reminderToItem(r, today)
@@ -125,6 +164,7 @@ func (d *DashboardTools) getDashboard(ctx context.Context, req *mcp.CallToolRequ
 				}
 			}
 			result.Reminders.CompletedCount = len(rf.Completed)
+			workload.reminders = rf.Upcoming
 
 			if input.IncludeCompleted {
 				completed := make([]ReminderItem, len(rf.Completed))
@@ -136,18 +176,12 @@ func (d *DashboardTools) getDashboard(ctx context.Context, req *mcp.CallToolRequ
 		}
 	}
 
-	// Ensure nil slices become empty arrays in JSON
-	if result.Reminders.Upcoming == nil {
-		result.Reminders.Upcoming = []ReminderItem{}
-	}
-	if result.Reminders.Overdue == nil {
-		result.Reminders.Overdue = []ReminderItem{}
-	}
-
 	// Reading list
-	readingContent, _, err := d.storage.ReadFile(ctx, "reading-list.md")
-	if err == nil {
-		rl, parseErr := storage.ParseReadingList(readingContent)
+	readingContent, readingSHA := files[storage.ReadingListFile].Content, files[storage.ReadingListFile].SHA
+	if files[storage.ReadingListFile].Err == nil {
+		sizes[storage.ReadingListFile] = len(readingContent)
+		result.ReadingList.SourceSHA = readingSHA
+		rl, parseErr := parseReadingList(ctx, readingContent)
 		if parseErr == nil {
 			unread := make([]ReadingListItem, len(rl.ToRead))
 			for i, r := range rl.ToRead {
@@ -167,9 +201,11 @@ func (d *DashboardTools) getDashboard(ctx context.Context, req *mcp.CallToolRequ
 	}
 
 	// Strategy
-	strategyContent, _, err := d.storage.ReadFile(ctx, "strategy.md")
-	if err == nil {
-		s, parseErr := storage.ParseStrategy(strategyContent)
+	strategyContent, strategySHA := files[storage.StrategyFile].Content, files[storage.StrategyFile].SHA
+	if files[storage.StrategyFile].Err == nil {
+		sizes[storage.StrategyFile] = len(strategyContent)
+		result.Strategy.SourceSHA = strategySHA
+		s, parseErr := parseStrategy(ctx, strategyContent)
 		if parseErr == nil {
 			result.Strategy.CurrentPhase = s.CurrentPhase
 
@@ -179,6 +215,7 @@ func (d *DashboardTools) getDashboard(ctx context.Context, req *mcp.CallToolRequ
 			}
 			result.Strategy.Active = active
 			result.Strategy.CompletedCount = len(s.CompletedMilestones)
+			workload.milestones = s.ActiveMilestones
 			result.Strategy.TotalNotes = len(s.Notes)
 
 			// Recent notes: last 5
@@ -188,8 +225,6 @@ func (d *DashboardTools) getDashboard(ctx context.Context, req *mcp.CallToolRequ
 			}
 			if recentCount > 0 {
 				result.Strategy.RecentNotes = s.Notes[len(s.Notes)-recentCount:]
-			} else {
-				result.Strategy.RecentNotes = []string{}
 			}
 
 			if input.IncludeCompleted {
@@ -202,13 +237,42 @@ func (d *DashboardTools) getDashboard(ctx context.Context, req *mcp.CallToolRequ
 		}
 	}
 
-	jsonBytes, err := json.Marshal(result)
-	if err != nil {
-		return nil, GetDashboardOutput{}, fmt.Errorf("marshaling dashboard: %w", err)
+	// Today's focus against everything else
+	if focus := focusProgress(ctx, files, today); len(focus.Items) > 0 {
+		result.Focus = &focus
 	}
 
-	return nil, GetDashboardOutput{
+	// Data file sizes and soft quota warnings
+	result.Storage.FileBytes = sizes
+	for _, size := range sizes {
+		result.Storage.TotalBytes += size
+	}
+	result.Storage.Warnings = d.quota.check(sizes)
+	d.notifySizeWarnings(ctx, req, result.Storage.Warnings)
+
+	// Burnout guard
+	result.Workload = d.workload.check(workload, today)
+
+	text := result.text()
+	return textResult(text), GetDashboardOutput{
 		Success: true,
-		Message: string(jsonBytes),
+		Message: text,
+		Result:  &result,
 	}, nil
 }
+
+// notifySizeWarnings sends quota warnings to the client as MCP log
+// notifications (delivered only if the client has set a log level) and to
+// the server log.
+func (d *DashboardTools) notifySizeWarnings(ctx context.Context, req *mcp.CallToolRequest, warnings []SizeWarning) {
+	for _, w := range warnings {
+		slog.Warn("data file over soft quota", "file", w.File, "bytes", w.Bytes, "limit", w.Limit)
+		if req != nil && req.Session != nil {
+			req.Session.Log(ctx, &mcp.LoggingMessageParams{
+				Level:  "warning",
+				Logger: "momentum",
+				Data:   w.Message,
+			})
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// duplicateSimilarity is the normalized-text similarity from which a new
+// todo is reported as a likely duplicate of an existing one.
+const duplicateSimilarity = 0.8
+
+// normalizeText lowercases s, drops punctuation, and collapses whitespace.
+func normalizeText(s string) string {
+	return entitystore.NormalizeText(s)
+}
+
+// textSimilarity is the Dice coefficient of the character bigrams of the
+// normalized texts: 1 for identical text, 0 for nothing in common.
+func textSimilarity(a, b string) float64 {
+	a, b = normalizeText(a), normalizeText(b)
+	if a == b {
+		return 1
+	}
+	if len(a) < 2 || len(b) < 2 {
+		return 0
+	}
+	bigrams := map[string]int{}
+	ra, rb := []rune(a), []rune(b)
+	for i := 0; i < len(ra)-1; i++ {
+		bigrams[string(ra[i:i+2])]++
+	}
+	shared := 0
+	for i := 0; i < len(rb)-1; i++ {
+		if bg := string(rb[i : i+2]); bigrams[bg] > 0 {
+			bigrams[bg]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(ra)-1+len(rb)-1)
+}
+
+// similarTodos returns the active todos whose text is close to text.
+func similarTodos(todos []storage.Todo, text string) []storage.Todo {
+	var matches []storage.Todo
+	for _, todo := range todos {
+		if textSimilarity(todo.Text, text) >= duplicateSimilarity {
+			matches = append(matches, todo)
+		}
+	}
+	return matches
+}
+
+// trackingParams are query parameters that identify where a link was shared
+// rather than what it points to. Any utm_* parameter is also dropped.
+var trackingParams = map[string]bool{
+	"fbclid":  true,
+	"gclid":   true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"ref_src": true,
+	"igshid":  true,
+}
+
+// canonicalURL rewrites a URL to the form stored in the reading list:
+// https instead of http, host lowercased, tracking parameters removed.
+// Unparseable URLs are returned trimmed.
+func canonicalURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	if u.Scheme == "http" {
+		u.Scheme = "https"
+	}
+	u.Host = strings.ToLower(u.Host)
+
+	// Only re-encode the query if something was dropped, since encoding
+	// reorders the parameters
+	query := u.Query()
+	dropped := false
+	for key := range query {
+		if trackingParams[strings.ToLower(key)] || strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+			dropped = true
+		}
+	}
+	if dropped {
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+// normalizeURL returns the form of a URL used to spot duplicates: the
+// canonical URL without its fragment or trailing slash.
+func normalizeURL(raw string) string {
+	canonical := canonicalURL(raw)
+	u, err := url.Parse(canonical)
+	if err != nil || u.Host == "" {
+		return canonical
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	return u.String()
+}
+
+// sameURLItems returns the reading items whose URL normalizes to the same
+// form as rawURL.
+func sameURLItems(items []storage.ReadingItem, rawURL string) []storage.ReadingItem {
+	want := normalizeURL(rawURL)
+	var matches []storage.ReadingItem
+	for _, item := range items {
+		if normalizeURL(item.URL) == want {
+			matches = append(matches, item)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestTextSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		dup  bool
+	}{
+		{"Write the docs", "write the docs!", true},
+		{"Write API docs", "Write the API docs", true},
+		{"Renew domain", "Write API docs", false},
+		{"Fix login bug", "Fix logout bug", false},
+	}
+	for _, tt := range tests {
+		if got := textSimilarity(tt.a, tt.b) >= duplicateSimilarity; got != tt.dup {
+			t.Errorf("textSimilarity(%q, %q) = %.2f, duplicate = %v, want %v", tt.a, tt.b, textSimilarity(tt.a, tt.b), got, tt.dup)
+		}
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"https://Example.com/post/?utm_source=x&id=3#comments", "https://example.com/post?id=3"},
+		{"https://example.com/post/", "https://example.com/post"},
+		{"https://example.com/a?fbclid=abc", "https://example.com/a"},
+		{"http://example.com/a", "https://example.com/a"},
+		{"not a url", "not a url"},
+	}
+	for _, tt := range tests {
+		if got := normalizeURL(tt.in); got != tt.want {
+			t.Errorf("normalizeURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAddTodo_Duplicate(t *testing.T) {
+	files := newFileStorage(map[string]string{storage.TodosFile: "# Active Todos\n\n## Normal\n- [ ] Write the API docs {id:aaaa1111}\n\n# Completed\n"})
+	tt := NewTodoTools(files, clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)), WIPLimits{})
+
+	_, out, err := tt.addTodo(context.Background(), nil, AddTodoInput{Text: "write API docs"})
+	if err != nil || out.Success || len(out.Duplicates) != 1 || out.Duplicates[0].ID != "aaaa1111" {
+		t.Fatalf("addTodo() = %+v, %v; want a duplicate warning", out, err)
+	}
+
+	_, out, err = tt.addTodo(context.Background(), nil, AddTodoInput{Text: "write API docs", Force: true})
+	if err != nil || !out.Success {
+		t.Errorf("addTodo(force) = %+v, %v", out, err)
+	}
+}
+
+func TestAddToReadingList_Duplicate(t *testing.T) {
+	files := newFileStorage(map[string]string{storage.ReadingListFile: "# Reading List\n\n## To Read\n\n## Read\n- [x] https://example.com/post {id:aaaa1111}\n"})
+	rt := NewReadingTools(files, clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)))
+
+	_, out, err := rt.addToReadingList(context.Background(), nil, AddToReadingListInput{URL: "https://EXAMPLE.com/post/?utm_campaign=feed"})
+	if err != nil || out.Success || len(out.Duplicates) != 1 || out.Duplicates[0].ID != "aaaa1111" {
+		t.Fatalf("addToReadingList() = %+v, %v; want a duplicate warning", out, err)
+	}
+
+	_, out, err = rt.addToReadingList(context.Background(), nil, AddToReadingListInput{URL: "https://example.com/post", Force: true})
+	if err != nil || !out.Success {
+		t.Errorf("addToReadingList(force) = %+v, %v", out, err)
+	}
+}
+
+func TestDedupeReadingList(t *testing.T) {
+	files := newFileStorage(map[string]string{storage.ReadingListFile: "# Reading List\n\n## To Read\n" +
+		"- [ ] https://example.com/post/?utm_source=rss {id:aaaa1111,added:2026-02-03} — Notes: via feed\n" +
+		"- [ ] http://Other.example/a {id:bbbb2222,added:2026-02-01}\n" +
+		"\n## Read\n" +
+		"- [x] https://example.com/post {id:cccc3333,added:2026-01-20} — Read: 2026-01-25 — Notes: good intro\n"})
+	rt := NewReadingTools(files, clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)))
+	original := fileContent(t, files, storage.ReadingListFile)
+
+	_, out, err := rt.dedupeReadingList(context.Background(), nil, DedupeReadingListInput{DryRun: true})
+	if err != nil || !out.Success {
+		t.Fatalf("dedupeReadingList(dry run) = %+v, %v", out, err)
+	}
+	if fileContent(t, files, storage.ReadingListFile) != original {
+		t.Error("dry run wrote the file")
+	}
+
+	_, out, err = rt.dedupeReadingList(context.Background(), nil, DedupeReadingListInput{})
+	if err != nil || !out.Success {
+		t.Fatalf("dedupeReadingList() = %+v, %v", out, err)
+	}
+	var result DedupeReadingListResult
+	if err := json.Unmarshal([]byte(out.Message), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(result.Canonicalized) != 2 || len(result.Merged) != 1 || result.Merged[0].ID != "cccc3333" || result.Merged[0].Removed[0] != "aaaa1111" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	rl, _ := storage.ParseReadingList(fileContent(t, files, storage.ReadingListFile))
+	if len(rl.ToRead) != 1 || rl.ToRead[0].URL != "https://other.example/a" {
+		t.Errorf("unexpected to-read items %+v", rl.ToRead)
+	}
+	if len(rl.Read) != 1 || rl.Read[0].ID != "cccc3333" || rl.Read[0].Notes != "via feed; good intro" {
+		t.Errorf("unexpected read items %+v", rl.Read)
+	}
+}
@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// Item kinds for entitystore, parsed with the traced parsers.
+
+var todoKind = entitystore.Kind[storage.TodoFile, storage.Todo]{
+	Name:      "todo",
+	Plural:    "todos",
+	OpenLabel: "active",
+	DoneLabel: "completed",
+	File:      storage.TodosFile,
+	Parse:     parseTodos,
+	Serialize: storage.SerializeTodos,
+	Lists: func(f *storage.TodoFile) (*[]storage.Todo, *[]storage.Todo) {
+		return &f.Active, &f.Completed
+	},
+	ID:     func(t *storage.Todo) string { return t.ID },
+	Prefix: storage.PrefixTodo,
+	SetID:  func(t *storage.Todo, id string) { t.ID = id },
+	Match:  func(t *storage.Todo) string { return t.Text },
+	Describe: func(t *storage.Todo) string {
+		return fmt.Sprintf("[%s] %s", t.ID, t.Text)
+	},
+}
+
+var reminderKind = entitystore.Kind[storage.ReminderFile, storage.Reminder]{
+	Name:      "reminder",
+	Plural:    "reminders",
+	OpenLabel: "upcoming",
+	DoneLabel: "completed",
+	File:      storage.RemindersFile,
+	Parse:     parseReminders,
+	Serialize: storage.SerializeReminders,
+	Lists: func(f *storage.ReminderFile) (*[]storage.Reminder, *[]storage.Reminder) {
+		return &f.Upcoming, &f.Completed
+	},
+	ID:     func(r *storage.Reminder) string { return r.ID },
+	Prefix: storage.PrefixReminder,
+	SetID:  func(r *storage.Reminder, id string) { r.ID = id },
+	Match:  func(r *storage.Reminder) string { return r.Text },
+	Describe: func(r *storage.Reminder) string {
+		return fmt.Sprintf("[%s] %s (%s)", r.ID, r.Text, r.Date.Format("2006-01-02"))
+	},
+}
+
+var readingKind = entitystore.Kind[storage.ReadingList, storage.ReadingItem]{
+	Name:      "reading list item",
+	Plural:    "reading list items",
+	OpenLabel: "unread",
+	DoneLabel: "read",
+	File:      storage.ReadingListFile,
+	Parse:     parseReadingList,
+	Serialize: storage.SerializeReadingList,
+	Lists: func(f *storage.ReadingList) (*[]storage.ReadingItem, *[]storage.ReadingItem) {
+		return &f.ToRead, &f.Read
+	},
+	ID:     func(r *storage.ReadingItem) string { return r.ID },
+	Prefix: storage.PrefixReading,
+	SetID:  func(r *storage.ReadingItem, id string) { r.ID = id },
+	Match:  func(r *storage.ReadingItem) string { return r.URL },
+	Describe: func(r *storage.ReadingItem) string {
+		return fmt.Sprintf("[%s] %s", r.ID, r.URL)
+	},
+}
+
+var milestoneKind = entitystore.Kind[storage.Strategy, storage.Milestone]{
+	Name:      "milestone",
+	Plural:    "milestones",
+	OpenLabel: "active",
+	DoneLabel: "completed",
+	File:      storage.StrategyFile,
+	Parse:     parseStrategy,
+	Serialize: storage.SerializeStrategy,
+	Lists: func(s *storage.Strategy) (*[]storage.Milestone, *[]storage.Milestone) {
+		return &s.ActiveMilestones, &s.CompletedMilestones
+	},
+	ID:     func(m *storage.Milestone) string { return m.ID },
+	Prefix: storage.PrefixMilestone,
+	SetID:  func(m *storage.Milestone, id string) { m.ID = id },
+	Match:  func(m *storage.Milestone) string { return m.Text },
+	Describe: func(m *storage.Milestone) string {
+		return fmt.Sprintf("[%s] %s", m.ID, m.Text)
+	},
+}
+
+// takenIn reports whether an ID is used by any item in lists, for
+// storage.NewID.
+func takenIn[T any](id func(*T) string, lists ...[]T) func(string) bool {
+	return func(candidate string) bool {
+		for _, list := range lists {
+			for i := range list {
+				if id(&list[i]) == candidate {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,354 @@
+package tools
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ExportTools produces full snapshots of the data for backup and analysis.
+type ExportTools struct {
+	storage storage.Storage
+	clock   clock.Clock
+}
+
+// NewExportTools creates a new ExportTools instance. A nil clock uses the system clock.
+func NewExportTools(s storage.Storage, c clock.Clock) *ExportTools {
+	return &ExportTools{storage: s, clock: clock.Or(c)}
+}
+
+// ExportDataInput is the input schema for the export_data tool.
+type ExportDataInput struct {
+	Format string `json:"format,omitempty" jsonschema:"json (default) for one structured document, or csv for a base64-encoded zip of CSV files"`
+}
+
+// ExportDataOutput is the output for the export_data tool.
+type ExportDataOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ExportSnapshot is every parsed item at one point in time.
+type ExportSnapshot struct {
+	ExportedAt  string            `json:"exported_at"`
+	Todos       ExportTodos       `json:"todos"`
+	Strategy    ExportStrategy    `json:"strategy"`
+	ReadingList ExportReadingList `json:"reading_list"`
+	Reminders   ExportReminders   `json:"reminders"`
+}
+
+// ExportTodos holds todos.md.
+type ExportTodos struct {
+	Active    []TodoItem `json:"active"`
+	Completed []TodoItem `json:"completed"`
+}
+
+// ExportStrategy holds strategy.md.
+type ExportStrategy struct {
+	CurrentPhase        string          `json:"current_phase"`
+	Phases              []PhaseItem     `json:"phases,omitempty"`
+	ActiveMilestones    []MilestoneItem `json:"active_milestones"`
+	CompletedMilestones []MilestoneItem `json:"completed_milestones"`
+	Notes               []string        `json:"notes"`
+}
+
+// ExportReadingList holds reading-list.md.
+type ExportReadingList struct {
+	ToRead []ReadingListItem `json:"to_read"`
+	Read   []ReadingListItem `json:"read"`
+}
+
+// ExportReminders holds reminders.md.
+type ExportReminders struct {
+	Upcoming  []ReminderItem `json:"upcoming"`
+	Completed []ReminderItem `json:"completed"`
+}
+
+// ExportZipResult is the export_data response payload for the csv format.
+type ExportZipResult struct {
+	Filename string `json:"filename"`
+	Encoding string `json:"encoding"`
+	Data     string `json:"data"`
+}
+
+// Register registers export tools with the MCP server.
+func (t *ExportTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "export_data",
+		Description: "Export all todos, milestones, notes, reading items and reminders as one JSON document, or as a base64-encoded zip of CSV files",
+	}, t.exportData)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "import_data",
+		Description: "Create or overwrite todos.md, strategy.md, reading-list.md and reminders.md from a JSON document in the export_data format. Use dry_run to preview the diff first.",
+	}, t.importData)
+}
+
+func (t *ExportTools) exportData(ctx context.Context, req *mcp.CallToolRequest, input ExportDataInput) (*mcp.CallToolResult, ExportDataOutput, error) {
+	format := strings.ToLower(strings.TrimSpace(input.Format))
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		return nil, ExportDataOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid format %q. Use: json or csv", input.Format),
+		}, nil
+	}
+
+	snap, err := t.Snapshot(ctx)
+	if err != nil {
+		return nil, ExportDataOutput{}, err
+	}
+
+	var payload any = snap
+	if format == "csv" {
+		var buf bytes.Buffer
+		if err := WriteExportZip(&buf, snap); err != nil {
+			return nil, ExportDataOutput{}, fmt.Errorf("building zip: %w", err)
+		}
+		payload = ExportZipResult{
+			Filename: exportFilename(t.clock.Now(), "zip"),
+			Encoding: "base64",
+			Data:     base64.StdEncoding.EncodeToString(buf.Bytes()),
+		}
+	}
+
+	jsonBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, ExportDataOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, ExportDataOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
+
+// ExportHandler serves the snapshot over HTTP as a download:
+// GET /export?format=json (default) or ?format=csv for a zip of CSVs.
+func (t *ExportTools) ExportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		http.Error(w, "format must be json or csv", http.StatusBadRequest)
+		return
+	}
+
+	snap, err := t.Snapshot(r.Context())
+	if err != nil {
+		slog.Error("export failed", "error", err)
+		http.Error(w, "Export failed", http.StatusInternalServerError)
+		return
+	}
+
+	now := t.clock.Now()
+	if format == "csv" {
+		var buf bytes.Buffer
+		if err := WriteExportZip(&buf, snap); err != nil {
+			slog.Error("export failed", "error", err)
+			http.Error(w, "Export failed", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+exportFilename(now, "zip")+`"`)
+		w.Write(buf.Bytes())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+exportFilename(now, "json")+`"`)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(snap)
+}
+
+func exportFilename(now time.Time, ext string) string {
+	return "momentum-export-" + now.Format("2006-01-02") + "." + ext
+}
+
+// Snapshot reads and parses every data file. Missing files export as empty
+// sections.
+func (t *ExportTools) Snapshot(ctx context.Context) (*ExportSnapshot, error) {
+	now := t.clock.Now()
+	today := clock.Today(t.clock)
+	snap := &ExportSnapshot{
+		ExportedAt:  now.UTC().Format(time.RFC3339),
+		Todos:       ExportTodos{Active: []TodoItem{}, Completed: []TodoItem{}},
+		Strategy:    ExportStrategy{ActiveMilestones: []MilestoneItem{}, CompletedMilestones: []MilestoneItem{}, Notes: []string{}},
+		ReadingList: ExportReadingList{ToRead: []ReadingListItem{}, Read: []ReadingListItem{}},
+		Reminders:   ExportReminders{Upcoming: []ReminderItem{}, Completed: []ReminderItem{}},
+	}
+
+	if content, ok, err := t.read(ctx, storage.TodosFile); err != nil {
+		return nil, err
+	} else if ok {
+		tf, err := parseTodos(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing todos: %w", err)
+		}
+		for _, todo := range tf.Active {
+			snap.Todos.Active = append(snap.Todos.Active, todoToItem(todo))
+		}
+		for _, todo := range tf.Completed {
+			snap.Todos.Completed = append(snap.Todos.Completed, todoToItem(todo))
+		}
+	}
+
+	if content, ok, err := t.read(ctx, storage.StrategyFile); err != nil {
+		return nil, err
+	} else if ok {
+		s, err := parseStrategy(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing strategy: %w", err)
+		}
+		snap.Strategy.CurrentPhase = s.CurrentPhase
+		if len(s.Phases) > 0 {
+			snap.Strategy.Phases = phasesToItems(s, today)
+		}
+		for _, m := range s.ActiveMilestones {
+			snap.Strategy.ActiveMilestones = append(snap.Strategy.ActiveMilestones, milestoneToItem(m))
+		}
+		for _, m := range s.CompletedMilestones {
+			snap.Strategy.CompletedMilestones = append(snap.Strategy.CompletedMilestones, milestoneToItem(m))
+		}
+		snap.Strategy.Notes = append(snap.Strategy.Notes, s.Notes...)
+	}
+
+	if content, ok, err := t.read(ctx, storage.ReadingListFile); err != nil {
+		return nil, err
+	} else if ok {
+		rl, err := parseReadingList(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing reading list: %w", err)
+		}
+		for _, item := range rl.ToRead {
+			snap.ReadingList.ToRead = append(snap.ReadingList.ToRead, readingToItem(item))
+		}
+		for _, item := range rl.Read {
+			snap.ReadingList.Read = append(snap.ReadingList.Read, readingToItem(item))
+		}
+	}
+
+	if content, ok, err := t.read(ctx, storage.RemindersFile); err != nil {
+		return nil, err
+	} else if ok {
+		rf, err := parseReminders(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing reminders: %w", err)
+		}
+		for _, r := range rf.Upcoming {
+			snap.Reminders.Upcoming = append(snap.Reminders.Upcoming, reminderToItem(r, today))
+		}
+		for _, r := range rf.Completed {
+			snap.Reminders.Completed = append(snap.Reminders.Completed, reminderToItem(r, today))
+		}
+	}
+
+	return snap, nil
+}
+
+// read returns a file's content, or ok=false if it doesn't exist.
+func (t *ExportTools) read(ctx context.Context, path string) (string, bool, error) {
+	content, _, err := t.storage.ReadFile(ctx, path)
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return content, true, nil
+}
+
+// WriteExportZip writes snap as a zip of CSV files, one per item type.
+func WriteExportZip(w io.Writer, snap *ExportSnapshot) error {
+	zw := zip.NewWriter(w)
+
+	todos := [][]string{{"id", "text", "priority", "project", "completed", "added", "completed_at"}}
+	for _, list := range [][]TodoItem{snap.Todos.Active, snap.Todos.Completed} {
+		for _, t := range list {
+			todos = append(todos, []string{t.ID, t.Text, t.Priority, t.Project, strconv.FormatBool(t.Completed), t.Added, deref(t.CompletedAt)})
+		}
+	}
+
+	milestones := [][]string{{"id", "text", "due", "project", "issue", "completed", "added", "completed_at"}}
+	for _, list := range [][]MilestoneItem{snap.Strategy.ActiveMilestones, snap.Strategy.CompletedMilestones} {
+		for _, m := range list {
+			issue := ""
+			if m.Issue > 0 {
+				issue = strconv.Itoa(m.Issue)
+			}
+			milestones = append(milestones, []string{m.ID, m.Text, deref(m.Due), m.Project, issue, strconv.FormatBool(m.Completed), m.Added, deref(m.CompletedAt)})
+		}
+	}
+
+	notes := [][]string{{"note"}}
+	for _, n := range snap.Strategy.Notes {
+		notes = append(notes, []string{n})
+	}
+
+	reading := [][]string{{"id", "url", "notes", "read", "added", "read_at"}}
+	for _, list := range [][]ReadingListItem{snap.ReadingList.ToRead, snap.ReadingList.Read} {
+		for _, r := range list {
+			reading = append(reading, []string{r.ID, r.URL, r.Notes, strconv.FormatBool(r.Read), r.Added, deref(r.ReadAt)})
+		}
+	}
+
+	reminders := [][]string{{"id", "date", "text", "completed", "overdue", "added", "completed_at"}}
+	for _, list := range [][]ReminderItem{snap.Reminders.Upcoming, snap.Reminders.Completed} {
+		for _, r := range list {
+			reminders = append(reminders, []string{r.ID, r.Date, r.Text, strconv.FormatBool(r.Completed), strconv.FormatBool(r.Overdue), r.Added, deref(r.CompletedAt)})
+		}
+	}
+
+	files := []struct {
+		name string
+		rows [][]string
+	}{
+		{"todos.csv", todos},
+		{"milestones.csv", milestones},
+		{"notes.csv", notes},
+		{"reading_list.csv", reading},
+		{"reminders.csv", reminders},
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		cw := csv.NewWriter(fw)
+		if err := cw.WriteAll(f.rows); err != nil {
+			return fmt.Errorf("writing %s: %w", f.name, err)
+		}
+	}
+	return zw.Close()
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// newFileStorage returns an in-memory Storage holding files.
+func newFileStorage(files map[string]string) *storage.MemoryStorage {
+	return storage.NewMemoryStorage(files, nil)
+}
+
+// fileContent returns the current content of path in s, or "" if it
+// doesn't exist.
+func fileContent(t *testing.T, s storage.Storage, path string) string {
+	t.Helper()
+	content, _, err := s.ReadFile(context.Background(), path)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestExportSnapshot(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		"todos.md":     "# Active Todos\n\n## High Priority\n- [ ] Ship it {id:aaaa1111,added:2026-02-01}\n\n## Completed\n- [x] Done {id:bbbb2222,added:2026-01-01,completed:2026-01-05}\n",
+		"strategy.md":  "## Current Phase\nLaunch\n\n## Active Milestones\n- [ ] Beta — Due: 2026-03-01 {id:cccc3333,added:2026-02-01,issue:7}\n\n## Notes\n- Keep scope small\n",
+		"reminders.md": "## Upcoming\n- 2026-02-01: Renew domain {id:dddd4444}\n",
+	})
+	now := time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)
+	et := NewExportTools(files, clock.NewFake(now))
+
+	snap, err := et.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	if len(snap.Todos.Active) != 1 || len(snap.Todos.Completed) != 1 {
+		t.Errorf("unexpected todos: %+v", snap.Todos)
+	}
+	if snap.Strategy.CurrentPhase != "Launch" || len(snap.Strategy.ActiveMilestones) != 1 || snap.Strategy.ActiveMilestones[0].Issue != 7 || len(snap.Strategy.Notes) != 1 {
+		t.Errorf("unexpected strategy: %+v", snap.Strategy)
+	}
+	// reading-list.md is missing, so it exports as empty
+	if snap.ReadingList.ToRead == nil || len(snap.ReadingList.ToRead) != 0 {
+		t.Errorf("expected empty reading list, got %+v", snap.ReadingList)
+	}
+	if len(snap.Reminders.Upcoming) != 1 || !snap.Reminders.Upcoming[0].Overdue {
+		t.Errorf("unexpected reminders: %+v", snap.Reminders)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteExportZip(&buf, snap); err != nil {
+		t.Fatalf("WriteExportZip() error = %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("reading zip: %v", err)
+	}
+	rows := map[string][][]string{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		records, err := csv.NewReader(rc).ReadAll()
+		rc.Close()
+		if err != nil {
+			t.Fatalf("parsing %s: %v", f.Name, err)
+		}
+		rows[f.Name] = records
+	}
+	if len(rows) != 5 {
+		t.Errorf("expected 5 CSV files, got %d", len(rows))
+	}
+	if len(rows["todos.csv"]) != 3 || rows["todos.csv"][2][6] != "2026-01-05" {
+		t.Errorf("unexpected todos.csv: %v", rows["todos.csv"])
+	}
+	if len(rows["milestones.csv"]) != 2 || rows["milestones.csv"][1][4] != "7" {
+		t.Errorf("unexpected milestones.csv: %v", rows["milestones.csv"])
+	}
+	if len(rows["reading_list.csv"]) != 1 {
+		t.Errorf("expected header-only reading_list.csv, got %v", rows["reading_list.csv"])
+	}
+}
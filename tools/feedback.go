@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/usage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FeedbackTools exposes recorded tool-call validation failures so schema
+// descriptions (and server-side coercion) can be tuned to real mistakes.
+type FeedbackTools struct {
+	tracker *usage.Tracker
+}
+
+// NewFeedbackTools creates a new FeedbackTools instance.
+func NewFeedbackTools(t *usage.Tracker) *FeedbackTools {
+	return &FeedbackTools{tracker: t}
+}
+
+// GetToolFeedbackInput is the input schema for the get_tool_feedback tool.
+type GetToolFeedbackInput struct {
+	Limit int `json:"limit,omitempty" jsonschema:"Maximum number of entries to return. Defaults to 10."`
+}
+
+// GetToolFeedbackOutput is the output for the get_tool_feedback tool.
+type GetToolFeedbackOutput struct {
+	Success bool                `json:"success"`
+	Message string              `json:"message"`
+	Result  *ToolFeedbackResult `json:"result,omitempty"`
+}
+
+// ToolFeedbackResult is the response payload for get_tool_feedback.
+type ToolFeedbackResult struct {
+	Mistakes      []usage.FailureStats `json:"mistakes"`
+	TotalMistakes int                  `json:"total_mistakes"`
+}
+
+// Register registers feedback tools with the MCP server.
+func (f *FeedbackTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_tool_feedback",
+		Description: "Report the most common tool-call validation failures (bad enum values, date formats, schema errors) with example values and the expected format",
+	}, f.getToolFeedback)
+}
+
+func (f *FeedbackTools) getToolFeedback(ctx context.Context, req *mcp.CallToolRequest, input GetToolFeedbackInput) (*mcp.CallToolResult, GetToolFeedbackOutput, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	all := f.tracker.Feedback(0)
+	total := 0
+	for _, m := range all {
+		total += m.Count
+	}
+	if len(all) > limit {
+		all = all[:limit]
+	}
+
+	result := ToolFeedbackResult{
+		Mistakes:      all,
+		TotalMistakes: total,
+	}
+	text := result.text()
+	return textResult(text), GetToolFeedbackOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+func (r ToolFeedbackResult) text() string {
+	if r.TotalMistakes == 0 {
+		return "No tool-call mistakes recorded."
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s recorded\n", plural(r.TotalMistakes, "mistake"))
+	for _, m := range r.Mistakes {
+		fmt.Fprintf(&sb, "- %s %s: %dx%s", m.Tool, m.Field, m.Count, itemDetails(
+			labeled("e.g.", strings.Join(m.Examples, ", ")), labeled("last seen", m.LastSeen.UTC().Format(time.RFC3339))))
+		if m.Hint != "" {
+			sb.WriteString(". " + m.Hint)
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
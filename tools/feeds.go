@@ -0,0 +1,220 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/feeds"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultMaxPerFeed caps the entries one fetch adds from a single feed.
+const defaultMaxPerFeed = 5
+
+// FeedTools pulls RSS and Atom feed entries into the reading list.
+type FeedTools struct {
+	storage storage.Storage
+	clock   clock.Clock
+	fetch   func(ctx context.Context, url string) ([]feeds.Entry, error)
+}
+
+// NewFeedTools creates a new FeedTools instance. A nil clock uses the system clock.
+func NewFeedTools(s storage.Storage, c clock.Clock) *FeedTools {
+	return &FeedTools{storage: s, clock: clock.Or(c), fetch: feeds.Fetch}
+}
+
+// FetchFeedsInput is the input schema for the fetch_feeds tool.
+type FetchFeedsInput struct {
+	Feed       string `json:"feed,omitempty" jsonschema:"Only fetch this feed (name from feeds.md). Defaults to all feeds."`
+	MaxPerFeed int    `json:"max_per_feed,omitempty" jsonschema:"Maximum new entries to add per feed (default 5)"`
+}
+
+// FetchFeedsOutput is the output for the fetch_feeds tool.
+type FetchFeedsOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// FetchFeedsResult is the response payload for fetch_feeds.
+type FetchFeedsResult struct {
+	Added int               `json:"added"`
+	Feeds []FeedFetchResult `json:"feeds"`
+}
+
+// FeedFetchResult reports one feed's fetch.
+type FeedFetchResult struct {
+	Feed  string            `json:"feed"`
+	Found int               `json:"found"`
+	Added []ReadingListItem `json:"added"`
+	// Capped counts new entries left out by max_per_feed.
+	Capped int    `json:"capped,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Register registers feed tools with the MCP server.
+func (t *FeedTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "fetch_feeds",
+		Description: "Fetch the RSS/Atom feeds listed in feeds.md and add entries published since the last fetch to the reading list, tagged #<feed>, capped per feed",
+	}, t.fetchFeeds)
+}
+
+func (t *FeedTools) fetchFeeds(ctx context.Context, req *mcp.CallToolRequest, input FetchFeedsInput) (*mcp.CallToolResult, FetchFeedsOutput, error) {
+	content, _, err := t.storage.ReadFile(ctx, storage.FeedsFile)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, FetchFeedsOutput{}, fmt.Errorf("reading %s: %w", storage.FeedsFile, err)
+	}
+	var feedList []storage.Feed
+	if err == nil {
+		if feedList, err = storage.ParseFeeds(content); err != nil {
+			return nil, FetchFeedsOutput{}, fmt.Errorf("parsing feeds: %w", err)
+		}
+	}
+	if input.Feed != "" {
+		name := storage.NormalizeProject(input.Feed)
+		var matched []storage.Feed
+		for _, f := range feedList {
+			if f.Name == name {
+				matched = append(matched, f)
+			}
+		}
+		if len(matched) == 0 {
+			return nil, FetchFeedsOutput{
+				Success: false,
+				Message: fmt.Sprintf("No feed named %q in %s", input.Feed, storage.FeedsFile),
+			}, nil
+		}
+		feedList = matched
+	}
+	if len(feedList) == 0 {
+		return nil, FetchFeedsOutput{
+			Success: false,
+			Message: fmt.Sprintf("No feeds configured. Add \"- name: URL\" lines to %s.", storage.FeedsFile),
+		}, nil
+	}
+
+	maxPerFeed := input.MaxPerFeed
+	if maxPerFeed <= 0 {
+		maxPerFeed = defaultMaxPerFeed
+	}
+
+	content, sha, err := t.storage.ReadFile(ctx, storage.ReadingListFile)
+	if err != nil {
+		return nil, FetchFeedsOutput{}, fmt.Errorf("reading reading-list.md: %w", err)
+	}
+	rl, err := parseReadingList(ctx, content)
+	if err != nil {
+		return nil, FetchFeedsOutput{}, fmt.Errorf("parsing reading list: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, item := range rl.ToRead {
+		seen[item.URL] = true
+	}
+	for _, item := range rl.Read {
+		seen[item.URL] = true
+	}
+
+	result := FetchFeedsResult{Feeds: []FeedFetchResult{}}
+	today := clock.Today(t.clock)
+	for _, feed := range feedList {
+		fr := FeedFetchResult{Feed: feed.Name, Added: []ReadingListItem{}}
+		entries, err := t.fetch(ctx, feed.URL)
+		if err != nil {
+			fr.Error = err.Error()
+			result.Feeds = append(result.Feeds, fr)
+			continue
+		}
+		fr.Found = len(entries)
+
+		selected, capped := selectFeedEntries(entries, seen, lastFeedImport(rl, feed.Name), maxPerFeed)
+		fr.Capped = capped
+		for _, e := range selected {
+			seen[e.URL] = true
+			notes := "#" + feed.Name
+			if e.Title != "" {
+				notes = e.Title + " " + notes
+			}
+			item := storage.ReadingItem{
+				ID:    storage.NewID(storage.PrefixReading, takenIn(readingKind.ID, rl.ToRead, rl.Read)),
+				URL:   e.URL,
+				Notes: notes,
+				Added: today,
+			}
+			rl.ToRead = append(rl.ToRead, item)
+			fr.Added = append(fr.Added, readingToItem(item))
+		}
+		result.Added += len(selected)
+		result.Feeds = append(result.Feeds, fr)
+	}
+
+	if result.Added > 0 {
+		newContent := storage.SerializeReadingList(rl)
+		if err := t.storage.WriteFile(ctx, storage.ReadingListFile, newContent, sha, fmt.Sprintf("Fetch feeds: %d new items", result.Added)); err != nil {
+			if msg, ok := entitystore.WriteMessage(err); ok {
+				return nil, FetchFeedsOutput{
+					Success: false,
+					Message: msg,
+				}, nil
+			}
+			return nil, FetchFeedsOutput{}, fmt.Errorf("writing reading-list.md: %w", err)
+		}
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, FetchFeedsOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, FetchFeedsOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
+
+// lastFeedImport returns the most recent date an item tagged #name was added
+// to the reading list, or zero if none was. It stands in for "last run", so
+// no separate fetch state needs to be committed.
+func lastFeedImport(rl *storage.ReadingList, name string) time.Time {
+	tag := "#" + name
+	var last time.Time
+	for _, items := range [][]storage.ReadingItem{rl.ToRead, rl.Read} {
+		for _, item := range items {
+			for _, word := range strings.Fields(item.Notes) {
+				if word == tag && item.Added.After(last) {
+					last = item.Added
+				}
+			}
+		}
+	}
+	return last
+}
+
+// selectFeedEntries picks up to max unseen entries published on or after
+// since (undated entries always qualify), newest first. It also returns how
+// many qualifying entries were left out by the cap.
+func selectFeedEntries(entries []feeds.Entry, seen map[string]bool, since time.Time, max int) ([]feeds.Entry, int) {
+	var fresh []feeds.Entry
+	for _, e := range entries {
+		if seen[e.URL] {
+			continue
+		}
+		if !since.IsZero() && !e.Published.IsZero() && e.Published.Before(since) {
+			continue
+		}
+		fresh = append(fresh, e)
+	}
+	sort.SliceStable(fresh, func(i, j int) bool { return fresh[i].Published.After(fresh[j].Published) })
+	if len(fresh) > max {
+		return fresh[:max], len(fresh) - max
+	}
+	return fresh, 0
+}
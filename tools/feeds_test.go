@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/feeds"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestLastFeedImport(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 2, d, 0, 0, 0, 0, time.UTC) }
+	rl := &storage.ReadingList{
+		ToRead: []storage.ReadingItem{
+			{URL: "https://a", Notes: "Post A #go-blog", Added: day(3)},
+			{URL: "https://b", Notes: "Post B #go-blogger", Added: day(9)},
+		},
+		Read: []storage.ReadingItem{
+			{URL: "https://c", Notes: "#go-blog", Added: day(5)},
+		},
+	}
+
+	if got := lastFeedImport(rl, "go-blog"); !got.Equal(day(5)) {
+		t.Errorf("lastFeedImport() = %v, want %v", got, day(5))
+	}
+	if got := lastFeedImport(rl, "other"); !got.IsZero() {
+		t.Errorf("expected zero time for unseen feed, got %v", got)
+	}
+}
+
+func TestSelectFeedEntries(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 2, d, 12, 0, 0, 0, time.UTC) }
+	entries := []feeds.Entry{
+		{URL: "https://old", Published: day(1)},
+		{URL: "https://seen", Published: day(6)},
+		{URL: "https://new1", Published: day(5)},
+		{URL: "https://new2", Published: day(7)},
+		{URL: "https://undated"},
+		{URL: "https://new3", Published: day(6)},
+	}
+	seen := map[string]bool{"https://seen": true}
+	since := time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC)
+
+	got, capped := selectFeedEntries(entries, seen, since, 3)
+	if capped != 1 {
+		t.Errorf("capped = %d, want 1", capped)
+	}
+	want := []string{"https://new2", "https://new3", "https://new1"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i, e := range got {
+		if e.URL != want[i] {
+			t.Errorf("entry %d = %s, want %s", i, e.URL, want[i])
+		}
+	}
+
+	// Without a previous import every unseen entry qualifies
+	got, capped = selectFeedEntries(entries, seen, time.Time{}, 10)
+	if len(got) != 5 || capped != 0 {
+		t.Errorf("expected 5 entries uncapped, got %d (capped %d)", len(got), capped)
+	}
+}
@@ -0,0 +1,264 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxFocusItems is how many items the daily focus holds.
+const maxFocusItems = 3
+
+// FocusTools manages the daily focus list in focus.md.
+type FocusTools struct {
+	storage storage.Storage
+	clock   clock.Clock
+}
+
+// NewFocusTools creates a new FocusTools instance. A nil clock uses the system clock.
+func NewFocusTools(s storage.Storage, c clock.Clock) *FocusTools {
+	return &FocusTools{storage: s, clock: clock.Or(c)}
+}
+
+// SetFocusInput is the input schema for the set_focus tool.
+type SetFocusInput struct {
+	IDs            []string `json:"ids" jsonschema:"IDs of up to 3 active todos or milestones to focus on today, most important first. Replaces today's focus; pass an empty list to clear it."`
+	IfUnchangedSHA string   `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier get_focus call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// GetFocusInput is the input schema for the get_focus tool.
+type GetFocusInput struct{}
+
+// FocusOutput is the output for the set_focus and get_focus tools.
+type FocusOutput struct {
+	Success bool         `json:"success"`
+	Message string       `json:"message"`
+	Result  *FocusResult `json:"result,omitempty"`
+}
+
+// FocusResult is today's focus and how it's going.
+type FocusResult struct {
+	Date  string       `json:"date"`
+	Items []FocusEntry `json:"items"`
+	Done  int          `json:"done"`
+	// OtherActive counts the active todos and milestones outside the focus,
+	// and OtherCompletedToday those completed today.
+	OtherActive         int `json:"other_active"`
+	OtherCompletedToday int `json:"other_completed_today"`
+	// LastSet is the day the focus was last set, if not today.
+	LastSet   string `json:"last_set,omitempty"`
+	SourceSHA string `json:"source_sha"`
+}
+
+// FocusEntry is a focus item and its progress.
+type FocusEntry struct {
+	Type         string `json:"type"`
+	ID           string `json:"id"`
+	Text         string `json:"text"`
+	Done         bool   `json:"done"`
+	Missing      bool   `json:"missing,omitempty"`
+	SubtasksDone int    `json:"subtasks_done,omitempty"`
+	Subtasks     int    `json:"subtasks,omitempty"`
+}
+
+// Register registers focus tools with the MCP server.
+func (f *FocusTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "set_focus",
+		Description: "Pick up to 3 todos or milestones as today's focus. The dashboard and weekly summary show their progress against everything else.",
+	}, f.setFocus)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_focus",
+		Description: "Get today's focus items and their progress, with how much else is active and was completed today",
+	}, f.getFocus)
+}
+
+func (f *FocusTools) setFocus(ctx context.Context, req *mcp.CallToolRequest, input SetFocusInput) (*mcp.CallToolResult, FocusOutput, error) {
+	if len(input.IDs) > maxFocusItems {
+		return nil, FocusOutput{
+			Success: false,
+			Message: fmt.Sprintf("Too many focus items (%d). Pick at most %d.", len(input.IDs), maxFocusItems),
+		}, nil
+	}
+
+	today := clock.Today(f.clock)
+	focus := &storage.Focus{Date: today}
+	seen := make(map[string]bool)
+	for _, id := range input.IDs {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		item, err := findTrackable(ctx, f.storage, id)
+		if err != nil {
+			return nil, FocusOutput{}, err
+		}
+		if item == nil {
+			return nil, FocusOutput{
+				Success: false,
+				Message: fmt.Sprintf("No active todo or milestone found with id %q", id),
+			}, nil
+		}
+		focus.Items = append(focus.Items, storage.FocusItem{ItemType: item.ItemType, ItemID: item.ItemID, Text: item.Text})
+	}
+	if len(focus.Items) == 0 {
+		focus = &storage.Focus{}
+	}
+
+	_, sha, err := f.storage.ReadFile(ctx, storage.FocusFile)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, FocusOutput{}, fmt.Errorf("reading focus.md: %w", err)
+	}
+	if msg := entitystore.CheckUnchanged(storage.FocusFile, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, FocusOutput{Success: false, Message: msg}, nil
+	}
+
+	message := "Clear focus"
+	if len(focus.Items) > 0 {
+		message = fmt.Sprintf("Set focus for %s", today.Format("2006-01-02"))
+	}
+	if err := f.storage.WriteFile(ctx, storage.FocusFile, storage.SerializeFocus(focus), sha, message); err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, FocusOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, FocusOutput{}, fmt.Errorf("writing focus.md: %w", err)
+	}
+
+	return f.getFocus(ctx, req, GetFocusInput{})
+}
+
+func (f *FocusTools) getFocus(ctx context.Context, req *mcp.CallToolRequest, input GetFocusInput) (*mcp.CallToolResult, FocusOutput, error) {
+	files := storage.ReadFiles(ctx, f.storage, storage.FocusFile, storage.TodosFile, storage.StrategyFile)
+	if err := files[storage.FocusFile].Err; err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, FocusOutput{}, fmt.Errorf("reading focus.md: %w", err)
+	}
+	result := focusProgress(ctx, files, clock.Today(f.clock))
+	text := result.text()
+	return textResult(text), FocusOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+// focusProgress reports the progress of today's focus from the focus, todo
+// and strategy files in files. A focus set on another day counts as unset.
+func focusProgress(ctx context.Context, files map[string]storage.ReadResult, today time.Time) FocusResult {
+	result := FocusResult{
+		Date:      today.Format("2006-01-02"),
+		Items:     []FocusEntry{},
+		SourceSHA: files[storage.FocusFile].SHA,
+	}
+	focus := &storage.Focus{}
+	if r := files[storage.FocusFile]; r.Err == nil {
+		focus, _ = storage.ParseFocus(r.Content)
+	}
+	if !focus.Date.IsZero() && !focus.Date.Equal(today) {
+		result.LastSet = focus.Date.Format("2006-01-02")
+		focus = &storage.Focus{}
+	}
+
+	var todos *storage.TodoFile
+	if r := files[storage.TodosFile]; r.Err == nil {
+		todos, _ = parseTodos(ctx, r.Content)
+	}
+	var strategy *storage.Strategy
+	if r := files[storage.StrategyFile]; r.Err == nil {
+		strategy, _ = parseStrategy(ctx, r.Content)
+	}
+
+	inFocus := make(map[string]bool)
+	for _, s := range storage.ResolveFocus(focus, todos, strategy) {
+		inFocus[s.ItemType+":"+s.ItemID] = true
+		result.Items = append(result.Items, FocusEntry{
+			Type:         s.ItemType,
+			ID:           s.ItemID,
+			Text:         s.Text,
+			Done:         s.Done,
+			Missing:      s.Missing,
+			SubtasksDone: s.SubtasksDone,
+			Subtasks:     s.Subtasks,
+		})
+		if s.Done {
+			result.Done++
+		}
+	}
+
+	// Everything else, for comparison
+	count := func(itemType, id string, completedAt *time.Time) {
+		switch {
+		case inFocus[itemType+":"+id]:
+		case completedAt == nil:
+			result.OtherActive++
+		case completedAt.Equal(today):
+			result.OtherCompletedToday++
+		}
+	}
+	if todos != nil {
+		for _, t := range todos.Active {
+			count("todo", t.ID, nil)
+		}
+		for _, t := range todos.Completed {
+			if t.CompletedAt != nil {
+				count("todo", t.ID, t.CompletedAt)
+			}
+		}
+	}
+	if strategy != nil {
+		for _, m := range strategy.ActiveMilestones {
+			count("milestone", m.ID, nil)
+		}
+		for _, m := range strategy.CompletedMilestones {
+			if m.CompletedAt != nil {
+				count("milestone", m.ID, m.CompletedAt)
+			}
+		}
+	}
+	return result
+}
+
+func (e FocusEntry) text() string {
+	box := "[ ]"
+	if e.Done {
+		box = "[x]"
+	}
+	subtasks := ""
+	if e.Subtasks > 0 {
+		subtasks = fmt.Sprintf("%d/%d subtasks", e.SubtasksDone, e.Subtasks)
+	}
+	missing := ""
+	if e.Missing {
+		missing = "no longer exists"
+	}
+	return "- " + box + " " + e.Text + itemDetails(e.Type+" "+e.ID, subtasks, missing)
+}
+
+func (r FocusResult) text() string {
+	var sb strings.Builder
+	if len(r.Items) == 0 {
+		fmt.Fprintf(&sb, "No focus set for %s", r.Date)
+		if r.LastSet != "" {
+			fmt.Fprintf(&sb, " (last set for %s)", r.LastSet)
+		}
+		sb.WriteString(". Use set_focus to pick up to 3 items.\n")
+	} else {
+		fmt.Fprintf(&sb, "Focus for %s: %d of %d done (source_sha %s)\n", r.Date, r.Done, len(r.Items), r.SourceSHA)
+		for _, e := range r.Items {
+			sb.WriteString(e.text() + "\n")
+		}
+	}
+	fmt.Fprintf(&sb, "Everything else: %d active, %d completed today\n", r.OtherActive, r.OtherCompletedToday)
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestFocus(t *testing.T) {
+	ctx := context.Background()
+	mem := storage.NewMemoryStorage(map[string]string{
+		storage.TodosFile: "# Active Todos\n\n## Normal\n- [ ] Ship it {id:aaaa1111}\n- [ ] Tidy up {id:bbbb2222}\n- [ ] Email Sam {id:cccc3333}\n\n" +
+			"# Completed\n- [x] Pay rent {id:dddd4444,completed:2026-02-10}\n",
+		storage.StrategyFile: "## Active Milestones\n- [ ] Launch {id:eeee5555}\n",
+	}, nil)
+	clk := clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC))
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	NewFocusTools(mem, clk).Register(server)
+	NewTodoTools(mem, clk, WIPLimits{}).Register(server)
+
+	focus := func(tool string, args map[string]any) FocusOutput {
+		t.Helper()
+		res := callOverMCP(t, server, tool, args)
+		raw, _ := json.Marshal(res.StructuredContent)
+		var out FocusOutput
+		if err := json.Unmarshal(raw, &out); err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	if out := focus("set_focus", map[string]any{"ids": []string{"aaaa1111", "bbbb2222", "cccc3333", "eeee5555"}}); out.Success {
+		t.Error("set_focus accepted 4 items")
+	}
+	if out := focus("set_focus", map[string]any{"ids": []string{"zzzz9999"}}); out.Success {
+		t.Error("set_focus accepted an unknown ID")
+	}
+
+	out := focus("set_focus", map[string]any{"ids": []string{"aaaa1111", "eeee5555"}})
+	if !out.Success || out.Result == nil || len(out.Result.Items) != 2 || out.Result.Items[1].Type != "milestone" {
+		t.Fatalf("unexpected result %+v", out)
+	}
+	if content, _, _ := mem.ReadFile(ctx, storage.FocusFile); content != "# Focus\n\n## 2026-02-10\n- todo:aaaa1111 Ship it\n- milestone:eeee5555 Launch\n" {
+		t.Errorf("unexpected focus.md:\n%s", content)
+	}
+
+	callOverMCP(t, server, "complete_todo", map[string]any{"id": "aaaa1111"})
+	r := focus("get_focus", map[string]any{}).Result
+	if r == nil || r.Done != 1 || !r.Items[0].Done || r.OtherActive != 2 || r.OtherCompletedToday != 1 {
+		t.Fatalf("unexpected progress %+v", r)
+	}
+
+	// The next day the focus is unset again
+	clk.Advance(24 * time.Hour)
+	out = focus("get_focus", map[string]any{})
+	if len(out.Result.Items) != 0 || out.Result.LastSet != "2026-02-10" || !strings.Contains(out.Message, "No focus set for 2026-02-11") {
+		t.Errorf("unexpected result the next day %+v", out)
+	}
+}
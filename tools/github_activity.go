@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GitHubActivityRefresher refetches GitHub activity, bypassing its cache.
+// resources.GitHubActivityResource implements it.
+type GitHubActivityRefresher interface {
+	// Refresh fetches fresh activity and returns when it was fetched.
+	Refresh(ctx context.Context) (time.Time, error)
+}
+
+// GitHubActivityTools manages the cached GitHub activity data.
+type GitHubActivityTools struct {
+	activity GitHubActivityRefresher
+}
+
+// NewGitHubActivityTools creates a new GitHubActivityTools instance.
+func NewGitHubActivityTools(a GitHubActivityRefresher) *GitHubActivityTools {
+	return &GitHubActivityTools{activity: a}
+}
+
+// RefreshGitHubActivityInput is the input schema for the refresh_github_activity tool.
+type RefreshGitHubActivityInput struct{}
+
+// RefreshGitHubActivityOutput is the output for the refresh_github_activity tool.
+type RefreshGitHubActivityOutput struct {
+	Success  bool   `json:"success"`
+	Message  string `json:"message"`
+	CachedAt string `json:"cached_at,omitempty"`
+}
+
+// Register registers GitHub activity tools with the MCP server.
+func (t *GitHubActivityTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "refresh_github_activity",
+		Description: "Refetch GitHub activity now instead of waiting for the cache to expire, e.g. right after pushing. Read momentum://github-activity afterwards for the data.",
+	}, t.refreshGitHubActivity)
+}
+
+func (t *GitHubActivityTools) refreshGitHubActivity(ctx context.Context, req *mcp.CallToolRequest, input RefreshGitHubActivityInput) (*mcp.CallToolResult, RefreshGitHubActivityOutput, error) {
+	cachedAt, err := t.activity.Refresh(ctx)
+	if err != nil {
+		return nil, RefreshGitHubActivityOutput{
+			Success: false,
+			Message: fmt.Sprintf("Refreshing GitHub activity failed: %v. The previously cached data is still served.", err),
+		}, nil
+	}
+
+	at := cachedAt.UTC().Format(time.RFC3339)
+	return nil, RefreshGitHubActivityOutput{
+		Success:  true,
+		Message:  fmt.Sprintf("GitHub activity refreshed at %s", at),
+		CachedAt: at,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeRefresher struct {
+	at  time.Time
+	err error
+}
+
+func (f fakeRefresher) Refresh(ctx context.Context) (time.Time, error) { return f.at, f.err }
+
+func TestRefreshGitHubActivity(t *testing.T) {
+	at := time.Date(2026, 2, 10, 9, 30, 0, 0, time.UTC)
+	_, out, err := NewGitHubActivityTools(fakeRefresher{at: at}).refreshGitHubActivity(context.Background(), nil, RefreshGitHubActivityInput{})
+	if err != nil || !out.Success || out.CachedAt != "2026-02-10T09:30:00Z" {
+		t.Errorf("refreshGitHubActivity() = %+v, %v", out, err)
+	}
+
+	_, out, _ = NewGitHubActivityTools(fakeRefresher{err: errors.New("rate limited")}).refreshGitHubActivity(context.Background(), nil, RefreshGitHubActivityInput{})
+	if out.Success || !strings.Contains(out.Message, "rate limited") {
+		t.Errorf("expected a failure message, got %+v", out)
+	}
+}
@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GoalTools manages the goals in goals.md.
+type GoalTools struct {
+	storage storage.Storage
+}
+
+// NewGoalTools creates a new GoalTools instance.
+func NewGoalTools(s storage.Storage) *GoalTools {
+	return &GoalTools{storage: s}
+}
+
+// SetContributionGoalInput is the input schema for the set_contribution_goal tool.
+type SetContributionGoalInput struct {
+	WeeklyCommits    *int   `json:"weekly_commits,omitempty" jsonschema:"Target number of GitHub contributions per week (Monday to Sunday). If omitted, keeps the existing target. Pass 0 to clear it."`
+	WeeklyActiveDays *int   `json:"weekly_active_days,omitempty" jsonschema:"Target number of days per week with at least one contribution, from 1 to 7. If omitted, keeps the existing target. Pass 0 to clear it."`
+	IfUnchangedSHA   string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// SetContributionGoalOutput is the output for the set_contribution_goal tool.
+type SetContributionGoalOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ContributionGoalItem is a JSON-serializable contribution goal for API responses.
+type ContributionGoalItem struct {
+	WeeklyCommits    int `json:"weekly_commits,omitempty"`
+	WeeklyActiveDays int `json:"weekly_active_days,omitempty"`
+}
+
+// Register registers goal tools with the MCP server.
+func (t *GoalTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "set_contribution_goal",
+		Description: "Set a weekly GitHub contribution goal (commits and/or active days). Progress is reported in momentum://github-activity and the weekly summary.",
+	}, t.setContributionGoal)
+}
+
+func (t *GoalTools) setContributionGoal(ctx context.Context, req *mcp.CallToolRequest, input SetContributionGoalInput) (*mcp.CallToolResult, SetContributionGoalOutput, error) {
+	if input.WeeklyCommits == nil && input.WeeklyActiveDays == nil {
+		return nil, SetContributionGoalOutput{
+			Success: false,
+			Message: "At least one of weekly_commits or weekly_active_days must be provided",
+		}, nil
+	}
+	if input.WeeklyCommits != nil && *input.WeeklyCommits < 0 {
+		return nil, SetContributionGoalOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid weekly_commits %d. Use a positive number, or 0 to clear the target.", *input.WeeklyCommits),
+		}, nil
+	}
+	if input.WeeklyActiveDays != nil && (*input.WeeklyActiveDays < 0 || *input.WeeklyActiveDays > 7) {
+		return nil, SetContributionGoalOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid weekly_active_days %d. Use a number from 1 to 7, or 0 to clear the target.", *input.WeeklyActiveDays),
+		}, nil
+	}
+
+	goals := &storage.Goals{}
+	content, sha, err := t.storage.ReadFile(ctx, storage.GoalsFile)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, SetContributionGoalOutput{}, fmt.Errorf("reading goals.md: %w", err)
+	}
+	if err == nil {
+		if goals, err = storage.ParseGoals(content); err != nil {
+			return nil, SetContributionGoalOutput{}, fmt.Errorf("parsing goals: %w", err)
+		}
+	}
+
+	if msg := entitystore.CheckUnchanged(storage.GoalsFile, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, SetContributionGoalOutput{
+			Success: false,
+			Message: msg,
+		}, nil
+	}
+
+	if input.WeeklyCommits != nil {
+		goals.Contribution.WeeklyCommits = *input.WeeklyCommits
+	}
+	if input.WeeklyActiveDays != nil {
+		goals.Contribution.WeeklyActiveDays = *input.WeeklyActiveDays
+	}
+
+	if err := t.storage.WriteFile(ctx, storage.GoalsFile, storage.SerializeGoals(goals), sha, "Set contribution goal"); err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, SetContributionGoalOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, SetContributionGoalOutput{}, fmt.Errorf("writing goals.md: %w", err)
+	}
+
+	itemJSON, err := json.Marshal(ContributionGoalItem{
+		WeeklyCommits:    goals.Contribution.WeeklyCommits,
+		WeeklyActiveDays: goals.Contribution.WeeklyActiveDays,
+	})
+	if err != nil {
+		return nil, SetContributionGoalOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, SetContributionGoalOutput{
+		Success: true,
+		Message: string(itemJSON),
+	}, nil
+}
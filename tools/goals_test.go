@@ -0,0 +1,37 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestSetContributionGoal(t *testing.T) {
+	files := newFileStorage(nil)
+	tools := NewGoalTools(files)
+	ctx := context.Background()
+	n := func(v int) *int { return &v }
+
+	_, out, err := tools.setContributionGoal(ctx, nil, SetContributionGoalInput{WeeklyCommits: n(20)})
+	if err != nil || !out.Success || out.Message != `{"weekly_commits":20}` {
+		t.Fatalf("setContributionGoal() = %+v, %v", out, err)
+	}
+
+	// Setting one target keeps the other
+	_, out, _ = tools.setContributionGoal(ctx, nil, SetContributionGoalInput{WeeklyActiveDays: n(5)})
+	if !out.Success || fileContent(t, files, storage.GoalsFile) != "# Goals\n\n## Contribution\n- Weekly commits: 20\n- Weekly active days: 5\n" {
+		t.Errorf("unexpected goals.md %q (%+v)", fileContent(t, files, storage.GoalsFile), out)
+	}
+
+	_, out, _ = tools.setContributionGoal(ctx, nil, SetContributionGoalInput{WeeklyCommits: n(0)})
+	g, _ := storage.ParseGoals(fileContent(t, files, storage.GoalsFile))
+	if !out.Success || g.Contribution.WeeklyCommits != 0 || g.Contribution.WeeklyActiveDays != 5 {
+		t.Errorf("expected the commits target to be cleared, got %+v", g.Contribution)
+	}
+
+	if _, out, _ := tools.setContributionGoal(ctx, nil, SetContributionGoalInput{WeeklyActiveDays: n(8)}); out.Success || !strings.Contains(out.Message, "Invalid weekly_active_days") {
+		t.Errorf("expected a validation error, got %+v", out)
+	}
+}
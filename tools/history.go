@@ -0,0 +1,221 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultHistoryCommits and maxHistoryCommits bound how many commits of a
+// data file get_item_history walks. Each commit costs one API request.
+const (
+	defaultHistoryCommits = 50
+	maxHistoryCommits     = 200
+)
+
+// historyFiles are the data files get_item_history searches for an ID.
+var historyFiles = []string{
+	storage.TodosFile,
+	storage.StrategyFile,
+	storage.ReadingListFile,
+	storage.RemindersFile,
+}
+
+// HistoryTools answers "when did I add this?" and "what changed since
+// yesterday?" from the data repo's commits.
+type HistoryTools struct {
+	storage storage.Storage
+	history storage.History
+	clock   clock.Clock
+}
+
+// NewHistoryTools creates a new HistoryTools instance. A nil clock uses the
+// system clock.
+func NewHistoryTools(s storage.Storage, h storage.History, c clock.Clock) *HistoryTools {
+	return &HistoryTools{storage: s, history: h, clock: clock.Or(c)}
+}
+
+// GetItemHistoryInput is the input schema for the get_item_history tool.
+type GetItemHistoryInput struct {
+	ID      string `json:"id" jsonschema:"ID of the todo, milestone, reading item, or reminder"`
+	Commits int    `json:"commits,omitempty" jsonschema:"How many recent commits of the item's file to check (default 50, max 200)"`
+}
+
+// GetItemHistoryOutput is the output for the get_item_history tool.
+type GetItemHistoryOutput struct {
+	Success bool               `json:"success"`
+	Message string             `json:"message"`
+	Result  *ItemHistoryResult `json:"result,omitempty"`
+}
+
+// ItemHistoryResult is the response payload for get_item_history.
+type ItemHistoryResult struct {
+	ID     string             `json:"id"`
+	File   string             `json:"file"`
+	Events []ItemHistoryEvent `json:"events"`
+	// Commits is how many commits of the file were checked.
+	Commits int `json:"commits"`
+}
+
+// ItemHistoryEvent is one change to an item, oldest first.
+type ItemHistoryEvent struct {
+	// Event is added, first seen (already present at the oldest commit
+	// checked), edited, moved, completed, reopened, or removed.
+	Event   string `json:"event"`
+	Date    string `json:"date"`
+	Commit  string `json:"commit"`
+	Message string `json:"message"`
+	// Line is the item's markdown line after the change; empty if removed.
+	Line string `json:"line,omitempty"`
+	// Section is the heading the item was under after the change.
+	Section string `json:"section,omitempty"`
+}
+
+// Register registers the item history tool with the MCP server.
+func (t *HistoryTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_item_history",
+		Description: "Report when an item was added, edited, and completed, with the commit messages, by walking recent commits of its data file. Answers questions like \"when did I add this?\".",
+	}, t.getItemHistory)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_changes",
+		Description: "List only the todos, milestones, reading items, and reminders added, edited, completed, reopened, moved, or removed since a time (default: the start of yesterday), by comparing the data files with their versions then. Much cheaper than re-reading the dashboard to see what changed.",
+	}, t.getChanges)
+}
+
+func (t *HistoryTools) getItemHistory(ctx context.Context, req *mcp.CallToolRequest, input GetItemHistoryInput) (*mcp.CallToolResult, GetItemHistoryOutput, error) {
+	id := strings.TrimSpace(input.ID)
+	if id == "" {
+		return nil, GetItemHistoryOutput{
+			Success: false,
+			Message: "id is required",
+		}, nil
+	}
+	commits := input.Commits
+	if commits <= 0 {
+		commits = defaultHistoryCommits
+	}
+	if commits > maxHistoryCommits {
+		commits = maxHistoryCommits
+	}
+
+	file, err := t.locate(ctx, id)
+	if err != nil {
+		return nil, GetItemHistoryOutput{}, err
+	}
+	if file == "" {
+		return nil, GetItemHistoryOutput{
+			Success: false,
+			Message: fmt.Sprintf("No todo, milestone, reading item, or reminder with ID %q", id),
+		}, nil
+	}
+
+	versions, err := storage.ItemHistory(ctx, t.history, file, id, commits)
+	if err != nil {
+		return nil, GetItemHistoryOutput{}, fmt.Errorf("reading history of %s: %w", file, err)
+	}
+
+	result := ItemHistoryResult{ID: id, File: file, Events: itemHistoryEvents(versions), Commits: commits}
+	text := result.text()
+	return textResult(text), GetItemHistoryOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+// locate returns the data file currently holding the item, or "" if none does.
+func (t *HistoryTools) locate(ctx context.Context, id string) (string, error) {
+	for _, file := range historyFiles {
+		content, _, err := t.storage.ReadFile(ctx, file)
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", file, err)
+		}
+		if _, line := storage.FindItemLine(content, id); line != "" {
+			return file, nil
+		}
+	}
+	return "", nil
+}
+
+// itemHistoryEvents classifies each change between consecutive versions.
+func itemHistoryEvents(versions []storage.ItemVersion) []ItemHistoryEvent {
+	events := []ItemHistoryEvent{}
+	var prev storage.ItemVersion
+	for i, v := range versions {
+		var event string
+		switch {
+		case v.Line == "":
+			event = "removed"
+		case i == 0 && v.Existing:
+			event = "first seen"
+		case i == 0 || prev.Line == "":
+			event = "added"
+		default:
+			event = changeEvent(prev, v)
+		}
+		message, _, _ := strings.Cut(v.Commit.Message, "\n")
+		events = append(events, ItemHistoryEvent{
+			Event:   event,
+			Date:    v.Commit.Date.UTC().Format("2006-01-02 15:04"),
+			Commit:  v.Commit.SHA,
+			Message: message,
+			Line:    v.Line,
+			Section: v.Section,
+		})
+		prev = v
+	}
+	return events
+}
+
+// changeEvent classifies the change between two versions of an item that
+// is in the file in both: completed, reopened, moved (same line, another
+// section) or edited.
+func changeEvent(prev, v storage.ItemVersion) string {
+	switch {
+	case isDone(v) && !isDone(prev):
+		return "completed"
+	case !isDone(v) && isDone(prev):
+		return "reopened"
+	case v.Line == prev.Line:
+		return "moved"
+	}
+	return "edited"
+}
+
+// isDone reports whether an item version is checked off or in a completed
+// section. Reminders have no checkbox, only a Completed section.
+func isDone(v storage.ItemVersion) bool {
+	return strings.HasPrefix(v.Line, "- [x]") || strings.HasPrefix(v.Line, "- [X]") ||
+		strings.HasPrefix(v.Section, "Completed") || v.Section == "Read"
+}
+
+func (r ItemHistoryResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "History of %s in %s (last %s checked)\n", r.ID, r.File, plural(r.Commits, "commit"))
+	if len(r.Events) == 0 {
+		sb.WriteString("No changes found")
+		return sb.String()
+	}
+	for _, e := range r.Events {
+		sha := e.Commit
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		fmt.Fprintf(&sb, "- %s %s: %s (%s)", e.Date, e.Event, e.Message, sha)
+		if e.Event == "moved" {
+			fmt.Fprintf(&sb, " to %s", e.Section)
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
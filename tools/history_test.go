@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// fileHistory serves fixed file versions, keyed by commit SHA, of path, or
+// of every file if path is empty.
+type fileHistory struct {
+	path     string
+	commits  []storage.Commit // newest first
+	versions map[string]string
+}
+
+func (h *fileHistory) ListCommits(ctx context.Context, path string, limit int) ([]storage.Commit, error) {
+	if h.path != "" && path != h.path {
+		return nil, nil
+	}
+	if limit > 0 && len(h.commits) > limit {
+		return h.commits[:limit], nil
+	}
+	return h.commits, nil
+}
+
+func (h *fileHistory) ReadFileAt(ctx context.Context, path string, ref string) (string, error) {
+	content, ok := h.versions[ref]
+	if !ok {
+		return "", storage.ErrNotFound
+	}
+	return content, nil
+}
+
+func TestGetItemHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 2, d, 9, 0, 0, 0, time.UTC) }
+	current := "# Active Todos\n\n# Completed\n- [x] Write API docs {id:abcd1234,completed:2026-02-05}\n"
+	files := newFileStorage(map[string]string{storage.TodosFile: current})
+	h := &fileHistory{
+		commits: []storage.Commit{
+			{SHA: "d", Message: "Complete todo: Write API docs", Date: day(5)},
+			{SHA: "c", Message: "Move todo to high priority", Date: day(4)},
+			{SHA: "b", Message: "Edit todo\n\nDetails", Date: day(3)},
+			{SHA: "a", Message: "Add todo: Write docs", Date: day(2)},
+		},
+		versions: map[string]string{
+			"a": "# Active Todos\n\n## Normal\n- [ ] Write docs {id:abcd1234}\n\n# Completed\n",
+			"b": "# Active Todos\n\n## Normal\n- [ ] Write API docs {id:abcd1234}\n\n# Completed\n",
+			"c": "# Active Todos\n\n## High Priority\n- [ ] Write API docs {id:abcd1234}\n\n# Completed\n",
+			"d": current,
+		},
+	}
+	ht := NewHistoryTools(files, h, nil)
+
+	_, out, err := ht.getItemHistory(context.Background(), nil, GetItemHistoryInput{ID: "abcd1234"})
+	if err != nil || !out.Success {
+		t.Fatalf("getItemHistory() = %+v, %v", out, err)
+	}
+	var events []string
+	for _, e := range out.Result.Events {
+		events = append(events, e.Event)
+	}
+	if got := strings.Join(events, ","); got != "added,edited,moved,completed" {
+		t.Errorf("events = %s, want added,edited,moved,completed", got)
+	}
+	if out.Result.File != storage.TodosFile || out.Result.Events[1].Message != "Edit todo" {
+		t.Errorf("unexpected result %+v", out.Result)
+	}
+
+	// Only the latest commits checked: the item predates them
+	_, out, _ = ht.getItemHistory(context.Background(), nil, GetItemHistoryInput{ID: "abcd1234", Commits: 2})
+	if len(out.Result.Events) != 2 || out.Result.Events[0].Event != "first seen" {
+		t.Errorf("unexpected limited events %+v", out.Result.Events)
+	}
+
+	if _, out, _ := ht.getItemHistory(context.Background(), nil, GetItemHistoryInput{ID: "ffff0000"}); out.Success {
+		t.Error("expected an unknown ID to fail")
+	}
+}
+
+func TestGetChanges(t *testing.T) {
+	at := func(d, h int) time.Time { return time.Date(2026, 2, d, h, 0, 0, 0, time.UTC) }
+	current := "# Active Todos\n\n## High Priority\n- [ ] Write API docs {id:abcd1234}\n- [ ] Ship it {id:eeee5555}\n\n" +
+		"# Completed\n- [x] Book flights {id:bbbb2222,completed:2026-02-05}\n"
+	files := newFileStorage(map[string]string{storage.TodosFile: current})
+	h := &fileHistory{
+		path: storage.TodosFile,
+		commits: []storage.Commit{
+			{SHA: "c", Message: "Add todo: Ship it", Date: at(5, 10)},
+			{SHA: "b", Message: "Complete todo: Book flights", Date: at(4, 18)},
+			{SHA: "a", Message: "Add todos", Date: at(3, 9)},
+		},
+		versions: map[string]string{
+			"a": "# Active Todos\n\n## High Priority\n- [ ] Write docs {id:abcd1234}\n- [ ] Book flights {id:bbbb2222}\n- [ ] Old idea {id:cccc3333}\n\n# Completed\n",
+			"b": "# Active Todos\n\n## High Priority\n- [ ] Write API docs {id:abcd1234}\n\n# Completed\n- [x] Book flights {id:bbbb2222,completed:2026-02-05}\n",
+			"c": current,
+		},
+	}
+	ht := NewHistoryTools(files, h, clock.NewFake(at(5, 12)))
+
+	changes := func(since string) string {
+		t.Helper()
+		_, out, err := ht.getChanges(context.Background(), nil, GetChangesInput{Since: since})
+		if err != nil || !out.Success {
+			t.Fatalf("getChanges(%q) = %+v, %v", since, out, err)
+		}
+		var got []string
+		for _, c := range out.Result.Changes {
+			got = append(got, c.Change+" "+c.ID)
+		}
+		return strings.Join(got, ",")
+	}
+
+	// Since the start of the 4th: against commit a
+	if got := changes("2026-02-04"); got != "edited abcd1234,added eeee5555,completed bbbb2222,removed cccc3333" {
+		t.Errorf("changes since the 4th = %s", got)
+	}
+	// Yesterday (the 4th) is the default
+	if got := changes(""); got != "edited abcd1234,added eeee5555,completed bbbb2222,removed cccc3333" {
+		t.Errorf("changes since yesterday = %s", got)
+	}
+	// Against commit b
+	if got := changes("2026-02-05T08:00:00Z"); got != "added eeee5555" {
+		t.Errorf("changes since the 5th 08:00 = %s", got)
+	}
+	// Before the file's first commit, everything is new
+	if got := changes("2026-02-01"); got != "added abcd1234,added eeee5555,added bbbb2222" {
+		t.Errorf("changes since the 1st = %s", got)
+	}
+
+	if _, out, _ := ht.getChanges(context.Background(), nil, GetChangesInput{Since: "2026-02-06"}); out.Success {
+		t.Error("expected since in the future to fail")
+	}
+}
@@ -0,0 +1,437 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ImportDataInput is the input schema for the import_data tool.
+type ImportDataInput struct {
+	Data   string `json:"data" jsonschema:"JSON document in the export_data format. Only the sections present (todos, strategy, reading_list, reminders) are imported; their files are overwritten."`
+	DryRun bool   `json:"dry_run,omitempty" jsonschema:"Set to true to validate and show the diff for each file without writing anything"`
+}
+
+// ImportDataOutput is the output for the import_data tool.
+type ImportDataOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ImportDataResult is the response payload for import_data.
+type ImportDataResult struct {
+	DryRun bool             `json:"dry_run"`
+	Files  []ImportFileDiff `json:"files"`
+}
+
+// ImportFileDiff describes what an import does to one file.
+type ImportFileDiff struct {
+	Path   string `json:"path"`
+	Action string `json:"action"` // create, overwrite, or unchanged
+	Items  int    `json:"items"`
+	// Diff lists removed ("- ") and added ("+ ") lines; only set on dry runs.
+	Diff string `json:"diff,omitempty"`
+}
+
+// importSnapshot is ExportSnapshot with optional sections, so a partial
+// document (e.g. only todos from another task manager) leaves the other
+// files alone.
+type importSnapshot struct {
+	Todos       *ExportTodos       `json:"todos"`
+	Strategy    *ExportStrategy    `json:"strategy"`
+	ReadingList *ExportReadingList `json:"reading_list"`
+	Reminders   *ExportReminders   `json:"reminders"`
+}
+
+// importFile is a rendered file waiting to be written.
+type importFile struct {
+	path    string
+	content string
+	items   int
+}
+
+func (t *ExportTools) importData(ctx context.Context, req *mcp.CallToolRequest, input ImportDataInput) (*mcp.CallToolResult, ImportDataOutput, error) {
+	if strings.TrimSpace(input.Data) == "" {
+		return nil, ImportDataOutput{
+			Success: false,
+			Message: "data is required",
+		}, nil
+	}
+
+	var snap importSnapshot
+	if err := json.Unmarshal([]byte(input.Data), &snap); err != nil {
+		return nil, ImportDataOutput{
+			Success: false,
+			Message: fmt.Sprintf("Could not parse data as JSON: %v", err),
+		}, nil
+	}
+
+	files, problems := buildImportFiles(&snap, t.clock.Now())
+	if len(problems) > 0 {
+		return nil, ImportDataOutput{
+			Success: false,
+			Message: "Nothing was imported. Fix these items and try again:\n" + strings.Join(problems, "\n"),
+		}, nil
+	}
+	if len(files) == 0 {
+		return nil, ImportDataOutput{
+			Success: false,
+			Message: "No sections to import. Include at least one of: todos, strategy, reading_list, reminders",
+		}, nil
+	}
+
+	result := ImportDataResult{DryRun: input.DryRun, Files: []ImportFileDiff{}}
+	var changes []storage.FileChange
+	var summary []string
+	for _, f := range files {
+		current, sha, err := t.storage.ReadFile(ctx, f.path)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return nil, ImportDataOutput{}, fmt.Errorf("reading %s: %w", f.path, err)
+		}
+
+		d := ImportFileDiff{Path: f.path, Items: f.items, Action: "overwrite"}
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			d.Action = "create"
+		case current == f.content:
+			d.Action = "unchanged"
+		}
+		if input.DryRun {
+			if d.Action != "unchanged" {
+				d.Diff = lineDiff(current, f.content)
+			}
+			result.Files = append(result.Files, d)
+			continue
+		}
+
+		if d.Action != "unchanged" {
+			changes = append(changes, storage.FileChange{Path: f.path, Content: f.content, SHA: sha})
+			summary = append(summary, fmt.Sprintf("%s (%d items)", f.path, f.items))
+		}
+		result.Files = append(result.Files, d)
+	}
+
+	// All files go in one commit where the backend supports it, so an import
+	// is a single step in the history
+	if len(changes) > 0 {
+		message := "Import " + strings.Join(summary, ", ")
+		if err := storage.WriteFiles(ctx, t.storage, changes, message); err != nil {
+			if msg, ok := entitystore.WriteMessage(err); ok {
+				return nil, ImportDataOutput{
+					Success: false,
+					Message: msg,
+				}, nil
+			}
+			return nil, ImportDataOutput{}, fmt.Errorf("writing import: %w", err)
+		}
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, ImportDataOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, ImportDataOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
+
+// buildImportFiles converts each present section back to markdown. Items
+// without IDs get new ones and items without an added date are added today.
+// Every invalid item is reported, so one pass finds all problems.
+func buildImportFiles(snap *importSnapshot, now time.Time) ([]importFile, []string) {
+	c := &importConverter{now: now, today: clock.Date(now)}
+	var files []importFile
+
+	if snap.Todos != nil {
+		tf := &storage.TodoFile{}
+		for i, item := range snap.Todos.Active {
+			tf.Active = append(tf.Active, c.todo(fmt.Sprintf("todos.active[%d]", i), item, false))
+		}
+		for i, item := range snap.Todos.Completed {
+			tf.Completed = append(tf.Completed, c.todo(fmt.Sprintf("todos.completed[%d]", i), item, true))
+		}
+		files = append(files, importFile{storage.TodosFile, storage.SerializeTodos(tf), len(tf.Active) + len(tf.Completed)})
+	}
+
+	if snap.Strategy != nil {
+		s := &storage.Strategy{CurrentPhase: snap.Strategy.CurrentPhase}
+		for i, item := range snap.Strategy.Phases {
+			s.Phases = append(s.Phases, c.phase(fmt.Sprintf("strategy.phases[%d]", i), item))
+		}
+		for i, item := range snap.Strategy.ActiveMilestones {
+			s.ActiveMilestones = append(s.ActiveMilestones, c.milestone(fmt.Sprintf("strategy.active_milestones[%d]", i), item, false))
+		}
+		for i, item := range snap.Strategy.CompletedMilestones {
+			s.CompletedMilestones = append(s.CompletedMilestones, c.milestone(fmt.Sprintf("strategy.completed_milestones[%d]", i), item, true))
+		}
+		for _, note := range snap.Strategy.Notes {
+			if note = strings.TrimSpace(note); note != "" {
+				s.Notes = append(s.Notes, note)
+			}
+		}
+		files = append(files, importFile{storage.StrategyFile, storage.SerializeStrategy(s), len(s.ActiveMilestones) + len(s.CompletedMilestones) + len(s.Notes)})
+	}
+
+	if snap.ReadingList != nil {
+		rl := &storage.ReadingList{}
+		for i, item := range snap.ReadingList.ToRead {
+			rl.ToRead = append(rl.ToRead, c.reading(fmt.Sprintf("reading_list.to_read[%d]", i), item, false))
+		}
+		for i, item := range snap.ReadingList.Read {
+			rl.Read = append(rl.Read, c.reading(fmt.Sprintf("reading_list.read[%d]", i), item, true))
+		}
+		files = append(files, importFile{storage.ReadingListFile, storage.SerializeReadingList(rl), len(rl.ToRead) + len(rl.Read)})
+	}
+
+	if snap.Reminders != nil {
+		rf := &storage.ReminderFile{}
+		for i, item := range snap.Reminders.Upcoming {
+			rf.Upcoming = append(rf.Upcoming, c.reminder(fmt.Sprintf("reminders.upcoming[%d]", i), item, false))
+		}
+		for i, item := range snap.Reminders.Completed {
+			rf.Completed = append(rf.Completed, c.reminder(fmt.Sprintf("reminders.completed[%d]", i), item, true))
+		}
+		files = append(files, importFile{storage.RemindersFile, storage.SerializeReminders(rf), len(rf.Upcoming) + len(rf.Completed)})
+	}
+
+	return files, c.problems
+}
+
+// importConverter turns export items back into storage types, collecting
+// validation problems as it goes.
+type importConverter struct {
+	now      time.Time
+	today    time.Time
+	problems []string
+	// ids are the IDs used so far, so new ones don't collide
+	ids map[string]bool
+}
+
+func (c *importConverter) fail(where, format string, args ...any) {
+	c.problems = append(c.problems, "- "+where+": "+fmt.Sprintf(format, args...))
+}
+
+// id returns the item's ID, or a new one with prefix if it has none.
+func (c *importConverter) id(id, prefix string) string {
+	if c.ids == nil {
+		c.ids = make(map[string]bool)
+	}
+	if id = strings.TrimSpace(id); id == "" {
+		id = storage.NewID(prefix, func(id string) bool { return c.ids[id] })
+	}
+	c.ids[id] = true
+	return id
+}
+
+// date parses an optional date, defaulting to def when empty.
+func (c *importConverter) date(where, field, s string, def time.Time) time.Time {
+	if strings.TrimSpace(s) == "" {
+		return def
+	}
+	d, err := parseDate(s, c.now)
+	if err != nil {
+		c.fail(where, "invalid %s %q", field, s)
+	}
+	return d
+}
+
+// completedAt parses completed_at for items in a completed section, which
+// defaults to today.
+func (c *importConverter) completedAt(where, field string, s *string, completed bool) *time.Time {
+	if !completed {
+		return nil
+	}
+	value := ""
+	if s != nil {
+		value = *s
+	}
+	d := c.date(where, field, value, c.today)
+	return &d
+}
+
+func (c *importConverter) todo(where string, item TodoItem, completed bool) storage.Todo {
+	if strings.TrimSpace(item.Text) == "" {
+		c.fail(where, "text is required")
+	}
+	priority := storage.PriorityNormal
+	if item.Priority != "" {
+		p, ok := parsePriority(item.Priority)
+		if !ok {
+			c.fail(where, "invalid priority %q", item.Priority)
+		}
+		priority = p
+	}
+	todo := storage.Todo{
+		ID:          c.id(item.ID, storage.PrefixTodo),
+		Text:        strings.TrimSpace(item.Text),
+		Priority:    priority,
+		Project:     storage.NormalizeProject(item.Project),
+		Milestone:   strings.TrimSpace(item.MilestoneID),
+		Completed:   completed,
+		Added:       c.date(where, "added", item.Added, c.today),
+		CompletedAt: c.completedAt(where, "completed_at", item.CompletedAt, completed),
+	}
+	for i, sub := range item.Subtasks {
+		if strings.TrimSpace(sub.Text) == "" {
+			c.fail(fmt.Sprintf("%s subtask %d", where, i+1), "text is required")
+		}
+		todo.Subtasks = append(todo.Subtasks, storage.Subtask{ID: c.id(sub.ID, storage.PrefixSubtask), Text: strings.TrimSpace(sub.Text), Completed: sub.Completed})
+	}
+	return todo
+}
+
+func (c *importConverter) phase(where string, item PhaseItem) storage.Phase {
+	if strings.TrimSpace(item.Name) == "" {
+		c.fail(where, "name is required")
+	}
+	p := storage.Phase{
+		Name:       strings.TrimSpace(item.Name),
+		Started:    c.date(where, "started", item.Started, time.Time{}),
+		Objectives: item.Objectives,
+	}
+	if strings.TrimSpace(item.Ended) != "" {
+		ended := c.date(where, "ended", item.Ended, time.Time{})
+		p.Ended = &ended
+	}
+	return p
+}
+
+func (c *importConverter) milestone(where string, item MilestoneItem, completed bool) storage.Milestone {
+	if strings.TrimSpace(item.Text) == "" {
+		c.fail(where, "text is required")
+	}
+	m := storage.Milestone{
+		ID:          c.id(item.ID, storage.PrefixMilestone),
+		Text:        strings.TrimSpace(item.Text),
+		Project:     storage.NormalizeProject(item.Project),
+		Issue:       item.Issue,
+		Completed:   completed,
+		Added:       c.date(where, "added", item.Added, c.today),
+		CompletedAt: c.completedAt(where, "completed_at", item.CompletedAt, completed),
+	}
+	if item.Due != nil && strings.TrimSpace(*item.Due) != "" {
+		due := c.date(where, "due", *item.Due, time.Time{})
+		m.Due = &due
+	}
+	for i, k := range item.KeyResults {
+		if strings.TrimSpace(k.Text) == "" || k.Target <= 0 {
+			c.fail(fmt.Sprintf("%s.key_results[%d]", where, i), "text and a target greater than 0 are required")
+		}
+		m.KeyResults = append(m.KeyResults, storage.KeyResult{
+			ID:      c.id(k.ID, storage.PrefixKeyResult),
+			Text:    strings.TrimSpace(k.Text),
+			Current: k.Current,
+			Target:  k.Target,
+			Unit:    strings.TrimSpace(k.Unit),
+		})
+	}
+	return m
+}
+
+func (c *importConverter) reading(where string, item ReadingListItem, read bool) storage.ReadingItem {
+	if strings.TrimSpace(item.URL) == "" {
+		c.fail(where, "url is required")
+	}
+	priority, ok := parseReadingPriority(item.Priority)
+	if !ok && item.Priority != "" {
+		c.fail(where, "invalid priority %q", item.Priority)
+	}
+	return storage.ReadingItem{
+		ID:       c.id(item.ID, storage.PrefixReading),
+		URL:      strings.TrimSpace(item.URL),
+		Notes:    strings.TrimSpace(item.Notes),
+		Read:     read,
+		Added:    c.date(where, "added", item.Added, c.today),
+		ReadAt:   c.completedAt(where, "read_at", item.ReadAt, read),
+		Priority: priority,
+		Category: storage.NormalizeProject(item.Category),
+	}
+}
+
+func (c *importConverter) reminder(where string, item ReminderItem, completed bool) storage.Reminder {
+	if strings.TrimSpace(item.Text) == "" {
+		c.fail(where, "text is required")
+	}
+	if strings.TrimSpace(item.Date) == "" {
+		c.fail(where, "date is required")
+	}
+	return storage.Reminder{
+		ID:          c.id(item.ID, storage.PrefixReminder),
+		Date:        c.date(where, "date", item.Date, time.Time{}),
+		Text:        strings.TrimSpace(item.Text),
+		Completed:   completed,
+		Added:       c.date(where, "added", item.Added, c.today),
+		CompletedAt: c.completedAt(where, "completed_at", item.CompletedAt, completed),
+	}
+}
+
+// maxDiffLines bounds the line-by-line diff; larger files are shown as a
+// whole replacement rather than spending quadratic time on them.
+const maxDiffLines = 2000
+
+// lineDiff returns the removed ("- ") and added ("+ ") lines between two
+// texts, in order, based on their longest common subsequence.
+func lineDiff(oldText, newText string) string {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+
+	var out strings.Builder
+	if len(a) > maxDiffLines || len(b) > maxDiffLines {
+		for _, l := range a {
+			out.WriteString("- " + l + "\n")
+		}
+		for _, l := range b {
+			out.WriteString("+ " + l + "\n")
+		}
+		return out.String()
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			out.WriteString("+ " + b[j] + "\n")
+			j++
+		default:
+			out.WriteString("- " + a[i] + "\n")
+			i++
+		}
+	}
+	return out.String()
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
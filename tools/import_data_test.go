@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+)
+
+func TestImportData_RoundTrip(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		"todos.md":        "# Active Todos\n\n## High Priority\n- [ ] Ship it {id:aaaa1111,added:2026-02-01,milestone:cccc3333}\n\n## Normal Priority\n\n## Someday\n\n## Completed\n- [x] Done {id:bbbb2222,added:2026-01-01,completed:2026-01-05}\n",
+		"strategy.md":     "## Current Phase\nLaunch\n\n## Active Milestones\n- [ ] Beta — Due: 2026-03-01 {id:cccc3333,added:2026-02-01,issue:7}\n\n## Notes\n- Keep scope small\n",
+		"reading-list.md": "# Reading List\n\n## To Read\n- [ ] https://go.dev/blog — Added: 2026-02-01 {id:dddd4444,priority:next,category:go}\n\n## Read\n",
+	})
+	now := time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)
+	et := NewExportTools(files, clock.NewFake(now))
+
+	snap, err := et.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+	data, _ := json.Marshal(snap)
+	var in importSnapshot
+	if err := json.Unmarshal(data, &in); err != nil {
+		t.Fatal(err)
+	}
+
+	out, problems := buildImportFiles(&in, now)
+	if len(problems) > 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+	if len(out) != 4 {
+		t.Fatalf("expected 4 files, got %d", len(out))
+	}
+	for _, f := range out {
+		if f.path == "todos.md" && !strings.Contains(f.content, "Ship it {id:aaaa1111,added:2026-02-01,milestone:cccc3333}") {
+			t.Errorf("todo not preserved:\n%s", f.content)
+		}
+		if f.path == "strategy.md" && !strings.Contains(f.content, "issue:7") {
+			t.Errorf("milestone issue not preserved:\n%s", f.content)
+		}
+		if f.path == "reading-list.md" && !strings.Contains(f.content, "{id:dddd4444,priority:next,category:go}") {
+			t.Errorf("reading priority and category not preserved:\n%s", f.content)
+		}
+	}
+}
+
+func TestBuildImportFiles_Partial(t *testing.T) {
+	now := time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)
+	var in importSnapshot
+	json.Unmarshal([]byte(`{"todos":{"active":[{"text":"From elsewhere","priority":"urgent"}]}}`), &in)
+
+	out, problems := buildImportFiles(&in, now)
+	if len(problems) > 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+	if len(out) != 1 || out[0].path != "todos.md" {
+		t.Fatalf("expected only todos.md, got %+v", out)
+	}
+	if !strings.Contains(out[0].content, "From elsewhere {id:") || !strings.Contains(out[0].content, "added:2026-02-10") {
+		t.Errorf("expected generated id and today's added date:\n%s", out[0].content)
+	}
+}
+
+func TestBuildImportFiles_Problems(t *testing.T) {
+	now := time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)
+	var in importSnapshot
+	json.Unmarshal([]byte(`{"todos":{"active":[{"text":""},{"text":"ok","priority":"whenever"}]},"reminders":{"upcoming":[{"text":"x","date":"someday"}]}}`), &in)
+
+	_, problems := buildImportFiles(&in, now)
+	if len(problems) != 3 {
+		t.Fatalf("expected 3 problems, got %v", problems)
+	}
+	if !strings.Contains(problems[0], "todos.active[0]") || !strings.Contains(problems[2], "reminders.upcoming[0]") {
+		t.Errorf("problems don't name the items: %v", problems)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	got := lineDiff("a\nb\nc\n", "a\nc\nd\n")
+	want := "- b\n+ d\n"
+	if got != want {
+		t.Errorf("lineDiff() = %q, want %q", got, want)
+	}
+	if got := lineDiff("", "x\n"); got != "+ x\n" {
+		t.Errorf("lineDiff() for new file = %q", got)
+	}
+}
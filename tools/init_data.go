@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/assets"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// InitTools bootstraps the data repository.
+type InitTools struct {
+	storage storage.Storage
+}
+
+// NewInitTools creates a new InitTools instance.
+func NewInitTools(s storage.Storage) *InitTools {
+	return &InitTools{storage: s}
+}
+
+// InitDataInput is the input schema for the init_data tool.
+type InitDataInput struct{}
+
+// InitDataOutput is the output for the init_data tool.
+type InitDataOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// InitDataResult reports which data files init_data created.
+type InitDataResult struct {
+	Created  []string `json:"created"`
+	Existing []string `json:"existing"`
+}
+
+// Register registers the init_data tool with the MCP server.
+func (t *InitTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "init_data",
+		Description: "Create any missing data files (todos, strategy, reading list, reminders, journal, ...) with empty templates. Existing files are left alone.",
+	}, t.initData)
+}
+
+func (t *InitTools) initData(ctx context.Context, req *mcp.CallToolRequest, input InitDataInput) (*mcp.CallToolResult, InitDataOutput, error) {
+	result, err := InitDataFiles(ctx, t.storage)
+	if err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, InitDataOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, InitDataOutput{}, err
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, InitDataOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, InitDataOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
+
+// InitDataFiles creates every missing data file from its default template,
+// in a single commit where the backend supports it.
+func InitDataFiles(ctx context.Context, s storage.Storage) (*InitDataResult, error) {
+	result := &InitDataResult{Created: []string{}, Existing: []string{}}
+	var changes []storage.FileChange
+	for _, name := range storage.DataFiles {
+		_, _, err := s.ReadFile(ctx, name)
+		if err == nil {
+			result.Existing = append(result.Existing, name)
+			continue
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+
+		content, err := assets.DefaultDataFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("loading template for %s: %w", name, err)
+		}
+		changes = append(changes, storage.FileChange{Path: name, Content: content})
+		result.Created = append(result.Created, name)
+	}
+
+	if len(changes) > 0 {
+		message := "Initialize " + strings.Join(result.Created, ", ")
+		if err := storage.WriteFiles(ctx, s, changes, message); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestInitDataFiles(t *testing.T) {
+	files := newFileStorage(map[string]string{storage.TodosFile: "# Active Todos\n\n## High Priority\n- [ ] Keep me {id:aaaa1111,added:2026-02-01}\n"})
+	ctx := context.Background()
+
+	result, err := InitDataFiles(ctx, files)
+	if err != nil {
+		t.Fatalf("InitDataFiles() error = %v", err)
+	}
+	if len(result.Existing) != 1 || result.Existing[0] != storage.TodosFile {
+		t.Errorf("existing = %v, want [todos.md]", result.Existing)
+	}
+	if len(result.Created) != len(storage.DataFiles)-1 {
+		t.Errorf("created %d files, want %d: %v", len(result.Created), len(storage.DataFiles)-1, result.Created)
+	}
+	if fileContent(t, files, storage.TodosFile) != "# Active Todos\n\n## High Priority\n- [ ] Keep me {id:aaaa1111,added:2026-02-01}\n" {
+		t.Error("existing file was overwritten")
+	}
+
+	// The created files are usable straight away
+	if _, err := parseStrategy(ctx, fileContent(t, files, storage.StrategyFile)); err != nil {
+		t.Errorf("created strategy.md doesn't parse: %v", err)
+	}
+	if _, err := parseReminders(ctx, fileContent(t, files, storage.RemindersFile)); err != nil {
+		t.Errorf("created reminders.md doesn't parse: %v", err)
+	}
+
+	// A second run has nothing to do
+	result, err = InitDataFiles(ctx, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Created) != 0 {
+		t.Errorf("expected nothing created on the second run, got %v", result.Created)
+	}
+}
+
+func TestInitData_Tool(t *testing.T) {
+	files := newFileStorage(nil)
+	tools := NewInitTools(files)
+
+	_, out, err := tools.initData(context.Background(), nil, InitDataInput{})
+	if err != nil || !out.Success {
+		t.Fatalf("initData() = %+v, %v", out, err)
+	}
+	var result InitDataResult
+	if err := json.Unmarshal([]byte(out.Message), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Created) != len(storage.DataFiles) {
+		t.Errorf("expected every data file created, got %v", result.Created)
+	}
+	for _, name := range storage.DataFiles {
+		if fileContent(t, files, name) == "" {
+			t.Errorf("%s wasn't written", name)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// itemFiles are the files get_item searches, in order. The first item with
+// the ID wins.
+var itemFiles = []string{
+	storage.TodosFile, storage.StrategyFile, storage.RemindersFile, storage.ReadingListFile,
+	storage.NotesFile, storage.JournalFile, storage.TimeLogFile, storage.TrashFile,
+}
+
+// todoSections are the todos.md headings active todos are filed under.
+var todoSections = map[storage.Priority]string{
+	storage.PriorityUrgent:  "Urgent",
+	storage.PriorityHigh:    "High Priority",
+	storage.PriorityNormal:  "Normal",
+	storage.PrioritySomeday: "Someday",
+}
+
+// ItemTools looks up single items by ID.
+type ItemTools struct {
+	storage storage.Storage
+	clock   clock.Clock
+}
+
+// NewItemTools creates a new ItemTools instance. A nil clock uses the system clock.
+func NewItemTools(s storage.Storage, c clock.Clock) *ItemTools {
+	return &ItemTools{storage: s, clock: clock.Or(c)}
+}
+
+// GetItemInput is the input schema for the get_item tool.
+type GetItemInput struct {
+	ID string `json:"id" jsonschema:"ID of the item: a todo, milestone, reminder, reading list item, note, journal entry or time log session"`
+}
+
+// GetItemOutput is the output for the get_item tool.
+type GetItemOutput struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Result  *GetItemResult `json:"result,omitempty"`
+}
+
+// GetItemResult is the response payload for get_item. The field named by
+// Type holds the item.
+type GetItemResult struct {
+	// Type is todo, milestone, reminder, reading, note, journal or time.
+	Type string `json:"type"`
+	File string `json:"file"`
+	// Section is the heading the item is filed under, if any.
+	Section      string            `json:"section,omitempty"`
+	Todo         *TodoItem         `json:"todo,omitempty"`
+	Milestone    *MilestoneItem    `json:"milestone,omitempty"`
+	Reminder     *ReminderItem     `json:"reminder,omitempty"`
+	Reading      *ReadingListItem  `json:"reading,omitempty"`
+	Note         *NoteItem         `json:"note,omitempty"`
+	JournalEntry *JournalEntryItem `json:"journal,omitempty"`
+	TimeEntry    *TimeEntryItem    `json:"time,omitempty"`
+	// DeletedAt is set for items in the trash.
+	DeletedAt string `json:"deleted_at,omitempty"`
+	SourceSHA string `json:"source_sha"`
+}
+
+// Register registers item tools with the MCP server.
+func (t *ItemTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_item",
+		Description: "Get any item by ID (todo, milestone, reminder, reading list item, note, journal entry or time log session) with its full record and the file and section it lives in. Includes archived reading and deleted items.",
+	}, t.getItem)
+}
+
+func (t *ItemTools) getItem(ctx context.Context, req *mcp.CallToolRequest, input GetItemInput) (*mcp.CallToolResult, GetItemOutput, error) {
+	id := strings.TrimSpace(input.ID)
+	if id == "" {
+		return nil, GetItemOutput{Success: false, Message: "id is required"}, nil
+	}
+
+	result, err := t.find(ctx, id)
+	if err != nil {
+		return nil, GetItemOutput{}, err
+	}
+	if result == nil {
+		return nil, GetItemOutput{
+			Success: false,
+			Message: fmt.Sprintf("No item found with id %q", id),
+		}, nil
+	}
+
+	text := result.text()
+	return textResult(text), GetItemOutput{
+		Success: true,
+		Message: text,
+		Result:  result,
+	}, nil
+}
+
+// find searches itemFiles, then the reading list archives, for id. It
+// returns nil if there's no such item.
+func (t *ItemTools) find(ctx context.Context, id string) (*GetItemResult, error) {
+	now := t.clock.Now()
+	files := storage.ReadFiles(ctx, t.storage, itemFiles...)
+	for _, path := range itemFiles {
+		r := files[path]
+		if errors.Is(r.Err, storage.ErrNotFound) {
+			continue
+		}
+		if r.Err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, r.Err)
+		}
+		result, err := findItem(ctx, path, r.Content, id, now)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			result.File, result.SourceSHA = path, r.SHA
+			return result, nil
+		}
+	}
+
+	archived, err := readArchivedReading(ctx, t.storage, clock.Today(t.clock))
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range archived {
+		if a.item.ID == id {
+			item := readingToItem(a.item)
+			item.Archive = a.path
+			_, sha, err := t.storage.ReadFile(ctx, a.path)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", a.path, err)
+			}
+			return &GetItemResult{Type: "reading", File: a.path, Section: "Read", Reading: &item, SourceSHA: sha}, nil
+		}
+	}
+	return nil, nil
+}
+
+// findItem looks for id among the items in one of itemFiles.
+func findItem(ctx context.Context, path, content, id string, now time.Time) (*GetItemResult, error) {
+	switch path {
+	case storage.TodosFile:
+		tf, err := parseTodos(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing todos: %w", err)
+		}
+		for _, todo := range tf.Active {
+			if todo.ID == id {
+				item := todoToItem(todo)
+				section := todoSections[todo.Priority]
+				if section == "" {
+					section = todoSections[storage.PriorityNormal]
+				}
+				return &GetItemResult{Type: "todo", Section: section, Todo: &item}, nil
+			}
+		}
+		for _, todo := range tf.Completed {
+			if todo.ID == id {
+				item := todoToItem(todo)
+				return &GetItemResult{Type: "todo", Section: "Completed", Todo: &item}, nil
+			}
+		}
+
+	case storage.StrategyFile:
+		s, err := parseStrategy(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing strategy: %w", err)
+		}
+		for _, m := range s.ActiveMilestones {
+			if m.ID == id {
+				item := milestoneToItem(m)
+				return &GetItemResult{Type: "milestone", Section: "Active Milestones", Milestone: &item}, nil
+			}
+		}
+		for _, m := range s.CompletedMilestones {
+			if m.ID == id {
+				item := milestoneToItem(m)
+				return &GetItemResult{Type: "milestone", Section: "Completed Milestones", Milestone: &item}, nil
+			}
+		}
+
+	case storage.RemindersFile:
+		rf, err := parseReminders(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing reminders: %w", err)
+		}
+		for _, r := range append(rf.Upcoming, rf.Completed...) {
+			if r.ID == id {
+				item := reminderToItem(r, clock.Date(now))
+				section := "Upcoming"
+				if r.Completed {
+					section = "Completed"
+				}
+				return &GetItemResult{Type: "reminder", Section: section, Reminder: &item}, nil
+			}
+		}
+
+	case storage.ReadingListFile:
+		rl, err := parseReadingList(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing reading list: %w", err)
+		}
+		for _, r := range append(rl.ToRead, rl.Read...) {
+			if r.ID == id {
+				item := readingToItem(r)
+				section := "To Read"
+				if r.Read {
+					section = "Read"
+				}
+				return &GetItemResult{Type: "reading", Section: section, Reading: &item}, nil
+			}
+		}
+
+	case storage.NotesFile:
+		nf, err := parseNotes(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing notes: %w", err)
+		}
+		for _, n := range nf.Notes {
+			if n.ID == id {
+				item := noteToItem(n)
+				return &GetItemResult{Type: "note", Section: n.Category, Note: &item}, nil
+			}
+		}
+
+	case storage.JournalFile:
+		j, err := parseJournal(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing journal: %w", err)
+		}
+		for _, e := range j.Entries {
+			if e.ID == id {
+				item := journalEntryToItem(e)
+				return &GetItemResult{Type: "journal", Section: item.Date, JournalEntry: &item}, nil
+			}
+		}
+
+	case storage.TimeLogFile:
+		l, err := parseTimeLog(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing time log: %w", err)
+		}
+		for _, e := range l.Entries {
+			if e.ID == id {
+				item := timeEntryToItem(e, now)
+				return &GetItemResult{Type: "time", TimeEntry: &item}, nil
+			}
+		}
+
+	case storage.TrashFile:
+		trash, err := storage.ParseTrash(content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing trash: %w", err)
+		}
+		for _, ti := range trash.Items {
+			if ti.ID() != id {
+				continue
+			}
+			result := &GetItemResult{Type: ti.Type(), DeletedAt: ti.DeletedAt.UTC().Format(time.RFC3339)}
+			switch {
+			case ti.Todo != nil:
+				item := todoToItem(*ti.Todo)
+				result.Section, result.Todo = "Todos", &item
+			case ti.Reminder != nil:
+				item := reminderToItem(*ti.Reminder, clock.Date(now))
+				result.Section, result.Reminder = "Reminders", &item
+			case ti.Note != nil:
+				item := noteToItem(*ti.Note)
+				result.Section, result.Note = "Notes", &item
+			}
+			return result, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r GetItemResult) text() string {
+	var sb strings.Builder
+	var comments []CommentItem
+	switch {
+	case r.Todo != nil:
+		status := "active"
+		if r.Todo.Completed {
+			status = "completed"
+		}
+		fmt.Fprintf(&sb, "Todo %s: %s%s\n", r.Todo.ID, r.Todo.Text, itemDetails(status, labeled("priority", r.Todo.Priority),
+			labeled("project", r.Todo.Project), labeled("milestone", r.Todo.MilestoneID), labeled("added", r.Todo.Added),
+			labeledPtr("completed", r.Todo.CompletedAt)))
+		for _, sub := range r.Todo.Subtasks {
+			fmt.Fprintf(&sb, "  - %s %s (%s)\n", checkbox(sub.Completed), sub.Text, sub.ID)
+		}
+		comments = r.Todo.Comments
+	case r.Milestone != nil:
+		status := "active"
+		if r.Milestone.Completed {
+			status = "completed"
+		}
+		fmt.Fprintf(&sb, "Milestone %s: %s%s\n", r.Milestone.ID, r.Milestone.Text, itemDetails(status, labeledPtr("due", r.Milestone.Due),
+			labeled("project", r.Milestone.Project), labeled("added", r.Milestone.Added), labeledPtr("completed", r.Milestone.CompletedAt)))
+		comments = r.Milestone.Comments
+	case r.Reminder != nil:
+		status := "upcoming"
+		switch {
+		case r.Reminder.Completed:
+			status = "completed"
+		case r.Reminder.Overdue:
+			status = "overdue"
+		}
+		fmt.Fprintf(&sb, "Reminder %s: %s: %s%s\n", r.Reminder.ID, r.Reminder.Date, r.Reminder.Text, itemDetails(status,
+			labeled("added", r.Reminder.Added), labeledPtr("completed", r.Reminder.CompletedAt)))
+	case r.Reading != nil:
+		status := "unread"
+		if r.Reading.Read {
+			status = "read"
+		}
+		fmt.Fprintf(&sb, "Reading %s: %s%s\n", r.Reading.ID, r.Reading.URL, itemDetails(status, labeled("priority", r.Reading.Priority),
+			labeled("category", r.Reading.Category), labeled("notes", r.Reading.Notes), labeled("added", r.Reading.Added),
+			labeledPtr("read", r.Reading.ReadAt)))
+	case r.Note != nil:
+		fmt.Fprintf(&sb, "Note %s: %s%s\n", r.Note.ID, r.Note.Text, itemDetails(labeled("category", r.Note.Category), labeled("added", r.Note.Added)))
+	case r.JournalEntry != nil:
+		fmt.Fprintf(&sb, "Journal entry %s: %s %s %s\n", r.JournalEntry.ID, r.JournalEntry.Date, r.JournalEntry.Time, r.JournalEntry.Text)
+	case r.TimeEntry != nil:
+		end := "running"
+		if r.TimeEntry.End != nil {
+			end = *r.TimeEntry.End
+		}
+		fmt.Fprintf(&sb, "Time session %s: %s %s, %s to %s%s\n", r.TimeEntry.ID, r.TimeEntry.ItemType, r.TimeEntry.ItemID,
+			r.TimeEntry.Start, end, itemDetails(labeled("text", r.TimeEntry.Text), fmt.Sprintf("%d min", r.TimeEntry.Minutes)))
+	}
+	for _, c := range comments {
+		fmt.Fprintf(&sb, "  > %s %s\n", c.At, c.Text)
+	}
+	location := r.File
+	if r.Section != "" {
+		location += ", section " + r.Section
+	}
+	if r.DeletedAt != "" {
+		location += ", deleted " + r.DeletedAt
+	}
+	fmt.Fprintf(&sb, "In %s, source_sha %s", location, r.SourceSHA)
+	return sb.String()
+}
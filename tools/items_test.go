@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestGetItem(t *testing.T) {
+	ctx := context.Background()
+	files := newFileStorage(map[string]string{
+		storage.TodosFile:                "# Active Todos\n\n## High Priority\n- [ ] Ship it {id:aaaa1111}\n\n# Completed\n- [x] Pay rent {id:aaaa2222,completed:2026-02-09}\n",
+		storage.StrategyFile:             "## Active Milestones\n- [ ] Launch {id:bbbb1111}\n",
+		storage.RemindersFile:            "# Reminders\n\n## Upcoming\n- 2026-02-08: Renew passport {id:cccc1111}\n",
+		storage.ReadingListFile:          "# Reading List\n\n## To Read\n- [ ] https://example.com/a {id:dddd1111}\n",
+		storage.NotesFile:                "# Notes\n\n## Ideas\n- Dark mode {id:eeee1111}\n",
+		storage.JournalFile:              "# Journal\n\n## 2026-02-09\n- 18:30 Shipped the beta {id:ffff1111}\n",
+		storage.TimeLogFile:              "# Time Log\n\n- 2026-02-10 08:00 - running todo:aaaa1111 Ship it {id:9999aaaa}\n",
+		storage.TrashFile:                "# Trash\n\n## Notes\n- Old idea {id:eeee2222,deleted:2026-02-09T10:00:00Z}\n",
+		storage.ReadingArchivePath(2025): "# Reading Archive 2025\n\n## Read\n- [x] https://example.com/old {id:dddd2222,read:2025-06-01}\n",
+	})
+	items := NewItemTools(files, clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)))
+
+	for _, tc := range []struct {
+		id, typ, file, section string
+	}{
+		{"aaaa1111", "todo", storage.TodosFile, "High Priority"},
+		{"aaaa2222", "todo", storage.TodosFile, "Completed"},
+		{"bbbb1111", "milestone", storage.StrategyFile, "Active Milestones"},
+		{"cccc1111", "reminder", storage.RemindersFile, "Upcoming"},
+		{"dddd1111", "reading", storage.ReadingListFile, "To Read"},
+		{"dddd2222", "reading", storage.ReadingArchivePath(2025), "Read"},
+		{"eeee1111", "note", storage.NotesFile, "Ideas"},
+		{"eeee2222", "note", storage.TrashFile, "Notes"},
+		{"ffff1111", "journal", storage.JournalFile, "2026-02-09"},
+		{"9999aaaa", "time", storage.TimeLogFile, ""},
+	} {
+		_, out, err := items.getItem(ctx, nil, GetItemInput{ID: tc.id})
+		if err != nil || !out.Success {
+			t.Errorf("getItem(%s) = %+v, %v", tc.id, out, err)
+			continue
+		}
+		if r := out.Result; r.Type != tc.typ || r.File != tc.file || r.Section != tc.section {
+			t.Errorf("getItem(%s) = %s in %s/%s, want %s in %s/%s", tc.id, r.Type, r.File, r.Section, tc.typ, tc.file, tc.section)
+		}
+	}
+
+	_, out, _ := items.getItem(ctx, nil, GetItemInput{ID: "cccc1111"})
+	if out.Result.Reminder == nil || !out.Result.Reminder.Overdue || !strings.Contains(out.Message, "Renew passport (overdue") {
+		t.Errorf("unexpected reminder %+v", out)
+	}
+	_, out, _ = items.getItem(ctx, nil, GetItemInput{ID: "9999aaaa"})
+	if te := out.Result.TimeEntry; te == nil || !te.Running || te.Minutes != 60 {
+		t.Errorf("unexpected time entry %+v", out.Result.TimeEntry)
+	}
+	_, out, _ = items.getItem(ctx, nil, GetItemInput{ID: "eeee2222"})
+	if out.Result.DeletedAt != "2026-02-09T10:00:00Z" {
+		t.Errorf("unexpected trash item %+v", out.Result)
+	}
+	if _, out, _ := items.getItem(ctx, nil, GetItemInput{ID: "zzzz9999"}); out.Success {
+		t.Error("getItem found an unknown ID")
+	}
+}
@@ -0,0 +1,212 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// JournalTools provides tools for the daily journal.
+type JournalTools struct {
+	storage storage.Storage
+	clock   clock.Clock
+}
+
+// NewJournalTools creates a new JournalTools instance. A nil clock uses the system clock.
+func NewJournalTools(s storage.Storage, c clock.Clock) *JournalTools {
+	return &JournalTools{storage: s, clock: clock.Or(c)}
+}
+
+// AddJournalEntryInput is the input schema for the add_journal_entry tool.
+type AddJournalEntryInput struct {
+	Text string `json:"text" jsonschema:"The journal entry text. Line breaks are folded into a single line."`
+	Date string `json:"date,omitempty" jsonschema:"Date to file the entry under (YYYY-MM-DD) for backdating. Defaults to now."`
+
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// AddJournalEntryOutput is the output for the add_journal_entry tool.
+type AddJournalEntryOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// ID and Item are the added entry, set on success.
+	ID   string            `json:"id,omitempty"`
+	Item *JournalEntryItem `json:"item,omitempty"`
+}
+
+// ListJournalInput is the input schema for the list_journal tool.
+type ListJournalInput struct {
+	DateFrom string `json:"date_from,omitempty" jsonschema:"Only entries on or after this date (YYYY-MM-DD). Defaults to 7 days ago."`
+	DateTo   string `json:"date_to,omitempty" jsonschema:"Only entries on or before this date (YYYY-MM-DD). Defaults to today."`
+}
+
+// ListJournalOutput is the output for the list_journal tool.
+type ListJournalOutput struct {
+	Success bool               `json:"success"`
+	Message string             `json:"message"`
+	Result  *ListJournalResult `json:"result,omitempty"`
+}
+
+// ListJournalResult is the response payload for list_journal.
+type ListJournalResult struct {
+	Entries      []JournalEntryItem `json:"entries"`
+	TotalEntries int                `json:"total_entries"`
+	SourceSHA    string             `json:"source_sha"`
+}
+
+// Register registers journal tools with the MCP server.
+func (j *JournalTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "add_journal_entry",
+		Description: "Add a timestamped entry to the daily journal for reflections, progress notes, or how the day went",
+	}, j.addJournalEntry)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_journal",
+		Description: "List journal entries within a date range (defaults to the last 7 days)",
+	}, j.listJournal)
+}
+
+// readJournal loads journal.md, treating a missing file as an empty journal.
+func (j *JournalTools) readJournal(ctx context.Context) (*storage.Journal, string, error) {
+	content, sha, err := j.storage.ReadFile(ctx, storage.JournalFile)
+	if errors.Is(err, storage.ErrNotFound) {
+		return &storage.Journal{}, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("reading journal.md: %w", err)
+	}
+
+	journal, err := parseJournal(ctx, content)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing journal: %w", err)
+	}
+	return journal, sha, nil
+}
+
+func (j *JournalTools) addJournalEntry(ctx context.Context, req *mcp.CallToolRequest, input AddJournalEntryInput) (*mcp.CallToolResult, AddJournalEntryOutput, error) {
+	text := strings.Join(strings.Fields(input.Text), " ")
+	if text == "" {
+		return nil, AddJournalEntryOutput{
+			Success: false,
+			Message: "Journal entry text cannot be empty",
+		}, nil
+	}
+
+	// Entries are written in the user's local time
+	now := j.clock.Now().Truncate(time.Minute)
+	if strings.TrimSpace(input.Date) != "" {
+		date, err := parseDate(input.Date, j.clock.Now())
+		if err != nil {
+			return nil, AddJournalEntryOutput{
+				Success: false,
+				Message: fmt.Sprintf("Invalid date format %q. Use YYYY-MM-DD format.", input.Date),
+			}, nil
+		}
+		// Keep the current time of day on the backdated entry
+		now = time.Date(date.Year(), date.Month(), date.Day(), now.Hour(), now.Minute(), 0, 0, now.Location())
+	}
+
+	journal, sha, err := j.readJournal(ctx)
+	if err != nil {
+		return nil, AddJournalEntryOutput{}, err
+	}
+	if msg := entitystore.CheckUnchanged(storage.JournalFile, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, AddJournalEntryOutput{Success: false, Message: msg}, nil
+	}
+
+	entry := storage.JournalEntry{
+		ID:   storage.NewID(storage.PrefixJournal, takenIn(func(e *storage.JournalEntry) string { return e.ID }, journal.Entries)),
+		Time: now,
+		Text: text,
+	}
+	journal.Entries = append(journal.Entries, entry)
+
+	newContent := storage.SerializeJournal(journal)
+	if err := j.storage.WriteFile(ctx, storage.JournalFile, newContent, sha, fmt.Sprintf("Journal: %s", truncate(text, 50))); err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, AddJournalEntryOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, AddJournalEntryOutput{}, fmt.Errorf("writing journal.md: %w", err)
+	}
+
+	item := journalEntryToItem(entry)
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		return nil, AddJournalEntryOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, AddJournalEntryOutput{
+		Success: true,
+		Message: string(itemJSON),
+		ID:      item.ID,
+		Item:    &item,
+	}, nil
+}
+
+func (j *JournalTools) listJournal(ctx context.Context, req *mcp.CallToolRequest, input ListJournalInput) (*mcp.CallToolResult, ListJournalOutput, error) {
+	today := clock.Today(j.clock)
+
+	dateFrom := today.AddDate(0, 0, -6)
+	if input.DateFrom != "" {
+		d, err := parseDate(input.DateFrom, j.clock.Now())
+		if err != nil {
+			return nil, ListJournalOutput{
+				Success: false,
+				Message: fmt.Sprintf("Invalid date_from format %q. Use YYYY-MM-DD.", input.DateFrom),
+			}, nil
+		}
+		dateFrom = d
+	}
+
+	dateTo := today
+	if input.DateTo != "" {
+		d, err := parseDate(input.DateTo, j.clock.Now())
+		if err != nil {
+			return nil, ListJournalOutput{
+				Success: false,
+				Message: fmt.Sprintf("Invalid date_to format %q. Use YYYY-MM-DD.", input.DateTo),
+			}, nil
+		}
+		dateTo = d
+	}
+
+	journal, sha, err := j.readJournal(ctx)
+	if err != nil {
+		return nil, ListJournalOutput{}, err
+	}
+
+	// date_to is inclusive of the whole day
+	end := dateTo.AddDate(0, 0, 1)
+	entries := []JournalEntryItem{}
+	for _, e := range journal.Entries {
+		if e.Time.Before(dateFrom) || !e.Time.Before(end) {
+			continue
+		}
+		entries = append(entries, journalEntryToItem(e))
+	}
+
+	result := ListJournalResult{
+		Entries:      entries,
+		TotalEntries: len(journal.Entries),
+		SourceSHA:    sha,
+	}
+
+	text := result.text()
+	return textResult(text), ListJournalOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UpdateKeyResultInput is the input schema for the update_key_result tool.
+type UpdateKeyResultInput struct {
+	MilestoneID    string   `json:"milestone_id" jsonschema:"ID of the milestone the key result belongs to. Use get_milestones to find IDs."`
+	ID             string   `json:"id,omitempty" jsonschema:"ID of the key result to update or remove. Omit to add a new key result to the milestone."`
+	Text           string   `json:"text,omitempty" jsonschema:"What is measured, e.g. Beta users. Required when adding; renames the key result when updating."`
+	Current        *float64 `json:"current,omitempty" jsonschema:"Current value. Defaults to 0 when adding."`
+	Target         *float64 `json:"target,omitempty" jsonschema:"Target value, greater than 0. Required when adding."`
+	Unit           *string  `json:"unit,omitempty" jsonschema:"Optional unit, e.g. users or %. Pass an empty string to clear it."`
+	Remove         bool     `json:"remove,omitempty" jsonschema:"Set to true to remove the key result with the given id"`
+	IfUnchangedSHA string   `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier get_milestones call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// UpdateKeyResultOutput is the output for the update_key_result tool.
+type UpdateKeyResultOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// ID is the key result's, and Item its milestone, set on success.
+	ID   string         `json:"id,omitempty"`
+	Item *MilestoneItem `json:"item,omitempty"`
+}
+
+func (t *StrategyTools) updateKeyResult(ctx context.Context, req *mcp.CallToolRequest, input UpdateKeyResultInput) (*mcp.CallToolResult, UpdateKeyResultOutput, error) {
+	if strings.TrimSpace(input.MilestoneID) == "" {
+		return nil, UpdateKeyResultOutput{Success: false, Message: "milestone_id is required"}, nil
+	}
+	id := strings.TrimSpace(input.ID)
+	text := strings.TrimSpace(input.Text)
+	switch {
+	case input.Remove && id == "":
+		return nil, UpdateKeyResultOutput{Success: false, Message: "id is required to remove a key result"}, nil
+	case id == "" && (text == "" || input.Target == nil):
+		return nil, UpdateKeyResultOutput{Success: false, Message: "text and target are required to add a key result"}, nil
+	case id != "" && !input.Remove && text == "" && input.Current == nil && input.Target == nil && input.Unit == nil:
+		return nil, UpdateKeyResultOutput{Success: false, Message: "At least one of text, current, target or unit must be provided"}, nil
+	case input.Target != nil && *input.Target <= 0:
+		return nil, UpdateKeyResultOutput{Success: false, Message: "target must be greater than 0"}, nil
+	case strings.ContainsAny(text, "{}\n") || input.Unit != nil && strings.ContainsAny(*input.Unit, "{}\n"):
+		return nil, UpdateKeyResultOutput{Success: false, Message: "text and unit cannot contain braces or line breaks"}, nil
+	}
+
+	s, sha, err := t.milestones.Load(ctx, input.IfUnchangedSHA)
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, UpdateKeyResultOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, UpdateKeyResultOutput{}, err
+	}
+	list, i, err := t.milestones.Find(s, entitystore.Both, entitystore.Ref{ID: input.MilestoneID})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, UpdateKeyResultOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, UpdateKeyResultOutput{}, err
+	}
+	m := &(*list)[i]
+
+	var k storage.KeyResult
+	var message string
+	if id == "" {
+		k = storage.KeyResult{
+			ID:   storage.NewID(storage.PrefixKeyResult, takenKeyResultIDs(s)),
+			Text: text,
+		}
+		applyKeyResultInput(&k, input)
+		m.KeyResults = append(m.KeyResults, k)
+		message = fmt.Sprintf("Add key result to %s: %s", truncate(m.Text, 30), truncate(k.Text, 40))
+	} else {
+		j := -1
+		for n := range m.KeyResults {
+			if m.KeyResults[n].ID == id {
+				j = n
+				break
+			}
+		}
+		if j < 0 {
+			return nil, UpdateKeyResultOutput{
+				Success: false,
+				Message: fmt.Sprintf("No key result found with id %q under %q", id, m.Text),
+			}, nil
+		}
+		k = m.KeyResults[j]
+		if input.Remove {
+			m.KeyResults = append(m.KeyResults[:j], m.KeyResults[j+1:]...)
+			message = fmt.Sprintf("Remove key result from %s: %s", truncate(m.Text, 30), truncate(k.Text, 40))
+		} else {
+			if text != "" {
+				k.Text = text
+			}
+			applyKeyResultInput(&k, input)
+			m.KeyResults[j] = k
+			message = fmt.Sprintf("Update key result of %s: %s", truncate(m.Text, 30), truncate(k.Text, 40))
+		}
+	}
+
+	err = t.milestones.Save(ctx, s, sha, message)
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, UpdateKeyResultOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, UpdateKeyResultOutput{}, err
+	}
+
+	item := milestoneToItem(*m)
+	var result string
+	switch {
+	case input.Remove:
+		result = fmt.Sprintf("Removed key result %q from %q", k.Text, m.Text)
+	case id == "":
+		result = fmt.Sprintf("Added key result %q to %q", k.Text, m.Text)
+	default:
+		result = fmt.Sprintf("Updated key result %q of %q", k.Text, m.Text)
+	}
+	if !input.Remove {
+		result += fmt.Sprintf(": %s (%d%%)", keyResultValue(k.Current, k.Target, k.Unit), k.Progress())
+	}
+	if item.Progress != nil {
+		result += fmt.Sprintf(". The milestone is at %d%% of its key results.", *item.Progress)
+	} else {
+		result += ". The milestone has no key results left."
+	}
+	return nil, UpdateKeyResultOutput{
+		Success: true,
+		Message: result,
+		ID:      k.ID,
+		Item:    &item,
+	}, nil
+}
+
+// applyKeyResultInput sets the values given in input.
+func applyKeyResultInput(k *storage.KeyResult, input UpdateKeyResultInput) {
+	if input.Current != nil {
+		k.Current = *input.Current
+	}
+	if input.Target != nil {
+		k.Target = *input.Target
+	}
+	if input.Unit != nil {
+		k.Unit = strings.TrimSpace(*input.Unit)
+	}
+}
+
+// takenKeyResultIDs reports the key result IDs in use across s, so new
+// ones stay unique within strategy.md.
+func takenKeyResultIDs(s *storage.Strategy) func(string) bool {
+	return func(id string) bool {
+		for _, list := range [][]storage.Milestone{s.ActiveMilestones, s.CompletedMilestones} {
+			for _, m := range list {
+				if takenIn(func(k *storage.KeyResult) string { return k.ID }, m.KeyResults)(id) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// keyResultValue formats a key result's values, e.g. "40/100 users".
+func keyResultValue(current, target float64, unit string) string {
+	value := storage.FormatAmount(current) + "/" + storage.FormatAmount(target)
+	if unit != "" {
+		value += " " + unit
+	}
+	return value
+}
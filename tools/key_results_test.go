@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestUpdateKeyResult(t *testing.T) {
+	ctx := context.Background()
+	files := newFileStorage(map[string]string{
+		storage.StrategyFile: "# My Plan\n\n## Current Phase\nLaunch\n\n## Active Milestones\n- [ ] Public beta {id:ms_aaaa11}\n  > 2026-02-10 09:30 Invites going out\n\n## Completed Milestones\n\n## Notes\n",
+	})
+	tools := NewStrategyTools(files, nil, nil)
+	target, current, unit := 100.0, 40.0, "users"
+
+	_, out, err := tools.updateKeyResult(ctx, nil, UpdateKeyResultInput{MilestoneID: "ms_aaaa11", Text: "Beta users", Current: &current, Target: &target, Unit: &unit})
+	if err != nil || !out.Success || storage.IDPrefix(out.ID) != storage.PrefixKeyResult {
+		t.Fatalf("updateKeyResult(add) = %+v, %v", out, err)
+	}
+	if !strings.Contains(fileContent(t, files, storage.StrategyFile), "- [ ] Public beta {id:ms_aaaa11}\n  - KR: Beta users: 40/100 users {id:"+out.ID+"}\n  > 2026-02-10") {
+		t.Fatalf("unexpected strategy.md:\n%s", fileContent(t, files, storage.StrategyFile))
+	}
+	id := out.ID
+
+	invites := 50.0
+	if _, out, _ := tools.updateKeyResult(ctx, nil, UpdateKeyResultInput{MilestoneID: "ms_aaaa11", Text: "Invites sent", Target: &invites}); !out.Success {
+		t.Fatalf("updateKeyResult(add) = %+v", out)
+	}
+
+	current = 70
+	_, out, err = tools.updateKeyResult(ctx, nil, UpdateKeyResultInput{MilestoneID: "ms_aaaa11", ID: id, Current: &current})
+	if err != nil || !out.Success {
+		t.Fatalf("updateKeyResult(update) = %+v, %v", out, err)
+	}
+	if out.Item.Progress == nil || *out.Item.Progress != 35 || out.Item.KeyResults[0].Progress != 70 || out.Item.KeyResults[0].Unit != "users" {
+		t.Errorf("unexpected milestone %+v", out.Item)
+	}
+
+	// get_milestones rolls up the progress
+	_, list, err := tools.getMilestones(ctx, nil, GetMilestonesInput{})
+	if err != nil || !strings.Contains(list.Message, "Public beta (id ms_aaaa11, 35% of key results)\n  - KR Beta users: 70/100 users, 70% (id "+id+")") {
+		t.Errorf("getMilestones() = %q, %v", list.Message, err)
+	}
+
+	for _, input := range []UpdateKeyResultInput{
+		{MilestoneID: "ms_aaaa11", Text: "No target"},
+		{MilestoneID: "ms_aaaa11", ID: id},
+		{MilestoneID: "ms_aaaa11", ID: "kr_ffffff", Current: &current},
+		{MilestoneID: "ms_aaaa11", ID: id, Target: new(float64)},
+		{MilestoneID: "ms_bbbb22", Text: "Elsewhere", Target: &target},
+	} {
+		if _, out, err := tools.updateKeyResult(ctx, nil, input); err != nil || out.Success {
+			t.Errorf("updateKeyResult(%+v) = %+v, %v, want a failure", input, out, err)
+		}
+	}
+
+	_, out, err = tools.updateKeyResult(ctx, nil, UpdateKeyResultInput{MilestoneID: "ms_aaaa11", ID: id, Remove: true})
+	if err != nil || !out.Success || len(out.Item.KeyResults) != 1 || strings.Contains(fileContent(t, files, storage.StrategyFile), id) {
+		t.Errorf("updateKeyResult(remove) = %+v, %v\n%s", out, err, fileContent(t, files, storage.StrategyFile))
+	}
+}
@@ -0,0 +1,298 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// itemTypePrefixes maps the item types time log and focus entries refer to
+// to their ID prefixes.
+var itemTypePrefixes = map[string]string{
+	"todo":      storage.PrefixTodo,
+	"milestone": storage.PrefixMilestone,
+}
+
+// prefixTypes names the item type of each ID prefix.
+var prefixTypes = map[string]string{
+	storage.PrefixTodo:      "todo",
+	storage.PrefixSubtask:   "subtask",
+	storage.PrefixMilestone: "milestone",
+	storage.PrefixReminder:  "reminder",
+	storage.PrefixReading:   "reading",
+	storage.PrefixNote:      "note",
+	storage.PrefixJournal:   "journal",
+	storage.PrefixTime:      "time",
+	storage.PrefixKeyResult: "key result",
+}
+
+// IDTools migrates item IDs to the prefixed scheme (see storage.NewID).
+type IDTools struct {
+	storage storage.Storage
+	clock   clock.Clock
+}
+
+// NewIDTools creates a new IDTools instance. A nil clock uses the system clock.
+func NewIDTools(s storage.Storage, c clock.Clock) *IDTools {
+	return &IDTools{storage: s, clock: clock.Or(c)}
+}
+
+// MigrateIDsInput is the input schema for the migrate_ids tool.
+type MigrateIDsInput struct {
+	DryRun bool `json:"dry_run,omitempty" jsonschema:"Set to true to list the new IDs without rewriting any files"`
+}
+
+// MigrateIDsOutput is the output for the migrate_ids tool.
+type MigrateIDsOutput struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message"`
+	Result  *MigrateIDsResult `json:"result,omitempty"`
+}
+
+// MigrateIDsResult is the response payload for migrate_ids.
+type MigrateIDsResult struct {
+	Migrated int `json:"migrated"`
+	// Files are the files rewritten, or that would be on a dry run.
+	Files   []string   `json:"files"`
+	Changes []IDChange `json:"changes"`
+	DryRun  bool       `json:"dry_run,omitempty"`
+}
+
+// IDChange is one legacy ID and its replacement.
+type IDChange struct {
+	Type string `json:"type"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// Register registers the migrate_ids tool with the MCP server.
+func (t *IDTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "migrate_ids",
+		Description: "Rewrite legacy 8-character item IDs as type-prefixed ones (td_ for todos, ms_ for milestones, rm_ for reminders, ...), updating every reference to them across files in one commit. Items without an ID get one too.",
+	}, t.migrateIDs)
+}
+
+// migratedFile is a data file parsed for migration.
+type migratedFile struct {
+	path, content, sha string
+	parse              func(ctx context.Context, content string) (*validatedFile, error)
+	parsed             *validatedFile
+	changed            bool
+}
+
+func (t *IDTools) migrateIDs(ctx context.Context, req *mcp.CallToolRequest, input MigrateIDsInput) (*mcp.CallToolResult, MigrateIDsOutput, error) {
+	files, err := t.readFiles(ctx)
+	if err != nil {
+		return nil, MigrateIDsOutput{}, err
+	}
+
+	// Every ID in use, so the new ones don't collide with any
+	taken := make(map[string]bool)
+	for _, f := range files {
+		for _, item := range f.parsed.items {
+			taken[*item.id] = true
+		}
+	}
+	isTaken := func(id string) bool { return taken[id] }
+
+	// An ID used twice within a type (e.g. by a todo and its copy in the
+	// trash) maps to the same new ID, keeping the two alike
+	result := MigrateIDsResult{Files: []string{}, Changes: []IDChange{}, DryRun: input.DryRun}
+	renamed := make(map[string]map[string]string)
+	for _, f := range files {
+		for _, item := range f.parsed.items {
+			if storage.IDPrefix(*item.id) != "" {
+				// Parsers give items without an ID a prefixed one
+				f.changed = f.changed || !strings.Contains(f.content, "id:"+*item.id)
+				continue
+			}
+			if renamed[item.prefix] == nil {
+				renamed[item.prefix] = make(map[string]string)
+			}
+			newID, ok := renamed[item.prefix][*item.id]
+			if !ok {
+				newID = storage.NewID(item.prefix, isTaken)
+				taken[newID] = true
+				renamed[item.prefix][*item.id] = newID
+				result.Changes = append(result.Changes, IDChange{Type: prefixTypes[item.prefix], Old: *item.id, New: newID})
+			}
+			*item.id = newID
+			f.changed = true
+		}
+	}
+	for _, f := range files {
+		for _, ref := range f.parsed.refs {
+			if newID, ok := renamed[ref.prefix][*ref.id]; ok {
+				*ref.id = newID
+				f.changed = true
+			}
+		}
+	}
+	result.Migrated = len(result.Changes)
+
+	var changes []storage.FileChange
+	for _, f := range files {
+		if !f.changed {
+			continue
+		}
+		content := f.parsed.serialize()
+		// Refuse rather than drop items from a file that doesn't round-trip
+		reparsed, err := f.parse(ctx, content)
+		if err != nil {
+			return nil, MigrateIDsOutput{}, fmt.Errorf("re-parsing %s: %w", f.path, err)
+		}
+		if len(reparsed.items) != len(f.parsed.items) {
+			return nil, MigrateIDsOutput{
+				Success: false,
+				Message: fmt.Sprintf("%s would lose items when rewritten. Run validate_data to find them, then retry.", f.path),
+			}, nil
+		}
+		changes = append(changes, storage.FileChange{Path: f.path, Content: content, SHA: f.sha})
+		result.Files = append(result.Files, f.path)
+	}
+
+	if !input.DryRun && len(changes) > 0 {
+		err := storage.WriteFiles(ctx, t.storage, changes, fmt.Sprintf("Migrate %s to prefixed IDs", plural(result.Migrated, "ID")))
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, MigrateIDsOutput{Success: false, Message: msg}, nil
+		}
+		if err != nil {
+			return nil, MigrateIDsOutput{}, fmt.Errorf("writing migrated files: %w", err)
+		}
+	}
+
+	text := result.text()
+	return textResult(text), MigrateIDsOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+// readFiles reads and parses every file holding item IDs or references to
+// them, skipping missing ones.
+func (t *IDTools) readFiles(ctx context.Context) ([]*migratedFile, error) {
+	var files []*migratedFile
+	for _, v := range validators {
+		files = append(files, &migratedFile{path: v.path, parse: v.parse})
+	}
+	files = append(files,
+		&migratedFile{path: storage.TrashFile, parse: parseTrashIDs},
+		&migratedFile{path: storage.FocusFile, parse: parseFocusIDs},
+	)
+	today := clock.Today(t.clock)
+	for year := today.Year(); year > today.Year()-readingArchiveYears; year-- {
+		files = append(files, &migratedFile{path: storage.ReadingArchivePath(year), parse: parseReadingArchiveIDs(year)})
+	}
+
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.path
+	}
+	read := storage.ReadFiles(ctx, t.storage, paths...)
+	var found []*migratedFile
+	for _, f := range files {
+		r := read[f.path]
+		if errors.Is(r.Err, storage.ErrNotFound) {
+			continue
+		}
+		if r.Err != nil {
+			return nil, fmt.Errorf("reading %s: %w", f.path, r.Err)
+		}
+		parsed, err := f.parse(ctx, r.Content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", f.path, err)
+		}
+		f.content, f.sha, f.parsed = r.Content, r.SHA, parsed
+		found = append(found, f)
+	}
+	return found, nil
+}
+
+func parseTrashIDs(ctx context.Context, content string) (*validatedFile, error) {
+	trash, err := storage.ParseTrash(content)
+	if err != nil {
+		return nil, err
+	}
+	v := &validatedFile{serialize: func() string { return storage.SerializeTrash(trash) }}
+	for _, item := range trash.Items {
+		switch {
+		case item.Todo != nil:
+			v.items = append(v.items, validatedItem{&item.Todo.ID, item.Todo.Text, storage.PrefixTodo})
+			for j := range item.Todo.Subtasks {
+				v.items = append(v.items, validatedItem{&item.Todo.Subtasks[j].ID, item.Todo.Subtasks[j].Text, storage.PrefixSubtask})
+			}
+			if item.Todo.Milestone != "" {
+				v.refs = append(v.refs, idRef{&item.Todo.Milestone, storage.PrefixMilestone})
+			}
+		case item.Reminder != nil:
+			v.items = append(v.items, validatedItem{&item.Reminder.ID, item.Reminder.Text, storage.PrefixReminder})
+		case item.Note != nil:
+			v.items = append(v.items, validatedItem{&item.Note.ID, item.Note.Text, storage.PrefixNote})
+		}
+	}
+	return v, nil
+}
+
+func parseFocusIDs(ctx context.Context, content string) (*validatedFile, error) {
+	f, err := storage.ParseFocus(content)
+	if err != nil {
+		return nil, err
+	}
+	v := &validatedFile{serialize: func() string { return storage.SerializeFocus(f) }}
+	for i := range f.Items {
+		v.refs = append(v.refs, idRef{&f.Items[i].ItemID, itemTypePrefixes[f.Items[i].ItemType]})
+	}
+	return v, nil
+}
+
+func parseReadingArchiveIDs(year int) func(context.Context, string) (*validatedFile, error) {
+	return func(ctx context.Context, content string) (*validatedFile, error) {
+		rl, err := storage.ParseReadingList(content)
+		if err != nil {
+			return nil, err
+		}
+		v := &validatedFile{serialize: func() string { return storage.SerializeReadingArchive(year, rl.Read) }}
+		for i := range rl.Read {
+			v.items = append(v.items, validatedItem{&rl.Read[i].ID, rl.Read[i].URL, storage.PrefixReading})
+		}
+		return v, nil
+	}
+}
+
+func (r MigrateIDsResult) text() string {
+	var sb strings.Builder
+	verb := "Migrated"
+	if r.DryRun {
+		verb = "Would migrate"
+	}
+	if r.Migrated == 0 && len(r.Files) == 0 {
+		return "All IDs are already prefixed"
+	}
+	fmt.Fprintf(&sb, "%s %s in %s", verb, plural(r.Migrated, "legacy ID"), strings.Join(r.Files, ", "))
+
+	counts := make(map[string]int)
+	for _, c := range r.Changes {
+		counts[c.Type]++
+	}
+	var types []string
+	for typ, n := range counts {
+		types = append(types, plural(n, typ))
+	}
+	sort.Strings(types)
+	if len(types) > 0 {
+		fmt.Fprintf(&sb, " (%s)", strings.Join(types, ", "))
+	}
+	for _, c := range r.Changes {
+		fmt.Fprintf(&sb, "\n- %s %s -> %s", c.Type, c.Old, c.New)
+	}
+	return sb.String()
+}
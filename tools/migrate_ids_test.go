@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestMigrateIDs(t *testing.T) {
+	ctx := context.Background()
+	files := newFileStorage(map[string]string{
+		storage.TodosFile:    "# Active Todos\n\n## High Priority\n- [ ] Ship {id:aaaa1111,added:2026-02-01,milestone:bbbb2222}\n  - [ ] Write tests {id:eeee5555}\n\n# Completed\n",
+		storage.StrategyFile: "# My Plan\n\n## Current Phase\nLaunch\n\n## Active Milestones\n- [ ] Beta — Due: soon {id:bbbb2222}\n\n## Notes\n",
+		storage.TimeLogFile:  "# Time Log\n\n- 2026-02-01 09:00 - 2026-02-01 10:00 todo:aaaa1111 Ship {id:cccc3333}\n",
+		storage.FocusFile:    "# Focus\n\n## 2026-02-10\n- todo:aaaa1111 Ship\n- milestone:bbbb2222 Beta\n",
+		storage.TrashFile:    "# Trash\n\n## Todos\n- [ ] Old {id:aaaa1111,milestone:bbbb2222,deleted:2026-02-10T09:00:00Z}\n",
+	})
+	tools := NewIDTools(files, clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)))
+
+	_, dry, err := tools.migrateIDs(ctx, nil, MigrateIDsInput{DryRun: true})
+	if err != nil || !dry.Success {
+		t.Fatalf("migrateIDs(dry run) = %+v, %v", dry, err)
+	}
+	if dry.Result.Migrated != 4 || !strings.Contains(fileContent(t, files, storage.TodosFile), "id:aaaa1111") {
+		t.Fatalf("unexpected dry run %+v\n%s", dry.Result, fileContent(t, files, storage.TodosFile))
+	}
+
+	_, out, err := tools.migrateIDs(ctx, nil, MigrateIDsInput{})
+	if err != nil || !out.Success || out.Result.Migrated != 4 {
+		t.Fatalf("migrateIDs() = %+v, %v", out, err)
+	}
+	renamed := make(map[string]string)
+	for _, c := range out.Result.Changes {
+		if storage.IDPrefix(c.New) != map[string]string{"todo": "td", "subtask": "st", "milestone": "ms", "time": "tm"}[c.Type] {
+			t.Errorf("unexpected change %+v", c)
+		}
+		renamed[c.Old] = c.New
+	}
+	todo, milestone := renamed["aaaa1111"], renamed["bbbb2222"]
+
+	// IDs and references to them are rewritten alike in every file
+	tf, _ := storage.ParseTodos(fileContent(t, files, storage.TodosFile))
+	if tf.Active[0].ID != todo || tf.Active[0].Milestone != milestone || tf.Active[0].Subtasks[0].ID != renamed["eeee5555"] {
+		t.Errorf("unexpected todos:\n%s", fileContent(t, files, storage.TodosFile))
+	}
+	if !strings.Contains(fileContent(t, files, storage.StrategyFile), "{id:"+milestone+"}") {
+		t.Errorf("unexpected strategy:\n%s", fileContent(t, files, storage.StrategyFile))
+	}
+	if !strings.Contains(fileContent(t, files, storage.TimeLogFile), "todo:"+todo+" Ship {id:"+renamed["cccc3333"]+"}") {
+		t.Errorf("unexpected time log:\n%s", fileContent(t, files, storage.TimeLogFile))
+	}
+	if !strings.Contains(fileContent(t, files, storage.FocusFile), "todo:"+todo) || !strings.Contains(fileContent(t, files, storage.FocusFile), "milestone:"+milestone) {
+		t.Errorf("unexpected focus:\n%s", fileContent(t, files, storage.FocusFile))
+	}
+	if !strings.Contains(fileContent(t, files, storage.TrashFile), "id:"+todo) || !strings.Contains(fileContent(t, files, storage.TrashFile), "milestone:"+milestone) {
+		t.Errorf("unexpected trash:\n%s", fileContent(t, files, storage.TrashFile))
+	}
+
+	// A second run has nothing to do
+	if _, again, _ := tools.migrateIDs(ctx, nil, MigrateIDsInput{}); again.Result.Migrated != 0 || len(again.Result.Files) != 0 {
+		t.Errorf("second migrateIDs() = %+v", again.Result)
+	}
+}
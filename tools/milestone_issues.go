@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MilestoneIssues mirrors milestones as issues in a GitHub repo so they show
+// up on project boards. The issue number is kept in the milestone's metadata
+// ({issue:N}), which makes syncing idempotent: a milestone with a number is
+// only ever updated, never re-created.
+type MilestoneIssues interface {
+	// CreateMilestoneIssue opens an issue for m and returns its number.
+	CreateMilestoneIssue(ctx context.Context, m storage.Milestone) (int, error)
+	// UpdateMilestoneIssue sets the title, body (due date) and open/closed
+	// state of m's issue to match m.
+	UpdateMilestoneIssue(ctx context.Context, m storage.Milestone) error
+}
+
+// SyncMilestoneIssuesInput is the input schema for the sync_milestone_issues tool.
+type SyncMilestoneIssuesInput struct {
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// SyncMilestoneIssuesOutput is the output for the sync_milestone_issues tool.
+type SyncMilestoneIssuesOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// SyncMilestoneIssuesResult is the response payload for sync_milestone_issues.
+type SyncMilestoneIssuesResult struct {
+	Created []MilestoneItem `json:"created"`
+	Updated int             `json:"updated"`
+	Errors  []string        `json:"errors,omitempty"`
+}
+
+// createMilestoneIssues opens issues for active milestones that don't have
+// one yet, recording the numbers on s so the caller's write persists them.
+// Failures are returned as messages rather than aborting the caller's write;
+// those milestones are picked up by the next sync.
+func (t *StrategyTools) createMilestoneIssues(ctx context.Context, s *storage.Strategy) (created []storage.Milestone, errs []string) {
+	if t.issues == nil {
+		return nil, nil
+	}
+	for i, m := range s.ActiveMilestones {
+		if m.Issue > 0 {
+			continue
+		}
+		number, err := t.issues.CreateMilestoneIssue(ctx, m)
+		if err != nil {
+			slog.Warn("creating milestone issue failed", "milestone", m.ID, "error", err)
+			errs = append(errs, fmt.Sprintf("%s: %v", m.Text, err))
+			continue
+		}
+		s.ActiveMilestones[i].Issue = number
+		created = append(created, s.ActiveMilestones[i])
+	}
+	return created, errs
+}
+
+// updateMilestoneIssue pushes m's current state to its issue, if it has one.
+// It runs after the strategy write, so a failure is logged and left for the
+// next sync rather than failing the tool call.
+func (t *StrategyTools) updateMilestoneIssue(ctx context.Context, m storage.Milestone) {
+	if t.issues == nil || m.Issue == 0 {
+		return
+	}
+	if err := t.issues.UpdateMilestoneIssue(ctx, m); err != nil {
+		slog.Warn("updating milestone issue failed", "milestone", m.ID, "issue", m.Issue, "error", err)
+	}
+}
+
+// withIssueNumbers copies issue numbers from s onto items by milestone ID.
+func withIssueNumbers(items []MilestoneItem, s *storage.Strategy) []MilestoneItem {
+	issues := make(map[string]int)
+	for _, m := range s.ActiveMilestones {
+		issues[m.ID] = m.Issue
+	}
+	for i := range items {
+		items[i].Issue = issues[items[i].ID]
+	}
+	return items
+}
+
+func (t *StrategyTools) syncMilestoneIssues(ctx context.Context, req *mcp.CallToolRequest, input SyncMilestoneIssuesInput) (*mcp.CallToolResult, SyncMilestoneIssuesOutput, error) {
+	content, sha, err := t.storage.ReadFile(ctx, storage.StrategyFile)
+	if err != nil {
+		return nil, SyncMilestoneIssuesOutput{}, fmt.Errorf("reading strategy.md: %w", err)
+	}
+
+	if msg := entitystore.CheckUnchanged(storage.StrategyFile, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, SyncMilestoneIssuesOutput{Success: false, Message: msg}, nil
+	}
+
+	s, err := parseStrategy(ctx, content)
+	if err != nil {
+		return nil, SyncMilestoneIssuesOutput{}, fmt.Errorf("parsing strategy: %w", err)
+	}
+
+	result := SyncMilestoneIssuesResult{Created: []MilestoneItem{}}
+
+	// Bring existing issues in line first (title, due, closed state), so a
+	// newly created issue isn't immediately updated again
+	for _, list := range [][]storage.Milestone{s.ActiveMilestones, s.CompletedMilestones} {
+		for _, m := range list {
+			if m.Issue == 0 {
+				continue
+			}
+			if err := t.issues.UpdateMilestoneIssue(ctx, m); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s (#%d): %v", m.Text, m.Issue, err))
+				continue
+			}
+			result.Updated++
+		}
+	}
+
+	created, errs := t.createMilestoneIssues(ctx, s)
+	result.Errors = append(result.Errors, errs...)
+	for _, m := range created {
+		result.Created = append(result.Created, milestoneToItem(m))
+	}
+
+	if len(created) > 0 {
+		newContent := storage.SerializeStrategy(s)
+		if err := t.storage.WriteFile(ctx, storage.StrategyFile, newContent, sha, fmt.Sprintf("Link %d milestones to GitHub issues", len(created))); err != nil {
+			if msg, ok := entitystore.WriteMessage(err); ok {
+				return nil, SyncMilestoneIssuesOutput{
+					Success: false,
+					Message: msg,
+				}, nil
+			}
+			return nil, SyncMilestoneIssuesOutput{}, fmt.Errorf("writing strategy.md: %w", err)
+		}
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, SyncMilestoneIssuesOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, SyncMilestoneIssuesOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// fakeIssues numbers issues sequentially and fails for milestones named "fail".
+type fakeIssues struct {
+	next    int
+	updated []int
+}
+
+func (f *fakeIssues) CreateMilestoneIssue(ctx context.Context, m storage.Milestone) (int, error) {
+	if m.Text == "fail" {
+		return 0, errors.New("boom")
+	}
+	f.next++
+	return f.next, nil
+}
+
+func (f *fakeIssues) UpdateMilestoneIssue(ctx context.Context, m storage.Milestone) error {
+	f.updated = append(f.updated, m.Issue)
+	return nil
+}
+
+func TestCreateMilestoneIssues(t *testing.T) {
+	fi := &fakeIssues{next: 10}
+	st := NewStrategyTools(nil, fi, nil)
+	s := &storage.Strategy{
+		ActiveMilestones: []storage.Milestone{
+			{ID: "a", Text: "linked", Issue: 3},
+			{ID: "b", Text: "new"},
+			{ID: "c", Text: "fail"},
+		},
+	}
+
+	created, errs := st.createMilestoneIssues(context.Background(), s)
+	if len(created) != 1 || created[0].ID != "b" || s.ActiveMilestones[1].Issue != 11 {
+		t.Errorf("expected only b to get issue 11, got %+v", s.ActiveMilestones)
+	}
+	if s.ActiveMilestones[0].Issue != 3 {
+		t.Errorf("linked milestone was re-created: %+v", s.ActiveMilestones[0])
+	}
+	if len(errs) != 1 || s.ActiveMilestones[2].Issue != 0 {
+		t.Errorf("expected one error for c, got %v", errs)
+	}
+
+	items := withIssueNumbers([]MilestoneItem{{ID: "b"}, {ID: "c"}}, s)
+	if items[0].Issue != 11 || items[1].Issue != 0 {
+		t.Errorf("withIssueNumbers() = %+v", items)
+	}
+
+	// Running again creates nothing new for b
+	created, _ = st.createMilestoneIssues(context.Background(), s)
+	if len(created) != 0 {
+		t.Errorf("expected no new issues on re-run, got %+v", created)
+	}
+}
+
+func TestCreateMilestoneIssues_Disabled(t *testing.T) {
+	st := NewStrategyTools(nil, nil, nil)
+	s := &storage.Strategy{ActiveMilestones: []storage.Milestone{{ID: "a", Text: "new"}}}
+	if created, errs := st.createMilestoneIssues(context.Background(), s); created != nil || errs != nil {
+		t.Errorf("expected no-op without issue sync, got %v %v", created, errs)
+	}
+}
@@ -0,0 +1,391 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Note sources reported in NoteItem.Source. New notes go to notes.md; notes
+// written to strategy.md's Notes section before notes.md existed are still
+// listed and can be deleted.
+const (
+	noteSourceNotes    = "notes"
+	noteSourceStrategy = "strategy"
+)
+
+// NoteTools provides MCP tools for notes.md.
+type NoteTools struct {
+	storage storage.Storage
+	clock   clock.Clock
+}
+
+// NewNoteTools creates a new NoteTools instance. A nil clock uses the system clock.
+func NewNoteTools(s storage.Storage, c clock.Clock) *NoteTools {
+	return &NoteTools{storage: s, clock: clock.Or(c)}
+}
+
+// AddNoteInput is the input schema for the add_note tool.
+type AddNoteInput struct {
+	Note           string `json:"note" jsonschema:"The note text"`
+	Category       string `json:"category,omitempty" jsonschema:"Category heading to file the note under, e.g. Ideas. Created if new. Uncategorized if omitted."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list_notes call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// AddNoteOutput is the output for the add_note tool.
+type AddNoteOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// ID and Item are the added note, set on success.
+	ID   string    `json:"id,omitempty"`
+	Item *NoteItem `json:"item,omitempty"`
+}
+
+// ListNotesInput is the input schema for the list_notes tool.
+type ListNotesInput struct {
+	Search   string `json:"search,omitempty" jsonschema:"Text to filter notes by. Case-insensitive partial match."`
+	Category string `json:"category,omitempty" jsonschema:"Only list notes in this category (case-insensitive). Use strategy for notes kept in strategy.md."`
+}
+
+// ListNotesOutput is the output for the list_notes tool.
+type ListNotesOutput struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Result  *ListNotesResult `json:"result,omitempty"`
+}
+
+// ListNotesResult is the response payload for list_notes.
+type ListNotesResult struct {
+	Notes      []NoteItem `json:"notes"`
+	Total      int        `json:"total"`
+	Categories []string   `json:"categories"`
+	// SourceSHA is the version of notes.md read.
+	SourceSHA string `json:"source_sha"`
+}
+
+// DeleteNoteInput is the input schema for the delete_note tool.
+type DeleteNoteInput struct {
+	ID             string `json:"id,omitempty" jsonschema:"ID of the note to delete. Use list_notes to find IDs."`
+	Text           string `json:"text,omitempty" jsonschema:"Text to match against note content instead of an id. The closest match is used when it is a clear winner; otherwise the candidates are returned."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list_notes call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// DeleteNoteOutput is the output for the delete_note tool.
+type DeleteNoteOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// Candidates are the notes the text matched, best first, when it
+	// matched several without a clear winner.
+	Candidates []entitystore.Candidate `json:"candidates,omitempty"`
+}
+
+// Register registers note tools with the MCP server.
+func (t *NoteTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "add_note",
+		Description: "Add a note to notes.md, optionally under a category such as Ideas or Meetings",
+	}, t.addNote)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_notes",
+		Description: "List notes with optional category and text filters. Includes older notes kept in strategy.md (source strategy).",
+	}, t.listNotes)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_note",
+		Description: "Delete a note by id, or by a text match that selects exactly one note. It stays in the trash for 30 days, where restore_item can bring it back.",
+	}, t.deleteNote)
+}
+
+// readNotes reads notes.md. A missing file reads as empty, so the first
+// add_note creates it.
+func (t *NoteTools) readNotes(ctx context.Context) (*storage.NoteFile, string, error) {
+	content, sha, err := t.storage.ReadFile(ctx, storage.NotesFile)
+	if errors.Is(err, storage.ErrNotFound) {
+		return &storage.NoteFile{}, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("reading notes.md: %w", err)
+	}
+	nf, err := parseNotes(ctx, content)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing notes: %w", err)
+	}
+	return nf, sha, nil
+}
+
+// readStrategyNotes reads the legacy notes in strategy.md. A missing file
+// has none.
+func (t *NoteTools) readStrategyNotes(ctx context.Context) (*storage.Strategy, string, error) {
+	content, sha, err := t.storage.ReadFile(ctx, storage.StrategyFile)
+	if errors.Is(err, storage.ErrNotFound) {
+		return &storage.Strategy{}, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("reading strategy.md: %w", err)
+	}
+	s, err := parseStrategy(ctx, content)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing strategy: %w", err)
+	}
+	return s, sha, nil
+}
+
+// matchCategory returns the existing spelling of category, or category
+// itself if it is new.
+func matchCategory(categories []string, category string) string {
+	for _, c := range categories {
+		if strings.EqualFold(c, category) {
+			return c
+		}
+	}
+	return category
+}
+
+func (t *NoteTools) addNote(ctx context.Context, req *mcp.CallToolRequest, input AddNoteInput) (*mcp.CallToolResult, AddNoteOutput, error) {
+	text := strings.TrimSpace(input.Note)
+	if text == "" {
+		return nil, AddNoteOutput{
+			Success: false,
+			Message: "Note text cannot be empty",
+		}, nil
+	}
+	if strings.Contains(text, "\n") {
+		return nil, AddNoteOutput{
+			Success: false,
+			Message: "Note text must be a single line",
+		}, nil
+	}
+
+	nf, sha, err := t.readNotes(ctx)
+	if err != nil {
+		return nil, AddNoteOutput{}, err
+	}
+
+	if msg := entitystore.CheckUnchanged(storage.NotesFile, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, AddNoteOutput{
+			Success: false,
+			Message: msg,
+		}, nil
+	}
+
+	note := storage.Note{
+		ID:       storage.NewID(storage.PrefixNote, takenIn(func(n *storage.Note) string { return n.ID }, nf.Notes)),
+		Text:     text,
+		Category: matchCategory(nf.Categories, strings.TrimSpace(input.Category)),
+		Added:    clock.Today(t.clock),
+	}
+	nf.Notes = append(nf.Notes, note)
+
+	newContent := storage.SerializeNotes(nf)
+	if err := t.storage.WriteFile(ctx, storage.NotesFile, newContent, sha, fmt.Sprintf("Add note: %s", truncate(text, 50))); err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, AddNoteOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, AddNoteOutput{}, fmt.Errorf("writing notes.md: %w", err)
+	}
+
+	item := noteToItem(note)
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		return nil, AddNoteOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, AddNoteOutput{
+		Success: true,
+		Message: string(itemJSON),
+		ID:      item.ID,
+		Item:    &item,
+	}, nil
+}
+
+func (t *NoteTools) listNotes(ctx context.Context, req *mcp.CallToolRequest, input ListNotesInput) (*mcp.CallToolResult, ListNotesOutput, error) {
+	nf, sha, err := t.readNotes(ctx)
+	if err != nil {
+		return nil, ListNotesOutput{}, err
+	}
+	s, _, err := t.readStrategyNotes(ctx)
+	if err != nil {
+		return nil, ListNotesOutput{}, err
+	}
+
+	all := make([]NoteItem, 0, len(nf.Notes)+len(s.Notes))
+	for _, n := range nf.Notes {
+		all = append(all, noteToItem(n))
+	}
+	for _, text := range s.Notes {
+		all = append(all, NoteItem{Text: text, Source: noteSourceStrategy})
+	}
+
+	category := strings.TrimSpace(input.Category)
+	search := strings.ToLower(strings.TrimSpace(input.Search))
+	notes := []NoteItem{}
+	for _, n := range all {
+		if category != "" {
+			if n.Source == noteSourceStrategy {
+				if !strings.EqualFold(category, noteSourceStrategy) {
+					continue
+				}
+			} else if !strings.EqualFold(n.Category, category) {
+				continue
+			}
+		}
+		if search != "" && !strings.Contains(strings.ToLower(n.Text), search) {
+			continue
+		}
+		notes = append(notes, n)
+	}
+
+	result := ListNotesResult{
+		SourceSHA:  sha,
+		Notes:      notes,
+		Total:      len(all),
+		Categories: append([]string{}, nf.Categories...),
+	}
+
+	text := result.text()
+	return textResult(text), ListNotesOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+func (t *NoteTools) deleteNote(ctx context.Context, req *mcp.CallToolRequest, input DeleteNoteInput) (*mcp.CallToolResult, DeleteNoteOutput, error) {
+	id := strings.TrimSpace(input.ID)
+	searchText := strings.TrimSpace(input.Text)
+	if id == "" && searchText == "" {
+		return nil, DeleteNoteOutput{
+			Success: false,
+			Message: "Either id or text must be provided",
+		}, nil
+	}
+
+	nf, notesSHA, err := t.readNotes(ctx)
+	if err != nil {
+		return nil, DeleteNoteOutput{}, err
+	}
+	s, strategySHA, err := t.readStrategyNotes(ctx)
+	if err != nil {
+		return nil, DeleteNoteOutput{}, err
+	}
+
+	// Look across both files; legacy notes have no IDs, so they are
+	// labelled by their source and only found by text
+	type match struct {
+		source string
+		idx    int
+		text   string
+	}
+	var m match
+	if id != "" {
+		found := false
+		for i, n := range nf.Notes {
+			if n.ID == id {
+				m, found = match{noteSourceNotes, i, n.Text}, true
+				break
+			}
+		}
+		if !found {
+			return nil, DeleteNoteOutput{
+				Success: false,
+				Message: fmt.Sprintf("No note found with id %q", id),
+			}, nil
+		}
+	} else {
+		var all []match
+		var ids, texts []string
+		for i, n := range nf.Notes {
+			all = append(all, match{noteSourceNotes, i, n.Text})
+			ids = append(ids, n.ID)
+			texts = append(texts, n.Text)
+		}
+		for i, text := range s.Notes {
+			all = append(all, match{noteSourceStrategy, i, text})
+			ids = append(ids, noteSourceStrategy)
+			texts = append(texts, text)
+		}
+
+		ranked := entitystore.Rank(input.Text, ids, texts)
+		if len(ranked) == 0 {
+			return nil, DeleteNoteOutput{
+				Success: false,
+				Message: fmt.Sprintf("No note found matching %q", input.Text),
+			}, nil
+		}
+		best, ok := entitystore.Pick(ranked)
+		if !ok {
+			var matchTexts []string
+			for _, c := range ranked {
+				matchTexts = append(matchTexts, fmt.Sprintf("- [%s] %s", c.ID, truncate(c.Text, 80)))
+			}
+			return nil, DeleteNoteOutput{
+				Success:    false,
+				Message:    fmt.Sprintf("Multiple notes match %q. Please be more specific or use an id:\n%s", input.Text, strings.Join(matchTexts, "\n")),
+				Candidates: ranked,
+			}, nil
+		}
+		m = all[best.Index]
+	}
+
+	path, sha := storage.NotesFile, notesSHA
+	if m.source == noteSourceStrategy {
+		path, sha = storage.StrategyFile, strategySHA
+	}
+	if msg := entitystore.CheckUnchanged(path, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, DeleteNoteOutput{
+			Success: false,
+			Message: msg,
+		}, nil
+	}
+
+	// Legacy notes go to the trash with a new ID, to be restored to notes.md
+	var newContent string
+	var trashed storage.Note
+	if m.source == noteSourceStrategy {
+		s.Notes = append(s.Notes[:m.idx], s.Notes[m.idx+1:]...)
+		newContent = storage.SerializeStrategy(s)
+		trashed = storage.Note{ID: storage.NewID(storage.PrefixNote, takenIn(func(n *storage.Note) string { return n.ID }, nf.Notes)), Text: m.text}
+	} else {
+		trashed = nf.Notes[m.idx]
+		nf.Notes = append(nf.Notes[:m.idx], nf.Notes[m.idx+1:]...)
+		newContent = storage.SerializeNotes(nf)
+	}
+
+	msg, err := moveToTrash(ctx, t.storage, t.clock.Now(), storage.FileChange{Path: path, Content: newContent, SHA: sha},
+		storage.TrashItem{Note: &trashed}, fmt.Sprintf("Delete note: %s", truncate(m.text, 50)))
+	if err != nil {
+		return nil, DeleteNoteOutput{}, fmt.Errorf("writing %s: %w", path, err)
+	}
+	if msg != "" {
+		return nil, DeleteNoteOutput{Success: false, Message: msg}, nil
+	}
+
+	noteJSON, err := json.Marshal(struct {
+		Deleted string `json:"deleted_note"`
+		Source  string `json:"source"`
+		Total   int    `json:"total_notes"`
+	}{
+		Deleted: m.text,
+		Source:  m.source,
+		Total:   len(nf.Notes) + len(s.Notes),
+	})
+	if err != nil {
+		return nil, DeleteNoteOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, DeleteNoteOutput{
+		Success: true,
+		Message: string(noteJSON),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestNotes(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.StrategyFile: "## Current Phase\nLaunch\n\n## Notes\n- Keep scope small\n",
+	})
+	ctx := context.Background()
+	notes := NewNoteTools(files, nil)
+
+	// notes.md is created on the first add; categories match case-insensitively
+	for _, in := range []AddNoteInput{
+		{Note: "Dark mode", Category: "Ideas"},
+		{Note: "Offline sync", Category: "ideas"},
+		{Note: "Loose thought"},
+	} {
+		if _, out, err := notes.addNote(ctx, nil, in); err != nil || !out.Success {
+			t.Fatalf("addNote(%+v) = %+v, %v", in, out, err)
+		}
+	}
+	nf, _ := storage.ParseNotes(fileContent(t, files, storage.NotesFile))
+	if len(nf.Notes) != 3 || nf.Notes[1].Category != "Ideas" {
+		t.Fatalf("unexpected notes.md:\n%s", fileContent(t, files, storage.NotesFile))
+	}
+
+	_, list, err := notes.listNotes(ctx, nil, ListNotesInput{})
+	if err != nil || list.Result.Total != 4 {
+		t.Fatalf("listNotes() = %+v, %v", list, err)
+	}
+	if legacy := list.Result.Notes[3]; legacy.Source != "strategy" || legacy.Text != "Keep scope small" {
+		t.Errorf("expected the strategy note last, got %+v", legacy)
+	}
+	_, list, _ = notes.listNotes(ctx, nil, ListNotesInput{Category: "IDEAS"})
+	if len(list.Result.Notes) != 2 {
+		t.Errorf("expected 2 ideas, got %+v", list.Result.Notes)
+	}
+
+	// Text matches span both files
+	if _, out, _ := notes.deleteNote(ctx, nil, DeleteNoteInput{Text: "o"}); out.Success || !strings.Contains(out.Message, "Multiple notes") {
+		t.Errorf("expected an ambiguous match, got %+v", out)
+	}
+	if _, out, _ := notes.deleteNote(ctx, nil, DeleteNoteInput{Text: "scope"}); !out.Success {
+		t.Fatalf("deleteNote(strategy) = %+v", out)
+	}
+	if s, _ := storage.ParseStrategy(fileContent(t, files, storage.StrategyFile)); len(s.Notes) != 0 {
+		t.Errorf("expected the strategy note to be deleted, got %v", s.Notes)
+	}
+	if _, out, _ := notes.deleteNote(ctx, nil, DeleteNoteInput{ID: nf.Notes[0].ID}); !out.Success {
+		t.Fatalf("deleteNote(id) = %+v", out)
+	}
+	if nf, _ := storage.ParseNotes(fileContent(t, files, storage.NotesFile)); len(nf.Notes) != 2 || nf.Notes[0].Text != "Dark mode" {
+		t.Errorf("unexpected notes after delete %+v", nf.Notes)
+	}
+}
@@ -0,0 +1,286 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Read tools return their data twice: typed, in the output's Result field
+// (the call's structuredContent), and as readable text in Message and the
+// text content, for clients that don't use structured output.
+
+// textResult returns a call result whose text content is text. Without it
+// the SDK would fill the text content with the output serialized as JSON.
+func textResult(text string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: text}}}
+}
+
+// plural returns "1 todo" or "3 todos".
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// itemDetails joins the non-empty details into a trailing "(...)" suffix.
+func itemDetails(details ...string) string {
+	var parts []string
+	for _, d := range details {
+		if d != "" {
+			parts = append(parts, d)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// labeled returns "label value", or "" if value is empty.
+func labeled(label, value string) string {
+	if value == "" {
+		return ""
+	}
+	return label + " " + value
+}
+
+func labeledPtr(label string, value *string) string {
+	if value == nil {
+		return ""
+	}
+	return labeled(label, *value)
+}
+
+func checkbox(done bool) string {
+	if done {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+func (t TodoItem) text() string {
+	return fmt.Sprintf("- %s %s%s", checkbox(t.Completed), t.Text, itemDetails(
+		"id "+t.ID, t.Priority, labeled("project", t.Project), labeled("milestone", t.MilestoneID), labeled("added", t.Added), labeledPtr("completed", t.CompletedAt)))
+}
+
+func (r ReminderItem) text() string {
+	overdue := ""
+	if r.Overdue {
+		overdue = "overdue"
+	}
+	return fmt.Sprintf("- %s %s: %s%s", checkbox(r.Completed), r.Date, r.Text, itemDetails(
+		"id "+r.ID, overdue, labeledPtr("completed", r.CompletedAt)))
+}
+
+func (r ReadingListItem) text() string {
+	notes := ""
+	if r.Notes != "" {
+		notes = " — " + r.Notes
+	}
+	minutes := ""
+	if r.Minutes > 0 {
+		minutes = fmt.Sprintf("%d min", r.Minutes)
+	}
+	return fmt.Sprintf("- %s %s%s%s", checkbox(r.Read), r.URL, notes, itemDetails(
+		"id "+r.ID, r.Priority, labeled("category", r.Category), minutes, labeled("added", r.Added), labeledPtr("read", r.ReadAt), labeled("archived in", r.Archive)))
+}
+
+func (m MilestoneItem) text() string {
+	issue, open, progress := "", "", ""
+	if m.Issue > 0 {
+		issue = fmt.Sprintf("issue #%d", m.Issue)
+	}
+	if m.OpenTodos > 0 {
+		open = plural(m.OpenTodos, "open todo")
+	}
+	if m.Progress != nil {
+		progress = fmt.Sprintf("%d%% of key results", *m.Progress)
+	}
+	text := fmt.Sprintf("- %s %s%s", checkbox(m.Completed), m.Text, itemDetails(
+		"id "+m.ID, labeledPtr("due", m.Due), labeled("project", m.Project), issue, open, progress, labeledPtr("completed", m.CompletedAt)))
+	for _, k := range m.KeyResults {
+		text += "\n  " + k.text()
+	}
+	return text
+}
+
+func (k KeyResultItem) text() string {
+	return fmt.Sprintf("- KR %s: %s, %d%% (id %s)", k.Text, keyResultValue(k.Current, k.Target, k.Unit), k.Progress, k.ID)
+}
+
+func (e JournalEntryItem) text() string {
+	return fmt.Sprintf("- %s %s %s (id %s)", e.Date, e.Time, e.Text, e.ID)
+}
+
+func (r ListTodosResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s (%d active, %d completed in total), source_sha %s\n",
+		plural(len(r.Todos), "todo"), r.TotalActive, r.TotalCompleted, r.SourceSHA)
+	for _, t := range r.Todos {
+		sb.WriteString(t.text() + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (r ListRemindersResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s (%d pending, %d overdue, %d completed in total), source_sha %s\n",
+		plural(len(r.Reminders), "reminder"), r.TotalPending, r.TotalOverdue, r.TotalCompleted, r.SourceSHA)
+	for _, rem := range r.Reminders {
+		sb.WriteString(rem.text() + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (r ListReadingListResult) text() string {
+	var sb strings.Builder
+	archived := ""
+	if r.TotalArchived > 0 {
+		archived = fmt.Sprintf(", %d archived", r.TotalArchived)
+	}
+	fmt.Fprintf(&sb, "%s (%d unread, %d read%s in total), source_sha %s\n",
+		plural(len(r.Items), "item"), r.TotalUnread, r.TotalRead, archived, r.SourceSHA)
+	for _, item := range r.Items {
+		sb.WriteString(item.text() + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (r GetMilestonesResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Current phase: %s\nsource_sha %s\n", r.CurrentPhase, r.SourceSHA)
+	fmt.Fprintf(&sb, "\nActive milestones (%d)\n", len(r.ActiveMilestones))
+	for _, m := range r.ActiveMilestones {
+		sb.WriteString(m.text() + "\n")
+	}
+	fmt.Fprintf(&sb, "\nCompleted milestones (%d)\n", len(r.CompletedMilestones))
+	for _, m := range r.CompletedMilestones {
+		sb.WriteString(m.text() + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (r ListPhasesResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Current phase: %s\nsource_sha %s\n", r.CurrentPhase, r.SourceSHA)
+	fmt.Fprintf(&sb, "\nPhases (%d)\n", len(r.Phases))
+	for _, p := range r.Phases {
+		sb.WriteString(p.text() + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (p PhaseItem) text() string {
+	dates := ""
+	switch {
+	case p.Started != "" && p.Ended != "":
+		dates = p.Started + " to " + p.Ended
+	case p.Started != "":
+		dates = "since " + p.Started
+	case p.Ended != "":
+		dates = "until " + p.Ended
+	}
+	days := ""
+	if p.Days != nil {
+		days = plural(*p.Days, "day")
+	}
+	current := ""
+	if p.Current {
+		current = "current"
+	}
+	line := "- " + p.Name + itemDetails(current, dates, days)
+	for _, objective := range p.Objectives {
+		line += "\n  - " + objective
+	}
+	return line
+}
+
+func (r ListNotesResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s (%d in total), source_sha %s\n", plural(len(r.Notes), "note"), r.Total, r.SourceSHA)
+	if len(r.Categories) > 0 {
+		fmt.Fprintf(&sb, "Categories: %s\n", strings.Join(r.Categories, ", "))
+	}
+	for _, n := range r.Notes {
+		id := ""
+		if n.ID != "" {
+			id = "id " + n.ID
+		}
+		source := ""
+		if n.Source == noteSourceStrategy {
+			source = "from strategy.md"
+		}
+		sb.WriteString("- " + n.Text + itemDetails(id, labeled("category", n.Category), labeled("added", n.Added), source) + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (r ListJournalResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s (%d in total), source_sha %s\n", plural(len(r.Entries), "journal entry"), r.TotalEntries, r.SourceSHA)
+	for _, e := range r.Entries {
+		sb.WriteString(e.text() + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (r DashboardResult) text() string {
+	var sb strings.Builder
+
+	if r.Focus != nil {
+		sb.WriteString(r.Focus.text() + "\n\n")
+	}
+
+	fmt.Fprintf(&sb, "Todos: %d active, %d completed (source_sha %s)\n", r.Todos.ActiveCount, r.Todos.CompletedCount, r.Todos.SourceSHA)
+	for _, t := range r.Todos.Active {
+		sb.WriteString(t.text() + "\n")
+	}
+	for _, t := range r.Todos.Completed {
+		sb.WriteString(t.text() + "\n")
+	}
+
+	fmt.Fprintf(&sb, "\nReminders: %d overdue, %d in the next 7 days, %d completed (source_sha %s)\n",
+		len(r.Reminders.Overdue), len(r.Reminders.Upcoming), r.Reminders.CompletedCount, r.Reminders.SourceSHA)
+	for _, rem := range r.Reminders.Overdue {
+		sb.WriteString(rem.text() + "\n")
+	}
+	for _, rem := range r.Reminders.Upcoming {
+		sb.WriteString(rem.text() + "\n")
+	}
+	for _, rem := range r.Reminders.Completed {
+		sb.WriteString(rem.text() + "\n")
+	}
+
+	fmt.Fprintf(&sb, "\nReading list: %d unread, %d read (source_sha %s)\n", len(r.ReadingList.Unread), r.ReadingList.ReadCount, r.ReadingList.SourceSHA)
+	for _, item := range r.ReadingList.Unread {
+		sb.WriteString(item.text() + "\n")
+	}
+	for _, item := range r.ReadingList.Read {
+		sb.WriteString(item.text() + "\n")
+	}
+
+	fmt.Fprintf(&sb, "\nStrategy: %s (source_sha %s)\n", r.Strategy.CurrentPhase, r.Strategy.SourceSHA)
+	for _, m := range r.Strategy.Active {
+		sb.WriteString(m.text() + "\n")
+	}
+	for _, m := range r.Strategy.Completed {
+		sb.WriteString(m.text() + "\n")
+	}
+	if len(r.Strategy.RecentNotes) > 0 {
+		fmt.Fprintf(&sb, "Recent notes (%d in total):\n", r.Strategy.TotalNotes)
+		for _, note := range r.Strategy.RecentNotes {
+			sb.WriteString("- " + note + "\n")
+		}
+	}
+
+	for _, w := range r.Storage.Warnings {
+		fmt.Fprintf(&sb, "\nStorage warning: %s\n", w.Message)
+	}
+	for _, w := range r.Workload.Warnings {
+		fmt.Fprintf(&sb, "\nWorkload warning: %s\n", w)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/usage"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// callOverMCP calls a tool through an in-memory client session, so the SDK's
+// output schema validation and content handling apply.
+func callOverMCP(t *testing.T, server *mcp.Server, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("CallTool(%s) error = %v", name, err)
+	}
+	if res.IsError {
+		t.Fatalf("CallTool(%s) returned an error: %s", name, res.Content[0].(*mcp.TextContent).Text)
+	}
+	return res
+}
+
+func TestStructuredOutput_ListTodos(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.TodosFile: "# Active Todos\n\n## High Priority\n- [ ] Ship it {id:aaaa1111,added:2026-02-01}\n\n# Completed\n",
+	})
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	NewTodoTools(files, nil, WIPLimits{}).Register(server)
+
+	res := callOverMCP(t, server, "list_todos", map[string]any{})
+	_, sha, _ := files.ReadFile(context.Background(), storage.TodosFile)
+
+	raw, _ := json.Marshal(res.StructuredContent)
+	var out ListTodosOutput
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Success || out.Result == nil || len(out.Result.Todos) != 1 || out.Result.Todos[0].ID != "aaaa1111" || out.Result.SourceSHA != sha {
+		t.Fatalf("unexpected structured content %s", raw)
+	}
+
+	// The text fallback is readable, not JSON, and keeps IDs and the sha
+	text := res.Content[0].(*mcp.TextContent).Text
+	if text != out.Message || strings.HasPrefix(text, "{") {
+		t.Errorf("unexpected text content %q", text)
+	}
+	for _, want := range []string{"Ship it", "id aaaa1111", "source_sha " + sha} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text content %q missing %q", text, want)
+		}
+	}
+}
+
+func TestStructuredOutput_Dashboard(t *testing.T) {
+	// Missing files leave empty sections, which must still match the schema
+	files := newFileStorage(map[string]string{
+		storage.RemindersFile: "## Upcoming\n- 2026-02-01: Renew domain {id:dddd4444}\n",
+	})
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	NewDashboardTools(files, clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)), SizeQuota{}, WorkloadLimits{}).Register(server)
+
+	res := callOverMCP(t, server, "get_dashboard", map[string]any{})
+
+	raw, _ := json.Marshal(res.StructuredContent)
+	var out GetDashboardOutput
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Result == nil || len(out.Result.Reminders.Overdue) != 1 {
+		t.Fatalf("unexpected structured content %s", raw)
+	}
+	if !strings.Contains(out.Message, "Renew domain") || !strings.Contains(out.Message, "overdue") {
+		t.Errorf("unexpected message %q", out.Message)
+	}
+}
+
+func TestStructuredOutput_EmptyLists(t *testing.T) {
+	files := newFileStorage(map[string]string{storage.StrategyFile: "## Current Phase\nLaunch\n"})
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	NewStrategyTools(files, nil, nil).Register(server)
+	NewNoteTools(files, nil).Register(server)
+
+	callOverMCP(t, server, "list_notes", map[string]any{"search": "nothing"})
+	callOverMCP(t, server, "get_milestones", map[string]any{})
+}
+
+func TestStructuredOutput_ReadTools(t *testing.T) {
+	// No time log, no stats history and an empty tracker must still match the schemas
+	files := newFileStorage(map[string]string{
+		storage.TodosFile:    "# Active Todos\n\n## High Priority\n- [ ] Ship it {id:aaaa1111,project:website}\n\n# Completed\n",
+		storage.StrategyFile: "## Current Phase\nLaunch\n",
+	})
+	c := clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC))
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	NewProjectTools(files).Register(server)
+	NewTimeTools(files, c).Register(server)
+	NewStatsTools(files, c).Register(server)
+	NewFeedbackTools(usage.NewTracker("")).Register(server)
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"list_projects", map[string]any{}, "website"},
+		{"get_project", map[string]any{"project": "website"}, "id aaaa1111"},
+		{"time_report", map[string]any{}, "0.00 hours"},
+		{"get_stats", map[string]any{}, "Todos:"},
+		{"get_tool_feedback", map[string]any{}, "No tool-call mistakes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := callOverMCP(t, server, tt.name, tt.args)
+			raw, _ := json.Marshal(res.StructuredContent)
+			var out struct {
+				Message string          `json:"message"`
+				Result  json.RawMessage `json:"result"`
+			}
+			if err := json.Unmarshal(raw, &out); err != nil {
+				t.Fatal(err)
+			}
+			if len(out.Result) == 0 {
+				t.Fatalf("expected a structured result, got %s", raw)
+			}
+			text := res.Content[0].(*mcp.TextContent).Text
+			if text != out.Message || strings.HasPrefix(text, "{") || !strings.Contains(text, tt.want) {
+				t.Errorf("unexpected text content %q", text)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PendingTools reviews changes that were proposed as pull requests instead
+// of being committed, when REVIEW_FILES is set.
+type PendingTools struct {
+	pulls storage.PullRequests
+}
+
+// NewPendingTools creates a new PendingTools instance.
+func NewPendingTools(pulls storage.PullRequests) *PendingTools {
+	return &PendingTools{pulls: pulls}
+}
+
+// PendingChangesInput is the input schema for the pending_changes tool.
+type PendingChangesInput struct {
+	Action string `json:"action,omitempty" jsonschema:"list (default), merge, or close (discard)"`
+	Number int    `json:"number,omitempty" jsonschema:"Pull request number of the change to merge or close"`
+}
+
+// PendingChangesOutput is the output for the pending_changes tool.
+type PendingChangesOutput struct {
+	Success bool                  `json:"success"`
+	Message string                `json:"message"`
+	Result  *PendingChangesResult `json:"result,omitempty"`
+}
+
+// PendingChangesResult is the response payload for pending_changes.
+type PendingChangesResult struct {
+	Changes []PendingChange `json:"changes"`
+}
+
+// PendingChange is one change awaiting review.
+type PendingChange struct {
+	Number  int      `json:"number"`
+	Title   string   `json:"title"`
+	URL     string   `json:"url"`
+	Files   []string `json:"files"`
+	Created string   `json:"created"`
+}
+
+// Register registers the pending changes tool with the MCP server.
+func (t *PendingTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "pending_changes",
+		Description: "List changes to reviewed data files that are waiting as pull requests, or merge or close one by number. Reviewed changes don't show up in the data until merged.",
+	}, t.pendingChanges)
+}
+
+func (t *PendingTools) pendingChanges(ctx context.Context, req *mcp.CallToolRequest, input PendingChangesInput) (*mcp.CallToolResult, PendingChangesOutput, error) {
+	action := strings.ToLower(strings.TrimSpace(input.Action))
+	switch action {
+	case "", "list":
+		return t.list(ctx)
+	case "merge", "close":
+	default:
+		return nil, PendingChangesOutput{
+			Success: false,
+			Message: fmt.Sprintf("Unknown action %q. Use list, merge, or close.", input.Action),
+		}, nil
+	}
+
+	if input.Number <= 0 {
+		return nil, PendingChangesOutput{
+			Success: false,
+			Message: "number is required to " + action + " a change",
+		}, nil
+	}
+
+	var err error
+	if action == "merge" {
+		err = t.pulls.MergePullRequest(ctx, input.Number)
+	} else {
+		err = t.pulls.ClosePullRequest(ctx, input.Number)
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, PendingChangesOutput{
+			Success: false,
+			Message: fmt.Sprintf("No pending change #%d", input.Number),
+		}, nil
+	case errors.Is(err, storage.ErrNotMergeable):
+		return nil, PendingChangesOutput{
+			Success: false,
+			Message: fmt.Sprintf("Change #%d conflicts with changes made since it was proposed. Close it and make the change again.", input.Number),
+		}, nil
+	default:
+		return nil, PendingChangesOutput{}, fmt.Errorf("%s pull request #%d: %w", action, input.Number, err)
+	}
+
+	message := fmt.Sprintf("Merged change #%d", input.Number)
+	if action == "close" {
+		message = fmt.Sprintf("Closed change #%d without merging", input.Number)
+	}
+	return nil, PendingChangesOutput{Success: true, Message: message}, nil
+}
+
+func (t *PendingTools) list(ctx context.Context) (*mcp.CallToolResult, PendingChangesOutput, error) {
+	pulls, err := t.pulls.ListPullRequests(ctx)
+	if err != nil {
+		return nil, PendingChangesOutput{}, fmt.Errorf("listing pull requests: %w", err)
+	}
+
+	result := PendingChangesResult{Changes: []PendingChange{}}
+	for _, p := range pulls {
+		result.Changes = append(result.Changes, PendingChange{
+			Number:  p.Number,
+			Title:   p.Title,
+			URL:     p.URL,
+			Files:   p.Files,
+			Created: p.CreatedAt.Format("2006-01-02 15:04"),
+		})
+	}
+	text := result.text()
+	return textResult(text), PendingChangesOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+func (r PendingChangesResult) text() string {
+	if len(r.Changes) == 0 {
+		return "No pending changes."
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s awaiting review:\n", plural(len(r.Changes), "change"))
+	for _, c := range r.Changes {
+		fmt.Fprintf(&b, "\n#%d %s (%s)\n  Files: %s\n  %s\n", c.Number, c.Title, c.Created, strings.Join(c.Files, ", "), c.URL)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
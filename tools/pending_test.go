@@ -0,0 +1,126 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// fakePulls holds open pull requests; #9 conflicts.
+type fakePulls struct {
+	open   []storage.PullRequest
+	merged []int
+}
+
+func (f *fakePulls) ProposeFiles(ctx context.Context, changes []storage.FileChange, message string) (*storage.PullRequest, error) {
+	n := 20 + len(f.open)
+	pr := storage.PullRequest{Number: n, Title: message, URL: fmt.Sprintf("https://github.com/o/r/pull/%d", n)}
+	f.open = append(f.open, pr)
+	return &pr, nil
+}
+
+func (f *fakePulls) ListPullRequests(ctx context.Context) ([]storage.PullRequest, error) {
+	return f.open, nil
+}
+
+func (f *fakePulls) MergePullRequest(ctx context.Context, number int) error {
+	if number == 9 {
+		return storage.ErrNotMergeable
+	}
+	return f.remove(number, true)
+}
+
+func (f *fakePulls) ClosePullRequest(ctx context.Context, number int) error {
+	return f.remove(number, false)
+}
+
+func (f *fakePulls) remove(number int, merged bool) error {
+	for i, p := range f.open {
+		if p.Number == number {
+			f.open = append(f.open[:i], f.open[i+1:]...)
+			if merged {
+				f.merged = append(f.merged, number)
+			}
+			return nil
+		}
+	}
+	return storage.ErrNotFound
+}
+
+func TestPendingChanges(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	pulls := &fakePulls{open: []storage.PullRequest{
+		{Number: 7, Title: "Edit milestone m1", URL: "https://github.com/o/r/pull/7", Files: []string{"strategy.md"}, CreatedAt: created},
+		{Number: 9, Title: "Add milestone", Files: []string{"strategy.md"}, CreatedAt: created},
+	}}
+	pt := NewPendingTools(pulls)
+
+	_, out, err := pt.pendingChanges(ctx, nil, PendingChangesInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out.Success || len(out.Result.Changes) != 2 || out.Result.Changes[0].Created != "2026-03-04 09:00" {
+		t.Fatalf("list = %+v", out)
+	}
+	if !strings.Contains(out.Message, "2 changes awaiting review") || !strings.Contains(out.Message, "#7 Edit milestone m1") {
+		t.Errorf("list message = %q", out.Message)
+	}
+
+	tests := []struct {
+		input PendingChangesInput
+		ok    bool
+		want  string
+	}{
+		{PendingChangesInput{Action: "merge"}, false, "number is required"},
+		{PendingChangesInput{Action: "rebase", Number: 7}, false, "Unknown action"},
+		{PendingChangesInput{Action: "merge", Number: 3}, false, "No pending change #3"},
+		{PendingChangesInput{Action: "merge", Number: 9}, false, "conflicts"},
+		{PendingChangesInput{Action: "Merge", Number: 7}, true, "Merged change #7"},
+		{PendingChangesInput{Action: "close", Number: 9}, true, "Closed change #9"},
+	}
+	for _, tt := range tests {
+		_, out, err := pt.pendingChanges(ctx, nil, tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out.Success != tt.ok || !strings.Contains(out.Message, tt.want) {
+			t.Errorf("%+v = %+v, want success %v and %q", tt.input, out, tt.ok, tt.want)
+		}
+	}
+	if len(pulls.merged) != 1 || pulls.merged[0] != 7 {
+		t.Errorf("merged %v, want [7]", pulls.merged)
+	}
+
+	_, out, _ = pt.pendingChanges(ctx, nil, PendingChangesInput{Action: "list"})
+	if out.Message != "No pending changes." {
+		t.Errorf("empty list message = %q", out.Message)
+	}
+}
+
+func TestProposedWrites(t *testing.T) {
+	ctx := context.Background()
+	mem := newFileStorage(map[string]string{
+		storage.TodosFile: "# Active Todos\n\n## Normal Priority\n",
+		storage.NotesFile: "# Notes\n",
+	})
+	s := storage.WithReview(mem, &fakePulls{}, []string{"*"})
+
+	// Tools writing through entitystore and directly both report the pull
+	// request instead of a change that didn't happen
+	_, todo, err := NewTodoTools(s, nil, WIPLimits{}).addTodo(ctx, nil, AddTodoInput{Text: "Ship it"})
+	if err != nil || todo.Success || !strings.Contains(todo.Message, "pull request #20, pending review") {
+		t.Errorf("addTodo() = %+v, %v", todo, err)
+	}
+	_, note, err := NewNoteTools(s, nil).addNote(ctx, nil, AddNoteInput{Note: "Dark mode"})
+	if err != nil || note.Success || !strings.Contains(note.Message, "pull request #21, pending review") || !strings.Contains(note.Message, "https://github.com/o/r/pull/21") {
+		t.Errorf("addNote() = %+v, %v", note, err)
+	}
+	if fileContent(t, mem, storage.TodosFile) != "# Active Todos\n\n## Normal Priority\n" || fileContent(t, mem, storage.NotesFile) != "# Notes\n" {
+		t.Error("proposed changes were written")
+	}
+}
@@ -0,0 +1,373 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AdvancePhaseInput is the input schema for the advance_phase tool.
+type AdvancePhaseInput struct {
+	Phase          string   `json:"phase" jsonschema:"Name of the phase to move to, e.g. 'Phase 2: Launch'. Matched case-insensitively against phase-templates.md headings."`
+	StartDate      string   `json:"start_date,omitempty" jsonschema:"Date the phase starts (YYYY-MM-DD), used for relative due dates. Defaults to today."`
+	SkipTemplate   bool     `json:"skip_template,omitempty" jsonschema:"Set to true to change the phase without seeding milestones from the template"`
+	Objectives     []string `json:"objectives,omitempty" jsonschema:"Optional objectives for the new phase, kept in its phase history entry"`
+	IfUnchangedSHA string   `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// AdvancePhaseOutput is the output for the advance_phase tool.
+type AdvancePhaseOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ApplyPhaseTemplateInput is the input schema for the apply_phase_template tool.
+type ApplyPhaseTemplateInput struct {
+	Phase          string `json:"phase,omitempty" jsonschema:"Phase whose template to apply. Defaults to the current phase."`
+	StartDate      string `json:"start_date,omitempty" jsonschema:"Date to count relative due dates from (YYYY-MM-DD). Defaults to today."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// ApplyPhaseTemplateOutput is the output for the apply_phase_template tool.
+type ApplyPhaseTemplateOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ListPhasesInput is the input schema for the list_phases tool.
+type ListPhasesInput struct{}
+
+// ListPhasesOutput is the output for the list_phases tool.
+type ListPhasesOutput struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message"`
+	Result  *ListPhasesResult `json:"result,omitempty"`
+}
+
+// ListPhasesResult is the response payload for list_phases.
+type ListPhasesResult struct {
+	CurrentPhase string `json:"current_phase"`
+	// Phases is the phase history, oldest first.
+	Phases    []PhaseItem `json:"phases"`
+	SourceSHA string      `json:"source_sha"`
+}
+
+// PhaseItem is a JSON-serializable strategy phase.
+type PhaseItem struct {
+	Name    string `json:"name"`
+	Started string `json:"started,omitempty"`
+	Ended   string `json:"ended,omitempty"`
+	// Days is the time spent in the phase so far, if its start is known.
+	Days       *int     `json:"days,omitempty"`
+	Current    bool     `json:"current,omitempty"`
+	Objectives []string `json:"objectives,omitempty"`
+}
+
+// PhaseTemplateResult is the response payload for advance_phase and
+// apply_phase_template.
+type PhaseTemplateResult struct {
+	CurrentPhase string `json:"current_phase"`
+	// PreviousPhase is the phase advance_phase ended.
+	PreviousPhase   *PhaseItem      `json:"previous_phase,omitempty"`
+	TemplateApplied bool            `json:"template_applied"`
+	Added           []MilestoneItem `json:"added"`
+	// Skipped lists template milestones already active, which are not duplicated.
+	Skipped []string `json:"skipped,omitempty"`
+	// IssueErrors lists milestones whose GitHub issue couldn't be created;
+	// sync_milestone_issues retries them.
+	IssueErrors []string `json:"issue_errors,omitempty"`
+}
+
+func (t *StrategyTools) advancePhase(ctx context.Context, req *mcp.CallToolRequest, input AdvancePhaseInput) (*mcp.CallToolResult, AdvancePhaseOutput, error) {
+	phase := strings.TrimSpace(input.Phase)
+	if phase == "" {
+		return nil, AdvancePhaseOutput{
+			Success: false,
+			Message: "phase is required",
+		}, nil
+	}
+
+	start, errMsg := phaseStartDate(input.StartDate, t.clock.Now())
+	if errMsg != "" {
+		return nil, AdvancePhaseOutput{Success: false, Message: errMsg}, nil
+	}
+
+	var tmpl *storage.PhaseTemplate
+	if !input.SkipTemplate {
+		var err error
+		tmpl, err = t.readPhaseTemplate(ctx, phase)
+		if err != nil {
+			return nil, AdvancePhaseOutput{}, err
+		}
+	}
+
+	// Read current strategy
+	content, sha, err := t.storage.ReadFile(ctx, storage.StrategyFile)
+	if err != nil {
+		return nil, AdvancePhaseOutput{}, fmt.Errorf("reading strategy.md: %w", err)
+	}
+
+	if msg := entitystore.CheckUnchanged(storage.StrategyFile, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, AdvancePhaseOutput{Success: false, Message: msg}, nil
+	}
+
+	s, err := parseStrategy(ctx, content)
+	if err != nil {
+		return nil, AdvancePhaseOutput{}, fmt.Errorf("parsing strategy: %w", err)
+	}
+
+	result := PhaseTemplateResult{CurrentPhase: phase, Added: []MilestoneItem{}}
+	var objectives []string
+	for _, objective := range input.Objectives {
+		// One line each, like milestones
+		if objective = strings.Join(strings.Fields(objective), " "); objective != "" {
+			objectives = append(objectives, objective)
+		}
+	}
+	result.PreviousPhase = startPhase(s, phase, start, objectives, clock.Today(t.clock))
+	if tmpl != nil {
+		result.TemplateApplied = true
+		result.Added, result.Skipped = seedMilestones(s, tmpl, start, clock.Today(t.clock))
+		_, result.IssueErrors = t.createMilestoneIssues(ctx, s)
+		result.Added = withIssueNumbers(result.Added, s)
+	}
+
+	newContent := storage.SerializeStrategy(s)
+	if err := t.storage.WriteFile(ctx, storage.StrategyFile, newContent, sha, fmt.Sprintf("Advance to phase: %s", truncate(phase, 50))); err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, AdvancePhaseOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, AdvancePhaseOutput{}, fmt.Errorf("writing strategy.md: %w", err)
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, AdvancePhaseOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, AdvancePhaseOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
+
+func (t *StrategyTools) applyPhaseTemplate(ctx context.Context, req *mcp.CallToolRequest, input ApplyPhaseTemplateInput) (*mcp.CallToolResult, ApplyPhaseTemplateOutput, error) {
+	start, errMsg := phaseStartDate(input.StartDate, t.clock.Now())
+	if errMsg != "" {
+		return nil, ApplyPhaseTemplateOutput{Success: false, Message: errMsg}, nil
+	}
+
+	// Read current strategy
+	content, sha, err := t.storage.ReadFile(ctx, storage.StrategyFile)
+	if err != nil {
+		return nil, ApplyPhaseTemplateOutput{}, fmt.Errorf("reading strategy.md: %w", err)
+	}
+
+	if msg := entitystore.CheckUnchanged(storage.StrategyFile, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, ApplyPhaseTemplateOutput{Success: false, Message: msg}, nil
+	}
+
+	s, err := parseStrategy(ctx, content)
+	if err != nil {
+		return nil, ApplyPhaseTemplateOutput{}, fmt.Errorf("parsing strategy: %w", err)
+	}
+
+	phase := strings.TrimSpace(input.Phase)
+	if phase == "" {
+		phase = s.CurrentPhase
+	}
+
+	tmpl, err := t.readPhaseTemplate(ctx, phase)
+	if err != nil {
+		return nil, ApplyPhaseTemplateOutput{}, err
+	}
+	if tmpl == nil {
+		return nil, ApplyPhaseTemplateOutput{
+			Success: false,
+			Message: fmt.Sprintf("No template found for phase %q. Add a \"## %s\" section to %s.", phase, phase, storage.PhaseTemplatesFile),
+		}, nil
+	}
+
+	result := PhaseTemplateResult{CurrentPhase: s.CurrentPhase, TemplateApplied: true}
+	result.Added, result.Skipped = seedMilestones(s, tmpl, start, clock.Today(t.clock))
+
+	if len(result.Added) > 0 {
+		_, result.IssueErrors = t.createMilestoneIssues(ctx, s)
+		result.Added = withIssueNumbers(result.Added, s)
+
+		newContent := storage.SerializeStrategy(s)
+		if err := t.storage.WriteFile(ctx, storage.StrategyFile, newContent, sha, fmt.Sprintf("Apply phase template: %s", truncate(tmpl.Phase, 50))); err != nil {
+			if msg, ok := entitystore.WriteMessage(err); ok {
+				return nil, ApplyPhaseTemplateOutput{
+					Success: false,
+					Message: msg,
+				}, nil
+			}
+			return nil, ApplyPhaseTemplateOutput{}, fmt.Errorf("writing strategy.md: %w", err)
+		}
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, ApplyPhaseTemplateOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, ApplyPhaseTemplateOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
+
+func (t *StrategyTools) listPhases(ctx context.Context, req *mcp.CallToolRequest, input ListPhasesInput) (*mcp.CallToolResult, ListPhasesOutput, error) {
+	content, sha, err := t.storage.ReadFile(ctx, storage.StrategyFile)
+	if err != nil {
+		return nil, ListPhasesOutput{}, fmt.Errorf("reading strategy.md: %w", err)
+	}
+	s, err := parseStrategy(ctx, content)
+	if err != nil {
+		return nil, ListPhasesOutput{}, fmt.Errorf("parsing strategy: %w", err)
+	}
+
+	result := ListPhasesResult{
+		CurrentPhase: s.CurrentPhase,
+		Phases:       phasesToItems(s, clock.Today(t.clock)),
+		SourceSHA:    sha,
+	}
+	text := result.text()
+	return textResult(text), ListPhasesOutput{Success: true, Message: text, Result: &result}, nil
+}
+
+// startPhase makes phase the current phase from start, ending the one
+// before it in the phase history, which it returns. Moving to the current
+// phase again (to re-seed its template) only adds the objectives.
+func startPhase(s *storage.Strategy, phase string, start time.Time, objectives []string, today time.Time) *PhaseItem {
+	previous := s.CurrentPhase
+	s.CurrentPhase = phase
+
+	if len(s.Phases) == 0 && previous != "" {
+		// Files from before phases were tracked: the start is unknown
+		s.Phases = append(s.Phases, storage.Phase{Name: previous})
+	}
+	if n := len(s.Phases); n > 0 && s.Phases[n-1].Ended == nil {
+		last := &s.Phases[n-1]
+		if strings.EqualFold(last.Name, phase) {
+			last.Objectives = append(last.Objectives, objectives...)
+			return nil
+		}
+		last.Ended = &start
+		item := phaseToItem(*last, false, today)
+		s.Phases = append(s.Phases, storage.Phase{Name: phase, Started: start, Objectives: objectives})
+		return &item
+	}
+	s.Phases = append(s.Phases, storage.Phase{Name: phase, Started: start, Objectives: objectives})
+	return nil
+}
+
+// phasesToItems lists the phase history, or just the current phase for
+// files from before phases were tracked.
+func phasesToItems(s *storage.Strategy, today time.Time) []PhaseItem {
+	items := []PhaseItem{}
+	for i, p := range s.Phases {
+		items = append(items, phaseToItem(p, i == len(s.Phases)-1 && p.Ended == nil, today))
+	}
+	if len(items) == 0 && s.CurrentPhase != "" {
+		items = append(items, PhaseItem{Name: s.CurrentPhase, Current: true})
+	}
+	return items
+}
+
+func phaseToItem(p storage.Phase, current bool, today time.Time) PhaseItem {
+	item := PhaseItem{
+		Name:       p.Name,
+		Current:    current,
+		Objectives: p.Objectives,
+	}
+	if p.Ended != nil {
+		item.Ended = formatDate(*p.Ended)
+	}
+	if !p.Started.IsZero() {
+		item.Started = formatDate(p.Started)
+		end := today
+		if p.Ended != nil {
+			end = *p.Ended
+		}
+		days := int(end.Sub(p.Started).Hours() / 24)
+		item.Days = &days
+	}
+	return item
+}
+
+// readPhaseTemplate loads the template for phase. It returns nil if the
+// template file or the phase's section doesn't exist.
+func (t *StrategyTools) readPhaseTemplate(ctx context.Context, phase string) (*storage.PhaseTemplate, error) {
+	content, _, err := t.storage.ReadFile(ctx, storage.PhaseTemplatesFile)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", storage.PhaseTemplatesFile, err)
+	}
+
+	templates, err := storage.ParsePhaseTemplates(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing phase templates: %w", err)
+	}
+	return storage.FindPhaseTemplate(templates, phase), nil
+}
+
+// phaseStartDate parses an optional start date, defaulting to today. It
+// returns a validation message if the date is invalid.
+func phaseStartDate(s string, now time.Time) (time.Time, string) {
+	if strings.TrimSpace(s) == "" {
+		return clock.Date(now), ""
+	}
+	start, err := parseDate(s, now)
+	if err != nil {
+		return time.Time{}, fmt.Sprintf("Invalid start_date format %q. Use YYYY-MM-DD format.", s)
+	}
+	return start, ""
+}
+
+// seedMilestones appends the template's milestones to the active list, with
+// due dates counted from start and added set to today. Milestones whose text
+// is already active are skipped so a template can be re-applied safely.
+func seedMilestones(s *storage.Strategy, tmpl *storage.PhaseTemplate, start, today time.Time) ([]MilestoneItem, []string) {
+	existing := make(map[string]bool, len(s.ActiveMilestones))
+	for _, m := range s.ActiveMilestones {
+		existing[strings.ToLower(m.Text)] = true
+	}
+
+	added := []MilestoneItem{}
+	var skipped []string
+	for _, tm := range tmpl.Milestones {
+		if existing[strings.ToLower(tm.Text)] {
+			skipped = append(skipped, tm.Text)
+			continue
+		}
+		existing[strings.ToLower(tm.Text)] = true
+
+		m := storage.Milestone{
+			ID:    storage.NewID(storage.PrefixMilestone, takenIn(milestoneKind.ID, s.ActiveMilestones, s.CompletedMilestones)),
+			Text:  tm.Text,
+			Added: today,
+		}
+		if tm.DueInDays > 0 {
+			due := start.AddDate(0, 0, tm.DueInDays)
+			m.Due = &due
+		}
+		s.ActiveMilestones = append(s.ActiveMilestones, m)
+		added = append(added, milestoneToItem(m))
+	}
+	return added, skipped
+}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestAdvancePhase_History(t *testing.T) {
+	ctx := context.Background()
+	files := newFileStorage(map[string]string{
+		storage.StrategyFile: "# My Plan\n\n## Current Phase\nPhase 1: Foundations\n\n## Active Milestones\n\n## Completed Milestones\n\n## Notes\n",
+	})
+	clk := clock.NewFake(time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC))
+	tools := NewStrategyTools(files, nil, clk)
+
+	// A file from before phases were tracked: the old phase's start is unknown
+	_, out, err := tools.advancePhase(ctx, nil, AdvancePhaseInput{Phase: "Phase 2: Launch", SkipTemplate: true, Objectives: []string{"Ship  the beta", " "}})
+	if err != nil || !out.Success {
+		t.Fatalf("advancePhase() = %+v, %v", out, err)
+	}
+	if !strings.Contains(fileContent(t, files, storage.StrategyFile), "## Phases\n- Phase 1: Foundations {ended:2026-02-01}\n- Phase 2: Launch {started:2026-02-01}\n  - Ship the beta\n") {
+		t.Fatalf("unexpected strategy.md:\n%s", fileContent(t, files, storage.StrategyFile))
+	}
+
+	clk.Advance(14 * 24 * time.Hour)
+	if _, out, _ := tools.advancePhase(ctx, nil, AdvancePhaseInput{Phase: "Phase 3: Growth", SkipTemplate: true}); !out.Success || !strings.Contains(out.Message, `"previous_phase":{"name":"Phase 2: Launch","started":"2026-02-01","ended":"2026-02-15","days":14`) {
+		t.Fatalf("advancePhase() = %+v", out)
+	}
+
+	clk.Advance(3 * 24 * time.Hour)
+	_, list, err := tools.listPhases(ctx, nil, ListPhasesInput{})
+	if err != nil || !list.Success || len(list.Result.Phases) != 3 {
+		t.Fatalf("listPhases() = %+v, %v", list, err)
+	}
+	current := list.Result.Phases[2]
+	if list.Result.CurrentPhase != "Phase 3: Growth" || !current.Current || current.Started != "2026-02-15" || current.Days == nil || *current.Days != 3 {
+		t.Errorf("unexpected current phase %+v", current)
+	}
+	if first := list.Result.Phases[0]; first.Days != nil || first.Ended != "2026-02-01" {
+		t.Errorf("unexpected first phase %+v", first)
+	}
+
+	// Re-entering the current phase doesn't add to the history
+	if _, out, _ := tools.advancePhase(ctx, nil, AdvancePhaseInput{Phase: "phase 3: growth", SkipTemplate: true, Objectives: []string{"Hire"}}); !out.Success {
+		t.Fatalf("advancePhase() = %+v", out)
+	}
+	s, _ := storage.ParseStrategy(fileContent(t, files, storage.StrategyFile))
+	if len(s.Phases) != 3 || len(s.Phases[2].Objectives) != 1 {
+		t.Errorf("unexpected phases %+v", s.Phases)
+	}
+}
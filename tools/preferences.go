@@ -0,0 +1,206 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/notify"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// digests are the digest frequencies set_preferences accepts.
+var digests = []string{storage.DigestDaily, storage.DigestWeekdays, storage.DigestWeekly, storage.DigestOff}
+
+// PreferenceTools manages the notification preferences in preferences.md,
+// which the reminder notifications and the scheduled briefing follow.
+type PreferenceTools struct {
+	storage storage.Storage
+}
+
+// NewPreferenceTools creates a new PreferenceTools instance.
+func NewPreferenceTools(s storage.Storage) *PreferenceTools {
+	return &PreferenceTools{storage: s}
+}
+
+// GetPreferencesInput is the input schema for the get_preferences tool.
+type GetPreferencesInput struct{}
+
+// SetPreferencesInput is the input schema for the set_preferences tool.
+// Omitted fields keep their current values.
+type SetPreferencesInput struct {
+	Channels       []string `json:"channels,omitempty" jsonschema:"Channels to notify through: webhook and/or email. Pass [\"all\"] for every configured channel."`
+	QuietHours     *string  `json:"quiet_hours,omitempty" jsonschema:"Daily window without notifications as HH:MM-HH:MM, e.g. 22:00-07:00 (may span midnight). Notifications due in it wait until it ends. Pass off to clear."`
+	Digest         *string  `json:"digest,omitempty" jsonschema:"How often the due reminders digest is sent: daily, weekdays, weekly (Mondays) or off"`
+	IfUnchangedSHA string   `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier get_preferences call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// PreferencesOutput is the output for the get_preferences and
+// set_preferences tools.
+type PreferencesOutput struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Result  *PreferencesItem `json:"result,omitempty"`
+}
+
+// PreferencesItem is a JSON-serializable set of notification preferences.
+// Empty fields are the defaults.
+type PreferencesItem struct {
+	// Channels is empty for every configured channel.
+	Channels   []string `json:"channels"`
+	QuietHours string   `json:"quiet_hours,omitempty"`
+	Digest     string   `json:"digest"`
+	SourceSHA  string   `json:"source_sha,omitempty"`
+}
+
+// Register registers preference tools with the MCP server.
+func (t *PreferenceTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_preferences",
+		Description: "Get the notification preferences: which channels reminder notifications and the scheduled briefing use, quiet hours, and how often the reminder digest is sent.",
+	}, t.getPreferences)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "set_preferences",
+		Description: "Set notification preferences: channels (webhook, email), quiet hours (e.g. 22:00-07:00) and digest frequency (daily, weekdays, weekly, off). Omitted fields are kept.",
+	}, t.setPreferences)
+}
+
+func (t *PreferenceTools) load(ctx context.Context) (*storage.Preferences, string, error) {
+	content, sha, err := t.storage.ReadFile(ctx, storage.PreferencesFile)
+	if errors.Is(err, storage.ErrNotFound) {
+		return &storage.Preferences{}, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("reading preferences.md: %w", err)
+	}
+	p, err := storage.ParsePreferences(content)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing preferences: %w", err)
+	}
+	return p, sha, nil
+}
+
+func (t *PreferenceTools) getPreferences(ctx context.Context, req *mcp.CallToolRequest, input GetPreferencesInput) (*mcp.CallToolResult, PreferencesOutput, error) {
+	p, sha, err := t.load(ctx)
+	if err != nil {
+		return nil, PreferencesOutput{}, err
+	}
+	item := preferencesToItem(p.Notifications, sha)
+	text := item.text()
+	return textResult(text), PreferencesOutput{Success: true, Message: text, Result: &item}, nil
+}
+
+func (t *PreferenceTools) setPreferences(ctx context.Context, req *mcp.CallToolRequest, input SetPreferencesInput) (*mcp.CallToolResult, PreferencesOutput, error) {
+	if input.Channels == nil && input.QuietHours == nil && input.Digest == nil {
+		return nil, PreferencesOutput{
+			Success: false,
+			Message: "At least one of channels, quiet_hours or digest must be provided",
+		}, nil
+	}
+
+	var channels []string
+	for _, channel := range input.Channels {
+		channel = strings.ToLower(strings.TrimSpace(channel))
+		switch {
+		case channel == "all":
+			channels = nil
+		case slices.Contains(notify.Channels, channel):
+			if !slices.Contains(channels, channel) {
+				channels = append(channels, channel)
+			}
+		default:
+			return nil, PreferencesOutput{
+				Success: false,
+				Message: fmt.Sprintf("Unknown channel %q. Use %s, or all.", channel, strings.Join(notify.Channels, " or ")),
+			}, nil
+		}
+	}
+	var quiet storage.QuietHours
+	if input.QuietHours != nil && !strings.EqualFold(strings.TrimSpace(*input.QuietHours), "off") && strings.TrimSpace(*input.QuietHours) != "" {
+		var err error
+		if quiet, err = storage.ParseQuietHours(*input.QuietHours); err != nil {
+			return nil, PreferencesOutput{Success: false, Message: err.Error()}, nil
+		}
+	}
+	var digest string
+	if input.Digest != nil {
+		digest = strings.ToLower(strings.TrimSpace(*input.Digest))
+		if !slices.Contains(digests, digest) {
+			return nil, PreferencesOutput{
+				Success: false,
+				Message: fmt.Sprintf("Invalid digest %q. Use %s.", *input.Digest, strings.Join(digests, ", ")),
+			}, nil
+		}
+	}
+
+	p, sha, err := t.load(ctx)
+	if err != nil {
+		return nil, PreferencesOutput{}, err
+	}
+	if msg := entitystore.CheckUnchanged(storage.PreferencesFile, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, PreferencesOutput{Success: false, Message: msg}, nil
+	}
+
+	n := &p.Notifications
+	if input.Channels != nil {
+		n.Channels = channels
+	}
+	if input.QuietHours != nil {
+		n.QuietHours = quiet
+	}
+	if input.Digest != nil {
+		n.Digest = digest
+		if digest == storage.DigestDaily {
+			// The default, left out of the file
+			n.Digest = ""
+		}
+	}
+
+	err = t.storage.WriteFile(ctx, storage.PreferencesFile, storage.SerializePreferences(p), sha, "Set notification preferences")
+	if msg, ok := entitystore.WriteMessage(err); ok {
+		return nil, PreferencesOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, PreferencesOutput{}, fmt.Errorf("writing preferences.md: %w", err)
+	}
+
+	item := preferencesToItem(*n, "")
+	text := "Updated notification preferences. " + item.text()
+	return textResult(text), PreferencesOutput{Success: true, Message: text, Result: &item}, nil
+}
+
+func preferencesToItem(n storage.NotificationPreferences, sha string) PreferencesItem {
+	item := PreferencesItem{
+		Channels:   n.Channels,
+		QuietHours: n.QuietHours.String(),
+		Digest:     n.Digest,
+		SourceSHA:  sha,
+	}
+	if item.Channels == nil {
+		item.Channels = []string{}
+	}
+	if item.Digest == "" {
+		item.Digest = storage.DigestDaily
+	}
+	return item
+}
+
+func (p PreferencesItem) text() string {
+	channels := "all configured channels"
+	if len(p.Channels) > 0 {
+		channels = strings.Join(p.Channels, ", ")
+	}
+	quiet := "no quiet hours"
+	if p.QuietHours != "" {
+		quiet = "quiet hours " + p.QuietHours
+	}
+	text := fmt.Sprintf("Notify through %s, %s, %s digest", channels, quiet, p.Digest)
+	if p.SourceSHA != "" {
+		text += fmt.Sprintf(" (source_sha %s)", p.SourceSHA)
+	}
+	return text
+}
@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestPreferences(t *testing.T) {
+	ctx := context.Background()
+	files := newFileStorage(nil)
+	prefs := NewPreferenceTools(files)
+
+	_, out, err := prefs.getPreferences(ctx, nil, GetPreferencesInput{})
+	if err != nil || !out.Success || len(out.Result.Channels) != 0 || out.Result.Digest != "daily" || out.Result.QuietHours != "" {
+		t.Fatalf("getPreferences() = %+v, %v", out, err)
+	}
+
+	quiet, digest := "22:00-07:00", "Weekdays"
+	_, out, err = prefs.setPreferences(ctx, nil, SetPreferencesInput{Channels: []string{"Email"}, QuietHours: &quiet, Digest: &digest})
+	if err != nil || !out.Success {
+		t.Fatalf("setPreferences() = %+v, %v", out, err)
+	}
+	want := "# Preferences\n\n## Notifications\n- Channels: email\n- Quiet hours: 22:00-07:00\n- Digest: weekdays\n"
+	if fileContent(t, files, storage.PreferencesFile) != want {
+		t.Errorf("unexpected preferences.md:\n%s", fileContent(t, files, storage.PreferencesFile))
+	}
+
+	// Omitted fields are kept; all and off reset to the defaults
+	off := "off"
+	if _, out, _ := prefs.setPreferences(ctx, nil, SetPreferencesInput{Channels: []string{"all"}, QuietHours: &off}); !out.Success {
+		t.Fatalf("setPreferences() = %+v", out)
+	}
+	if want := "# Preferences\n\n## Notifications\n- Digest: weekdays\n"; fileContent(t, files, storage.PreferencesFile) != want {
+		t.Errorf("unexpected preferences.md:\n%s", fileContent(t, files, storage.PreferencesFile))
+	}
+
+	bad := "7pm-8am"
+	for _, input := range []SetPreferencesInput{
+		{},
+		{Channels: []string{"sms"}},
+		{QuietHours: &bad},
+		{Digest: &bad},
+	} {
+		if _, out, _ := prefs.setPreferences(ctx, nil, input); out.Success {
+			t.Errorf("setPreferences(%+v) succeeded", input)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ProjectTools provides a project-level view across todos and milestones.
+type ProjectTools struct {
+	storage storage.Storage
+}
+
+// NewProjectTools creates a new ProjectTools instance.
+func NewProjectTools(s storage.Storage) *ProjectTools {
+	return &ProjectTools{storage: s}
+}
+
+// ListProjectsInput is the input schema for the list_projects tool.
+type ListProjectsInput struct{}
+
+// ListProjectsOutput is the output for the list_projects tool.
+type ListProjectsOutput struct {
+	Success bool                `json:"success"`
+	Message string              `json:"message"`
+	Result  *ListProjectsResult `json:"result,omitempty"`
+}
+
+// ListProjectsResult is the response payload for list_projects.
+type ListProjectsResult struct {
+	Projects []ProjectSummary `json:"projects"`
+}
+
+// GetProjectInput is the input schema for the get_project tool.
+type GetProjectInput struct {
+	Project string `json:"project" jsonschema:"Project name. Use list_projects to see available projects."`
+}
+
+// GetProjectOutput is the output for the get_project tool.
+type GetProjectOutput struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message"`
+	Result  *GetProjectResult `json:"result,omitempty"`
+}
+
+// ProjectSummary describes a project and its progress.
+type ProjectSummary struct {
+	Project     string `json:"project"`
+	Description string `json:"description,omitempty"`
+	// Registered is false for projects referenced by items but missing from projects.md.
+	Registered        bool `json:"registered"`
+	TotalItems        int  `json:"total_items"`
+	CompletedItems    int  `json:"completed_items"`
+	CompletionPercent int  `json:"completion_percent"`
+}
+
+// GetProjectResult is the response payload for get_project.
+type GetProjectResult struct {
+	ProjectSummary
+	ActiveTodos         []TodoItem      `json:"active_todos"`
+	CompletedTodos      []TodoItem      `json:"completed_todos"`
+	ActiveMilestones    []MilestoneItem `json:"active_milestones"`
+	CompletedMilestones []MilestoneItem `json:"completed_milestones"`
+}
+
+// Register registers project tools with the MCP server.
+func (p *ProjectTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_projects",
+		Description: "List projects from projects.md and any project referenced by a todo or milestone, with completion percentages",
+	}, p.listProjects)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_project",
+		Description: "Get all todos and milestones belonging to a project, plus its completion percentage",
+	}, p.getProject)
+}
+
+// projectData is everything needed to build project views.
+type projectData struct {
+	registry []storage.Project
+	todos    *storage.TodoFile
+	strategy *storage.Strategy
+}
+
+// load reads the registry, todos and strategy. A missing projects.md is an
+// empty registry.
+func (p *ProjectTools) load(ctx context.Context) (*projectData, error) {
+	data := &projectData{}
+
+	content, _, err := p.storage.ReadFile(ctx, storage.ProjectsFile)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("reading %s: %w", storage.ProjectsFile, err)
+	}
+	if err == nil {
+		if data.registry, err = storage.ParseProjects(content); err != nil {
+			return nil, fmt.Errorf("parsing projects: %w", err)
+		}
+	}
+
+	content, _, err = p.storage.ReadFile(ctx, storage.TodosFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading todos.md: %w", err)
+	}
+	if data.todos, err = parseTodos(ctx, content); err != nil {
+		return nil, fmt.Errorf("parsing todos: %w", err)
+	}
+
+	content, _, err = p.storage.ReadFile(ctx, storage.StrategyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading strategy.md: %w", err)
+	}
+	if data.strategy, err = parseStrategy(ctx, content); err != nil {
+		return nil, fmt.Errorf("parsing strategy: %w", err)
+	}
+
+	return data, nil
+}
+
+// summaries returns a summary per project, registered projects first in
+// registry order, then unregistered ones alphabetically.
+func (d *projectData) summaries() []ProjectSummary {
+	byProject := make(map[string]*ProjectSummary)
+	var order []string
+	get := func(slug string) *ProjectSummary {
+		if s, ok := byProject[slug]; ok {
+			return s
+		}
+		s := &ProjectSummary{Project: slug}
+		byProject[slug] = s
+		order = append(order, slug)
+		return s
+	}
+
+	for _, proj := range d.registry {
+		s := get(proj.Slug)
+		s.Description = proj.Description
+		s.Registered = true
+	}
+	registered := len(order)
+
+	count := func(slug string, completed bool) {
+		if slug == "" {
+			return
+		}
+		s := get(slug)
+		s.TotalItems++
+		if completed {
+			s.CompletedItems++
+		}
+	}
+	for _, t := range d.todos.Active {
+		count(t.Project, false)
+	}
+	for _, t := range d.todos.Completed {
+		count(t.Project, true)
+	}
+	for _, m := range d.strategy.ActiveMilestones {
+		count(m.Project, false)
+	}
+	for _, m := range d.strategy.CompletedMilestones {
+		count(m.Project, true)
+	}
+
+	sort.Strings(order[registered:])
+	result := make([]ProjectSummary, len(order))
+	for i, slug := range order {
+		s := byProject[slug]
+		if s.TotalItems > 0 {
+			s.CompletionPercent = s.CompletedItems * 100 / s.TotalItems
+		}
+		result[i] = *s
+	}
+	return result
+}
+
+func (p *ProjectTools) listProjects(ctx context.Context, req *mcp.CallToolRequest, input ListProjectsInput) (*mcp.CallToolResult, ListProjectsOutput, error) {
+	data, err := p.load(ctx)
+	if err != nil {
+		return nil, ListProjectsOutput{}, err
+	}
+
+	result := ListProjectsResult{Projects: data.summaries()}
+	text := result.text()
+	return textResult(text), ListProjectsOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+func (p *ProjectTools) getProject(ctx context.Context, req *mcp.CallToolRequest, input GetProjectInput) (*mcp.CallToolResult, GetProjectOutput, error) {
+	slug := storage.NormalizeProject(input.Project)
+	if slug == "" {
+		return nil, GetProjectOutput{
+			Success: false,
+			Message: "project is required",
+		}, nil
+	}
+
+	data, err := p.load(ctx)
+	if err != nil {
+		return nil, GetProjectOutput{}, err
+	}
+
+	var summary *ProjectSummary
+	summaries := data.summaries()
+	var known []string
+	for i, s := range summaries {
+		if s.Project == slug {
+			summary = &summaries[i]
+		}
+		known = append(known, s.Project)
+	}
+	if summary == nil {
+		msg := fmt.Sprintf("No project found named %q", input.Project)
+		if len(known) > 0 {
+			msg += ". Known projects: " + strings.Join(known, ", ")
+		}
+		return nil, GetProjectOutput{Success: false, Message: msg}, nil
+	}
+
+	result := GetProjectResult{
+		ProjectSummary:      *summary,
+		ActiveTodos:         []TodoItem{},
+		CompletedTodos:      []TodoItem{},
+		ActiveMilestones:    []MilestoneItem{},
+		CompletedMilestones: []MilestoneItem{},
+	}
+	for _, t := range data.todos.Active {
+		if t.Project == slug {
+			result.ActiveTodos = append(result.ActiveTodos, todoToItem(t))
+		}
+	}
+	for _, t := range data.todos.Completed {
+		if t.Project == slug {
+			result.CompletedTodos = append(result.CompletedTodos, todoToItem(t))
+		}
+	}
+	for _, m := range data.strategy.ActiveMilestones {
+		if m.Project == slug {
+			result.ActiveMilestones = append(result.ActiveMilestones, milestoneToItem(m))
+		}
+	}
+	for _, m := range data.strategy.CompletedMilestones {
+		if m.Project == slug {
+			result.CompletedMilestones = append(result.CompletedMilestones, milestoneToItem(m))
+		}
+	}
+
+	text := result.text()
+	return textResult(text), GetProjectOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+func (p ProjectSummary) text() string {
+	description := ""
+	if p.Description != "" {
+		description = ": " + p.Description
+	}
+	unregistered := ""
+	if !p.Registered {
+		unregistered = "not in projects.md"
+	}
+	return fmt.Sprintf("- %s%s%s", p.Project, description, itemDetails(
+		fmt.Sprintf("%d/%d done, %d%%", p.CompletedItems, p.TotalItems, p.CompletionPercent), unregistered))
+}
+
+func (r ListProjectsResult) text() string {
+	if len(r.Projects) == 0 {
+		return "No projects."
+	}
+	var sb strings.Builder
+	sb.WriteString(plural(len(r.Projects), "project") + "\n")
+	for _, p := range r.Projects {
+		sb.WriteString(p.text() + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (r GetProjectResult) text() string {
+	var sb strings.Builder
+	sb.WriteString("Project " + strings.TrimPrefix(r.ProjectSummary.text(), "- ") + "\n")
+	fmt.Fprintf(&sb, "\nTodos: %d active, %d completed\n", len(r.ActiveTodos), len(r.CompletedTodos))
+	for _, t := range r.ActiveTodos {
+		sb.WriteString(t.text() + "\n")
+	}
+	for _, t := range r.CompletedTodos {
+		sb.WriteString(t.text() + "\n")
+	}
+	fmt.Fprintf(&sb, "\nMilestones: %d active, %d completed\n", len(r.ActiveMilestones), len(r.CompletedMilestones))
+	for _, m := range r.ActiveMilestones {
+		sb.WriteString(m.text() + "\n")
+	}
+	for _, m := range r.CompletedMilestones {
+		sb.WriteString(m.text() + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// projectOrNone normalizes a project input, where "none" clears the project.
+func projectOrNone(s string) string {
+	if strings.EqualFold(strings.TrimSpace(s), "none") {
+		return ""
+	}
+	return storage.NormalizeProject(s)
+}
@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestProjectData_Summaries(t *testing.T) {
+	data := &projectData{
+		registry: []storage.Project{{Slug: "website", Description: "Site relaunch"}, {Slug: "empty"}},
+		todos: &storage.TodoFile{
+			Active:    []storage.Todo{{Project: "website"}, {Project: "zeta"}, {}},
+			Completed: []storage.Todo{{Project: "website"}, {Project: "alpha"}},
+		},
+		strategy: &storage.Strategy{
+			ActiveMilestones:    []storage.Milestone{{Project: "website"}},
+			CompletedMilestones: []storage.Milestone{{Project: "website"}},
+		},
+	}
+
+	got := data.summaries()
+	var names []string
+	for _, s := range got {
+		names = append(names, s.Project)
+	}
+	// Registered projects first in registry order, then unregistered alphabetically
+	want := []string{"website", "empty", "alpha", "zeta"}
+	if len(names) != len(want) {
+		t.Fatalf("expected projects %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("expected projects %v, got %v", want, names)
+		}
+	}
+
+	website := got[0]
+	if !website.Registered || website.TotalItems != 4 || website.CompletedItems != 2 || website.CompletionPercent != 50 {
+		t.Errorf("unexpected website summary: %+v", website)
+	}
+	if got[1].TotalItems != 0 || got[1].CompletionPercent != 0 {
+		t.Errorf("expected empty project to have no items: %+v", got[1])
+	}
+	if got[2].Registered || got[2].CompletionPercent != 100 {
+		t.Errorf("unexpected alpha summary: %+v", got[2])
+	}
+}
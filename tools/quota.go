@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// SizeQuota sets soft limits on data file sizes. Exceeding them never blocks
+// writes; it only produces warnings suggesting archival, since the GitHub
+// Contents API and tool payloads slow down as the markdown grows.
+type SizeQuota struct {
+	// FileWarnBytes is the per-file warning threshold.
+	FileWarnBytes int
+	// TotalWarnBytes is the warning threshold for all data files combined.
+	TotalWarnBytes int
+}
+
+// DefaultSizeQuota is used when no quota is configured.
+var DefaultSizeQuota = SizeQuota{
+	FileWarnBytes:  100 * 1024,
+	TotalWarnBytes: 400 * 1024,
+}
+
+// SizeWarning describes a data file (or the whole repo) over its soft quota.
+type SizeWarning struct {
+	File    string `json:"file"` // "total" for the combined size
+	Bytes   int    `json:"bytes"`
+	Limit   int    `json:"limit"`
+	Message string `json:"message"`
+}
+
+// archiveHints suggests how to shrink each file.
+var archiveHints = map[string]string{
+	storage.TodosFile:       "delete or archive old completed todos",
+	storage.StrategyFile:    "archive completed milestones and old notes",
+	storage.ReadingListFile: "archive_reading to move old read items to yearly archives",
+	storage.RemindersFile:   "delete old completed reminders",
+	storage.JournalFile:     "move older entries to a dated archive file",
+	storage.NotesFile:       "delete or archive notes you no longer need",
+	storage.TimeLogFile:     "move older sessions to a dated archive file",
+}
+
+// check returns warnings for files over quota, sorted by size descending.
+func (q SizeQuota) check(sizes map[string]int) []SizeWarning {
+	if q.FileWarnBytes <= 0 {
+		q.FileWarnBytes = DefaultSizeQuota.FileWarnBytes
+	}
+	if q.TotalWarnBytes <= 0 {
+		q.TotalWarnBytes = DefaultSizeQuota.TotalWarnBytes
+	}
+
+	var warnings []SizeWarning
+	total := 0
+	for file, size := range sizes {
+		total += size
+		if size <= q.FileWarnBytes {
+			continue
+		}
+		hint := archiveHints[file]
+		if hint == "" {
+			hint = "archive old entries"
+		}
+		warnings = append(warnings, SizeWarning{
+			File:    file,
+			Bytes:   size,
+			Limit:   q.FileWarnBytes,
+			Message: fmt.Sprintf("%s is %d KB (soft limit %d KB); consider: %s", file, size/1024, q.FileWarnBytes/1024, hint),
+		})
+	}
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].Bytes > warnings[j].Bytes })
+
+	if total > q.TotalWarnBytes {
+		warnings = append(warnings, SizeWarning{
+			File:    "total",
+			Bytes:   total,
+			Limit:   q.TotalWarnBytes,
+			Message: fmt.Sprintf("Data files total %d KB (soft limit %d KB); consider archiving completed items", total/1024, q.TotalWarnBytes/1024),
+		})
+	}
+	return warnings
+}
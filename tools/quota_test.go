@@ -0,0 +1,26 @@
+package tools
+
+import "testing"
+
+func TestSizeQuota_Check(t *testing.T) {
+	q := SizeQuota{FileWarnBytes: 100, TotalWarnBytes: 250}
+
+	warnings := q.check(map[string]int{
+		"todos.md":        150,
+		"strategy.md":     120,
+		"reading-list.md": 10,
+	})
+	if len(warnings) != 3 {
+		t.Fatalf("expected 2 file warnings and 1 total warning, got %+v", warnings)
+	}
+	if warnings[0].File != "todos.md" || warnings[1].File != "strategy.md" {
+		t.Errorf("expected largest file first, got %+v", warnings)
+	}
+	if warnings[2].File != "total" || warnings[2].Bytes != 280 {
+		t.Errorf("unexpected total warning: %+v", warnings[2])
+	}
+
+	if got := q.check(map[string]int{"todos.md": 50}); len(got) != 0 {
+		t.Errorf("expected no warnings under quota, got %+v", got)
+	}
+}
@@ -0,0 +1,311 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RawFileTools reads and edits data files as plain markdown, for content the
+// structured tools don't model (custom sections, one-off edits).
+type RawFileTools struct {
+	storage storage.Storage
+}
+
+// NewRawFileTools creates a new RawFileTools instance.
+func NewRawFileTools(s storage.Storage) *RawFileTools {
+	return &RawFileTools{storage: s}
+}
+
+// ReadRawFileInput is the input schema for the read_raw_file tool.
+type ReadRawFileInput struct {
+	File string `json:"file" jsonschema:"Data file to read, e.g. todos.md or strategy.md"`
+}
+
+// ReadRawFileOutput is the output for the read_raw_file tool.
+type ReadRawFileOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// RawFileResult is the response payload for read_raw_file.
+type RawFileResult struct {
+	File      string `json:"file"`
+	Content   string `json:"content"`
+	SourceSHA string `json:"source_sha"`
+}
+
+// AppendToFileInput is the input schema for the append_to_file tool.
+type AppendToFileInput struct {
+	File    string `json:"file" jsonschema:"Data file to append to, e.g. strategy.md"`
+	Text    string `json:"text" jsonschema:"Markdown to append. May span several lines."`
+	Section string `json:"section,omitempty" jsonschema:"Heading to append under, without the # marks (e.g. Notes). Defaults to the end of the file."`
+
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from read_raw_file. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// AppendToFileOutput is the output for the append_to_file tool.
+type AppendToFileOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// PatchFileInput is the input schema for the patch_file tool.
+type PatchFileInput struct {
+	File    string `json:"file" jsonschema:"Data file to edit, e.g. todos.md"`
+	OldText string `json:"old_text" jsonschema:"Exact text to replace. Must appear exactly once in the file."`
+	NewText string `json:"new_text" jsonschema:"Replacement text. Empty deletes old_text."`
+
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from read_raw_file. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// PatchFileOutput is the output for the patch_file tool.
+type PatchFileOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// Register registers raw file tools with the MCP server.
+func (t *RawFileTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "read_raw_file",
+		Description: "Read a data file (todos.md, strategy.md, ...) as raw markdown, with its source_sha. Use when the structured tools don't cover what you need.",
+	}, t.readRawFile)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "append_to_file",
+		Description: "Append raw markdown to a data file, at the end or under a given section heading. Prefer the structured tools for todos, milestones, reminders and reading items.",
+	}, t.appendToFile)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "patch_file",
+		Description: "Replace one exact, unique piece of text in a data file with new text. Read the file with read_raw_file first and pass its source_sha.",
+	}, t.patchFile)
+}
+
+// resolveRawFile maps a file argument to a whitelisted data file name. The
+// .md extension is optional.
+func resolveRawFile(file string) (string, bool) {
+	name := strings.ToLower(strings.TrimSpace(file))
+	if !strings.HasSuffix(name, ".md") {
+		name += ".md"
+	}
+	for _, f := range storage.DataFiles {
+		if f == name {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+func invalidRawFileMessage(file string) string {
+	return fmt.Sprintf("Invalid file %q. Use: %s", file, strings.Join(storage.DataFiles, ", "))
+}
+
+func (t *RawFileTools) readRawFile(ctx context.Context, req *mcp.CallToolRequest, input ReadRawFileInput) (*mcp.CallToolResult, ReadRawFileOutput, error) {
+	name, ok := resolveRawFile(input.File)
+	if !ok {
+		return nil, ReadRawFileOutput{Success: false, Message: invalidRawFileMessage(input.File)}, nil
+	}
+
+	content, sha, err := t.storage.ReadFile(ctx, name)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ReadRawFileOutput{
+			Success: false,
+			Message: fmt.Sprintf("%s does not exist yet. Run init_data to create it.", name),
+		}, nil
+	}
+	if err != nil {
+		return nil, ReadRawFileOutput{}, fmt.Errorf("reading %s: %w", name, err)
+	}
+
+	jsonBytes, err := json.Marshal(RawFileResult{File: name, Content: content, SourceSHA: sha})
+	if err != nil {
+		return nil, ReadRawFileOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, ReadRawFileOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
+
+func (t *RawFileTools) appendToFile(ctx context.Context, req *mcp.CallToolRequest, input AppendToFileInput) (*mcp.CallToolResult, AppendToFileOutput, error) {
+	name, ok := resolveRawFile(input.File)
+	if !ok {
+		return nil, AppendToFileOutput{Success: false, Message: invalidRawFileMessage(input.File)}, nil
+	}
+	text := strings.Trim(input.Text, "\n")
+	if strings.TrimSpace(text) == "" {
+		return nil, AppendToFileOutput{
+			Success: false,
+			Message: "Text cannot be empty",
+		}, nil
+	}
+
+	content, sha, err := t.storage.ReadFile(ctx, name)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, AppendToFileOutput{}, fmt.Errorf("reading %s: %w", name, err)
+	}
+	if msg := entitystore.CheckUnchanged(name, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, AppendToFileOutput{Success: false, Message: msg}, nil
+	}
+
+	var newContent string
+	if section := strings.TrimSpace(input.Section); section != "" {
+		var found bool
+		if newContent, found = appendToSection(content, section, text); !found {
+			return nil, AppendToFileOutput{
+				Success: false,
+				Message: fmt.Sprintf("No section %q in %s", section, name),
+			}, nil
+		}
+	} else {
+		newContent = strings.TrimRight(content, "\n")
+		if newContent != "" {
+			newContent += "\n"
+		}
+		newContent += text + "\n"
+	}
+
+	if err := t.storage.WriteFile(ctx, name, newContent, sha, fmt.Sprintf("Append to %s: %s", name, truncate(firstLine(text), 50))); err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, AppendToFileOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, AppendToFileOutput{}, fmt.Errorf("writing %s: %w", name, err)
+	}
+
+	return nil, AppendToFileOutput{
+		Success: true,
+		Message: fmt.Sprintf("Appended %d lines to %s", strings.Count(text, "\n")+1, name),
+	}, nil
+}
+
+// appendToSection inserts text at the end of the section under the heading
+// whose title matches section (case-insensitive), before the next heading of
+// the same or a higher level. It reports whether the heading was found.
+func appendToSection(content, section, text string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	start, level := -1, 0
+	for i, line := range lines {
+		l, title := markdownHeading(line)
+		if l > 0 && strings.EqualFold(title, section) {
+			start, level = i, l
+			break
+		}
+	}
+	if start < 0 {
+		return content, false
+	}
+
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if l, _ := markdownHeading(lines[i]); l > 0 && l <= level {
+			end = i
+			break
+		}
+	}
+	// Insert after the section's last non-blank line, keeping its spacing
+	insert := end
+	for insert > start+1 && strings.TrimSpace(lines[insert-1]) == "" {
+		insert--
+	}
+
+	out := make([]string, 0, len(lines)+strings.Count(text, "\n")+1)
+	out = append(out, lines[:insert]...)
+	out = append(out, strings.Split(text, "\n")...)
+	out = append(out, lines[insert:]...)
+	return strings.Join(out, "\n"), true
+}
+
+// markdownHeading returns the level and title of an ATX heading line, or a
+// zero level if line isn't a heading.
+func markdownHeading(line string) (int, string) {
+	trimmed := strings.TrimSpace(line)
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(trimmed) || trimmed[level] != ' ' {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(trimmed[level:])
+}
+
+// firstLine returns s up to its first line break.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+func (t *RawFileTools) patchFile(ctx context.Context, req *mcp.CallToolRequest, input PatchFileInput) (*mcp.CallToolResult, PatchFileOutput, error) {
+	name, ok := resolveRawFile(input.File)
+	if !ok {
+		return nil, PatchFileOutput{Success: false, Message: invalidRawFileMessage(input.File)}, nil
+	}
+	if input.OldText == "" {
+		return nil, PatchFileOutput{
+			Success: false,
+			Message: "old_text is required",
+		}, nil
+	}
+	if input.OldText == input.NewText {
+		return nil, PatchFileOutput{
+			Success: false,
+			Message: "new_text is the same as old_text",
+		}, nil
+	}
+
+	content, sha, err := t.storage.ReadFile(ctx, name)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, PatchFileOutput{
+			Success: false,
+			Message: fmt.Sprintf("%s does not exist yet. Run init_data to create it.", name),
+		}, nil
+	}
+	if err != nil {
+		return nil, PatchFileOutput{}, fmt.Errorf("reading %s: %w", name, err)
+	}
+	if msg := entitystore.CheckUnchanged(name, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, PatchFileOutput{Success: false, Message: msg}, nil
+	}
+
+	switch n := strings.Count(content, input.OldText); n {
+	case 0:
+		return nil, PatchFileOutput{
+			Success: false,
+			Message: fmt.Sprintf("old_text not found in %s. Re-read the file with read_raw_file and copy the text exactly.", name),
+		}, nil
+	case 1:
+	default:
+		return nil, PatchFileOutput{
+			Success: false,
+			Message: fmt.Sprintf("old_text appears %d times in %s. Include more surrounding text so it matches once.", n, name),
+		}, nil
+	}
+
+	newContent := strings.Replace(content, input.OldText, input.NewText, 1)
+	if err := t.storage.WriteFile(ctx, name, newContent, sha, fmt.Sprintf("Patch %s", name)); err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, PatchFileOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, PatchFileOutput{}, fmt.Errorf("writing %s: %w", name, err)
+	}
+
+	return nil, PatchFileOutput{
+		Success: true,
+		Message: fmt.Sprintf("Patched %s", name),
+	}, nil
+}
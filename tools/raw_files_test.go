@@ -0,0 +1,159 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestReadRawFile(t *testing.T) {
+	files := newFileStorage(map[string]string{storage.StrategyFile: "## Current Phase\nLaunch\n"})
+	tools := NewRawFileTools(files)
+	ctx := context.Background()
+
+	_, out, err := tools.readRawFile(ctx, nil, ReadRawFileInput{File: "Strategy"})
+	if err != nil || !out.Success {
+		t.Fatalf("readRawFile() = %+v, %v", out, err)
+	}
+	var result RawFileResult
+	json.Unmarshal([]byte(out.Message), &result)
+	_, sha, _ := files.ReadFile(ctx, storage.StrategyFile)
+	if result.File != storage.StrategyFile || result.Content != "## Current Phase\nLaunch\n" || result.SourceSHA != sha {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	// Only data files are reachable
+	for _, file := range []string{"../secrets.md", ".env", "events.jsonl"} {
+		if _, out, _ := tools.readRawFile(ctx, nil, ReadRawFileInput{File: file}); out.Success {
+			t.Errorf("expected %q to be refused", file)
+		}
+	}
+
+	if _, out, _ := tools.readRawFile(ctx, nil, ReadRawFileInput{File: "journal.md"}); out.Success || !strings.Contains(out.Message, "init_data") {
+		t.Errorf("expected a missing file hint, got %+v", out)
+	}
+}
+
+func TestAppendToFile(t *testing.T) {
+	strategy := "## Current Phase\nLaunch\n\n## Notes\n- First note\n\n## Retro\n### Went well\n- Shipping\n\n### To improve\n- Scope\n"
+
+	tests := []struct {
+		name    string
+		input   AppendToFileInput
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "end of file",
+			input: AppendToFileInput{File: "strategy.md", Text: "## Custom\n- x"},
+			want:  strategy + "## Custom\n- x\n",
+		},
+		{
+			name:  "section before next heading",
+			input: AppendToFileInput{File: "strategy.md", Text: "- Second note", Section: "notes"},
+			want:  strings.Replace(strategy, "- First note\n", "- First note\n- Second note\n", 1),
+		},
+		{
+			name:  "section includes subsections",
+			input: AppendToFileInput{File: "strategy.md", Text: "- Follow up", Section: "Retro"},
+			want:  strategy + "- Follow up\n",
+		},
+		{
+			name:    "unknown section",
+			input:   AppendToFileInput{File: "strategy.md", Text: "- x", Section: "Ideas"},
+			wantErr: `No section "Ideas"`,
+		},
+		{
+			name:    "stale sha",
+			input:   AppendToFileInput{File: "strategy.md", Text: "- x", IfUnchangedSHA: "old"},
+			wantErr: "has changed since you read it",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := newFileStorage(map[string]string{storage.StrategyFile: strategy})
+			_, out, err := NewRawFileTools(files).appendToFile(context.Background(), nil, tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != "" {
+				if out.Success || !strings.Contains(out.Message, tt.wantErr) {
+					t.Errorf("expected failure containing %q, got %+v", tt.wantErr, out)
+				}
+				return
+			}
+			if !out.Success {
+				t.Fatalf("appendToFile() = %+v", out)
+			}
+			if fileContent(t, files, storage.StrategyFile) != tt.want {
+				t.Errorf("content =\n%s\nwant\n%s", fileContent(t, files, storage.StrategyFile), tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchFile(t *testing.T) {
+	todos := "# Active Todos\n\n## Normal\n- [ ] Write docs {id:aaaa1111}\n- [ ] Write tests {id:bbbb2222}\n"
+
+	tests := []struct {
+		name    string
+		input   PatchFileInput
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "unique match",
+			input: PatchFileInput{File: "todos", OldText: "Write docs", NewText: "Write user docs"},
+			want:  strings.Replace(todos, "Write docs", "Write user docs", 1),
+		},
+		{
+			name:  "delete",
+			input: PatchFileInput{File: "todos", OldText: "- [ ] Write tests {id:bbbb2222}\n"},
+			want:  "# Active Todos\n\n## Normal\n- [ ] Write docs {id:aaaa1111}\n",
+		},
+		{
+			name:    "ambiguous",
+			input:   PatchFileInput{File: "todos", OldText: "- [ ] Write", NewText: "- [x] Write"},
+			wantErr: "appears 2 times",
+		},
+		{
+			name:    "not found",
+			input:   PatchFileInput{File: "todos", OldText: "Write code", NewText: "x"},
+			wantErr: "not found",
+		},
+		{
+			name:    "no old text",
+			input:   PatchFileInput{File: "todos", NewText: "x"},
+			wantErr: "old_text is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := newFileStorage(map[string]string{storage.TodosFile: todos})
+			_, out, err := NewRawFileTools(files).patchFile(context.Background(), nil, tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != "" {
+				if out.Success || !strings.Contains(out.Message, tt.wantErr) {
+					t.Errorf("expected failure containing %q, got %+v", tt.wantErr, out)
+				}
+				if fileContent(t, files, storage.TodosFile) != todos {
+					t.Error("file changed on a failed patch")
+				}
+				return
+			}
+			if !out.Success {
+				t.Fatalf("patchFile() = %+v", out)
+			}
+			if fileContent(t, files, storage.TodosFile) != tt.want {
+				t.Errorf("content =\n%s\nwant\n%s", fileContent(t, files, storage.TodosFile), tt.want)
+			}
+		})
+	}
+}
@@ -5,56 +5,86 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/feeds"
 	"github.com/dang-w/momentum-mcp-server/storage"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // ReadingTools provides tools for managing the reading list.
 type ReadingTools struct {
-	storage storage.Storage
+	storage  storage.Storage
+	items    *entitystore.Store[storage.ReadingList, storage.ReadingItem]
+	clock    clock.Clock
+	estimate func(ctx context.Context, url string) (int, error)
 }
 
-// NewReadingTools creates a new ReadingTools instance.
-func NewReadingTools(s storage.Storage) *ReadingTools {
-	return &ReadingTools{storage: s}
+// NewReadingTools creates a new ReadingTools instance. A nil clock uses the system clock.
+func NewReadingTools(s storage.Storage, c clock.Clock) *ReadingTools {
+	return &ReadingTools{storage: s, items: entitystore.New(s, readingKind), clock: clock.Or(c), estimate: feeds.ReadingMinutes}
 }
 
 // AddToReadingListInput is the input schema for the add_to_reading_list tool.
 type AddToReadingListInput struct {
-	URL   string `json:"url" jsonschema:"The URL of the article to add"`
-	Notes string `json:"notes,omitempty" jsonschema:"Optional notes about why this is interesting"`
+	URL            string `json:"url" jsonschema:"The URL of the article to add"`
+	Notes          string `json:"notes,omitempty" jsonschema:"Optional notes about why this is interesting"`
+	Priority       string `json:"priority,omitempty" jsonschema:"Optional priority: next (read soon) or someday. Omit for neither."`
+	Category       string `json:"category,omitempty" jsonschema:"Optional category or topic (e.g. go, databases) for grouping the list"`
+	Minutes        int    `json:"minutes,omitempty" jsonschema:"Optional estimated reading time in minutes, used by plan_reading"`
+	Force          bool   `json:"force,omitempty" jsonschema:"Add the URL even if the same page (ignoring http vs https, tracking parameters and trailing slashes) is already listed"`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // AddToReadingListOutput is the output for the add_to_reading_list tool.
 type AddToReadingListOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
+	// ID and Item are the added item, set on success.
+	ID   string           `json:"id,omitempty"`
+	Item *ReadingListItem `json:"item,omitempty"`
+	// Duplicates lists the existing items for the same page when the add
+	// was refused as a duplicate.
+	Duplicates []ReadingListItem `json:"duplicates,omitempty"`
 }
 
 // MarkReadInput is the input schema for the mark_read tool.
 type MarkReadInput struct {
-	URL   string `json:"url,omitempty" jsonschema:"URL or partial URL to match against reading list items"`
-	ID    string `json:"id,omitempty" jsonschema:"ID of the reading list item to mark as read. More reliable than URL matching. Use list_reading_list to find IDs."`
-	Notes string `json:"notes,omitempty" jsonschema:"Optional notes about the article (will replace existing notes)"`
+	URL            string `json:"url,omitempty" jsonschema:"URL or partial URL to match against reading list items"`
+	ID             string `json:"id,omitempty" jsonschema:"ID of the reading list item to mark as read. More reliable than URL matching. Use list_reading_list to find IDs."`
+	Notes          string `json:"notes,omitempty" jsonschema:"Optional notes about the article (will replace existing notes)"`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // MarkReadOutput is the output for the mark_read tool.
 type MarkReadOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
+	// ID and Item are the item marked read, set on success.
+	ID   string           `json:"id,omitempty"`
+	Item *ReadingListItem `json:"item,omitempty"`
+	// Candidates are the items the text matched, best first, when it
+	// matched several without a clear winner.
+	Candidates []entitystore.Candidate `json:"candidates,omitempty"`
 }
 
 // ListReadingListInput is the input schema for the list_reading_list tool.
 type ListReadingListInput struct {
-	Status string `json:"status,omitempty" jsonschema:"Filter by status: unread, read, or all. Defaults to all."`
+	Status   string `json:"status,omitempty" jsonschema:"Filter by status: unread, read, or all. Defaults to all."`
+	Priority string `json:"priority,omitempty" jsonschema:"Filter by priority: next, someday, or none for items with neither"`
+	Category string `json:"category,omitempty" jsonschema:"Filter by category, or none for uncategorized items"`
+	Sort     string `json:"sort,omitempty" jsonschema:"Sort by priority (next first), added, alphabetical (by URL), or completed (read date). Prefix with - to reverse (e.g. -added for newest first). Defaults to file order."`
+	Query    string `json:"query,omitempty" jsonschema:"Only items whose URL or notes contain this text (case-insensitive)"`
+	// IncludeArchived adds the items archive_reading moved out of the list.
+	IncludeArchived bool `json:"include_archived,omitempty" jsonschema:"Also search the yearly archives of old read items (archive/reading-YYYY.md). Archived items are read, so this applies to the read and all statuses."`
 }
 
 // ListReadingListOutput is the output for the list_reading_list tool.
 type ListReadingListOutput struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
+	Success bool                   `json:"success"`
+	Message string                 `json:"message"`
+	Result  *ListReadingListResult `json:"result,omitempty"`
 }
 
 // ListReadingListResult is the response payload for list_reading_list.
@@ -62,12 +92,17 @@ type ListReadingListResult struct {
 	Items       []ReadingListItem `json:"items"`
 	TotalUnread int               `json:"total_unread"`
 	TotalRead   int               `json:"total_read"`
+	// TotalArchived counts the archived items searched, set when
+	// include_archived is.
+	TotalArchived int    `json:"total_archived,omitempty"`
+	SourceSHA     string `json:"source_sha"`
 }
 
 // DeleteReadingItemInput is the input schema for the delete_reading_item tool.
 type DeleteReadingItemInput struct {
-	ID      string `json:"id" jsonschema:"ID of the reading list item to delete. Use list_reading_list to find IDs."`
-	Confirm bool   `json:"confirm" jsonschema:"Must be set to true to confirm deletion."`
+	ID             string `json:"id" jsonschema:"ID of the reading list item to delete. Use list_reading_list to find IDs."`
+	Confirm        bool   `json:"confirm" jsonschema:"Must be set to true to confirm deletion."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // DeleteReadingItemOutput is the output for the delete_reading_item tool.
@@ -78,21 +113,28 @@ type DeleteReadingItemOutput struct {
 
 // EditReadingItemInput is the input schema for the edit_reading_item tool.
 type EditReadingItemInput struct {
-	ID    string `json:"id" jsonschema:"ID of the reading list item to edit. Use list_reading_list to find IDs."`
-	Notes string `json:"notes,omitempty" jsonschema:"New notes. Pass empty string to clear notes."`
+	ID             string  `json:"id" jsonschema:"ID of the reading list item to edit. Use list_reading_list to find IDs."`
+	Notes          *string `json:"notes,omitempty" jsonschema:"New notes. If omitted, keeps existing notes. Pass empty string to clear notes."`
+	Priority       string  `json:"priority,omitempty" jsonschema:"New priority: next or someday. If omitted, keeps existing priority. Pass 'none' to clear it."`
+	Category       string  `json:"category,omitempty" jsonschema:"New category. If omitted, keeps existing category. Pass 'none' to clear it."`
+	Minutes        *int    `json:"minutes,omitempty" jsonschema:"New estimated reading time in minutes. If omitted, keeps the existing estimate. Pass 0 to clear it."`
+	IfUnchangedSHA string  `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // EditReadingItemOutput is the output for the edit_reading_item tool.
 type EditReadingItemOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
+	// ID and Item are the edited item, set on success.
+	ID   string           `json:"id,omitempty"`
+	Item *ReadingListItem `json:"item,omitempty"`
 }
 
 // Register registers reading list tools with the MCP server.
 func (t *ReadingTools) Register(server *mcp.Server) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "add_to_reading_list",
-		Description: "Add a URL to the reading list",
+		Description: "Add a URL to the reading list. Refused with the existing entry if the same page is already listed, unless force is set.",
 	}, t.addToReadingList)
 
 	mcp.AddTool(server, &mcp.Tool{
@@ -102,18 +144,38 @@ func (t *ReadingTools) Register(server *mcp.Server) {
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "list_reading_list",
-		Description: "List reading list items with optional filtering by read status",
+		Description: "List reading list items with optional filtering by read status, priority, category and text, optionally including archived read items",
 	}, t.listReadingList)
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "edit_reading_item",
-		Description: "Edit notes on a reading list item",
+		Description: "Edit the notes, priority, category or estimated reading time of a reading list item",
 	}, t.editReadingItem)
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "dedupe_reading_list",
+		Description: "Canonicalize reading list URLs (https, lowercase host, no utm_* or other tracking parameters) and merge items for the same page, keeping the earliest added date and all notes. Use dry_run to see what would change first.",
+	}, t.dedupeReadingList)
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "delete_reading_item",
 		Description: "Permanently delete a reading list item",
 	}, t.deleteReadingItem)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "plan_reading",
+		Description: "Propose which queued reading list items to read this week within a minutes budget (next priority first, then oldest), and forecast when the queue clears at the current pace. Estimated reading times come from each item's minutes, or can be fetched from the pages.",
+	}, t.planReading)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "archive_reading",
+		Description: "Move read items read more than older_than_months ago (default 6) out of the reading list into yearly archive files (archive/reading-YYYY.md). list_reading_list can still search them with include_archived.",
+	}, t.archiveReading)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "import_reading_list",
+		Description: "Import a Pocket export (HTML or CSV) or Instapaper CSV into the reading list, skipping URLs already present, in one commit per batch",
+	}, t.importReadingList)
 }
 
 func (t *ReadingTools) addToReadingList(ctx context.Context, req *mcp.CallToolRequest, input AddToReadingListInput) (*mcp.CallToolResult, AddToReadingListOutput, error) {
@@ -124,58 +186,61 @@ func (t *ReadingTools) addToReadingList(ctx context.Context, req *mcp.CallToolRe
 		}, nil
 	}
 
-	// Read current reading list
-	content, sha, err := t.storage.ReadFile(ctx, "reading-list.md")
-	if err != nil {
-		return nil, AddToReadingListOutput{}, fmt.Errorf("reading reading-list.md: %w", err)
-	}
-
-	rl, err := storage.ParseReadingList(content)
-	if err != nil {
-		return nil, AddToReadingListOutput{}, fmt.Errorf("parsing reading list: %w", err)
-	}
-
-	// Check for duplicates
-	url := strings.TrimSpace(input.URL)
-	for _, item := range rl.ToRead {
-		if item.URL == url {
+	priority := ""
+	if strings.TrimSpace(input.Priority) != "" {
+		p, ok := parseReadingPriority(input.Priority)
+		if !ok {
 			return nil, AddToReadingListOutput{
 				Success: false,
-				Message: fmt.Sprintf("URL already in reading list: %s", url),
-			}, nil
-		}
-	}
-	for _, item := range rl.Read {
-		if item.URL == url {
-			return nil, AddToReadingListOutput{
-				Success: false,
-				Message: fmt.Sprintf("URL already marked as read: %s", url),
+				Message: fmt.Sprintf("Invalid priority %q. Use: next or someday", input.Priority),
 			}, nil
 		}
+		priority = p
 	}
 
-	// Add the new item
-	newItem := storage.ReadingItem{
-		ID:    storage.GenerateID(),
-		URL:   url,
-		Notes: strings.TrimSpace(input.Notes),
-		Added: time.Now().UTC().Truncate(24 * time.Hour),
+	if input.Minutes < 0 {
+		return nil, AddToReadingListOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid minutes %d. Use a positive number of minutes.", input.Minutes),
+		}, nil
 	}
-	rl.ToRead = append(rl.ToRead, newItem)
 
-	// Serialize and write back
-	newContent := storage.SerializeReadingList(rl)
-	if err := t.storage.WriteFile(ctx, "reading-list.md", newContent, sha, "Add to reading list"); err != nil {
-		if err == storage.ErrConflict {
-			return nil, AddToReadingListOutput{
-				Success: false,
-				Message: "File was modified by another process. Please try again.",
-			}, nil
+	url := strings.TrimSpace(input.URL)
+	newItem := storage.ReadingItem{
+		URL:      url,
+		Notes:    strings.TrimSpace(input.Notes),
+		Priority: priority,
+		Category: storage.NormalizeProject(input.Category),
+		Minutes:  input.Minutes,
+		Added:    clock.Today(t.clock),
+	}
+	var duplicates []ReadingListItem
+	newItem, err := t.items.Add(ctx, input.IfUnchangedSHA, newItem, "Add to reading list", func(rl *storage.ReadingList) error {
+		// Check for duplicates, ignoring tracking parameters and trailing slashes
+		if input.Force {
+			return nil
+		}
+		matches := append(sameURLItems(rl.ToRead, url), sameURLItems(rl.Read, url)...)
+		if len(matches) == 0 {
+			return nil
+		}
+		for _, m := range matches {
+			duplicates = append(duplicates, readingToItem(m))
+		}
+		if matches[0].Read {
+			return &entitystore.Error{Message: fmt.Sprintf("URL already marked as read: %s. Set force to add it anyway.", matches[0].URL)}
 		}
-		return nil, AddToReadingListOutput{}, fmt.Errorf("writing reading-list.md: %w", err)
+		return &entitystore.Error{Message: fmt.Sprintf("URL already in reading list: %s. Set force to add it anyway.", matches[0].URL)}
+	})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, AddToReadingListOutput{Success: false, Message: msg, Duplicates: duplicates}, nil
+	}
+	if err != nil {
+		return nil, AddToReadingListOutput{}, err
 	}
 
-	itemJSON, err := json.Marshal(readingToItem(newItem))
+	item := readingToItem(newItem)
+	itemJSON, err := json.Marshal(item)
 	if err != nil {
 		return nil, AddToReadingListOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
@@ -183,6 +248,8 @@ func (t *ReadingTools) addToReadingList(ctx context.Context, req *mcp.CallToolRe
 	return nil, AddToReadingListOutput{
 		Success: true,
 		Message: string(itemJSON),
+		ID:      item.ID,
+		Item:    &item,
 	}, nil
 }
 
@@ -194,86 +261,25 @@ func (t *ReadingTools) markRead(ctx context.Context, req *mcp.CallToolRequest, i
 		}, nil
 	}
 
-	// Read current reading list
-	content, sha, err := t.storage.ReadFile(ctx, "reading-list.md")
-	if err != nil {
-		return nil, MarkReadOutput{}, fmt.Errorf("reading reading-list.md: %w", err)
-	}
-
-	rl, err := storage.ParseReadingList(content)
-	if err != nil {
-		return nil, MarkReadOutput{}, fmt.Errorf("parsing reading list: %w", err)
-	}
-
-	// Find matching items — prefer ID match if provided
-	var matches []int
-	if id := strings.TrimSpace(input.ID); id != "" {
-		for i, item := range rl.ToRead {
-			if item.ID == id {
-				matches = append(matches, i)
-				break
-			}
-		}
-		if len(matches) == 0 {
-			return nil, MarkReadOutput{
-				Success: false,
-				Message: fmt.Sprintf("No unread item found with id %q", input.ID),
-			}, nil
-		}
-	} else {
-		searchText := strings.ToLower(strings.TrimSpace(input.URL))
-		for i, item := range rl.ToRead {
-			if strings.Contains(strings.ToLower(item.URL), searchText) {
-				matches = append(matches, i)
-			}
-		}
-
-		if len(matches) == 0 {
-			return nil, MarkReadOutput{
-				Success: false,
-				Message: fmt.Sprintf("No unread item found matching %q", input.URL),
-			}, nil
-		}
-
-		if len(matches) > 1 {
-			var matchURLs []string
-			for _, idx := range matches {
-				matchURLs = append(matchURLs, fmt.Sprintf("- [%s] %s", rl.ToRead[idx].ID, rl.ToRead[idx].URL))
-			}
-			return nil, MarkReadOutput{
-				Success: false,
-				Message: fmt.Sprintf("Multiple items match %q. Please be more specific or use an id:\n%s", input.URL, strings.Join(matchURLs, "\n")),
-			}, nil
+	// Mark as read and move to the front of the read list
+	item, err := t.items.Move(ctx, entitystore.Open, entitystore.Ref{ID: input.ID, Text: input.URL}, input.IfUnchangedSHA, func(item *storage.ReadingItem) string {
+		item.Read = true
+		now := clock.Today(t.clock)
+		item.ReadAt = &now
+		if input.Notes != "" {
+			item.Notes = strings.TrimSpace(input.Notes)
 		}
+		return "Mark as read"
+	})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, MarkReadOutput{Success: false, Message: msg, Candidates: entitystore.Candidates(err)}, nil
 	}
-
-	// Mark as read
-	idx := matches[0]
-	item := rl.ToRead[idx]
-	item.Read = true
-	now := time.Now().UTC().Truncate(24 * time.Hour)
-	item.ReadAt = &now
-	if input.Notes != "" {
-		item.Notes = strings.TrimSpace(input.Notes)
-	}
-
-	// Move from to-read to read
-	rl.ToRead = append(rl.ToRead[:idx], rl.ToRead[idx+1:]...)
-	rl.Read = append([]storage.ReadingItem{item}, rl.Read...) // Add to front
-
-	// Serialize and write back
-	newContent := storage.SerializeReadingList(rl)
-	if err := t.storage.WriteFile(ctx, "reading-list.md", newContent, sha, "Mark as read"); err != nil {
-		if err == storage.ErrConflict {
-			return nil, MarkReadOutput{
-				Success: false,
-				Message: "File was modified by another process. Please try again.",
-			}, nil
-		}
-		return nil, MarkReadOutput{}, fmt.Errorf("writing reading-list.md: %w", err)
+	if err != nil {
+		return nil, MarkReadOutput{}, err
 	}
 
-	itemJSON, err := json.Marshal(readingToItem(item))
+	readItem := readingToItem(item)
+	itemJSON, err := json.Marshal(readItem)
 	if err != nil {
 		return nil, MarkReadOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
@@ -281,16 +287,34 @@ func (t *ReadingTools) markRead(ctx context.Context, req *mcp.CallToolRequest, i
 	return nil, MarkReadOutput{
 		Success: true,
 		Message: string(itemJSON),
+		ID:      readItem.ID,
+		Item:    &readItem,
 	}, nil
 }
 
 func (t *ReadingTools) listReadingList(ctx context.Context, req *mcp.CallToolRequest, input ListReadingListInput) (*mcp.CallToolResult, ListReadingListOutput, error) {
-	content, _, err := t.storage.ReadFile(ctx, "reading-list.md")
+	sortKey, sortDesc, msg := parseSort(input.Sort, sortPriority, sortAdded, sortAlphabetical, sortCompleted)
+	if msg != "" {
+		return nil, ListReadingListOutput{Success: false, Message: msg}, nil
+	}
+
+	filterPriority := strings.TrimSpace(input.Priority) != ""
+	priority, ok := parseReadingPriority(input.Priority)
+	if filterPriority && !ok {
+		return nil, ListReadingListOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid priority %q. Use: next, someday, or none", input.Priority),
+		}, nil
+	}
+	filterCategory := strings.TrimSpace(input.Category) != ""
+	category := projectOrNone(input.Category)
+
+	content, sha, err := t.storage.ReadFile(ctx, storage.ReadingListFile)
 	if err != nil {
 		return nil, ListReadingListOutput{}, fmt.Errorf("reading reading-list.md: %w", err)
 	}
 
-	rl, err := storage.ParseReadingList(content)
+	rl, err := parseReadingList(ctx, content)
 	if err != nil {
 		return nil, ListReadingListOutput{}, fmt.Errorf("parsing reading list: %w", err)
 	}
@@ -316,25 +340,55 @@ func (t *ReadingTools) listReadingList(ctx context.Context, req *mcp.CallToolReq
 		}, nil
 	}
 
-	readingItems := make([]ReadingListItem, len(items))
-	for i, item := range items {
-		readingItems[i] = readingToItem(item)
+	var archived []archivedReading
+	if input.IncludeArchived {
+		if archived, err = readArchivedReading(ctx, t.storage, clock.Today(t.clock)); err != nil {
+			return nil, ListReadingListOutput{}, err
+		}
 	}
 
-	result := ListReadingListResult{
-		Items:       readingItems,
-		TotalUnread: len(rl.ToRead),
-		TotalRead:   len(rl.Read),
+	query := strings.ToLower(strings.TrimSpace(input.Query))
+	matches := func(item storage.ReadingItem) bool {
+		if filterPriority && item.Priority != priority {
+			return false
+		}
+		if filterCategory && item.Category != category {
+			return false
+		}
+		return query == "" ||
+			strings.Contains(strings.ToLower(item.URL), query) ||
+			strings.Contains(strings.ToLower(item.Notes), query)
+	}
+	readingItems := []ReadingListItem{}
+	for _, item := range items {
+		if matches(item) {
+			readingItems = append(readingItems, readingToItem(item))
+		}
+	}
+	if status != "unread" {
+		for _, a := range archived {
+			if matches(a.item) {
+				item := readingToItem(a.item)
+				item.Archive = a.path
+				readingItems = append(readingItems, item)
+			}
+		}
 	}
+	sortReadingItems(readingItems, sortKey, sortDesc)
 
-	jsonBytes, err := json.Marshal(result)
-	if err != nil {
-		return nil, ListReadingListOutput{}, fmt.Errorf("marshaling response: %w", err)
+	result := ListReadingListResult{
+		SourceSHA:     sha,
+		Items:         readingItems,
+		TotalUnread:   len(rl.ToRead),
+		TotalRead:     len(rl.Read),
+		TotalArchived: len(archived),
 	}
 
-	return nil, ListReadingListOutput{
+	text := result.text()
+	return textResult(text), ListReadingListOutput{
 		Success: true,
-		Message: string(jsonBytes),
+		Message: text,
+		Result:  &result,
 	}, nil
 }
 
@@ -346,80 +400,70 @@ func (t *ReadingTools) editReadingItem(ctx context.Context, req *mcp.CallToolReq
 		}, nil
 	}
 
-	// Read current reading list
-	content, sha, err := t.storage.ReadFile(ctx, "reading-list.md")
-	if err != nil {
-		return nil, EditReadingItemOutput{}, fmt.Errorf("reading reading-list.md: %w", err)
+	if input.Notes == nil && strings.TrimSpace(input.Priority) == "" && strings.TrimSpace(input.Category) == "" && input.Minutes == nil {
+		return nil, EditReadingItemOutput{
+			Success: false,
+			Message: "At least one of notes, priority, category, or minutes must be provided",
+		}, nil
 	}
-
-	rl, err := storage.ParseReadingList(content)
-	if err != nil {
-		return nil, EditReadingItemOutput{}, fmt.Errorf("parsing reading list: %w", err)
+	if input.Minutes != nil && *input.Minutes < 0 {
+		return nil, EditReadingItemOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid minutes %d. Use a positive number of minutes, or 0 to clear the estimate.", *input.Minutes),
+		}, nil
 	}
 
-	// Search both lists by ID
-	id := strings.TrimSpace(input.ID)
-
-	for i, item := range rl.ToRead {
-		if item.ID == id {
-			rl.ToRead[i].Notes = strings.TrimSpace(input.Notes)
-
-			newContent := storage.SerializeReadingList(rl)
-			if err := t.storage.WriteFile(ctx, "reading-list.md", newContent, sha, "Edit reading list item"); err != nil {
-				if err == storage.ErrConflict {
-					return nil, EditReadingItemOutput{
-						Success: false,
-						Message: "File was modified by another process. Please try again.",
-					}, nil
-				}
-				return nil, EditReadingItemOutput{}, fmt.Errorf("writing reading-list.md: %w", err)
-			}
-
-			itemJSON, err := json.Marshal(readingToItem(rl.ToRead[i]))
-			if err != nil {
-				return nil, EditReadingItemOutput{}, fmt.Errorf("marshaling response: %w", err)
-			}
-
+	if strings.TrimSpace(input.Priority) != "" {
+		if _, ok := parseReadingPriority(input.Priority); !ok {
 			return nil, EditReadingItemOutput{
-				Success: true,
-				Message: string(itemJSON),
+				Success: false,
+				Message: fmt.Sprintf("Invalid priority %q. Use: next, someday, or none", input.Priority),
 			}, nil
 		}
 	}
 
-	for i, item := range rl.Read {
-		if item.ID == id {
-			rl.Read[i].Notes = strings.TrimSpace(input.Notes)
-
-			newContent := storage.SerializeReadingList(rl)
-			if err := t.storage.WriteFile(ctx, "reading-list.md", newContent, sha, "Edit reading list item"); err != nil {
-				if err == storage.ErrConflict {
-					return nil, EditReadingItemOutput{
-						Success: false,
-						Message: "File was modified by another process. Please try again.",
-					}, nil
-				}
-				return nil, EditReadingItemOutput{}, fmt.Errorf("writing reading-list.md: %w", err)
-			}
-
-			itemJSON, err := json.Marshal(readingToItem(rl.Read[i]))
-			if err != nil {
-				return nil, EditReadingItemOutput{}, fmt.Errorf("marshaling response: %w", err)
-			}
+	edited, err := t.items.Update(ctx, entitystore.Both, entitystore.Ref{ID: input.ID}, input.IfUnchangedSHA, func(item *storage.ReadingItem) string {
+		applyReadingEdit(item, input)
+		return "Edit reading list item"
+	})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, EditReadingItemOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, EditReadingItemOutput{}, err
+	}
 
-			return nil, EditReadingItemOutput{
-				Success: true,
-				Message: string(itemJSON),
-			}, nil
-		}
+	item := readingToItem(edited)
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		return nil, EditReadingItemOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
 
 	return nil, EditReadingItemOutput{
-		Success: false,
-		Message: fmt.Sprintf("No reading list item found with id %q", id),
+		Success: true,
+		Message: string(itemJSON),
+		ID:      item.ID,
+		Item:    &item,
 	}, nil
 }
 
+// applyReadingEdit applies the fields set in input to item. The priority and
+// minutes have already been validated.
+func applyReadingEdit(item *storage.ReadingItem, input EditReadingItemInput) {
+	if input.Notes != nil {
+		item.Notes = strings.TrimSpace(*input.Notes)
+	}
+	if strings.TrimSpace(input.Priority) != "" {
+		item.Priority, _ = parseReadingPriority(input.Priority)
+	}
+	if strings.TrimSpace(input.Category) != "" {
+		item.Category = projectOrNone(input.Category)
+	}
+	if input.Minutes != nil {
+		item.Minutes = *input.Minutes
+	}
+}
+
 func (t *ReadingTools) deleteReadingItem(ctx context.Context, req *mcp.CallToolRequest, input DeleteReadingItemInput) (*mcp.CallToolResult, DeleteReadingItemOutput, error) {
 	if strings.TrimSpace(input.ID) == "" {
 		return nil, DeleteReadingItemOutput{
@@ -435,79 +479,23 @@ func (t *ReadingTools) deleteReadingItem(ctx context.Context, req *mcp.CallToolR
 		}, nil
 	}
 
-	// Read current reading list
-	content, sha, err := t.storage.ReadFile(ctx, "reading-list.md")
-	if err != nil {
-		return nil, DeleteReadingItemOutput{}, fmt.Errorf("reading reading-list.md: %w", err)
+	deleted, err := t.items.Delete(ctx, entitystore.Ref{ID: input.ID}, input.IfUnchangedSHA, func(*storage.ReadingItem) string {
+		return "Delete reading list item"
+	})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, DeleteReadingItemOutput{Success: false, Message: msg}, nil
 	}
-
-	rl, err := storage.ParseReadingList(content)
 	if err != nil {
-		return nil, DeleteReadingItemOutput{}, fmt.Errorf("parsing reading list: %w", err)
-	}
-
-	id := strings.TrimSpace(input.ID)
-
-	// Search unread list
-	for i, item := range rl.ToRead {
-		if item.ID == id {
-			deleted := item
-			rl.ToRead = append(rl.ToRead[:i], rl.ToRead[i+1:]...)
-
-			newContent := storage.SerializeReadingList(rl)
-			if err := t.storage.WriteFile(ctx, "reading-list.md", newContent, sha, "Delete reading list item"); err != nil {
-				if err == storage.ErrConflict {
-					return nil, DeleteReadingItemOutput{
-						Success: false,
-						Message: "File was modified by another process. Please try again.",
-					}, nil
-				}
-				return nil, DeleteReadingItemOutput{}, fmt.Errorf("writing reading-list.md: %w", err)
-			}
-
-			itemJSON, err := json.Marshal(readingToItem(deleted))
-			if err != nil {
-				return nil, DeleteReadingItemOutput{}, fmt.Errorf("marshaling response: %w", err)
-			}
-
-			return nil, DeleteReadingItemOutput{
-				Success: true,
-				Message: string(itemJSON),
-			}, nil
-		}
+		return nil, DeleteReadingItemOutput{}, err
 	}
 
-	// Search read list
-	for i, item := range rl.Read {
-		if item.ID == id {
-			deleted := item
-			rl.Read = append(rl.Read[:i], rl.Read[i+1:]...)
-
-			newContent := storage.SerializeReadingList(rl)
-			if err := t.storage.WriteFile(ctx, "reading-list.md", newContent, sha, "Delete reading list item"); err != nil {
-				if err == storage.ErrConflict {
-					return nil, DeleteReadingItemOutput{
-						Success: false,
-						Message: "File was modified by another process. Please try again.",
-					}, nil
-				}
-				return nil, DeleteReadingItemOutput{}, fmt.Errorf("writing reading-list.md: %w", err)
-			}
-
-			itemJSON, err := json.Marshal(readingToItem(deleted))
-			if err != nil {
-				return nil, DeleteReadingItemOutput{}, fmt.Errorf("marshaling response: %w", err)
-			}
-
-			return nil, DeleteReadingItemOutput{
-				Success: true,
-				Message: string(itemJSON),
-			}, nil
-		}
+	itemJSON, err := json.Marshal(readingToItem(deleted))
+	if err != nil {
+		return nil, DeleteReadingItemOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
 
 	return nil, DeleteReadingItemOutput{
-		Success: false,
-		Message: fmt.Sprintf("No reading list item found with id %q", id),
+		Success: true,
+		Message: string(itemJSON),
 	}, nil
 }
@@ -0,0 +1,190 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// DefaultReadingArchiveMonths is how long read items stay in the reading
+	// list before archive_reading moves them out.
+	DefaultReadingArchiveMonths = 6
+	// readingArchiveYears is how many years back list_reading_list looks
+	// for archives.
+	readingArchiveYears = 10
+)
+
+// ArchiveReadingInput is the input schema for the archive_reading tool.
+type ArchiveReadingInput struct {
+	OlderThanMonths int  `json:"older_than_months,omitempty" jsonschema:"Archive items read more than this many months ago. Defaults to 6."`
+	DryRun          bool `json:"dry_run,omitempty" jsonschema:"Report what would be archived without changing anything"`
+}
+
+// ArchiveReadingOutput is the output for the archive_reading tool.
+type ArchiveReadingOutput struct {
+	Success bool                  `json:"success"`
+	Message string                `json:"message"`
+	Result  *ArchiveReadingResult `json:"result,omitempty"`
+}
+
+// ArchiveReadingResult is the response payload for archive_reading.
+type ArchiveReadingResult struct {
+	Archived int               `json:"archived"`
+	Files    []string          `json:"files"`
+	Items    []ReadingListItem `json:"items"`
+	DryRun   bool              `json:"dry_run,omitempty"`
+}
+
+func (t *ReadingTools) archiveReading(ctx context.Context, req *mcp.CallToolRequest, input ArchiveReadingInput) (*mcp.CallToolResult, ArchiveReadingOutput, error) {
+	months := input.OlderThanMonths
+	if months == 0 {
+		months = DefaultReadingArchiveMonths
+	}
+	if months < 0 {
+		return nil, ArchiveReadingOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid older_than_months %d. Use a positive number of months.", months),
+		}, nil
+	}
+
+	rl, sha, err := t.items.Load(ctx, "")
+	if err != nil {
+		return nil, ArchiveReadingOutput{}, err
+	}
+
+	// Group the old read items by the year they were read
+	cutoff := clock.Today(t.clock).AddDate(0, -months, 0)
+	byYear := make(map[int][]storage.ReadingItem)
+	var kept []storage.ReadingItem
+	result := ArchiveReadingResult{Files: []string{}, Items: []ReadingListItem{}, DryRun: input.DryRun}
+	for _, item := range rl.Read {
+		if item.ReadAt == nil || !item.ReadAt.Before(cutoff) {
+			kept = append(kept, item)
+			continue
+		}
+		byYear[item.ReadAt.Year()] = append(byYear[item.ReadAt.Year()], item)
+		result.Items = append(result.Items, readingToItem(item))
+	}
+	result.Archived = len(result.Items)
+	years := make([]int, 0, len(byYear))
+	for year := range byYear {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	for _, year := range years {
+		result.Files = append(result.Files, storage.ReadingArchivePath(year))
+	}
+
+	if result.Archived > 0 && !input.DryRun {
+		var changes []storage.FileChange
+		for _, year := range years {
+			change, err := t.archiveChange(ctx, year, byYear[year])
+			if err != nil {
+				return nil, ArchiveReadingOutput{}, err
+			}
+			changes = append(changes, change)
+		}
+		rl.Read = kept
+		changes = append(changes, storage.FileChange{Path: storage.ReadingListFile, Content: storage.SerializeReadingList(rl), SHA: sha})
+
+		message := fmt.Sprintf("Archive %s read before %s", plural(result.Archived, "reading list item"), formatDate(cutoff))
+		if err := storage.WriteFiles(ctx, t.storage, changes, message); err != nil {
+			if msg, ok := entitystore.WriteMessage(err); ok {
+				return nil, ArchiveReadingOutput{Success: false, Message: msg}, nil
+			}
+			return nil, ArchiveReadingOutput{}, fmt.Errorf("writing the archive: %w", err)
+		}
+	}
+
+	text := result.text()
+	return textResult(text), ArchiveReadingOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+// archiveChange adds items to year's archive, skipping any already there.
+func (t *ReadingTools) archiveChange(ctx context.Context, year int, items []storage.ReadingItem) (storage.FileChange, error) {
+	path := storage.ReadingArchivePath(year)
+	content, sha, err := t.storage.ReadFile(ctx, path)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return storage.FileChange{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	archived := &storage.ReadingList{}
+	if err == nil {
+		if archived, err = storage.ParseReadingList(content); err != nil {
+			return storage.FileChange{}, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, item := range archived.Read {
+		seen[item.ID] = true
+	}
+	for _, item := range items {
+		if !seen[item.ID] {
+			archived.Read = append(archived.Read, item)
+		}
+	}
+	return storage.FileChange{Path: path, Content: storage.SerializeReadingArchive(year, archived.Read), SHA: sha}, nil
+}
+
+// archivedReading is an item in a reading archive.
+type archivedReading struct {
+	path string
+	item storage.ReadingItem
+}
+
+// readArchivedReading reads the reading archives of the last
+// readingArchiveYears years, newest first.
+func readArchivedReading(ctx context.Context, s storage.Storage, today time.Time) ([]archivedReading, error) {
+	var paths []string
+	for year := today.Year(); year > today.Year()-readingArchiveYears; year-- {
+		paths = append(paths, storage.ReadingArchivePath(year))
+	}
+	files := storage.ReadFiles(ctx, s, paths...)
+
+	var items []archivedReading
+	for _, path := range paths {
+		r := files[path]
+		if errors.Is(r.Err, storage.ErrNotFound) {
+			continue
+		}
+		if r.Err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, r.Err)
+		}
+		archived, err := storage.ParseReadingList(r.Content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		for _, item := range archived.Read {
+			items = append(items, archivedReading{path: path, item: item})
+		}
+	}
+	return items, nil
+}
+
+func (r ArchiveReadingResult) text() string {
+	var sb strings.Builder
+	switch {
+	case r.Archived == 0:
+		sb.WriteString("Nothing to archive")
+	case r.DryRun:
+		fmt.Fprintf(&sb, "Would archive %s to %s:\n", plural(r.Archived, "read item"), strings.Join(r.Files, ", "))
+	default:
+		fmt.Fprintf(&sb, "Archived %s to %s:\n", plural(r.Archived, "read item"), strings.Join(r.Files, ", "))
+	}
+	for _, item := range r.Items {
+		sb.WriteString(item.text() + "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestArchiveReading(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.ReadingListFile: "# Reading List\n\n## To Read\n" +
+			"- [ ] https://queued.example — Added: 2025-01-01 {id:aaaa1111}\n\n" +
+			"## Read\n" +
+			"- [x] https://recent.example — Read: 2026-01-20 {id:bbbb2222}\n" +
+			"- [x] https://old.example/go — Read: 2025-06-01 — Notes: generics {id:cccc3333}\n" +
+			"- [x] https://older.example — Read: 2024-11-15 {id:dddd4444}\n",
+		storage.ReadingArchivePath(2024): "# Reading Archive 2024\n\n## Read\n" +
+			"- [x] https://ancient.example — Read: 2024-02-01 {id:eeee5555}\n",
+	})
+	rt := NewReadingTools(files, clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)))
+	ctx := context.Background()
+
+	_, out, err := rt.archiveReading(ctx, nil, ArchiveReadingInput{DryRun: true})
+	if err != nil || !out.Success || out.Result.Archived != 2 {
+		t.Fatalf("archiveReading(dry run) = %+v, %v", out, err)
+	}
+	if _, _, err := files.ReadFile(ctx, storage.ReadingArchivePath(2025)); err == nil {
+		t.Error("dry run wrote an archive")
+	}
+
+	_, out, err = rt.archiveReading(ctx, nil, ArchiveReadingInput{})
+	if err != nil || !out.Success {
+		t.Fatalf("archiveReading() = %+v, %v", out, err)
+	}
+	if got := strings.Join(out.Result.Files, ","); got != "archive/reading-2024.md,archive/reading-2025.md" {
+		t.Errorf("Files = %s", got)
+	}
+	rl, _ := storage.ParseReadingList(fileContent(t, files, storage.ReadingListFile))
+	if len(rl.ToRead) != 1 || len(rl.Read) != 1 || rl.Read[0].ID != "bbbb2222" {
+		t.Errorf("reading list after archiving = %+v", rl)
+	}
+	archive2024, _ := storage.ParseReadingList(fileContent(t, files, storage.ReadingArchivePath(2024)))
+	if len(archive2024.Read) != 2 || archive2024.Read[1].ID != "dddd4444" {
+		t.Errorf("2024 archive = %+v", archive2024)
+	}
+
+	// Archived items are searchable
+	_, list, err := rt.listReadingList(ctx, nil, ListReadingListInput{Query: "GENERICS", IncludeArchived: true})
+	if err != nil || !list.Success {
+		t.Fatalf("listReadingList() = %+v, %v", list, err)
+	}
+	if len(list.Result.Items) != 1 || list.Result.Items[0].Archive != "archive/reading-2025.md" || list.Result.TotalArchived != 3 {
+		t.Errorf("archived search = %+v", list.Result)
+	}
+	_, list, _ = rt.listReadingList(ctx, nil, ListReadingListInput{Status: "unread", IncludeArchived: true})
+	if len(list.Result.Items) != 1 {
+		t.Errorf("unread list included archived items: %+v", list.Result.Items)
+	}
+
+	_, out, _ = rt.archiveReading(ctx, nil, ArchiveReadingInput{})
+	if out.Result.Archived != 0 || out.Message != "Nothing to archive" {
+		t.Errorf("second archiveReading() = %+v", out)
+	}
+}
@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DedupeReadingListInput is the input schema for the dedupe_reading_list tool.
+type DedupeReadingListInput struct {
+	DryRun         bool   `json:"dry_run,omitempty" jsonschema:"Set to true to report what would change without writing anything"`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// DedupeReadingListOutput is the output for the dedupe_reading_list tool.
+type DedupeReadingListOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// DedupeReadingListResult is the response payload for dedupe_reading_list.
+type DedupeReadingListResult struct {
+	DryRun        bool                `json:"dry_run"`
+	Canonicalized []CanonicalizedURL  `json:"canonicalized"`
+	Merged        []MergedReadingItem `json:"merged"`
+}
+
+// CanonicalizedURL is a URL rewritten to its canonical form.
+type CanonicalizedURL struct {
+	ID   string `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// MergedReadingItem is a group of duplicates merged into one item.
+type MergedReadingItem struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Removed lists the IDs of the duplicates folded into ID.
+	Removed []string `json:"removed"`
+}
+
+func (t *ReadingTools) dedupeReadingList(ctx context.Context, req *mcp.CallToolRequest, input DedupeReadingListInput) (*mcp.CallToolResult, DedupeReadingListOutput, error) {
+	content, sha, err := t.storage.ReadFile(ctx, storage.ReadingListFile)
+	if err != nil {
+		return nil, DedupeReadingListOutput{}, fmt.Errorf("reading reading-list.md: %w", err)
+	}
+
+	if msg := entitystore.CheckUnchanged(storage.ReadingListFile, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, DedupeReadingListOutput{
+			Success: false,
+			Message: msg,
+		}, nil
+	}
+
+	rl, err := parseReadingList(ctx, content)
+	if err != nil {
+		return nil, DedupeReadingListOutput{}, fmt.Errorf("parsing reading list: %w", err)
+	}
+
+	result := dedupeReadingItems(rl)
+	result.DryRun = input.DryRun
+
+	changed := len(result.Canonicalized) > 0 || len(result.Merged) > 0
+	if changed && !input.DryRun {
+		message := fmt.Sprintf("Dedupe reading list: %d merged, %d URLs canonicalized", len(result.Merged), len(result.Canonicalized))
+		if err := t.storage.WriteFile(ctx, storage.ReadingListFile, storage.SerializeReadingList(rl), sha, message); err != nil {
+			if msg, ok := entitystore.WriteMessage(err); ok {
+				return nil, DedupeReadingListOutput{
+					Success: false,
+					Message: msg,
+				}, nil
+			}
+			return nil, DedupeReadingListOutput{}, fmt.Errorf("writing reading-list.md: %w", err)
+		}
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, DedupeReadingListOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, DedupeReadingListOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
+
+// dedupeReadingItems canonicalizes every URL in rl and merges items for the
+// same page, modifying rl in place. A merged item keeps the earliest added
+// item's ID, the earliest Added and ReadAt dates, and every item's notes; it
+// is read if any duplicate was.
+func dedupeReadingItems(rl *storage.ReadingList) DedupeReadingListResult {
+	result := DedupeReadingListResult{Canonicalized: []CanonicalizedURL{}, Merged: []MergedReadingItem{}}
+
+	all := append(append([]storage.ReadingItem{}, rl.ToRead...), rl.Read...)
+	var keys []string
+	groups := map[string][]storage.ReadingItem{}
+	for _, item := range all {
+		if canonical := canonicalURL(item.URL); canonical != item.URL {
+			result.Canonicalized = append(result.Canonicalized, CanonicalizedURL{ID: item.ID, From: item.URL, To: canonical})
+			item.URL = canonical
+		}
+		key := normalizeURL(item.URL)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], item)
+	}
+
+	rl.ToRead, rl.Read = []storage.ReadingItem{}, []storage.ReadingItem{}
+	for _, key := range keys {
+		group := groups[key]
+		merged := group[0]
+		if len(group) > 1 {
+			merged = mergeReadingItems(group)
+			var removed []string
+			for _, item := range group {
+				if item.ID != merged.ID {
+					removed = append(removed, item.ID)
+				}
+			}
+			result.Merged = append(result.Merged, MergedReadingItem{ID: merged.ID, URL: merged.URL, Removed: removed})
+		}
+		if merged.Read {
+			rl.Read = append(rl.Read, merged)
+		} else {
+			rl.ToRead = append(rl.ToRead, merged)
+		}
+	}
+	return result
+}
+
+// mergeReadingItems folds duplicates of one page into a single item.
+func mergeReadingItems(group []storage.ReadingItem) storage.ReadingItem {
+	merged := group[0]
+	for _, item := range group[1:] {
+		if !item.Added.IsZero() && (merged.Added.IsZero() || item.Added.Before(merged.Added)) {
+			merged.ID, merged.URL, merged.Added = item.ID, item.URL, item.Added
+		}
+	}
+
+	var notes []string
+	seen := map[string]bool{}
+	for _, item := range group {
+		if n := strings.TrimSpace(item.Notes); n != "" && !seen[n] {
+			seen[n] = true
+			notes = append(notes, n)
+		}
+		if item.Read {
+			merged.Read = true
+			if item.ReadAt != nil && (merged.ReadAt == nil || item.ReadAt.Before(*merged.ReadAt)) {
+				merged.ReadAt = item.ReadAt
+			}
+		}
+		if merged.Priority == "" {
+			merged.Priority = item.Priority
+		}
+		if merged.Category == "" {
+			merged.Category = item.Category
+		}
+	}
+	merged.Notes = strings.Join(notes, "; ")
+	return merged
+}
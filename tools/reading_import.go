@@ -0,0 +1,338 @@
+package tools
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultImportLimit caps the items appended by one import_reading_list call,
+// keeping each commit reviewable. Re-running continues where it stopped,
+// since already-imported URLs are skipped as duplicates.
+const defaultImportLimit = 200
+
+// ImportReadingListInput is the input schema for the import_reading_list tool.
+type ImportReadingListInput struct {
+	Content         string `json:"content" jsonschema:"Contents of a Pocket export (HTML or CSV) or an Instapaper CSV export"`
+	Format          string `json:"format,omitempty" jsonschema:"pocket, instapaper, or auto (default) to detect from the content"`
+	IncludeArchived bool   `json:"include_archived,omitempty" jsonschema:"Also import archived items, added to the Read section. Defaults to false."`
+	Limit           int    `json:"limit,omitempty" jsonschema:"Maximum items to add in this batch (default 200). Run again to import the rest."`
+	IfUnchangedSHA  string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// ImportReadingListOutput is the output for the import_reading_list tool.
+type ImportReadingListOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ImportReadingListResult is the response payload for import_reading_list.
+type ImportReadingListResult struct {
+	Format     string `json:"format"`
+	Found      int    `json:"found"`
+	Imported   int    `json:"imported"`
+	Duplicates int    `json:"duplicates"`
+	// Archived counts archived items skipped because include_archived was false.
+	Archived int `json:"archived"`
+	// Remaining counts new items left over because of the batch limit.
+	Remaining int `json:"remaining"`
+}
+
+// importedItem is one entry from an export file.
+type importedItem struct {
+	URL      string
+	Title    string
+	Tags     []string
+	Added    time.Time
+	Archived bool
+}
+
+func (t *ReadingTools) importReadingList(ctx context.Context, req *mcp.CallToolRequest, input ImportReadingListInput) (*mcp.CallToolResult, ImportReadingListOutput, error) {
+	if strings.TrimSpace(input.Content) == "" {
+		return nil, ImportReadingListOutput{
+			Success: false,
+			Message: "content is required",
+		}, nil
+	}
+
+	format := strings.ToLower(strings.TrimSpace(input.Format))
+	if format == "" || format == "auto" {
+		format = detectImportFormat(input.Content)
+	}
+	var items []importedItem
+	var err error
+	switch format {
+	case "pocket":
+		items, err = parsePocketExport(input.Content)
+	case "instapaper":
+		items, err = parseInstapaperExport(input.Content)
+	default:
+		return nil, ImportReadingListOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid format %q. Use: pocket, instapaper, or auto", input.Format),
+		}, nil
+	}
+	if err != nil {
+		return nil, ImportReadingListOutput{
+			Success: false,
+			Message: fmt.Sprintf("Could not parse %s export: %v", format, err),
+		}, nil
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultImportLimit
+	}
+
+	content, sha, err := t.storage.ReadFile(ctx, storage.ReadingListFile)
+	if err != nil {
+		return nil, ImportReadingListOutput{}, fmt.Errorf("reading reading-list.md: %w", err)
+	}
+
+	if msg := entitystore.CheckUnchanged(storage.ReadingListFile, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, ImportReadingListOutput{
+			Success: false,
+			Message: msg,
+		}, nil
+	}
+
+	rl, err := parseReadingList(ctx, content)
+	if err != nil {
+		return nil, ImportReadingListOutput{}, fmt.Errorf("parsing reading list: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, item := range rl.ToRead {
+		seen[item.URL] = true
+	}
+	for _, item := range rl.Read {
+		seen[item.URL] = true
+	}
+
+	result := ImportReadingListResult{Format: format, Found: len(items)}
+	today := clock.Today(t.clock)
+	for _, it := range items {
+		if it.Archived && !input.IncludeArchived {
+			result.Archived++
+			continue
+		}
+		if seen[it.URL] {
+			result.Duplicates++
+			continue
+		}
+		seen[it.URL] = true
+		if result.Imported >= limit {
+			result.Remaining++
+			continue
+		}
+
+		added := it.Added
+		if added.IsZero() {
+			added = today
+		}
+		newItem := storage.ReadingItem{
+			ID:    storage.NewID(storage.PrefixReading, takenIn(readingKind.ID, rl.ToRead, rl.Read)),
+			URL:   it.URL,
+			Notes: importNotes(it),
+			Added: added,
+		}
+		if it.Archived {
+			newItem.Read = true
+			readAt := added
+			newItem.ReadAt = &readAt
+			rl.Read = append(rl.Read, newItem)
+		} else {
+			rl.ToRead = append(rl.ToRead, newItem)
+		}
+		result.Imported++
+	}
+
+	if result.Imported > 0 {
+		newContent := storage.SerializeReadingList(rl)
+		message := fmt.Sprintf("Import %d reading list items from %s", result.Imported, format)
+		if err := t.storage.WriteFile(ctx, storage.ReadingListFile, newContent, sha, message); err != nil {
+			if msg, ok := entitystore.WriteMessage(err); ok {
+				return nil, ImportReadingListOutput{
+					Success: false,
+					Message: msg,
+				}, nil
+			}
+			return nil, ImportReadingListOutput{}, fmt.Errorf("writing reading-list.md: %w", err)
+		}
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, ImportReadingListOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, ImportReadingListOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
+
+// importNotes turns an export's title and tags into reading list notes.
+func importNotes(it importedItem) string {
+	notes := strings.TrimSpace(it.Title)
+	if notes == it.URL {
+		notes = ""
+	}
+	if len(it.Tags) > 0 {
+		tags := "#" + strings.Join(it.Tags, " #")
+		if notes == "" {
+			return tags
+		}
+		notes += " " + tags
+	}
+	return notes
+}
+
+// detectImportFormat guesses the export format from its content.
+func detectImportFormat(content string) string {
+	trimmed := strings.TrimSpace(content)
+	if strings.HasPrefix(trimmed, "<") {
+		return "pocket"
+	}
+	header, _, _ := strings.Cut(trimmed, "\n")
+	header = strings.ToLower(header)
+	switch {
+	case strings.Contains(header, "folder"):
+		return "instapaper"
+	case strings.Contains(header, "time_added"):
+		return "pocket"
+	}
+	return ""
+}
+
+var (
+	pocketLinkPattern    = regexp.MustCompile(`(?is)<a\s+([^>]*)>(.*?)</a>`)
+	pocketAttrPattern    = regexp.MustCompile(`(?i)(href|time_added|tags)="([^"]*)"`)
+	pocketArchivePattern = regexp.MustCompile(`(?i)<h1>\s*Read Archive\s*</h1>`)
+)
+
+// parsePocketExport parses Pocket's HTML export (ril_export.html) or its CSV
+// export (title,url,time_added,tags,status).
+func parsePocketExport(content string) ([]importedItem, error) {
+	if !strings.HasPrefix(strings.TrimSpace(content), "<") {
+		return parseExportCSV(content, exportColumns{url: "url", title: "title", added: "time_added", tags: "tags", tagSep: "|", status: "status"}, func(status string) bool {
+			return strings.EqualFold(status, "archive")
+		})
+	}
+
+	archiveStart := len(content)
+	if loc := pocketArchivePattern.FindStringIndex(content); loc != nil {
+		archiveStart = loc[0]
+	}
+
+	var items []importedItem
+	for _, m := range pocketLinkPattern.FindAllStringSubmatchIndex(content, -1) {
+		attrs := content[m[2]:m[3]]
+		it := importedItem{
+			Title:    html.UnescapeString(strings.TrimSpace(content[m[4]:m[5]])),
+			Archived: m[0] > archiveStart,
+		}
+		for _, a := range pocketAttrPattern.FindAllStringSubmatch(attrs, -1) {
+			value := html.UnescapeString(a[2])
+			switch strings.ToLower(a[1]) {
+			case "href":
+				it.URL = strings.TrimSpace(value)
+			case "time_added":
+				it.Added = unixDate(value)
+			case "tags":
+				it.Tags = splitTags(value, ",")
+			}
+		}
+		if it.URL != "" {
+			items = append(items, it)
+		}
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no links found")
+	}
+	return items, nil
+}
+
+// parseInstapaperExport parses Instapaper's CSV export
+// (URL,Title,Selection,Folder,Timestamp).
+func parseInstapaperExport(content string) ([]importedItem, error) {
+	return parseExportCSV(content, exportColumns{url: "url", title: "title", added: "timestamp", tags: "tags", tagSep: ",", status: "folder"}, func(folder string) bool {
+		return strings.EqualFold(folder, "archive")
+	})
+}
+
+// exportColumns names the CSV columns to read (matched case-insensitively).
+type exportColumns struct {
+	url, title, added, tags, tagSep, status string
+}
+
+func parseExportCSV(content string, cols exportColumns, archived func(status string) bool) ([]importedItem, error) {
+	r := csv.NewReader(strings.NewReader(content))
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("no rows found")
+	}
+
+	index := make(map[string]int)
+	for i, name := range records[0] {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := index[cols.url]; !ok {
+		return nil, fmt.Errorf("missing %q column", cols.url)
+	}
+	field := func(row []string, name string) string {
+		if i, ok := index[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	var items []importedItem
+	for _, row := range records[1:] {
+		it := importedItem{
+			URL:      field(row, cols.url),
+			Title:    field(row, cols.title),
+			Added:    unixDate(field(row, cols.added)),
+			Tags:     splitTags(field(row, cols.tags), cols.tagSep),
+			Archived: archived(field(row, cols.status)),
+		}
+		if it.URL != "" {
+			items = append(items, it)
+		}
+	}
+	return items, nil
+}
+
+// unixDate converts a Unix timestamp to a UTC date, or zero if invalid.
+func unixDate(s string) time.Time {
+	sec, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || sec <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0).UTC().Truncate(24 * time.Hour)
+}
+
+func splitTags(s, sep string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, sep) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, strings.ReplaceAll(tag, " ", "-"))
+		}
+	}
+	return tags
+}
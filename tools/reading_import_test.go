@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"testing"
+)
+
+func TestParsePocketExport_HTML(t *testing.T) {
+	input := `<!DOCTYPE html>
+<html><body>
+<h1>Unread</h1>
+<ul>
+<li><a href="https://example.com/a" time_added="1770076800" tags="go,tools">Article &amp; A</a></li>
+</ul>
+<h1>Read Archive</h1>
+<ul>
+<li><a href="https://example.com/b" time_added="1770076800" tags="">https://example.com/b</a></li>
+</ul>
+</body></html>`
+
+	items, err := parsePocketExport(input)
+	if err != nil {
+		t.Fatalf("parsePocketExport() error = %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	a := items[0]
+	if a.URL != "https://example.com/a" || a.Title != "Article & A" || a.Archived {
+		t.Errorf("unexpected first item: %+v", a)
+	}
+	if a.Added.Format("2006-01-02") != "2026-02-03" || len(a.Tags) != 2 {
+		t.Errorf("unexpected first item date or tags: %+v", a)
+	}
+	if !items[1].Archived {
+		t.Errorf("expected second item archived")
+	}
+	if notes := importNotes(a); notes != "Article & A #go #tools" {
+		t.Errorf("importNotes() = %q", notes)
+	}
+	if notes := importNotes(items[1]); notes != "" {
+		t.Errorf("expected title equal to URL to be dropped, got %q", notes)
+	}
+}
+
+func TestParsePocketExport_CSV(t *testing.T) {
+	input := "title,url,time_added,cursor,tags,status\n" +
+		"Go tips,https://example.com/go,1770076800,x,go|tips,unread\n" +
+		"Old,https://example.com/old,1770076800,y,,archive\n"
+
+	if got := detectImportFormat(input); got != "pocket" {
+		t.Fatalf("detectImportFormat() = %q, want pocket", got)
+	}
+	items, err := parsePocketExport(input)
+	if err != nil {
+		t.Fatalf("parsePocketExport() error = %v", err)
+	}
+	if len(items) != 2 || items[0].Title != "Go tips" || len(items[0].Tags) != 2 || !items[1].Archived {
+		t.Errorf("unexpected items: %+v", items)
+	}
+}
+
+func TestParseInstapaperExport(t *testing.T) {
+	input := "URL,Title,Selection,Folder,Timestamp\n" +
+		"https://example.com/x,\"Title, with comma\",,Unread,1770076800\n" +
+		"https://example.com/y,Y,,Archive,1770076800\n"
+
+	if got := detectImportFormat(input); got != "instapaper" {
+		t.Fatalf("detectImportFormat() = %q, want instapaper", got)
+	}
+	items, err := parseInstapaperExport(input)
+	if err != nil {
+		t.Fatalf("parseInstapaperExport() error = %v", err)
+	}
+	if len(items) != 2 || items[0].Title != "Title, with comma" || items[0].Archived || !items[1].Archived {
+		t.Errorf("unexpected items: %+v", items)
+	}
+}
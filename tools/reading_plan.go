@@ -0,0 +1,226 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultReadingMinutes stands in for items without an estimate.
+	defaultReadingMinutes = 10
+	// maxEstimateFetches caps the pages one plan_reading call fetches.
+	maxEstimateFetches = 10
+	// readingPaceDays is the window the reading pace is measured over.
+	readingPaceDays = 28
+)
+
+// PlanReadingInput is the input schema for the plan_reading tool.
+type PlanReadingInput struct {
+	WeeklyMinutes  int  `json:"weekly_minutes" jsonschema:"Minutes a week to spend reading"`
+	FetchEstimates bool `json:"fetch_estimates,omitempty" jsonschema:"Fetch the pages of up to 10 queued items without an estimated reading time to estimate it, saving the estimates to the reading list"`
+}
+
+// PlanReadingOutput is the output for the plan_reading tool.
+type PlanReadingOutput struct {
+	Success bool               `json:"success"`
+	Message string             `json:"message"`
+	Result  *PlanReadingResult `json:"result,omitempty"`
+}
+
+// PlanReadingResult is a week's reading plan and a forecast for the queue.
+type PlanReadingResult struct {
+	WeeklyMinutes  int           `json:"weekly_minutes"`
+	Plan           []PlannedRead `json:"plan"`
+	PlannedMinutes int           `json:"planned_minutes"`
+	Queued         int           `json:"queued"`
+	QueuedMinutes  int           `json:"queued_minutes"`
+	// Unestimated counts the queued items without an estimate, counted at
+	// 10 minutes each.
+	Unestimated int `json:"unestimated"`
+	// Fetched counts the estimates fetched by this call.
+	Fetched int `json:"fetched,omitempty"`
+	// ReadPerWeek and AddedPerWeek are the pace over the last 4 weeks.
+	ReadPerWeek  float64 `json:"read_per_week"`
+	AddedPerWeek float64 `json:"added_per_week"`
+	// ClearsAtPace is when the queue empties at that pace, if it's
+	// shrinking; ClearsOnBudget is when it empties reading the weekly
+	// minutes, ignoring new additions.
+	ClearsAtPace   string `json:"clears_at_pace,omitempty"`
+	ClearsOnBudget string `json:"clears_on_budget,omitempty"`
+}
+
+// PlannedRead is a queued item proposed for the week.
+type PlannedRead struct {
+	Item    ReadingListItem `json:"item"`
+	Minutes int             `json:"minutes"`
+	// Assumed is set when the item has no estimate and Minutes is the default.
+	Assumed bool `json:"assumed,omitempty"`
+}
+
+func (t *ReadingTools) planReading(ctx context.Context, req *mcp.CallToolRequest, input PlanReadingInput) (*mcp.CallToolResult, PlanReadingOutput, error) {
+	if input.WeeklyMinutes <= 0 {
+		return nil, PlanReadingOutput{
+			Success: false,
+			Message: "weekly_minutes must be a positive number of minutes",
+		}, nil
+	}
+
+	rl, sha, err := t.items.Load(ctx, "")
+	if err != nil {
+		return nil, PlanReadingOutput{}, err
+	}
+
+	fetched := 0
+	if input.FetchEstimates {
+		if fetched = t.fetchEstimates(ctx, rl.ToRead); fetched > 0 {
+			err := t.items.Save(ctx, rl, sha, fmt.Sprintf("Estimate reading time for %s", plural(fetched, "item")))
+			if msg, ok := entitystore.Message(err); ok {
+				return nil, PlanReadingOutput{Success: false, Message: msg}, nil
+			}
+			if err != nil {
+				return nil, PlanReadingOutput{}, err
+			}
+		}
+	}
+
+	result := planReading(rl, input.WeeklyMinutes, clock.Today(t.clock))
+	result.Fetched = fetched
+	text := result.text()
+	return textResult(text), PlanReadingOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+// fetchEstimates estimates the reading time of up to maxEstimateFetches
+// items without one, concurrently, and returns how many it set. Pages that
+// can't be fetched are left unestimated.
+func (t *ReadingTools) fetchEstimates(ctx context.Context, items []storage.ReadingItem) int {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	fetched, started := 0, 0
+	for i := range items {
+		if items[i].Minutes > 0 || started == maxEstimateFetches {
+			continue
+		}
+		started++
+		wg.Add(1)
+		go func(item *storage.ReadingItem) {
+			defer wg.Done()
+			minutes, err := t.estimate(ctx, item.URL)
+			if err != nil {
+				slog.Warn("estimating reading time failed", "url", item.URL, "error", err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			item.Minutes = minutes
+			fetched++
+		}(&items[i])
+	}
+	wg.Wait()
+	return fetched
+}
+
+// planReading proposes the queued items to read within weeklyMinutes, in
+// priority order (next, then unprioritised, then someday) and oldest first
+// within a priority, skipping items that don't fit, and forecasts when the
+// queue clears.
+func planReading(rl *storage.ReadingList, weeklyMinutes int, today time.Time) PlanReadingResult {
+	result := PlanReadingResult{WeeklyMinutes: weeklyMinutes, Plan: []PlannedRead{}, Queued: len(rl.ToRead)}
+
+	queue := append([]storage.ReadingItem{}, rl.ToRead...)
+	sort.SliceStable(queue, func(i, j int) bool {
+		if a, b := readingPriorityRank(queue[i].Priority), readingPriorityRank(queue[j].Priority); a != b {
+			return a < b
+		}
+		return queue[i].Added.Before(queue[j].Added)
+	})
+	for _, item := range queue {
+		minutes, assumed := item.Minutes, item.Minutes <= 0
+		if assumed {
+			minutes = defaultReadingMinutes
+			result.Unestimated++
+		}
+		result.QueuedMinutes += minutes
+		if result.PlannedMinutes+minutes <= weeklyMinutes {
+			result.Plan = append(result.Plan, PlannedRead{Item: readingToItem(item), Minutes: minutes, Assumed: assumed})
+			result.PlannedMinutes += minutes
+		}
+	}
+
+	// Pace over the last readingPaceDays days
+	since := today.AddDate(0, 0, -readingPaceDays)
+	read, added := 0, 0
+	for _, item := range rl.Read {
+		if item.ReadAt != nil && item.ReadAt.After(since) {
+			read++
+		}
+	}
+	for _, list := range [][]storage.ReadingItem{rl.ToRead, rl.Read} {
+		for _, item := range list {
+			if item.Added.After(since) {
+				added++
+			}
+		}
+	}
+	weeks := float64(readingPaceDays) / 7
+	result.ReadPerWeek = round1(float64(read) / weeks)
+	result.AddedPerWeek = round1(float64(added) / weeks)
+
+	if result.Queued > 0 {
+		if shrink := float64(read-added) / weeks; shrink > 0 {
+			days := math.Ceil(float64(result.Queued) / shrink * 7)
+			result.ClearsAtPace = formatDate(today.AddDate(0, 0, int(days)))
+		}
+		days := math.Ceil(float64(result.QueuedMinutes) / float64(weeklyMinutes) * 7)
+		result.ClearsOnBudget = formatDate(today.AddDate(0, 0, int(days)))
+	}
+	return result
+}
+
+func (r PlanReadingResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Reading plan: %d of %d minutes, %s\n", r.PlannedMinutes, r.WeeklyMinutes, plural(len(r.Plan), "item"))
+	for _, p := range r.Plan {
+		minutes := fmt.Sprintf("%d min", p.Minutes)
+		if p.Assumed {
+			minutes += " assumed"
+		}
+		fmt.Fprintf(&sb, "- %s%s\n", p.Item.URL, itemDetails("id "+p.Item.ID, minutes, p.Item.Priority))
+	}
+
+	fmt.Fprintf(&sb, "\nQueue: %s, about %d minutes", plural(r.Queued, "item"), r.QueuedMinutes)
+	if r.Unestimated > 0 {
+		fmt.Fprintf(&sb, " (%d without an estimate, counted at %d minutes)", r.Unestimated, defaultReadingMinutes)
+	}
+	sb.WriteString("\n")
+	if r.Fetched > 0 {
+		fmt.Fprintf(&sb, "Fetched %s.\n", plural(r.Fetched, "new estimate"))
+	}
+	fmt.Fprintf(&sb, "Pace over the last 4 weeks: %.1f read, %.1f added a week\n", r.ReadPerWeek, r.AddedPerWeek)
+	switch {
+	case r.Queued == 0:
+		sb.WriteString("The queue is empty.\n")
+	case r.ClearsAtPace != "":
+		fmt.Fprintf(&sb, "At this pace the queue clears by %s.\n", r.ClearsAtPace)
+	default:
+		sb.WriteString("At this pace the queue isn't shrinking.\n")
+	}
+	if r.ClearsOnBudget != "" {
+		fmt.Fprintf(&sb, "Reading %d minutes a week, it clears by %s if nothing is added.\n", r.WeeklyMinutes, r.ClearsOnBudget)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
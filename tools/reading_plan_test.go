@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestPlanReading(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.ReadingListFile: "# Reading List\n\n## To Read\n" +
+			"- [ ] https://long.example — Added: 2026-01-01 {id:aaaa1111,minutes:50}\n" +
+			"- [ ] https://next.example — Added: 2026-02-05 {id:bbbb2222,priority:next,minutes:15}\n" +
+			"- [ ] https://old.example — Added: 2026-01-20 {id:cccc3333}\n" +
+			"- [ ] https://down.example — Added: 2026-02-06 {id:dddd4444}\n" +
+			"- [ ] https://later.example — Added: 2026-01-02 {id:eeee5555,priority:someday,minutes:5}\n\n" +
+			"## Read\n" +
+			"- [x] https://a.example — Read: 2026-02-01 {id:ffff0001}\n- [x] https://b.example — Read: 2026-02-03 {id:ffff0002}\n" +
+			"- [x] https://c.example — Read: 2026-02-08 {id:ffff0003}\n- [x] https://d.example — Read: 2026-02-09 {id:ffff0004}\n",
+	})
+	rt := NewReadingTools(files, clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)))
+	rt.estimate = func(ctx context.Context, url string) (int, error) {
+		if url == "https://down.example" {
+			return 0, errors.New("unreachable")
+		}
+		return 8, nil
+	}
+	ctx := context.Background()
+
+	if _, out, _ := rt.planReading(ctx, nil, PlanReadingInput{}); out.Success {
+		t.Error("planReading() without a budget succeeded")
+	}
+
+	_, out, err := rt.planReading(ctx, nil, PlanReadingInput{WeeklyMinutes: 30, FetchEstimates: true})
+	if err != nil || !out.Success {
+		t.Fatalf("planReading() = %+v, %v", out, err)
+	}
+	r := out.Result
+	var plan []string
+	for _, p := range r.Plan {
+		plan = append(plan, p.Item.ID)
+	}
+	// next first, then oldest first, skipping the 50 minute read; the
+	// unreachable page counts at the default
+	if got := strings.Join(plan, ","); got != "bbbb2222,cccc3333,eeee5555" || r.PlannedMinutes != 28 {
+		t.Errorf("plan = %s (%d minutes)", got, r.PlannedMinutes)
+	}
+	if r.Fetched != 1 || r.Unestimated != 1 || r.QueuedMinutes != 88 {
+		t.Errorf("unexpected estimates %+v", r)
+	}
+	if !strings.Contains(fileContent(t, files, storage.ReadingListFile), "{id:cccc3333,minutes:8}") {
+		t.Errorf("fetched estimate not saved:\n%s", fileContent(t, files, storage.ReadingListFile))
+	}
+
+	// 4 read and 3 added in 4 weeks: the queue of 5 shrinks by a quarter item a week
+	if r.ReadPerWeek != 1 || r.AddedPerWeek != 0.8 || r.ClearsAtPace != "2026-06-30" || r.ClearsOnBudget != "2026-03-03" {
+		t.Errorf("unexpected forecast %+v", r)
+	}
+}
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestReadingPriorityAndCategory(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.ReadingListFile: "# Reading List\n\n## To Read\n- [ ] https://a.example {id:aaaa1111,category:go}\n- [ ] https://b.example — Notes: keep {id:bbbb2222}\n\n## Read\n",
+	})
+	tools := NewReadingTools(files, nil)
+	ctx := context.Background()
+
+	_, out, err := tools.addToReadingList(ctx, nil, AddToReadingListInput{URL: "https://c.example", Priority: "Next", Category: "Go"})
+	if err != nil || !out.Success {
+		t.Fatalf("addToReadingList() = %+v, %v", out, err)
+	}
+	if _, out, _ := tools.addToReadingList(ctx, nil, AddToReadingListInput{URL: "https://d.example", Priority: "urgent"}); out.Success || !strings.Contains(out.Message, "Invalid priority") {
+		t.Errorf("expected an invalid priority error, got %+v", out)
+	}
+
+	_, list, err := tools.listReadingList(ctx, nil, ListReadingListInput{Category: "go", Sort: "priority"})
+	if err != nil || !list.Success {
+		t.Fatalf("listReadingList() = %+v, %v", list, err)
+	}
+	if items := list.Result.Items; len(items) != 2 || items[0].URL != "https://c.example" || items[0].Priority != "next" || items[1].ID != "aaaa1111" {
+		t.Errorf("unexpected items %+v", items)
+	}
+
+	_, list, _ = tools.listReadingList(ctx, nil, ListReadingListInput{Priority: "none", Category: "none"})
+	if items := list.Result.Items; len(items) != 1 || items[0].ID != "bbbb2222" {
+		t.Errorf("unexpected unprioritised, uncategorized items %+v", items)
+	}
+
+	// Setting a priority leaves the notes alone
+	_, edit, err := tools.editReadingItem(ctx, nil, EditReadingItemInput{ID: "bbbb2222", Priority: "someday", Category: "Databases"})
+	if err != nil || !edit.Success {
+		t.Fatalf("editReadingItem() = %+v, %v", edit, err)
+	}
+	rl, _ := storage.ParseReadingList(fileContent(t, files, storage.ReadingListFile))
+	if b := rl.ToRead[1]; b.Notes != "keep" || b.Priority != storage.ReadingPrioritySomeday || b.Category != "databases" {
+		t.Errorf("unexpected edited item %+v", b)
+	}
+
+	_, edit, _ = tools.editReadingItem(ctx, nil, EditReadingItemInput{ID: "bbbb2222", Priority: "none"})
+	rl, _ = storage.ParseReadingList(fileContent(t, files, storage.ReadingListFile))
+	if b := rl.ToRead[1]; !edit.Success || b.Priority != "" || b.Category != "databases" {
+		t.Errorf("expected the priority to be cleared, got %+v", b)
+	}
+}
@@ -7,42 +7,59 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
 	"github.com/dang-w/momentum-mcp-server/storage"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // ReminderTools provides tools for managing reminders.
 type ReminderTools struct {
-	storage storage.Storage
+	storage   storage.Storage
+	reminders *entitystore.Store[storage.ReminderFile, storage.Reminder]
+	clock     clock.Clock
 }
 
-// NewReminderTools creates a new ReminderTools instance.
-func NewReminderTools(s storage.Storage) *ReminderTools {
-	return &ReminderTools{storage: s}
+// NewReminderTools creates a new ReminderTools instance. A nil clock uses the system clock.
+func NewReminderTools(s storage.Storage, c clock.Clock) *ReminderTools {
+	return &ReminderTools{storage: s, reminders: entitystore.New(s, reminderKind), clock: clock.Or(c)}
 }
 
 // SetReminderInput is the input schema for the set_reminder tool.
 type SetReminderInput struct {
-	Date string `json:"date" jsonschema:"The date for the reminder in YYYY-MM-DD format"`
-	Text string `json:"text" jsonschema:"The reminder text"`
+	Date           string `json:"date" jsonschema:"The date for the reminder in YYYY-MM-DD format (e.g. 2025-03-14). today and tomorrow are also accepted."`
+	Text           string `json:"text" jsonschema:"The reminder text"`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // SetReminderOutput is the output for the set_reminder tool.
 type SetReminderOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
+	// ID and Item are the new reminder, set on success.
+	ID   string        `json:"id,omitempty"`
+	Item *ReminderItem `json:"item,omitempty"`
 }
 
 // CompleteReminderInput is the input schema for the complete_reminder tool.
 type CompleteReminderInput struct {
-	Text string `json:"text,omitempty" jsonschema:"Text to match against reminder descriptions. Can be partial match."`
-	ID   string `json:"id,omitempty" jsonschema:"ID of the reminder to complete. More reliable than text matching. Use list_reminders to find IDs."`
+	Text           string `json:"text,omitempty" jsonschema:"Text to match against reminder descriptions. Can be partial or approximate; if it matches several without a clear winner, the candidates are returned."`
+	ID             string `json:"id,omitempty" jsonschema:"ID of the reminder to complete. More reliable than text matching. Use list_reminders to find IDs."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // CompleteReminderOutput is the output for the complete_reminder tool.
 type CompleteReminderOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
+	// ID and Item are the completed reminder, set on success.
+	ID   string        `json:"id,omitempty"`
+	Item *ReminderItem `json:"item,omitempty"`
+	// Progress is the completion streak and weekly record, set on success.
+	Progress *CompletionProgress `json:"progress,omitempty"`
+	// Candidates are the items the text matched, best first, when it
+	// matched several without a clear winner.
+	Candidates []entitystore.Candidate `json:"candidates,omitempty"`
 }
 
 // ListRemindersInput is the input schema for the list_reminders tool.
@@ -50,12 +67,14 @@ type ListRemindersInput struct {
 	Status   string `json:"status,omitempty" jsonschema:"Filter by status: pending, completed, or all. Defaults to pending."`
 	DateFrom string `json:"date_from,omitempty" jsonschema:"Filter reminders from this date (YYYY-MM-DD). Only applies to pending reminders."`
 	DateTo   string `json:"date_to,omitempty" jsonschema:"Filter reminders up to this date (YYYY-MM-DD). Only applies to pending reminders."`
+	Sort     string `json:"sort,omitempty" jsonschema:"Sort by due, added, alphabetical, or completed. Prefix with - to reverse (e.g. -due for latest first). Defaults to file order."`
 }
 
 // ListRemindersOutput is the output for the list_reminders tool.
 type ListRemindersOutput struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
+	Success bool                 `json:"success"`
+	Message string               `json:"message"`
+	Result  *ListRemindersResult `json:"result,omitempty"`
 }
 
 // ListRemindersResult is the response payload for list_reminders.
@@ -64,12 +83,14 @@ type ListRemindersResult struct {
 	TotalPending   int            `json:"total_pending"`
 	TotalCompleted int            `json:"total_completed"`
 	TotalOverdue   int            `json:"total_overdue"`
+	SourceSHA      string         `json:"source_sha"`
 }
 
 // DeleteReminderInput is the input schema for the delete_reminder tool.
 type DeleteReminderInput struct {
-	ID      string `json:"id" jsonschema:"ID of the reminder to delete. Use list_reminders to find IDs."`
-	Confirm bool   `json:"confirm" jsonschema:"Must be set to true to confirm deletion. This is a permanent deletion."`
+	ID             string `json:"id" jsonschema:"ID of the reminder to delete. Use list_reminders to find IDs."`
+	Confirm        bool   `json:"confirm" jsonschema:"Must be set to true to confirm deletion. The reminder stays in the trash for 30 days."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // DeleteReminderOutput is the output for the delete_reminder tool.
@@ -80,15 +101,19 @@ type DeleteReminderOutput struct {
 
 // EditReminderInput is the input schema for the edit_reminder tool.
 type EditReminderInput struct {
-	ID   string `json:"id" jsonschema:"ID of the reminder to edit. Use list_reminders to find IDs."`
-	Text string `json:"text,omitempty" jsonschema:"New reminder text. If omitted, keeps existing text."`
-	Date string `json:"date,omitempty" jsonschema:"New date in YYYY-MM-DD format. If omitted, keeps existing date."`
+	ID             string `json:"id" jsonschema:"ID of the reminder to edit. Use list_reminders to find IDs."`
+	Text           string `json:"text,omitempty" jsonschema:"New reminder text. If omitted, keeps existing text."`
+	Date           string `json:"date,omitempty" jsonschema:"New date in YYYY-MM-DD format. If omitted, keeps existing date."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // EditReminderOutput is the output for the edit_reminder tool.
 type EditReminderOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
+	// ID and Item are the edited reminder, set on success.
+	ID   string        `json:"id,omitempty"`
+	Item *ReminderItem `json:"item,omitempty"`
 }
 
 // Register registers reminder tools with the MCP server.
@@ -100,7 +125,7 @@ func (t *ReminderTools) Register(server *mcp.Server) {
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "complete_reminder",
-		Description: "Mark a reminder as completed",
+		Description: "Mark a reminder as completed. The response includes the completion streak and weekly record (progress.highlight) to share with the user.",
 	}, t.completeReminder)
 
 	mcp.AddTool(server, &mcp.Tool{
@@ -115,7 +140,7 @@ func (t *ReminderTools) Register(server *mcp.Server) {
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "delete_reminder",
-		Description: "Permanently delete a reminder",
+		Description: "Delete a reminder into the trash, where restore_item can bring it back for 30 days",
 	}, t.deleteReminder)
 }
 
@@ -134,7 +159,7 @@ func (t *ReminderTools) setReminder(ctx context.Context, req *mcp.CallToolReques
 	}
 
 	// Parse the date
-	date, err := time.Parse("2006-01-02", strings.TrimSpace(input.Date))
+	date, err := parseDate(input.Date, t.clock.Now())
 	if err != nil {
 		return nil, SetReminderOutput{
 			Success: false,
@@ -142,40 +167,22 @@ func (t *ReminderTools) setReminder(ctx context.Context, req *mcp.CallToolReques
 		}, nil
 	}
 
-	// Read current reminders
-	content, sha, err := t.storage.ReadFile(ctx, "reminders.md")
-	if err != nil {
-		return nil, SetReminderOutput{}, fmt.Errorf("reading reminders.md: %w", err)
-	}
-
-	rf, err := storage.ParseReminders(content)
-	if err != nil {
-		return nil, SetReminderOutput{}, fmt.Errorf("parsing reminders: %w", err)
-	}
-
-	// Add the new reminder
 	newReminder := storage.Reminder{
-		ID:    storage.GenerateID(),
 		Date:  date,
 		Text:  strings.TrimSpace(input.Text),
-		Added: time.Now().UTC().Truncate(24 * time.Hour),
+		Added: clock.Today(t.clock),
 	}
-	rf.Upcoming = append(rf.Upcoming, newReminder)
-
-	// Serialize and write back
-	newContent := storage.SerializeReminders(rf)
-	if err := t.storage.WriteFile(ctx, "reminders.md", newContent, sha, fmt.Sprintf("Set reminder: %s", truncate(input.Text, 50))); err != nil {
-		if err == storage.ErrConflict {
-			return nil, SetReminderOutput{
-				Success: false,
-				Message: "File was modified by another process. Please try again.",
-			}, nil
-		}
-		return nil, SetReminderOutput{}, fmt.Errorf("writing reminders.md: %w", err)
+	newReminder, err = t.reminders.Add(ctx, input.IfUnchangedSHA, newReminder, fmt.Sprintf("Set reminder: %s", truncate(input.Text, 50)), nil)
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, SetReminderOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, SetReminderOutput{}, err
 	}
 
-	today := time.Now().UTC().Truncate(24 * time.Hour)
-	itemJSON, err := json.Marshal(reminderToItem(newReminder, today))
+	today := clock.Today(t.clock)
+	item := reminderToItem(newReminder, today)
+	itemJSON, err := json.Marshal(item)
 	if err != nil {
 		return nil, SetReminderOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
@@ -183,6 +190,8 @@ func (t *ReminderTools) setReminder(ctx context.Context, req *mcp.CallToolReques
 	return nil, SetReminderOutput{
 		Success: true,
 		Message: string(itemJSON),
+		ID:      item.ID,
+		Item:    &item,
 	}, nil
 }
 
@@ -194,112 +203,58 @@ func (t *ReminderTools) completeReminder(ctx context.Context, req *mcp.CallToolR
 		}, nil
 	}
 
-	// Read current reminders
-	content, sha, err := t.storage.ReadFile(ctx, "reminders.md")
-	if err != nil {
-		return nil, CompleteReminderOutput{}, fmt.Errorf("reading reminders.md: %w", err)
+	// Mark as completed and move to the front of the completed list
+	reminder, err := t.reminders.Move(ctx, entitystore.Open, entitystore.Ref{ID: input.ID, Text: input.Text}, input.IfUnchangedSHA, func(r *storage.Reminder) string {
+		r.Completed = true
+		now := clock.Today(t.clock)
+		r.CompletedAt = &now
+		return fmt.Sprintf("Complete reminder: %s", truncate(r.Text, 50))
+	})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, CompleteReminderOutput{Success: false, Message: msg, Candidates: entitystore.Candidates(err)}, nil
 	}
-
-	rf, err := storage.ParseReminders(content)
 	if err != nil {
-		return nil, CompleteReminderOutput{}, fmt.Errorf("parsing reminders: %w", err)
+		return nil, CompleteReminderOutput{}, err
 	}
 
-	// Find matching reminders — prefer ID match if provided
-	var matches []int
-	if id := strings.TrimSpace(input.ID); id != "" {
-		for i, r := range rf.Upcoming {
-			if r.ID == id {
-				matches = append(matches, i)
-				break
-			}
-		}
-		if len(matches) == 0 {
-			return nil, CompleteReminderOutput{
-				Success: false,
-				Message: fmt.Sprintf("No upcoming reminder found with id %q", input.ID),
-			}, nil
-		}
-	} else {
-		searchText := strings.ToLower(strings.TrimSpace(input.Text))
-		for i, r := range rf.Upcoming {
-			if strings.Contains(strings.ToLower(r.Text), searchText) {
-				matches = append(matches, i)
-			}
-		}
-
-		if len(matches) == 0 {
-			return nil, CompleteReminderOutput{
-				Success: false,
-				Message: fmt.Sprintf("No upcoming reminder found matching %q", input.Text),
-			}, nil
-		}
-
-		if len(matches) > 1 {
-			var matchTexts []string
-			for _, idx := range matches {
-				r := rf.Upcoming[idx]
-				matchTexts = append(matchTexts, fmt.Sprintf("- [%s] %s (%s)", r.ID, r.Text, r.Date.Format("2006-01-02")))
-			}
-			return nil, CompleteReminderOutput{
-				Success: false,
-				Message: fmt.Sprintf("Multiple reminders match %q. Please be more specific or use an id:\n%s", input.Text, strings.Join(matchTexts, "\n")),
-			}, nil
-		}
-	}
-
-	// Mark as completed
-	idx := matches[0]
-	reminder := rf.Upcoming[idx]
-	reminder.Completed = true
-	now := time.Now().UTC().Truncate(24 * time.Hour)
-	reminder.CompletedAt = &now
-
-	// Move from upcoming to completed
-	rf.Upcoming = append(rf.Upcoming[:idx], rf.Upcoming[idx+1:]...)
-	rf.Completed = append([]storage.Reminder{reminder}, rf.Completed...) // Add to front
-
-	// Serialize and write back
-	newContent := storage.SerializeReminders(rf)
-	if err := t.storage.WriteFile(ctx, "reminders.md", newContent, sha, fmt.Sprintf("Complete reminder: %s", truncate(reminder.Text, 50))); err != nil {
-		if err == storage.ErrConflict {
-			return nil, CompleteReminderOutput{
-				Success: false,
-				Message: "File was modified by another process. Please try again.",
-			}, nil
-		}
-		return nil, CompleteReminderOutput{}, fmt.Errorf("writing reminders.md: %w", err)
-	}
-
-	today := time.Now().UTC().Truncate(24 * time.Hour)
-	itemJSON, err := json.Marshal(reminderToItem(reminder, today))
+	today := clock.Today(t.clock)
+	item := reminderToItem(reminder, today)
+	itemJSON, err := json.Marshal(item)
 	if err != nil {
 		return nil, CompleteReminderOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
 
 	return nil, CompleteReminderOutput{
-		Success: true,
-		Message: string(itemJSON),
+		Success:  true,
+		Message:  string(itemJSON),
+		ID:       item.ID,
+		Item:     &item,
+		Progress: completionProgress(ctx, t.storage, today),
 	}, nil
 }
 
 func (t *ReminderTools) listReminders(ctx context.Context, req *mcp.CallToolRequest, input ListRemindersInput) (*mcp.CallToolResult, ListRemindersOutput, error) {
-	content, _, err := t.storage.ReadFile(ctx, "reminders.md")
+	sortKey, sortDesc, msg := parseSort(input.Sort, sortDue, sortAdded, sortAlphabetical, sortCompleted)
+	if msg != "" {
+		return nil, ListRemindersOutput{Success: false, Message: msg}, nil
+	}
+
+	content, sha, err := t.storage.ReadFile(ctx, storage.RemindersFile)
 	if err != nil {
 		return nil, ListRemindersOutput{}, fmt.Errorf("reading reminders.md: %w", err)
 	}
 
-	rf, err := storage.ParseReminders(content)
+	rf, err := parseReminders(ctx, content)
 	if err != nil {
 		return nil, ListRemindersOutput{}, fmt.Errorf("parsing reminders: %w", err)
 	}
 
-	today := time.Now().UTC().Truncate(24 * time.Hour)
+	today := clock.Today(t.clock)
 
 	// Parse optional date filters
 	var dateFrom, dateTo time.Time
 	if input.DateFrom != "" {
-		dateFrom, err = time.Parse("2006-01-02", strings.TrimSpace(input.DateFrom))
+		dateFrom, err = parseDate(input.DateFrom, t.clock.Now())
 		if err != nil {
 			return nil, ListRemindersOutput{
 				Success: false,
@@ -308,7 +263,7 @@ func (t *ReminderTools) listReminders(ctx context.Context, req *mcp.CallToolRequ
 		}
 	}
 	if input.DateTo != "" {
-		dateTo, err = time.Parse("2006-01-02", strings.TrimSpace(input.DateTo))
+		dateTo, err = parseDate(input.DateTo, t.clock.Now())
 		if err != nil {
 			return nil, ListRemindersOutput{
 				Success: false,
@@ -362,6 +317,7 @@ func (t *ReminderTools) listReminders(ctx context.Context, req *mcp.CallToolRequ
 			totalOverdue++
 		}
 	}
+	sortReminderItems(reminderItems, sortKey, sortDesc)
 
 	// Count overdue across all pending (not just filtered)
 	allOverdue := 0
@@ -372,20 +328,18 @@ func (t *ReminderTools) listReminders(ctx context.Context, req *mcp.CallToolRequ
 	}
 
 	result := ListRemindersResult{
+		SourceSHA:      sha,
 		Reminders:      reminderItems,
 		TotalPending:   len(rf.Upcoming),
 		TotalCompleted: len(rf.Completed),
 		TotalOverdue:   allOverdue,
 	}
 
-	jsonBytes, err := json.Marshal(result)
-	if err != nil {
-		return nil, ListRemindersOutput{}, fmt.Errorf("marshaling response: %w", err)
-	}
-
-	return nil, ListRemindersOutput{
+	text := result.text()
+	return textResult(text), ListRemindersOutput{
 		Success: true,
-		Message: string(jsonBytes),
+		Message: text,
+		Result:  &result,
 	}, nil
 }
 
@@ -408,7 +362,7 @@ func (t *ReminderTools) editReminder(ctx context.Context, req *mcp.CallToolReque
 	var newDate time.Time
 	if d := strings.TrimSpace(input.Date); d != "" {
 		var err error
-		newDate, err = time.Parse("2006-01-02", d)
+		newDate, err = parseDate(d, t.clock.Now())
 		if err != nil {
 			return nil, EditReminderOutput{
 				Success: false,
@@ -417,56 +371,34 @@ func (t *ReminderTools) editReminder(ctx context.Context, req *mcp.CallToolReque
 		}
 	}
 
-	// Read current reminders
-	content, sha, err := t.storage.ReadFile(ctx, "reminders.md")
-	if err != nil {
-		return nil, EditReminderOutput{}, fmt.Errorf("reading reminders.md: %w", err)
+	reminder, err := t.reminders.Update(ctx, entitystore.Open, entitystore.Ref{ID: input.ID}, input.IfUnchangedSHA, func(r *storage.Reminder) string {
+		if text := strings.TrimSpace(input.Text); text != "" {
+			r.Text = text
+		}
+		if !newDate.IsZero() {
+			r.Date = newDate
+		}
+		return fmt.Sprintf("Edit reminder: %s", truncate(r.Text, 50))
+	})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, EditReminderOutput{Success: false, Message: msg}, nil
 	}
-
-	rf, err := storage.ParseReminders(content)
 	if err != nil {
-		return nil, EditReminderOutput{}, fmt.Errorf("parsing reminders: %w", err)
+		return nil, EditReminderOutput{}, err
 	}
 
-	// Find the reminder by ID in upcoming list
-	id := strings.TrimSpace(input.ID)
-	for i, r := range rf.Upcoming {
-		if r.ID == id {
-			if text := strings.TrimSpace(input.Text); text != "" {
-				rf.Upcoming[i].Text = text
-			}
-			if !newDate.IsZero() {
-				rf.Upcoming[i].Date = newDate
-			}
-
-			// Serialize and write back
-			newContent := storage.SerializeReminders(rf)
-			if err := t.storage.WriteFile(ctx, "reminders.md", newContent, sha, fmt.Sprintf("Edit reminder: %s", truncate(rf.Upcoming[i].Text, 50))); err != nil {
-				if err == storage.ErrConflict {
-					return nil, EditReminderOutput{
-						Success: false,
-						Message: "File was modified by another process. Please try again.",
-					}, nil
-				}
-				return nil, EditReminderOutput{}, fmt.Errorf("writing reminders.md: %w", err)
-			}
-
-			today := time.Now().UTC().Truncate(24 * time.Hour)
-			itemJSON, err := json.Marshal(reminderToItem(rf.Upcoming[i], today))
-			if err != nil {
-				return nil, EditReminderOutput{}, fmt.Errorf("marshaling response: %w", err)
-			}
-
-			return nil, EditReminderOutput{
-				Success: true,
-				Message: string(itemJSON),
-			}, nil
-		}
+	today := clock.Today(t.clock)
+	item := reminderToItem(reminder, today)
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		return nil, EditReminderOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
 
 	return nil, EditReminderOutput{
-		Success: false,
-		Message: fmt.Sprintf("No upcoming reminder found with id %q", id),
+		Success: true,
+		Message: string(itemJSON),
+		ID:      item.ID,
+		Item:    &item,
 	}, nil
 }
 
@@ -481,85 +413,41 @@ func (t *ReminderTools) deleteReminder(ctx context.Context, req *mcp.CallToolReq
 	if !input.Confirm {
 		return nil, DeleteReminderOutput{
 			Success: false,
-			Message: "confirm must be set to true to delete a reminder. This is a permanent deletion.",
+			Message: "confirm must be set to true to delete a reminder. It stays in the trash for 30 days.",
 		}, nil
 	}
 
-	// Read current reminders
-	content, sha, err := t.storage.ReadFile(ctx, "reminders.md")
+	rf, sha, err := t.reminders.Load(ctx, input.IfUnchangedSHA)
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, DeleteReminderOutput{Success: false, Message: msg}, nil
+	}
 	if err != nil {
-		return nil, DeleteReminderOutput{}, fmt.Errorf("reading reminders.md: %w", err)
+		return nil, DeleteReminderOutput{}, err
+	}
+	deleted, err := t.reminders.Remove(rf, entitystore.Ref{ID: input.ID})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, DeleteReminderOutput{Success: false, Message: msg}, nil
 	}
-
-	rf, err := storage.ParseReminders(content)
 	if err != nil {
-		return nil, DeleteReminderOutput{}, fmt.Errorf("parsing reminders: %w", err)
-	}
-
-	id := strings.TrimSpace(input.ID)
-
-	// Search upcoming list
-	for i, r := range rf.Upcoming {
-		if r.ID == id {
-			deleted := r
-			rf.Upcoming = append(rf.Upcoming[:i], rf.Upcoming[i+1:]...)
-
-			newContent := storage.SerializeReminders(rf)
-			if err := t.storage.WriteFile(ctx, "reminders.md", newContent, sha, fmt.Sprintf("Delete reminder: %s", truncate(deleted.Text, 50))); err != nil {
-				if err == storage.ErrConflict {
-					return nil, DeleteReminderOutput{
-						Success: false,
-						Message: "File was modified by another process. Please try again.",
-					}, nil
-				}
-				return nil, DeleteReminderOutput{}, fmt.Errorf("writing reminders.md: %w", err)
-			}
-
-			today := time.Now().UTC().Truncate(24 * time.Hour)
-			itemJSON, err := json.Marshal(reminderToItem(deleted, today))
-			if err != nil {
-				return nil, DeleteReminderOutput{}, fmt.Errorf("marshaling response: %w", err)
-			}
-
-			return nil, DeleteReminderOutput{
-				Success: true,
-				Message: string(itemJSON),
-			}, nil
-		}
+		return nil, DeleteReminderOutput{}, err
+	}
+	msg, err := moveToTrash(ctx, t.storage, t.clock.Now(), t.reminders.Change(rf, sha), storage.TrashItem{Reminder: &deleted},
+		fmt.Sprintf("Delete reminder: %s", truncate(deleted.Text, 50)))
+	if err != nil {
+		return nil, DeleteReminderOutput{}, err
+	}
+	if msg != "" {
+		return nil, DeleteReminderOutput{Success: false, Message: msg}, nil
 	}
 
-	// Search completed list
-	for i, r := range rf.Completed {
-		if r.ID == id {
-			deleted := r
-			rf.Completed = append(rf.Completed[:i], rf.Completed[i+1:]...)
-
-			newContent := storage.SerializeReminders(rf)
-			if err := t.storage.WriteFile(ctx, "reminders.md", newContent, sha, fmt.Sprintf("Delete reminder: %s", truncate(deleted.Text, 50))); err != nil {
-				if err == storage.ErrConflict {
-					return nil, DeleteReminderOutput{
-						Success: false,
-						Message: "File was modified by another process. Please try again.",
-					}, nil
-				}
-				return nil, DeleteReminderOutput{}, fmt.Errorf("writing reminders.md: %w", err)
-			}
-
-			today := time.Now().UTC().Truncate(24 * time.Hour)
-			itemJSON, err := json.Marshal(reminderToItem(deleted, today))
-			if err != nil {
-				return nil, DeleteReminderOutput{}, fmt.Errorf("marshaling response: %w", err)
-			}
-
-			return nil, DeleteReminderOutput{
-				Success: true,
-				Message: string(itemJSON),
-			}, nil
-		}
+	today := clock.Today(t.clock)
+	itemJSON, err := json.Marshal(reminderToItem(deleted, today))
+	if err != nil {
+		return nil, DeleteReminderOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
 
 	return nil, DeleteReminderOutput{
-		Success: false,
-		Message: fmt.Sprintf("No reminder found with id %q", id),
+		Success: true,
+		Message: string(itemJSON),
 	}, nil
 }
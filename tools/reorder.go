@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Positions accepted by reorder_todo.
+const (
+	positionTop    = "top"
+	positionBottom = "bottom"
+	positionBefore = "before"
+	positionAfter  = "after"
+)
+
+// ReorderTodoInput is the input schema for the reorder_todo tool.
+type ReorderTodoInput struct {
+	ID             string `json:"id" jsonschema:"ID of the active todo to move. Use list_todos to find IDs."`
+	Position       string `json:"position" jsonschema:"Where to move it: top or bottom of its priority section, or before or after the todo given in relative_to"`
+	RelativeTo     string `json:"relative_to,omitempty" jsonschema:"ID of the todo to move it before or after. Must have the same priority; use edit_todo to change priority first."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// ReorderTodoOutput is the output for the reorder_todo tool.
+type ReorderTodoOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// ID and Item are the moved todo, set on success.
+	ID   string    `json:"id,omitempty"`
+	Item *TodoItem `json:"item,omitempty"`
+	// Rank is the todo's new 1-based place in its priority section.
+	Rank int `json:"rank,omitempty"`
+}
+
+// reorderTodo moves an active todo within its priority section. A section's
+// order is the order of its todos in todos.md, which SerializeTodos keeps,
+// so moving a todo in the active list is enough.
+func (t *TodoTools) reorderTodo(ctx context.Context, req *mcp.CallToolRequest, input ReorderTodoInput) (*mcp.CallToolResult, ReorderTodoOutput, error) {
+	if strings.TrimSpace(input.ID) == "" {
+		return nil, ReorderTodoOutput{
+			Success: false,
+			Message: "id is required",
+		}, nil
+	}
+	position := strings.ToLower(strings.TrimSpace(input.Position))
+	relativeTo := strings.TrimSpace(input.RelativeTo)
+	switch position {
+	case positionTop, positionBottom:
+	case positionBefore, positionAfter:
+		if relativeTo == "" {
+			return nil, ReorderTodoOutput{
+				Success: false,
+				Message: fmt.Sprintf("relative_to is required to move a todo %s another", position),
+			}, nil
+		}
+	default:
+		return nil, ReorderTodoOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid position %q. Use: top, bottom, before, or after", input.Position),
+		}, nil
+	}
+
+	tf, sha, err := t.todos.Load(ctx, input.IfUnchangedSHA)
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, ReorderTodoOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, ReorderTodoOutput{}, err
+	}
+	_, i, err := t.todos.Find(tf, entitystore.Open, entitystore.Ref{ID: input.ID})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, ReorderTodoOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, ReorderTodoOutput{}, err
+	}
+	todo := tf.Active[i]
+	active := append(tf.Active[:i:i], tf.Active[i+1:]...)
+
+	var at int
+	switch position {
+	case positionTop:
+		at = 0
+	case positionBottom:
+		at = len(active)
+	default:
+		j := -1
+		for k, other := range active {
+			if other.ID == relativeTo {
+				j = k
+				break
+			}
+		}
+		if j < 0 {
+			msg := fmt.Sprintf("No active todo found with id %q", relativeTo)
+			if relativeTo == todo.ID {
+				msg = "A todo can't be moved relative to itself"
+			}
+			return nil, ReorderTodoOutput{Success: false, Message: msg}, nil
+		}
+		if priorityOrNormal(active[j].Priority) != priorityOrNormal(todo.Priority) {
+			return nil, ReorderTodoOutput{
+				Success: false,
+				Message: fmt.Sprintf("%q is %s priority and %q is %s. Change the priority with edit_todo first.", truncate(todo.Text, 50), priorityOrNormal(todo.Priority), truncate(active[j].Text, 50), priorityOrNormal(active[j].Priority)),
+			}, nil
+		}
+		at = j
+		if position == positionAfter {
+			at++
+		}
+	}
+	tf.Active = append(active[:at:at], append([]storage.Todo{todo}, active[at:]...)...)
+
+	err = t.todos.Save(ctx, tf, sha, fmt.Sprintf("Reorder todo: %s", truncate(todo.Text, 50)))
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, ReorderTodoOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, ReorderTodoOutput{}, err
+	}
+
+	rank, total := 0, 0
+	for _, other := range tf.Active {
+		if priorityOrNormal(other.Priority) == priorityOrNormal(todo.Priority) {
+			total++
+			if other.ID == todo.ID {
+				rank = total
+			}
+		}
+	}
+	item := todoToItem(todo)
+	return nil, ReorderTodoOutput{
+		Success: true,
+		Message: fmt.Sprintf("Moved %q to position %d of %d %s-priority todos", truncate(todo.Text, 50), rank, total, priorityOrNormal(todo.Priority)),
+		ID:      item.ID,
+		Item:    &item,
+		Rank:    rank,
+	}, nil
+}
+
+// priorityOrNormal returns p, or normal if it is empty.
+func priorityOrNormal(p storage.Priority) storage.Priority {
+	if p == "" {
+		return storage.PriorityNormal
+	}
+	return p
+}
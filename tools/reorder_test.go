@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestReorderTodo(t *testing.T) {
+	files := newFileStorage(map[string]string{storage.TodosFile: "# Active Todos\n\n## High Priority\n- [ ] Ship release {id:aaaa1111}\n\n## Normal\n- [ ] One {id:bbbb1111}\n- [ ] Two {id:bbbb2222}\n- [ ] Three {id:bbbb3333}\n\n# Completed\n"})
+	todos := NewTodoTools(files, nil, WIPLimits{})
+	ctx := context.Background()
+
+	order := func() []string {
+		tf, _ := storage.ParseTodos(fileContent(t, files, storage.TodosFile))
+		var ids []string
+		for _, todo := range tf.Active {
+			ids = append(ids, todo.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		input    ReorderTodoInput
+		wantRank int
+		want     []string
+	}{
+		{ReorderTodoInput{ID: "bbbb3333", Position: "top"}, 1, []string{"aaaa1111", "bbbb3333", "bbbb1111", "bbbb2222"}},
+		{ReorderTodoInput{ID: "bbbb3333", Position: "bottom"}, 3, []string{"aaaa1111", "bbbb1111", "bbbb2222", "bbbb3333"}},
+		{ReorderTodoInput{ID: "bbbb1111", Position: "after", RelativeTo: "bbbb2222"}, 2, []string{"aaaa1111", "bbbb2222", "bbbb1111", "bbbb3333"}},
+		{ReorderTodoInput{ID: "bbbb3333", Position: "Before", RelativeTo: "bbbb2222"}, 1, []string{"aaaa1111", "bbbb3333", "bbbb2222", "bbbb1111"}},
+	}
+	for _, tt := range tests {
+		_, out, err := todos.reorderTodo(ctx, nil, tt.input)
+		if err != nil || !out.Success || out.Rank != tt.wantRank {
+			t.Fatalf("reorderTodo(%+v) = %+v, %v", tt.input, out, err)
+		}
+		if got := order(); !slices.Equal(got, tt.want) {
+			t.Errorf("after reorderTodo(%+v) order = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []ReorderTodoInput{
+		{ID: "bbbb1111", Position: "middle"},
+		{ID: "bbbb1111", Position: "after"},
+		{ID: "bbbb1111", Position: "after", RelativeTo: "bbbb1111"},
+		{ID: "bbbb1111", Position: "before", RelativeTo: "aaaa1111"},
+		{ID: "ffffffff", Position: "top"},
+	} {
+		if _, out, _ := todos.reorderTodo(ctx, nil, input); out.Success {
+			t.Errorf("reorderTodo(%+v) succeeded, want refusal", input)
+		}
+	}
+}
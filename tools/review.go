@@ -0,0 +1,175 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/analytics"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/summary"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// reviewsDir is the data repo directory weekly reviews are archived in.
+const reviewsDir = "reviews"
+
+// WeeklyRenderer renders the weekly summary markdown for the week containing
+// now. resources.SummaryResource implements it.
+type WeeklyRenderer interface {
+	Render(ctx context.Context, now time.Time, opts summary.Options) string
+}
+
+// ReviewTools renders weekly summaries and archives weekly reviews in the
+// data repo.
+type ReviewTools struct {
+	storage  storage.Storage
+	renderer WeeklyRenderer
+	clock    clock.Clock
+}
+
+// NewReviewTools creates a new ReviewTools instance. A nil clock uses the system clock.
+func NewReviewTools(s storage.Storage, r WeeklyRenderer, c clock.Clock) *ReviewTools {
+	return &ReviewTools{storage: s, renderer: r, clock: clock.Or(c)}
+}
+
+// GenerateWeeklyReviewInput is the input schema for the generate_weekly_review tool.
+type GenerateWeeklyReviewInput struct {
+	Week      string `json:"week,omitempty" jsonschema:"ISO week to review, e.g. 2026-W06, or any date in it (YYYY-MM-DD). Defaults to the current week."`
+	Overwrite bool   `json:"overwrite,omitempty" jsonschema:"Regenerate the review if one already exists for the week"`
+}
+
+// GenerateWeeklyReviewOutput is the output for the generate_weekly_review tool.
+type GenerateWeeklyReviewOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// WeeklyReviewResult is the response payload for generate_weekly_review.
+type WeeklyReviewResult struct {
+	Week        string `json:"week"`
+	Path        string `json:"path"`
+	Overwritten bool   `json:"overwritten"`
+	Content     string `json:"content"`
+}
+
+// GetWeeklySummaryInput is the input schema for the get_weekly_summary tool.
+type GetWeeklySummaryInput struct {
+	WeeksAgo  int      `json:"weeks_ago,omitempty" jsonschema:"How many weeks back to summarize: 0 for this week (default), 1 for last week"`
+	Verbosity string   `json:"verbosity,omitempty" jsonschema:"brief (headline numbers only), normal (default), or detailed (every item)"`
+	Sections  []string `json:"sections,omitempty" jsonschema:"Sections to include: momentum, focus, time, reading, completions. Defaults to all."`
+}
+
+// GetWeeklySummaryOutput is the output for the get_weekly_summary tool.
+type GetWeeklySummaryOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// Register registers review tools with the MCP server.
+func (t *ReviewTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_weekly_summary",
+		Description: "Render the weekly summary for this or a past week, optionally limited to some sections (momentum, focus, time, reading, completions) and at brief, normal or detailed verbosity",
+	}, t.getWeeklySummary)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "generate_weekly_review",
+		Description: "Render the weekly summary (completions, GitHub activity, reading, overdue items, time tracked) and archive it as reviews/<year>-W<week>.md in the data repo",
+	}, t.generateWeeklyReview)
+}
+
+func (t *ReviewTools) getWeeklySummary(ctx context.Context, req *mcp.CallToolRequest, input GetWeeklySummaryInput) (*mcp.CallToolResult, GetWeeklySummaryOutput, error) {
+	if input.WeeksAgo < 0 {
+		return nil, GetWeeklySummaryOutput{
+			Success: false,
+			Message: "weeks_ago must be 0 or more",
+		}, nil
+	}
+	opts, err := summary.ParseOptions(input.Sections, input.Verbosity)
+	if err != nil {
+		return nil, GetWeeklySummaryOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid options: %v", err),
+		}, nil
+	}
+
+	now := t.clock.Now()
+	weekStart := analytics.WeekStart(now).AddDate(0, 0, -7*input.WeeksAgo)
+	text := t.renderer.Render(ctx, analytics.AsOf(weekStart, now), opts)
+	return textResult(text), GetWeeklySummaryOutput{
+		Success: true,
+		Message: text,
+	}, nil
+}
+
+func (t *ReviewTools) generateWeeklyReview(ctx context.Context, req *mcp.CallToolRequest, input GenerateWeeklyReviewInput) (*mcp.CallToolResult, GenerateWeeklyReviewOutput, error) {
+	now := t.clock.Now()
+	weekStart := analytics.WeekStart(now)
+	if input.Week != "" {
+		var err error
+		if weekStart, err = analytics.ParseWeek(input.Week); err != nil {
+			return nil, GenerateWeeklyReviewOutput{
+				Success: false,
+				Message: fmt.Sprintf("Invalid week %q. Use an ISO week like 2026-W06 or a date (YYYY-MM-DD)", input.Week),
+			}, nil
+		}
+	}
+	if weekStart.After(now) {
+		return nil, GenerateWeeklyReviewOutput{
+			Success: false,
+			Message: fmt.Sprintf("Week %s hasn't started yet", analytics.ISOWeek(weekStart)),
+		}, nil
+	}
+
+	week := analytics.ISOWeek(weekStart)
+	path := fmt.Sprintf("%s/%s.md", reviewsDir, week)
+
+	_, sha, err := t.storage.ReadFile(ctx, path)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, GenerateWeeklyReviewOutput{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	exists := err == nil
+	if exists && !input.Overwrite {
+		return nil, GenerateWeeklyReviewOutput{
+			Success: false,
+			Message: fmt.Sprintf("A review for %s already exists at %s. Set overwrite to regenerate it.", week, path),
+		}, nil
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# Weekly Review: %s\n\n", week))
+	b.WriteString(fmt.Sprintf("*Generated %s*\n\n", now.Format("2006-01-02 15:04 MST")))
+	b.WriteString(t.renderer.Render(ctx, analytics.AsOf(weekStart, now), summary.Options{}))
+	content := b.String()
+
+	if err := t.storage.WriteFile(ctx, path, content, sha, fmt.Sprintf("Weekly review: %s", week)); err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, GenerateWeeklyReviewOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, GenerateWeeklyReviewOutput{}, fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	jsonBytes, err := json.Marshal(WeeklyReviewResult{
+		Week:        week,
+		Path:        path,
+		Overwritten: exists,
+		Content:     content,
+	})
+	if err != nil {
+		return nil, GenerateWeeklyReviewOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, GenerateWeeklyReviewOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/internal/summary"
+)
+
+// recordingRenderer records the arguments of its last Render call.
+type recordingRenderer struct {
+	now  time.Time
+	opts summary.Options
+}
+
+func (r *recordingRenderer) Render(ctx context.Context, now time.Time, opts summary.Options) string {
+	r.now, r.opts = now, opts
+	return "## Weekly Summary\n"
+}
+
+func TestGetWeeklySummary(t *testing.T) {
+	// Wednesday
+	now := time.Date(2026, 2, 4, 10, 0, 0, 0, time.UTC)
+	r := &recordingRenderer{}
+	rt := NewReviewTools(newFileStorage(nil), r, clock.NewFake(now))
+
+	_, out, err := rt.getWeeklySummary(context.Background(), nil, GetWeeklySummaryInput{
+		WeeksAgo:  1,
+		Verbosity: "Brief",
+		Sections:  []string{"focus", "completions"},
+	})
+	if err != nil || !out.Success {
+		t.Fatalf("getWeeklySummary() = %+v, %v", out, err)
+	}
+	// Last week is rendered as of its final second
+	if want := time.Date(2026, 2, 1, 23, 59, 59, 0, time.UTC); !r.now.Equal(want) {
+		t.Errorf("rendered as of %v, want %v", r.now, want)
+	}
+	if !r.opts.Brief() || !r.opts.Includes("focus") || r.opts.Includes("momentum") {
+		t.Errorf("unexpected options %+v", r.opts)
+	}
+
+	// This week is rendered as of now
+	if _, _, err := rt.getWeeklySummary(context.Background(), nil, GetWeeklySummaryInput{}); err != nil || !r.now.Equal(now) {
+		t.Errorf("rendered as of %v, want %v (err %v)", r.now, now, err)
+	}
+
+	for _, input := range []GetWeeklySummaryInput{
+		{WeeksAgo: -1},
+		{Verbosity: "verbose"},
+		{Sections: []string{"github"}},
+	} {
+		if _, out, _ := rt.getWeeklySummary(context.Background(), nil, input); out.Success {
+			t.Errorf("getWeeklySummary(%+v) succeeded, want a validation error", input)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// Sort keys accepted by the list tools' sort parameter. Prefixing a key with
+// "-" reverses the order.
+const (
+	sortAdded        = "added"
+	sortDue          = "due"
+	sortPriority     = "priority"
+	sortAlphabetical = "alphabetical"
+	sortCompleted    = "completed"
+)
+
+// parseSort validates a sort parameter against the keys a tool supports and
+// returns the key and whether to reverse it. An empty sort keeps file order.
+func parseSort(s string, keys ...string) (key string, desc bool, errMsg string) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return "", false, ""
+	}
+	key, desc = strings.CutPrefix(s, "-")
+	for _, k := range keys {
+		if key == k {
+			return key, desc, ""
+		}
+	}
+	return "", false, fmt.Sprintf("Invalid sort %q. Use: %s, optionally prefixed with -", s, joinOr(keys))
+}
+
+// joinOr joins keys as "a, b, or c".
+func joinOr(keys []string) string {
+	if len(keys) < 2 {
+		return strings.Join(keys, "")
+	}
+	return strings.Join(keys[:len(keys)-1], ", ") + ", or " + keys[len(keys)-1]
+}
+
+// sortItems stably sorts items by the string value returns. Dates sort
+// correctly as YYYY-MM-DD strings. Items without a value go last in either
+// direction.
+func sortItems[T any](items []T, desc bool, value func(T) string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := value(items[i]), value(items[j])
+		if a == "" || b == "" {
+			return a != "" && b == ""
+		}
+		if desc {
+			return a > b
+		}
+		return a < b
+	})
+}
+
+// priorityRank orders priorities from most to least urgent.
+func priorityRank(p string) string {
+	switch p {
+	case "urgent":
+		return "0"
+	case "high":
+		return "1"
+	case "normal":
+		return "2"
+	case "someday":
+		return "3"
+	}
+	return ""
+}
+
+// readingPriorityRank orders next before unprioritised items before someday.
+func readingPriorityRank(p string) string {
+	switch p {
+	case storage.ReadingPriorityNext:
+		return "0"
+	case storage.ReadingPrioritySomeday:
+		return "2"
+	}
+	return "1"
+}
+
+func derefDate(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func sortTodoItems(items []TodoItem, key string, desc bool) {
+	switch key {
+	case sortAdded:
+		sortItems(items, desc, func(t TodoItem) string { return t.Added })
+	case sortPriority:
+		sortItems(items, desc, func(t TodoItem) string { return priorityRank(t.Priority) })
+	case sortAlphabetical:
+		sortItems(items, desc, func(t TodoItem) string { return strings.ToLower(t.Text) })
+	case sortCompleted:
+		sortItems(items, desc, func(t TodoItem) string { return derefDate(t.CompletedAt) })
+	}
+}
+
+func sortReminderItems(items []ReminderItem, key string, desc bool) {
+	switch key {
+	case sortDue:
+		sortItems(items, desc, func(r ReminderItem) string { return r.Date })
+	case sortAdded:
+		sortItems(items, desc, func(r ReminderItem) string { return r.Added })
+	case sortAlphabetical:
+		sortItems(items, desc, func(r ReminderItem) string { return strings.ToLower(r.Text) })
+	case sortCompleted:
+		sortItems(items, desc, func(r ReminderItem) string { return derefDate(r.CompletedAt) })
+	}
+}
+
+func sortReadingItems(items []ReadingListItem, key string, desc bool) {
+	switch key {
+	case sortPriority:
+		sortItems(items, desc, func(r ReadingListItem) string { return readingPriorityRank(r.Priority) })
+	case sortAdded:
+		sortItems(items, desc, func(r ReadingListItem) string { return r.Added })
+	case sortAlphabetical:
+		sortItems(items, desc, func(r ReadingListItem) string { return strings.ToLower(r.URL) })
+	case sortCompleted:
+		sortItems(items, desc, func(r ReadingListItem) string { return derefDate(r.ReadAt) })
+	}
+}
+
+func sortMilestoneItems(items []MilestoneItem, key string, desc bool) {
+	switch key {
+	case sortDue:
+		sortItems(items, desc, func(m MilestoneItem) string { return derefDate(m.Due) })
+	case sortAdded:
+		sortItems(items, desc, func(m MilestoneItem) string { return m.Added })
+	case sortAlphabetical:
+		sortItems(items, desc, func(m MilestoneItem) string { return strings.ToLower(m.Text) })
+	case sortCompleted:
+		sortItems(items, desc, func(m MilestoneItem) string { return derefDate(m.CompletedAt) })
+	}
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestListTodos_Sort(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.TodosFile: "# Active Todos\n\n## High Priority\n- [ ] Beta {id:aaaa1111,added:2026-02-03}\n\n" +
+			"## Normal\n- [ ] alpha {id:bbbb2222,added:2026-01-15}\n- [ ] Gamma {id:cccc3333}\n\n" +
+			"## Someday\n- [ ] Delta {id:dddd4444,added:2026-02-01}\n\n# Completed\n",
+	})
+
+	tests := []struct {
+		sort string
+		want string
+	}{
+		{"", "Beta,alpha,Gamma,Delta"},
+		{"added", "alpha,Delta,Beta,Gamma"},
+		{"-added", "Beta,Delta,alpha,Gamma"}, // undated stays last
+		{"priority", "Beta,alpha,Gamma,Delta"},
+		{"-priority", "Delta,alpha,Gamma,Beta"},
+		{"Alphabetical", "alpha,Beta,Delta,Gamma"},
+	}
+	for _, tt := range tests {
+		_, out, err := NewTodoTools(files, nil, WIPLimits{}).listTodos(context.Background(), nil, ListTodosInput{Sort: tt.sort})
+		if err != nil || !out.Success {
+			t.Fatalf("sort %q: listTodos() = %+v, %v", tt.sort, out, err)
+		}
+		var got []string
+		for _, todo := range out.Result.Todos {
+			got = append(got, todo.Text)
+		}
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("sort %q = %s, want %s", tt.sort, strings.Join(got, ","), tt.want)
+		}
+	}
+
+	_, out, _ := NewTodoTools(files, nil, WIPLimits{}).listTodos(context.Background(), nil, ListTodosInput{Sort: "due"})
+	if out.Success || !strings.Contains(out.Message, `Invalid sort "due"`) {
+		t.Errorf("expected todos to reject sorting by due, got %+v", out)
+	}
+}
+
+func TestGetMilestones_Sort(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.StrategyFile: "## Current Phase\nLaunch\n\n## Active Milestones\n" +
+			"- [ ] Beta — Due: 2026-04-01 {id:aaaa1111}\n" +
+			"- [ ] Docs {id:bbbb2222}\n" +
+			"- [ ] Alpha — Due: 2026-03-01 {id:cccc3333}\n",
+	})
+	_, out, err := NewStrategyTools(files, nil, nil).getMilestones(context.Background(), nil, GetMilestonesInput{Sort: "due"})
+	if err != nil || !out.Success {
+		t.Fatalf("getMilestones() = %+v, %v", out, err)
+	}
+	var got []string
+	for _, m := range out.Result.ActiveMilestones {
+		got = append(got, m.Text)
+	}
+	if strings.Join(got, ",") != "Alpha,Beta,Docs" {
+		t.Errorf("sorted milestones = %v", got)
+	}
+}
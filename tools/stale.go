@@ -0,0 +1,210 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// StaleThresholds sets how old an item must be before get_stale_items
+// reports it.
+type StaleThresholds struct {
+	// TodoDays is the age in days after which an active todo is stale.
+	TodoDays int
+	// ReadingWeeks is the age in weeks after which an unread item is stale.
+	ReadingWeeks int
+	// MilestoneOverdueDays is how many days past due an active milestone
+	// must be to count as stale.
+	MilestoneOverdueDays int
+}
+
+// DefaultStaleThresholds is used for any threshold left at zero.
+var DefaultStaleThresholds = StaleThresholds{
+	TodoDays:             30,
+	ReadingWeeks:         8,
+	MilestoneOverdueDays: 7,
+}
+
+// withDefaults fills zero thresholds from DefaultStaleThresholds.
+func (s StaleThresholds) withDefaults() StaleThresholds {
+	if s.TodoDays <= 0 {
+		s.TodoDays = DefaultStaleThresholds.TodoDays
+	}
+	if s.ReadingWeeks <= 0 {
+		s.ReadingWeeks = DefaultStaleThresholds.ReadingWeeks
+	}
+	if s.MilestoneOverdueDays <= 0 {
+		s.MilestoneOverdueDays = DefaultStaleThresholds.MilestoneOverdueDays
+	}
+	return s
+}
+
+// StaleTools surfaces items that have sat untouched long enough to be worth
+// dropping or rescheduling.
+type StaleTools struct {
+	storage    storage.Storage
+	clock      clock.Clock
+	thresholds StaleThresholds
+}
+
+// NewStaleTools creates a new StaleTools instance. A nil clock uses the
+// system clock; zero thresholds use DefaultStaleThresholds.
+func NewStaleTools(s storage.Storage, c clock.Clock, thresholds StaleThresholds) *StaleTools {
+	return &StaleTools{storage: s, clock: clock.Or(c), thresholds: thresholds.withDefaults()}
+}
+
+// GetStaleItemsInput is the input schema for the get_stale_items tool.
+type GetStaleItemsInput struct {
+	TodoDays             int `json:"todo_days,omitempty" jsonschema:"Report active todos added at least this many days ago. Defaults to the server setting (30)."`
+	ReadingWeeks         int `json:"reading_weeks,omitempty" jsonschema:"Report unread items added at least this many weeks ago. Defaults to the server setting (8)."`
+	MilestoneOverdueDays int `json:"milestone_overdue_days,omitempty" jsonschema:"Report active milestones more than this many days past due. Defaults to the server setting (7)."`
+}
+
+// GetStaleItemsOutput is the output for the get_stale_items tool.
+type GetStaleItemsOutput struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message"`
+	Result  *StaleItemsResult `json:"result,omitempty"`
+}
+
+// StaleItemsResult is the response payload for get_stale_items. Each list is
+// ordered stalest first.
+type StaleItemsResult struct {
+	Todos      []StaleItem `json:"todos"`
+	Reading    []StaleItem `json:"reading"`
+	Milestones []StaleItem `json:"milestones"`
+	// Thresholds are the values the lists were built with.
+	TodoDays             int `json:"todo_days"`
+	ReadingWeeks         int `json:"reading_weeks"`
+	MilestoneOverdueDays int `json:"milestone_overdue_days"`
+}
+
+// StaleItem is an item reported by get_stale_items.
+type StaleItem struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	// Since is the date the age is counted from: the added date for todos
+	// and reading items, the due date for milestones.
+	Since string `json:"since"`
+	Days  int    `json:"days"`
+}
+
+// Register registers the stale item tool with the MCP server.
+func (t *StaleTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_stale_items",
+		Description: "List items that may be worth dropping: active todos added long ago, unread reading items that have waited for weeks, and milestones well past due. Thresholds can be set per call.",
+	}, t.getStaleItems)
+}
+
+func (t *StaleTools) getStaleItems(ctx context.Context, req *mcp.CallToolRequest, input GetStaleItemsInput) (*mcp.CallToolResult, GetStaleItemsOutput, error) {
+	if input.TodoDays < 0 || input.ReadingWeeks < 0 || input.MilestoneOverdueDays < 0 {
+		return nil, GetStaleItemsOutput{
+			Success: false,
+			Message: "Thresholds must be positive",
+		}, nil
+	}
+
+	th := StaleThresholds{
+		TodoDays:             input.TodoDays,
+		ReadingWeeks:         input.ReadingWeeks,
+		MilestoneOverdueDays: input.MilestoneOverdueDays,
+	}
+	if th.TodoDays == 0 {
+		th.TodoDays = t.thresholds.TodoDays
+	}
+	if th.ReadingWeeks == 0 {
+		th.ReadingWeeks = t.thresholds.ReadingWeeks
+	}
+	if th.MilestoneOverdueDays == 0 {
+		th.MilestoneOverdueDays = t.thresholds.MilestoneOverdueDays
+	}
+
+	today := clock.Today(t.clock)
+	result := StaleItemsResult{
+		Todos:                []StaleItem{},
+		Reading:              []StaleItem{},
+		Milestones:           []StaleItem{},
+		TodoDays:             th.TodoDays,
+		ReadingWeeks:         th.ReadingWeeks,
+		MilestoneOverdueDays: th.MilestoneOverdueDays,
+	}
+
+	// Items without an added date can't be aged, so they are skipped
+	content, _, err := t.storage.ReadFile(ctx, storage.TodosFile)
+	if err != nil {
+		return nil, GetStaleItemsOutput{}, fmt.Errorf("reading todos.md: %w", err)
+	}
+	tf, err := parseTodos(ctx, content)
+	if err != nil {
+		return nil, GetStaleItemsOutput{}, fmt.Errorf("parsing todos: %w", err)
+	}
+	for _, todo := range tf.Active {
+		if days := int(daysBetween(todo.Added, today)); !todo.Added.IsZero() && days >= th.TodoDays {
+			result.Todos = append(result.Todos, StaleItem{ID: todo.ID, Text: todo.Text, Since: formatDate(todo.Added), Days: days})
+		}
+	}
+
+	content, _, err = t.storage.ReadFile(ctx, storage.ReadingListFile)
+	if err != nil {
+		return nil, GetStaleItemsOutput{}, fmt.Errorf("reading reading-list.md: %w", err)
+	}
+	rl, err := parseReadingList(ctx, content)
+	if err != nil {
+		return nil, GetStaleItemsOutput{}, fmt.Errorf("parsing reading list: %w", err)
+	}
+	for _, item := range rl.ToRead {
+		if days := int(daysBetween(item.Added, today)); !item.Added.IsZero() && days >= th.ReadingWeeks*7 {
+			result.Reading = append(result.Reading, StaleItem{ID: item.ID, Text: item.URL, Since: formatDate(item.Added), Days: days})
+		}
+	}
+
+	content, _, err = t.storage.ReadFile(ctx, storage.StrategyFile)
+	if err != nil {
+		return nil, GetStaleItemsOutput{}, fmt.Errorf("reading strategy.md: %w", err)
+	}
+	s, err := parseStrategy(ctx, content)
+	if err != nil {
+		return nil, GetStaleItemsOutput{}, fmt.Errorf("parsing strategy: %w", err)
+	}
+	for _, m := range s.ActiveMilestones {
+		if m.Due == nil {
+			continue
+		}
+		if days := int(daysBetween(*m.Due, today)); days > th.MilestoneOverdueDays {
+			result.Milestones = append(result.Milestones, StaleItem{ID: m.ID, Text: m.Text, Since: formatDate(*m.Due), Days: days})
+		}
+	}
+
+	for _, items := range [][]StaleItem{result.Todos, result.Reading, result.Milestones} {
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Days > items[j].Days })
+	}
+
+	text := result.text()
+	return textResult(text), GetStaleItemsOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+func (r StaleItemsResult) text() string {
+	var sb strings.Builder
+	section := func(title string, items []StaleItem, age string) {
+		fmt.Fprintf(&sb, "%s (%d)\n", title, len(items))
+		for _, item := range items {
+			fmt.Fprintf(&sb, "- %s (id %s, %s %s, %s)\n", item.Text, item.ID, age, item.Since, plural(item.Days, "day"))
+		}
+	}
+	section(fmt.Sprintf("Todos added %d+ days ago", r.TodoDays), r.Todos, "added")
+	sb.WriteString("\n")
+	section(fmt.Sprintf("Unread for %d+ weeks", r.ReadingWeeks), r.Reading, "added")
+	sb.WriteString("\n")
+	section(fmt.Sprintf("Milestones more than %d days overdue", r.MilestoneOverdueDays), r.Milestones, "due")
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,42 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestGetStaleItems(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.TodosFile: "# Active Todos\n\n## Normal\n- [ ] Old {id:aaaa1111,added:2026-01-01}\n- [ ] Older {id:bbbb2222,added:2025-12-01}\n" +
+			"- [ ] Fresh {id:cccc3333,added:2026-02-05}\n- [ ] Undated {id:dddd4444}\n\n# Completed\n",
+		storage.ReadingListFile: "## To Read\n- [ ] https://a.example {id:eeee5555,added:2025-12-01}\n- [ ] https://b.example {id:ffff6666,added:2026-02-01}\n",
+		storage.StrategyFile: "## Current Phase\nLaunch\n\n## Active Milestones\n" +
+			"- [ ] Beta — Due: 2026-01-20 {id:abab1212}\n- [ ] Launch — Due: 2026-02-05 {id:cdcd3434}\n",
+	})
+	tools := NewStaleTools(files, clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)), StaleThresholds{})
+
+	_, out, err := tools.getStaleItems(context.Background(), nil, GetStaleItemsInput{})
+	if err != nil || !out.Success {
+		t.Fatalf("getStaleItems() = %+v, %v", out, err)
+	}
+	r := out.Result
+	if len(r.Todos) != 2 || r.Todos[0].ID != "bbbb2222" || r.Todos[1].ID != "aaaa1111" || r.Todos[1].Days != 40 {
+		t.Errorf("unexpected todos %+v", r.Todos)
+	}
+	if len(r.Reading) != 1 || r.Reading[0].ID != "eeee5555" {
+		t.Errorf("unexpected reading %+v", r.Reading)
+	}
+	if len(r.Milestones) != 1 || r.Milestones[0].ID != "abab1212" || r.Milestones[0].Days != 21 {
+		t.Errorf("unexpected milestones %+v", r.Milestones)
+	}
+
+	// Per-call thresholds override the defaults
+	_, out, _ = tools.getStaleItems(context.Background(), nil, GetStaleItemsInput{TodoDays: 60, MilestoneOverdueDays: 2})
+	if r := out.Result; len(r.Todos) != 1 || len(r.Milestones) != 2 || r.TodoDays != 60 || r.ReadingWeeks != 8 {
+		t.Errorf("unexpected result with overrides %+v", r)
+	}
+}
@@ -0,0 +1,454 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// defaultStatsWindowDays is the get_stats window when none is given.
+const defaultStatsWindowDays = 28
+
+// StatsTools computes productivity statistics from the dates already recorded
+// in item metadata (added, completed, due, read).
+type StatsTools struct {
+	storage storage.Storage
+	clock   clock.Clock
+}
+
+// NewStatsTools creates a new StatsTools instance. A nil clock uses the system clock.
+func NewStatsTools(s storage.Storage, c clock.Clock) *StatsTools {
+	return &StatsTools{storage: s, clock: clock.Or(c)}
+}
+
+// GetStatsInput is the input schema for the get_stats tool.
+type GetStatsInput struct {
+	WindowDays int    `json:"window_days,omitempty" jsonschema:"Length of the window in days (default 28)"`
+	Until      string `json:"until,omitempty" jsonschema:"Last day of the window (YYYY-MM-DD). Defaults to today."`
+}
+
+// GetStatsOutput is the output for the get_stats tool.
+type GetStatsOutput struct {
+	Success bool         `json:"success"`
+	Message string       `json:"message"`
+	Result  *StatsResult `json:"result,omitempty"`
+}
+
+// StatsResult is the response payload for get_stats.
+type StatsResult struct {
+	WindowStart string          `json:"window_start"`
+	WindowEnd   string          `json:"window_end"`
+	Todos       TodoStats       `json:"todos"`
+	Reminders   DueStats        `json:"reminders"`
+	Milestones  MilestoneStats  `json:"milestones"`
+	Reading     ReadingStats    `json:"reading"`
+	Streaks     CompletionStats `json:"streaks"`
+}
+
+// TodoStats covers todo velocity and age.
+type TodoStats struct {
+	Completed      int     `json:"completed"`
+	PerWeek        float64 `json:"per_week"`
+	Added          int     `json:"added"`
+	AvgDaysToDone  float64 `json:"avg_days_to_complete"`
+	Active         int     `json:"active"`
+	AvgActiveAge   float64 `json:"avg_active_age_days"`
+	OldestActiveID string  `json:"oldest_active_id,omitempty"`
+}
+
+// DueStats covers items with due dates that fell in the window.
+type DueStats struct {
+	Due     int `json:"due"`
+	OnTime  int `json:"on_time"`
+	Late    int `json:"late"`
+	Overdue int `json:"still_overdue"`
+	// OverdueRate is (late + still overdue) / due, or 0 if nothing was due.
+	OverdueRate float64 `json:"overdue_rate"`
+}
+
+// MilestoneStats covers milestone due dates and burn-down.
+type MilestoneStats struct {
+	DueStats
+	Completed int             `json:"completed"`
+	BurnDown  []BurnDownPoint `json:"burn_down"`
+}
+
+// BurnDownPoint is the number of open milestones at the end of one week.
+type BurnDownPoint struct {
+	WeekEnding string `json:"week_ending"`
+	Open       int    `json:"open"`
+	Completed  int    `json:"completed"`
+}
+
+// ReadingStats covers reading throughput.
+type ReadingStats struct {
+	Read          int     `json:"read"`
+	PerWeek       float64 `json:"per_week"`
+	Added         int     `json:"added"`
+	AvgDaysToRead float64 `json:"avg_days_to_read"`
+	Backlog       int     `json:"backlog"`
+}
+
+// CompletionStats covers runs of consecutive days with at least one
+// completion (todo, milestone, reminder or article read), across all history.
+type CompletionStats struct {
+	Current      int    `json:"current_days"`
+	Longest      int    `json:"longest_days"`
+	LongestEnded string `json:"longest_ended,omitempty"`
+	ActiveDays   int    `json:"active_days_in_window"`
+}
+
+// Register registers stats tools with the MCP server.
+func (t *StatsTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_stats",
+		Description: "Get productivity statistics over a time window: todo completion velocity and age, reminder and milestone overdue rates, milestone burn-down, reading throughput, and completion streaks",
+	}, t.getStats)
+}
+
+// statsData is everything get_stats reads.
+type statsData struct {
+	todos     *storage.TodoFile
+	reminders *storage.ReminderFile
+	strategy  *storage.Strategy
+	reading   *storage.ReadingList
+}
+
+func (t *StatsTools) getStats(ctx context.Context, req *mcp.CallToolRequest, input GetStatsInput) (*mcp.CallToolResult, GetStatsOutput, error) {
+	window := input.WindowDays
+	if window == 0 {
+		window = defaultStatsWindowDays
+	}
+	if window < 1 || window > 3660 {
+		return nil, GetStatsOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid window_days %d. Use a number of days between 1 and 3660.", input.WindowDays),
+		}, nil
+	}
+
+	today := clock.Today(t.clock)
+	end := today
+	if strings.TrimSpace(input.Until) != "" {
+		d, err := parseDate(input.Until, t.clock.Now())
+		if err != nil {
+			return nil, GetStatsOutput{
+				Success: false,
+				Message: fmt.Sprintf("Invalid until date %q. Use YYYY-MM-DD format.", input.Until),
+			}, nil
+		}
+		end = d
+	}
+	start := end.AddDate(0, 0, -(window - 1))
+
+	data, err := loadStatsData(ctx, t.storage)
+	if err != nil {
+		return nil, GetStatsOutput{}, err
+	}
+
+	result := computeStats(data, start, end, today)
+
+	text := result.text()
+	return textResult(text), GetStatsOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+func (r StatsResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Stats from %s to %s\n", r.WindowStart, r.WindowEnd)
+	t := r.Todos
+	fmt.Fprintf(&sb, "Todos: %d completed (%.1f/week), %d added, %.1f days to complete on average; %d active, %.1f days old on average%s\n",
+		t.Completed, t.PerWeek, t.Added, t.AvgDaysToDone, t.Active, t.AvgActiveAge, itemDetails(labeled("oldest", t.OldestActiveID)))
+	fmt.Fprintf(&sb, "Reminders: %s\n", r.Reminders.text())
+	m := r.Milestones
+	fmt.Fprintf(&sb, "Milestones: %d completed; %s\n", m.Completed, m.DueStats.text())
+	for _, p := range m.BurnDown {
+		fmt.Fprintf(&sb, "- week ending %s: %d open, %d completed\n", p.WeekEnding, p.Open, p.Completed)
+	}
+	rd := r.Reading
+	fmt.Fprintf(&sb, "Reading: %d read (%.1f/week), %d added, %.1f days to read on average, %d in the backlog\n",
+		rd.Read, rd.PerWeek, rd.Added, rd.AvgDaysToRead, rd.Backlog)
+	st := r.Streaks
+	fmt.Fprintf(&sb, "Streaks: current %s, longest %s%s, %s active in the window",
+		plural(st.Current, "day"), plural(st.Longest, "day"), itemDetails(labeled("ended", st.LongestEnded)), plural(st.ActiveDays, "day"))
+	return sb.String()
+}
+
+func (d DueStats) text() string {
+	return fmt.Sprintf("%d due, %d on time, %d late, %d still overdue (%.0f%% overdue)",
+		d.Due, d.OnTime, d.Late, d.Overdue, d.OverdueRate*100)
+}
+
+// loadStatsData reads the data files, treating missing ones as empty.
+func loadStatsData(ctx context.Context, s storage.Storage) (*statsData, error) {
+	data := &statsData{
+		todos:     &storage.TodoFile{},
+		reminders: &storage.ReminderFile{},
+		strategy:  &storage.Strategy{},
+		reading:   &storage.ReadingList{},
+	}
+	read := func(path string) (string, bool, error) {
+		content, _, err := s.ReadFile(ctx, path)
+		if errors.Is(err, storage.ErrNotFound) {
+			return "", false, nil
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("reading %s: %w", path, err)
+		}
+		return content, true, nil
+	}
+
+	if content, ok, err := read(storage.TodosFile); err != nil {
+		return nil, err
+	} else if ok {
+		if data.todos, err = parseTodos(ctx, content); err != nil {
+			return nil, fmt.Errorf("parsing todos: %w", err)
+		}
+	}
+	if content, ok, err := read(storage.RemindersFile); err != nil {
+		return nil, err
+	} else if ok {
+		if data.reminders, err = parseReminders(ctx, content); err != nil {
+			return nil, fmt.Errorf("parsing reminders: %w", err)
+		}
+	}
+	if content, ok, err := read(storage.StrategyFile); err != nil {
+		return nil, err
+	} else if ok {
+		if data.strategy, err = parseStrategy(ctx, content); err != nil {
+			return nil, fmt.Errorf("parsing strategy: %w", err)
+		}
+	}
+	if content, ok, err := read(storage.ReadingListFile); err != nil {
+		return nil, err
+	} else if ok {
+		if data.reading, err = parseReadingList(ctx, content); err != nil {
+			return nil, fmt.Errorf("parsing reading list: %w", err)
+		}
+	}
+	return data, nil
+}
+
+// computeStats derives all statistics for the window [start, end]. today
+// decides what is still overdue and how old active items are.
+func computeStats(data *statsData, start, end, today time.Time) StatsResult {
+	inWindow := func(d time.Time) bool {
+		return !d.IsZero() && !d.Before(start) && !d.After(end)
+	}
+	weeks := float64(end.Sub(start).Hours()/24+1) / 7
+
+	result := StatsResult{
+		WindowStart: formatDate(start),
+		WindowEnd:   formatDate(end),
+	}
+	completionDays := make(map[time.Time]bool)
+
+	// Todos
+	var doneDays []float64
+	for _, todo := range data.todos.Completed {
+		if todo.CompletedAt == nil {
+			continue
+		}
+		completionDays[*todo.CompletedAt] = true
+		if inWindow(*todo.CompletedAt) {
+			result.Todos.Completed++
+			if !todo.Added.IsZero() {
+				doneDays = append(doneDays, daysBetween(todo.Added, *todo.CompletedAt))
+			}
+		}
+	}
+	var activeAges []float64
+	var oldest time.Time
+	for _, todo := range data.todos.Active {
+		if todo.Added.IsZero() {
+			continue
+		}
+		activeAges = append(activeAges, daysBetween(todo.Added, today))
+		if oldest.IsZero() || todo.Added.Before(oldest) {
+			oldest = todo.Added
+			result.Todos.OldestActiveID = todo.ID
+		}
+	}
+	for _, list := range [][]storage.Todo{data.todos.Active, data.todos.Completed} {
+		for _, todo := range list {
+			if inWindow(todo.Added) {
+				result.Todos.Added++
+			}
+		}
+	}
+	result.Todos.PerWeek = round1(float64(result.Todos.Completed) / weeks)
+	result.Todos.AvgDaysToDone = round1(mean(doneDays))
+	result.Todos.Active = len(data.todos.Active)
+	result.Todos.AvgActiveAge = round1(mean(activeAges))
+
+	// Reminders
+	for _, r := range data.reminders.Completed {
+		if r.CompletedAt != nil {
+			completionDays[*r.CompletedAt] = true
+		}
+		if inWindow(r.Date) {
+			result.Reminders.countDue(r.CompletedAt, r.Date, today)
+		}
+	}
+	for _, r := range data.reminders.Upcoming {
+		if inWindow(r.Date) {
+			result.Reminders.countDue(nil, r.Date, today)
+		}
+	}
+	result.Reminders.finish()
+
+	// Milestones
+	all := append(append([]storage.Milestone{}, data.strategy.ActiveMilestones...), data.strategy.CompletedMilestones...)
+	for _, m := range all {
+		var completedAt *time.Time
+		if m.Completed {
+			completedAt = m.CompletedAt
+		}
+		if completedAt != nil {
+			completionDays[*completedAt] = true
+			if inWindow(*completedAt) {
+				result.Milestones.Completed++
+			}
+		}
+		if m.Due != nil && inWindow(*m.Due) {
+			result.Milestones.countDue(completedAt, *m.Due, today)
+		}
+	}
+	result.Milestones.finish()
+	result.Milestones.BurnDown = milestoneBurnDown(all, start, end)
+
+	// Reading
+	var readDays []float64
+	for _, item := range data.reading.Read {
+		if item.ReadAt == nil {
+			continue
+		}
+		completionDays[*item.ReadAt] = true
+		if inWindow(*item.ReadAt) {
+			result.Reading.Read++
+			if !item.Added.IsZero() {
+				readDays = append(readDays, daysBetween(item.Added, *item.ReadAt))
+			}
+		}
+	}
+	for _, list := range [][]storage.ReadingItem{data.reading.ToRead, data.reading.Read} {
+		for _, item := range list {
+			if inWindow(item.Added) {
+				result.Reading.Added++
+			}
+		}
+	}
+	result.Reading.PerWeek = round1(float64(result.Reading.Read) / weeks)
+	result.Reading.AvgDaysToRead = round1(mean(readDays))
+	result.Reading.Backlog = len(data.reading.ToRead)
+
+	result.Streaks = completionStreaks(completionDays, start, end, today)
+	return result
+}
+
+// countDue classifies one item due in the window.
+func (s *DueStats) countDue(completedAt *time.Time, due, today time.Time) {
+	switch {
+	case completedAt != nil && completedAt.After(due):
+		s.Due++
+		s.Late++
+	case completedAt != nil:
+		s.Due++
+		s.OnTime++
+	case due.Before(today):
+		s.Due++
+		s.Overdue++
+	default:
+		// Due later today or in the window's future part; not yet decided
+	}
+}
+
+func (s *DueStats) finish() {
+	if s.Due > 0 {
+		s.OverdueRate = round2(float64(s.Late+s.Overdue) / float64(s.Due))
+	}
+}
+
+// milestoneBurnDown counts open and completed milestones at the end of each
+// week in the window, the last point being the window's end.
+func milestoneBurnDown(milestones []storage.Milestone, start, end time.Time) []BurnDownPoint {
+	points := []BurnDownPoint{}
+	var ends []time.Time
+	for d := end; !d.Before(start); d = d.AddDate(0, 0, -7) {
+		ends = append([]time.Time{d}, ends...)
+	}
+	for _, e := range ends {
+		p := BurnDownPoint{WeekEnding: formatDate(e)}
+		for _, m := range milestones {
+			if !m.Added.IsZero() && m.Added.After(e) {
+				continue
+			}
+			if m.Completed && m.CompletedAt != nil && !m.CompletedAt.After(e) {
+				p.Completed++
+			} else {
+				p.Open++
+			}
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+// completionStreaks finds the current and longest runs of consecutive
+// completion days. The current streak may end yesterday, so it isn't broken
+// before today's work is done.
+func completionStreaks(days map[time.Time]bool, start, end, today time.Time) CompletionStats {
+	var s CompletionStats
+	var longestEnd time.Time
+	for d := range days {
+		if !d.Before(start) && !d.After(end) {
+			s.ActiveDays++
+		}
+		// Only count from the first day of a run
+		if days[d.AddDate(0, 0, -1)] {
+			continue
+		}
+		n := 1
+		for days[d.AddDate(0, 0, n)] {
+			n++
+		}
+		last := d.AddDate(0, 0, n-1)
+		// Ties go to the most recent run, so the result doesn't depend on map order
+		if n > s.Longest || (n == s.Longest && last.After(longestEnd)) {
+			s.Longest = n
+			longestEnd = last
+		}
+		if last.Equal(today) || last.Equal(today.AddDate(0, 0, -1)) {
+			s.Current = n
+		}
+	}
+	s.LongestEnded = formatDate(longestEnd)
+	return s
+}
+
+func daysBetween(from, to time.Time) float64 {
+	return to.Sub(from).Hours() / 24
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func round1(v float64) float64 { return math.Round(v*10) / 10 }
+func round2(v float64) float64 { return math.Round(v*100) / 100 }
@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestComputeStats(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 2, d, 0, 0, 0, 0, time.UTC) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	data := &statsData{
+		todos: &storage.TodoFile{
+			Active: []storage.Todo{
+				{ID: "old", Added: day(1)},
+				{ID: "new", Added: day(13)},
+			},
+			Completed: []storage.Todo{
+				{ID: "a", Added: day(2), CompletedAt: ptr(day(4)), Completed: true},
+				{ID: "b", Added: day(3), CompletedAt: ptr(day(5)), Completed: true},
+				{ID: "c", Added: day(6), CompletedAt: ptr(day(6)), Completed: true},
+			},
+		},
+		reminders: &storage.ReminderFile{
+			Upcoming: []storage.Reminder{
+				{ID: "r1", Date: day(10)},
+				{ID: "r2", Date: day(20)},
+			},
+			Completed: []storage.Reminder{
+				{ID: "r3", Date: day(8), CompletedAt: ptr(day(8)), Completed: true},
+				{ID: "r4", Date: day(8), CompletedAt: ptr(day(9)), Completed: true},
+			},
+		},
+		strategy: &storage.Strategy{
+			ActiveMilestones: []storage.Milestone{
+				{ID: "m1", Added: day(1), Due: ptr(day(12))},
+			},
+			CompletedMilestones: []storage.Milestone{
+				{ID: "m2", Added: day(1), Completed: true, CompletedAt: ptr(day(9))},
+			},
+		},
+		reading: &storage.ReadingList{
+			ToRead: []storage.ReadingItem{{ID: "u", Added: day(10)}},
+			Read:   []storage.ReadingItem{{ID: "x", Added: day(1), Read: true, ReadAt: ptr(day(8))}},
+		},
+	}
+
+	got := computeStats(data, day(1), day(14), day(14))
+
+	if got.Todos.Completed != 3 || got.Todos.PerWeek != 1.5 || got.Todos.AvgDaysToDone != 1.3 {
+		t.Errorf("unexpected todo velocity: %+v", got.Todos)
+	}
+	if got.Todos.Active != 2 || got.Todos.AvgActiveAge != 7 || got.Todos.OldestActiveID != "old" {
+		t.Errorf("unexpected todo age: %+v", got.Todos)
+	}
+
+	// r1 overdue, r3 on time, r4 late; r2 falls outside the window
+	if got.Reminders.Due != 3 || got.Reminders.OnTime != 1 || got.Reminders.Late != 1 || got.Reminders.Overdue != 1 || got.Reminders.OverdueRate != 0.67 {
+		t.Errorf("unexpected reminder stats: %+v", got.Reminders)
+	}
+
+	if got.Milestones.Completed != 1 || got.Milestones.Overdue != 1 {
+		t.Errorf("unexpected milestone stats: %+v", got.Milestones)
+	}
+	if n := len(got.Milestones.BurnDown); n != 2 {
+		t.Fatalf("expected 2 burn-down points, got %d", n)
+	}
+	if p := got.Milestones.BurnDown[0]; p.WeekEnding != "2026-02-07" || p.Open != 2 || p.Completed != 0 {
+		t.Errorf("unexpected first burn-down point: %+v", p)
+	}
+	if p := got.Milestones.BurnDown[1]; p.Open != 1 || p.Completed != 1 {
+		t.Errorf("unexpected last burn-down point: %+v", p)
+	}
+
+	if got.Reading.Read != 1 || got.Reading.AvgDaysToRead != 7 || got.Reading.Backlog != 1 {
+		t.Errorf("unexpected reading stats: %+v", got.Reading)
+	}
+
+	// Completion days: 4, 5, 6, 8, 9 -> longest run 3 ending on the 6th; no current streak
+	if got.Streaks.Longest != 3 || got.Streaks.LongestEnded != "2026-02-06" || got.Streaks.Current != 0 || got.Streaks.ActiveDays != 5 {
+		t.Errorf("unexpected streaks: %+v", got.Streaks)
+	}
+}
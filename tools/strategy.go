@@ -7,92 +7,74 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
 	"github.com/dang-w/momentum-mcp-server/storage"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // StrategyTools provides tools for managing strategy milestones and notes.
 type StrategyTools struct {
-	storage storage.Storage
+	storage    storage.Storage
+	milestones *entitystore.Store[storage.Strategy, storage.Milestone]
+	issues     MilestoneIssues // nil if GitHub Issues sync is disabled
+	clock      clock.Clock
 }
 
-// NewStrategyTools creates a new StrategyTools instance.
-func NewStrategyTools(s storage.Storage) *StrategyTools {
-	return &StrategyTools{storage: s}
+// NewStrategyTools creates a new StrategyTools instance. Pass a nil issues to
+// disable GitHub Issues sync. A nil clock uses the system clock.
+func NewStrategyTools(s storage.Storage, issues MilestoneIssues, c clock.Clock) *StrategyTools {
+	return &StrategyTools{storage: s, milestones: entitystore.New(s, milestoneKind), issues: issues, clock: clock.Or(c)}
 }
 
 // UpdateMilestoneInput is the input schema for the update_milestone tool.
 type UpdateMilestoneInput struct {
-	Text     string `json:"text,omitempty" jsonschema:"Text to match against milestone descriptions. Can be partial match."`
-	ID       string `json:"id,omitempty" jsonschema:"ID of the milestone to update. More reliable than text matching. Use get_milestones to find IDs."`
-	Complete bool   `json:"complete" jsonschema:"Set to true to mark as complete, false to mark as incomplete"`
+	Text           string `json:"text,omitempty" jsonschema:"Text to match against milestone descriptions. Can be partial or approximate; if it matches several without a clear winner, the candidates are returned."`
+	ID             string `json:"id,omitempty" jsonschema:"ID of the milestone to update. More reliable than text matching. Use get_milestones to find IDs."`
+	Complete       bool   `json:"complete" jsonschema:"Set to true to mark as complete, false to mark as incomplete"`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // UpdateMilestoneOutput is the output for the update_milestone tool.
 type UpdateMilestoneOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
-}
-
-// AddNoteInput is the input schema for the add_note tool.
-type AddNoteInput struct {
-	Note string `json:"note" jsonschema:"The note text to add to the strategy notes section"`
-}
-
-// AddNoteOutput is the output for the add_note tool.
-type AddNoteOutput struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-}
-
-// ListNotesInput is the input schema for the list_notes tool.
-type ListNotesInput struct {
-	Search string `json:"search,omitempty" jsonschema:"Text to filter notes by. Case-insensitive partial match."`
-}
-
-// ListNotesOutput is the output for the list_notes tool.
-type ListNotesOutput struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-}
-
-// ListNotesResult is the response payload for list_notes.
-type ListNotesResult struct {
-	Notes []string `json:"notes"`
-	Total int      `json:"total"`
+	// ID and Item are the updated milestone, set on success.
+	ID   string         `json:"id,omitempty"`
+	Item *MilestoneItem `json:"item,omitempty"`
+	// Candidates are the items the text matched, best first, when it
+	// matched several without a clear winner.
+	Candidates []entitystore.Candidate `json:"candidates,omitempty"`
 }
 
 // EditMilestoneInput is the input schema for the edit_milestone tool.
 type EditMilestoneInput struct {
-	ID       string `json:"id" jsonschema:"ID of the milestone to edit. Use get_milestones to find IDs."`
-	Text     string `json:"text,omitempty" jsonschema:"New milestone text. If omitted, keeps existing text."`
-	Due      string `json:"due,omitempty" jsonschema:"New due date in YYYY-MM-DD format. If omitted, keeps existing due date. Pass 'none' to clear the due date."`
+	ID             string `json:"id" jsonschema:"ID of the milestone to edit. Use get_milestones to find IDs."`
+	Text           string `json:"text,omitempty" jsonschema:"New milestone text. If omitted, keeps existing text."`
+	Due            string `json:"due,omitempty" jsonschema:"New due date in YYYY-MM-DD format. If omitted, keeps existing due date. Pass 'none' to clear the due date."`
+	Project        string `json:"project,omitempty" jsonschema:"New project (see list_projects). If omitted, keeps existing project. Pass 'none' to remove it from its project."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // EditMilestoneOutput is the output for the edit_milestone tool.
 type EditMilestoneOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
-}
-
-// DeleteNoteInput is the input schema for the delete_note tool.
-type DeleteNoteInput struct {
-	Text string `json:"text" jsonschema:"Text to match against note content. Must match exactly one note (case-insensitive partial match)."`
-}
-
-// DeleteNoteOutput is the output for the delete_note tool.
-type DeleteNoteOutput struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
+	// ID and Item are the edited milestone, set on success.
+	ID   string         `json:"id,omitempty"`
+	Item *MilestoneItem `json:"item,omitempty"`
 }
 
 // GetMilestonesInput is the input schema for the get_milestones tool.
-type GetMilestonesInput struct{}
+type GetMilestonesInput struct {
+	Sort string `json:"sort,omitempty" jsonschema:"Sort milestones by due, added, alphabetical, or completed. Prefix with - to reverse (e.g. -due for latest first). Defaults to file order."`
+}
 
 // GetMilestonesOutput is the output for the get_milestones tool.
 type GetMilestonesOutput struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
+	Success bool                 `json:"success"`
+	Message string               `json:"message"`
+	Result  *GetMilestonesResult `json:"result,omitempty"`
 }
 
 // GetMilestonesResult is the response payload for get_milestones.
@@ -100,6 +82,7 @@ type GetMilestonesResult struct {
 	CurrentPhase        string          `json:"current_phase"`
 	ActiveMilestones    []MilestoneItem `json:"active_milestones"`
 	CompletedMilestones []MilestoneItem `json:"completed_milestones"`
+	SourceSHA           string          `json:"source_sha"`
 }
 
 // Register registers strategy tools with the MCP server.
@@ -109,16 +92,6 @@ func (t *StrategyTools) Register(server *mcp.Server) {
 		Description: "Toggle a milestone's completion status",
 	}, t.updateMilestone)
 
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "add_note",
-		Description: "Add a note to the strategy notes section",
-	}, t.addNote)
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "list_notes",
-		Description: "List strategy notes with optional text search. Notes are plain text entries without dates or IDs.",
-	}, t.listNotes)
-
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "get_milestones",
 		Description: "Get all strategy milestones with their completion status",
@@ -126,250 +99,96 @@ func (t *StrategyTools) Register(server *mcp.Server) {
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "edit_milestone",
-		Description: "Edit a milestone's text or due date",
+		Description: "Edit a milestone's text, due date, or project",
 	}, t.editMilestone)
 
 	mcp.AddTool(server, &mcp.Tool{
-		Name:        "delete_note",
-		Description: "Delete a strategy note by text match",
-	}, t.deleteNote)
-}
-
-func (t *StrategyTools) updateMilestone(ctx context.Context, req *mcp.CallToolRequest, input UpdateMilestoneInput) (*mcp.CallToolResult, UpdateMilestoneOutput, error) {
-	if strings.TrimSpace(input.Text) == "" && strings.TrimSpace(input.ID) == "" {
-		return nil, UpdateMilestoneOutput{
-			Success: false,
-			Message: "Either text or id must be provided",
-		}, nil
-	}
-
-	// Read current strategy
-	content, sha, err := t.storage.ReadFile(ctx, "strategy.md")
-	if err != nil {
-		return nil, UpdateMilestoneOutput{}, fmt.Errorf("reading strategy.md: %w", err)
-	}
+		Name:        "update_key_result",
+		Description: "Add, update or remove a milestone's key result: a measurable result with a current and target value (e.g. 40/100 beta users). The milestone's progress is the average of its key results.",
+	}, t.updateKeyResult)
 
-	s, err := storage.ParseStrategy(content)
-	if err != nil {
-		return nil, UpdateMilestoneOutput{}, fmt.Errorf("parsing strategy: %w", err)
-	}
-
-	// Helper to find milestone by ID or text in a slice
-	findMilestone := func(milestones []storage.Milestone, label string) (int, *UpdateMilestoneOutput) {
-		if id := strings.TrimSpace(input.ID); id != "" {
-			for i, m := range milestones {
-				if m.ID == id {
-					return i, nil
-				}
-			}
-			return -1, &UpdateMilestoneOutput{
-				Success: false,
-				Message: fmt.Sprintf("No %s milestone found with id %q", label, input.ID),
-			}
-		}
-
-		searchText := strings.ToLower(strings.TrimSpace(input.Text))
-		var matches []int
-		for i, m := range milestones {
-			if strings.Contains(strings.ToLower(m.Text), searchText) {
-				matches = append(matches, i)
-			}
-		}
-
-		if len(matches) == 0 {
-			return -1, &UpdateMilestoneOutput{
-				Success: false,
-				Message: fmt.Sprintf("No %s milestone found matching %q", label, input.Text),
-			}
-		}
-
-		if len(matches) > 1 {
-			var matchTexts []string
-			for _, idx := range matches {
-				matchTexts = append(matchTexts, fmt.Sprintf("- [%s] %s", milestones[idx].ID, milestones[idx].Text))
-			}
-			return -1, &UpdateMilestoneOutput{
-				Success: false,
-				Message: fmt.Sprintf("Multiple milestones match %q. Please be more specific or use an id:\n%s", input.Text, strings.Join(matchTexts, "\n")),
-			}
-		}
-
-		return matches[0], nil
-	}
-
-	if input.Complete {
-		idx, errOut := findMilestone(s.ActiveMilestones, "active")
-		if errOut != nil {
-			return nil, *errOut, nil
-		}
-
-		// Mark as completed
-		milestone := s.ActiveMilestones[idx]
-		milestone.Completed = true
-		now := time.Now().UTC().Truncate(24 * time.Hour)
-		milestone.CompletedAt = &now
-
-		// Move from active to completed
-		s.ActiveMilestones = append(s.ActiveMilestones[:idx], s.ActiveMilestones[idx+1:]...)
-		s.CompletedMilestones = append([]storage.Milestone{milestone}, s.CompletedMilestones...)
-
-		// Serialize and write back
-		newContent := storage.SerializeStrategy(s)
-		if err := t.storage.WriteFile(ctx, "strategy.md", newContent, sha, fmt.Sprintf("Complete milestone: %s", truncate(milestone.Text, 50))); err != nil {
-			if err == storage.ErrConflict {
-				return nil, UpdateMilestoneOutput{
-					Success: false,
-					Message: "File was modified by another process. Please try again.",
-				}, nil
-			}
-			return nil, UpdateMilestoneOutput{}, fmt.Errorf("writing strategy.md: %w", err)
-		}
-
-		itemJSON, err := json.Marshal(milestoneToItem(milestone))
-		if err != nil {
-			return nil, UpdateMilestoneOutput{}, fmt.Errorf("marshaling response: %w", err)
-		}
-
-		return nil, UpdateMilestoneOutput{
-			Success: true,
-			Message: string(itemJSON),
-		}, nil
-	} else {
-		idx, errOut := findMilestone(s.CompletedMilestones, "completed")
-		if errOut != nil {
-			return nil, *errOut, nil
-		}
-
-		// Mark as incomplete
-		milestone := s.CompletedMilestones[idx]
-		milestone.Completed = false
-		milestone.CompletedAt = nil
-
-		// Move from completed to active
-		s.CompletedMilestones = append(s.CompletedMilestones[:idx], s.CompletedMilestones[idx+1:]...)
-		s.ActiveMilestones = append(s.ActiveMilestones, milestone)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_phases",
+		Description: "List the strategy phases, oldest first, with their start and end dates, time spent in each, and objectives",
+	}, t.listPhases)
 
-		// Serialize and write back
-		newContent := storage.SerializeStrategy(s)
-		if err := t.storage.WriteFile(ctx, "strategy.md", newContent, sha, fmt.Sprintf("Reopen milestone: %s", truncate(milestone.Text, 50))); err != nil {
-			if err == storage.ErrConflict {
-				return nil, UpdateMilestoneOutput{
-					Success: false,
-					Message: "File was modified by another process. Please try again.",
-				}, nil
-			}
-			return nil, UpdateMilestoneOutput{}, fmt.Errorf("writing strategy.md: %w", err)
-		}
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "advance_phase",
+		Description: "Move strategy to a new phase, ending the current one in the phase history, and seed its milestones from phase-templates.md, with due dates relative to the start date",
+	}, t.advancePhase)
 
-		itemJSON, err := json.Marshal(milestoneToItem(milestone))
-		if err != nil {
-			return nil, UpdateMilestoneOutput{}, fmt.Errorf("marshaling response: %w", err)
-		}
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "apply_phase_template",
+		Description: "Add a phase's template milestones from phase-templates.md to the active milestones (defaults to the current phase). Milestones already active are skipped.",
+	}, t.applyPhaseTemplate)
 
-		return nil, UpdateMilestoneOutput{
-			Success: true,
-			Message: string(itemJSON),
-		}, nil
+	if t.issues != nil {
+		mcp.AddTool(server, &mcp.Tool{
+			Name:        "sync_milestone_issues",
+			Description: "Mirror milestones to GitHub issues: create issues for active milestones without one, and update title, due date and open/closed state of linked issues",
+		}, t.syncMilestoneIssues)
 	}
 }
 
-func (t *StrategyTools) addNote(ctx context.Context, req *mcp.CallToolRequest, input AddNoteInput) (*mcp.CallToolResult, AddNoteOutput, error) {
-	if strings.TrimSpace(input.Note) == "" {
-		return nil, AddNoteOutput{
+func (t *StrategyTools) updateMilestone(ctx context.Context, req *mcp.CallToolRequest, input UpdateMilestoneInput) (*mcp.CallToolResult, UpdateMilestoneOutput, error) {
+	if strings.TrimSpace(input.Text) == "" && strings.TrimSpace(input.ID) == "" {
+		return nil, UpdateMilestoneOutput{
 			Success: false,
-			Message: "Note text cannot be empty",
+			Message: "Either text or id must be provided",
 		}, nil
 	}
 
-	// Read current strategy
-	content, sha, err := t.storage.ReadFile(ctx, "strategy.md")
-	if err != nil {
-		return nil, AddNoteOutput{}, fmt.Errorf("reading strategy.md: %w", err)
+	// Completing moves an active milestone to the front of the completed
+	// list; reopening moves a completed one back to the end of the active list
+	from := entitystore.Open
+	if !input.Complete {
+		from = entitystore.Done
 	}
-
-	s, err := storage.ParseStrategy(content)
-	if err != nil {
-		return nil, AddNoteOutput{}, fmt.Errorf("parsing strategy: %w", err)
-	}
-
-	// Add the note
-	s.Notes = append(s.Notes, strings.TrimSpace(input.Note))
-
-	// Serialize and write back
-	newContent := storage.SerializeStrategy(s)
-	if err := t.storage.WriteFile(ctx, "strategy.md", newContent, sha, "Add strategy note"); err != nil {
-		if err == storage.ErrConflict {
-			return nil, AddNoteOutput{
-				Success: false,
-				Message: "File was modified by another process. Please try again.",
-			}, nil
+	milestone, err := t.milestones.Move(ctx, from, entitystore.Ref{ID: input.ID, Text: input.Text}, input.IfUnchangedSHA, func(m *storage.Milestone) string {
+		if !input.Complete {
+			m.Completed = false
+			m.CompletedAt = nil
+			return fmt.Sprintf("Reopen milestone: %s", truncate(m.Text, 50))
 		}
-		return nil, AddNoteOutput{}, fmt.Errorf("writing strategy.md: %w", err)
-	}
-
-	noteJSON, err := json.Marshal(struct {
-		Note  string `json:"note"`
-		Total int    `json:"total_notes"`
-	}{
-		Note:  strings.TrimSpace(input.Note),
-		Total: len(s.Notes),
+		m.Completed = true
+		now := clock.Today(t.clock)
+		m.CompletedAt = &now
+		return fmt.Sprintf("Complete milestone: %s", truncate(m.Text, 50))
 	})
-	if err != nil {
-		return nil, AddNoteOutput{}, fmt.Errorf("marshaling response: %w", err)
-	}
-
-	return nil, AddNoteOutput{
-		Success: true,
-		Message: string(noteJSON),
-	}, nil
-}
-
-func (t *StrategyTools) listNotes(ctx context.Context, req *mcp.CallToolRequest, input ListNotesInput) (*mcp.CallToolResult, ListNotesOutput, error) {
-	content, _, err := t.storage.ReadFile(ctx, "strategy.md")
-	if err != nil {
-		return nil, ListNotesOutput{}, fmt.Errorf("reading strategy.md: %w", err)
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, UpdateMilestoneOutput{Success: false, Message: msg, Candidates: entitystore.Candidates(err)}, nil
 	}
-
-	s, err := storage.ParseStrategy(content)
 	if err != nil {
-		return nil, ListNotesOutput{}, fmt.Errorf("parsing strategy: %w", err)
+		return nil, UpdateMilestoneOutput{}, err
 	}
+	t.updateMilestoneIssue(ctx, milestone)
 
-	notes := s.Notes
-	search := strings.ToLower(strings.TrimSpace(input.Search))
-	if search != "" {
-		var filtered []string
-		for _, note := range notes {
-			if strings.Contains(strings.ToLower(note), search) {
-				filtered = append(filtered, note)
-			}
-		}
-		notes = filtered
-	}
-
-	result := ListNotesResult{
-		Notes: notes,
-		Total: len(s.Notes),
-	}
-
-	jsonBytes, err := json.Marshal(result)
+	item := milestoneToItem(milestone)
+	itemJSON, err := json.Marshal(item)
 	if err != nil {
-		return nil, ListNotesOutput{}, fmt.Errorf("marshaling response: %w", err)
+		return nil, UpdateMilestoneOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
 
-	return nil, ListNotesOutput{
+	return nil, UpdateMilestoneOutput{
 		Success: true,
-		Message: string(jsonBytes),
+		Message: string(itemJSON),
+		ID:      item.ID,
+		Item:    &item,
 	}, nil
 }
 
 func (t *StrategyTools) getMilestones(ctx context.Context, req *mcp.CallToolRequest, input GetMilestonesInput) (*mcp.CallToolResult, GetMilestonesOutput, error) {
-	content, _, err := t.storage.ReadFile(ctx, "strategy.md")
+	sortKey, sortDesc, msg := parseSort(input.Sort, sortDue, sortAdded, sortAlphabetical, sortCompleted)
+	if msg != "" {
+		return nil, GetMilestonesOutput{Success: false, Message: msg}, nil
+	}
+
+	content, sha, err := t.storage.ReadFile(ctx, storage.StrategyFile)
 	if err != nil {
 		return nil, GetMilestonesOutput{}, fmt.Errorf("reading strategy.md: %w", err)
 	}
 
-	s, err := storage.ParseStrategy(content)
+	s, err := parseStrategy(ctx, content)
 	if err != nil {
 		return nil, GetMilestonesOutput{}, fmt.Errorf("parsing strategy: %w", err)
 	}
@@ -383,21 +202,39 @@ func (t *StrategyTools) getMilestones(ctx context.Context, req *mcp.CallToolRequ
 	for i, m := range s.CompletedMilestones {
 		completed[i] = milestoneToItem(m)
 	}
+	// Count open todos per milestone. Without todos.md the counts stay zero.
+	if todosContent, _, err := t.storage.ReadFile(ctx, storage.TodosFile); err == nil {
+		if tf, err := parseTodos(ctx, todosContent); err == nil {
+			open := make(map[string]int)
+			for _, todo := range tf.Active {
+				if todo.Milestone != "" {
+					open[todo.Milestone]++
+				}
+			}
+			for i := range active {
+				active[i].OpenTodos = open[active[i].ID]
+			}
+			for i := range completed {
+				completed[i].OpenTodos = open[completed[i].ID]
+			}
+		}
+	}
+
+	sortMilestoneItems(active, sortKey, sortDesc)
+	sortMilestoneItems(completed, sortKey, sortDesc)
 
 	result := GetMilestonesResult{
+		SourceSHA:           sha,
 		CurrentPhase:        s.CurrentPhase,
 		ActiveMilestones:    active,
 		CompletedMilestones: completed,
 	}
 
-	jsonBytes, err := json.Marshal(result)
-	if err != nil {
-		return nil, GetMilestonesOutput{}, fmt.Errorf("marshaling response: %w", err)
-	}
-
-	return nil, GetMilestonesOutput{
+	text := result.text()
+	return textResult(text), GetMilestonesOutput{
 		Success: true,
-		Message: string(jsonBytes),
+		Message: text,
+		Result:  &result,
 	}, nil
 }
 
@@ -409,10 +246,10 @@ func (t *StrategyTools) editMilestone(ctx context.Context, req *mcp.CallToolRequ
 		}, nil
 	}
 
-	if strings.TrimSpace(input.Text) == "" && strings.TrimSpace(input.Due) == "" {
+	if strings.TrimSpace(input.Text) == "" && strings.TrimSpace(input.Due) == "" && strings.TrimSpace(input.Project) == "" {
 		return nil, EditMilestoneOutput{
 			Success: false,
-			Message: "At least one of text or due must be provided",
+			Message: "At least one of text, due, or project must be provided",
 		}, nil
 	}
 
@@ -423,7 +260,7 @@ func (t *StrategyTools) editMilestone(ctx context.Context, req *mcp.CallToolRequ
 		if strings.ToLower(d) == "none" {
 			clearDue = true
 		} else {
-			t, err := time.Parse("2006-01-02", d)
+			t, err := parseDate(d, t.clock.Now())
 			if err != nil {
 				return nil, EditMilestoneOutput{
 					Success: false,
@@ -434,21 +271,8 @@ func (t *StrategyTools) editMilestone(ctx context.Context, req *mcp.CallToolRequ
 		}
 	}
 
-	// Read current strategy
-	content, sha, err := t.storage.ReadFile(ctx, "strategy.md")
-	if err != nil {
-		return nil, EditMilestoneOutput{}, fmt.Errorf("reading strategy.md: %w", err)
-	}
-
-	s, err := storage.ParseStrategy(content)
-	if err != nil {
-		return nil, EditMilestoneOutput{}, fmt.Errorf("parsing strategy: %w", err)
-	}
-
-	// Search both active and completed milestones by ID
-	id := strings.TrimSpace(input.ID)
-
-	applyEdit := func(m *storage.Milestone) {
+	// Active and completed milestones can both be edited
+	milestone, err := t.milestones.Update(ctx, entitystore.Both, entitystore.Ref{ID: input.ID}, input.IfUnchangedSHA, func(m *storage.Milestone) string {
 		if text := strings.TrimSpace(input.Text); text != "" {
 			m.Text = text
 		}
@@ -457,144 +281,29 @@ func (t *StrategyTools) editMilestone(ctx context.Context, req *mcp.CallToolRequ
 		} else if newDue != nil {
 			m.Due = newDue
 		}
-	}
-
-	for i, m := range s.ActiveMilestones {
-		if m.ID == id {
-			applyEdit(&s.ActiveMilestones[i])
-
-			newContent := storage.SerializeStrategy(s)
-			if err := t.storage.WriteFile(ctx, "strategy.md", newContent, sha, fmt.Sprintf("Edit milestone: %s", truncate(s.ActiveMilestones[i].Text, 50))); err != nil {
-				if err == storage.ErrConflict {
-					return nil, EditMilestoneOutput{
-						Success: false,
-						Message: "File was modified by another process. Please try again.",
-					}, nil
-				}
-				return nil, EditMilestoneOutput{}, fmt.Errorf("writing strategy.md: %w", err)
-			}
-
-			itemJSON, err := json.Marshal(milestoneToItem(s.ActiveMilestones[i]))
-			if err != nil {
-				return nil, EditMilestoneOutput{}, fmt.Errorf("marshaling response: %w", err)
-			}
-
-			return nil, EditMilestoneOutput{
-				Success: true,
-				Message: string(itemJSON),
-			}, nil
-		}
-	}
-
-	for i, m := range s.CompletedMilestones {
-		if m.ID == id {
-			applyEdit(&s.CompletedMilestones[i])
-
-			newContent := storage.SerializeStrategy(s)
-			if err := t.storage.WriteFile(ctx, "strategy.md", newContent, sha, fmt.Sprintf("Edit milestone: %s", truncate(s.CompletedMilestones[i].Text, 50))); err != nil {
-				if err == storage.ErrConflict {
-					return nil, EditMilestoneOutput{
-						Success: false,
-						Message: "File was modified by another process. Please try again.",
-					}, nil
-				}
-				return nil, EditMilestoneOutput{}, fmt.Errorf("writing strategy.md: %w", err)
-			}
-
-			itemJSON, err := json.Marshal(milestoneToItem(s.CompletedMilestones[i]))
-			if err != nil {
-				return nil, EditMilestoneOutput{}, fmt.Errorf("marshaling response: %w", err)
-			}
-
-			return nil, EditMilestoneOutput{
-				Success: true,
-				Message: string(itemJSON),
-			}, nil
+		if project := strings.TrimSpace(input.Project); project != "" {
+			m.Project = projectOrNone(project)
 		}
+		return fmt.Sprintf("Edit milestone: %s", truncate(m.Text, 50))
+	})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, EditMilestoneOutput{Success: false, Message: msg}, nil
 	}
-
-	return nil, EditMilestoneOutput{
-		Success: false,
-		Message: fmt.Sprintf("No milestone found with id %q", id),
-	}, nil
-}
-
-func (t *StrategyTools) deleteNote(ctx context.Context, req *mcp.CallToolRequest, input DeleteNoteInput) (*mcp.CallToolResult, DeleteNoteOutput, error) {
-	if strings.TrimSpace(input.Text) == "" {
-		return nil, DeleteNoteOutput{
-			Success: false,
-			Message: "text is required",
-		}, nil
-	}
-
-	// Read current strategy
-	content, sha, err := t.storage.ReadFile(ctx, "strategy.md")
-	if err != nil {
-		return nil, DeleteNoteOutput{}, fmt.Errorf("reading strategy.md: %w", err)
-	}
-
-	s, err := storage.ParseStrategy(content)
 	if err != nil {
-		return nil, DeleteNoteOutput{}, fmt.Errorf("parsing strategy: %w", err)
-	}
-
-	// Find matching notes
-	searchText := strings.ToLower(strings.TrimSpace(input.Text))
-	var matches []int
-	for i, note := range s.Notes {
-		if strings.Contains(strings.ToLower(note), searchText) {
-			matches = append(matches, i)
-		}
-	}
-
-	if len(matches) == 0 {
-		return nil, DeleteNoteOutput{
-			Success: false,
-			Message: fmt.Sprintf("No note found matching %q", input.Text),
-		}, nil
-	}
-
-	if len(matches) > 1 {
-		var matchTexts []string
-		for _, idx := range matches {
-			matchTexts = append(matchTexts, fmt.Sprintf("- %s", truncate(s.Notes[idx], 80)))
-		}
-		return nil, DeleteNoteOutput{
-			Success: false,
-			Message: fmt.Sprintf("Multiple notes match %q. Please be more specific:\n%s", input.Text, strings.Join(matchTexts, "\n")),
-		}, nil
+		return nil, EditMilestoneOutput{}, err
 	}
+	t.updateMilestoneIssue(ctx, milestone)
 
-	// Delete the note
-	idx := matches[0]
-	deleted := s.Notes[idx]
-	s.Notes = append(s.Notes[:idx], s.Notes[idx+1:]...)
-
-	// Serialize and write back
-	newContent := storage.SerializeStrategy(s)
-	if err := t.storage.WriteFile(ctx, "strategy.md", newContent, sha, fmt.Sprintf("Delete note: %s", truncate(deleted, 50))); err != nil {
-		if err == storage.ErrConflict {
-			return nil, DeleteNoteOutput{
-				Success: false,
-				Message: "File was modified by another process. Please try again.",
-			}, nil
-		}
-		return nil, DeleteNoteOutput{}, fmt.Errorf("writing strategy.md: %w", err)
-	}
-
-	noteJSON, err := json.Marshal(struct {
-		Deleted string `json:"deleted_note"`
-		Total   int    `json:"total_notes"`
-	}{
-		Deleted: deleted,
-		Total:   len(s.Notes),
-	})
+	item := milestoneToItem(milestone)
+	itemJSON, err := json.Marshal(item)
 	if err != nil {
-		return nil, DeleteNoteOutput{}, fmt.Errorf("marshaling response: %w", err)
+		return nil, EditMilestoneOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
 
-	return nil, DeleteNoteOutput{
+	return nil, EditMilestoneOutput{
 		Success: true,
-		Message: string(noteJSON),
+		Message: string(itemJSON),
+		ID:      item.ID,
+		Item:    &item,
 	}, nil
 }
@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/analytics"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// CompletionProgress puts a completion in context: the run of days with at
+// least one completion and this week's count against the best week, so the
+// assistant can reflect it back. Completions are todos, reminders and
+// milestones completed and reading list items read, by their completed date.
+type CompletionProgress struct {
+	StreakDays        int  `json:"streak_days"`
+	LongestStreakDays int  `json:"longest_streak_days"`
+	NewLongestStreak  bool `json:"new_longest_streak,omitempty"`
+	CompletedToday    int  `json:"completed_today"`
+	CompletedThisWeek int  `json:"completed_this_week"`
+	// BestWeek is the most completions in an earlier week (Monday to
+	// Sunday), starting on BestWeekStart.
+	BestWeek        int    `json:"best_week"`
+	BestWeekStart   string `json:"best_week_start,omitempty"`
+	NewWeeklyRecord bool   `json:"new_weekly_record,omitempty"`
+	// Highlight is a one-line note worth passing on, if any.
+	Highlight string `json:"highlight,omitempty"`
+}
+
+// completionProgress reads the data files and computes the progress as of
+// today, just after a completion. It returns nil if the files can't be read;
+// the completion itself has already succeeded.
+func completionProgress(ctx context.Context, s storage.Storage, today time.Time) *CompletionProgress {
+	data, err := loadStatsData(ctx, s)
+	if err != nil {
+		slog.Warn("computing completion streaks failed", "error", err)
+		return nil
+	}
+	p := progressFrom(completionDates(data), today)
+	return &p
+}
+
+// completionDates lists the date of every completion, once per completion.
+func completionDates(data *statsData) []time.Time {
+	var dates []time.Time
+	add := func(t *time.Time) {
+		if t != nil {
+			dates = append(dates, *t)
+		}
+	}
+	for _, t := range data.todos.Completed {
+		add(t.CompletedAt)
+	}
+	for _, r := range data.reminders.Completed {
+		add(r.CompletedAt)
+	}
+	for _, m := range data.strategy.CompletedMilestones {
+		add(m.CompletedAt)
+	}
+	for _, item := range data.reading.Read {
+		add(item.ReadAt)
+	}
+	return dates
+}
+
+// progressFrom computes the streak and weekly record as of today from the
+// completion dates.
+func progressFrom(dates []time.Time, today time.Time) CompletionProgress {
+	var p CompletionProgress
+	days := make(map[time.Time]bool)
+	weeks := make(map[time.Time]int)
+	thisWeek := analytics.WeekStart(today)
+	for _, d := range dates {
+		if d.After(today) {
+			continue
+		}
+		days[d] = true
+		if d.Equal(today) {
+			p.CompletedToday++
+		}
+		weeks[analytics.WeekStart(d)]++
+	}
+
+	streaks := completionStreaks(days, time.Time{}, today, today)
+	p.StreakDays, p.LongestStreakDays = streaks.Current, streaks.Longest
+	if p.StreakDays > 1 {
+		// Longer than any run before the current one
+		runStart := today.AddDate(0, 0, 1-p.StreakDays)
+		if !days[today] {
+			runStart = runStart.AddDate(0, 0, -1)
+		}
+		before := make(map[time.Time]bool)
+		for d := range days {
+			if d.Before(runStart) {
+				before[d] = true
+			}
+		}
+		p.NewLongestStreak = p.StreakDays > completionStreaks(before, time.Time{}, today, today).Longest
+	}
+
+	p.CompletedThisWeek = weeks[thisWeek]
+	var bestWeek time.Time
+	for week, n := range weeks {
+		// Ties go to the most recent week, so the result doesn't depend on map order
+		if week.Before(thisWeek) && (n > p.BestWeek || (n == p.BestWeek && week.After(bestWeek))) {
+			p.BestWeek, bestWeek = n, week
+		}
+	}
+	p.BestWeekStart = formatDate(bestWeek)
+	p.NewWeeklyRecord = p.BestWeek > 0 && p.CompletedThisWeek > p.BestWeek
+
+	switch {
+	case p.NewWeeklyRecord:
+		p.Highlight = fmt.Sprintf("New weekly record: %d completions this week, beating %d.", p.CompletedThisWeek, p.BestWeek)
+	case p.NewLongestStreak:
+		p.Highlight = fmt.Sprintf("Longest streak yet: %d days in a row with a completion.", p.StreakDays)
+	case p.StreakDays > 1:
+		p.Highlight = fmt.Sprintf("%d days in a row with a completion.", p.StreakDays)
+	case p.CompletedToday == 1:
+		p.Highlight = "First completion today."
+	}
+	return p
+}
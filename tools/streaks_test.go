@@ -0,0 +1,42 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgressFrom(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 2, d, 0, 0, 0, 0, time.UTC) }
+	today := day(11) // a Wednesday
+
+	tests := []struct {
+		name  string
+		dates []time.Time
+		want  CompletionProgress
+	}{
+		{
+			name:  "first ever",
+			dates: []time.Time{day(11)},
+			want:  CompletionProgress{StreakDays: 1, LongestStreakDays: 1, CompletedToday: 1, CompletedThisWeek: 1, Highlight: "First completion today."},
+		},
+		{
+			name:  "new longest streak",
+			dates: []time.Time{day(2), day(3), day(9), day(10), day(11)},
+			want: CompletionProgress{StreakDays: 3, LongestStreakDays: 3, NewLongestStreak: true, CompletedToday: 1, CompletedThisWeek: 3,
+				BestWeek: 2, BestWeekStart: "2026-02-02", NewWeeklyRecord: true, Highlight: "New weekly record: 3 completions this week, beating 2."},
+		},
+		{
+			name:  "streak short of the record",
+			dates: []time.Time{day(1), day(2), day(3), day(4), day(5), day(10), day(11)},
+			want: CompletionProgress{StreakDays: 2, LongestStreakDays: 5, CompletedToday: 1, CompletedThisWeek: 2,
+				BestWeek: 4, BestWeekStart: "2026-02-02", Highlight: "2 days in a row with a completion."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := progressFrom(tt.dates, today); got != tt.want {
+				t.Errorf("progressFrom() = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
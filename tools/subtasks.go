@@ -0,0 +1,212 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AddSubtaskInput is the input schema for the add_subtask tool.
+type AddSubtaskInput struct {
+	TodoID         string `json:"todo_id" jsonschema:"ID of the active todo to add the subtask to. Use list_todos to find IDs."`
+	Text           string `json:"text" jsonschema:"The subtask text"`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// AddSubtaskOutput is the output for the add_subtask tool.
+type AddSubtaskOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// ID is the new subtask's, and Item its todo, set on success.
+	ID   string    `json:"id,omitempty"`
+	Item *TodoItem `json:"item,omitempty"`
+}
+
+// CompleteSubtaskInput is the input schema for the complete_subtask tool.
+type CompleteSubtaskInput struct {
+	TodoID         string `json:"todo_id" jsonschema:"ID of the active todo the subtask belongs to"`
+	ID             string `json:"id,omitempty" jsonschema:"ID of the subtask to complete. More reliable than text matching."`
+	Text           string `json:"text,omitempty" jsonschema:"Text to match against the todo's open subtasks instead of an id. Can be partial or approximate; if it matches several without a clear winner, the candidates are returned."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// CompleteSubtaskOutput is the output for the complete_subtask tool.
+type CompleteSubtaskOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// ID is the completed subtask's, and Item its todo, set on success.
+	ID   string    `json:"id,omitempty"`
+	Item *TodoItem `json:"item,omitempty"`
+	// Candidates are the subtasks the text matched, best first, when it
+	// matched several without a clear winner.
+	Candidates []entitystore.Candidate `json:"candidates,omitempty"`
+}
+
+func (t *TodoTools) addSubtask(ctx context.Context, req *mcp.CallToolRequest, input AddSubtaskInput) (*mcp.CallToolResult, AddSubtaskOutput, error) {
+	if strings.TrimSpace(input.TodoID) == "" {
+		return nil, AddSubtaskOutput{
+			Success: false,
+			Message: "todo_id is required",
+		}, nil
+	}
+	if strings.TrimSpace(input.Text) == "" {
+		return nil, AddSubtaskOutput{
+			Success: false,
+			Message: "Subtask text cannot be empty",
+		}, nil
+	}
+
+	sub := storage.Subtask{Text: strings.TrimSpace(input.Text)}
+	todo, err := t.todos.Update(ctx, entitystore.Open, entitystore.Ref{ID: input.TodoID}, input.IfUnchangedSHA, func(todo *storage.Todo) string {
+		sub.ID = storage.NewID(storage.PrefixSubtask, takenIn(func(s *storage.Subtask) string { return s.ID }, todo.Subtasks))
+		todo.Subtasks = append(todo.Subtasks, sub)
+		return fmt.Sprintf("Add subtask to %s: %s", truncate(todo.Text, 30), truncate(sub.Text, 40))
+	})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, AddSubtaskOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, AddSubtaskOutput{}, err
+	}
+
+	item := todoToItem(todo)
+	return nil, AddSubtaskOutput{
+		Success: true,
+		Message: fmt.Sprintf("Added subtask %q to %q (%s)", sub.Text, todo.Text, subtaskProgress(todo)),
+		ID:      sub.ID,
+		Item:    &item,
+	}, nil
+}
+
+func (t *TodoTools) completeSubtask(ctx context.Context, req *mcp.CallToolRequest, input CompleteSubtaskInput) (*mcp.CallToolResult, CompleteSubtaskOutput, error) {
+	if strings.TrimSpace(input.TodoID) == "" {
+		return nil, CompleteSubtaskOutput{
+			Success: false,
+			Message: "todo_id is required",
+		}, nil
+	}
+	id := strings.TrimSpace(input.ID)
+	if id == "" && strings.TrimSpace(input.Text) == "" {
+		return nil, CompleteSubtaskOutput{
+			Success: false,
+			Message: "Either id or text must be provided",
+		}, nil
+	}
+
+	tf, sha, err := t.todos.Load(ctx, input.IfUnchangedSHA)
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, CompleteSubtaskOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, CompleteSubtaskOutput{}, err
+	}
+	list, i, err := t.todos.Find(tf, entitystore.Open, entitystore.Ref{ID: input.TodoID})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, CompleteSubtaskOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, CompleteSubtaskOutput{}, err
+	}
+	todo := &(*list)[i]
+
+	// Match by ID among all subtasks, or by text among the open ones
+	j := -1
+	if id != "" {
+		for k, sub := range todo.Subtasks {
+			if sub.ID == id {
+				j = k
+				break
+			}
+		}
+		if j < 0 {
+			return nil, CompleteSubtaskOutput{
+				Success: false,
+				Message: fmt.Sprintf("No subtask found with id %q under %q", id, todo.Text),
+			}, nil
+		}
+	} else {
+		var open []int
+		var ids, texts []string
+		for k, sub := range todo.Subtasks {
+			if !sub.Completed {
+				open = append(open, k)
+				ids = append(ids, sub.ID)
+				texts = append(texts, sub.Text)
+			}
+		}
+		ranked := entitystore.Rank(input.Text, ids, texts)
+		if len(ranked) == 0 {
+			return nil, CompleteSubtaskOutput{
+				Success: false,
+				Message: fmt.Sprintf("No open subtask found matching %q under %q", input.Text, todo.Text),
+			}, nil
+		}
+		best, ok := entitystore.Pick(ranked)
+		if !ok {
+			var lines []string
+			for _, c := range ranked {
+				lines = append(lines, fmt.Sprintf("- [%s] %s", c.ID, c.Text))
+			}
+			return nil, CompleteSubtaskOutput{
+				Success:    false,
+				Message:    fmt.Sprintf("Multiple subtasks match %q. Please be more specific or use an id:\n%s", input.Text, strings.Join(lines, "\n")),
+				Candidates: ranked,
+			}, nil
+		}
+		j = open[best.Index]
+	}
+
+	sub := &todo.Subtasks[j]
+	if sub.Completed {
+		return nil, CompleteSubtaskOutput{
+			Success: false,
+			Message: fmt.Sprintf("Subtask %q is already completed", sub.Text),
+		}, nil
+	}
+	sub.Completed = true
+	err = t.todos.Save(ctx, tf, sha, fmt.Sprintf("Complete subtask of %s: %s", truncate(todo.Text, 30), truncate(sub.Text, 40)))
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, CompleteSubtaskOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, CompleteSubtaskOutput{}, err
+	}
+
+	message := fmt.Sprintf("Completed subtask %q (%s)", sub.Text, subtaskProgress(*todo))
+	if subtasksDone(*todo) {
+		message += fmt.Sprintf(". All subtasks are done; complete the todo with complete_todo (id %s).", todo.ID)
+	}
+	item := todoToItem(*todo)
+	return nil, CompleteSubtaskOutput{
+		Success: true,
+		Message: message,
+		ID:      sub.ID,
+		Item:    &item,
+	}, nil
+}
+
+// subtaskProgress describes how many of todo's subtasks are done, e.g.
+// "2/3 subtasks done".
+func subtaskProgress(todo storage.Todo) string {
+	done := 0
+	for _, sub := range todo.Subtasks {
+		if sub.Completed {
+			done++
+		}
+	}
+	return fmt.Sprintf("%d/%d subtasks done", done, len(todo.Subtasks))
+}
+
+// subtasksDone reports whether todo has subtasks and all are completed.
+func subtasksDone(todo storage.Todo) bool {
+	for _, sub := range todo.Subtasks {
+		if !sub.Completed {
+			return false
+		}
+	}
+	return len(todo.Subtasks) > 0
+}
@@ -0,0 +1,47 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestSubtasks(t *testing.T) {
+	files := newFileStorage(map[string]string{storage.TodosFile: "# Active Todos\n\n## Normal\n- [ ] Plan trip {id:aaaa1111}\n\n# Completed\n"})
+	todos := NewTodoTools(files, nil, WIPLimits{})
+	ctx := context.Background()
+
+	for _, text := range []string{"Book flights", "Book hotel"} {
+		if _, out, err := todos.addSubtask(ctx, nil, AddSubtaskInput{TodoID: "aaaa1111", Text: text}); err != nil || !out.Success || out.ID == "" {
+			t.Fatalf("addSubtask(%q) = %+v, %v", text, out, err)
+		}
+	}
+
+	// "book" matches both without a clear winner
+	_, out, _ := todos.completeSubtask(ctx, nil, CompleteSubtaskInput{TodoID: "aaaa1111", Text: "book"})
+	if out.Success || len(out.Candidates) != 2 {
+		t.Fatalf("completeSubtask(book) = %+v", out)
+	}
+	_, out, _ = todos.completeSubtask(ctx, nil, CompleteSubtaskInput{TodoID: "aaaa1111", Text: "flihgts"})
+	if !out.Success || !strings.Contains(out.Message, "1/2 subtasks done") {
+		t.Fatalf("completeSubtask(flihgts) = %+v", out)
+	}
+	_, out, _ = todos.completeSubtask(ctx, nil, CompleteSubtaskInput{TodoID: "aaaa1111", ID: out.ID})
+	if out.Success {
+		t.Errorf("completing a completed subtask = %+v", out)
+	}
+	_, out, _ = todos.completeSubtask(ctx, nil, CompleteSubtaskInput{TodoID: "aaaa1111", Text: "hotel"})
+	if !out.Success || !strings.Contains(out.Message, "All subtasks are done") {
+		t.Errorf("completeSubtask(hotel) = %+v", out)
+	}
+
+	tf, _ := storage.ParseTodos(fileContent(t, files, storage.TodosFile))
+	if len(tf.Active) != 1 || len(tf.Active[0].Subtasks) != 2 || !tf.Active[0].Subtasks[1].Completed {
+		t.Errorf("todos after subtasks = %+v", tf.Active)
+	}
+	if out.Item == nil || len(out.Item.Subtasks) != 2 {
+		t.Errorf("item = %+v", out.Item)
+	}
+}
@@ -0,0 +1,171 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SyncQueueTools reports on writes saved locally while GitHub was
+// unavailable, when OFFLINE_QUEUE is set.
+type SyncQueueTools struct {
+	queue *storage.WriteQueue
+}
+
+// NewSyncQueueTools creates a new SyncQueueTools instance.
+func NewSyncQueueTools(queue *storage.WriteQueue) *SyncQueueTools {
+	return &SyncQueueTools{queue: queue}
+}
+
+// SyncStatusInput is the input schema for the sync_status tool.
+type SyncStatusInput struct {
+	Action string `json:"action,omitempty" jsonschema:"status (default), sync (retry now), overwrite (replace the repo's version of a conflicted file), or discard (drop a file's unsynced changes)"`
+	File   string `json:"file,omitempty" jsonschema:"File to overwrite or discard, as listed by status"`
+}
+
+// SyncStatusOutput is the output for the sync_status tool.
+type SyncStatusOutput struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message"`
+	Result  *SyncStatusResult `json:"result,omitempty"`
+}
+
+// SyncStatusResult is the response payload for sync_status.
+type SyncStatusResult struct {
+	Files       []QueuedFileInfo `json:"files"`
+	LastAttempt string           `json:"last_attempt,omitempty"`
+	LastError   string           `json:"last_error,omitempty"`
+	LastSynced  string           `json:"last_synced,omitempty"`
+}
+
+// QueuedFileInfo is one file waiting to sync.
+type QueuedFileInfo struct {
+	File     string   `json:"file"`
+	Changes  []string `json:"changes"`
+	QueuedAt string   `json:"queued_at"`
+	Conflict bool     `json:"conflict,omitempty"`
+}
+
+// Register registers the sync status tool with the MCP server.
+func (t *SyncQueueTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "sync_status",
+		Description: "Show changes saved locally while GitHub was unavailable that haven't synced yet; they sync automatically once GitHub is back. Use it to tell the user a change was saved locally and will sync. Can also retry now, or resolve a file that changed on GitHub meanwhile by overwriting or discarding it.",
+	}, t.syncStatus)
+}
+
+func (t *SyncQueueTools) syncStatus(ctx context.Context, req *mcp.CallToolRequest, input SyncStatusInput) (*mcp.CallToolResult, SyncStatusOutput, error) {
+	action := strings.ToLower(strings.TrimSpace(input.Action))
+	file := strings.TrimSpace(input.File)
+	switch action {
+	case "", "status":
+		return t.status("")
+	case "sync":
+		n, err := t.queue.Sync(ctx)
+		if err != nil {
+			return t.status(fmt.Sprintf("Still can't reach GitHub: %v", err))
+		}
+		return t.status(fmt.Sprintf("Synced %s.", plural(n, "file")))
+	case "overwrite", "discard":
+	default:
+		return nil, SyncStatusOutput{
+			Success: false,
+			Message: fmt.Sprintf("Unknown action %q. Use status, sync, overwrite, or discard.", input.Action),
+		}, nil
+	}
+
+	if file == "" {
+		return nil, SyncStatusOutput{
+			Success: false,
+			Message: "file is required to " + action + " unsynced changes",
+		}, nil
+	}
+	var err error
+	if action == "overwrite" {
+		err = t.queue.Overwrite(ctx, file)
+	} else {
+		err = t.queue.Discard(file)
+	}
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, SyncStatusOutput{
+			Success: false,
+			Message: fmt.Sprintf("No unsynced changes to %s", file),
+		}, nil
+	case err != nil:
+		return nil, SyncStatusOutput{}, fmt.Errorf("%s queued %s: %w", action, file, err)
+	}
+
+	if action == "discard" {
+		return t.status(fmt.Sprintf("Discarded the unsynced changes to %s.", file))
+	}
+	// Sync right away, so the overwrite doesn't wait for the next retry
+	if _, err := t.queue.Sync(ctx); err != nil {
+		return t.status(fmt.Sprintf("%s will replace the repo's version once GitHub is reachable.", file))
+	}
+	return t.status(fmt.Sprintf("Replaced the repo's version of %s with the local one.", file))
+}
+
+// status reports the queue, after note if there is one.
+func (t *SyncQueueTools) status(note string) (*mcp.CallToolResult, SyncStatusOutput, error) {
+	status := t.queue.Status()
+	result := SyncStatusResult{Files: []QueuedFileInfo{}, LastError: status.LastError}
+	if !status.LastAttempt.IsZero() {
+		result.LastAttempt = status.LastAttempt.Format("2006-01-02 15:04")
+	}
+	if !status.LastSynced.IsZero() {
+		result.LastSynced = status.LastSynced.Format("2006-01-02 15:04")
+	}
+	for _, f := range status.Files {
+		result.Files = append(result.Files, QueuedFileInfo{
+			File:     f.Path,
+			Changes:  f.Messages,
+			QueuedAt: f.QueuedAt.Format("2006-01-02 15:04"),
+			Conflict: f.Conflict,
+		})
+	}
+
+	text := result.text()
+	if note != "" {
+		text = note + "\n\n" + text
+	}
+	return textResult(text), SyncStatusOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+func (r SyncStatusResult) text() string {
+	if len(r.Files) == 0 {
+		if r.LastSynced != "" {
+			return fmt.Sprintf("Everything is synced to GitHub (last offline changes synced %s).", r.LastSynced)
+		}
+		return "Everything is synced to GitHub."
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s saved locally, waiting to sync to GitHub:\n", plural(len(r.Files), "file"))
+	for _, f := range r.Files {
+		fmt.Fprintf(&b, "\n%s (%s since %s)\n", f.File, plural(len(f.Changes), "change"), f.QueuedAt)
+		for _, c := range f.Changes {
+			fmt.Fprintf(&b, "  - %s\n", c)
+		}
+		if f.Conflict {
+			b.WriteString("  Changed on GitHub meanwhile: overwrite it with the local version, or discard the local changes.\n")
+		}
+	}
+	if r.LastAttempt != "" {
+		fmt.Fprintf(&b, "\nLast sync attempt: %s", r.LastAttempt)
+		if r.LastError != "" {
+			fmt.Fprintf(&b, " (%s)", r.LastError)
+		}
+	} else if r.LastError != "" {
+		fmt.Fprintf(&b, "\nGitHub error: %s", r.LastError)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// downStorage refuses every write as if GitHub were down.
+type downStorage struct{ *storage.MemoryStorage }
+
+func (d downStorage) WriteFile(ctx context.Context, path, content, sha, message string) error {
+	return storage.ErrUnavailable
+}
+
+func (d downStorage) WriteFiles(ctx context.Context, changes []storage.FileChange, message string) error {
+	return storage.ErrUnavailable
+}
+
+func TestSyncStatus(t *testing.T) {
+	ctx := context.Background()
+	mem := storage.NewMemoryStorage(map[string]string{storage.TodosFile: "# Todos\n"}, nil)
+	queue := storage.NewWriteQueue(downStorage{mem}, "", nil)
+	st := NewSyncQueueTools(queue)
+
+	_, out, err := st.syncStatus(ctx, nil, SyncStatusInput{})
+	if err != nil || !out.Success || out.Message != "Everything is synced to GitHub." {
+		t.Fatalf("empty status = %+v, %v", out, err)
+	}
+
+	_, sha, _ := queue.ReadFile(ctx, storage.TodosFile)
+	if err := queue.WriteFile(ctx, storage.TodosFile, "# Todos\n- [ ] a\n", sha, "Add todo: a"); err != nil {
+		t.Fatal(err)
+	}
+	_, out, _ = st.syncStatus(ctx, nil, SyncStatusInput{Action: "status"})
+	if len(out.Result.Files) != 1 || !strings.Contains(out.Message, "1 file saved locally") || !strings.Contains(out.Message, "Add todo: a") {
+		t.Errorf("status = %s", out.Message)
+	}
+
+	// Syncing while still down keeps the queue
+	_, out, _ = st.syncStatus(ctx, nil, SyncStatusInput{Action: "sync"})
+	if !strings.HasPrefix(out.Message, "Still can't reach GitHub") || len(out.Result.Files) != 1 {
+		t.Errorf("sync while down = %s", out.Message)
+	}
+
+	_, out, _ = st.syncStatus(ctx, nil, SyncStatusInput{Action: "discard"})
+	if out.Success {
+		t.Error("discard without a file succeeded")
+	}
+	_, out, _ = st.syncStatus(ctx, nil, SyncStatusInput{Action: "discard", File: "notes.md"})
+	if out.Success || out.Message != "No unsynced changes to notes.md" {
+		t.Errorf("discard unknown = %+v", out)
+	}
+	_, out, _ = st.syncStatus(ctx, nil, SyncStatusInput{Action: "discard", File: storage.TodosFile})
+	if !out.Success || len(out.Result.Files) != 0 {
+		t.Errorf("discard = %+v", out)
+	}
+	if content, _, _ := queue.ReadFile(ctx, storage.TodosFile); content != "# Todos\n" {
+		t.Errorf("after discard content = %q", content)
+	}
+}
@@ -0,0 +1,419 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/analytics"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TimeTools provides time tracking against todos and milestones.
+type TimeTools struct {
+	storage storage.Storage
+	clock   clock.Clock
+}
+
+// NewTimeTools creates a new TimeTools instance. A nil clock uses the system clock.
+func NewTimeTools(s storage.Storage, c clock.Clock) *TimeTools {
+	return &TimeTools{storage: s, clock: clock.Or(c)}
+}
+
+// StartTimerInput is the input schema for the start_timer tool.
+type StartTimerInput struct {
+	ID             string `json:"id" jsonschema:"ID of the active todo or milestone to track time against. Use list_todos or get_milestones to find IDs."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier time_report call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// StartTimerOutput is the output for the start_timer tool.
+type StartTimerOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// StartTimerResult is the response payload for start_timer.
+type StartTimerResult struct {
+	Started TimeEntryItem `json:"started"`
+	// Stopped is the session that was running before, stopped automatically.
+	Stopped *TimeEntryItem `json:"stopped,omitempty"`
+}
+
+// StopTimerInput is the input schema for the stop_timer tool.
+type StopTimerInput struct {
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier time_report call. If the file has changed since, the write is refused so you can re-read first."`
+}
+
+// StopTimerOutput is the output for the stop_timer tool.
+type StopTimerOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// TimeReportInput is the input schema for the time_report tool.
+type TimeReportInput struct {
+	GroupBy  string `json:"group_by,omitempty" jsonschema:"How to aggregate: day, week, project, or item. Defaults to day."`
+	DateFrom string `json:"date_from,omitempty" jsonschema:"Only sessions starting on or after this date (YYYY-MM-DD). Defaults to 7 days ago."`
+	DateTo   string `json:"date_to,omitempty" jsonschema:"Only sessions starting on or before this date (YYYY-MM-DD). Defaults to today."`
+}
+
+// TimeReportOutput is the output for the time_report tool.
+type TimeReportOutput struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message"`
+	Result  *TimeReportResult `json:"result,omitempty"`
+}
+
+// TimeReportResult is the response payload for time_report.
+type TimeReportResult struct {
+	GroupBy    string            `json:"group_by"`
+	DateFrom   string            `json:"date_from"`
+	DateTo     string            `json:"date_to"`
+	Groups     []TimeReportGroup `json:"groups"`
+	TotalHours float64           `json:"total_hours"`
+	Running    *TimeEntryItem    `json:"running,omitempty"`
+	SourceSHA  string            `json:"source_sha"`
+}
+
+// TimeReportGroup is one row of a time report.
+type TimeReportGroup struct {
+	Key      string  `json:"key"` // date, week start, project, or item ID
+	Label    string  `json:"label,omitempty"`
+	Hours    float64 `json:"hours"`
+	Sessions int     `json:"sessions"`
+}
+
+// Register registers time tracking tools with the MCP server.
+func (t *TimeTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "start_timer",
+		Description: "Start tracking time against a todo or milestone. Any running timer is stopped first.",
+	}, t.startTimer)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "stop_timer",
+		Description: "Stop the running timer and log the session",
+	}, t.stopTimer)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "time_report",
+		Description: "Report hours tracked per day, week, project, or item over a date range (defaults to the last 7 days)",
+	}, t.timeReport)
+}
+
+// readTimeLog loads timelog.md, treating a missing file as an empty log.
+func (t *TimeTools) readTimeLog(ctx context.Context) (*storage.TimeLog, string, error) {
+	content, sha, err := t.storage.ReadFile(ctx, storage.TimeLogFile)
+	if errors.Is(err, storage.ErrNotFound) {
+		return &storage.TimeLog{}, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("reading timelog.md: %w", err)
+	}
+
+	l, err := parseTimeLog(ctx, content)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing time log: %w", err)
+	}
+	return l, sha, nil
+}
+
+// findTrackable looks up an active todo or milestone by ID and returns a
+// session template for it, or nil if there is no such item.
+func findTrackable(ctx context.Context, s storage.Storage, id string) (*storage.TimeEntry, error) {
+	content, _, err := s.ReadFile(ctx, storage.TodosFile)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("reading todos.md: %w", err)
+	}
+	if err == nil {
+		tf, err := parseTodos(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing todos: %w", err)
+		}
+		for _, todo := range tf.Active {
+			if todo.ID == id {
+				return &storage.TimeEntry{ItemType: "todo", ItemID: id, Text: todo.Text, Project: todo.Project}, nil
+			}
+		}
+	}
+
+	content, _, err = s.ReadFile(ctx, storage.StrategyFile)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("reading strategy.md: %w", err)
+	}
+	if err == nil {
+		strategy, err := parseStrategy(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing strategy: %w", err)
+		}
+		for _, m := range strategy.ActiveMilestones {
+			if m.ID == id {
+				return &storage.TimeEntry{ItemType: "milestone", ItemID: id, Text: m.Text, Project: m.Project}, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (t *TimeTools) startTimer(ctx context.Context, req *mcp.CallToolRequest, input StartTimerInput) (*mcp.CallToolResult, StartTimerOutput, error) {
+	id := strings.TrimSpace(input.ID)
+	if id == "" {
+		return nil, StartTimerOutput{
+			Success: false,
+			Message: "id is required",
+		}, nil
+	}
+
+	entry, err := findTrackable(ctx, t.storage, id)
+	if err != nil {
+		return nil, StartTimerOutput{}, err
+	}
+	if entry == nil {
+		return nil, StartTimerOutput{
+			Success: false,
+			Message: fmt.Sprintf("No active todo or milestone found with id %q", id),
+		}, nil
+	}
+
+	l, sha, err := t.readTimeLog(ctx)
+	if err != nil {
+		return nil, StartTimerOutput{}, err
+	}
+	if msg := entitystore.CheckUnchanged(storage.TimeLogFile, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, StartTimerOutput{Success: false, Message: msg}, nil
+	}
+
+	now := t.clock.Now().UTC().Truncate(time.Minute)
+	result := StartTimerResult{}
+
+	if i := l.Running(); i >= 0 {
+		l.Entries[i].End = &now
+		stopped := timeEntryToItem(l.Entries[i], now)
+		result.Stopped = &stopped
+	}
+
+	entry.ID = storage.NewID(storage.PrefixTime, takenIn(func(e *storage.TimeEntry) string { return e.ID }, l.Entries))
+	entry.Start = now
+	l.Entries = append(l.Entries, *entry)
+	result.Started = timeEntryToItem(*entry, now)
+
+	newContent := storage.SerializeTimeLog(l)
+	if err := t.storage.WriteFile(ctx, storage.TimeLogFile, newContent, sha, fmt.Sprintf("Start timer: %s", truncate(entry.Text, 50))); err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, StartTimerOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, StartTimerOutput{}, fmt.Errorf("writing timelog.md: %w", err)
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, StartTimerOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, StartTimerOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
+
+func (t *TimeTools) stopTimer(ctx context.Context, req *mcp.CallToolRequest, input StopTimerInput) (*mcp.CallToolResult, StopTimerOutput, error) {
+	l, sha, err := t.readTimeLog(ctx)
+	if err != nil {
+		return nil, StopTimerOutput{}, err
+	}
+	if msg := entitystore.CheckUnchanged(storage.TimeLogFile, input.IfUnchangedSHA, sha); msg != "" {
+		return nil, StopTimerOutput{Success: false, Message: msg}, nil
+	}
+
+	i := l.Running()
+	if i < 0 {
+		return nil, StopTimerOutput{
+			Success: false,
+			Message: "No timer is running",
+		}, nil
+	}
+
+	now := t.clock.Now().UTC().Truncate(time.Minute)
+	l.Entries[i].End = &now
+
+	newContent := storage.SerializeTimeLog(l)
+	if err := t.storage.WriteFile(ctx, storage.TimeLogFile, newContent, sha, fmt.Sprintf("Stop timer: %s", truncate(l.Entries[i].Text, 50))); err != nil {
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, StopTimerOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, StopTimerOutput{}, fmt.Errorf("writing timelog.md: %w", err)
+	}
+
+	itemJSON, err := json.Marshal(timeEntryToItem(l.Entries[i], now))
+	if err != nil {
+		return nil, StopTimerOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, StopTimerOutput{
+		Success: true,
+		Message: string(itemJSON),
+	}, nil
+}
+
+func (t *TimeTools) timeReport(ctx context.Context, req *mcp.CallToolRequest, input TimeReportInput) (*mcp.CallToolResult, TimeReportOutput, error) {
+	groupBy := strings.ToLower(strings.TrimSpace(input.GroupBy))
+	if groupBy == "" {
+		groupBy = "day"
+	}
+	if groupBy != "day" && groupBy != "week" && groupBy != "project" && groupBy != "item" {
+		return nil, TimeReportOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid group_by %q. Use: day, week, project, or item", input.GroupBy),
+		}, nil
+	}
+
+	today := clock.Today(t.clock)
+
+	dateFrom := today.AddDate(0, 0, -6)
+	if input.DateFrom != "" {
+		d, err := parseDate(input.DateFrom, t.clock.Now())
+		if err != nil {
+			return nil, TimeReportOutput{
+				Success: false,
+				Message: fmt.Sprintf("Invalid date_from format %q. Use YYYY-MM-DD.", input.DateFrom),
+			}, nil
+		}
+		dateFrom = d
+	}
+
+	dateTo := today
+	if input.DateTo != "" {
+		d, err := parseDate(input.DateTo, t.clock.Now())
+		if err != nil {
+			return nil, TimeReportOutput{
+				Success: false,
+				Message: fmt.Sprintf("Invalid date_to format %q. Use YYYY-MM-DD.", input.DateTo),
+			}, nil
+		}
+		dateTo = d
+	}
+
+	l, sha, err := t.readTimeLog(ctx)
+	if err != nil {
+		return nil, TimeReportOutput{}, err
+	}
+
+	now := t.clock.Now()
+	result := aggregateTime(l.Entries, groupBy, dateFrom, dateTo, now)
+	result.SourceSHA = sha
+	if i := l.Running(); i >= 0 {
+		running := timeEntryToItem(l.Entries[i], now)
+		result.Running = &running
+	}
+
+	text := result.text()
+	return textResult(text), TimeReportOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+func (r TimeReportResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%.2f hours from %s to %s by %s, source_sha %s\n",
+		r.TotalHours, r.DateFrom, r.DateTo, r.GroupBy, r.SourceSHA)
+	for _, g := range r.Groups {
+		key := g.Key
+		if g.Label != "" {
+			key += ": " + g.Label
+		}
+		fmt.Fprintf(&sb, "- %s (%.2f hours, %s)\n", key, g.Hours, plural(g.Sessions, "session"))
+	}
+	if e := r.Running; e != nil {
+		fmt.Fprintf(&sb, "Running: %s %s %s%s\n", e.ItemType, e.ItemID, e.Text, itemDetails(
+			labeled("since", e.Start), fmt.Sprintf("%d min", e.Minutes)))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// aggregateTime groups sessions starting within [from, to] (whole days) and
+// sums their hours. Sessions are attributed to the day they started.
+func aggregateTime(entries []storage.TimeEntry, groupBy string, from, to, now time.Time) TimeReportResult {
+	result := TimeReportResult{
+		GroupBy:  groupBy,
+		DateFrom: from.Format("2006-01-02"),
+		DateTo:   to.Format("2006-01-02"),
+		Groups:   []TimeReportGroup{},
+	}
+
+	end := to.AddDate(0, 0, 1)
+	groups := make(map[string]*TimeReportGroup)
+	var total time.Duration
+	durations := make(map[string]time.Duration)
+
+	for _, e := range entries {
+		// The day a session started is the user's, in now's timezone
+		day := clock.Date(e.Start.In(now.Location()))
+		if day.Before(from) || !day.Before(end) {
+			continue
+		}
+
+		var key, label string
+		switch groupBy {
+		case "day":
+			key = day.Format("2006-01-02")
+		case "week":
+			key = analytics.WeekStart(day).Format("2006-01-02")
+		case "project":
+			key = e.Project
+			if key == "" {
+				key = "(none)"
+			}
+		case "item":
+			key, label = e.ItemType+":"+e.ItemID, e.Text
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &TimeReportGroup{Key: key, Label: label}
+			groups[key] = g
+		}
+		g.Sessions++
+		d := e.Duration(now)
+		durations[key] += d
+		total += d
+	}
+
+	for key, g := range groups {
+		g.Hours = hours(durations[key])
+		result.Groups = append(result.Groups, *g)
+	}
+	// Dates chronologically; projects and items by time spent
+	sort.Slice(result.Groups, func(i, j int) bool {
+		if groupBy == "day" || groupBy == "week" {
+			return result.Groups[i].Key < result.Groups[j].Key
+		}
+		if result.Groups[i].Hours != result.Groups[j].Hours {
+			return result.Groups[i].Hours > result.Groups[j].Hours
+		}
+		return result.Groups[i].Key < result.Groups[j].Key
+	})
+	result.TotalHours = hours(total)
+	return result
+}
+
+// hours converts a duration to hours rounded to two decimal places.
+func hours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestAggregateTime(t *testing.T) {
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC) }
+	end := func(day, hour, min int) *time.Time { t := at(day, hour, min); return &t }
+
+	entries := []storage.TimeEntry{
+		{ItemType: "todo", ItemID: "a", Project: "website", Start: at(1, 9, 0), End: end(1, 10, 0)}, // outside range
+		{ItemType: "todo", ItemID: "a", Project: "website", Start: at(2, 9, 0), End: end(2, 10, 30)},
+		{ItemType: "milestone", ItemID: "m", Start: at(2, 14, 0), End: end(2, 14, 45)},
+		{ItemType: "todo", ItemID: "a", Project: "website", Start: at(4, 8, 0)}, // running
+	}
+	now := at(4, 9, 0)
+	from, to := at(2, 0, 0), at(8, 0, 0)
+
+	byDay := aggregateTime(entries, "day", from, to, now)
+	if len(byDay.Groups) != 2 || byDay.Groups[0].Key != "2026-03-02" || byDay.Groups[0].Hours != 2.25 || byDay.Groups[0].Sessions != 2 {
+		t.Errorf("unexpected day groups: %+v", byDay.Groups)
+	}
+	if byDay.TotalHours != 3.25 {
+		t.Errorf("expected 3.25 total hours including the running session, got %v", byDay.TotalHours)
+	}
+
+	byProject := aggregateTime(entries, "project", from, to, now)
+	if len(byProject.Groups) != 2 || byProject.Groups[0].Key != "website" || byProject.Groups[0].Hours != 2.5 || byProject.Groups[1].Key != "(none)" {
+		t.Errorf("unexpected project groups: %+v", byProject.Groups)
+	}
+
+	byWeek := aggregateTime(entries, "week", from, to, now)
+	if len(byWeek.Groups) != 1 || byWeek.Groups[0].Key != "2026-03-02" {
+		t.Errorf("unexpected week groups: %+v", byWeek.Groups)
+	}
+}
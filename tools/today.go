@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// todayMilestoneDays is how far ahead get_today looks for milestone due dates.
+const todayMilestoneDays = 7
+
+// GetTodayInput is the input schema for the get_today tool.
+type GetTodayInput struct{}
+
+// GetTodayOutput is the output for the get_today tool.
+type GetTodayOutput struct {
+	Success bool         `json:"success"`
+	Message string       `json:"message"`
+	Result  *TodayResult `json:"result,omitempty"`
+}
+
+// TodayResult is the agenda returned by get_today. Empty sections are
+// omitted to keep it small.
+type TodayResult struct {
+	Date             string      `json:"date"`
+	OverdueReminders []TodayItem `json:"overdue_reminders,omitempty"`
+	DueReminders     []TodayItem `json:"due_reminders,omitempty"`
+	HighPriority     []TodayItem `json:"high_priority_todos,omitempty"`
+	Milestones       []TodayItem `json:"milestones_due,omitempty"`
+	SuggestedReading *TodayItem  `json:"suggested_reading,omitempty"`
+}
+
+// TodayItem is one agenda entry: just enough to mention or act on it.
+type TodayItem struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	Due  string `json:"due,omitempty"`
+}
+
+func (d *DashboardTools) getToday(ctx context.Context, req *mcp.CallToolRequest, input GetTodayInput) (*mcp.CallToolResult, GetTodayOutput, error) {
+	result := todayAgenda(ctx, d.storage, clock.Today(d.clock))
+	text := result.text()
+	return textResult(text), GetTodayOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+// todayAgenda gathers the agenda for today from the data files in s.
+// Missing or unparseable files leave their section empty, as on the
+// dashboard.
+func todayAgenda(ctx context.Context, s storage.Storage, today time.Time) TodayResult {
+	result := TodayResult{Date: formatDate(today)}
+
+	if content, _, err := s.ReadFile(ctx, storage.RemindersFile); err == nil {
+		if rf, err := parseReminders(ctx, content); err == nil {
+			for _, r := range rf.Upcoming {
+				item := TodayItem{ID: r.ID, Text: r.Text, Due: formatDate(r.Date)}
+				switch {
+				case r.Date.Before(today):
+					result.OverdueReminders = append(result.OverdueReminders, item)
+				case r.Date.Equal(today):
+					result.DueReminders = append(result.DueReminders, item)
+				}
+			}
+		}
+	}
+
+	if content, _, err := s.ReadFile(ctx, storage.TodosFile); err == nil {
+		if tf, err := parseTodos(ctx, content); err == nil {
+			for _, t := range tf.Active {
+				if t.Priority.AtLeast(storage.PriorityHigh) {
+					result.HighPriority = append(result.HighPriority, TodayItem{ID: t.ID, Text: t.Text})
+				}
+			}
+		}
+	}
+
+	if content, _, err := s.ReadFile(ctx, storage.StrategyFile); err == nil {
+		if sf, err := parseStrategy(ctx, content); err == nil {
+			horizon := today.AddDate(0, 0, todayMilestoneDays)
+			for _, m := range sf.ActiveMilestones {
+				if m.Due != nil && !m.Due.After(horizon) {
+					result.Milestones = append(result.Milestones, TodayItem{ID: m.ID, Text: m.Text, Due: formatDate(*m.Due)})
+				}
+			}
+			sort.SliceStable(result.Milestones, func(i, j int) bool { return result.Milestones[i].Due < result.Milestones[j].Due })
+		}
+	}
+
+	// Suggest the unread item that has waited longest, preferring items
+	// marked to read next and avoiding someday items
+	if content, _, err := s.ReadFile(ctx, storage.ReadingListFile); err == nil {
+		if rl, err := parseReadingList(ctx, content); err == nil && len(rl.ToRead) > 0 {
+			oldest := rl.ToRead[0]
+			for _, r := range rl.ToRead[1:] {
+				if rank, best := readingPriorityRank(r.Priority), readingPriorityRank(oldest.Priority); rank != best {
+					if rank < best {
+						oldest = r
+					}
+					continue
+				}
+				if !r.Added.IsZero() && (oldest.Added.IsZero() || r.Added.Before(oldest.Added)) {
+					oldest = r
+				}
+			}
+			text := oldest.URL
+			if oldest.Notes != "" {
+				text += " — " + oldest.Notes
+			}
+			result.SuggestedReading = &TodayItem{ID: oldest.ID, Text: text}
+		}
+	}
+	return result
+}
+
+func (r TodayResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Today, %s\n", r.Date)
+	section := func(title string, items []TodayItem) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n%s\n", title)
+		for _, item := range items {
+			sb.WriteString("- " + item.Text + itemDetails("id "+item.ID, labeled("due", item.Due)) + "\n")
+		}
+	}
+	section("Overdue reminders", r.OverdueReminders)
+	section("Reminders due today", r.DueReminders)
+	section("High-priority todos", r.HighPriority)
+	section(fmt.Sprintf("Milestones due within %d days", todayMilestoneDays), r.Milestones)
+	if r.SuggestedReading != nil {
+		section("Suggested reading", []TodayItem{*r.SuggestedReading})
+	}
+	if len(r.OverdueReminders)+len(r.DueReminders)+len(r.HighPriority)+len(r.Milestones) == 0 {
+		sb.WriteString("\nNothing due and no high-priority todos.\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestGetToday(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.TodosFile: "# Active Todos\n\n## High Priority\n- [ ] Ship it {id:aaaa1111}\n\n## Normal\n- [ ] Tidy up {id:bbbb2222}\n\n# Completed\n",
+		storage.RemindersFile: "## Upcoming\n- 2026-02-09: Renew domain {id:cccc3333}\n" +
+			"- 2026-02-10: Call bank {id:dddd4444}\n- 2026-02-11: Dentist {id:eeee5555}\n",
+		storage.StrategyFile: "## Current Phase\nLaunch\n\n## Active Milestones\n" +
+			"- [ ] Launch — Due: 2026-02-20 {id:ffff6666}\n- [ ] Beta — Due: 2026-02-15 {id:abab1212}\n",
+		storage.ReadingListFile: "## To Read\n- [ ] https://b.example {id:cdcd3434,added:2026-02-01}\n" +
+			"- [ ] https://a.example — Old one {id:efef5656,added:2026-01-01}\n",
+	})
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	NewDashboardTools(files, clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)), SizeQuota{}, WorkloadLimits{}).Register(server)
+
+	res := callOverMCP(t, server, "get_today", map[string]any{})
+	raw, _ := json.Marshal(res.StructuredContent)
+	var out GetTodayOutput
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatal(err)
+	}
+	r := out.Result
+	if r == nil || r.Date != "2026-02-10" {
+		t.Fatalf("unexpected result %s", raw)
+	}
+	ids := func(items []TodayItem) string {
+		var s []string
+		for _, item := range items {
+			s = append(s, item.ID)
+		}
+		return strings.Join(s, ",")
+	}
+	if got := ids(r.OverdueReminders); got != "cccc3333" {
+		t.Errorf("overdue = %s", got)
+	}
+	if got := ids(r.DueReminders); got != "dddd4444" {
+		t.Errorf("due today = %s", got)
+	}
+	if got := ids(r.HighPriority); got != "aaaa1111" {
+		t.Errorf("high priority = %s", got)
+	}
+	// Only milestones within a week, soonest first
+	if got := ids(r.Milestones); got != "abab1212" {
+		t.Errorf("milestones = %s", got)
+	}
+	if r.SuggestedReading == nil || r.SuggestedReading.ID != "efef5656" {
+		t.Errorf("suggested reading = %+v", r.SuggestedReading)
+	}
+	if !strings.Contains(out.Message, "Renew domain") || strings.Contains(out.Message, "Tidy up") {
+		t.Errorf("unexpected message %q", out.Message)
+	}
+}
+
+func TestGetToday_Empty(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	NewDashboardTools(newFileStorage(nil), nil, SizeQuota{}, WorkloadLimits{}).Register(server)
+
+	res := callOverMCP(t, server, "get_today", map[string]any{})
+	if text := res.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "Nothing due") {
+		t.Errorf("unexpected text %q", text)
+	}
+}
+
+func TestGetToday_MidnightRollover(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.RemindersFile: "## Upcoming\n- 2026-02-10: Call bank {id:dddd4444}\n- 2026-02-11: Dentist {id:eeee5555}\n",
+	})
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A minute before midnight on the 10th in Auckland (10:59 UTC)
+	fake := clock.NewFake(time.Date(2026, 2, 10, 10, 59, 0, 0, time.UTC))
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	NewDashboardTools(files, clock.InLocation(fake, auckland), SizeQuota{}, WorkloadLimits{}).Register(server)
+
+	today := func() *TodayResult {
+		res := callOverMCP(t, server, "get_today", map[string]any{})
+		raw, _ := json.Marshal(res.StructuredContent)
+		var out GetTodayOutput
+		if err := json.Unmarshal(raw, &out); err != nil || out.Result == nil {
+			t.Fatalf("unexpected result %s", raw)
+		}
+		return out.Result
+	}
+
+	r := today()
+	if r.Date != "2026-02-10" || len(r.OverdueReminders) != 0 || len(r.DueReminders) != 1 || r.DueReminders[0].ID != "dddd4444" {
+		t.Errorf("before midnight: %+v", r)
+	}
+
+	fake.Advance(time.Minute)
+	r = today()
+	if r.Date != "2026-02-11" || len(r.OverdueReminders) != 1 || r.OverdueReminders[0].ID != "dddd4444" ||
+		len(r.DueReminders) != 1 || r.DueReminders[0].ID != "eeee5555" {
+		t.Errorf("after midnight: %+v", r)
+	}
+}
@@ -6,8 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
-	"time"
 
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
 	"github.com/dang-w/momentum-mcp-server/storage"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -15,47 +16,76 @@ import (
 // TodoTools provides tools for managing todos.
 type TodoTools struct {
 	storage storage.Storage
+	todos   *entitystore.Store[storage.TodoFile, storage.Todo]
+	clock   clock.Clock
+	wip     WIPLimits
 }
 
-// NewTodoTools creates a new TodoTools instance.
-func NewTodoTools(s storage.Storage) *TodoTools {
-	return &TodoTools{storage: s}
+// NewTodoTools creates a new TodoTools instance. A nil clock uses the system
+// clock; zero limits leave every priority unlimited.
+func NewTodoTools(s storage.Storage, c clock.Clock, wip WIPLimits) *TodoTools {
+	return &TodoTools{storage: s, todos: entitystore.New(s, todoKind), clock: clock.Or(c), wip: wip}
 }
 
 // AddTodoInput is the input schema for the add_todo tool.
 type AddTodoInput struct {
-	Text     string `json:"text" jsonschema:"The todo item text"`
-	Priority string `json:"priority,omitempty" jsonschema:"Priority level: high, normal, or someday. Defaults to normal."`
+	Text           string `json:"text" jsonschema:"The todo item text"`
+	Priority       string `json:"priority,omitempty" jsonschema:"Priority level: exactly one of urgent, high, normal, or someday (lowercase). Defaults to normal."`
+	Project        string `json:"project,omitempty" jsonschema:"Project the todo belongs to (see list_projects). Optional."`
+	MilestoneID    string `json:"milestone_id,omitempty" jsonschema:"ID of the milestone the todo works towards (see get_milestones). Optional."`
+	Force          bool   `json:"force,omitempty" jsonschema:"Add the todo even if it looks like a duplicate of an active one or its priority is at its WIP limit"`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // AddTodoOutput is the output for the add_todo tool.
 type AddTodoOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
+	// ID and Item are the added todo, set on success.
+	ID   string    `json:"id,omitempty"`
+	Item *TodoItem `json:"item,omitempty"`
+	// Duplicates lists the similar active todos when the add was refused
+	// as a likely duplicate.
+	Duplicates []TodoItem `json:"duplicates,omitempty"`
+	// Warning is set when the todo was added over its priority's WIP limit.
+	Warning string `json:"warning,omitempty"`
 }
 
 // CompleteTodoInput is the input schema for the complete_todo tool.
 type CompleteTodoInput struct {
-	Text string `json:"text,omitempty" jsonschema:"Text to match against todo items. Can be partial match."`
-	ID   string `json:"id,omitempty" jsonschema:"ID of the todo to complete. More reliable than text matching. Use list_todos to find IDs."`
+	Text           string `json:"text,omitempty" jsonschema:"Text to match against todo items. Can be partial or approximate; if it matches several without a clear winner, the candidates are returned."`
+	ID             string `json:"id,omitempty" jsonschema:"ID of the todo to complete. More reliable than text matching. Use list_todos to find IDs."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // CompleteTodoOutput is the output for the complete_todo tool.
 type CompleteTodoOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
+	// ID and Item are the completed todo, set on success.
+	ID   string    `json:"id,omitempty"`
+	Item *TodoItem `json:"item,omitempty"`
+	// Progress is the completion streak and weekly record, set on success.
+	Progress *CompletionProgress `json:"progress,omitempty"`
+	// Candidates are the items the text matched, best first, when it
+	// matched several without a clear winner.
+	Candidates []entitystore.Candidate `json:"candidates,omitempty"`
 }
 
 // ListTodosInput is the input schema for the list_todos tool.
 type ListTodosInput struct {
-	Status   string `json:"status,omitempty" jsonschema:"Filter by status: active, completed, or all. Defaults to active."`
-	Priority string `json:"priority,omitempty" jsonschema:"Filter by priority: high, normal, or someday. No filter if omitted."`
+	Status      string `json:"status,omitempty" jsonschema:"Filter by status: active, completed, or all. Defaults to active."`
+	Priority    string `json:"priority,omitempty" jsonschema:"Filter by priority: urgent, high, normal, or someday. No filter if omitted."`
+	Project     string `json:"project,omitempty" jsonschema:"Filter by project. No filter if omitted."`
+	MilestoneID string `json:"milestone_id,omitempty" jsonschema:"Filter to todos linked to this milestone ID. No filter if omitted."`
+	Sort        string `json:"sort,omitempty" jsonschema:"Sort by added, priority, alphabetical, or completed. Prefix with - to reverse (e.g. -added for newest first). Defaults to file order."`
 }
 
 // ListTodosOutput is the output for the list_todos tool.
 type ListTodosOutput struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Result  *ListTodosResult `json:"result,omitempty"`
 }
 
 // ListTodosResult is the response payload for list_todos.
@@ -63,12 +93,16 @@ type ListTodosResult struct {
 	Todos          []TodoItem `json:"todos"`
 	TotalActive    int        `json:"total_active"`
 	TotalCompleted int        `json:"total_completed"`
+	// SourceSHA identifies the file version read; pass it as if_unchanged_sha
+	// on a later write to detect concurrent changes.
+	SourceSHA string `json:"source_sha"`
 }
 
 // DeleteTodoInput is the input schema for the delete_todo tool.
 type DeleteTodoInput struct {
-	ID      string `json:"id" jsonschema:"ID of the todo to delete. Use list_todos to find IDs."`
-	Confirm bool   `json:"confirm" jsonschema:"Must be set to true to confirm deletion. This is a hard delete, not completion."`
+	ID             string `json:"id" jsonschema:"ID of the todo to delete. Use list_todos to find IDs."`
+	Confirm        bool   `json:"confirm" jsonschema:"Must be set to true to confirm deletion. This deletes the todo (into the trash), it doesn't complete it."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // DeleteTodoOutput is the output for the delete_todo tool.
@@ -79,42 +113,67 @@ type DeleteTodoOutput struct {
 
 // EditTodoInput is the input schema for the edit_todo tool.
 type EditTodoInput struct {
-	ID       string `json:"id" jsonschema:"ID of the todo to edit. Use list_todos to find IDs."`
-	Text     string `json:"text,omitempty" jsonschema:"New todo text. If omitted, keeps existing text."`
-	Priority string `json:"priority,omitempty" jsonschema:"New priority level: high, normal, or someday. If omitted, keeps existing priority."`
+	ID             string `json:"id" jsonschema:"ID of the todo to edit. Use list_todos to find IDs."`
+	Text           string `json:"text,omitempty" jsonschema:"New todo text. If omitted, keeps existing text."`
+	Priority       string `json:"priority,omitempty" jsonschema:"New priority level: urgent, high, normal, or someday. If omitted, keeps existing priority."`
+	Project        string `json:"project,omitempty" jsonschema:"New project. If omitted, keeps existing project. Pass 'none' to remove it from its project."`
+	MilestoneID    string `json:"milestone_id,omitempty" jsonschema:"ID of the milestone the todo works towards. If omitted, keeps the existing link. Pass 'none' to unlink it."`
+	Force          bool   `json:"force,omitempty" jsonschema:"Change the priority even if the new one is at its WIP limit"`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list call. If the file has changed since, the write is refused so you can re-read first."`
 }
 
 // EditTodoOutput is the output for the edit_todo tool.
 type EditTodoOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
+	// ID and Item are the edited todo, set on success.
+	ID   string    `json:"id,omitempty"`
+	Item *TodoItem `json:"item,omitempty"`
+	// Warning is set when the todo was moved over its new priority's WIP
+	// limit.
+	Warning string `json:"warning,omitempty"`
 }
 
 // Register registers todo tools with the MCP server.
 func (t *TodoTools) Register(server *mcp.Server) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "add_todo",
-		Description: "Add a new todo item to the list",
+		Description: "Add a new todo item to the list. Refused with the matching todos if it looks like a duplicate of an active one, unless force is set.",
 	}, t.addTodo)
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "complete_todo",
-		Description: "Mark a todo item as completed",
+		Description: "Mark a todo item as completed. The response includes the completion streak and weekly record (progress.highlight) to share with the user.",
 	}, t.completeTodo)
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "list_todos",
-		Description: "List todo items with optional filtering by status and priority",
+		Description: "List todo items with optional filtering by status, priority, project, and milestone",
 	}, t.listTodos)
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "edit_todo",
-		Description: "Edit a todo item's text or priority",
+		Description: "Edit a todo item's text, priority, project, or milestone link",
 	}, t.editTodo)
 
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "reorder_todo",
+		Description: "Move an active todo to the top or bottom of its priority section, or before or after another todo with the same priority",
+	}, t.reorderTodo)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "add_subtask",
+		Description: "Add a checklist item (subtask) under an active todo, for multi-step tasks",
+	}, t.addSubtask)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "complete_subtask",
+		Description: "Check off a subtask of an active todo",
+	}, t.completeSubtask)
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "delete_todo",
-		Description: "Permanently delete a todo item. Use complete_todo for normal completion.",
+		Description: "Delete a todo item into the trash, where restore_item can bring it back for 30 days. Use complete_todo for normal completion.",
 	}, t.deleteTodo)
 }
 
@@ -126,55 +185,56 @@ func (t *TodoTools) addTodo(ctx context.Context, req *mcp.CallToolRequest, input
 		}, nil
 	}
 
-	// Read current todos
-	content, sha, err := t.storage.ReadFile(ctx, "todos.md")
-	if err != nil {
-		return nil, AddTodoOutput{}, fmt.Errorf("reading todos.md: %w", err)
-	}
-
-	tf, err := storage.ParseTodos(content)
-	if err != nil {
-		return nil, AddTodoOutput{}, fmt.Errorf("parsing todos: %w", err)
-	}
-
 	// Determine priority
 	priority := storage.PriorityNormal
-	switch strings.ToLower(input.Priority) {
-	case "high":
-		priority = storage.PriorityHigh
-	case "someday":
-		priority = storage.PrioritySomeday
-	case "normal", "":
-		priority = storage.PriorityNormal
-	default:
-		return nil, AddTodoOutput{
-			Success: false,
-			Message: fmt.Sprintf("Invalid priority %q. Use: high, normal, or someday", input.Priority),
-		}, nil
-	}
-
-	// Add the new todo
-	newTodo := storage.Todo{
-		ID:       storage.GenerateID(),
-		Text:     strings.TrimSpace(input.Text),
-		Priority: priority,
-		Added:    time.Now().UTC().Truncate(24 * time.Hour),
-	}
-	tf.Active = append(tf.Active, newTodo)
-
-	// Serialize and write back
-	newContent := storage.SerializeTodos(tf)
-	if err := t.storage.WriteFile(ctx, "todos.md", newContent, sha, fmt.Sprintf("Add todo: %s", truncate(input.Text, 50))); err != nil {
-		if err == storage.ErrConflict {
+	if strings.TrimSpace(input.Priority) != "" {
+		p, ok := parsePriority(input.Priority)
+		if !ok {
 			return nil, AddTodoOutput{
 				Success: false,
-				Message: "File was modified by another process. Please try again.",
+				Message: fmt.Sprintf("Invalid priority %q. Use: urgent, high, normal, or someday", input.Priority),
 			}, nil
 		}
-		return nil, AddTodoOutput{}, fmt.Errorf("writing todos.md: %w", err)
+		priority = p
+	}
+
+	newTodo := storage.Todo{
+		Text:      strings.TrimSpace(input.Text),
+		Priority:  priority,
+		Project:   storage.NormalizeProject(input.Project),
+		Milestone: strings.TrimSpace(input.MilestoneID),
+		Added:     clock.Today(t.clock),
+	}
+	var duplicates []TodoItem
+	var warning string
+	newTodo, err := t.todos.Add(ctx, input.IfUnchangedSHA, newTodo, fmt.Sprintf("Add todo: %s", truncate(input.Text, 50)), func(tf *storage.TodoFile) error {
+		warning = t.wip.check(tf.Active, priority, "")
+		if input.Force {
+			return nil
+		}
+		if warning != "" && t.wip.Refuse {
+			return &entitystore.Error{Message: warning + " Set force to add it anyway."}
+		}
+		matches := similarTodos(tf.Active, input.Text)
+		if len(matches) == 0 {
+			return nil
+		}
+		var texts []string
+		for _, m := range matches {
+			duplicates = append(duplicates, todoToItem(m))
+			texts = append(texts, fmt.Sprintf("%q (id %s)", m.Text, m.ID))
+		}
+		return &entitystore.Error{Message: fmt.Sprintf("Possible duplicate of %s. Set force to add it anyway.", strings.Join(texts, ", "))}
+	})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, AddTodoOutput{Success: false, Message: msg, Duplicates: duplicates}, nil
+	}
+	if err != nil {
+		return nil, AddTodoOutput{}, err
 	}
 
-	itemJSON, err := json.Marshal(todoToItem(newTodo))
+	item := todoToItem(newTodo)
+	itemJSON, err := json.Marshal(item)
 	if err != nil {
 		return nil, AddTodoOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
@@ -182,6 +242,9 @@ func (t *TodoTools) addTodo(ctx context.Context, req *mcp.CallToolRequest, input
 	return nil, AddTodoOutput{
 		Success: true,
 		Message: string(itemJSON),
+		ID:      item.ID,
+		Item:    &item,
+		Warning: warning,
 	}, nil
 }
 
@@ -193,100 +256,47 @@ func (t *TodoTools) completeTodo(ctx context.Context, req *mcp.CallToolRequest,
 		}, nil
 	}
 
-	// Read current todos
-	content, sha, err := t.storage.ReadFile(ctx, "todos.md")
-	if err != nil {
-		return nil, CompleteTodoOutput{}, fmt.Errorf("reading todos.md: %w", err)
+	// Mark as completed and move to the front of the completed list
+	todo, err := t.todos.Move(ctx, entitystore.Open, entitystore.Ref{ID: input.ID, Text: input.Text}, input.IfUnchangedSHA, func(todo *storage.Todo) string {
+		todo.Completed = true
+		now := clock.Today(t.clock)
+		todo.CompletedAt = &now
+		return fmt.Sprintf("Complete todo: %s", truncate(todo.Text, 50))
+	})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, CompleteTodoOutput{Success: false, Message: msg, Candidates: entitystore.Candidates(err)}, nil
 	}
-
-	tf, err := storage.ParseTodos(content)
 	if err != nil {
-		return nil, CompleteTodoOutput{}, fmt.Errorf("parsing todos: %w", err)
+		return nil, CompleteTodoOutput{}, err
 	}
 
-	// Find matching todos — prefer ID match if provided
-	var matches []int
-	if id := strings.TrimSpace(input.ID); id != "" {
-		for i, todo := range tf.Active {
-			if todo.ID == id {
-				matches = append(matches, i)
-				break
-			}
-		}
-		if len(matches) == 0 {
-			return nil, CompleteTodoOutput{
-				Success: false,
-				Message: fmt.Sprintf("No active todo found with id %q", input.ID),
-			}, nil
-		}
-	} else {
-		searchText := strings.ToLower(strings.TrimSpace(input.Text))
-		for i, todo := range tf.Active {
-			if strings.Contains(strings.ToLower(todo.Text), searchText) {
-				matches = append(matches, i)
-			}
-		}
-
-		if len(matches) == 0 {
-			return nil, CompleteTodoOutput{
-				Success: false,
-				Message: fmt.Sprintf("No active todo found matching %q", input.Text),
-			}, nil
-		}
-
-		if len(matches) > 1 {
-			var matchTexts []string
-			for _, idx := range matches {
-				matchTexts = append(matchTexts, fmt.Sprintf("- [%s] %s", tf.Active[idx].ID, tf.Active[idx].Text))
-			}
-			return nil, CompleteTodoOutput{
-				Success: false,
-				Message: fmt.Sprintf("Multiple todos match %q. Please be more specific or use an id:\n%s", input.Text, strings.Join(matchTexts, "\n")),
-			}, nil
-		}
-	}
-
-	// Mark as completed
-	idx := matches[0]
-	todo := tf.Active[idx]
-	todo.Completed = true
-	now := time.Now().UTC().Truncate(24 * time.Hour)
-	todo.CompletedAt = &now
-
-	// Move from active to completed
-	tf.Active = append(tf.Active[:idx], tf.Active[idx+1:]...)
-	tf.Completed = append([]storage.Todo{todo}, tf.Completed...) // Add to front
-
-	// Serialize and write back
-	newContent := storage.SerializeTodos(tf)
-	if err := t.storage.WriteFile(ctx, "todos.md", newContent, sha, fmt.Sprintf("Complete todo: %s", truncate(todo.Text, 50))); err != nil {
-		if err == storage.ErrConflict {
-			return nil, CompleteTodoOutput{
-				Success: false,
-				Message: "File was modified by another process. Please try again.",
-			}, nil
-		}
-		return nil, CompleteTodoOutput{}, fmt.Errorf("writing todos.md: %w", err)
-	}
-
-	itemJSON, err := json.Marshal(todoToItem(todo))
+	item := todoToItem(todo)
+	itemJSON, err := json.Marshal(item)
 	if err != nil {
 		return nil, CompleteTodoOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
 
 	return nil, CompleteTodoOutput{
-		Success: true,
-		Message: string(itemJSON),
+		Success:  true,
+		Message:  string(itemJSON),
+		ID:       item.ID,
+		Item:     &item,
+		Progress: completionProgress(ctx, t.storage, clock.Today(t.clock)),
 	}, nil
 }
 
 func (t *TodoTools) listTodos(ctx context.Context, req *mcp.CallToolRequest, input ListTodosInput) (*mcp.CallToolResult, ListTodosOutput, error) {
-	content, _, err := t.storage.ReadFile(ctx, "todos.md")
+	sortKey, sortDesc, msg := parseSort(input.Sort, sortAdded, sortPriority, sortAlphabetical, sortCompleted)
+	if msg != "" {
+		return nil, ListTodosOutput{Success: false, Message: msg}, nil
+	}
+
+	content, sha, err := t.storage.ReadFile(ctx, storage.TodosFile)
 	if err != nil {
 		return nil, ListTodosOutput{}, fmt.Errorf("reading todos.md: %w", err)
 	}
 
-	tf, err := storage.ParseTodos(content)
+	tf, err := parseTodos(ctx, content)
 	if err != nil {
 		return nil, ListTodosOutput{}, fmt.Errorf("parsing todos: %w", err)
 	}
@@ -314,20 +324,12 @@ func (t *TodoTools) listTodos(ctx context.Context, req *mcp.CallToolRequest, inp
 	}
 
 	// Filter by priority if specified
-	priority := strings.ToLower(strings.TrimSpace(input.Priority))
-	if priority != "" {
-		var p storage.Priority
-		switch priority {
-		case "high":
-			p = storage.PriorityHigh
-		case "normal":
-			p = storage.PriorityNormal
-		case "someday":
-			p = storage.PrioritySomeday
-		default:
+	if strings.TrimSpace(input.Priority) != "" {
+		p, ok := parsePriority(input.Priority)
+		if !ok {
 			return nil, ListTodosOutput{
 				Success: false,
-				Message: fmt.Sprintf("Invalid priority %q. Use: high, normal, or someday", input.Priority),
+				Message: fmt.Sprintf("Invalid priority %q. Use: urgent, high, normal, or someday", input.Priority),
 			}, nil
 		}
 
@@ -340,26 +342,47 @@ func (t *TodoTools) listTodos(ctx context.Context, req *mcp.CallToolRequest, inp
 		items = filtered
 	}
 
+	// Filter by project if specified
+	if project := storage.NormalizeProject(input.Project); project != "" {
+		var filtered []storage.Todo
+		for _, todo := range items {
+			if todo.Project == project {
+				filtered = append(filtered, todo)
+			}
+		}
+		items = filtered
+	}
+
+	// Filter by milestone if specified
+	if milestone := strings.TrimSpace(input.MilestoneID); milestone != "" {
+		var filtered []storage.Todo
+		for _, todo := range items {
+			if todo.Milestone == milestone {
+				filtered = append(filtered, todo)
+			}
+		}
+		items = filtered
+	}
+
 	// Convert to response items
 	todoItems := make([]TodoItem, len(items))
 	for i, todo := range items {
 		todoItems[i] = todoToItem(todo)
 	}
+	sortTodoItems(todoItems, sortKey, sortDesc)
 
 	result := ListTodosResult{
+		SourceSHA:      sha,
 		Todos:          todoItems,
 		TotalActive:    len(tf.Active),
 		TotalCompleted: len(tf.Completed),
 	}
 
-	jsonBytes, err := json.Marshal(result)
-	if err != nil {
-		return nil, ListTodosOutput{}, fmt.Errorf("marshaling response: %w", err)
-	}
-
-	return nil, ListTodosOutput{
+	text := result.text()
+	return textResult(text), ListTodosOutput{
 		Success: true,
-		Message: string(jsonBytes),
+		Message: text,
+		Result:  &result,
 	}, nil
 }
 
@@ -371,87 +394,90 @@ func (t *TodoTools) editTodo(ctx context.Context, req *mcp.CallToolRequest, inpu
 		}, nil
 	}
 
-	if strings.TrimSpace(input.Text) == "" && strings.TrimSpace(input.Priority) == "" {
+	if strings.TrimSpace(input.Text) == "" && strings.TrimSpace(input.Priority) == "" && strings.TrimSpace(input.Project) == "" && strings.TrimSpace(input.MilestoneID) == "" {
 		return nil, EditTodoOutput{
 			Success: false,
-			Message: "At least one of text or priority must be provided",
+			Message: "At least one of text, priority, project, or milestone_id must be provided",
 		}, nil
 	}
 
 	// Validate priority if provided
 	var newPriority storage.Priority
-	if p := strings.ToLower(strings.TrimSpace(input.Priority)); p != "" {
-		switch p {
-		case "high":
-			newPriority = storage.PriorityHigh
-		case "normal":
-			newPriority = storage.PriorityNormal
-		case "someday":
-			newPriority = storage.PrioritySomeday
-		default:
+	if strings.TrimSpace(input.Priority) != "" {
+		p, ok := parsePriority(input.Priority)
+		if !ok {
 			return nil, EditTodoOutput{
 				Success: false,
-				Message: fmt.Sprintf("Invalid priority %q. Use: high, normal, or someday", input.Priority),
+				Message: fmt.Sprintf("Invalid priority %q. Use: urgent, high, normal, or someday", input.Priority),
 			}, nil
 		}
+		newPriority = p
 	}
 
-	// Read current todos
-	content, sha, err := t.storage.ReadFile(ctx, "todos.md")
+	// Loaded here rather than through Update, to check the WIP limit
+	// against the other active todos
+	tf, sha, err := t.todos.Load(ctx, input.IfUnchangedSHA)
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, EditTodoOutput{Success: false, Message: msg}, nil
+	}
 	if err != nil {
-		return nil, EditTodoOutput{}, fmt.Errorf("reading todos.md: %w", err)
+		return nil, EditTodoOutput{}, err
+	}
+	list, i, err := t.todos.Find(tf, entitystore.Open, entitystore.Ref{ID: input.ID})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, EditTodoOutput{Success: false, Message: msg}, nil
 	}
-
-	tf, err := storage.ParseTodos(content)
 	if err != nil {
-		return nil, EditTodoOutput{}, fmt.Errorf("parsing todos: %w", err)
+		return nil, EditTodoOutput{}, err
 	}
+	todo := &(*list)[i]
 
-	// Find the todo by ID in active list
-	id := strings.TrimSpace(input.ID)
-	found := false
-	for i, todo := range tf.Active {
-		if todo.ID == id {
-			if text := strings.TrimSpace(input.Text); text != "" {
-				tf.Active[i].Text = text
-			}
-			if newPriority != "" {
-				tf.Active[i].Priority = newPriority
-			}
-			found = true
-
-			// Serialize and write back
-			newContent := storage.SerializeTodos(tf)
-			if err := t.storage.WriteFile(ctx, "todos.md", newContent, sha, fmt.Sprintf("Edit todo: %s", truncate(tf.Active[i].Text, 50))); err != nil {
-				if err == storage.ErrConflict {
-					return nil, EditTodoOutput{
-						Success: false,
-						Message: "File was modified by another process. Please try again.",
-					}, nil
-				}
-				return nil, EditTodoOutput{}, fmt.Errorf("writing todos.md: %w", err)
-			}
-
-			itemJSON, err := json.Marshal(todoToItem(tf.Active[i]))
-			if err != nil {
-				return nil, EditTodoOutput{}, fmt.Errorf("marshaling response: %w", err)
-			}
-
+	var warning string
+	if newPriority != "" && newPriority != todo.Priority {
+		if warning = t.wip.check(tf.Active, newPriority, todo.ID); warning != "" && t.wip.Refuse && !input.Force {
 			return nil, EditTodoOutput{
-				Success: true,
-				Message: string(itemJSON),
+				Success: false,
+				Message: warning + " Set force to change it anyway.",
 			}, nil
 		}
 	}
 
-	if !found {
-		return nil, EditTodoOutput{
-			Success: false,
-			Message: fmt.Sprintf("No active todo found with id %q", id),
-		}, nil
+	if text := strings.TrimSpace(input.Text); text != "" {
+		todo.Text = text
+	}
+	if newPriority != "" {
+		todo.Priority = newPriority
+	}
+	if project := strings.TrimSpace(input.Project); project != "" {
+		todo.Project = projectOrNone(project)
+	}
+	if milestone := strings.TrimSpace(input.MilestoneID); milestone != "" {
+		if strings.EqualFold(milestone, "none") {
+			milestone = ""
+		}
+		todo.Milestone = milestone
+	}
+	err = t.todos.Save(ctx, tf, sha, fmt.Sprintf("Edit todo: %s", truncate(todo.Text, 50)))
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, EditTodoOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, EditTodoOutput{}, err
+	}
+
+	item := todoToItem(*todo)
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		return nil, EditTodoOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
 
-	return nil, EditTodoOutput{}, nil // unreachable
+	return nil, EditTodoOutput{
+		Success: true,
+		Message: string(itemJSON),
+		ID:      item.ID,
+		Item:    &item,
+		Warning: warning,
+	}, nil
 }
 
 func (t *TodoTools) deleteTodo(ctx context.Context, req *mcp.CallToolRequest, input DeleteTodoInput) (*mcp.CallToolResult, DeleteTodoOutput, error) {
@@ -465,84 +491,41 @@ func (t *TodoTools) deleteTodo(ctx context.Context, req *mcp.CallToolRequest, in
 	if !input.Confirm {
 		return nil, DeleteTodoOutput{
 			Success: false,
-			Message: "confirm must be set to true to delete a todo. This is a permanent deletion.",
+			Message: "confirm must be set to true to delete a todo. It stays in the trash for 30 days.",
 		}, nil
 	}
 
-	// Read current todos
-	content, sha, err := t.storage.ReadFile(ctx, "todos.md")
+	tf, sha, err := t.todos.Load(ctx, input.IfUnchangedSHA)
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, DeleteTodoOutput{Success: false, Message: msg}, nil
+	}
 	if err != nil {
-		return nil, DeleteTodoOutput{}, fmt.Errorf("reading todos.md: %w", err)
+		return nil, DeleteTodoOutput{}, err
+	}
+	deleted, err := t.todos.Remove(tf, entitystore.Ref{ID: input.ID})
+	if msg, ok := entitystore.Message(err); ok {
+		return nil, DeleteTodoOutput{Success: false, Message: msg}, nil
 	}
-
-	tf, err := storage.ParseTodos(content)
 	if err != nil {
-		return nil, DeleteTodoOutput{}, fmt.Errorf("parsing todos: %w", err)
-	}
-
-	id := strings.TrimSpace(input.ID)
-
-	// Search active list
-	for i, todo := range tf.Active {
-		if todo.ID == id {
-			deleted := todo
-			tf.Active = append(tf.Active[:i], tf.Active[i+1:]...)
-
-			newContent := storage.SerializeTodos(tf)
-			if err := t.storage.WriteFile(ctx, "todos.md", newContent, sha, fmt.Sprintf("Delete todo: %s", truncate(deleted.Text, 50))); err != nil {
-				if err == storage.ErrConflict {
-					return nil, DeleteTodoOutput{
-						Success: false,
-						Message: "File was modified by another process. Please try again.",
-					}, nil
-				}
-				return nil, DeleteTodoOutput{}, fmt.Errorf("writing todos.md: %w", err)
-			}
-
-			itemJSON, err := json.Marshal(todoToItem(deleted))
-			if err != nil {
-				return nil, DeleteTodoOutput{}, fmt.Errorf("marshaling response: %w", err)
-			}
-
-			return nil, DeleteTodoOutput{
-				Success: true,
-				Message: string(itemJSON),
-			}, nil
-		}
+		return nil, DeleteTodoOutput{}, err
+	}
+	msg, err := moveToTrash(ctx, t.storage, t.clock.Now(), t.todos.Change(tf, sha), storage.TrashItem{Todo: &deleted},
+		fmt.Sprintf("Delete todo: %s", truncate(deleted.Text, 50)))
+	if err != nil {
+		return nil, DeleteTodoOutput{}, err
+	}
+	if msg != "" {
+		return nil, DeleteTodoOutput{Success: false, Message: msg}, nil
 	}
 
-	// Search completed list
-	for i, todo := range tf.Completed {
-		if todo.ID == id {
-			deleted := todo
-			tf.Completed = append(tf.Completed[:i], tf.Completed[i+1:]...)
-
-			newContent := storage.SerializeTodos(tf)
-			if err := t.storage.WriteFile(ctx, "todos.md", newContent, sha, fmt.Sprintf("Delete todo: %s", truncate(deleted.Text, 50))); err != nil {
-				if err == storage.ErrConflict {
-					return nil, DeleteTodoOutput{
-						Success: false,
-						Message: "File was modified by another process. Please try again.",
-					}, nil
-				}
-				return nil, DeleteTodoOutput{}, fmt.Errorf("writing todos.md: %w", err)
-			}
-
-			itemJSON, err := json.Marshal(todoToItem(deleted))
-			if err != nil {
-				return nil, DeleteTodoOutput{}, fmt.Errorf("marshaling response: %w", err)
-			}
-
-			return nil, DeleteTodoOutput{
-				Success: true,
-				Message: string(itemJSON),
-			}, nil
-		}
+	itemJSON, err := json.Marshal(todoToItem(deleted))
+	if err != nil {
+		return nil, DeleteTodoOutput{}, fmt.Errorf("marshaling response: %w", err)
 	}
 
 	return nil, DeleteTodoOutput{
-		Success: false,
-		Message: fmt.Sprintf("No todo found with id %q", id),
+		Success: true,
+		Message: string(itemJSON),
 	}, nil
 }
 
@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestMilestoneLinks(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.TodosFile: "# Active Todos\n\n## Normal\n" +
+			"- [ ] Write docs {id:aaaa1111,milestone:cccc3333}\n" +
+			"- [ ] Record demo {id:bbbb2222}\n\n" +
+			"# Completed\n- [x] Draft outline {id:dddd4444,milestone:cccc3333}\n",
+		storage.StrategyFile: "## Current Phase\nLaunch\n\n## Active Milestones\n- [ ] Beta {id:cccc3333}\n- [ ] GA {id:eeee5555}\n",
+	})
+	ctx := context.Background()
+	todos := NewTodoTools(files, nil, WIPLimits{})
+
+	if _, out, err := todos.editTodo(ctx, nil, EditTodoInput{ID: "bbbb2222", MilestoneID: "cccc3333"}); err != nil || !out.Success {
+		t.Fatalf("editTodo() = %+v, %v", out, err)
+	}
+
+	_, list, err := todos.listTodos(ctx, nil, ListTodosInput{MilestoneID: "cccc3333"})
+	if err != nil || !list.Success {
+		t.Fatalf("listTodos() = %+v, %v", list, err)
+	}
+	if len(list.Result.Todos) != 2 || list.Result.Todos[1].MilestoneID != "cccc3333" {
+		t.Errorf("expected both active todos for the milestone, got %+v", list.Result.Todos)
+	}
+
+	// Only active todos count towards a milestone
+	_, ms, err := NewStrategyTools(files, nil, nil).getMilestones(ctx, nil, GetMilestonesInput{})
+	if err != nil || !ms.Success {
+		t.Fatalf("getMilestones() = %+v, %v", ms, err)
+	}
+	if got := ms.Result.ActiveMilestones; got[0].OpenTodos != 2 || got[1].OpenTodos != 0 {
+		t.Errorf("unexpected open todo counts %+v", got)
+	}
+
+	if _, out, _ := todos.editTodo(ctx, nil, EditTodoInput{ID: "aaaa1111", MilestoneID: "none"}); !out.Success {
+		t.Fatalf("editTodo(none) = %+v", out)
+	}
+	tf, _ := storage.ParseTodos(fileContent(t, files, storage.TodosFile))
+	if tf.Active[0].Milestone != "" {
+		t.Errorf("expected the link to be removed, got %q", tf.Active[0].Milestone)
+	}
+}
+
+func TestTodoOutputsCarryItem(t *testing.T) {
+	files := newFileStorage(map[string]string{storage.TodosFile: "# Active Todos\n\n## Normal\n"})
+	ctx := context.Background()
+	todos := NewTodoTools(files, nil, WIPLimits{})
+
+	_, added, err := todos.addTodo(ctx, nil, AddTodoInput{Text: "Chain calls", Priority: "high"})
+	if err != nil || !added.Success {
+		t.Fatalf("addTodo() = %+v, %v", added, err)
+	}
+	if added.ID == "" || added.Item == nil || added.Item.ID != added.ID || added.Item.Priority != "high" {
+		t.Fatalf("addTodo() id %q, item %+v", added.ID, added.Item)
+	}
+
+	_, edited, err := todos.editTodo(ctx, nil, EditTodoInput{ID: added.ID, Text: "Chain tool calls"})
+	if err != nil || !edited.Success || edited.ID != added.ID || edited.Item.Text != "Chain tool calls" {
+		t.Fatalf("editTodo() = %+v, %v", edited, err)
+	}
+
+	_, completed, err := todos.completeTodo(ctx, nil, CompleteTodoInput{ID: added.ID})
+	if err != nil || !completed.Success || completed.ID != added.ID || !completed.Item.Completed {
+		t.Fatalf("completeTodo() = %+v, %v", completed, err)
+	}
+	if p := completed.Progress; p == nil || p.StreakDays != 1 || p.CompletedToday != 1 {
+		t.Errorf("completeTodo() progress = %+v", p)
+	}
+
+	// Failures carry no item
+	_, missing, _ := todos.completeTodo(ctx, nil, CompleteTodoInput{ID: added.ID})
+	if missing.Success || missing.ID != "" || missing.Item != nil || missing.Progress != nil {
+		t.Errorf("completeTodo() of a completed todo = %+v", missing)
+	}
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/dang-w/momentum-mcp-server/internal/tracing"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// The parse helpers wrap the storage parsers in a "parse" span so traces
+// show how much of a tool call is spent decoding markdown.
+
+func parseTodos(ctx context.Context, content string) (*storage.TodoFile, error) {
+	_, span := tracing.Start(ctx, "parse todos.md", tracing.KindInternal)
+	defer span.End()
+	tf, err := storage.ParseTodos(content)
+	span.SetError(err)
+	return tf, err
+}
+
+func parseStrategy(ctx context.Context, content string) (*storage.Strategy, error) {
+	_, span := tracing.Start(ctx, "parse strategy.md", tracing.KindInternal)
+	defer span.End()
+	s, err := storage.ParseStrategy(content)
+	span.SetError(err)
+	return s, err
+}
+
+func parseReadingList(ctx context.Context, content string) (*storage.ReadingList, error) {
+	_, span := tracing.Start(ctx, "parse reading-list.md", tracing.KindInternal)
+	defer span.End()
+	rl, err := storage.ParseReadingList(content)
+	span.SetError(err)
+	return rl, err
+}
+
+func parseReminders(ctx context.Context, content string) (*storage.ReminderFile, error) {
+	_, span := tracing.Start(ctx, "parse reminders.md", tracing.KindInternal)
+	defer span.End()
+	rf, err := storage.ParseReminders(content)
+	span.SetError(err)
+	return rf, err
+}
+
+func parseJournal(ctx context.Context, content string) (*storage.Journal, error) {
+	_, span := tracing.Start(ctx, "parse journal.md", tracing.KindInternal)
+	defer span.End()
+	j, err := storage.ParseJournal(content)
+	span.SetError(err)
+	return j, err
+}
+
+func parseNotes(ctx context.Context, content string) (*storage.NoteFile, error) {
+	_, span := tracing.Start(ctx, "parse notes.md", tracing.KindInternal)
+	defer span.End()
+	nf, err := storage.ParseNotes(content)
+	span.SetError(err)
+	return nf, err
+}
+
+func parseTimeLog(ctx context.Context, content string) (*storage.TimeLog, error) {
+	_, span := tracing.Start(ctx, "parse timelog.md", tracing.KindInternal)
+	defer span.End()
+	l, err := storage.ParseTimeLog(content)
+	span.SetError(err)
+	return l, err
+}
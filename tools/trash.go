@@ -0,0 +1,307 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// trashRetentionDays is how long deleted items stay in the trash. Older
+// ones are purged whenever the trash is written.
+const trashRetentionDays = 30
+
+// TrashTools lists and restores the todos, reminders and notes deleted
+// into trash.md.
+type TrashTools struct {
+	storage   storage.Storage
+	todos     *entitystore.Store[storage.TodoFile, storage.Todo]
+	reminders *entitystore.Store[storage.ReminderFile, storage.Reminder]
+	clock     clock.Clock
+}
+
+// NewTrashTools creates a new TrashTools instance. A nil clock uses the system clock.
+func NewTrashTools(s storage.Storage, c clock.Clock) *TrashTools {
+	return &TrashTools{
+		storage:   s,
+		todos:     entitystore.New(s, todoKind),
+		reminders: entitystore.New(s, reminderKind),
+		clock:     clock.Or(c),
+	}
+}
+
+// ListTrashInput is the input schema for the list_trash tool.
+type ListTrashInput struct {
+	Type string `json:"type,omitempty" jsonschema:"Filter by type: todo, reminder, or note"`
+}
+
+// ListTrashOutput is the output for the list_trash tool.
+type ListTrashOutput struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Result  *ListTrashResult `json:"result,omitempty"`
+}
+
+// ListTrashResult is the response payload for list_trash.
+type ListTrashResult struct {
+	Items     []TrashEntry `json:"items"`
+	SourceSHA string       `json:"source_sha"`
+}
+
+// TrashEntry is a deleted item in the trash.
+type TrashEntry struct {
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	Text      string `json:"text"`
+	DeletedAt string `json:"deleted_at"`
+	// PurgeAfter is the last day the item can be restored.
+	PurgeAfter string `json:"purge_after"`
+}
+
+// RestoreItemInput is the input schema for the restore_item tool.
+type RestoreItemInput struct {
+	ID             string `json:"id" jsonschema:"ID of the deleted todo, reminder or note. Use list_trash to find IDs."`
+	IfUnchangedSHA string `json:"if_unchanged_sha,omitempty" jsonschema:"Optional source_sha from an earlier list_trash call. If the trash has changed since, the restore is refused so you can re-read first."`
+}
+
+// RestoreItemOutput is the output for the restore_item tool.
+type RestoreItemOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	// Item is the restored item, set on success.
+	Item *TrashEntry `json:"item,omitempty"`
+}
+
+// Register registers trash tools with the MCP server.
+func (t *TrashTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_trash",
+		Description: "List the deleted todos, reminders and notes still in the trash. Deleted items are kept for 30 days.",
+	}, t.listTrash)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "restore_item",
+		Description: "Restore a deleted todo, reminder or note from the trash to where it was",
+	}, t.restoreItem)
+}
+
+func (t *TrashTools) listTrash(ctx context.Context, req *mcp.CallToolRequest, input ListTrashInput) (*mcp.CallToolResult, ListTrashOutput, error) {
+	itemType := strings.ToLower(strings.TrimSpace(input.Type))
+	if itemType != "" && itemType != "todo" && itemType != "reminder" && itemType != "note" {
+		return nil, ListTrashOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid type %q. Use: todo, reminder, or note", input.Type),
+		}, nil
+	}
+
+	trash, sha, err := readTrash(ctx, t.storage)
+	if err != nil {
+		return nil, ListTrashOutput{}, err
+	}
+	// Items past the retention period are purged on the next write
+	purgeTrash(trash, t.clock.Now())
+
+	result := ListTrashResult{Items: []TrashEntry{}, SourceSHA: sha}
+	for _, item := range trash.Items {
+		if itemType == "" || item.Type() == itemType {
+			result.Items = append(result.Items, trashToEntry(item))
+		}
+	}
+
+	text := result.text()
+	return textResult(text), ListTrashOutput{
+		Success: true,
+		Message: text,
+		Result:  &result,
+	}, nil
+}
+
+func (t *TrashTools) restoreItem(ctx context.Context, req *mcp.CallToolRequest, input RestoreItemInput) (*mcp.CallToolResult, RestoreItemOutput, error) {
+	id := strings.TrimSpace(input.ID)
+	if id == "" {
+		return nil, RestoreItemOutput{Success: false, Message: "id is required"}, nil
+	}
+
+	trash, trashSHA, err := readTrash(ctx, t.storage)
+	if err != nil {
+		return nil, RestoreItemOutput{}, err
+	}
+	if msg := entitystore.CheckUnchanged(storage.TrashFile, input.IfUnchangedSHA, trashSHA); msg != "" {
+		return nil, RestoreItemOutput{Success: false, Message: msg}, nil
+	}
+	now := t.clock.Now()
+	purgeTrash(trash, now)
+	idx := -1
+	for i, item := range trash.Items {
+		if item.ID() == id {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, RestoreItemOutput{
+			Success: false,
+			Message: fmt.Sprintf("No item found in the trash with id %q. Deleted items are purged after %d days.", id, trashRetentionDays),
+		}, nil
+	}
+	item := trash.Items[idx]
+	trash.Items = append(trash.Items[:idx], trash.Items[idx+1:]...)
+
+	change, msg, err := t.restoreChange(ctx, item)
+	if err != nil {
+		return nil, RestoreItemOutput{}, err
+	}
+	if msg != "" {
+		return nil, RestoreItemOutput{Success: false, Message: msg}, nil
+	}
+
+	entry := trashToEntry(item)
+	err = storage.WriteFiles(ctx, t.storage, []storage.FileChange{
+		change,
+		{Path: storage.TrashFile, Content: storage.SerializeTrash(trash), SHA: trashSHA},
+	}, fmt.Sprintf("Restore %s: %s", entry.Type, truncate(entry.Text, 50)))
+	if msg, ok := entitystore.WriteMessage(err); ok {
+		return nil, RestoreItemOutput{Success: false, Message: msg}, nil
+	}
+	if err != nil {
+		return nil, RestoreItemOutput{}, err
+	}
+
+	return nil, RestoreItemOutput{
+		Success: true,
+		Message: fmt.Sprintf("Restored %s %s: %s", entry.Type, entry.ID, entry.Text),
+		Item:    &entry,
+	}, nil
+}
+
+// restoreChange returns the write putting item back in its file, or a
+// message to report if it can't be.
+func (t *TrashTools) restoreChange(ctx context.Context, item storage.TrashItem) (storage.FileChange, string, error) {
+	exists := fmt.Sprintf("A %s with id %q already exists", item.Type(), item.ID())
+	switch {
+	case item.Todo != nil:
+		tf, sha, err := t.todos.Load(ctx, "")
+		if err != nil {
+			return storage.FileChange{}, "", err
+		}
+		if _, _, err := t.todos.Find(tf, entitystore.Both, entitystore.Ref{ID: item.Todo.ID}); err == nil {
+			return storage.FileChange{}, exists, nil
+		}
+		if item.Todo.Completed {
+			tf.Completed = append([]storage.Todo{*item.Todo}, tf.Completed...)
+		} else {
+			tf.Active = append(tf.Active, *item.Todo)
+		}
+		return t.todos.Change(tf, sha), "", nil
+
+	case item.Reminder != nil:
+		rf, sha, err := t.reminders.Load(ctx, "")
+		if err != nil {
+			return storage.FileChange{}, "", err
+		}
+		if _, _, err := t.reminders.Find(rf, entitystore.Both, entitystore.Ref{ID: item.Reminder.ID}); err == nil {
+			return storage.FileChange{}, exists, nil
+		}
+		if item.Reminder.Completed {
+			rf.Completed = append([]storage.Reminder{*item.Reminder}, rf.Completed...)
+		} else {
+			rf.Upcoming = append(rf.Upcoming, *item.Reminder)
+		}
+		return t.reminders.Change(rf, sha), "", nil
+	}
+
+	content, sha, err := t.storage.ReadFile(ctx, storage.NotesFile)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return storage.FileChange{}, "", fmt.Errorf("reading notes.md: %w", err)
+	}
+	nf := &storage.NoteFile{}
+	if err == nil {
+		if nf, err = parseNotes(ctx, content); err != nil {
+			return storage.FileChange{}, "", fmt.Errorf("parsing notes: %w", err)
+		}
+	}
+	for _, n := range nf.Notes {
+		if n.ID == item.Note.ID {
+			return storage.FileChange{}, exists, nil
+		}
+	}
+	nf.Notes = append(nf.Notes, *item.Note)
+	return storage.FileChange{Path: storage.NotesFile, Content: storage.SerializeNotes(nf), SHA: sha}, "", nil
+}
+
+// moveToTrash commits change, which removes item from its file, together
+// with adding item to the trash, and purges expired items on the way. It
+// returns a message to report if the write conflicted.
+func moveToTrash(ctx context.Context, s storage.Storage, now time.Time, change storage.FileChange, item storage.TrashItem, message string) (string, error) {
+	trash, sha, err := readTrash(ctx, s)
+	if err != nil {
+		return "", err
+	}
+	item.DeletedAt = now
+	trash.Items = append(trash.Items, item)
+	purgeTrash(trash, now)
+
+	err = storage.WriteFiles(ctx, s, []storage.FileChange{
+		change,
+		{Path: storage.TrashFile, Content: storage.SerializeTrash(trash), SHA: sha},
+	}, message)
+	if msg, ok := entitystore.WriteMessage(err); ok {
+		return msg, nil
+	}
+	return "", err
+}
+
+// readTrash reads trash.md. A missing file is an empty trash.
+func readTrash(ctx context.Context, s storage.Storage) (*storage.Trash, string, error) {
+	content, sha, err := s.ReadFile(ctx, storage.TrashFile)
+	if errors.Is(err, storage.ErrNotFound) {
+		return &storage.Trash{}, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("reading trash.md: %w", err)
+	}
+	trash, err := storage.ParseTrash(content)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing trash: %w", err)
+	}
+	return trash, sha, nil
+}
+
+// purgeTrash drops the items deleted more than trashRetentionDays ago. Items
+// without a deletion time, added by hand, are kept.
+func purgeTrash(trash *storage.Trash, now time.Time) {
+	cutoff := now.AddDate(0, 0, -trashRetentionDays)
+	kept := trash.Items[:0]
+	for _, item := range trash.Items {
+		if item.DeletedAt.IsZero() || !item.DeletedAt.Before(cutoff) {
+			kept = append(kept, item)
+		}
+	}
+	trash.Items = kept
+}
+
+func trashToEntry(item storage.TrashItem) TrashEntry {
+	return TrashEntry{
+		Type:       item.Type(),
+		ID:         item.ID(),
+		Text:       item.Text(),
+		DeletedAt:  item.DeletedAt.UTC().Format(time.RFC3339),
+		PurgeAfter: formatDate(item.DeletedAt.AddDate(0, 0, trashRetentionDays)),
+	}
+}
+
+func (r ListTrashResult) text() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s in the trash, source_sha %s\n", plural(len(r.Items), "item"), r.SourceSHA)
+	for _, e := range r.Items {
+		fmt.Fprintf(&sb, "- %s %s: %s%s\n", e.Type, e.ID, e.Text, itemDetails(
+			labeled("deleted", e.DeletedAt), labeled("restorable until", e.PurgeAfter)))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/internal/clock"
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestTrash(t *testing.T) {
+	ctx := context.Background()
+	files := newFileStorage(map[string]string{
+		storage.TodosFile:     "# Active Todos\n\n## High Priority\n- [ ] Ship it {id:aaaa1111,added:2026-02-01}\n  - [x] Write tests\n\n## Normal\n- [ ] Tidy up {id:bbbb2222}\n",
+		storage.RemindersFile: "# Reminders\n\n## Upcoming\n- 2026-02-20: Renew passport {id:cccc3333,added:2026-02-01}\n",
+		storage.NotesFile:     "# Notes\n\n## Ideas\n- Dark mode {id:dddd4444,added:2026-02-02}\n",
+	})
+	clk := clock.NewFake(time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC))
+	todos := NewTodoTools(files, clk, WIPLimits{})
+	reminders := NewReminderTools(files, clk)
+	notes := NewNoteTools(files, clk)
+	trash := NewTrashTools(files, clk)
+
+	if _, out, err := todos.deleteTodo(ctx, nil, DeleteTodoInput{ID: "aaaa1111", Confirm: true}); err != nil || !out.Success {
+		t.Fatalf("deleteTodo() = %+v, %v", out, err)
+	}
+	if _, out, err := reminders.deleteReminder(ctx, nil, DeleteReminderInput{ID: "cccc3333", Confirm: true}); err != nil || !out.Success {
+		t.Fatalf("deleteReminder() = %+v, %v", out, err)
+	}
+	if _, out, err := notes.deleteNote(ctx, nil, DeleteNoteInput{ID: "dddd4444"}); err != nil || !out.Success {
+		t.Fatalf("deleteNote() = %+v, %v", out, err)
+	}
+	if strings.Contains(fileContent(t, files, storage.TodosFile), "Ship it") || strings.Contains(fileContent(t, files, storage.NotesFile), "Dark mode") {
+		t.Fatalf("deleted items left in their files:\n%s\n%s", fileContent(t, files, storage.TodosFile), fileContent(t, files, storage.NotesFile))
+	}
+
+	_, list, err := trash.listTrash(ctx, nil, ListTrashInput{})
+	if err != nil || !list.Success || len(list.Result.Items) != 3 {
+		t.Fatalf("listTrash() = %+v, %v", list, err)
+	}
+	if e := list.Result.Items[0]; e.Type != "todo" || e.ID != "aaaa1111" || e.DeletedAt != "2026-02-10T09:00:00Z" || e.PurgeAfter != "2026-03-12" {
+		t.Errorf("unexpected todo entry %+v", e)
+	}
+	if _, out, _ := trash.listTrash(ctx, nil, ListTrashInput{Type: "note"}); len(out.Result.Items) != 1 || out.Result.Items[0].Text != "Dark mode" {
+		t.Errorf("listTrash(note) = %+v", out.Result)
+	}
+	if _, out, _ := trash.listTrash(ctx, nil, ListTrashInput{Type: "milestone"}); out.Success {
+		t.Error("listTrash accepted an unknown type")
+	}
+
+	// Restoring puts items back with what they had: priority, subtasks, category
+	for _, id := range []string{"aaaa1111", "cccc3333", "dddd4444"} {
+		if _, out, err := trash.restoreItem(ctx, nil, RestoreItemInput{ID: id}); err != nil || !out.Success {
+			t.Fatalf("restoreItem(%s) = %+v, %v", id, out, err)
+		}
+	}
+	tf, _ := storage.ParseTodos(fileContent(t, files, storage.TodosFile))
+	if len(tf.Active) != 2 || tf.Active[0].ID != "aaaa1111" || tf.Active[0].Priority != storage.PriorityHigh || len(tf.Active[0].Subtasks) != 1 {
+		t.Errorf("unexpected restored todos:\n%s", fileContent(t, files, storage.TodosFile))
+	}
+	if !strings.Contains(fileContent(t, files, storage.RemindersFile), "- 2026-02-20: Renew passport {id:cccc3333,added:2026-02-01}") {
+		t.Errorf("unexpected restored reminders:\n%s", fileContent(t, files, storage.RemindersFile))
+	}
+	if !strings.Contains(fileContent(t, files, storage.NotesFile), "## Ideas\n- Dark mode {id:dddd4444,added:2026-02-02}") {
+		t.Errorf("unexpected restored notes:\n%s", fileContent(t, files, storage.NotesFile))
+	}
+	if fileContent(t, files, storage.TrashFile) != "# Trash\n" {
+		t.Errorf("expected an empty trash, got:\n%s", fileContent(t, files, storage.TrashFile))
+	}
+	if _, out, _ := trash.restoreItem(ctx, nil, RestoreItemInput{ID: "aaaa1111"}); out.Success {
+		t.Error("restored an item that isn't in the trash")
+	}
+
+	// Items past the retention period are hidden, and purged on the next delete
+	if _, out, _ := todos.deleteTodo(ctx, nil, DeleteTodoInput{ID: "bbbb2222", Confirm: true}); !out.Success {
+		t.Fatalf("deleteTodo() = %+v", out)
+	}
+	clk.Advance(31 * 24 * time.Hour)
+	if _, out, _ := trash.listTrash(ctx, nil, ListTrashInput{}); len(out.Result.Items) != 0 {
+		t.Errorf("expected the expired todo to be hidden, got %+v", out.Result.Items)
+	}
+	if _, out, _ := todos.deleteTodo(ctx, nil, DeleteTodoInput{ID: "aaaa1111", Confirm: true}); !out.Success {
+		t.Fatalf("deleteTodo() = %+v", out)
+	}
+	if strings.Contains(fileContent(t, files, storage.TrashFile), "Tidy up") || !strings.Contains(fileContent(t, files, storage.TrashFile), "Ship it") {
+		t.Errorf("expected only the expired todo purged:\n%s", fileContent(t, files, storage.TrashFile))
+	}
+}
@@ -14,9 +14,28 @@ type TodoItem struct {
 	ID          string  `json:"id"`
 	Text        string  `json:"text"`
 	Priority    string  `json:"priority"`
+	Project     string  `json:"project,omitempty"`
+	MilestoneID string  `json:"milestone_id,omitempty"`
 	Completed   bool    `json:"completed"`
 	Added       string  `json:"added,omitempty"`
 	CompletedAt *string `json:"completed_at,omitempty"`
+	// Subtasks are the todo's checklist items, in order.
+	Subtasks []SubtaskItem `json:"subtasks,omitempty"`
+	// Comments are the todo's remarks, oldest first.
+	Comments []CommentItem `json:"comments,omitempty"`
+}
+
+// SubtaskItem is a JSON-serializable subtask of a todo.
+type SubtaskItem struct {
+	ID        string `json:"id"`
+	Text      string `json:"text"`
+	Completed bool   `json:"completed"`
+}
+
+// CommentItem is a JSON-serializable comment on a todo or milestone.
+type CommentItem struct {
+	At   string `json:"at"` // YYYY-MM-DD HH:MM
+	Text string `json:"text"`
 }
 
 // ReminderItem is a JSON-serializable reminder for API responses.
@@ -32,12 +51,35 @@ type ReminderItem struct {
 
 // ReadingListItem is a JSON-serializable reading list entry for API responses.
 type ReadingListItem struct {
-	ID     string  `json:"id"`
-	URL    string  `json:"url"`
-	Notes  string  `json:"notes,omitempty"`
-	Read   bool    `json:"read"`
-	Added  string  `json:"added,omitempty"`
-	ReadAt *string `json:"read_at,omitempty"`
+	ID       string  `json:"id"`
+	URL      string  `json:"url"`
+	Notes    string  `json:"notes,omitempty"`
+	Read     bool    `json:"read"`
+	Priority string  `json:"priority,omitempty"`
+	Category string  `json:"category,omitempty"`
+	Minutes  int     `json:"minutes,omitempty"`
+	Added    string  `json:"added,omitempty"`
+	ReadAt   *string `json:"read_at,omitempty"`
+	// Archive is the archive file holding the item, if it's been archived.
+	Archive string `json:"archive,omitempty"`
+}
+
+// JournalEntryItem is a JSON-serializable journal entry for API responses.
+type JournalEntryItem struct {
+	ID   string `json:"id"`
+	Date string `json:"date"`
+	Time string `json:"time"`
+	Text string `json:"text"`
+}
+
+// NoteItem is a JSON-serializable note for API responses. Notes from
+// strategy.md have no ID or category.
+type NoteItem struct {
+	ID       string `json:"id,omitempty"`
+	Text     string `json:"text"`
+	Category string `json:"category,omitempty"`
+	Added    string `json:"added,omitempty"`
+	Source   string `json:"source"` // "notes" or "strategy"
 }
 
 // MilestoneItem is a JSON-serializable milestone for API responses.
@@ -45,9 +87,31 @@ type MilestoneItem struct {
 	ID          string  `json:"id"`
 	Text        string  `json:"text"`
 	Due         *string `json:"due,omitempty"`
+	Project     string  `json:"project,omitempty"`
+	Issue       int     `json:"issue,omitempty"`
 	Completed   bool    `json:"completed"`
 	Added       string  `json:"added,omitempty"`
 	CompletedAt *string `json:"completed_at,omitempty"`
+	// OpenTodos counts active todos linked to the milestone. Only
+	// get_milestones fills it in.
+	OpenTodos int `json:"open_todos,omitempty"`
+	// KeyResults are the milestone's measurable results, and Progress their
+	// rolled-up progress percentage, unset without key results.
+	KeyResults []KeyResultItem `json:"key_results,omitempty"`
+	Progress   *int            `json:"progress,omitempty"`
+	// Comments are the milestone's remarks, oldest first.
+	Comments []CommentItem `json:"comments,omitempty"`
+}
+
+// KeyResultItem is a JSON-serializable key result of a milestone.
+type KeyResultItem struct {
+	ID      string  `json:"id"`
+	Text    string  `json:"text"`
+	Current float64 `json:"current"`
+	Target  float64 `json:"target"`
+	Unit    string  `json:"unit,omitempty"`
+	// Progress is the percentage of the target reached, from 0 to 100.
+	Progress int `json:"progress"`
 }
 
 // Conversion helpers
@@ -68,14 +132,29 @@ func formatDatePtr(t *time.Time) *string {
 }
 
 func todoToItem(t storage.Todo) TodoItem {
-	return TodoItem{
+	item := TodoItem{
 		ID:          t.ID,
 		Text:        t.Text,
 		Priority:    string(t.Priority),
+		Project:     t.Project,
+		MilestoneID: t.Milestone,
 		Completed:   t.Completed,
 		Added:       formatDate(t.Added),
 		CompletedAt: formatDatePtr(t.CompletedAt),
 	}
+	for _, sub := range t.Subtasks {
+		item.Subtasks = append(item.Subtasks, SubtaskItem{ID: sub.ID, Text: sub.Text, Completed: sub.Completed})
+	}
+	item.Comments = commentsToItems(t.Comments)
+	return item
+}
+
+func commentsToItems(comments []storage.Comment) []CommentItem {
+	var items []CommentItem
+	for _, c := range comments {
+		items = append(items, CommentItem{At: c.At.Format("2006-01-02 15:04"), Text: c.Text})
+	}
+	return items
 }
 
 func reminderToItem(r storage.Reminder, today time.Time) ReminderItem {
@@ -92,12 +171,15 @@ func reminderToItem(r storage.Reminder, today time.Time) ReminderItem {
 
 func readingToItem(r storage.ReadingItem) ReadingListItem {
 	return ReadingListItem{
-		ID:     r.ID,
-		URL:    r.URL,
-		Notes:  r.Notes,
-		Read:   r.Read,
-		Added:  formatDate(r.Added),
-		ReadAt: formatDatePtr(r.ReadAt),
+		ID:       r.ID,
+		URL:      r.URL,
+		Notes:    r.Notes,
+		Read:     r.Read,
+		Priority: r.Priority,
+		Category: r.Category,
+		Minutes:  r.Minutes,
+		Added:    formatDate(r.Added),
+		ReadAt:   formatDatePtr(r.ReadAt),
 	}
 }
 
@@ -106,8 +188,89 @@ func milestoneToItem(m storage.Milestone) MilestoneItem {
 		ID:          m.ID,
 		Text:        m.Text,
 		Due:         formatDatePtr(m.Due),
+		Project:     m.Project,
+		Issue:       m.Issue,
 		Completed:   m.Completed,
 		Added:       formatDate(m.Added),
 		CompletedAt: formatDatePtr(m.CompletedAt),
+		KeyResults:  keyResultsToItems(m.KeyResults),
+		Progress:    keyResultProgress(m),
+		Comments:    commentsToItems(m.Comments),
+	}
+}
+
+func keyResultsToItems(keyResults []storage.KeyResult) []KeyResultItem {
+	if len(keyResults) == 0 {
+		return nil
+	}
+	items := make([]KeyResultItem, len(keyResults))
+	for i, k := range keyResults {
+		items[i] = KeyResultItem{
+			ID:       k.ID,
+			Text:     k.Text,
+			Current:  k.Current,
+			Target:   k.Target,
+			Unit:     k.Unit,
+			Progress: k.Progress(),
+		}
+	}
+	return items
+}
+
+func keyResultProgress(m storage.Milestone) *int {
+	p, ok := m.KeyResultProgress()
+	if !ok {
+		return nil
+	}
+	return &p
+}
+
+func noteToItem(n storage.Note) NoteItem {
+	return NoteItem{
+		ID:       n.ID,
+		Text:     n.Text,
+		Category: n.Category,
+		Added:    formatDate(n.Added),
+		Source:   noteSourceNotes,
+	}
+}
+
+func journalEntryToItem(e storage.JournalEntry) JournalEntryItem {
+	return JournalEntryItem{
+		ID:   e.ID,
+		Date: e.Time.Format("2006-01-02"),
+		Time: e.Time.Format("15:04"),
+		Text: e.Text,
+	}
+}
+
+// TimeEntryItem is a JSON-serializable time tracking session for API responses.
+type TimeEntryItem struct {
+	ID       string  `json:"id"`
+	ItemType string  `json:"item_type"`
+	ItemID   string  `json:"item_id"`
+	Text     string  `json:"text,omitempty"`
+	Project  string  `json:"project,omitempty"`
+	Start    string  `json:"start"`
+	End      *string `json:"end,omitempty"`
+	Minutes  int     `json:"minutes"`
+	Running  bool    `json:"running"`
+}
+
+func timeEntryToItem(e storage.TimeEntry, now time.Time) TimeEntryItem {
+	item := TimeEntryItem{
+		ID:       e.ID,
+		ItemType: e.ItemType,
+		ItemID:   e.ItemID,
+		Text:     e.Text,
+		Project:  e.Project,
+		Start:    e.Start.Format("2006-01-02 15:04"),
+		Minutes:  int(e.Duration(now).Minutes()),
+		Running:  e.End == nil,
+	}
+	if e.End != nil {
+		end := e.End.Format("2006-01-02 15:04")
+		item.End = &end
 	}
+	return item
 }
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UndoTools provides undo for event-sourced storage.
+type UndoTools struct {
+	events *storage.EventStore
+}
+
+// NewUndoTools creates a new UndoTools instance.
+func NewUndoTools(e *storage.EventStore) *UndoTools {
+	return &UndoTools{events: e}
+}
+
+// UndoLastChangeInput is the input schema for the undo_last_change tool.
+type UndoLastChangeInput struct {
+	File string `json:"file" jsonschema:"Which data to undo the last change for: todos, strategy, reading, reminders, journal, or timelog"`
+}
+
+// UndoLastChangeOutput is the output for the undo_last_change tool.
+type UndoLastChangeOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// undoResult is the response payload for undo_last_change.
+type undoResult struct {
+	File    string `json:"file"`
+	Undone  string `json:"undone"`
+	EventID int    `json:"event_seq"`
+}
+
+// undoFiles maps the file names accepted by undo_last_change to data files.
+var undoFiles = map[string]string{
+	"todos":     storage.TodosFile,
+	"strategy":  storage.StrategyFile,
+	"reading":   storage.ReadingListFile,
+	"reminders": storage.RemindersFile,
+	"journal":   storage.JournalFile,
+	"timelog":   storage.TimeLogFile,
+}
+
+// Register registers undo tools with the MCP server.
+func (u *UndoTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "undo_last_change",
+		Description: "Undo the most recent change to todos, strategy, reading list, reminders, journal, or time log by restoring the previous version",
+	}, u.undoLastChange)
+}
+
+func (u *UndoTools) undoLastChange(ctx context.Context, req *mcp.CallToolRequest, input UndoLastChangeInput) (*mcp.CallToolResult, UndoLastChangeOutput, error) {
+	key := strings.ToLower(strings.TrimSpace(input.File))
+	path, ok := undoFiles[key]
+	if !ok {
+		return nil, UndoLastChangeOutput{
+			Success: false,
+			Message: fmt.Sprintf("Invalid file %q. Use: todos, strategy, reading, reminders, journal, or timelog", input.File),
+		}, nil
+	}
+
+	undone, err := u.events.Undo(ctx, path)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, UndoLastChangeOutput{
+				Success: false,
+				Message: fmt.Sprintf("No earlier version of %s to restore", key),
+			}, nil
+		}
+		if msg, ok := entitystore.WriteMessage(err); ok {
+			return nil, UndoLastChangeOutput{
+				Success: false,
+				Message: msg,
+			}, nil
+		}
+		return nil, UndoLastChangeOutput{}, fmt.Errorf("undoing %s: %w", path, err)
+	}
+
+	jsonBytes, err := json.Marshal(undoResult{
+		File:    key,
+		Undone:  undone.Message,
+		EventID: undone.Seq,
+	})
+	if err != nil {
+		return nil, UndoLastChangeOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, UndoLastChangeOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestUndoLastChange(t *testing.T) {
+	ctx := context.Background()
+	backend := storage.NewMemoryStorage(map[string]string{storage.RemindersFile: "# Reminders\n\n## Upcoming\n"}, nil)
+	events := storage.NewEventStore(backend, "", nil)
+	tools := NewUndoTools(events)
+
+	// Nothing logged yet
+	if _, out, err := tools.undoLastChange(ctx, nil, UndoLastChangeInput{File: "reminders"}); err != nil || out.Success {
+		t.Errorf("undoLastChange() before any change = %+v, %v", out, err)
+	}
+
+	_, sha, _ := events.ReadFile(ctx, storage.RemindersFile)
+	events.WriteFile(ctx, storage.RemindersFile, "# Reminders\n\n## Upcoming\n- 2026-03-01: Renew passport {id:rm_aaaa11}\n", sha, "Set reminder")
+
+	_, out, err := tools.undoLastChange(ctx, nil, UndoLastChangeInput{File: " Reminders "})
+	if err != nil || !out.Success || !strings.Contains(out.Message, `"undone":"Set reminder"`) {
+		t.Fatalf("undoLastChange() = %+v, %v", out, err)
+	}
+	if content, _, _ := backend.ReadFile(ctx, storage.RemindersFile); content != "# Reminders\n\n## Upcoming\n" {
+		t.Errorf("reminders.md after undo = %q", content)
+	}
+
+	// A file edited outside the log since is refused
+	_, sha, _ = backend.ReadFile(ctx, storage.RemindersFile)
+	backend.WriteFile(ctx, storage.RemindersFile, "# Reminders\n", sha, "Edited elsewhere")
+	if _, out, err := tools.undoLastChange(ctx, nil, UndoLastChangeInput{File: "reminders"}); err != nil || out.Success {
+		t.Errorf("undoLastChange() after an outside edit = %+v, %v", out, err)
+	}
+
+	if _, out, err := tools.undoLastChange(ctx, nil, UndoLastChangeInput{File: "notes"}); err != nil || out.Success {
+		t.Errorf("undoLastChange(notes) = %+v, %v", out, err)
+	}
+}
@@ -0,0 +1,386 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/entitystore"
+	"github.com/dang-w/momentum-mcp-server/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ValidateTools lints the data files.
+type ValidateTools struct {
+	storage storage.Storage
+}
+
+// NewValidateTools creates a new ValidateTools instance.
+func NewValidateTools(s storage.Storage) *ValidateTools {
+	return &ValidateTools{storage: s}
+}
+
+// ValidateDataInput is the input schema for the validate_data tool.
+type ValidateDataInput struct {
+	Fix bool `json:"fix,omitempty" jsonschema:"Set to true to rewrite files in their normalized form (assigning missing IDs and de-duplicating IDs) in one commit. Files that would lose content are never rewritten."`
+}
+
+// ValidateDataOutput is the output for the validate_data tool.
+type ValidateDataOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ValidateDataResult is the response payload for validate_data.
+type ValidateDataResult struct {
+	Valid bool             `json:"valid"`
+	Files []FileValidation `json:"files"`
+	Fixed []string         `json:"fixed,omitempty"`
+}
+
+// FileValidation reports the problems found in one data file.
+type FileValidation struct {
+	File   string            `json:"file"`
+	Issues []ValidationIssue `json:"issues"`
+	// Normalizes is set when the next tool write would reformat the file.
+	Normalizes bool `json:"normalizes"`
+	Missing    bool `json:"missing,omitempty"`
+}
+
+// ValidationIssue is a single problem in a data file.
+type ValidationIssue struct {
+	// Kind is malformed_date, missing_id, duplicate_id, lost_item, or dropped_content.
+	Kind    string `json:"kind"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// validatedItem points at an item's ID in a parsed file, so duplicates can
+// be given new IDs, with the item type's prefix, before the file is
+// serialized.
+type validatedItem struct {
+	id     *string
+	text   string
+	prefix string
+}
+
+// idRef points at a reference to another item's ID, such as a todo's
+// milestone, so migrate_ids can follow the item's new ID.
+type idRef struct {
+	id     *string
+	prefix string
+}
+
+// validatedFile is a parsed data file ready for checking.
+type validatedFile struct {
+	items     []validatedItem
+	refs      []idRef
+	serialize func() string
+}
+
+// validators parses each checked data file, in report order.
+var validators = []struct {
+	path  string
+	parse func(ctx context.Context, content string) (*validatedFile, error)
+}{
+	{storage.TodosFile, func(ctx context.Context, content string) (*validatedFile, error) {
+		tf, err := parseTodos(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		v := &validatedFile{serialize: func() string { return storage.SerializeTodos(tf) }}
+		for _, list := range [][]storage.Todo{tf.Active, tf.Completed} {
+			for i := range list {
+				v.items = append(v.items, validatedItem{&list[i].ID, list[i].Text, storage.PrefixTodo})
+				if list[i].Milestone != "" {
+					v.refs = append(v.refs, idRef{&list[i].Milestone, storage.PrefixMilestone})
+				}
+				for j := range list[i].Subtasks {
+					v.items = append(v.items, validatedItem{&list[i].Subtasks[j].ID, list[i].Subtasks[j].Text, storage.PrefixSubtask})
+				}
+			}
+		}
+		return v, nil
+	}},
+	{storage.StrategyFile, func(ctx context.Context, content string) (*validatedFile, error) {
+		s, err := parseStrategy(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		v := &validatedFile{serialize: func() string { return storage.SerializeStrategy(s) }}
+		for _, list := range [][]storage.Milestone{s.ActiveMilestones, s.CompletedMilestones} {
+			for i := range list {
+				v.items = append(v.items, validatedItem{&list[i].ID, list[i].Text, storage.PrefixMilestone})
+				for j := range list[i].KeyResults {
+					v.items = append(v.items, validatedItem{&list[i].KeyResults[j].ID, list[i].KeyResults[j].Text, storage.PrefixKeyResult})
+				}
+			}
+		}
+		return v, nil
+	}},
+	{storage.ReadingListFile, func(ctx context.Context, content string) (*validatedFile, error) {
+		rl, err := parseReadingList(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		v := &validatedFile{serialize: func() string { return storage.SerializeReadingList(rl) }}
+		for _, list := range [][]storage.ReadingItem{rl.ToRead, rl.Read} {
+			for i := range list {
+				v.items = append(v.items, validatedItem{&list[i].ID, list[i].URL, storage.PrefixReading})
+			}
+		}
+		return v, nil
+	}},
+	{storage.RemindersFile, func(ctx context.Context, content string) (*validatedFile, error) {
+		rf, err := parseReminders(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		v := &validatedFile{serialize: func() string { return storage.SerializeReminders(rf) }}
+		for _, list := range [][]storage.Reminder{rf.Upcoming, rf.Completed} {
+			for i := range list {
+				v.items = append(v.items, validatedItem{&list[i].ID, list[i].Text, storage.PrefixReminder})
+			}
+		}
+		return v, nil
+	}},
+	{storage.JournalFile, func(ctx context.Context, content string) (*validatedFile, error) {
+		j, err := parseJournal(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		v := &validatedFile{serialize: func() string { return storage.SerializeJournal(j) }}
+		for i := range j.Entries {
+			v.items = append(v.items, validatedItem{&j.Entries[i].ID, j.Entries[i].Text, storage.PrefixJournal})
+		}
+		return v, nil
+	}},
+	{storage.NotesFile, func(ctx context.Context, content string) (*validatedFile, error) {
+		nf, err := parseNotes(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		v := &validatedFile{serialize: func() string { return storage.SerializeNotes(nf) }}
+		for i := range nf.Notes {
+			v.items = append(v.items, validatedItem{&nf.Notes[i].ID, nf.Notes[i].Text, storage.PrefixNote})
+		}
+		return v, nil
+	}},
+	{storage.TimeLogFile, func(ctx context.Context, content string) (*validatedFile, error) {
+		l, err := parseTimeLog(ctx, content)
+		if err != nil {
+			return nil, err
+		}
+		v := &validatedFile{serialize: func() string { return storage.SerializeTimeLog(l) }}
+		for i := range l.Entries {
+			v.items = append(v.items, validatedItem{&l.Entries[i].ID, l.Entries[i].Text, storage.PrefixTime})
+			v.refs = append(v.refs, idRef{&l.Entries[i].ItemID, itemTypePrefixes[l.Entries[i].ItemType]})
+		}
+		return v, nil
+	}},
+}
+
+// Register registers the validate_data tool with the MCP server.
+func (t *ValidateTools) Register(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "validate_data",
+		Description: "Check the data files for malformed dates, missing or duplicate IDs, and content a tool write would lose or drop. With fix=true, safe files are rewritten in normalized form in one commit.",
+	}, t.validateData)
+}
+
+func (t *ValidateTools) validateData(ctx context.Context, req *mcp.CallToolRequest, input ValidateDataInput) (*mcp.CallToolResult, ValidateDataOutput, error) {
+	result := ValidateDataResult{Valid: true, Files: []FileValidation{}}
+	var changes []storage.FileChange
+
+	for _, v := range validators {
+		content, sha, err := t.storage.ReadFile(ctx, v.path)
+		if errors.Is(err, storage.ErrNotFound) {
+			result.Files = append(result.Files, FileValidation{File: v.path, Issues: []ValidationIssue{}, Missing: true})
+			continue
+		}
+		if err != nil {
+			return nil, ValidateDataOutput{}, fmt.Errorf("reading %s: %w", v.path, err)
+		}
+
+		fv, normalized, err := validateFile(ctx, v.path, content, v.parse)
+		if err != nil {
+			return nil, ValidateDataOutput{}, err
+		}
+		if len(fv.Issues) > 0 {
+			result.Valid = false
+		}
+		result.Files = append(result.Files, fv)
+
+		if input.Fix && fv.Normalizes && !losesContent(fv.Issues) {
+			changes = append(changes, storage.FileChange{Path: v.path, Content: normalized, SHA: sha})
+			result.Fixed = append(result.Fixed, v.path)
+		}
+	}
+
+	if len(changes) > 0 {
+		message := "Normalize " + strings.Join(result.Fixed, ", ")
+		if err := storage.WriteFiles(ctx, t.storage, changes, message); err != nil {
+			if msg, ok := entitystore.WriteMessage(err); ok {
+				return nil, ValidateDataOutput{
+					Success: false,
+					Message: msg,
+				}, nil
+			}
+			return nil, ValidateDataOutput{}, fmt.Errorf("writing normalized files: %w", err)
+		}
+	}
+
+	jsonBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, ValidateDataOutput{}, fmt.Errorf("marshaling response: %w", err)
+	}
+
+	return nil, ValidateDataOutput{
+		Success: true,
+		Message: string(jsonBytes),
+	}, nil
+}
+
+// validateFile checks one file and returns its normalized content, with
+// duplicate IDs replaced.
+func validateFile(ctx context.Context, path, content string, parse func(context.Context, string) (*validatedFile, error)) (FileValidation, string, error) {
+	fv := FileValidation{File: path, Issues: []ValidationIssue{}}
+	fv.Issues = append(fv.Issues, malformedDates(content)...)
+
+	parsed, err := parse(ctx, content)
+	if err != nil {
+		return fv, "", fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	// Parsers make up IDs for items without one, so they differ per read
+	seen := make(map[string]bool)
+	for _, item := range parsed.items {
+		if !strings.Contains(content, "id:"+*item.id) {
+			fv.Issues = append(fv.Issues, ValidationIssue{
+				Kind:    "missing_id",
+				Message: fmt.Sprintf("%q has no id; it gets a new one on every read until the file is rewritten", truncate(item.text, 60)),
+			})
+		} else if seen[*item.id] {
+			fv.Issues = append(fv.Issues, ValidationIssue{
+				Kind:    "duplicate_id",
+				Message: fmt.Sprintf("%q reuses id %s", truncate(item.text, 60), *item.id),
+			})
+			*item.id = storage.NewID(item.prefix, func(id string) bool { return seen[id] })
+		}
+		seen[*item.id] = true
+	}
+
+	normalized := parsed.serialize()
+	fv.Normalizes = normalized != content
+
+	// Every item must survive a write and re-read
+	reparsed, err := parse(ctx, normalized)
+	if err != nil {
+		return fv, "", fmt.Errorf("re-parsing %s: %w", path, err)
+	}
+	kept := make(map[string]bool)
+	for _, item := range reparsed.items {
+		kept[*item.id] = true
+	}
+	for _, item := range parsed.items {
+		if !kept[*item.id] {
+			fv.Issues = append(fv.Issues, ValidationIssue{
+				Kind:    "lost_item",
+				Message: fmt.Sprintf("%q would be lost when the file is rewritten", truncate(item.text, 60)),
+			})
+		}
+	}
+
+	fv.Issues = append(fv.Issues, droppedLines(content, normalized, parsed.items)...)
+	return fv, normalized, nil
+}
+
+// losesContent reports whether rewriting a file would lose anything.
+func losesContent(issues []ValidationIssue) bool {
+	for _, issue := range issues {
+		if issue.Kind == "lost_item" || issue.Kind == "dropped_content" {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// Matches dated fields: {added:...}, {completed:...}, — Due: ..., — Read: ...
+	metadataDatePattern = regexp.MustCompile(`\b(added|completed):([^,}\s]*)`)
+	inlineDatePattern   = regexp.MustCompile(`—\s*(Due|Added|Read):\s*(\S*)`)
+	// Matches the leading date of a reminder line
+	leadingDatePattern = regexp.MustCompile(`^-\s*(\d{4}-\d{2}-\d{2}):`)
+	metadataPattern    = regexp.MustCompile(`\{([^}]+)\}`)
+)
+
+// malformedDates finds dates the parsers would silently ignore.
+func malformedDates(content string) []ValidationIssue {
+	var issues []ValidationIssue
+	check := func(line int, field, value string) {
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			issues = append(issues, ValidationIssue{
+				Kind:    "malformed_date",
+				Line:    line,
+				Message: fmt.Sprintf("%s date %q is not YYYY-MM-DD and is ignored", field, value),
+			})
+		}
+	}
+
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		for _, meta := range metadataPattern.FindAllStringSubmatch(trimmed, -1) {
+			for _, m := range metadataDatePattern.FindAllStringSubmatch(meta[1], -1) {
+				check(i+1, m[1], m[2])
+			}
+		}
+		for _, m := range inlineDatePattern.FindAllStringSubmatch(trimmed, -1) {
+			check(i+1, m[1], m[2])
+		}
+		if m := leadingDatePattern.FindStringSubmatch(trimmed); m != nil {
+			check(i+1, "reminder", m[1])
+		}
+	}
+	return issues
+}
+
+// droppedLines reports lines of content that the normalized version no
+// longer contains. Item lines are skipped, since they're reformatted and
+// checked by ID, as are headings, which are regenerated.
+func droppedLines(content, normalized string, items []validatedItem) []ValidationIssue {
+	kept := make(map[string]bool)
+	for _, line := range strings.Split(normalized, "\n") {
+		kept[strings.TrimSpace(line)] = true
+	}
+
+	var issues []ValidationIssue
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || kept[trimmed] || strings.HasPrefix(trimmed, "#") || isItemLine(trimmed, items) {
+			continue
+		}
+		issues = append(issues, ValidationIssue{
+			Kind:    "dropped_content",
+			Line:    i + 1,
+			Message: fmt.Sprintf("%q would be dropped when the file is rewritten", truncate(trimmed, 60)),
+		})
+	}
+	return issues
+}
+
+// isItemLine reports whether line is the source line of one of items.
+func isItemLine(line string, items []validatedItem) bool {
+	if !strings.HasPrefix(line, "- ") {
+		return false
+	}
+	for _, item := range items {
+		if item.text != "" && strings.Contains(line, item.text) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func runValidate(t *testing.T, files *storage.MemoryStorage, fix bool) ValidateDataResult {
+	t.Helper()
+	_, out, err := NewValidateTools(files).validateData(context.Background(), nil, ValidateDataInput{Fix: fix})
+	if err != nil || !out.Success {
+		t.Fatalf("validateData() = %+v, %v", out, err)
+	}
+	var result ValidateDataResult
+	if err := json.Unmarshal([]byte(out.Message), &result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func issueKinds(result ValidateDataResult, file string) []string {
+	var kinds []string
+	for _, f := range result.Files {
+		if f.File == file {
+			for _, issue := range f.Issues {
+				kinds = append(kinds, issue.Kind)
+			}
+		}
+	}
+	return kinds
+}
+
+func TestValidateData_Clean(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.TodosFile: "# Active Todos\n\n## High Priority\n- [ ] Ship {id:aaaa1111,added:2026-02-01}\n\n# Completed\n",
+	})
+	result := runValidate(t, files, false)
+	if !result.Valid {
+		t.Errorf("expected a valid result, got %+v", result.Files)
+	}
+	for _, f := range result.Files {
+		if f.File == storage.TodosFile && (f.Normalizes || f.Missing) {
+			t.Errorf("unexpected todos.md report %+v", f)
+		}
+		if f.File == storage.StrategyFile && !f.Missing {
+			t.Error("expected strategy.md to be reported missing")
+		}
+	}
+}
+
+func TestValidateData_Issues(t *testing.T) {
+	todos := "# Active Todos\n\n## High Priority\n" +
+		"- [ ] Ship {id:aaaa1111,added:2026-02-31}\n" +
+		"- [ ] Tidy {id:aaaa1111}\n" +
+		"- [ ] No id here\n\n# Completed\n"
+	strategy := "# My Plan\n\n## Current Phase\nLaunch\n\n## Active Milestones\n- [ ] Beta — Due: soon {id:bbbb2222}\n\n## Notes\n"
+	files := newFileStorage(map[string]string{storage.TodosFile: todos, storage.StrategyFile: strategy})
+
+	result := runValidate(t, files, false)
+	if result.Valid {
+		t.Fatal("expected issues")
+	}
+
+	got := strings.Join(issueKinds(result, storage.TodosFile), ",")
+	if got != "malformed_date,duplicate_id,missing_id" {
+		t.Errorf("todos.md issues = %s", got)
+	}
+	// A custom title is regenerated, not kept
+	got = strings.Join(issueKinds(result, storage.StrategyFile), ",")
+	if got != "malformed_date" {
+		t.Errorf("strategy.md issues = %s", got)
+	}
+
+	// Validation alone never writes
+	if fileContent(t, files, storage.TodosFile) != todos {
+		t.Error("validate_data without fix changed todos.md")
+	}
+}
+
+func TestValidateData_DroppedContent(t *testing.T) {
+	journal := "# Journal\n\n## 2026-02-01\n- 09:00 Started {id:cccc3333}\nA stray paragraph\n"
+	files := newFileStorage(map[string]string{storage.JournalFile: journal})
+
+	result := runValidate(t, files, true)
+	if got := strings.Join(issueKinds(result, storage.JournalFile), ","); got != "dropped_content" {
+		t.Errorf("journal.md issues = %s", got)
+	}
+	if len(result.Fixed) != 0 || fileContent(t, files, storage.JournalFile) != journal {
+		t.Error("a file that would lose content must not be fixed")
+	}
+}
+
+func TestValidateData_Fix(t *testing.T) {
+	files := newFileStorage(map[string]string{
+		storage.TodosFile: "# Active Todos\n\n## Normal\n- [ ] One {id:aaaa1111}\n- [ ] Two {id:aaaa1111}\n- [ ] Three\n\n# Completed\n",
+	})
+
+	result := runValidate(t, files, true)
+	if len(result.Fixed) != 1 || result.Fixed[0] != storage.TodosFile {
+		t.Fatalf("fixed = %v, want [todos.md]", result.Fixed)
+	}
+
+	// The rewritten file has unique, stable IDs
+	tf, _ := storage.ParseTodos(fileContent(t, files, storage.TodosFile))
+	ids := make(map[string]bool)
+	for _, todo := range tf.Active {
+		if !strings.Contains(fileContent(t, files, storage.TodosFile), "id:"+todo.ID) {
+			t.Errorf("%q has no stored id", todo.Text)
+		}
+		ids[todo.ID] = true
+	}
+	if len(tf.Active) != 3 || len(ids) != 3 {
+		t.Errorf("expected 3 todos with distinct ids, got %+v", tf.Active)
+	}
+
+	if result := runValidate(t, files, false); !result.Valid {
+		t.Errorf("expected the fixed file to validate, got %+v", result.Files)
+	}
+}
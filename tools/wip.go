@@ -0,0 +1,40 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// WIPLimits caps the number of active todos at each priority, to keep plans
+// realistic. add_todo and edit_todo check them when a todo enters a
+// priority.
+type WIPLimits struct {
+	// Max is the most active todos a priority may hold. Priorities without
+	// a positive limit are unlimited.
+	Max map[storage.Priority]int
+	// Refuse refuses a change that goes over a limit (unless forced);
+	// otherwise the change is made with a warning.
+	Refuse bool
+}
+
+// check returns a message if one more active todo at priority p would go
+// over its limit. except is the ID of a todo not to count, the one being
+// moved to p.
+func (l WIPLimits) check(active []storage.Todo, p storage.Priority, except string) string {
+	p = priorityOrNormal(p)
+	limit := l.Max[p]
+	if limit <= 0 {
+		return ""
+	}
+	count := 0
+	for _, t := range active {
+		if priorityOrNormal(t.Priority) == p && t.ID != except {
+			count++
+		}
+	}
+	if count < limit {
+		return ""
+	}
+	return fmt.Sprintf("There are already %d active %s-priority todos (limit %d). Consider completing or reprioritizing one first.", count, p, limit)
+}
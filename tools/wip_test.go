@@ -0,0 +1,47 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestWIPLimits(t *testing.T) {
+	const content = "# Active Todos\n\n## High Priority\n- [ ] Ship release {id:aaaa1111}\n- [ ] Fix login {id:bbbb2222}\n\n## Normal\n- [ ] Tidy desk {id:cccc3333}\n"
+	ctx := context.Background()
+
+	// Warning: the todo is added, and the output says it's over the limit
+	files := newFileStorage(map[string]string{storage.TodosFile: content})
+	todos := NewTodoTools(files, nil, WIPLimits{Max: map[storage.Priority]int{storage.PriorityHigh: 2}})
+	_, out, err := todos.addTodo(ctx, nil, AddTodoInput{Text: "Plan offsite", Priority: "high"})
+	if err != nil || !out.Success || !strings.Contains(out.Warning, "already 2 active high-priority todos (limit 2)") {
+		t.Fatalf("addTodo() over a warning limit = %+v, %v", out, err)
+	}
+	_, out, _ = todos.addTodo(ctx, nil, AddTodoInput{Text: "Water plants"})
+	if !out.Success || out.Warning != "" {
+		t.Errorf("addTodo() under no limit = %+v", out)
+	}
+
+	// Refusal, unless forced
+	files = newFileStorage(map[string]string{storage.TodosFile: content})
+	todos = NewTodoTools(files, nil, WIPLimits{Max: map[storage.Priority]int{storage.PriorityHigh: 2}, Refuse: true})
+	if _, out, _ := todos.addTodo(ctx, nil, AddTodoInput{Text: "Plan offsite", Priority: "high"}); out.Success || !strings.Contains(out.Message, "Set force") {
+		t.Errorf("addTodo() over a refusing limit = %+v", out)
+	}
+	if _, out, _ := todos.editTodo(ctx, nil, EditTodoInput{ID: "cccc3333", Priority: "high"}); out.Success {
+		t.Errorf("editTodo() into a full priority = %+v", out)
+	}
+	// Editing a todo already at the priority doesn't count it twice
+	if _, out, _ := todos.editTodo(ctx, nil, EditTodoInput{ID: "aaaa1111", Text: "Ship the release", Priority: "high"}); !out.Success {
+		t.Errorf("editTodo() within its own priority = %+v", out)
+	}
+	if _, out, _ := todos.editTodo(ctx, nil, EditTodoInput{ID: "cccc3333", Priority: "high", Force: true}); !out.Success || out.Warning == "" {
+		t.Errorf("forced editTodo() = %+v", out)
+	}
+	tf, _ := storage.ParseTodos(fileContent(t, files, storage.TodosFile))
+	if len(tf.Active) != 3 || tf.Active[2].Priority != storage.PriorityHigh {
+		t.Errorf("todos after forced edit = %+v", tf.Active)
+	}
+}
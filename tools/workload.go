@@ -0,0 +1,151 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+// WorkloadLimits sets the thresholds for the dashboard's burnout guard. Going
+// over them never blocks anything; the dashboard flags the pile-up and
+// suggests specific items to defer.
+type WorkloadLimits struct {
+	// MaxHighPriority is the number of active high-priority and urgent todos
+	// above which the workload is flagged.
+	MaxHighPriority int
+	// MaxDueSoon is the number of milestones and reminders due within
+	// DueSoonDays (including overdue ones) above which the workload is flagged.
+	MaxDueSoon int
+	// DueSoonDays is the look-ahead window for MaxDueSoon.
+	DueSoonDays int
+}
+
+// DefaultWorkloadLimits is used for any limit left at zero.
+var DefaultWorkloadLimits = WorkloadLimits{
+	MaxHighPriority: 5,
+	MaxDueSoon:      5,
+	DueSoonDays:     3,
+}
+
+// WorkloadCheck is the burnout guard section of the dashboard.
+type WorkloadCheck struct {
+	Overloaded   bool `json:"overloaded"`
+	HighPriority int  `json:"high_priority"`
+	DueSoon      int  `json:"due_soon"`
+	// Warnings explain which limits were exceeded.
+	Warnings []string `json:"warnings,omitempty"`
+	// DeferCandidates are items suggested for deferral, enough to get back
+	// under each exceeded limit.
+	DeferCandidates []DeferCandidate `json:"defer_candidates,omitempty"`
+}
+
+// DeferCandidate is an item the burnout guard suggests deferring.
+type DeferCandidate struct {
+	Type   string `json:"type"` // "todo", "milestone", or "reminder"
+	ID     string `json:"id"`
+	Text   string `json:"text"`
+	Due    string `json:"due,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// workloadInputs holds the active items the burnout guard looks at.
+type workloadInputs struct {
+	todos      []storage.Todo
+	milestones []storage.Milestone
+	reminders  []storage.Reminder
+}
+
+// dueItem is a milestone or reminder with a date, for the due-soon check.
+type dueItem struct {
+	kind string
+	id   string
+	text string
+	due  time.Time
+}
+
+// check flags a pile-up of high-priority todos or near-term due dates.
+//
+// Suggestions are deliberately opinionated: the most recently added
+// high-priority todos are proposed for demotion (older ones have been waiting
+// longer), and for due dates self-set milestones are proposed before
+// reminders, latest due first, since those are the easiest to move.
+func (l WorkloadLimits) check(in workloadInputs, today time.Time) WorkloadCheck {
+	if l.MaxHighPriority <= 0 {
+		l.MaxHighPriority = DefaultWorkloadLimits.MaxHighPriority
+	}
+	if l.MaxDueSoon <= 0 {
+		l.MaxDueSoon = DefaultWorkloadLimits.MaxDueSoon
+	}
+	if l.DueSoonDays <= 0 {
+		l.DueSoonDays = DefaultWorkloadLimits.DueSoonDays
+	}
+
+	result := WorkloadCheck{}
+
+	var high []storage.Todo
+	for _, t := range in.todos {
+		if t.Priority.AtLeast(storage.PriorityHigh) {
+			high = append(high, t)
+		}
+	}
+	result.HighPriority = len(high)
+
+	if excess := len(high) - l.MaxHighPriority; excess > 0 {
+		result.Overloaded = true
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%d high-priority todos (limit %d)", len(high), l.MaxHighPriority))
+
+		// Suggest high before urgent todos, newest first
+		sort.SliceStable(high, func(i, j int) bool {
+			if high[i].Priority != high[j].Priority {
+				return high[j].Priority.AtLeast(high[i].Priority)
+			}
+			return high[i].Added.After(high[j].Added)
+		})
+		for _, t := range high[:excess] {
+			result.DeferCandidates = append(result.DeferCandidates, DeferCandidate{
+				Type:   "todo",
+				ID:     t.ID,
+				Text:   t.Text,
+				Reason: "Most recently added high-priority todo; consider moving it to normal priority",
+			})
+		}
+	}
+
+	horizon := today.AddDate(0, 0, l.DueSoonDays)
+	var milestones, reminders []dueItem
+	for _, m := range in.milestones {
+		if m.Due != nil && !m.Due.After(horizon) {
+			milestones = append(milestones, dueItem{kind: "milestone", id: m.ID, text: m.Text, due: *m.Due})
+		}
+	}
+	for _, r := range in.reminders {
+		if !r.Date.After(horizon) {
+			reminders = append(reminders, dueItem{kind: "reminder", id: r.ID, text: r.Text, due: r.Date})
+		}
+	}
+	result.DueSoon = len(milestones) + len(reminders)
+
+	if excess := result.DueSoon - l.MaxDueSoon; excess > 0 {
+		result.Overloaded = true
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%d items due within %d days (limit %d)", result.DueSoon, l.DueSoonDays, l.MaxDueSoon))
+
+		latestFirst := func(items []dueItem) {
+			sort.SliceStable(items, func(i, j int) bool { return items[i].due.After(items[j].due) })
+		}
+		latestFirst(milestones)
+		latestFirst(reminders)
+		for _, item := range append(milestones, reminders...)[:excess] {
+			result.DeferCandidates = append(result.DeferCandidates, DeferCandidate{
+				Type:   item.kind,
+				ID:     item.id,
+				Text:   item.text,
+				Due:    item.due.Format("2006-01-02"),
+				Reason: fmt.Sprintf("Due %s; consider pushing the %s back a week", item.due.Format("Jan 2"), item.kind),
+			})
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dang-w/momentum-mcp-server/storage"
+)
+
+func TestWorkloadLimits_Check(t *testing.T) {
+	today := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return today.AddDate(0, 0, n) }
+	due := func(n int) *time.Time { d := day(n); return &d }
+
+	l := WorkloadLimits{MaxHighPriority: 2, MaxDueSoon: 2, DueSoonDays: 3}
+	in := workloadInputs{
+		todos: []storage.Todo{
+			{ID: "t1", Priority: storage.PriorityHigh, Added: day(-10)},
+			{ID: "t2", Priority: storage.PriorityHigh, Added: day(-1)},
+			{ID: "t3", Priority: storage.PriorityHigh, Added: day(-5)},
+			{ID: "t4", Priority: storage.PriorityNormal, Added: day(0)},
+		},
+		milestones: []storage.Milestone{
+			{ID: "m1", Due: due(1)},
+			{ID: "m2", Due: due(3)},
+			{ID: "m3", Due: due(10)}, // outside the window
+			{ID: "m4"},               // no due date
+		},
+		reminders: []storage.Reminder{
+			{ID: "r1", Date: day(-1)}, // overdue counts
+		},
+	}
+
+	got := l.check(in, today)
+	if !got.Overloaded || got.HighPriority != 3 || got.DueSoon != 3 {
+		t.Fatalf("unexpected check: %+v", got)
+	}
+	if len(got.Warnings) != 2 {
+		t.Errorf("expected 2 warnings, got %v", got.Warnings)
+	}
+
+	var ids []string
+	for _, c := range got.DeferCandidates {
+		ids = append(ids, c.ID)
+	}
+	// Newest high-priority todo, then the latest-due milestone
+	if len(ids) != 2 || ids[0] != "t2" || ids[1] != "m2" {
+		t.Errorf("expected defer candidates [t2 m2], got %v", ids)
+	}
+
+	if got := DefaultWorkloadLimits.check(in, today); got.Overloaded || len(got.DeferCandidates) != 0 {
+		t.Errorf("expected no overload under default limits, got %+v", got)
+	}
+}